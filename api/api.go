@@ -24,6 +24,7 @@ import (
 	"github.com/frain-dev/convoy/api/policies"
 	"github.com/frain-dev/convoy/api/types"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/organisation_members"
 	"github.com/frain-dev/convoy/internal/organisations"
 	"github.com/frain-dev/convoy/internal/pkg/billing"
@@ -362,6 +363,12 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 
 			a.mountEventIntakeRoutes(r, handler)
 
+			// convoy listen sessions, authenticated by CLI keys only.
+			r.Route("/listen", func(listenRouter chi.Router) {
+				listenRouter.Get("/", handler.StreamCLIDeliveries)
+				listenRouter.Post("/deliveries/{eventDeliveryID}/ack", handler.AckCLIDelivery)
+			})
+
 			r.Route("/projects", func(projectRouter chi.Router) {
 				// Failure policy: fail closed. Rejecting a project API call
 				// costs the caller a retry, so a limiter backend outage must
@@ -392,6 +399,27 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 							e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/expire_secret", handler.ExpireSecret)
 							e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/pause", handler.PauseEndpoint)
 							e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/activate", handler.ActivateEndpoint)
+							e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test-event", handler.SendTestEvent)
+							e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/cli-keys", handler.CreateEndpointCLIKey)
+
+							e.Route("/circuit-breaker", func(cbRouter chi.Router) {
+								cbRouter.Use(middleware.RequireValidCircuitBreakingLicense(handler.A.Licenser, handler.A.Logger))
+
+								cbRouter.Get("/", handler.GetEndpointCircuitBreaker)
+								cbRouter.Get("/transitions", handler.GetEndpointCircuitBreakerTransitions)
+								cbRouter.With(handler.RequireEnabledProject()).Post("/force-open", handler.ForceOpenEndpointCircuitBreaker)
+								cbRouter.With(handler.RequireEnabledProject()).Post("/force-close", handler.ForceCloseEndpointCircuitBreaker)
+								cbRouter.With(handler.RequireEnabledProject()).Post("/reset", handler.ResetEndpointCircuitBreaker)
+								cbRouter.With(handler.RequireEnabledProject()).Put("/config", handler.UpdateEndpointCircuitBreakerConfig)
+								cbRouter.With(handler.RequireEnabledProject()).Delete("/config", handler.DeleteEndpointCircuitBreakerConfig)
+							})
+
+							e.Route("/health-check", func(hcRouter chi.Router) {
+								hcRouter.Get("/", handler.GetEndpointHealthCheck)
+								hcRouter.Get("/results", handler.GetEndpointHealthCheckResults)
+								hcRouter.With(handler.RequireEnabledProject()).Put("/", handler.UpsertEndpointHealthCheck)
+								hcRouter.With(handler.RequireEnabledProject()).Delete("/", handler.DeleteEndpointHealthCheck)
+							})
 						})
 					})
 
@@ -414,15 +442,57 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 							eventSubRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).
 								Put("/replay", handler.ReplayEndpointEvent)
 							eventSubRouter.Get("/", handler.GetEndpointEvent)
+							eventSubRouter.Get("/filter-rejections", handler.GetEventFilterRejections)
 						})
 					})
 
 					projectSubRouter.Route("/event-types", func(eventTypesRouter chi.Router) {
 						eventTypesRouter.Get("/", handler.GetEventTypes)
+						eventTypesRouter.Get("/catalog/{spec}", handler.GetEventTypeCatalog)
+						eventTypesRouter.Get("/{eventTypeId}/sample", handler.GetEventTypeSample)
 						eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateEventType)
 						eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/import", handler.ImportOpenApiSpec)
 						eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{eventTypeId}", handler.UpdateEventType)
 						eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
+						eventTypesRouter.Get("/{eventTypeId}/versions", handler.GetEventTypeVersions)
+						eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{eventTypeId}/versions", handler.CreateEventTypeVersion)
+						eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{eventTypeId}/versions/{version}", handler.UpdateEventTypeVersion)
+					})
+
+					projectSubRouter.Route("/spec", func(specRouter chi.Router) {
+						specRouter.Get("/", handler.ExportProjectSpec)
+						specRouter.Post("/plan", handler.PlanProjectSpec)
+						specRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/apply", handler.ApplyProjectSpec)
+					})
+
+					projectSubRouter.Route("/replay-jobs", func(replayJobRouter chi.Router) {
+						replayJobRouter.Get("/", handler.GetReplayJobs)
+						replayJobRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateReplayJob)
+						replayJobRouter.Get("/{replayJobID}", handler.GetReplayJob)
+						replayJobRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{replayJobID}/cancel", handler.CancelReplayJob)
+					})
+
+					projectSubRouter.Route("/alert-rules", func(alertRuleRouter chi.Router) {
+						alertRuleRouter.Get("/", handler.GetAlertRules)
+						alertRuleRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateAlertRule)
+						alertRuleRouter.Get("/{alertRuleID}", handler.GetAlertRule)
+						alertRuleRouter.With(handler.RequireEnabledProject()).Put("/{alertRuleID}", handler.UpdateAlertRule)
+						alertRuleRouter.With(handler.RequireEnabledProject()).Delete("/{alertRuleID}", handler.DeleteAlertRule)
+					})
+
+					projectSubRouter.Route("/saved-searches", func(savedSearchRouter chi.Router) {
+						savedSearchRouter.Get("/", handler.GetSavedSearches)
+						savedSearchRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateSavedSearch)
+						savedSearchRouter.Get("/{savedSearchID}", handler.GetSavedSearch)
+						savedSearchRouter.With(handler.RequireEnabledProject()).Put("/{savedSearchID}", handler.UpdateSavedSearch)
+						savedSearchRouter.With(handler.RequireEnabledProject()).Delete("/{savedSearchID}", handler.DeleteSavedSearch)
+					})
+
+					projectSubRouter.Route("/exports", func(exportRouter chi.Router) {
+						exportRouter.Get("/", handler.GetExportJobs)
+						exportRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateExportJob)
+						exportRouter.Post("/download", handler.DownloadExport)
+						exportRouter.Get("/{exportID}", handler.GetExportJob)
 					})
 
 					projectSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
@@ -444,11 +514,16 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 					projectSubRouter.Route("/subscriptions", func(subscriptionRouter chi.Router) {
 						subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateSubscription)
 						subscriptionRouter.Post("/test_filter", handler.TestSubscriptionFilter)
+						subscriptionRouter.Post("/test_filter/explain", handler.ExplainSubscriptionFilter)
+						subscriptionRouter.With(middleware.Pagination).Post("/filters/explain", handler.ExplainSubscriptionFilters)
 						subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test_function", handler.TestSubscriptionFunction)
 						subscriptionRouter.With(middleware.Pagination).Get("/", handler.GetSubscriptions)
 						subscriptionRouter.With(handler.RequireEnabledProject()).Delete("/{subscriptionID}", handler.DeleteSubscription)
 						subscriptionRouter.Get("/{subscriptionID}", handler.GetSubscription)
 						subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{subscriptionID}", handler.UpdateSubscription)
+						subscriptionRouter.Get("/{subscriptionID}/event-type-versions", handler.GetSubscriptionEventTypeVersions)
+						subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{subscriptionID}/event-type-versions", handler.PinSubscriptionEventTypeVersion)
+						subscriptionRouter.With(handler.RequireEnabledProject()).Delete("/{subscriptionID}/event-type-versions/{eventType}", handler.UnpinSubscriptionEventTypeVersion)
 						subscriptionRouter.Put("/{subscriptionID}/toggle_status", handler.ToggleSubscriptionStatus)
 
 						// Filter routes
@@ -464,6 +539,7 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 							filterRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{filterID}", handler.UpdateFilter)
 							filterRouter.With(handler.RequireEnabledProject()).Delete("/{filterID}", handler.DeleteFilter)
 							filterRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test/{eventType}", handler.TestFilter)
+							filterRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/explain/{eventType}", handler.ExplainFilter)
 						})
 					})
 
@@ -587,6 +663,7 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 				queueRouter.Get("/scheduler", handler.GetQueueSchedulerEntries)
 				queueRouter.Get("/{queueName}/history", handler.GetQueueHistory)
 				queueRouter.Get("/{queueName}/tasks", handler.GetQueueTasks)
+				queueRouter.Get("/{queueName}/tenants", handler.GetQueueTenantBacklog)
 				queueRouter.Post("/{queueName}/tasks/bulk", handler.BulkQueueTaskAction)
 				queueRouter.Post("/{queueName}/tasks/{taskID}/retry", handler.RetryQueueTask)
 				queueRouter.Post("/{queueName}/tasks/{taskID}/run", handler.RunQueueTask)
@@ -610,6 +687,16 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 				orgSubRouter.With(handler.RequireEnabledOrganisation()).Put("/feature-flags", handler.UpdateOrganisationFeatureFlags)
 				orgSubRouter.Get("/early-adopter-features", handler.GetEarlyAdopterFeatures)
 
+				orgSubRouter.Route("/email-templates", func(emailTemplateRouter chi.Router) {
+					emailTemplateRouter.With(handler.RequireOrganisationMembership()).Get("/", handler.GetEmailTemplates)
+					emailTemplateRouter.With(handler.RequireEnabledOrganisation()).Put("/{templateName}", handler.UpdateEmailTemplate)
+					emailTemplateRouter.Delete("/{templateName}", handler.DeleteEmailTemplate)
+					emailTemplateRouter.Post("/{templateName}/preview", handler.PreviewEmailTemplate)
+					emailTemplateRouter.With(handler.RequireEnabledOrganisation()).Post("/{templateName}/test", handler.SendTestEmailTemplate)
+				})
+				orgSubRouter.With(handler.RequireOrganisationMembership()).Get("/email-branding", handler.GetEmailBranding)
+				orgSubRouter.With(handler.RequireEnabledOrganisation()).Put("/email-branding", handler.UpdateEmailBranding)
+
 				orgSubRouter.Route("/invites", func(orgInvitesRouter chi.Router) {
 					orgInvitesRouter.With(handler.RequireEnabledOrganisation()).Post("/", handler.InviteUserToOrganisation)
 					orgInvitesRouter.With(handler.RequireEnabledOrganisation()).Post("/{inviteID}/resend", handler.ResendOrganizationInvite)
@@ -656,6 +743,27 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 								e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/expire_secret", handler.ExpireSecret)
 								e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/pause", handler.PauseEndpoint)
 								e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/activate", handler.ActivateEndpoint)
+								e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test-event", handler.SendTestEvent)
+								e.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/cli-keys", handler.CreateEndpointCLIKey)
+
+								e.Route("/circuit-breaker", func(cbRouter chi.Router) {
+									cbRouter.Use(middleware.RequireValidCircuitBreakingLicense(handler.A.Licenser, handler.A.Logger))
+
+									cbRouter.Get("/", handler.GetEndpointCircuitBreaker)
+									cbRouter.Get("/transitions", handler.GetEndpointCircuitBreakerTransitions)
+									cbRouter.With(handler.RequireEnabledProject()).Post("/force-open", handler.ForceOpenEndpointCircuitBreaker)
+									cbRouter.With(handler.RequireEnabledProject()).Post("/force-close", handler.ForceCloseEndpointCircuitBreaker)
+									cbRouter.With(handler.RequireEnabledProject()).Post("/reset", handler.ResetEndpointCircuitBreaker)
+									cbRouter.With(handler.RequireEnabledProject()).Put("/config", handler.UpdateEndpointCircuitBreakerConfig)
+									cbRouter.With(handler.RequireEnabledProject()).Delete("/config", handler.DeleteEndpointCircuitBreakerConfig)
+								})
+
+								e.Route("/health-check", func(hcRouter chi.Router) {
+									hcRouter.Get("/", handler.GetEndpointHealthCheck)
+									hcRouter.Get("/results", handler.GetEndpointHealthCheckResults)
+									hcRouter.With(handler.RequireEnabledProject()).Put("/", handler.UpsertEndpointHealthCheck)
+									hcRouter.With(handler.RequireEnabledProject()).Delete("/", handler.DeleteEndpointHealthCheck)
+								})
 							})
 						})
 
@@ -674,15 +782,57 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 							eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
 								eventSubRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/replay", handler.ReplayEndpointEvent)
 								eventSubRouter.Get("/", handler.GetEndpointEvent)
+								eventSubRouter.Get("/filter-rejections", handler.GetEventFilterRejections)
 							})
 						})
 
 						projectSubRouter.Route("/event-types", func(eventTypesRouter chi.Router) {
 							eventTypesRouter.Get("/", handler.GetEventTypes)
+							eventTypesRouter.Get("/catalog/{spec}", handler.GetEventTypeCatalog)
+							eventTypesRouter.Get("/{eventTypeId}/sample", handler.GetEventTypeSample)
 							eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateEventType)
 							eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/import", handler.ImportOpenApiSpec)
 							eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{eventTypeId}", handler.UpdateEventType)
 							eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
+							eventTypesRouter.Get("/{eventTypeId}/versions", handler.GetEventTypeVersions)
+							eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{eventTypeId}/versions", handler.CreateEventTypeVersion)
+							eventTypesRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{eventTypeId}/versions/{version}", handler.UpdateEventTypeVersion)
+						})
+
+						projectSubRouter.Route("/spec", func(specRouter chi.Router) {
+							specRouter.Get("/", handler.ExportProjectSpec)
+							specRouter.Post("/plan", handler.PlanProjectSpec)
+							specRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/apply", handler.ApplyProjectSpec)
+						})
+
+						projectSubRouter.Route("/replay-jobs", func(replayJobRouter chi.Router) {
+							replayJobRouter.Get("/", handler.GetReplayJobs)
+							replayJobRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateReplayJob)
+							replayJobRouter.Get("/{replayJobID}", handler.GetReplayJob)
+							replayJobRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{replayJobID}/cancel", handler.CancelReplayJob)
+						})
+
+						projectSubRouter.Route("/alert-rules", func(alertRuleRouter chi.Router) {
+							alertRuleRouter.Get("/", handler.GetAlertRules)
+							alertRuleRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateAlertRule)
+							alertRuleRouter.Get("/{alertRuleID}", handler.GetAlertRule)
+							alertRuleRouter.With(handler.RequireEnabledProject()).Put("/{alertRuleID}", handler.UpdateAlertRule)
+							alertRuleRouter.With(handler.RequireEnabledProject()).Delete("/{alertRuleID}", handler.DeleteAlertRule)
+						})
+
+						projectSubRouter.Route("/saved-searches", func(savedSearchRouter chi.Router) {
+							savedSearchRouter.Get("/", handler.GetSavedSearches)
+							savedSearchRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateSavedSearch)
+							savedSearchRouter.Get("/{savedSearchID}", handler.GetSavedSearch)
+							savedSearchRouter.With(handler.RequireEnabledProject()).Put("/{savedSearchID}", handler.UpdateSavedSearch)
+							savedSearchRouter.With(handler.RequireEnabledProject()).Delete("/{savedSearchID}", handler.DeleteSavedSearch)
+						})
+
+						projectSubRouter.Route("/exports", func(exportRouter chi.Router) {
+							exportRouter.Get("/", handler.GetExportJobs)
+							exportRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateExportJob)
+							exportRouter.Post("/download", handler.DownloadExport)
+							exportRouter.Get("/{exportID}", handler.GetExportJob)
 						})
 
 						projectSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
@@ -706,11 +856,16 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 						projectSubRouter.Route("/subscriptions", func(subscriptionRouter chi.Router) {
 							subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateSubscription)
 							subscriptionRouter.Post("/test_filter", handler.TestSubscriptionFilter)
+							subscriptionRouter.Post("/test_filter/explain", handler.ExplainSubscriptionFilter)
+							subscriptionRouter.With(middleware.Pagination).Post("/filters/explain", handler.ExplainSubscriptionFilters)
 							subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test_function", handler.TestSubscriptionFunction)
 							subscriptionRouter.With(middleware.Pagination).Get("/", handler.GetSubscriptions)
 							subscriptionRouter.With(handler.RequireEnabledProject()).Delete("/{subscriptionID}", handler.DeleteSubscription)
 							subscriptionRouter.Get("/{subscriptionID}", handler.GetSubscription)
 							subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{subscriptionID}", handler.UpdateSubscription)
+							subscriptionRouter.Get("/{subscriptionID}/event-type-versions", handler.GetSubscriptionEventTypeVersions)
+							subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{subscriptionID}/event-type-versions", handler.PinSubscriptionEventTypeVersion)
+							subscriptionRouter.With(handler.RequireEnabledProject()).Delete("/{subscriptionID}/event-type-versions/{eventType}", handler.UnpinSubscriptionEventTypeVersion)
 
 							// Filter routes
 							subscriptionRouter.Route("/{subscriptionID}/filters", func(filterRouter chi.Router) {
@@ -725,6 +880,7 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 								filterRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/{filterID}", handler.UpdateFilter)
 								filterRouter.With(handler.RequireEnabledProject()).Delete("/{filterID}", handler.DeleteFilter)
 								filterRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test/{eventType}", handler.TestFilter)
+								filterRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/explain/{eventType}", handler.ExplainFilter)
 							})
 						})
 
@@ -867,39 +1023,43 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 			endpointRouter.With(handler.CanManageEndpoint()).Put("/{endpointID}", handler.UpdateEndpoint)
 			endpointRouter.With(handler.CanManageEndpoint()).Delete("/{endpointID}", handler.DeleteEndpoint)
 			endpointRouter.With(handler.CanManageEndpoint()).Put("/{endpointID}/pause", handler.PauseEndpoint)
-			endpointRouter.With(handler.CanManageEndpoint()).Put("/{endpointID}/expire_secret", handler.ExpireSecret)
+			endpointRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRotateSecrets)).Put("/{endpointID}/expire_secret", handler.ExpireSecret)
+			endpointRouter.With(handler.CanManageEndpoint(), handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/{endpointID}/test-event", handler.SendTestEvent)
 		})
 
 		// TODO(subomi): left this here temporarily till the data plane is stable.
 		portalLinkRouter.Route("/events", func(eventRouter chi.Router) {
 			eventRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/", handler.CreateEndpointEvent)
 			eventRouter.With(middleware.Pagination).Get("/", handler.GetEventsPaged)
-			eventRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/batchreplay", handler.BatchReplayEvents)
-			eventRouter.Get("/countbatchreplayevents", handler.CountAffectedEvents)
+			eventRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkReplayEvents), handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/batchreplay", handler.BatchReplayEvents)
+			eventRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkReplayEvents)).Get("/countbatchreplayevents", handler.CountAffectedEvents)
 
 			eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
 				eventSubRouter.Get("/", handler.GetEndpointEvent)
-				eventSubRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/replay", handler.ReplayEndpointEvent)
+				eventSubRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkReplayEvents), handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/replay", handler.ReplayEndpointEvent)
 			})
 		})
 
 		portalLinkRouter.Route("/event-types", func(eventTypesRouter chi.Router) {
 			eventTypesRouter.Get("/", handler.GetEventTypes)
-			eventTypesRouter.With(handler.RequireEnabledProject()).Post("/", handler.CreateEventType)
-			eventTypesRouter.With(handler.RequireEnabledProject()).Put("/{eventTypeId}", handler.UpdateEventType)
-			eventTypesRouter.With(handler.RequireEnabledProject()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
+			eventTypesRouter.Get("/subscribable", handler.GetPortalEventCatalog)
+			eventTypesRouter.Get("/catalog/{spec}", handler.GetEventTypeCatalog)
+			eventTypesRouter.Get("/{eventTypeId}/sample", handler.GetEventTypeSample)
+			eventTypesRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkCreateEventTypes), handler.RequireEnabledProject()).Post("/", handler.CreateEventType)
+			eventTypesRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkCreateEventTypes), handler.RequireEnabledProject()).Put("/{eventTypeId}", handler.UpdateEventType)
+			eventTypesRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkCreateEventTypes), handler.RequireEnabledProject()).Post("/{eventTypeId}/deprecate", handler.DeprecateEventType)
 		})
 
 		portalLinkRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
 			eventDeliveryRouter.With(middleware.Pagination).Get("/", handler.GetEventDeliveriesPaged)
-			eventDeliveryRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/forceresend", handler.ForceResendEventDeliveries)
-			eventDeliveryRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/batchretry", handler.BatchRetryEventDelivery)
-			eventDeliveryRouter.Get("/countbatchretryevents", handler.CountAffectedEventDeliveries)
+			eventDeliveryRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries), handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/forceresend", handler.ForceResendEventDeliveries)
+			eventDeliveryRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries), handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/batchretry", handler.BatchRetryEventDelivery)
+			eventDeliveryRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries)).Get("/countbatchretryevents", handler.CountAffectedEventDeliveries)
 			eventDeliveryRouter.Get("/statustotals", handler.EventDeliveryStatusTotals)
 
 			eventDeliveryRouter.Route("/{eventDeliveryID}", func(eventDeliverySubRouter chi.Router) {
 				eventDeliverySubRouter.Get("/", handler.GetEventDelivery)
-				eventDeliverySubRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/resend", handler.ResendEventDelivery)
+				eventDeliverySubRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries), handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Put("/resend", handler.ResendEventDelivery)
 
 				eventDeliverySubRouter.Route("/deliveryattempts", func(deliveryRouter chi.Router) {
 					deliveryRouter.Get("/", handler.GetDeliveryAttempts)
@@ -915,6 +1075,7 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 		portalLinkRouter.Route("/subscriptions", func(subscriptionRouter chi.Router) {
 			subscriptionRouter.Post("/", handler.CreateSubscription)
 			subscriptionRouter.Post("/test_filter", handler.TestSubscriptionFilter)
+			subscriptionRouter.Post("/test_filter/explain", handler.ExplainSubscriptionFilter)
 			subscriptionRouter.With(handler.RequireEnabledProject(), handler.RequireEnabledOrganisation()).Post("/test_function", handler.TestSubscriptionFunction)
 			subscriptionRouter.With(middleware.Pagination).Get("/", handler.GetSubscriptions)
 			subscriptionRouter.Delete("/{subscriptionID}", handler.DeleteSubscription)
@@ -924,14 +1085,15 @@ func (a *ApplicationHandler) mountControlPlaneRoutes(router chi.Router, handler
 			// Filter routes
 			subscriptionRouter.Route("/{subscriptionID}/filters", func(filterRouter chi.Router) {
 				filterRouter.Use(handler.RequirePortalLinkOwnsSubscription())
-				filterRouter.Post("/", handler.CreateFilter)
-				filterRouter.With(handler.RequireEnabledProject()).Post("/bulk", handler.BulkCreateFilters)
-				filterRouter.With(handler.RequireEnabledProject()).Post("/bulk_update", handler.BulkUpdateFilters)
+				filterRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkManageFilters)).Post("/", handler.CreateFilter)
+				filterRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkManageFilters), handler.RequireEnabledProject()).Post("/bulk", handler.BulkCreateFilters)
+				filterRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkManageFilters), handler.RequireEnabledProject()).Post("/bulk_update", handler.BulkUpdateFilters)
 				filterRouter.Get("/", handler.GetFilters)
 				filterRouter.Get("/{filterID}", handler.GetFilter)
-				filterRouter.With(handler.RequireEnabledProject()).Put("/{filterID}", handler.UpdateFilter)
-				filterRouter.With(handler.RequireEnabledProject()).Delete("/{filterID}", handler.DeleteFilter)
+				filterRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkManageFilters), handler.RequireEnabledProject()).Put("/{filterID}", handler.UpdateFilter)
+				filterRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkManageFilters), handler.RequireEnabledProject()).Delete("/{filterID}", handler.DeleteFilter)
 				filterRouter.With(handler.RequireEnabledProject()).Post("/test/{eventType}", handler.TestFilter)
+				filterRouter.With(handler.RequireEnabledProject()).Post("/explain/{eventType}", handler.ExplainFilter)
 			})
 		})
 	})
@@ -1136,25 +1298,25 @@ func (a *ApplicationHandler) mountDataPlaneRoutes(router chi.Router, handler *ha
 		portalLinkRouter.Route("/events", func(eventRouter chi.Router) {
 			eventRouter.Post("/", handler.CreateEndpointEvent)
 			eventRouter.With(middleware.Pagination).Get("/", handler.GetEventsPaged)
-			eventRouter.Post("/batchreplay", handler.BatchReplayEvents)
-			eventRouter.Get("/countbatchreplayevents", handler.CountAffectedEvents)
+			eventRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkReplayEvents)).Post("/batchreplay", handler.BatchReplayEvents)
+			eventRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkReplayEvents)).Get("/countbatchreplayevents", handler.CountAffectedEvents)
 
 			eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
 				eventSubRouter.Get("/", handler.GetEndpointEvent)
-				eventSubRouter.Put("/replay", handler.ReplayEndpointEvent)
+				eventSubRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkReplayEvents)).Put("/replay", handler.ReplayEndpointEvent)
 			})
 		})
 
 		portalLinkRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
 			eventDeliveryRouter.With(middleware.Pagination).Get("/", handler.GetEventDeliveriesPaged)
-			eventDeliveryRouter.Post("/forceresend", handler.ForceResendEventDeliveries)
-			eventDeliveryRouter.Post("/batchretry", handler.BatchRetryEventDelivery)
-			eventDeliveryRouter.Get("/countbatchretryevents", handler.CountAffectedEventDeliveries)
+			eventDeliveryRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries)).Post("/forceresend", handler.ForceResendEventDeliveries)
+			eventDeliveryRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries)).Post("/batchretry", handler.BatchRetryEventDelivery)
+			eventDeliveryRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries)).Get("/countbatchretryevents", handler.CountAffectedEventDeliveries)
 			eventDeliveryRouter.Get("/statustotals", handler.EventDeliveryStatusTotals)
 
 			eventDeliveryRouter.Route("/{eventDeliveryID}", func(eventDeliverySubRouter chi.Router) {
 				eventDeliverySubRouter.Get("/", handler.GetEventDelivery)
-				eventDeliverySubRouter.Put("/resend", handler.ResendEventDelivery)
+				eventDeliverySubRouter.With(handler.RequirePortalLinkPermission(datastore.PortalLinkRetryDeliveries)).Put("/resend", handler.ResendEventDelivery)
 
 				eventDeliverySubRouter.Route("/deliveryattempts", func(deliveryRouter chi.Router) {
 					deliveryRouter.Get("/", handler.GetDeliveryAttempts)
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/alert_rules"
+	"github.com/frain-dev/convoy/internal/sources"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// GetAlertRules
+//
+//	@Summary		List alert rules
+//	@Description	This endpoint fetches a project's alert rules with their current state
+//	@Id				GetAlertRules
+//	@Tags			Alert Rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.AlertRuleResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/alert-rules [get]
+func (h *Handler) GetAlertRules(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	rules, err := alert_rules.New(h.A.Logger, h.A.DB).LoadAlertRules(r.Context(), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load alert rules", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(rules, func(rule datastore.AlertRule) models.AlertRuleResponse {
+		return models.AlertRuleResponse{AlertRule: &rule}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Alert rules fetched successfully", resp, http.StatusOK))
+}
+
+// CreateAlertRule
+//
+//	@Summary		Create an alert rule
+//	@Description	This endpoint creates an alert rule. Rules are checked every minute and notify their channels when they start firing and when they resolve
+//	@Id				CreateAlertRule
+//	@Tags			Alert Rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string					true	"Project ID"
+//	@Param			alertRule	body		models.CreateAlertRule	true	"Alert rule details"
+//	@Success		201			{object}	util.ServerResponse{data=models.AlertRuleResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/alert-rules [post]
+func (h *Handler) CreateAlertRule(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAlertRule
+	err := util.ReadJSON(r, &req)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	cs := services.CreateAlertRuleService{
+		Repo:         alert_rules.New(h.A.Logger, h.A.DB),
+		EndpointRepo: h.endpointWriteRepo(),
+		SourceRepo:   sources.New(h.A.Logger, h.A.DB),
+		ProjectID:    project.UID,
+		Rule:         req.Transform(),
+		Logger:       h.A.Logger,
+	}
+
+	rule, err := cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.AlertRuleResponse{AlertRule: rule}
+	_ = render.Render(w, r, util.NewServerResponse("Alert rule created successfully", resp, http.StatusCreated))
+}
+
+// GetAlertRule
+//
+//	@Summary		Retrieve an alert rule
+//	@Description	This endpoint fetches an alert rule with its current state
+//	@Id				GetAlertRule
+//	@Tags			Alert Rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			alertRuleID	path		string	true	"alert rule id"
+//	@Success		200			{object}	util.ServerResponse{data=models.AlertRuleResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/alert-rules/{alertRuleID} [get]
+func (h *Handler) GetAlertRule(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	rule, err := alert_rules.New(h.A.Logger, h.A.DB).FindAlertRuleByID(r.Context(), project.UID, chi.URLParam(r, "alertRuleID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrAlertRuleNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to find alert rule", http.StatusInternalServerError))
+		return
+	}
+
+	resp := &models.AlertRuleResponse{AlertRule: rule}
+	_ = render.Render(w, r, util.NewServerResponse("Alert rule fetched successfully", resp, http.StatusOK))
+}
+
+// UpdateAlertRule
+//
+//	@Summary		Update an alert rule
+//	@Description	This endpoint replaces an alert rule's configuration. Its current state is kept, so a firing rule still sends its resolved notification
+//	@Id				UpdateAlertRule
+//	@Tags			Alert Rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string					true	"Project ID"
+//	@Param			alertRuleID	path		string					true	"alert rule id"
+//	@Param			alertRule	body		models.UpdateAlertRule	true	"Alert rule details"
+//	@Success		202			{object}	util.ServerResponse{data=models.AlertRuleResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/alert-rules/{alertRuleID} [put]
+func (h *Handler) UpdateAlertRule(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateAlertRule
+	err := util.ReadJSON(r, &req)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	us := services.UpdateAlertRuleService{
+		Repo:         alert_rules.New(h.A.Logger, h.A.DB),
+		EndpointRepo: h.endpointWriteRepo(),
+		SourceRepo:   sources.New(h.A.Logger, h.A.DB),
+		ProjectID:    project.UID,
+		AlertRuleID:  chi.URLParam(r, "alertRuleID"),
+		Update:       req.Transform(),
+		Logger:       h.A.Logger,
+	}
+
+	rule, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.AlertRuleResponse{AlertRule: rule}
+	_ = render.Render(w, r, util.NewServerResponse("Alert rule updated successfully", resp, http.StatusAccepted))
+}
+
+// DeleteAlertRule
+//
+//	@Summary		Delete an alert rule
+//	@Description	This endpoint deletes an alert rule. No resolved notification is sent for a rule deleted while firing
+//	@Id				DeleteAlertRule
+//	@Tags			Alert Rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			alertRuleID	path		string	true	"alert rule id"
+//	@Success		200			{object}	util.ServerResponse{data=Stub}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/alert-rules/{alertRuleID} [delete]
+func (h *Handler) DeleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	err = alert_rules.New(h.A.Logger, h.A.DB).DeleteAlertRule(r.Context(), project.UID, chi.URLParam(r, "alertRuleID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrAlertRuleNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to delete alert rule", http.StatusInternalServerError))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Alert rule deleted successfully", nil, http.StatusOK))
+}
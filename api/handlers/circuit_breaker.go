@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/circuit_breakers"
+	endpointsvc "github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/meta_events"
+	"github.com/frain-dev/convoy/internal/pkg/middleware"
+	cb "github.com/frain-dev/convoy/pkg/circuit_breaker"
+	"github.com/frain-dev/convoy/pkg/clock"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// GetEndpointCircuitBreaker
+//
+//	@Summary		Retrieve an endpoint's circuit breaker
+//	@Description	This endpoint retrieves the circuit breaker state of an endpoint and any operator override on it
+//	@Id				GetEndpointCircuitBreaker
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			endpointID	path		string	true	"Endpoint ID"
+//	@Success		200			{object}	util.ServerResponse{data=models.CircuitBreakerResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker [get]
+func (h *Handler) GetEndpointCircuitBreaker(w http.ResponseWriter, r *http.Request) {
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, false)
+	if !ok {
+		return
+	}
+
+	manager, err := h.circuitBreakerManager()
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	breaker, err := manager.GetCircuitBreaker(r.Context(), endpointID)
+	if err != nil {
+		h.A.Logger.Error("failed to load circuit breaker", "error", err)
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load circuit breaker", http.StatusInternalServerError))
+		return
+	}
+
+	override, err := circuit_breakers.New(h.A.Logger, h.A.DB).FindCircuitBreakerOverride(r.Context(), project.UID, endpointID)
+	if err != nil && !errors.Is(err, datastore.ErrCircuitBreakerOverrideNotFound) {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load circuit breaker override", http.StatusInternalServerError))
+		return
+	}
+
+	resp := &models.CircuitBreakerResponse{Breaker: breaker, Override: override}
+	_ = render.Render(w, r, util.NewServerResponse("Circuit breaker fetched successfully", resp, http.StatusOK))
+}
+
+// ForceOpenEndpointCircuitBreaker
+//
+//	@Summary		Force open an endpoint's circuit breaker
+//	@Description	This endpoint holds an endpoint's circuit breaker open, isolating it until the breaker is reset
+//	@Id				ForceOpenEndpointCircuitBreaker
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			endpointID	path		string							true	"Endpoint ID"
+//	@Param			request		body		models.UpdateCircuitBreakerState	false	"Reason"
+//	@Success		202			{object}	util.ServerResponse{data=models.CircuitBreakerResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker/force-open [post]
+func (h *Handler) ForceOpenEndpointCircuitBreaker(w http.ResponseWriter, r *http.Request) {
+	h.updateCircuitBreakerState(w, r, services.CircuitBreakerForceOpen)
+}
+
+// ForceCloseEndpointCircuitBreaker
+//
+//	@Summary		Force close an endpoint's circuit breaker
+//	@Description	This endpoint holds an endpoint's circuit breaker closed so deliveries continue regardless of failures, until the breaker is reset
+//	@Id				ForceCloseEndpointCircuitBreaker
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			endpointID	path		string							true	"Endpoint ID"
+//	@Param			request		body		models.UpdateCircuitBreakerState	false	"Reason"
+//	@Success		202			{object}	util.ServerResponse{data=models.CircuitBreakerResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker/force-close [post]
+func (h *Handler) ForceCloseEndpointCircuitBreaker(w http.ResponseWriter, r *http.Request) {
+	h.updateCircuitBreakerState(w, r, services.CircuitBreakerForceClose)
+}
+
+// ResetEndpointCircuitBreaker
+//
+//	@Summary		Reset an endpoint's circuit breaker
+//	@Description	This endpoint closes an endpoint's circuit breaker with fresh counters and lifts any hold on it
+//	@Id				ResetEndpointCircuitBreaker
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			endpointID	path		string							true	"Endpoint ID"
+//	@Param			request		body		models.UpdateCircuitBreakerState	false	"Reason"
+//	@Success		202			{object}	util.ServerResponse{data=models.CircuitBreakerResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker/reset [post]
+func (h *Handler) ResetEndpointCircuitBreaker(w http.ResponseWriter, r *http.Request) {
+	h.updateCircuitBreakerState(w, r, services.CircuitBreakerReset)
+}
+
+func (h *Handler) updateCircuitBreakerState(w http.ResponseWriter, r *http.Request, action services.CircuitBreakerAction) {
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCircuitBreakerState
+	if r.ContentLength != 0 {
+		if err := util.ReadJSON(r, &req); err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	manager, err := h.circuitBreakerManager()
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	repo := circuit_breakers.New(h.A.Logger, h.A.DB)
+	us := services.UpdateCircuitBreakerStateService{
+		Repo:         repo,
+		EndpointRepo: h.endpointWriteRepo(),
+		Manager:      manager,
+		ProjectID:    project.UID,
+		EndpointID:   endpointID,
+		Action:       action,
+		Reason:       req.Reason,
+		Notifier: &services.CircuitBreakerTransitionNotifier{
+			ProjectRepo:  h.projectRepo(),
+			EndpointRepo: h.endpointWriteRepo(),
+			MetaEvent:    services.NewMetaEvent(h.A.Queue, h.projectRepo(), meta_events.New(h.A.Logger, h.A.DB), h.A.Logger),
+			Queue:        h.A.Queue,
+			Licenser:     h.A.Licenser,
+			Logger:       h.A.Logger,
+		},
+		Logger: h.A.Logger,
+	}
+
+	breaker, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	override, err := repo.FindCircuitBreakerOverride(r.Context(), project.UID, endpointID)
+	if err != nil && !errors.Is(err, datastore.ErrCircuitBreakerOverrideNotFound) {
+		h.A.Logger.Error("failed to load circuit breaker override", "error", err)
+	}
+
+	resp := &models.CircuitBreakerResponse{Breaker: breaker, Override: override}
+	_ = render.Render(w, r, util.NewServerResponse("Circuit breaker updated successfully", resp, http.StatusAccepted))
+}
+
+// UpdateEndpointCircuitBreakerConfig
+//
+//	@Summary		Override an endpoint's circuit breaker thresholds
+//	@Description	This endpoint overrides the project's circuit breaker thresholds for one endpoint. Zero fields inherit the project value
+//	@Id				UpdateEndpointCircuitBreakerConfig
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string										true	"Project ID"
+//	@Param			endpointID	path		string										true	"Endpoint ID"
+//	@Param			request		body		models.UpdateEndpointCircuitBreakerConfig	true	"Thresholds"
+//	@Success		202			{object}	util.ServerResponse{data=datastore.CircuitBreakerOverride}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker/config [put]
+func (h *Handler) UpdateEndpointCircuitBreakerConfig(w http.ResponseWriter, r *http.Request) {
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateEndpointCircuitBreakerConfig
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	us := services.UpdateCircuitBreakerConfigService{
+		Repo:         circuit_breakers.New(h.A.Logger, h.A.DB),
+		EndpointRepo: h.endpointWriteRepo(),
+		ProjectID:    project.UID,
+		EndpointID:   endpointID,
+		Config:       req.Transform(),
+		Logger:       h.A.Logger,
+	}
+
+	override, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Circuit breaker config updated successfully", override, http.StatusAccepted))
+}
+
+// DeleteEndpointCircuitBreakerConfig
+//
+//	@Summary		Remove an endpoint's circuit breaker thresholds
+//	@Description	This endpoint removes an endpoint's threshold overrides so it uses the project's circuit breaker config again
+//	@Id				DeleteEndpointCircuitBreakerConfig
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			endpointID	path		string	true	"Endpoint ID"
+//	@Success		200			{object}	util.ServerResponse{data=Stub}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker/config [delete]
+func (h *Handler) DeleteEndpointCircuitBreakerConfig(w http.ResponseWriter, r *http.Request) {
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	us := services.UpdateCircuitBreakerConfigService{
+		Repo:         circuit_breakers.New(h.A.Logger, h.A.DB),
+		EndpointRepo: h.endpointWriteRepo(),
+		ProjectID:    project.UID,
+		EndpointID:   endpointID,
+		Logger:       h.A.Logger,
+	}
+
+	if _, err := us.Run(r.Context()); err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Circuit breaker config removed successfully", nil, http.StatusOK))
+}
+
+// GetEndpointCircuitBreakerTransitions
+//
+//	@Summary		List an endpoint's circuit breaker transitions
+//	@Description	This endpoint lists the state changes of an endpoint's circuit breaker, newest first
+//	@Id				GetEndpointCircuitBreakerTransitions
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string										true	"Project ID"
+//	@Param			endpointID	path		string										true	"Endpoint ID"
+//	@Param			request		query		models.QueryListCircuitBreakerTransitions	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.CircuitBreakerTransitionResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/circuit-breaker/transitions [get]
+func (h *Handler) GetEndpointCircuitBreakerTransitions(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListCircuitBreakerTransitions
+	query, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, false)
+	if !ok {
+		return
+	}
+
+	transitions, err := circuit_breakers.New(h.A.Logger, h.A.DB).LoadCircuitBreakerTransitions(r.Context(), project.UID, endpointID, query.Limit)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching circuit breaker transitions", http.StatusInternalServerError))
+		return
+	}
+
+	resp := make([]models.CircuitBreakerTransitionResponse, 0, len(transitions))
+	for i := range transitions {
+		resp = append(resp, models.CircuitBreakerTransitionResponse{CircuitBreakerTransition: &transitions[i]})
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Circuit breaker transitions fetched successfully", resp, http.StatusOK))
+}
+
+// resolveEndpointRequest resolves the project and endpoint a request under
+// /endpoints/{endpointID} targets, answering 404 for an endpoint outside the
+// project. It writes the error response itself when it returns false.
+func (h *Handler) resolveEndpointRequest(w http.ResponseWriter, r *http.Request, manage bool) (*datastore.Project, string, bool) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return nil, "", false
+	}
+	if manage && !h.requireJWTProjectManage(w, r, project) {
+		return nil, "", false
+	}
+
+	endpointID := chi.URLParam(r, "endpointID")
+
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if !h.ensurePortalLinkOwnsEndpoints(w, r, authUser, endpointID) {
+		return nil, "", false
+	}
+
+	// Breaker state is keyed by endpoint alone, so an endpoint from another
+	// project must not resolve here.
+	_, err = endpointsvc.New(h.A.Logger, h.A.DB).FindEndpointByID(r.Context(), endpointID, project.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return nil, "", false
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to fetch endpoint", http.StatusBadRequest))
+		return nil, "", false
+	}
+
+	return project, endpointID, true
+}
+
+// circuitBreakerManager builds a manager over the shared breaker store for
+// one-off reads and writes; sampling only runs in the worker.
+func (h *Handler) circuitBreakerManager() (*cb.CircuitBreakerManager, error) {
+	if h.A.CircuitBreakerStore == nil {
+		return nil, &services.ServiceError{ErrMsg: "circuit breaker store is not configured"}
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return cb.NewCircuitBreakerManager(
+		cb.MasterConfigOption(cb.CircuitBreakerConfig{
+			SampleRate:                  cfg.CircuitBreaker.SampleRate,
+			BreakerTimeout:              cfg.CircuitBreaker.ErrorTimeout,
+			FailureThreshold:            cfg.CircuitBreaker.FailureThreshold,
+			SuccessThreshold:            cfg.CircuitBreaker.SuccessThreshold,
+			ObservabilityWindow:         cfg.CircuitBreaker.ObservabilityWindow,
+			MinimumRequestCount:         cfg.CircuitBreaker.MinimumRequestCount,
+			ConsecutiveFailureThreshold: cfg.CircuitBreaker.ConsecutiveFailureThreshold,
+		}),
+		cb.ConfigProviderOption(func(string) *cb.CircuitBreakerConfig { return nil }),
+		cb.StoreOption(h.A.CircuitBreakerStore),
+		cb.ClockOption(clock.NewRealClock()),
+		cb.LoggerOption(h.A.Logger),
+	)
+}
@@ -116,6 +116,7 @@ func (h *Handler) GetDashboardSummary(w http.ResponseWriter, r *http.Request) {
 
 	if data != nil {
 		h.cacheNewDashboardDataInBackground(project, searchParams, p, period, qs, endpointIDs)
+		data.EndpointHealth = h.endpointHealthOverview(r.Context(), project.UID, endpointIDs, startT, endT)
 		_ = render.Render(w, r, util.NewServerResponse("Dashboard summary fetched successfully",
 			data, http.StatusOK))
 		return
@@ -158,6 +159,10 @@ func (h *Handler) GetDashboardSummary(w http.ResponseWriter, r *http.Request) {
 		h.A.Logger.Error("failed to cache dashboard", "error", err)
 	}
 
+	// Health is read live rather than cached with the delivery counts, so the
+	// dashboard shows an endpoint going down within a check interval.
+	dashboard.EndpointHealth = h.endpointHealthOverview(r.Context(), project.UID, endpointIDs, startT, endT)
+
 	_ = render.Render(w, r, util.NewServerResponse("Dashboard summary fetched successfully",
 		dashboard, http.StatusOK))
 }
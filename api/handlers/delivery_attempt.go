@@ -42,6 +42,7 @@ func (h *Handler) GetDeliveryAttempt(w http.ResponseWriter, r *http.Request) {
 	}
 
 	showRawHeaders := h.canViewRawHeaders(authUser)
+	viewPayloads := h.canViewPayloads(r)
 
 	if len(eventDelivery.DeliveryAttempts) > 0 {
 		deliveryAttempt, deliveryErr := findDeliveryAttempt(eventDelivery.DeliveryAttempts, deliveryAttemptID)
@@ -50,7 +51,11 @@ func (h *Handler) GetDeliveryAttempt(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		_ = render.Render(w, r, util.NewServerResponse("Event delivery attempt fetched successfully", models.NewDeliveryAttemptResponse(deliveryAttempt, showRawHeaders), http.StatusOK))
+		resp := models.NewDeliveryAttemptResponse(deliveryAttempt, showRawHeaders)
+		if !viewPayloads {
+			resp = resp.WithoutPayload()
+		}
+		_ = render.Render(w, r, util.NewServerResponse("Event delivery attempt fetched successfully", resp, http.StatusOK))
 		return
 	}
 
@@ -61,7 +66,11 @@ func (h *Handler) GetDeliveryAttempt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_ = render.Render(w, r, util.NewServerResponse("Event delivery attempt fetched successfully", models.NewDeliveryAttemptResponse(deliveryAttempt, showRawHeaders), http.StatusOK))
+	resp := models.NewDeliveryAttemptResponse(deliveryAttempt, showRawHeaders)
+	if !viewPayloads {
+		resp = resp.WithoutPayload()
+	}
+	_ = render.Render(w, r, util.NewServerResponse("Event delivery attempt fetched successfully", resp, http.StatusOK))
 }
 
 // GetDeliveryAttempts
@@ -99,7 +108,13 @@ func (h *Handler) GetDeliveryAttempts(w http.ResponseWriter, r *http.Request) {
 
 	eventDelivery.DeliveryAttempts = append(eventDelivery.DeliveryAttempts, attempts...)
 
-	_ = render.Render(w, r, util.NewServerResponse("Event delivery attempts fetched successfully", models.NewDeliveryAttemptResponses(eventDelivery.DeliveryAttempts, h.canViewRawHeaders(authUser)), http.StatusOK))
+	resp := models.NewDeliveryAttemptResponses(eventDelivery.DeliveryAttempts, h.canViewRawHeaders(authUser))
+	if !h.canViewPayloads(r) {
+		for i := range resp {
+			resp[i] = resp[i].WithoutPayload()
+		}
+	}
+	_ = render.Render(w, r, util.NewServerResponse("Event delivery attempts fetched successfully", resp, http.StatusOK))
 }
 
 func findDeliveryAttempt(attempts []datastore.DeliveryAttempt, id string) (*datastore.DeliveryAttempt, error) {
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/api/policies"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/email"
+	"github.com/frain-dev/convoy/internal/email_templates"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// GetEmailTemplates lists the emails an organisation can customise, with
+// their variables and the organisation's overrides.
+func (h *Handler) GetEmailTemplates(w http.ResponseWriter, r *http.Request) {
+	org, err := h.retrieveOrganisation(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	overrides, err := email_templates.New(h.A.Logger, h.A.DB).LoadEmailTemplates(r.Context(), org.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load email templates", http.StatusInternalServerError))
+		return
+	}
+
+	byName := make(map[string]*datastore.EmailTemplate, len(overrides))
+	for i := range overrides {
+		byName[overrides[i].TemplateName] = &overrides[i]
+	}
+
+	templates := email.CustomisableTemplates()
+	resp := make([]models.EmailTemplateResponse, 0, len(templates))
+	for _, name := range templates {
+		resp = append(resp, models.EmailTemplateResponse{
+			TemplateName: string(name),
+			Variables:    email.Variables(name),
+			Override:     byName[string(name)],
+		})
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Email templates fetched successfully", resp, http.StatusOK))
+}
+
+func (h *Handler) UpdateEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateEmailTemplate
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	org, ok := h.authorizeOrganisationManage(w, r)
+	if !ok {
+		return
+	}
+
+	us := services.UpsertEmailTemplateService{
+		Repo:  email_templates.New(h.A.Logger, h.A.DB),
+		OrgID: org.UID,
+		Update: &datastore.EmailTemplate{
+			TemplateName: chi.URLParam(r, "templateName"),
+			Subject:      req.Subject,
+			Body:         req.Body,
+		},
+		Logger: h.A.Logger,
+	}
+
+	template, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Email template updated successfully", template, http.StatusAccepted))
+}
+
+// DeleteEmailTemplate drops the organisation's override, so the built-in
+// email is sent again.
+func (h *Handler) DeleteEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.authorizeOrganisationManage(w, r)
+	if !ok {
+		return
+	}
+
+	err := email_templates.New(h.A.Logger, h.A.DB).DeleteEmailTemplate(r.Context(), org.UID, chi.URLParam(r, "templateName"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrEmailTemplateNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to delete email template", http.StatusInternalServerError))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Email template deleted successfully", nil, http.StatusOK))
+}
+
+// PreviewEmailTemplate renders an unsaved subject and body with sample
+// variables and the organisation's branding.
+func (h *Handler) PreviewEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	var req models.PreviewEmailTemplate
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	org, ok := h.authorizeOrganisationManage(w, r)
+	if !ok {
+		return
+	}
+
+	ps := services.PreviewEmailTemplateService{
+		Repo:         email_templates.New(h.A.Logger, h.A.DB),
+		OrgID:        org.UID,
+		TemplateName: email.TemplateName(chi.URLParam(r, "templateName")),
+		Subject:      req.Subject,
+		Body:         req.Body,
+	}
+
+	preview, err := ps.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Email template rendered successfully", preview, http.StatusOK))
+}
+
+// SendTestEmailTemplate sends the saved template, with sample variables, to
+// the signed-in user.
+func (h *Handler) SendTestEmailTemplate(w http.ResponseWriter, r *http.Request) {
+	org, ok := h.authorizeOrganisationManage(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.retrieveUser(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusUnauthorized))
+		return
+	}
+
+	ts := services.SendTestEmailService{
+		Queue:        h.A.Queue,
+		OrgID:        org.UID,
+		TemplateName: email.TemplateName(chi.URLParam(r, "templateName")),
+		Recipient:    user.Email,
+	}
+
+	if err = ts.Run(r.Context()); err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Test email queued successfully", nil, http.StatusAccepted))
+}
+
+func (h *Handler) GetEmailBranding(w http.ResponseWriter, r *http.Request) {
+	org, err := h.retrieveOrganisation(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	branding, err := email_templates.New(h.A.Logger, h.A.DB).FindEmailBranding(r.Context(), org.UID)
+	if err != nil {
+		if !errors.Is(err, datastore.ErrEmailBrandingNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse("failed to find email branding", http.StatusInternalServerError))
+			return
+		}
+		branding = &datastore.EmailBranding{OrganisationID: org.UID}
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Email branding fetched successfully", branding, http.StatusOK))
+}
+
+func (h *Handler) UpdateEmailBranding(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateEmailBranding
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	org, ok := h.authorizeOrganisationManage(w, r)
+	if !ok {
+		return
+	}
+
+	us := services.UpdateEmailBrandingService{
+		Repo:     email_templates.New(h.A.Logger, h.A.DB),
+		OrgID:    org.UID,
+		Branding: req.Transform(),
+		Logger:   h.A.Logger,
+	}
+
+	branding, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Email branding updated successfully", branding, http.StatusAccepted))
+}
+
+// authorizeOrganisationManage resolves the route's organisation and checks the
+// user may manage it, rendering the failure when not.
+func (h *Handler) authorizeOrganisationManage(w http.ResponseWriter, r *http.Request) (*datastore.Organisation, bool) {
+	org, err := h.retrieveOrganisation(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return nil, false
+	}
+
+	if err = h.A.Authz.Authorize(r.Context(), string(policies.PermissionOrganisationManage), org); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return nil, false
+	}
+
+	return org, true
+}
@@ -15,8 +15,10 @@ import (
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/endpoint_health"
 	endpointsvc "github.com/frain-dev/convoy/internal/endpoints"
 	"github.com/frain-dev/convoy/internal/event_deliveries"
+	"github.com/frain-dev/convoy/internal/event_types"
 	"github.com/frain-dev/convoy/internal/pkg/cbenablement"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
 	convoynet "github.com/frain-dev/convoy/net"
@@ -118,7 +120,10 @@ func (h *Handler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	resp := &models.EndpointResponse{
+		Endpoint: endpoint,
+		Health:   h.endpointHealthSummary(r.Context(), project.UID, endpoint.UID),
+	}
 
 	resBytes, err := migrator.Marshal(resp)
 	if err != nil {
@@ -184,7 +189,10 @@ func (h *Handler) GetEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	resp := &models.EndpointResponse{
+		Endpoint: endpoint,
+		Health:   h.endpointHealthSummary(r.Context(), project.UID, endpoint.UID),
+	}
 
 	resBytes, err := migrator.Marshal(resp)
 	if err != nil {
@@ -305,8 +313,14 @@ func (h *Handler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	endpointIDs := make([]string, len(endpoints))
+	for i := range endpoints {
+		endpointIDs[i] = endpoints[i].UID
+	}
+	health := endpointHealthSummaries(r.Context(), endpoint_health.New(h.A.Logger, h.A.DB), h.A.Logger, project.UID, endpointIDs)
+
 	resp := models.NewListResponse(endpoints, func(endpoint datastore.Endpoint) models.EndpointResponse {
-		return models.EndpointResponse{Endpoint: &endpoint}
+		return models.EndpointResponse{Endpoint: &endpoint, Health: health[endpoint.UID]}
 	})
 
 	pagedResp := models.PagedResponse{Content: &resp, Pagination: &paginationData}
@@ -613,7 +627,10 @@ func (h *Handler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EndpointResponse{Endpoint: endpoint}
+	resp := &models.EndpointResponse{
+		Endpoint: endpoint,
+		Health:   h.endpointHealthSummary(r.Context(), project.UID, endpoint.UID),
+	}
 
 	resBytes, err := migrator.Marshal(resp)
 	if err != nil {
@@ -1047,6 +1064,68 @@ func (h *Handler) TestOAuth2Connection(w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, util.NewServerResponse("OAuth2 connection test successful", resp, http.StatusOK))
 }
 
+// SendTestEvent
+//
+//	@Summary		Send a test event
+//	@Description	This endpoint sends a test event of an event type to an endpoint, through one of its subscriptions and regardless of their filters. Without data, a sample payload is generated from the event type's JSON schema. The delivery is signed and authenticated like any other, but it is attempted once and left out of metrics and usage
+//	@Id				SendTestEvent
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string					true	"Project ID"
+//	@Param			endpointID	path		string					true	"Endpoint ID"
+//	@Param			event		body		models.SendTestEvent	true	"Test Event Details"
+//	@Success		201			{object}	util.ServerResponse{data=models.TestEventResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/test-event [post]
+func (h *Handler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	var testEvent models.SendTestEvent
+	err := util.ReadJSON(r, &testEvent)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = testEvent.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	endpointID := chi.URLParam(r, "endpointID")
+
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if !h.ensurePortalLinkOwnsEndpoints(w, r, authUser, endpointID) {
+		return
+	}
+
+	ts := services.SendTestEventService{
+		EndpointRepo:   endpointsvc.New(h.A.Logger, h.A.DB),
+		EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+		SubRepo:        h.subscriptionRepo(),
+		Queue:          h.A.Queue,
+		Logger:         h.A.Logger,
+		Project:        project,
+		EndpointID:     endpointID,
+		TestEvent:      &testEvent,
+	}
+
+	resp, err := ts.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Test event queued successfully", resp, http.StatusCreated))
+}
+
 func (h *Handler) newOAuth2TokenTestService() (*services.OAuth2TokenService, error) {
 	dispatcher, err := convoynet.NewDispatcher(
 		h.A.Licenser,
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/endpoint_health"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// GetEndpointHealthCheck
+//
+//	@Summary		Retrieve an endpoint's health check
+//	@Description	This endpoint retrieves an endpoint's health check configuration with its uptime and latency percentiles over a window
+//	@Id				GetEndpointHealthCheck
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			endpointID	path		string						true	"Endpoint ID"
+//	@Param			request		query		models.QueryEndpointHealth	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=models.EndpointHealthResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/health-check [get]
+func (h *Handler) GetEndpointHealthCheck(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryEndpointHealth
+	query, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, false)
+	if !ok {
+		return
+	}
+
+	repo := endpoint_health.New(h.A.Logger, h.A.DB)
+	check, err := repo.FindEndpointHealthCheck(r.Context(), project.UID, endpointID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointHealthCheckNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load endpoint health check", http.StatusInternalServerError))
+		return
+	}
+
+	now := time.Now()
+	stats, err := repo.SummarizeEndpointHealth(r.Context(), project.UID, []string{endpointID}, query.Since(now), now)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to summarize endpoint health", http.StatusInternalServerError))
+		return
+	}
+
+	resp := &models.EndpointHealthResponse{Check: check, Stats: stats}
+	_ = render.Render(w, r, util.NewServerResponse("Endpoint health check fetched successfully", resp, http.StatusOK))
+}
+
+// UpsertEndpointHealthCheck
+//
+//	@Summary		Configure an endpoint's health check
+//	@Description	This endpoint creates or replaces the periodic health check of an endpoint. The first check runs within a minute
+//	@Id				UpsertEndpointHealthCheck
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string								true	"Project ID"
+//	@Param			endpointID	path		string								true	"Endpoint ID"
+//	@Param			request		body		models.UpsertEndpointHealthCheck	true	"Health check"
+//	@Success		202			{object}	util.ServerResponse{data=datastore.EndpointHealthCheck}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/health-check [put]
+func (h *Handler) UpsertEndpointHealthCheck(w http.ResponseWriter, r *http.Request) {
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	var req models.UpsertEndpointHealthCheck
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	us := services.UpsertEndpointHealthCheckService{
+		Repo:         endpoint_health.New(h.A.Logger, h.A.DB),
+		EndpointRepo: h.endpointWriteRepo(),
+		ProjectID:    project.UID,
+		EndpointID:   endpointID,
+		Check:        req.Transform(),
+		Logger:       h.A.Logger,
+	}
+
+	check, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Endpoint health check updated successfully", check, http.StatusAccepted))
+}
+
+// DeleteEndpointHealthCheck
+//
+//	@Summary		Remove an endpoint's health check
+//	@Description	This endpoint stops the periodic health check of an endpoint. Recorded results are kept until they expire
+//	@Id				DeleteEndpointHealthCheck
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			endpointID	path		string	true	"Endpoint ID"
+//	@Success		200			{object}	util.ServerResponse{data=Stub}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/health-check [delete]
+func (h *Handler) DeleteEndpointHealthCheck(w http.ResponseWriter, r *http.Request) {
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	err := endpoint_health.New(h.A.Logger, h.A.DB).DeleteEndpointHealthCheck(r.Context(), project.UID, endpointID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to delete endpoint health check", http.StatusInternalServerError))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Endpoint health check removed successfully", nil, http.StatusOK))
+}
+
+// GetEndpointHealthCheckResults
+//
+//	@Summary		List an endpoint's health check results
+//	@Description	This endpoint lists the health check results of an endpoint within a window, newest first
+//	@Id				GetEndpointHealthCheckResults
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			endpointID	path		string						true	"Endpoint ID"
+//	@Param			request		query		models.QueryEndpointHealth	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.EndpointHealthCheckResultResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/health-check/results [get]
+func (h *Handler) GetEndpointHealthCheckResults(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryEndpointHealth
+	query, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, endpointID, ok := h.resolveEndpointRequest(w, r, false)
+	if !ok {
+		return
+	}
+
+	results, err := endpoint_health.New(h.A.Logger, h.A.DB).LoadEndpointHealthCheckResults(r.Context(), project.UID, endpointID, query.Since(time.Now()), query.Limit)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching endpoint health check results", http.StatusInternalServerError))
+		return
+	}
+
+	resp := make([]models.EndpointHealthCheckResultResponse, 0, len(results))
+	for i := range results {
+		resp = append(resp, models.EndpointHealthCheckResultResponse{EndpointHealthCheckResult: &results[i]})
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Endpoint health check results fetched successfully", resp, http.StatusOK))
+}
+
+// endpointHealthSummary returns the health view for an endpoint, or nil when
+// it has no health check. Failures are logged and leave the summary out, so
+// they never fail the endpoint read itself.
+func (h *Handler) endpointHealthSummary(ctx context.Context, projectID, endpointID string) *models.EndpointHealthSummary {
+	repo := endpoint_health.New(h.A.Logger, h.A.DB)
+	check, err := repo.FindEndpointHealthCheck(ctx, projectID, endpointID)
+	if err != nil {
+		if !errors.Is(err, datastore.ErrEndpointHealthCheckNotFound) {
+			h.A.Logger.ErrorContext(ctx, "failed to load endpoint health check", "error", err)
+		}
+		return nil
+	}
+
+	summary := &models.EndpointHealthSummary{Status: check.LastStatus, LastCheckedAt: check.LastCheckedAt}
+
+	now := time.Now()
+	summary.Last24h, err = repo.SummarizeEndpointHealth(ctx, projectID, []string{endpointID}, now.Add(-24*time.Hour), now)
+	if err != nil {
+		h.A.Logger.ErrorContext(ctx, "failed to summarize endpoint health", "error", err)
+	}
+
+	return summary
+}
+
+// endpointHealthSummaries returns the health summary of every monitored
+// endpoint among endpointIDs, keyed by endpoint id. It costs two queries
+// however many endpoints are listed, so endpoint lists can carry health too.
+func endpointHealthSummaries(ctx context.Context, repo datastore.EndpointHealthRepository, logger log.Logger, projectID string, endpointIDs []string) map[string]*models.EndpointHealthSummary {
+	summaries := map[string]*models.EndpointHealthSummary{}
+
+	checks, err := repo.FindEndpointHealthChecks(ctx, projectID, endpointIDs)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to load endpoint health checks", "error", err)
+		return summaries
+	}
+	if len(checks) == 0 {
+		return summaries
+	}
+
+	monitored := make([]string, 0, len(checks))
+	for i := range checks {
+		summaries[checks[i].EndpointID] = &models.EndpointHealthSummary{Status: checks[i].LastStatus, LastCheckedAt: checks[i].LastCheckedAt}
+		monitored = append(monitored, checks[i].EndpointID)
+	}
+
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+	stats, err := repo.SummarizeEndpointHealthByEndpoint(ctx, projectID, monitored, since, now)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to summarize endpoint health", "error", err)
+		return summaries
+	}
+
+	for id, summary := range summaries {
+		summary.Last24h = stats[id]
+		if summary.Last24h == nil {
+			// Match the single endpoint summary, which reports an empty window
+			// rather than no window.
+			summary.Last24h = &datastore.EndpointHealthStats{Since: since, Until: now}
+		}
+	}
+
+	return summaries
+}
+
+// endpointHealthOverview summarises the monitored endpoints in view for the
+// dashboard, or returns nil when none are monitored.
+func (h *Handler) endpointHealthOverview(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) *models.EndpointHealthOverview {
+	repo := endpoint_health.New(h.A.Logger, h.A.DB)
+	counts, err := repo.CountEndpointHealth(ctx, projectID, endpointIDs)
+	if err != nil {
+		h.A.Logger.ErrorContext(ctx, "failed to count endpoint health", "error", err)
+		return nil
+	}
+	if counts.Monitored == 0 {
+		return nil
+	}
+
+	stats, err := repo.SummarizeEndpointHealth(ctx, projectID, endpointIDs, since, until)
+	if err != nil {
+		h.A.Logger.ErrorContext(ctx, "failed to summarize endpoint health", "error", err)
+	}
+
+	return &models.EndpointHealthOverview{EndpointHealthCounts: counts, Stats: stats}
+}
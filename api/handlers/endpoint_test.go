@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
 )
 
 func TestApplyPeriodFailureRates(t *testing.T) {
@@ -175,3 +178,44 @@ func TestIntersectIDs(t *testing.T) {
 func f64(v float64) *float64 { return &v }
 
 func i64(v int64) *int64 { return &v }
+
+func TestEndpointHealthSummaries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEndpointHealthRepository(ctrl)
+	checkedAt := time.Now()
+	uptime := 50.0
+
+	repo.EXPECT().FindEndpointHealthChecks(gomock.Any(), "project-1", []string{"ep1", "ep2", "ep3"}).Return([]datastore.EndpointHealthCheck{
+		{EndpointID: "ep1", LastStatus: datastore.EndpointHealthy, LastCheckedAt: &checkedAt},
+		{EndpointID: "ep2", LastStatus: datastore.EndpointUnhealthy, LastCheckedAt: &checkedAt},
+	}, nil)
+	repo.EXPECT().SummarizeEndpointHealthByEndpoint(gomock.Any(), "project-1", []string{"ep1", "ep2"}, gomock.Any(), gomock.Any()).
+		Return(map[string]*datastore.EndpointHealthStats{"ep1": {Checks: 2, UptimePercentage: &uptime}}, nil)
+
+	summaries := endpointHealthSummaries(context.Background(), repo, log.New("convoy", log.LevelError), "project-1", []string{"ep1", "ep2", "ep3"})
+
+	require.Len(t, summaries, 2)
+	require.Equal(t, datastore.EndpointHealthy, summaries["ep1"].Status)
+	require.Equal(t, int64(2), summaries["ep1"].Last24h.Checks)
+
+	// monitored but no results in the window yet
+	require.Equal(t, datastore.EndpointUnhealthy, summaries["ep2"].Status)
+	require.Zero(t, summaries["ep2"].Last24h.Checks)
+	require.Nil(t, summaries["ep2"].Last24h.UptimePercentage)
+
+	// not monitored
+	require.Nil(t, summaries["ep3"])
+}
+
+func TestEndpointHealthSummaries_NoChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEndpointHealthRepository(ctrl)
+	repo.EXPECT().FindEndpointHealthChecks(gomock.Any(), "project-1", []string{"ep1"}).Return(nil, nil)
+
+	summaries := endpointHealthSummaries(context.Background(), repo, log.New("convoy", log.LevelError), "project-1", []string{"ep1"})
+	require.Empty(t, summaries)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/event_type_versions"
 	"github.com/frain-dev/convoy/internal/events"
 	internalio "github.com/frain-dev/convoy/internal/io"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
@@ -95,6 +96,12 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	err = services.CheckEventTypeVersion(r.Context(), event_type_versions.New(h.A.Logger, h.A.DB), projectID, newMessage.EventType, newMessage.EventTypeVersion)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	if h.enforceTrialEventCapForNewEvent(w, r, project.OrganisationID, projectID, newMessage.IdempotencyKey, h.duplicateByAnyEvent) {
 		return
 	}
@@ -119,6 +126,7 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		// addressing modes resolve to concrete endpoints in the worker, so both get
 		// a catch-all subscription auto-provisioned for subscription-less endpoints.
 		CreateSubscription: true,
+		EventTypeVersion:   newMessage.EventTypeVersion,
 	}
 
 	eventByte, err := msgpack.EncodeMsgPack(e)
@@ -128,8 +136,9 @@ func (h *Handler) CreateEndpointEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
+		ID:        jobId,
+		Payload:   eventByte,
+		ProjectID: projectID,
 	}
 
 	err = h.A.Queue.Write(r.Context(), convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
@@ -183,6 +192,12 @@ func (h *Handler) CreateBroadcastEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = services.CheckEventTypeVersion(r.Context(), event_type_versions.New(h.A.Logger, h.A.DB), project.UID, newMessage.EventType, newMessage.EventTypeVersion)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	if h.enforceTrialEventCapForNewEvent(w, r, project.OrganisationID, project.UID, newMessage.IdempotencyKey, h.duplicateByAnyEvent) {
 		return
 	}
@@ -262,6 +277,12 @@ func (h *Handler) CreateEndpointFanoutEvent(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = services.CheckEventTypeVersion(r.Context(), event_type_versions.New(h.A.Logger, h.A.DB), project.UID, newMessage.EventType, newMessage.EventTypeVersion)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	// Fanout decides novelty with FindFirstEventWithIdempotencyKey (non-duplicate rows
 	// only), so the gate must use the same predicate; see trialCapDuplicateVerdict.
 	if h.enforceTrialEventCapForNewEvent(w, r, project.OrganisationID, project.UID, newMessage.IdempotencyKey, h.duplicateByFirstNonDuplicateEvent) {
@@ -414,7 +435,10 @@ func (h *Handler) ReplayEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EventResponse{Event: event}
+	resp := models.EventResponse{Event: event}
+	if !h.canViewPayloads(r) {
+		resp = resp.WithoutPayload()
+	}
 	_ = render.Render(w, r, util.NewServerResponse("Endpoint event replayed successfully", resp, http.StatusOK))
 }
 
@@ -529,7 +553,10 @@ func (h *Handler) GetEndpointEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := &models.EventResponse{Event: event}
+	resp := models.EventResponse{Event: event}
+	if !h.canViewPayloads(r) {
+		resp = resp.WithoutPayload()
+	}
 	_ = render.Render(w, r, util.NewServerResponse("Endpoint event fetched successfully",
 		resp, http.StatusOK))
 }
@@ -587,6 +614,13 @@ func (h *Handler) GetEventsPaged(w http.ResponseWriter, r *http.Request) {
 
 	data.Filter.Project = project
 
+	// Searching payloads would reveal them to callers that cannot see them.
+	viewPayloads := h.canViewPayloads(r)
+	if !viewPayloads && (!util.IsStringEmpty(data.Filter.Query) || len(data.Filter.Body) > 0) {
+		_ = render.Render(w, r, util.NewErrorResponse("portal link is missing the view_payloads permission needed to search events", http.StatusForbidden))
+		return
+	}
+
 	if err := events.ApplyEventListSearch(data.Filter, project, h.A.Licenser.EventSearch(), time.Now()); err != nil {
 		status := http.StatusBadRequest
 		if errors.Is(err, events.ErrSearchUnlicensed) {
@@ -615,6 +649,9 @@ func (h *Handler) GetEventsPaged(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := models.NewListResponse(eventsPaged, func(event datastore.Event) models.EventResponse {
+		if !viewPayloads {
+			return models.EventResponse{Event: &event}.WithoutPayload()
+		}
 		return models.EventResponse{Event: &event}
 	})
 	_ = render.Render(w, r, util.NewServerResponse("App events fetched successfully",
@@ -47,6 +47,9 @@ func (h *Handler) GetEventDelivery(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := models.NewEventDeliveryResponse(eventDelivery, h.canViewRawHeaders(authUser))
+	if !h.canViewPayloads(r) {
+		resp = resp.WithoutPayload()
+	}
 	_ = render.Render(w, r, util.NewServerResponse("Event Delivery fetched successfully",
 		resp, http.StatusOK))
 }
@@ -99,6 +102,9 @@ func (h *Handler) ResendEventDelivery(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := models.NewEventDeliveryResponse(eventDelivery, h.canViewRawHeaders(authUser))
+	if !h.canViewPayloads(r) {
+		resp = resp.WithoutPayload()
+	}
 	_ = render.Render(w, r, util.NewServerResponse("App event processed for retry successfully",
 		resp, http.StatusOK))
 }
@@ -341,7 +347,11 @@ func (h *Handler) GetEventDeliveriesPaged(w http.ResponseWriter, r *http.Request
 	}
 
 	showRawHeaders := h.canViewRawHeaders(authUser)
+	viewPayloads := h.canViewPayloads(r)
 	resp := models.NewListResponse(ed, func(ed datastore.EventDelivery) models.EventDeliveryResponse {
+		if !viewPayloads {
+			return models.NewEventDeliveryResponse(&ed, showRawHeaders).WithoutPayload()
+		}
 		return models.NewEventDeliveryResponse(&ed, showRawHeaders)
 	})
 
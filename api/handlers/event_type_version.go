@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/event_type_versions"
+	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// GetEventTypeVersions
+//
+//	@Summary		List an event type's versions
+//	@Description	This endpoint fetches every version of an event type, oldest first
+//	@Id				GetEventTypeVersions
+//	@Tags			EventTypes
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			eventTypeId	path		string	true	"Event Type ID"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.EventTypeVersionResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/{eventTypeId}/versions [get]
+func (h *Handler) GetEventTypeVersions(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	eventType, err := event_types.New(h.A.Logger, h.A.DB).FetchEventTypeById(r.Context(), chi.URLParam(r, "eventTypeId"), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	versions, err := event_type_versions.New(h.A.Logger, h.A.DB).LoadEventTypeVersions(r.Context(), project.UID, eventType.Name)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load event type versions", http.StatusBadRequest))
+		return
+	}
+
+	resp := models.NewListResponse(versions, func(version datastore.EventTypeVersion) models.EventTypeVersionResponse {
+		return models.EventTypeVersionResponse{EventTypeVersion: &version}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Event type versions fetched successfully", resp, http.StatusOK))
+}
+
+// CreateEventTypeVersion
+//
+//	@Summary		Create an event type version
+//	@Description	This endpoint adds the next version to an event type. Versions are numbered from 1 in the order they are created.
+//	@Id				CreateEventTypeVersion
+//	@Tags			EventTypes
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			eventTypeId	path		string							true	"Event Type ID"
+//	@Param			version		body		models.CreateEventTypeVersion	true	"Event Type Version Details"
+//	@Success		201			{object}	util.ServerResponse{data=models.EventTypeVersionResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/{eventTypeId}/versions [post]
+func (h *Handler) CreateEventTypeVersion(w http.ResponseWriter, r *http.Request) {
+	// Project-wide event-type mutation; no portal ownership path.
+	// Failure policy: fail closed 401 for portal credentials.
+	if h.rejectPortalLinkToken(w, r) {
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	var newVersion models.CreateEventTypeVersion
+	err = util.ReadJSON(r, &newVersion)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = newVersion.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	version, err := newVersion.Transform()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	cs := services.CreateEventTypeVersionService{
+		EventTypeRepo: event_types.New(h.A.Logger, h.A.DB),
+		VersionRepo:   event_type_versions.New(h.A.Logger, h.A.DB),
+		ProjectID:     project.UID,
+		EventTypeID:   chi.URLParam(r, "eventTypeId"),
+		Version:       version,
+		Logger:        h.A.Logger,
+	}
+
+	version, err = cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.EventTypeVersionResponse{EventTypeVersion: version}
+	_ = render.Render(w, r, util.NewServerResponse("Event type version created successfully", resp, http.StatusCreated))
+}
+
+// UpdateEventTypeVersion
+//
+//	@Summary		Update an event type version
+//	@Description	This endpoint updates an event type version's schema, downgrade function and sunset date
+//	@Id				UpdateEventTypeVersion
+//	@Tags			EventTypes
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			eventTypeId	path		string							true	"Event Type ID"
+//	@Param			version		path		int								true	"Version number"
+//	@Param			update		body		models.UpdateEventTypeVersion	true	"Event Type Version Details"
+//	@Success		202			{object}	util.ServerResponse{data=models.EventTypeVersionResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/{eventTypeId}/versions/{version} [put]
+func (h *Handler) UpdateEventTypeVersion(w http.ResponseWriter, r *http.Request) {
+	// Project-wide event-type mutation; no portal ownership path.
+	// Failure policy: fail closed 401 for portal credentials.
+	if h.rejectPortalLinkToken(w, r) {
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	versionNumber, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || versionNumber < 1 {
+		_ = render.Render(w, r, util.NewErrorResponse("invalid event type version", http.StatusBadRequest))
+		return
+	}
+
+	var update models.UpdateEventTypeVersion
+	err = util.ReadJSON(r, &update)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = update.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	us := services.UpdateEventTypeVersionService{
+		VersionRepo: event_type_versions.New(h.A.Logger, h.A.DB),
+		ProjectID:   project.UID,
+		EventTypeID: chi.URLParam(r, "eventTypeId"),
+		Version:     versionNumber,
+		Update:      &update,
+		Logger:      h.A.Logger,
+	}
+
+	version, err := us.Run(r.Context())
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventTypeVersionNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.EventTypeVersionResponse{EventTypeVersion: version}
+	_ = render.Render(w, r, util.NewServerResponse("Event type version updated successfully", resp, http.StatusAccepted))
+}
+
+// GetSubscriptionEventTypeVersions
+//
+//	@Summary		List a subscription's event type version pins
+//	@Description	This endpoint fetches the event type versions a subscription is pinned to
+//	@Id				GetSubscriptionEventTypeVersions
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string	true	"Project ID"
+//	@Param			subscriptionID	path		string	true	"Subscription ID"
+//	@Success		200				{object}	util.ServerResponse{data=[]models.EventTypeVersionPinResponse}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/subscriptions/{subscriptionID}/event-type-versions [get]
+func (h *Handler) GetSubscriptionEventTypeVersions(w http.ResponseWriter, r *http.Request) {
+	if h.rejectPortalLinkToken(w, r) {
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	if !h.ensureSubscriptionExists(w, r, project.UID, subscriptionID) {
+		return
+	}
+
+	pins, err := event_type_versions.New(h.A.Logger, h.A.DB).LoadSubscriptionVersionPins(r.Context(), project.UID, subscriptionID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load event type version pins", http.StatusBadRequest))
+		return
+	}
+
+	resp := models.NewListResponse(pins, func(pin datastore.EventTypeVersionPin) models.EventTypeVersionPinResponse {
+		return models.EventTypeVersionPinResponse{EventTypeVersionPin: &pin}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Event type version pins fetched successfully", resp, http.StatusOK))
+}
+
+// PinSubscriptionEventTypeVersion
+//
+//	@Summary		Pin a subscription to an event type version
+//	@Description	This endpoint pins a subscription to a version of an event type. Newer events are downgraded to that version before they are delivered.
+//	@Id				PinSubscriptionEventTypeVersion
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string						true	"Project ID"
+//	@Param			subscriptionID	path		string						true	"Subscription ID"
+//	@Param			pin				body		models.PinEventTypeVersion	true	"Pin Details"
+//	@Success		200				{object}	util.ServerResponse{data=models.EventTypeVersionPinResponse}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/subscriptions/{subscriptionID}/event-type-versions [put]
+func (h *Handler) PinSubscriptionEventTypeVersion(w http.ResponseWriter, r *http.Request) {
+	if h.rejectPortalLinkToken(w, r) {
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	var pin models.PinEventTypeVersion
+	err = util.ReadJSON(r, &pin)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = pin.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	if !h.ensureSubscriptionExists(w, r, project.UID, subscriptionID) {
+		return
+	}
+
+	ps := services.PinEventTypeVersionService{
+		VersionRepo:    event_type_versions.New(h.A.Logger, h.A.DB),
+		ProjectID:      project.UID,
+		SubscriptionID: subscriptionID,
+		EventType:      pin.EventType,
+		Version:        pin.Version,
+		Logger:         h.A.Logger,
+	}
+
+	p, err := ps.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.EventTypeVersionPinResponse{EventTypeVersionPin: p}
+	_ = render.Render(w, r, util.NewServerResponse("Subscription pinned successfully", resp, http.StatusOK))
+}
+
+// UnpinSubscriptionEventTypeVersion
+//
+//	@Summary		Unpin a subscription from an event type version
+//	@Description	This endpoint removes a subscription's version pin for an event type, so it receives events as published
+//	@Id				UnpinSubscriptionEventTypeVersion
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string	true	"Project ID"
+//	@Param			subscriptionID	path		string	true	"Subscription ID"
+//	@Param			eventType		path		string	true	"Event Type"
+//	@Success		200				{object}	util.ServerResponse{data=Stub}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/subscriptions/{subscriptionID}/event-type-versions/{eventType} [delete]
+func (h *Handler) UnpinSubscriptionEventTypeVersion(w http.ResponseWriter, r *http.Request) {
+	if h.rejectPortalLinkToken(w, r) {
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	err = event_type_versions.New(h.A.Logger, h.A.DB).UnpinSubscriptionVersion(r.Context(), project.UID, subscriptionID, chi.URLParam(r, "eventType"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventTypeVersionPinNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to unpin event type version", http.StatusBadRequest))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Subscription unpinned successfully", nil, http.StatusOK))
+}
+
+func (h *Handler) ensureSubscriptionExists(w http.ResponseWriter, r *http.Request, projectID, subscriptionID string) bool {
+	_, err := h.subscriptionRepo().FindSubscriptionByID(r.Context(), projectID, subscriptionID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSubscriptionNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse("subscription not found", http.StatusNotFound))
+			return false
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to find subscription", http.StatusBadRequest))
+		return false
+	}
+
+	return true
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/oklog/ulid/v2"
+	"gopkg.in/yaml.v3"
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 )
@@ -35,6 +37,12 @@ func (h *Handler) GetEventTypes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	portalLink, err := h.portalLinkForRequest(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	eventTypeRepo := event_types.New(h.A.Logger, h.A.DB)
 	eventTypes, err := eventTypeRepo.FetchAllEventTypes(r.Context(), project.UID)
 	if err != nil {
@@ -42,6 +50,17 @@ func (h *Handler) GetEventTypes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Portal links only see the event types they may subscribe to.
+	if portalLink != nil {
+		allowed := make([]datastore.ProjectEventType, 0, len(eventTypes))
+		for _, eventType := range eventTypes {
+			if portalLink.AllowsEventType(eventType.Name) {
+				allowed = append(allowed, eventType)
+			}
+		}
+		eventTypes = allowed
+	}
+
 	resp := models.NewListResponse(eventTypes, func(eventType datastore.ProjectEventType) models.EventTypeResponse {
 		return models.EventTypeResponse{ProjectEventType: &eventType}
 	})
@@ -63,9 +82,9 @@ func (h *Handler) GetEventTypes(w http.ResponseWriter, r *http.Request) {
 //	@Security		ApiKeyAuth
 //	@Router			/v1/projects/{projectID}/event-types [post]
 func (h *Handler) CreateEventType(w http.ResponseWriter, r *http.Request) {
-	// Project-wide event-type mutation; no portal ownership path.
-	// Failure policy: fail closed 401 for portal credentials.
-	if h.rejectPortalLinkToken(w, r) {
+	// Project-wide event-type mutation; portal links need the
+	// create_event_types permission. Failure policy: fail closed 403.
+	if h.rejectPortalLinkWithout(w, r, datastore.PortalLinkCreateEventTypes) {
 		return
 	}
 
@@ -279,3 +298,172 @@ func (h *Handler) ImportOpenApiSpec(w http.ResponseWriter, r *http.Request) {
 	})
 	_ = render.Render(w, r, util.NewServerResponse("Event types imported successfully", resp, http.StatusOK))
 }
+
+// GetEventTypeCatalog
+//
+//	@Summary		Generate the webhook catalog
+//	@Description	This endpoint generates an OpenAPI 3.1 webhooks document or an AsyncAPI 3 document from the project's event types, schemas and signature configuration
+//	@Id				GetEventTypeCatalog
+//	@Tags			EventTypes
+//	@Accept			json
+//	@Produce		json,application/yaml
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			spec		path		string	true	"Document type"	Enums(openapi, asyncapi)
+//	@Param			format		query		string	false	"Document format"	Enums(json, yaml)
+//	@Success		200			{object}	map[string]interface{}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/catalog/{spec} [get]
+func (h *Handler) GetEventTypeCatalog(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "yaml" {
+		_ = render.Render(w, r, util.NewErrorResponse("format must be one of json, yaml", http.StatusBadRequest))
+		return
+	}
+
+	portalLink, err := h.portalLinkForRequest(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	cs := services.EventTypeCatalogService{
+		EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+		Project:        project,
+		PortalLink:     portalLink,
+	}
+
+	catalog, err := cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	var doc interface{}
+	switch spec := chi.URLParam(r, "spec"); spec {
+	case "openapi":
+		doc, err = catalog.OpenAPI()
+	case "asyncapi":
+		doc, err = catalog.AsyncAPI()
+	default:
+		_ = render.Render(w, r, util.NewErrorResponse("spec must be one of openapi, asyncapi", http.StatusBadRequest))
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// The document is served as is, not wrapped in a server response, so it
+	// can be published or fed to documentation tooling directly.
+	var b []byte
+	contentType := "application/json"
+	if format == "yaml" {
+		contentType = "application/yaml"
+		b, err = yaml.Marshal(doc)
+	} else {
+		b, err = json.MarshalIndent(doc, "", "  ")
+	}
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to encode catalog", http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
+
+// GetEventTypeSample
+//
+//	@Summary		Generate a sample payload
+//	@Description	This endpoint generates a sample payload for an event type from its JSON schema, honouring the examples, defaults, enums and formats it declares
+//	@Id				GetEventTypeSample
+//	@Tags			EventTypes
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			eventTypeId	path		string	true	"Event Type ID"
+//	@Success		200			{object}	util.ServerResponse{data=map[string]interface{}}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/event-types/{eventTypeId}/sample [get]
+func (h *Handler) GetEventTypeSample(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	portalLink, err := h.portalLinkForRequest(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	ss := services.EventTypeSampleService{
+		EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+		ProjectID:      project.UID,
+		EventTypeID:    chi.URLParam(r, "eventTypeId"),
+		PortalLink:     portalLink,
+	}
+
+	sample, err := ss.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Event type sample generated successfully", sample, http.StatusOK))
+}
+
+// GetPortalEventCatalog lists the event types a portal link can subscribe
+// its endpoints to, with a sample payload of each to preview subscription
+// filters against.
+func (h *Handler) GetPortalEventCatalog(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	portalLink, err := h.retrievePortalLinkFromToken(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	cs := services.PortalEventCatalogService{
+		EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+		ProjectID:      project.UID,
+		PortalLink:     portalLink,
+	}
+
+	catalog, err := cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Event types fetched successfully", catalog, http.StatusOK))
+}
+
+// portalLinkForRequest returns the portal link behind a request made with a
+// portal link token, and nil for any other request.
+func (h *Handler) portalLinkForRequest(r *http.Request) (*datastore.PortalLink, error) {
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if !h.IsReqWithPortalLinkToken(authUser) {
+		return nil, nil
+	}
+
+	return h.retrievePortalLinkFromToken(r)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/types"
 	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
 )
 
 func withPortalTokenAuth(req *http.Request) *http.Request {
@@ -19,8 +20,8 @@ func withPortalTokenAuth(req *http.Request) *http.Request {
 }
 
 // TestEventTypeMutations_RejectPortalLinkToken verifies portal-link credentials
-// cannot create, update, or deprecate project-wide event types. The guard runs
-// before any project lookup, so no datastore is required.
+// cannot update or deprecate project-wide event types. The guard runs before
+// any project lookup, so no datastore is required.
 func TestEventTypeMutations_RejectPortalLinkToken(t *testing.T) {
 	handler := &Handler{A: &types.APIOptions{}}
 
@@ -29,7 +30,6 @@ func TestEventTypeMutations_RejectPortalLinkToken(t *testing.T) {
 		handler http.HandlerFunc
 		method  string
 	}{
-		{"create", handler.CreateEventType, http.MethodPost},
 		{"update", handler.UpdateEventType, http.MethodPut},
 		{"deprecate", handler.DeprecateEventType, http.MethodPost},
 	}
@@ -46,6 +46,29 @@ func TestEventTypeMutations_RejectPortalLinkToken(t *testing.T) {
 	}
 }
 
+// TestCreateEventType_RequiresPortalLinkPermission verifies portal links only
+// create event types with the create_event_types permission.
+func TestCreateEventType_RequiresPortalLinkPermission(t *testing.T) {
+	handler := &Handler{A: &types.APIOptions{}}
+
+	withPortalLink := func(portalLink *datastore.PortalLink) *http.Request {
+		authUser := &auth.AuthenticatedUser{Credential: auth.Credential{Type: auth.CredentialTypeToken}, PortalLink: portalLink}
+		req := httptest.NewRequest(http.MethodPost, "/event-types", nil)
+		return req.WithContext(context.WithValue(req.Context(), convoy.AuthUserCtx, authUser))
+	}
+
+	// Links created without permissions keep the defaults, which leave event
+	// type creation out.
+	w := httptest.NewRecorder()
+	handler.CreateEventType(w, withPortalLink(&datastore.PortalLink{}))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	require.False(t, handler.rejectPortalLinkWithout(w, withPortalLink(&datastore.PortalLink{
+		Permissions: []datastore.PortalLinkPermission{datastore.PortalLinkCreateEventTypes},
+	}), datastore.PortalLinkCreateEventTypes))
+}
+
 // TestEventTypeMutations_AllowNonPortalCallers confirms the new portal guard does
 // not block JWT, PAT, or project-API-key callers (they fall through to normal
 // project authorization).
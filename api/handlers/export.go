@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/event_deliveries"
+	"github.com/frain-dev/convoy/internal/events"
+	"github.com/frain-dev/convoy/internal/export_jobs"
+	"github.com/frain-dev/convoy/internal/pkg/search_export"
+	"github.com/frain-dev/convoy/internal/saved_searches"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// exportDownloadTimeout replaces the server's write timeout for a download,
+// which would otherwise cut all but small exports short. Larger exports
+// belong in the blob store.
+const exportDownloadTimeout = 30 * time.Minute
+
+// DownloadExport
+//
+//	@Summary		Download events or deliveries
+//	@Description	This endpoint streams every event or event delivery a search matches, oldest first, as a CSV or JSON Lines file. Downloads stop after 30 minutes; export larger searches to the blob store
+//	@Id				DownloadExport
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		text/csv,application/x-ndjson
+//	@Param			projectID	path		string				true	"Project ID"
+//	@Param			export		body		models.CreateExport	true	"Export details"
+//	@Success		200			{file}		file
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/exports/download [post]
+func (h *Handler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	project, job, ok := h.resolveExport(w, r)
+	if !ok {
+		return
+	}
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportDownloadTimeout))
+
+	filename := fmt.Sprintf("%s-%s.%s", job.Resource, time.Now().UTC().Format("20060102T150405Z"), job.Format)
+	w.Header().Set("Content-Type", job.Format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	// The status line is already out, so a failure part way through can
+	// only cut the file short.
+	exporter := search_export.New(events.New(h.A.Logger, h.A.DB), event_deliveries.New(h.A.Logger, h.A.DB))
+	_, err := exporter.Export(r.Context(), project, job.Resource, job.Format, job.Filter, w)
+	if err != nil {
+		h.A.Logger.ErrorContext(r.Context(), "failed to stream export", "project_id", project.UID, "error", err)
+	}
+}
+
+// CreateExportJob
+//
+//	@Summary		Export events or deliveries to the blob store
+//	@Description	This endpoint starts a background job that writes every event or event delivery a search matches to the instance's blob store, as a CSV or JSON Lines file
+//	@Id				CreateExportJob
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string				true	"Project ID"
+//	@Param			export		body		models.CreateExport	true	"Export details"
+//	@Success		202			{object}	util.ServerResponse{data=models.ExportJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/exports [post]
+func (h *Handler) CreateExportJob(w http.ResponseWriter, r *http.Request) {
+	_, job, ok := h.resolveExport(w, r)
+	if !ok {
+		return
+	}
+
+	cs := services.CreateExportJobService{
+		ExportJobRepo: export_jobs.New(h.A.Logger, h.A.DB),
+		Queue:         h.A.Queue,
+		Job:           job,
+		Logger:        h.A.Logger,
+	}
+
+	job, err := cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.ExportJobResponse{ExportJob: job}
+	_ = render.Render(w, r, util.NewServerResponse("Export job created successfully", resp, http.StatusAccepted))
+}
+
+// GetExportJobs
+//
+//	@Summary		List export jobs
+//	@Description	This endpoint fetches a project's most recent blob store exports
+//	@Id				GetExportJobs
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.ExportJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/exports [get]
+func (h *Handler) GetExportJobs(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	jobs, err := export_jobs.New(h.A.Logger, h.A.DB).LoadExportJobs(r.Context(), project.UID, 0)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load export jobs", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(jobs, func(job datastore.ExportJob) models.ExportJobResponse {
+		return models.ExportJobResponse{ExportJob: &job}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Export jobs fetched successfully", resp, http.StatusOK))
+}
+
+// GetExportJob
+//
+//	@Summary		Retrieve an export job
+//	@Description	This endpoint fetches a blob store export with its status and, once complete, where the file was written
+//	@Id				GetExportJob
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			exportID	path		string	true	"export job id"
+//	@Success		200			{object}	util.ServerResponse{data=models.ExportJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/exports/{exportID} [get]
+func (h *Handler) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	job, err := export_jobs.New(h.A.Logger, h.A.DB).FindExportJobByID(r.Context(), project.UID, chi.URLParam(r, "exportID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrExportJobNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to find export job", http.StatusInternalServerError))
+		return
+	}
+
+	resp := &models.ExportJobResponse{ExportJob: job}
+	_ = render.Render(w, r, util.NewServerResponse("Export job fetched successfully", resp, http.StatusOK))
+}
+
+// resolveExport reads an export request and resolves it against its saved
+// search. It renders the error response itself when it reports false.
+func (h *Handler) resolveExport(w http.ResponseWriter, r *http.Request) (*datastore.Project, *datastore.ExportJob, bool) {
+	var req models.CreateExport
+	err := util.ReadJSON(r, &req)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return nil, nil, false
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return nil, nil, false
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return nil, nil, false
+	}
+
+	rs := services.ResolveExportService{
+		SavedSearchRepo: saved_searches.New(h.A.Logger, h.A.DB),
+		ProjectID:       project.UID,
+		Job:             req.Transform(),
+	}
+
+	job, err := rs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return nil, nil, false
+	}
+
+	// Exports search events the same way the events list does, so they need
+	// the same entitlement and accept the same query forms.
+	filter := job.Filter.EventFilter(project, "", 0)
+	if err = events.ApplyEventListSearch(filter, project, h.A.Licenser.EventSearch(), time.Now()); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, events.ErrSearchUnlicensed) {
+			status = http.StatusForbidden
+		}
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), status))
+		return nil, nil, false
+	}
+	job.Filter.Query, job.Filter.Body = filter.Query, filter.Body
+
+	return project, job, true
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/internal/filter_rejections"
 	"github.com/frain-dev/convoy/internal/filters"
 	"github.com/frain-dev/convoy/util"
 )
@@ -111,10 +112,16 @@ func (h *Handler) CreateFilter(w http.ResponseWriter, r *http.Request) {
 		RawBody:        models.CloneFilterMap(newFilter.Body),
 		RawQuery:       models.CloneFilterMap(newFilter.Query),
 		RawPath:        models.CloneFilterMap(newFilter.Path),
+		Expression:     newFilter.Expression,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
 
+	if err = filter.ValidateExpression(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
 	err = h.filterWriteRepo().CreateFilter(r.Context(), filter)
 	if err != nil {
 		_ = render.Render(w, r, util.NewErrorResponse("failed to create filter", http.StatusBadRequest))
@@ -246,6 +253,7 @@ func (h *Handler) GetFilters(w http.ResponseWriter, r *http.Request) {
 			RawBody:        filter.RawBody,
 			RawQuery:       filter.RawQuery,
 			RawPath:        filter.RawPath,
+			Expression:     filter.Expression,
 			CreatedAt:      filter.CreatedAt,
 			UpdatedAt:      filter.UpdatedAt,
 		})
@@ -379,6 +387,13 @@ func (h *Handler) UpdateFilter(w http.ResponseWriter, r *http.Request) {
 		filter.RawPath = models.CloneFilterMap(updateFilter.Path)
 	}
 
+	setsCriteria := updateFilter.Headers != nil || updateFilter.Body != nil || updateFilter.Query != nil || updateFilter.Path != nil
+	applyFilterExpression(filter, updateFilter.Expression, setsCriteria)
+	if err = filter.ValidateExpression(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
 	if updateFilter.EnabledAt.Set {
 		filter.EnabledAt = updateFilter.EnabledAt.Time
 		filter.EnabledAtSet = true
@@ -518,6 +533,159 @@ func (h *Handler) TestFilter(w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, util.NewServerResponse("Filter test completed", resp, http.StatusOK))
 }
 
+// ExplainFilter
+//
+//	@Summary		Explain a filter
+//	@Description	This endpoint reports which of a subscription's filters applies to an event type, and whether each of its conditions matched a payload
+//	@Id				ExplainFilter
+//	@Tags			Filters
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string						true	"Project ID"
+//	@Param			subscriptionID	path		string						true	"Subscription ID"
+//	@Param			eventType		path		string						true	"Event Type"
+//	@Param			payload			body		models.TestFilterRequest	true	"Payload to test"
+//	@Success		200				{object}	util.ServerResponse{data=models.FilterExplanationResponse}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/subscriptions/{subscriptionID}/filters/explain/{eventType} [post]
+func (h *Handler) ExplainFilter(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "subscriptionID")
+	eventType := chi.URLParam(r, "eventType")
+
+	var testPayload models.TestFilterRequest
+	if err := util.ReadJSON(r, &testPayload); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	subscription, err := h.subscriptionRepo().FindSubscriptionByID(r.Context(), project.UID, subscriptionID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSubscriptionNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse("subscription not found", http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to find subscription", http.StatusNotFound))
+		return
+	}
+
+	filterRepo := filters.New(h.A.Logger, h.A.DB)
+	explanation, err := filterRepo.ExplainFilter(r.Context(), subscription.UID, eventType, testPayload.Transform())
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to explain filter", http.StatusBadRequest))
+		return
+	}
+	explanation.SubscriptionName = subscription.Name
+
+	resp := models.FilterExplanationResponse{FilterExplanation: explanation}
+
+	_ = render.Render(w, r, util.NewServerResponse("Filter explained", resp, http.StatusOK))
+}
+
+// GetEventFilterRejections
+//
+//	@Summary		List an event's filter rejections
+//	@Description	This endpoint lists the subscriptions whose filters rejected an event and the condition that rejected it. Rejections are only recorded for projects with record_filter_rejections enabled, and are kept for seven days.
+//	@Id				GetEventFilterRejections
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			eventID		path		string	true	"event id"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.FilterRejectionResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/events/{eventID}/filter-rejections [get]
+func (h *Handler) GetEventFilterRejections(w http.ResponseWriter, r *http.Request) {
+	event, err := h.retrieveEvent(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	rejections, err := filter_rejections.New(h.A.Logger, h.A.DB).LoadFilterRejectionsByEventID(r.Context(), event.ProjectID, event.UID)
+	if err != nil {
+		h.A.Logger.ErrorContext(r.Context(), "failed to load filter rejections", "error", err)
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load filter rejections", http.StatusBadRequest))
+		return
+	}
+
+	resp := models.NewListResponse(rejections, func(rejection datastore.FilterRejection) models.FilterRejectionResponse {
+		return models.FilterRejectionResponse{FilterRejection: &rejection}
+	})
+
+	_ = render.Render(w, r, util.NewServerResponse("Filter rejections fetched successfully", resp, http.StatusOK))
+}
+
+// ExplainSubscriptionFilters
+//
+//	@Summary		Explain filters for an event
+//	@Description	This endpoint reports, for each subscription in a project, which filter applies to an event type and whether each of its conditions matched a payload. Use it to find out why an event was not delivered to a subscription.
+//	@Id				ExplainSubscriptionFilters
+//	@Tags			Filters
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string							true	"Project ID"
+//	@Param			request		body		models.ExplainFiltersRequest	true	"Event type and payload to test"
+//	@Param			request		query		models.QueryListSubscription	false	"Query Params"
+//	@Success		200			{object}	util.ServerResponse{data=models.PagedResponse{content=[]models.FilterExplanationResponse}}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/subscriptions/filters/explain [post]
+func (h *Handler) ExplainSubscriptionFilters(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	var req models.ExplainFiltersRequest
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := util.Validate(req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	var q *models.QueryListSubscription
+	data := q.Transform(r)
+
+	subs, paginationData, err := h.subscriptionRepo().LoadSubscriptionsPaged(r.Context(), project.UID, data.FilterBy, data.Pageable)
+	if err != nil {
+		h.A.Logger.ErrorContext(r.Context(), "an error occurred while fetching subscriptions", "error", err)
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching subscriptions", http.StatusInternalServerError))
+		return
+	}
+
+	filterRepo := filters.New(h.A.Logger, h.A.DB)
+	payload := req.Transform()
+
+	resp := make([]models.FilterExplanationResponse, 0, len(subs))
+	for i := range subs {
+		explanation, err := filterRepo.ExplainFilter(r.Context(), subs[i].UID, req.EventType, payload)
+		if err != nil {
+			h.A.Logger.ErrorContext(r.Context(), "failed to explain filter", "error", err, "subscription.id", subs[i].UID)
+			_ = render.Render(w, r, util.NewErrorResponse("failed to explain filters", http.StatusInternalServerError))
+			return
+		}
+		explanation.SubscriptionName = subs[i].Name
+
+		resp = append(resp, models.FilterExplanationResponse{FilterExplanation: explanation})
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Filters explained",
+		models.PagedResponse{Content: &resp, Pagination: &paginationData}, http.StatusOK))
+}
+
 // BulkCreateFilters
 //
 //	@Summary		Create multiple subscription filters
@@ -630,9 +798,15 @@ func (h *Handler) BulkCreateFilters(w http.ResponseWriter, r *http.Request) {
 			RawBody:        models.CloneFilterMap(filter.Body),
 			RawQuery:       models.CloneFilterMap(filter.Query),
 			RawPath:        models.CloneFilterMap(filter.Path),
+			Expression:     filter.Expression,
 			CreatedAt:      now,
 			UpdatedAt:      now,
 		})
+
+		if err = filtersToCreate[len(filtersToCreate)-1].ValidateExpression(); err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(fmt.Sprintf("Invalid filter for event type %s: %s", filter.EventType, err.Error()), http.StatusBadRequest))
+			return
+		}
 	}
 
 	// Create filters in a transaction
@@ -799,6 +973,13 @@ func (h *Handler) BulkUpdateFilters(w http.ResponseWriter, r *http.Request) {
 			existingFilter.RawPath = models.CloneFilterMap(filterUpdate.Path)
 		}
 
+		setsCriteria := filterUpdate.Headers != nil || filterUpdate.Body != nil || filterUpdate.Query != nil || filterUpdate.Path != nil
+		applyFilterExpression(&existingFilter, filterUpdate.Expression, setsCriteria)
+		if err = existingFilter.ValidateExpression(); err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(fmt.Sprintf("Invalid filter %s: %s", filterUpdate.UID, err.Error()), http.StatusBadRequest))
+			return
+		}
+
 		if filterUpdate.EnabledAt.Set {
 			existingFilter.EnabledAt = filterUpdate.EnabledAt.Time
 			existingFilter.EnabledAtSet = true
@@ -832,3 +1013,24 @@ func enabledAtOrDefault(enabledAt models.OptionalTime) *time.Time {
 	now := time.Now()
 	return &now
 }
+
+// applyFilterExpression switches a filter between criteria and an expression.
+// Setting a non-empty expression drops the criteria the update did not also
+// set, and setting criteria without an expression drops the stored one, so an
+// update never has to clear the other kind explicitly.
+func applyFilterExpression(filter *datastore.EventTypeFilter, expression *string, setsCriteria bool) {
+	if expression == nil {
+		if setsCriteria {
+			filter.Expression = ""
+		}
+		return
+	}
+
+	filter.Expression = *expression
+	if filter.Expression == "" || setsCriteria {
+		return
+	}
+
+	filter.Headers, filter.Body, filter.Query, filter.Path = datastore.M{}, datastore.M{}, datastore.M{}, datastore.M{}
+	filter.RawHeaders, filter.RawBody, filter.RawQuery, filter.RawPath = datastore.M{}, datastore.M{}, datastore.M{}, datastore.M{}
+}
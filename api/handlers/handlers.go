@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -175,7 +176,19 @@ func (h *Handler) IsReqWithProjectAPIKey(authUser *auth.AuthenticatedUser) bool
 	keyIsAPIKey := authUser.Credential.Type == auth.CredentialTypeAPIKey
 	userIsNil := authUser.User == nil
 
-	return keyIsAPIKey && userIsNil
+	return keyIsAPIKey && userIsNil && !h.IsReqWithCLIKey(authUser)
+}
+
+// IsReqWithCLIKey reports whether the request carries a convoy listen key.
+// Those keys only open listen sessions for their endpoint, so they are not
+// project API keys.
+func (h *Handler) IsReqWithCLIKey(authUser *auth.AuthenticatedUser) bool {
+	if authUser.Credential.Type != auth.CredentialTypeAPIKey {
+		return false
+	}
+
+	apiKey, ok := authUser.APIKey.(*datastore.APIKey)
+	return ok && apiKey.Type == datastore.CLIKey
 }
 
 func (h *Handler) IsReqWithPersonalAccessToken(authUser *auth.AuthenticatedUser) bool {
@@ -579,6 +592,61 @@ func (h *Handler) CanManageEndpoint() func(next http.Handler) http.Handler {
 	}
 }
 
+// RequirePortalLinkPermission guards a portal route with one of the portal
+// link's permissions.
+func (h *Handler) RequirePortalLinkPermission(permission datastore.PortalLinkPermission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h.rejectPortalLinkWithout(w, r, permission) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rejectPortalLinkWithout writes a 403 and returns true when the request
+// uses a portal link token whose link does not hold permission. Other
+// callers are left to their own checks.
+func (h *Handler) rejectPortalLinkWithout(w http.ResponseWriter, r *http.Request, permission datastore.PortalLinkPermission) bool {
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if authUser == nil || !h.IsReqWithPortalLinkToken(authUser) {
+		return false
+	}
+
+	portalLink, err := h.retrievePortalLinkFromToken(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return true
+	}
+
+	if !portalLink.HasPermission(permission) {
+		_ = render.Render(w, r, util.NewErrorResponse(fmt.Sprintf("portal link is missing the %s permission", permission), http.StatusForbidden))
+		return true
+	}
+
+	return false
+}
+
+// canViewPayloads reports whether the caller may see event bodies and
+// endpoint response bodies. Portal links need the view_payloads permission.
+// Fails closed: an unknown/nil caller, or a portal link that cannot be
+// loaded, gets payloads hidden.
+func (h *Handler) canViewPayloads(r *http.Request) bool {
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if authUser == nil {
+		return false
+	}
+
+	if !h.IsReqWithPortalLinkToken(authUser) {
+		return true
+	}
+
+	portalLink, err := h.retrievePortalLinkFromToken(r)
+	return err == nil && portalLink.HasPermission(datastore.PortalLinkViewPayloads)
+}
+
 func (h *Handler) isOrganisationDisabled(org *datastore.Organisation) bool {
 	return org.DisabledAt.Valid && org.DisabledAt.Time.After(time.Unix(0, 0))
 }
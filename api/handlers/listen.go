@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/api_keys"
+	"github.com/frain-dev/convoy/internal/delivery_attempts"
+	endpointsvc "github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/event_deliveries"
+	"github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/internal/sources"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+const (
+	// listenPollInterval is how often a listen session checks for deliveries.
+	listenPollInterval = time.Second
+	// listenHeartbeatInterval keeps idle streams from being cut by proxies.
+	listenHeartbeatInterval = 15 * time.Second
+	// listenBatchSize caps the deliveries claimed per poll.
+	listenBatchSize = 50
+	// defaultCLIKeyExpiration is in days.
+	defaultCLIKeyExpiration = 30
+)
+
+// CreateEndpointCLIKey
+//
+//	@Summary		Create a CLI key
+//	@Description	This endpoint creates a key convoy listen uses to stream an endpoint's deliveries to a local server. The key cannot call any other API
+//	@Id				CreateEndpointCLIKey
+//	@Tags			Endpoints
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string				true	"Project ID"
+//	@Param			endpointID	path		string				true	"Endpoint ID"
+//	@Param			key			body		models.CreateCLIKey	true	"Key Details"
+//	@Success		201			{object}	util.ServerResponse{data=models.CLIKeyResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/endpoints/{endpointID}/cli-keys [post]
+func (h *Handler) CreateEndpointCLIKey(w http.ResponseWriter, r *http.Request) {
+	if h.rejectPortalLinkToken(w, r) {
+		return
+	}
+
+	var newKey models.CreateCLIKey
+	err := util.ReadJSON(r, &newKey)
+	if err != nil && !errors.Is(err, util.ErrEmptyBody) {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = newKey.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	// A CLI key streams the endpoint's deliveries, payloads included.
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	endpoint, err := endpointsvc.New(h.A.Logger, h.A.DB).FindEndpointByID(r.Context(), chi.URLParam(r, "endpointID"), project.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to fetch endpoint", http.StatusBadRequest))
+		return
+	}
+
+	if newKey.Expiration == 0 {
+		newKey.Expiration = defaultCLIKeyExpiration
+	}
+
+	if util.IsStringEmpty(newKey.Name) {
+		newKey.Name = fmt.Sprintf("%s cli key", endpoint.Name)
+	}
+
+	ck := services.CreateEndpointAPIKeyService{
+		APIKeyRepo: api_keys.New(h.A.Logger, h.A.DB),
+		Logger:     h.A.Logger,
+		D: &models.CreateEndpointApiKey{
+			Project:    project,
+			Endpoint:   endpoint,
+			Name:       newKey.Name,
+			KeyType:    datastore.CLIKey,
+			Expiration: newKey.Expiration,
+		},
+	}
+
+	apiKey, key, err := ck.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := models.CLIKeyResponse{
+		UID:        apiKey.UID,
+		Name:       apiKey.Name,
+		ProjectID:  project.UID,
+		EndpointID: endpoint.UID,
+		Key:        key,
+		ExpiresAt:  apiKey.ExpiresAt.ValueOrZero(),
+		CreatedAt:  apiKey.CreatedAt,
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("CLI key created successfully", resp, http.StatusCreated))
+}
+
+// StreamCLIDeliveries opens a convoy listen session. It streams the deliveries
+// of a session scoped CLI subscription as server-sent events until the client
+// goes away, then removes the subscription. Each heartbeat extends the
+// subscription's expiry so the reaper can tell live sessions from ones whose
+// server died. Only CLI keys may open a session.
+func (h *Handler) StreamCLIDeliveries(w http.ResponseWriter, r *http.Request) {
+	project, endpoint, ok := h.resolveListenSession(w, r)
+	if !ok {
+		return
+	}
+
+	var eventTypes []string
+	for _, v := range r.URL.Query()["event_types"] {
+		for _, eventType := range strings.Split(v, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+
+	subRepo := h.subscriptionRepo()
+	ls := services.ListenSessionService{
+		SubRepo:    subRepo,
+		SourceRepo: sources.New(h.A.Logger, h.A.DB),
+		Project:    project,
+		Endpoint:   endpoint,
+		SourceID:   r.URL.Query().Get("source_id"),
+		EventTypes: eventTypes,
+		Logger:     h.A.Logger,
+	}
+
+	subscription, err := ls.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	// Deliveries not acknowledged when the session ends are discarded with the
+	// subscription, a session that dies without getting here is reaped once
+	// its heartbeats stop.
+	edRepo := event_deliveries.New(h.A.Logger, h.A.DB)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 10*time.Second)
+		defer cancel()
+
+		cs := services.CloseListenSessionService{
+			SubRepo:           subRepo,
+			EventDeliveryRepo: edRepo,
+			Subscription:      subscription,
+			Reason:            "convoy listen session ended",
+			Logger:            h.A.Logger,
+		}
+		_ = cs.Run(ctx)
+	}()
+
+	// A session lasts as long as the developer keeps it open, so the server's
+	// write timeout does not apply.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	ready := map[string]string{"subscription_id": subscription.UID, "project_id": project.UID, "endpoint_id": endpoint.UID}
+	if err := writeServerSentEvent(w, rc, "ready", "", ready); err != nil {
+		return
+	}
+
+	poll := time.NewTicker(listenPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(listenHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+
+			err := subRepo.ExtendCLISubscription(r.Context(), project.UID, subscription.UID, time.Now().Add(services.ListenSessionTTL))
+			if err != nil && r.Context().Err() == nil {
+				// Reaped after a stall longer than the TTL, the client has
+				// to open a new session.
+				if errors.Is(err, datastore.ErrSubscriptionNotFound) {
+					return
+				}
+				h.A.Logger.ErrorContext(r.Context(), "failed to extend cli subscription", "subscription_id", subscription.UID, "error", err)
+			}
+		case <-poll.C:
+			deliveries, err := edRepo.ClaimScheduledEventDeliveries(r.Context(), project.UID, subscription.UID, listenBatchSize)
+			if err != nil {
+				if r.Context().Err() == nil {
+					h.A.Logger.ErrorContext(r.Context(), "failed to claim cli deliveries", "subscription_id", subscription.UID, "error", err)
+				}
+				continue
+			}
+
+			for i := range deliveries {
+				if err := writeServerSentEvent(w, rc, "delivery", deliveries[i].UID, models.NewCLIDelivery(&deliveries[i])); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AckCLIDelivery records the local server's response to a delivery streamed
+// to a convoy listen session as a delivery attempt.
+func (h *Handler) AckCLIDelivery(w http.ResponseWriter, r *http.Request) {
+	project, endpoint, ok := h.resolveListenSession(w, r)
+	if !ok {
+		return
+	}
+
+	var ack models.CLIDeliveryAck
+	err := util.ReadJSON(r, &ack)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	as := services.AckCLIDeliveryService{
+		EventDeliveryRepo: event_deliveries.New(h.A.Logger, h.A.DB),
+		AttemptsRepo:      delivery_attempts.New(h.A.Logger, h.A.DB),
+		Project:           project,
+		EndpointID:        endpoint.UID,
+		EventDeliveryID:   chi.URLParam(r, "eventDeliveryID"),
+		Ack:               &ack,
+		Logger:            h.A.Logger,
+	}
+
+	delivery, err := as.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := models.NewEventDeliveryResponse(delivery, false)
+	_ = render.Render(w, r, util.NewServerResponse("Event delivery acknowledged", resp, http.StatusOK))
+}
+
+// resolveListenSession loads the project and endpoint a CLI key is scoped to.
+// A project_id query parameter, when given, must name the key's project.
+func (h *Handler) resolveListenSession(w http.ResponseWriter, r *http.Request) (*datastore.Project, *datastore.Endpoint, bool) {
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if authUser == nil || !h.IsReqWithCLIKey(authUser) {
+		_ = render.Render(w, r, util.NewErrorResponse("a cli key is required", http.StatusUnauthorized))
+		return nil, nil, false
+	}
+
+	apiKey := authUser.APIKey.(*datastore.APIKey)
+	if projectID := r.URL.Query().Get("project_id"); projectID != "" && projectID != apiKey.Role.Project {
+		_ = render.Render(w, r, util.NewErrorResponse("cli key does not belong to this project", http.StatusForbidden))
+		return nil, nil, false
+	}
+
+	project, err := h.projectRepo().FetchProjectByID(r.Context(), apiKey.Role.Project)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to fetch project", http.StatusBadRequest))
+		return nil, nil, false
+	}
+
+	endpoint, err := endpointsvc.New(h.A.Logger, h.A.DB).FindEndpointByID(r.Context(), apiKey.Role.Endpoint, project.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return nil, nil, false
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to fetch endpoint", http.StatusBadRequest))
+		return nil, nil, false
+	}
+
+	return project, endpoint, true
+}
+
+func writeServerSentEvent(w io.Writer, rc *http.ResponseController, event, id string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event, payload)
+
+	if _, err = io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
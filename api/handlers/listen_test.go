@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/types"
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func withAPIKeyAuth(req *http.Request, keyType datastore.KeyType) *http.Request {
+	authUser := &auth.AuthenticatedUser{
+		Credential: auth.Credential{Type: auth.CredentialTypeAPIKey},
+		APIKey:     &datastore.APIKey{Type: keyType, Role: auth.Role{Type: auth.RoleProjectAdmin, Project: "project-1", Endpoint: "endpoint-1"}},
+	}
+	return req.WithContext(context.WithValue(req.Context(), convoy.AuthUserCtx, authUser))
+}
+
+// TestIsReqWithCLIKey verifies CLI keys are kept apart from project API keys,
+// so they cannot reach the project API.
+func TestIsReqWithCLIKey(t *testing.T) {
+	handler := &Handler{A: &types.APIOptions{}}
+
+	cliUser := &auth.AuthenticatedUser{
+		Credential: auth.Credential{Type: auth.CredentialTypeAPIKey},
+		APIKey:     &datastore.APIKey{Type: datastore.CLIKey},
+	}
+	require.True(t, handler.IsReqWithCLIKey(cliUser))
+	require.False(t, handler.IsReqWithProjectAPIKey(cliUser))
+
+	projectUser := &auth.AuthenticatedUser{
+		Credential: auth.Credential{Type: auth.CredentialTypeAPIKey},
+		APIKey:     &datastore.APIKey{Type: datastore.ProjectKey},
+	}
+	require.False(t, handler.IsReqWithCLIKey(projectUser))
+	require.True(t, handler.IsReqWithProjectAPIKey(projectUser))
+
+	jwtUser := &auth.AuthenticatedUser{Credential: auth.Credential{Type: auth.CredentialTypeJWT}}
+	require.False(t, handler.IsReqWithCLIKey(jwtUser))
+}
+
+// TestListenSession_RequiresCLIKey verifies only CLI keys open listen sessions
+// or acknowledge deliveries, and only for their own project. The checks run
+// before any datastore access.
+func TestListenSession_RequiresCLIKey(t *testing.T) {
+	handler := &Handler{A: &types.APIOptions{}}
+
+	endpoints := []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+	}{
+		{"stream", handler.StreamCLIDeliveries, http.MethodGet},
+		{"ack", handler.AckCLIDelivery, http.MethodPost},
+	}
+
+	for _, e := range endpoints {
+		t.Run(e.name+"/project key", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			e.handler(w, withAPIKeyAuth(httptest.NewRequest(e.method, "/listen", nil), datastore.ProjectKey))
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+
+		t.Run(e.name+"/portal token", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			e.handler(w, withPortalTokenAuth(httptest.NewRequest(e.method, "/listen", nil)))
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+
+		t.Run(e.name+"/other project", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			e.handler(w, withAPIKeyAuth(httptest.NewRequest(e.method, "/listen?project_id=project-2", nil), datastore.CLIKey))
+			require.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
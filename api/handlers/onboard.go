@@ -172,8 +172,9 @@ func (h *Handler) BulkOnboard(w http.ResponseWriter, r *http.Request) {
 
 		jobs = append(jobs, batchJob{
 			job: &queue.Job{
-				ID:      queue.JobId{ProjectID: project.UID, ResourceID: batchID}.OnboardJobId(),
-				Payload: payload,
+				ID:        queue.JobId{ProjectID: project.UID, ResourceID: batchID}.OnboardJobId(),
+				Payload:   payload,
+				ProjectID: project.UID,
 			},
 		})
 	}
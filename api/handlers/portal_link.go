@@ -399,6 +399,8 @@ func portalLinkResponse(pl *datastore.PortalLink, baseUrl string) datastore.Port
 		EndpointCount:     pl.EndpointCount,
 		EndpointsMetadata: pl.EndpointsMetadata,
 		CanManageEndpoint: pl.CanManageEndpoint,
+		EventTypes:        pl.EventTypes,
+		Permissions:       pl.EffectivePermissions(),
 		CreatedAt:         pl.CreatedAt,
 		UpdatedAt:         pl.UpdatedAt,
 		AuthType:          pl.AuthType,
@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/api/types"
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
 )
 
 func TestFilterAllowedEndpointIDs(t *testing.T) {
@@ -29,3 +38,58 @@ func TestFilterAllowedEndpointIDs(t *testing.T) {
 		require.Empty(t, filterAllowedEndpointIDs([]string{"ep-a"}, []string{}))
 	})
 }
+
+// TestRejectPortalLinkFilterChange verifies subscription create and update
+// only let portal links with manage_filters change a subscription's filter.
+func TestRejectPortalLinkFilterChange(t *testing.T) {
+	handler := &Handler{A: &types.APIOptions{}}
+
+	withPortalLink := func(permissions ...datastore.PortalLinkPermission) *http.Request {
+		authUser := &auth.AuthenticatedUser{
+			Credential: auth.Credential{Type: auth.CredentialTypeToken},
+			PortalLink: &datastore.PortalLink{Permissions: permissions},
+		}
+		req := httptest.NewRequest(http.MethodPut, "/subscriptions/sub-1", nil)
+		return req.WithContext(context.WithValue(req.Context(), convoy.AuthUserCtx, authUser))
+	}
+
+	// A link with no permissions takes the defaults, which include
+	// manage_filters; these links were created with view_payloads alone.
+	viewOnly := datastore.PortalLinkViewPayloads
+
+	bodyFilter := func(amount int) *models.FilterConfiguration {
+		return &models.FilterConfiguration{
+			EventTypes: []string{"invoice.paid"},
+			Filter:     models.FS{Body: datastore.M{"amount": amount}},
+		}
+	}
+	stored := bodyFilter(100).Transform()
+
+	cases := []struct {
+		name        string
+		req         *http.Request
+		requested   *models.FilterConfiguration
+		current     *datastore.FilterConfiguration
+		wantBlocked bool
+	}{
+		{"no filter config", withPortalLink(viewOnly), nil, nil, false},
+		{"event types only", withPortalLink(viewOnly), &models.FilterConfiguration{EventTypes: []string{"invoice.paid"}}, nil, false},
+		{"new filter on create", withPortalLink(viewOnly), bodyFilter(100), nil, true},
+		{"expression on create", withPortalLink(viewOnly), &models.FilterConfiguration{Filter: models.FS{Expression: "body.amount > 1"}}, nil, true},
+		{"stored filter sent back", withPortalLink(viewOnly), bodyFilter(100), stored, false},
+		{"changed filter", withPortalLink(viewOnly), bodyFilter(200), stored, true},
+		{"cleared filter", withPortalLink(viewOnly), &models.FilterConfiguration{EventTypes: []string{"invoice.paid"}}, stored, true},
+		{"link with manage_filters", withPortalLink(datastore.PortalLinkManageFilters), bodyFilter(200), stored, false},
+		{"project api key", withAPIKeyAuth(httptest.NewRequest(http.MethodPut, "/subscriptions/sub-1", nil), datastore.ProjectKey), bodyFilter(200), stored, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			require.Equal(t, tc.wantBlocked, handler.rejectPortalLinkFilterChange(w, tc.req, tc.requested, tc.current))
+			if tc.wantBlocked {
+				require.Equal(t, http.StatusForbidden, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/internal/pkg/project_spec"
+	"github.com/frain-dev/convoy/internal/sources"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// ExportProjectSpec
+//
+//	@Summary		Export the project's configuration
+//	@Description	This endpoint exports the project's endpoints, subscriptions, sources, event types, portal links and config as a document that can be applied. Secrets are exported as references without their values
+//	@Id				ExportProjectSpec
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json,application/yaml
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			format		query		string	false	"Document format"	Enums(json, yaml)
+//	@Success		200			{object}	map[string]interface{}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/spec [get]
+func (h *Handler) ExportProjectSpec(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = project_spec.FormatJSON
+	}
+	if format != project_spec.FormatJSON && format != project_spec.FormatYAML {
+		_ = render.Render(w, r, util.NewErrorResponse("format must be one of json, yaml", http.StatusBadRequest))
+		return
+	}
+
+	doc, _, err := h.projectSpecService(project).Export(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	// The document is served as is, like the event type catalog, so it can
+	// be committed and applied without unwrapping it.
+	b, err := project_spec.Encode(doc, format)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to encode project spec", http.StatusInternalServerError))
+		return
+	}
+
+	contentType := "application/json"
+	if format == project_spec.FormatYAML {
+		contentType = "application/yaml"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
+
+// PlanProjectSpec
+//
+//	@Summary		Plan a project configuration change
+//	@Description	This endpoint lists the changes applying a document to the project would make, without making them
+//	@Id				PlanProjectSpec
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			spec		body		models.ApplyProjectSpec		true	"Project Spec"
+//	@Success		200			{object}	util.ServerResponse{data=models.ProjectSpecPlanResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/spec/plan [post]
+func (h *Handler) PlanProjectSpec(w http.ResponseWriter, r *http.Request) {
+	h.applyProjectSpec(w, r, false)
+}
+
+// ApplyProjectSpec
+//
+//	@Summary		Apply a project configuration
+//	@Description	This endpoint makes the project match a document, creating, updating and, with prune, deleting what differs
+//	@Id				ApplyProjectSpec
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			spec		body		models.ApplyProjectSpec		true	"Project Spec"
+//	@Success		200			{object}	util.ServerResponse{data=models.ProjectSpecPlanResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/spec/apply [post]
+func (h *Handler) ApplyProjectSpec(w http.ResponseWriter, r *http.Request) {
+	h.applyProjectSpec(w, r, true)
+}
+
+func (h *Handler) applyProjectSpec(w http.ResponseWriter, r *http.Request, apply bool) {
+	authUser := middleware.GetAuthUserFromContext(r.Context())
+	if h.IsReqWithPortalLinkToken(authUser) {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return
+	}
+
+	var req models.ApplyProjectSpec
+	if err := util.ReadJSON(r, &req); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	doc, err := req.Document()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	// Secrets are only taken from the request, never from the server's
+	// environment.
+	svc := h.projectSpecService(project)
+	resolver := project_spec.SecretMap(req.Secrets)
+
+	var plan *project_spec.Plan
+	if apply {
+		plan, err = svc.Apply(r.Context(), doc, resolver, req.Prune)
+	} else {
+		plan, err = svc.Plan(r.Context(), doc, resolver, req.Prune)
+	}
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	msg := "Project spec planned successfully"
+	if apply {
+		msg = "Project spec applied successfully"
+	}
+
+	resp := &models.ProjectSpecPlanResponse{Plan: plan, Applied: apply}
+	_ = render.Render(w, r, util.NewServerResponse(msg, resp, http.StatusOK))
+}
+
+func (h *Handler) projectSpecService(project *datastore.Project) *services.ProjectSpecService {
+	return &services.ProjectSpecService{
+		ProjectRepo:    h.projectRepo(),
+		EndpointRepo:   h.endpointWriteRepo(),
+		SourceRepo:     sources.New(h.A.Logger, h.A.DB),
+		SubRepo:        h.subscriptionRepo(),
+		FilterRepo:     h.filterWriteRepo(),
+		EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+		PortalLinkRepo: h.portalLinkRepo(),
+		Licenser:       h.A.Licenser,
+		Logger:         h.A.Logger,
+		Project:        project,
+	}
+}
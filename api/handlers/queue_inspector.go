@@ -109,6 +109,27 @@ func (h *Handler) GetQueueTasks(w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, util.NewServerResponse("queue tasks fetched successfully", tasks, http.StatusOK))
 }
 
+// GetQueueTenantBacklog returns one queue's work broken down by tenant.
+func (h *Handler) GetQueueTenantBacklog(w http.ResponseWriter, r *http.Request) {
+	inspector, ok := h.queueInspector(w, r)
+	if !ok {
+		return
+	}
+
+	queueName, ok := queueNameParam(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := inspector.TenantBacklog(r.Context(), queueName)
+	if err != nil {
+		h.failQueueRequest(w, r, "failed to load queue tenant backlog", err)
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("queue tenant backlog fetched successfully", report, http.StatusOK))
+}
+
 // RetryQueueTask returns one archived task to the queue.
 func (h *Handler) RetryQueueTask(w http.ResponseWriter, r *http.Request) {
 	h.runQueueTaskAction(w, r, queue.ActionRetry, "queue task retried")
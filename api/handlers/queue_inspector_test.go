@@ -29,6 +29,7 @@ type fakeInspector struct {
 	history []queue.HistoryPoint
 	entries []queue.SchedulerEntry
 	bulk    queue.BulkResult
+	tenants queue.TenantBacklogReport
 
 	filter    queue.TaskFilter
 	days      int
@@ -41,6 +42,7 @@ type fakeInspector struct {
 	pausedQ   string
 	resumedQ  string
 	historyOf string
+	tenantsOf string
 }
 
 func (f *fakeInspector) Stats(context.Context) (queue.Stats, error) {
@@ -97,6 +99,11 @@ func (f *fakeInspector) UnpauseQueue(_ context.Context, queueName string) error
 	return f.err
 }
 
+func (f *fakeInspector) TenantBacklog(_ context.Context, queueName string) (queue.TenantBacklogReport, error) {
+	f.tenantsOf = queueName
+	return f.tenants, f.err
+}
+
 func queueRequest(target string, params map[string]string, user *datastore.User) *http.Request {
 	req := httptest.NewRequest(http.MethodPost, target, nil)
 
@@ -181,6 +188,7 @@ func TestQueueEndpointsRequireInstanceAdmin(t *testing.T) {
 		"history":   func(h *Handler) http.HandlerFunc { return h.GetQueueHistory },
 		"scheduler": func(h *Handler) http.HandlerFunc { return h.GetQueueSchedulerEntries },
 		"tasks":     func(h *Handler) http.HandlerFunc { return h.GetQueueTasks },
+		"tenants":   func(h *Handler) http.HandlerFunc { return h.GetQueueTenantBacklog },
 		"retry":     func(h *Handler) http.HandlerFunc { return h.RetryQueueTask },
 		"run":       func(h *Handler) http.HandlerFunc { return h.RunQueueTask },
 		"archive":   func(h *Handler) http.HandlerFunc { return h.ArchiveQueueTask },
@@ -209,6 +217,7 @@ func TestQueueEndpointsRequireInstanceAdmin(t *testing.T) {
 				require.Empty(t, inspector.resumedQ, "the action must not run for an unauthorized caller")
 				require.Empty(t, inspector.filter.Queue, "the read must not run for an unauthorized caller")
 				require.Empty(t, inspector.historyOf, "the read must not run for an unauthorized caller")
+				require.Empty(t, inspector.tenantsOf, "the read must not run for an unauthorized caller")
 			})
 		}
 	}
@@ -230,6 +239,26 @@ func TestGetQueueStats(t *testing.T) {
 	require.Contains(t, rec.Body.String(), `"pending":3`)
 }
 
+func TestGetQueueTenantBacklog(t *testing.T) {
+	inspector := &fakeInspector{tenants: queue.TenantBacklogReport{
+		Queue:     "EventQueue",
+		FairShare: true,
+		Tenants:   []queue.TenantBacklog{{Tenant: "project-1", Pending: 40, Processing: 2, Weight: 1}},
+	}}
+	h := newQueueHandler(t, inspector, adminOnce(1))
+
+	req := queueRequest("/ui/admin/queue/EventQueue/tenants",
+		map[string]string{"queueName": "EventQueue"}, &datastore.User{UID: "user-1"})
+
+	rec := httptest.NewRecorder()
+	h.GetQueueTenantBacklog(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "EventQueue", inspector.tenantsOf)
+	require.Contains(t, rec.Body.String(), `"tenant":"project-1"`)
+	require.Contains(t, rec.Body.String(), `"pending":40`)
+}
+
 func TestGetQueueTasksPassesFilter(t *testing.T) {
 	inspector := &fakeInspector{page: queue.TaskPage{Page: 2}}
 	h := newQueueHandler(t, inspector, adminOnce(1))
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/events"
+	"github.com/frain-dev/convoy/internal/replay_jobs"
+	"github.com/frain-dev/convoy/pkg/constants"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// CreateReplayJob
+//
+//	@Summary		Replay events in a time range
+//	@Description	This endpoint starts a background job that replays the events a filter selects, oldest first and no faster than its rate limit. Events are replayed to the subscriptions that match them today, to an existing endpoint, or to an endpoint created with the job
+//	@Id				CreateReplayJob
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string					true	"Project ID"
+//	@Param			replayJob	body		models.CreateReplayJob	true	"Replay job details"
+//	@Success		202			{object}	util.ServerResponse{data=models.ReplayJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/replay-jobs [post]
+func (h *Handler) CreateReplayJob(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateReplayJob
+	err := util.ReadJSON(r, &req)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if e := req.Target.Endpoint; e != nil && e.ContentType == "" {
+		e.ContentType = constants.ContentTypeJSON
+	}
+
+	err = req.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	job := req.Transform()
+
+	// Replay jobs search events the same way the events list does, so they
+	// need the same entitlement and accept the same query forms.
+	filter := job.Filter.EventFilter(project, "", 0)
+	if err = events.ApplyEventListSearch(filter, project, h.A.Licenser.EventSearch(), time.Now()); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, events.ErrSearchUnlicensed) {
+			status = http.StatusForbidden
+		}
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), status))
+		return
+	}
+	job.Filter.Query, job.Filter.Body = filter.Query, filter.Body
+
+	cr := services.CreateReplayJobService{
+		ReplayJobRepo: replay_jobs.New(h.A.Logger, h.A.DB),
+		EventRepo:     events.New(h.A.Logger, h.A.DB),
+		EndpointRepo:  h.endpointWriteRepo(),
+		Queue:         h.A.Queue,
+		Project:       project,
+		Job:           job,
+		Logger:        h.A.Logger,
+	}
+	if job.TargetType == datastore.ReplayTargetNewEndpoint {
+		cr.CreateEndpoint = func(ctx context.Context) (*datastore.Endpoint, error) {
+			ce := services.NewCreateEndpointService(
+				h.endpointWriteRepo(),
+				h.projectRepo(),
+				h.A.Licenser,
+				h.A.FFlag,
+				h.A.FeatureFlagFetcher,
+				h.A.EarlyAdopterFeatureFetcher,
+				h.A.DB,
+				h.A.Logger,
+				*req.Target.Endpoint,
+				project.UID,
+			)
+			return ce.Run(ctx)
+		}
+	}
+
+	job, err = cr.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.ReplayJobResponse{ReplayJob: job}
+	_ = render.Render(w, r, util.NewServerResponse("Replay job created successfully", resp, http.StatusAccepted))
+}
+
+// GetReplayJobs
+//
+//	@Summary		List replay jobs
+//	@Description	This endpoint fetches a project's most recent replay jobs with their progress
+//	@Id				GetReplayJobs
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.ReplayJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/replay-jobs [get]
+func (h *Handler) GetReplayJobs(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	jobs, err := replay_jobs.New(h.A.Logger, h.A.DB).LoadReplayJobs(r.Context(), project.UID, 0)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load replay jobs", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(jobs, func(job datastore.ReplayJob) models.ReplayJobResponse {
+		return models.ReplayJobResponse{ReplayJob: &job}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Replay jobs fetched successfully", resp, http.StatusOK))
+}
+
+// GetReplayJob
+//
+//	@Summary		Retrieve a replay job
+//	@Description	This endpoint fetches a replay job with its progress
+//	@Id				GetReplayJob
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			replayJobID	path		string	true	"replay job id"
+//	@Success		200			{object}	util.ServerResponse{data=models.ReplayJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/replay-jobs/{replayJobID} [get]
+func (h *Handler) GetReplayJob(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	job, err := replay_jobs.New(h.A.Logger, h.A.DB).FindReplayJobByID(r.Context(), project.UID, chi.URLParam(r, "replayJobID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrReplayJobNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to find replay job", http.StatusInternalServerError))
+		return
+	}
+
+	resp := &models.ReplayJobResponse{ReplayJob: job}
+	_ = render.Render(w, r, util.NewServerResponse("Replay job fetched successfully", resp, http.StatusOK))
+}
+
+// CancelReplayJob
+//
+//	@Summary		Cancel a replay job
+//	@Description	This endpoint cancels a pending or running replay job. A running job stops after the event it is on; deliveries it already created are kept
+//	@Id				CancelReplayJob
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Param			replayJobID	path		string	true	"replay job id"
+//	@Success		200			{object}	util.ServerResponse{data=models.ReplayJobResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/replay-jobs/{replayJobID}/cancel [post]
+func (h *Handler) CancelReplayJob(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	cs := services.CancelReplayJobService{
+		ReplayJobRepo: replay_jobs.New(h.A.Logger, h.A.DB),
+		ProjectID:     project.UID,
+		ReplayJobID:   chi.URLParam(r, "replayJobID"),
+		Logger:        h.A.Logger,
+	}
+
+	job, err := cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.ReplayJobResponse{ReplayJob: job}
+	_ = render.Render(w, r, util.NewServerResponse("Replay job cancelled successfully", resp, http.StatusOK))
+}
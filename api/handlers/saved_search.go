@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/saved_searches"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+)
+
+// GetSavedSearches
+//
+//	@Summary		List saved searches
+//	@Description	This endpoint fetches a project's saved events and event delivery searches
+//	@Id				GetSavedSearches
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string	true	"Project ID"
+//	@Success		200			{object}	util.ServerResponse{data=[]models.SavedSearchResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/saved-searches [get]
+func (h *Handler) GetSavedSearches(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	searches, err := saved_searches.New(h.A.Logger, h.A.DB).LoadSavedSearches(r.Context(), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to load saved searches", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(searches, func(search datastore.SavedSearch) models.SavedSearchResponse {
+		return models.SavedSearchResponse{SavedSearch: &search}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Saved searches fetched successfully", resp, http.StatusOK))
+}
+
+// CreateSavedSearch
+//
+//	@Summary		Save a search
+//	@Description	This endpoint saves a named events or event delivery search on the project
+//	@Id				CreateSavedSearch
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string						true	"Project ID"
+//	@Param			savedSearch	body		models.CreateSavedSearch	true	"Saved search details"
+//	@Success		201			{object}	util.ServerResponse{data=models.SavedSearchResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/saved-searches [post]
+func (h *Handler) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSavedSearch
+	err := util.ReadJSON(r, &req)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	cs := services.CreateSavedSearchService{
+		SavedSearchRepo: saved_searches.New(h.A.Logger, h.A.DB),
+		ProjectID:       project.UID,
+		Search:          req.Transform(),
+		Logger:          h.A.Logger,
+	}
+
+	search, err := cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.SavedSearchResponse{SavedSearch: search}
+	_ = render.Render(w, r, util.NewServerResponse("Saved search created successfully", resp, http.StatusCreated))
+}
+
+// GetSavedSearch
+//
+//	@Summary		Retrieve a saved search
+//	@Description	This endpoint fetches a saved search
+//	@Id				GetSavedSearch
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string	true	"Project ID"
+//	@Param			savedSearchID	path		string	true	"saved search id"
+//	@Success		200				{object}	util.ServerResponse{data=models.SavedSearchResponse}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/saved-searches/{savedSearchID} [get]
+func (h *Handler) GetSavedSearch(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	search, err := saved_searches.New(h.A.Logger, h.A.DB).FindSavedSearchByID(r.Context(), project.UID, chi.URLParam(r, "savedSearchID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrSavedSearchNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to find saved search", http.StatusInternalServerError))
+		return
+	}
+
+	resp := &models.SavedSearchResponse{SavedSearch: search}
+	_ = render.Render(w, r, util.NewServerResponse("Saved search fetched successfully", resp, http.StatusOK))
+}
+
+// UpdateSavedSearch
+//
+//	@Summary		Update a saved search
+//	@Description	This endpoint replaces a saved search's name, resource and filter
+//	@Id				UpdateSavedSearch
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string						true	"Project ID"
+//	@Param			savedSearchID	path		string						true	"saved search id"
+//	@Param			savedSearch		body		models.UpdateSavedSearch	true	"Saved search details"
+//	@Success		202				{object}	util.ServerResponse{data=models.SavedSearchResponse}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/saved-searches/{savedSearchID} [put]
+func (h *Handler) UpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateSavedSearch
+	err := util.ReadJSON(r, &req)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	us := services.UpdateSavedSearchService{
+		SavedSearchRepo: saved_searches.New(h.A.Logger, h.A.DB),
+		ProjectID:       project.UID,
+		SavedSearchID:   chi.URLParam(r, "savedSearchID"),
+		Update:          req.Transform(),
+		Logger:          h.A.Logger,
+	}
+
+	search, err := us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.SavedSearchResponse{SavedSearch: search}
+	_ = render.Render(w, r, util.NewServerResponse("Saved search updated successfully", resp, http.StatusAccepted))
+}
+
+// DeleteSavedSearch
+//
+//	@Summary		Delete a saved search
+//	@Description	This endpoint deletes a saved search. Exports already made from it are kept
+//	@Id				DeleteSavedSearch
+//	@Tags			Saved Searches
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID		path		string	true	"Project ID"
+//	@Param			savedSearchID	path		string	true	"saved search id"
+//	@Success		200				{object}	util.ServerResponse{data=Stub}
+//	@Failure		400,401,404		{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/saved-searches/{savedSearchID} [delete]
+func (h *Handler) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	project, err := h.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+	if !h.requireJWTProjectManage(w, r, project) {
+		return
+	}
+
+	err = saved_searches.New(h.A.Logger, h.A.DB).DeleteSavedSearch(r.Context(), project.UID, chi.URLParam(r, "savedSearchID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrSavedSearchNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+		_ = render.Render(w, r, util.NewErrorResponse("failed to delete saved search", http.StatusInternalServerError))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Saved search deleted successfully", nil, http.StatusOK))
+}
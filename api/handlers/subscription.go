@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"reflect"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -10,9 +11,12 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/internal/filters"
 	"github.com/frain-dev/convoy/internal/organisations"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/internal/sources"
+	"github.com/frain-dev/convoy/pkg/celfilter"
 	"github.com/frain-dev/convoy/pkg/transform"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
@@ -205,6 +209,7 @@ func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
 
 	authUser := middleware.GetAuthUserFromContext(r.Context())
 
+	var eventTypes []string
 	if h.IsReqWithPortalLinkToken(authUser) {
 		portalLink, err := h.retrievePortalLinkFromToken(r)
 		if err != nil {
@@ -222,6 +227,28 @@ func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
 			_ = render.Render(w, r, util.NewErrorResponse("unauthorized", http.StatusUnauthorized))
 			return
 		}
+
+		if h.rejectPortalLinkFilterChange(w, r, sub.FilterConfig, nil) {
+			return
+		}
+
+		var requested []string
+		if sub.FilterConfig != nil {
+			requested = sub.FilterConfig.EventTypes
+		}
+
+		es := services.PortalSubscriptionEventTypesService{
+			EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+			ProjectID:      project.UID,
+			PortalLink:     portalLink,
+			EventTypes:     requested,
+		}
+
+		eventTypes, err = es.Run(r.Context())
+		if err != nil {
+			_ = render.Render(w, r, util.NewServiceErrResponse(err))
+			return
+		}
 	}
 
 	cs := services.NewCreateSubscriptionService(
@@ -233,6 +260,7 @@ func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
 		h.A.Licenser,
 		h.A.Logger,
 	)
+	cs.EventTypes = eventTypes
 
 	subscription, err := cs.Run(r.Context())
 	if err != nil {
@@ -382,6 +410,31 @@ func (h *Handler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
 			_ = render.Render(w, r, util.NewErrorResponse("unauthorized", http.StatusUnauthorized))
 			return
 		}
+
+		if h.rejectPortalLinkFilterChange(w, r, update.FilterConfig, sub.FilterConfig) {
+			return
+		}
+
+		if update.FilterConfig != nil && len(update.FilterConfig.EventTypes) > 0 {
+			var current []string
+			if sub.FilterConfig != nil {
+				current = sub.FilterConfig.EventTypes
+			}
+
+			es := services.PortalSubscriptionEventTypesService{
+				EventTypesRepo: event_types.New(h.A.Logger, h.A.DB),
+				ProjectID:      project.UID,
+				PortalLink:     portalLink,
+				EventTypes:     update.FilterConfig.EventTypes,
+				Current:        current,
+			}
+
+			update.FilterConfig.EventTypes, err = es.Run(r.Context())
+			if err != nil {
+				_ = render.Render(w, r, util.NewServiceErrResponse(err))
+				return
+			}
+		}
 	}
 
 	us := services.NewUpdateSubscriptionService(
@@ -453,6 +506,17 @@ func (h *Handler) TestSubscriptionFilter(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if test.Schema.Expression != "" {
+		isMatch, err := celfilter.Match(test.Schema.Expression, test.Request.ExpressionInput())
+		if err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewServerResponse("Filter validated successfully", isMatch, http.StatusOK))
+		return
+	}
+
 	subRepo := h.subscriptionRepo()
 	isBodyValid, err := subRepo.TestSubscriptionFilter(r.Context(), test.Request.Body, test.Schema.Body, false)
 	if err != nil {
@@ -487,6 +551,44 @@ func (h *Handler) TestSubscriptionFilter(w http.ResponseWriter, r *http.Request)
 	_ = render.Render(w, r, util.NewServerResponse("Filter validated successfully", isValid, http.StatusOK))
 }
 
+// ExplainSubscriptionFilter
+//
+//	@Summary		Explain subscription filter
+//	@Description	This endpoint evaluates a filter against a payload like test_filter, and returns whether each of its conditions matched.
+//	@Id				ExplainSubscriptionFilter
+//	@Tags			Subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectID	path		string				true	"Project ID"
+//	@Param			filter		body		models.TestFilter	true	"Filter Details"
+//	@Success		200			{object}	util.ServerResponse{data=models.FilterExplanationResponse}
+//	@Failure		400,401,404	{object}	util.ServerResponse{data=Stub}
+//	@Security		ApiKeyAuth
+//	@Router			/v1/projects/{projectID}/subscriptions/test_filter/explain [post]
+func (h *Handler) ExplainSubscriptionFilter(w http.ResponseWriter, r *http.Request) {
+	if !h.A.Licenser.AdvancedSubscriptions() {
+		_ = render.Render(w, r, util.NewErrorResponse("your instance does not have access to subscription filters, upgrade to access this feature", http.StatusBadRequest))
+		return
+	}
+
+	var test models.TestFilter
+	err := util.ReadJSON(r, &test)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	req, filter, err := test.Transform()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	resp := models.FilterExplanationResponse{FilterExplanation: filters.Explain(req, filter)}
+
+	_ = render.Render(w, r, util.NewServerResponse("Filter explained", resp, http.StatusOK))
+}
+
 // TestSubscriptionFunction
 //
 //	@Summary		Test a subscription function
@@ -529,3 +631,38 @@ func (h *Handler) TestSubscriptionFunction(w http.ResponseWriter, r *http.Reques
 
 	_ = render.Render(w, r, util.NewServerResponse("Transformer function run successfully", functionResponse, http.StatusOK))
 }
+
+// rejectPortalLinkFilterChange writes a 403 and returns true when a portal link
+// without manage_filters sets a subscription filter other than current, the
+// same permission the /filters routes take. Choosing event types is open to
+// every link, and the filter a subscription already has may be sent back
+// unchanged, as the portal does when saving other fields.
+func (h *Handler) rejectPortalLinkFilterChange(w http.ResponseWriter, r *http.Request, requested *models.FilterConfiguration, current *datastore.FilterConfiguration) bool {
+	if requested == nil {
+		return false
+	}
+
+	var stored datastore.FilterSchema
+	if current != nil {
+		stored = current.Filter
+	}
+
+	if requested.Filter.Expression == stored.Expression &&
+		sameFilterMap(requested.Filter.Headers, stored.RawHeaders, stored.Headers) &&
+		sameFilterMap(requested.Filter.Body, stored.RawBody, stored.Body) &&
+		sameFilterMap(requested.Filter.Query, stored.RawQuery, stored.Query) &&
+		sameFilterMap(requested.Filter.Path, stored.RawPath, stored.Path) {
+		return false
+	}
+
+	return h.rejectPortalLinkWithout(w, r, datastore.PortalLinkManageFilters)
+}
+
+// sameFilterMap reports whether a requested filter map matches the stored one,
+// in its raw form or the flattened form responses carry.
+func sameFilterMap(requested, raw, flattened datastore.M) bool {
+	if len(requested) == 0 {
+		return len(raw) == 0 && len(flattened) == 0
+	}
+	return reflect.DeepEqual(requested, raw) || reflect.DeepEqual(requested, flattened)
+}
@@ -285,9 +285,10 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 	}
 
 	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
-		Delay:   0,
+		ID:        jobId,
+		Payload:   eventByte,
+		Delay:     0,
+		ProjectID: event.ProjectID,
 	}
 
 	err = a.A.Queue.Write(r.Context(), convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
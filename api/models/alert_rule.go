@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type CreateAlertRule struct {
+	// Name of the rule, unique within the project
+	Name string `json:"name"`
+	// failure_rate, consecutive_failures, latency_p95, retry_backlog or source_silence
+	Type datastore.AlertRuleType `json:"type"`
+	// Narrows a delivery rule to one endpoint; empty covers the whole project
+	EndpointID string `json:"endpoint_id"`
+	// Source watched by a source_silence rule
+	SourceID string `json:"source_id"`
+	// Percentage for failure_rate, milliseconds for latency_p95, and a count
+	// for consecutive_failures and retry_backlog
+	Threshold float64 `json:"threshold"`
+	// Seconds of traffic the rule looks at, between 60 and 86400; defaults to 300
+	Window uint64 `json:"window"`
+	// Least seconds between two notifications while the rule stays firing;
+	// defaults to 1800
+	Cooldown uint64 `json:"cooldown"`
+	// Where notifications go; at least one channel is required
+	Channels datastore.AlertRuleChannels `json:"channels"`
+	// Defaults to true
+	Enabled *bool `json:"enabled"`
+}
+
+func (c *CreateAlertRule) Transform() *datastore.AlertRule {
+	return &datastore.AlertRule{
+		Name:            c.Name,
+		Type:            c.Type,
+		EndpointID:      c.EndpointID,
+		SourceID:        c.SourceID,
+		Threshold:       c.Threshold,
+		WindowSeconds:   c.Window,
+		CooldownSeconds: c.Cooldown,
+		Channels:        c.Channels,
+		Enabled:         c.Enabled == nil || *c.Enabled,
+	}
+}
+
+type UpdateAlertRule struct {
+	Name       string                      `json:"name"`
+	Type       datastore.AlertRuleType     `json:"type"`
+	EndpointID string                      `json:"endpoint_id"`
+	SourceID   string                      `json:"source_id"`
+	Threshold  float64                     `json:"threshold"`
+	Window     uint64                      `json:"window"`
+	Cooldown   uint64                      `json:"cooldown"`
+	Channels   datastore.AlertRuleChannels `json:"channels"`
+	Enabled    *bool                       `json:"enabled"`
+}
+
+func (u *UpdateAlertRule) Transform() *datastore.AlertRule {
+	c := CreateAlertRule(*u)
+	return c.Transform()
+}
+
+type AlertRuleResponse struct {
+	*datastore.AlertRule
+}
+
+// MarshalJSON masks the incident channel credentials; Slack and Teams URLs are
+// returned as they are on endpoints.
+func (ar AlertRuleResponse) MarshalJSON() ([]byte, error) {
+	if ar.AlertRule == nil {
+		return []byte("null"), nil
+	}
+
+	rule := *ar.AlertRule
+	rule.Channels.NotificationChannels = *rule.Channels.NotificationChannels.Redacted()
+	return json.Marshal(&rule)
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/frain-dev/convoy/datastore"
+	cb "github.com/frain-dev/convoy/pkg/circuit_breaker"
+	"github.com/frain-dev/convoy/util"
+)
+
+const maxCircuitBreakerTransitions = 100
+
+type UpdateCircuitBreakerState struct {
+	// Why the breaker is being changed; stored with the override and the
+	// transition history.
+	Reason string `json:"reason" valid:"stringlength(0|500)~reason must not exceed 500 characters"`
+}
+
+func (u *UpdateCircuitBreakerState) Validate() error {
+	return util.Validate(u)
+}
+
+type UpdateEndpointCircuitBreakerConfig struct {
+	// Seconds an open breaker waits before moving to half-open
+	ErrorTimeout uint64 `json:"error_timeout"`
+	// Failure rate percentage that trips the breaker
+	FailureThreshold uint64 `json:"failure_threshold"`
+	// Success rate percentage that closes a half-open breaker
+	SuccessThreshold uint64 `json:"success_threshold"`
+	// Minimum requests in the observability window before the breaker can trip
+	MinimumRequestCount uint64 `json:"minimum_request_count"`
+	// Consecutive trips before the endpoint is disabled
+	ConsecutiveFailureThreshold uint64 `json:"consecutive_failure_threshold"`
+}
+
+func (u *UpdateEndpointCircuitBreakerConfig) Transform() *datastore.EndpointCircuitBreakerConfiguration {
+	return &datastore.EndpointCircuitBreakerConfiguration{
+		ErrorTimeout:                u.ErrorTimeout,
+		FailureThreshold:            u.FailureThreshold,
+		SuccessThreshold:            u.SuccessThreshold,
+		MinimumRequestCount:         u.MinimumRequestCount,
+		ConsecutiveFailureThreshold: u.ConsecutiveFailureThreshold,
+	}
+}
+
+type CircuitBreakerResponse struct {
+	// Nil until the endpoint has been sampled or changed by an operator
+	Breaker  *cb.CircuitBreaker                `json:"breaker"`
+	Override *datastore.CircuitBreakerOverride `json:"override"`
+}
+
+type CircuitBreakerTransitionResponse struct {
+	*datastore.CircuitBreakerTransition
+}
+
+type QueryListCircuitBreakerTransitions struct {
+	// Number of transitions to return, newest first (max 100)
+	Limit int `json:"limit" example:"50"`
+}
+
+func (q *QueryListCircuitBreakerTransitions) Transform(r *http.Request) (*QueryListCircuitBreakerTransitions, error) {
+	res := &QueryListCircuitBreakerTransitions{}
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return nil, errors.New("limit must be a positive integer")
+		}
+		res.Limit = min(limit, maxCircuitBreakerTransitions)
+	}
+
+	return res, nil
+}
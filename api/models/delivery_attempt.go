@@ -14,6 +14,10 @@ type DeliveryAttemptResponse struct {
 	// on serialization. It is only set true for higher-trust callers via the
 	// constructors, so a bare literal fails closed to redaction.
 	showRawHeaders bool
+
+	// hidePayload leaves the endpoint's response body out, for portal links
+	// without the view_payloads permission.
+	hidePayload bool
 }
 
 // NewDeliveryAttemptResponse wraps a single delivery attempt for API
@@ -23,6 +27,12 @@ func NewDeliveryAttemptResponse(attempt *datastore.DeliveryAttempt, showRawHeade
 	return DeliveryAttemptResponse{DeliveryAttempt: attempt, showRawHeaders: showRawHeaders}
 }
 
+// WithoutPayload returns the response with the response body left out.
+func (d DeliveryAttemptResponse) WithoutPayload() DeliveryAttemptResponse {
+	d.hidePayload = true
+	return d
+}
+
 // MarshalJSON redacts sensitive header values (auth tokens, API keys, cookies)
 // on both the outbound request headers and the endpoint's response headers
 // (e.g. Set-Cookie) before serializing a delivery attempt, unless the caller is
@@ -39,6 +49,10 @@ func (d DeliveryAttemptResponse) MarshalJSON() ([]byte, error) {
 		clone.ResponseHeader = m.RedactSensitiveHeaders(clone.ResponseHeader)
 	}
 
+	if d.hidePayload {
+		clone.ResponseDataString = ""
+	}
+
 	return json.Marshal(&clone)
 }
 
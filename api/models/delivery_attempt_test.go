@@ -150,3 +150,33 @@ func TestEventDeliveryResponse_MarshalJSON_NilIsNull(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "null", string(b))
 }
+
+func TestDeliveryAttemptResponse_MarshalJSON_WithoutPayload(t *testing.T) {
+	attempt := &datastore.DeliveryAttempt{UID: "att-1", HttpResponseCode: "200", ResponseDataString: `{"email":"jane@example.com"}`}
+
+	b, err := json.Marshal(NewDeliveryAttemptResponse(attempt, true).WithoutPayload())
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "jane@example.com")
+	require.Contains(t, string(b), `"http_status":"200"`)
+
+	require.Equal(t, `{"email":"jane@example.com"}`, attempt.ResponseDataString)
+}
+
+func TestEventDeliveryResponse_MarshalJSON_WithoutPayload(t *testing.T) {
+	ed := &datastore.EventDelivery{
+		UID:      "ed-1",
+		Status:   datastore.SuccessEventStatus,
+		Metadata: &datastore.Metadata{Data: []byte(`{"email":"jane@example.com"}`), Raw: `{"email":"jane@example.com"}`, NumTrials: 2},
+		Event:    &datastore.Event{UID: "event-1", Data: []byte(`{"email":"jane@example.com"}`)},
+	}
+
+	b, err := json.Marshal(NewEventDeliveryResponse(ed, false).WithoutPayload())
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "jane@example.com")
+	require.Contains(t, string(b), `"num_trials":2`)
+	require.Contains(t, string(b), `"status":"Success"`)
+
+	// The stored delivery keeps its payload.
+	require.Contains(t, string(ed.Metadata.Data), "jane@example.com")
+	require.Contains(t, string(ed.Event.Data), "jane@example.com")
+}
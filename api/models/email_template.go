@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type UpdateEmailTemplate struct {
+	// Go template for the subject line; empty keeps the built-in subject
+	Subject string `json:"subject"`
+	// Go template for the HTML body, rendered inside the branded layout;
+	// empty keeps the built-in body
+	Body string `json:"body"`
+}
+
+type PreviewEmailTemplate struct {
+	// Unsaved subject to render; empty renders the built-in subject
+	Subject string `json:"subject"`
+	// Unsaved body to render; empty renders the built-in body
+	Body string `json:"body"`
+}
+
+type UpdateEmailBranding struct {
+	// Header colour as #RRGGBB; empty keeps the default
+	BrandColor string `json:"brand_color"`
+	// Logo shown in the header when the project has none of its own
+	LogoURL string `json:"logo_url"`
+}
+
+func (u *UpdateEmailBranding) Transform() *datastore.EmailBranding {
+	return &datastore.EmailBranding{
+		BrandColor: u.BrandColor,
+		LogoURL:    u.LogoURL,
+	}
+}
+
+// EmailTemplateResponse describes one customisable email: the variables its
+// templates can use and the organisation's override, if it has one.
+type EmailTemplateResponse struct {
+	TemplateName string                   `json:"template_name"`
+	Variables    []string                 `json:"variables"`
+	Override     *datastore.EmailTemplate `json:"override"`
+}
@@ -50,6 +50,12 @@ type CreateEndpoint struct {
 	// (Power Automate) webhook URL; retired Office 365 connector URLs no longer deliver.
 	TeamsWebhookURL string `json:"teams_webhook_url"`
 
+	// Notification channels are incident channels (a signed generic webhook,
+	// PagerDuty or Opsgenie) that open an incident when the endpoint is disabled
+	// and close it when the endpoint is reactivated. A webhook channel without a
+	// secret gets a generated one.
+	NotificationChannels *datastore.NotificationChannels `json:"notification_channels"`
+
 	// Define endpoint http timeout in seconds.
 	HttpTimeout uint64 `json:"http_timeout" copier:"-"`
 
@@ -119,6 +125,10 @@ type UpdateEndpoint struct {
 	// (Power Automate) webhook URL; retired Office 365 connector URLs no longer deliver.
 	TeamsWebhookURL *string `json:"teams_webhook_url"`
 
+	// Notification channels replace the endpoint's incident channels. Omit the
+	// field to keep them, or send an empty object to remove them.
+	NotificationChannels *datastore.NotificationChannels `json:"notification_channels"`
+
 	// Define endpoint http timeout in seconds.
 	HttpTimeout uint64 `json:"http_timeout" copier:"-"`
 
@@ -316,10 +326,13 @@ func (ea *EndpointAuthentication) Transform() *datastore.EndpointAuthentication
 
 type EndpointResponse struct {
 	*datastore.Endpoint
+	// Health is set when the endpoint has health checks configured.
+	Health *EndpointHealthSummary `json:"health,omitempty"`
 }
 
 // MarshalJSON redacts sensitive fields before serializing the endpoint response.
-// Specifically, it removes the mTLS client private key from the JSON output.
+// Specifically, it removes the basic auth password, the mTLS client private key
+// and the incident channel credentials from the JSON output.
 func (er EndpointResponse) MarshalJSON() ([]byte, error) {
 	if er.Endpoint == nil {
 		return []byte("null"), nil
@@ -331,7 +344,7 @@ func (er EndpointResponse) MarshalJSON() ([]byte, error) {
 		auth := *e.Authentication
 		ba := *auth.BasicAuth
 		if ba.Password != "" {
-			ba.Password = "[REDACTED]"
+			ba.Password = datastore.RedactedSecret
 		}
 		auth.BasicAuth = &ba
 		e.Authentication = &auth
@@ -340,12 +353,20 @@ func (er EndpointResponse) MarshalJSON() ([]byte, error) {
 		mtls := *e.MtlsClientCert
 		// Redact private key from API responses - show placeholder if key exists
 		if mtls.ClientKey != "" {
-			mtls.ClientKey = "[REDACTED]"
+			mtls.ClientKey = datastore.RedactedSecret
 		}
 		e.MtlsClientCert = &mtls
 	}
+	e.NotificationChannels = e.NotificationChannels.Redacted()
+
+	if er.Health == nil {
+		return json.Marshal(&e)
+	}
 
-	return json.Marshal(&e)
+	return json.Marshal(struct {
+		*datastore.Endpoint
+		Health *EndpointHealthSummary `json:"health"`
+	}{Endpoint: &e, Health: er.Health})
 }
 
 // TestOAuth2Request represents a request to test OAuth2 connection
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+const (
+	maxEndpointHealthCheckResults = 1000
+	maxEndpointHealthWindowHours  = 30 * 24
+)
+
+type UpsertEndpointHealthCheck struct {
+	// HTTP method used for the check: GET (default), HEAD, POST or OPTIONS
+	Method string `json:"method"`
+	// Path appended to the endpoint url, e.g. /health; empty checks the url itself
+	Path string `json:"path" valid:"stringlength(0|255)~path must not exceed 255 characters"`
+	// Status code a healthy endpoint answers with; 0 accepts any 2xx
+	ExpectedStatus int `json:"expected_status"`
+	// Seconds between checks, at least 60
+	Interval uint64 `json:"interval"`
+	// Seconds to wait for a response, at most 30
+	Timeout uint64 `json:"timeout"`
+	// Consecutive failed checks before the endpoint is considered unhealthy
+	FailureThreshold int `json:"failure_threshold"`
+	// Pause the endpoint once it is unhealthy
+	AutoPause bool `json:"auto_pause"`
+	// Activate the endpoint again once a check succeeds, if it was paused by
+	// the health check or disabled for failing deliveries
+	AutoActivate bool `json:"auto_activate"`
+}
+
+func (u *UpsertEndpointHealthCheck) Validate() error {
+	return util.Validate(u)
+}
+
+func (u *UpsertEndpointHealthCheck) Transform() *datastore.EndpointHealthCheck {
+	return &datastore.EndpointHealthCheck{
+		Method:           u.Method,
+		Path:             u.Path,
+		ExpectedStatus:   u.ExpectedStatus,
+		IntervalSeconds:  u.Interval,
+		TimeoutSeconds:   u.Timeout,
+		FailureThreshold: u.FailureThreshold,
+		AutoPause:        u.AutoPause,
+		AutoActivate:     u.AutoActivate,
+	}
+}
+
+type EndpointHealthResponse struct {
+	Check *datastore.EndpointHealthCheck `json:"check"`
+	Stats *datastore.EndpointHealthStats `json:"stats"`
+}
+
+// EndpointHealthSummary is the health view attached to an endpoint that has
+// health checks configured.
+type EndpointHealthSummary struct {
+	// healthy, unhealthy, or empty before enough checks have run
+	Status        string                         `json:"status"`
+	LastCheckedAt *time.Time                     `json:"last_checked_at"`
+	Last24h       *datastore.EndpointHealthStats `json:"last_24h"`
+}
+
+// EndpointHealthOverview summarises the health of a project's monitored
+// endpoints on the dashboard.
+type EndpointHealthOverview struct {
+	*datastore.EndpointHealthCounts
+	Stats *datastore.EndpointHealthStats `json:"stats"`
+}
+
+type EndpointHealthCheckResultResponse struct {
+	*datastore.EndpointHealthCheckResult
+}
+
+type QueryEndpointHealth struct {
+	// Hours of history to cover, counting back from now (default 24, max 720)
+	Hours int `json:"hours" example:"24"`
+	// Number of results to return, newest first (default 100, max 1000)
+	Limit int `json:"limit" example:"100"`
+}
+
+func (q *QueryEndpointHealth) Transform(r *http.Request) (*QueryEndpointHealth, error) {
+	res := &QueryEndpointHealth{Hours: 24}
+
+	if h := r.URL.Query().Get("hours"); h != "" {
+		hours, err := strconv.Atoi(h)
+		if err != nil || hours < 1 {
+			return nil, errors.New("hours must be a positive integer")
+		}
+		res.Hours = min(hours, maxEndpointHealthWindowHours)
+	}
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return nil, errors.New("limit must be a positive integer")
+		}
+		res.Limit = min(limit, maxEndpointHealthCheckResults)
+	}
+
+	return res, nil
+}
+
+// Since is the start of the requested window.
+func (q *QueryEndpointHealth) Since(now time.Time) time.Time {
+	return now.Add(-time.Duration(q.Hours) * time.Hour)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestEndpointResponse_MarshalJSON_Health(t *testing.T) {
+	endpoint := &datastore.Endpoint{
+		UID:            "123",
+		MtlsClientCert: &datastore.MtlsClientCert{ClientCert: "cert", ClientKey: "key"},
+	}
+
+	b, err := json.Marshal(EndpointResponse{Endpoint: endpoint})
+	require.NoError(t, err)
+	require.NotContains(t, string(b), `"health"`)
+
+	b, err = json.Marshal(EndpointResponse{Endpoint: endpoint, Health: &EndpointHealthSummary{Status: datastore.EndpointHealthy}})
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &out))
+	require.Equal(t, "123", out["uid"])
+	require.Equal(t, "healthy", out["health"].(map[string]interface{})["status"])
+	require.Equal(t, "[REDACTED]", out["mtls_client_cert"].(map[string]interface{})["client_key"])
+}
+
+func TestQueryEndpointHealth_Transform(t *testing.T) {
+	var q *QueryEndpointHealth
+
+	res, err := q.Transform(httptest.NewRequest("GET", "/?hours=1000&limit=5000", nil))
+	require.NoError(t, err)
+	require.Equal(t, maxEndpointHealthWindowHours, res.Hours)
+	require.Equal(t, maxEndpointHealthCheckResults, res.Limit)
+
+	res, err = q.Transform(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, 24, res.Hours)
+
+	_, err = q.Transform(httptest.NewRequest("GET", "/?hours=-1", nil))
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -163,3 +164,32 @@ func TestOAuth2_Transform_Nil(t *testing.T) {
 	result := oauth2.Transform()
 	require.Nil(t, result)
 }
+
+func TestEndpointResponse_MarshalJSON_RedactsNotificationChannels(t *testing.T) {
+	endpoint := &datastore.Endpoint{
+		UID: "123",
+		NotificationChannels: &datastore.NotificationChannels{
+			Webhook:   &datastore.WebhookChannel{URL: "https://alerts.example.com/convoy", Secret: "whsec-secret"},
+			PagerDuty: &datastore.PagerDutyChannel{RoutingKey: "pd-routing-key"},
+			Opsgenie:  &datastore.OpsgenieChannel{APIKey: "genie-api-key", Region: datastore.OpsgenieRegionEU},
+		},
+	}
+
+	b, err := json.Marshal(EndpointResponse{Endpoint: endpoint})
+	require.NoError(t, err)
+	for _, secret := range []string{"whsec-secret", "pd-routing-key", "genie-api-key"} {
+		require.NotContains(t, string(b), secret)
+	}
+	require.Contains(t, string(b), "https://alerts.example.com/convoy")
+	require.Equal(t, "whsec-secret", endpoint.NotificationChannels.Webhook.Secret)
+
+	b, err = json.Marshal(AlertRuleResponse{AlertRule: &datastore.AlertRule{
+		UID:      "rule-1",
+		Channels: datastore.AlertRuleChannels{NotificationChannels: *endpoint.NotificationChannels},
+	}})
+	require.NoError(t, err)
+	for _, secret := range []string{"whsec-secret", "pd-routing-key", "genie-api-key"} {
+		require.NotContains(t, string(b), secret)
+	}
+	require.Contains(t, string(b), `"uid":"rule-1"`)
+}
@@ -29,6 +29,11 @@ type CreateEvent struct {
 	// Event Type is used for filtering and debugging e.g invoice.paid
 	EventType string `json:"event_type" valid:"required~please provide an event type"`
 
+	// Event Type Version is the version of the event type the data is shaped
+	// as. Subscriptions pinned to an older version receive it downgraded.
+	// Defaults to the event type's latest version.
+	EventTypeVersion int `json:"event_type_version"`
+
 	// Specifies custom headers you want convoy to add when the event is dispatched to your endpoint
 	CustomHeaders map[string]string `json:"custom_headers"`
 
@@ -53,6 +58,30 @@ func (e *CreateEvent) Validate() error {
 	return nil
 }
 
+type SendTestEvent struct {
+	// Event Type is the event type to send a test event of e.g invoice.paid
+	EventType string `json:"event_type" valid:"required~please provide an event type"`
+
+	// Data is sent as the body of the test event. When it is empty a sample
+	// is generated from the event type's JSON schema.
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+
+	// Specifies custom headers you want convoy to add when the event is dispatched to your endpoint
+	CustomHeaders map[string]string `json:"custom_headers"`
+}
+
+func (st *SendTestEvent) Validate() error {
+	return util.Validate(st)
+}
+
+type TestEventResponse struct {
+	// EventID is the test event's id. Its delivery is marked as a test.
+	EventID string `json:"event_id"`
+
+	// Data is the payload that was sent.
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
 type DynamicEvent struct {
 	JobID string `json:"jid" swaggerignore:"true"`
 
@@ -169,6 +198,11 @@ type BroadcastEvent struct {
 	// Event Type is used for filtering and debugging e.g invoice.paid
 	EventType string `json:"event_type" valid:"required~please provide an event type"`
 
+	// Event Type Version is the version of the event type the data is shaped
+	// as. Subscriptions pinned to an older version receive it downgraded.
+	// Defaults to the event type's latest version.
+	EventTypeVersion int `json:"event_type_version"`
+
 	ProjectID string `json:"project_id" swaggerignore:"true"`
 	SourceID  string `json:"source_id" swaggerignore:"true"`
 
@@ -196,6 +230,11 @@ type FanoutEvent struct {
 	// Event Type is used for filtering and debugging e.g invoice.paid
 	EventType string `json:"event_type" valid:"required~please provide an event type"`
 
+	// Event Type Version is the version of the event type the data is shaped
+	// as. Subscriptions pinned to an older version receive it downgraded.
+	// Defaults to the event type's latest version.
+	EventTypeVersion int `json:"event_type_version"`
+
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data" valid:"required~please provide your data" swaggertype:"object"`
@@ -213,6 +252,30 @@ func (fe *FanoutEvent) Validate() error {
 
 type EventResponse struct {
 	*datastore.Event
+
+	// hidePayload leaves the event body out on serialization, for portal
+	// links without the view_payloads permission.
+	hidePayload bool
+}
+
+// WithoutPayload returns the response with the event body left out.
+func (e EventResponse) WithoutPayload() EventResponse {
+	e.hidePayload = true
+	return e
+}
+
+func (e EventResponse) MarshalJSON() ([]byte, error) {
+	if e.Event == nil {
+		return []byte("null"), nil
+	}
+
+	if !e.hidePayload {
+		return json.Marshal(e.Event)
+	}
+
+	clone := *e.Event
+	clone.Data, clone.Raw = nil, ""
+	return json.Marshal(&clone)
 }
 
 type QueryCountAffectedEvents struct {
@@ -16,6 +16,10 @@ type EventDeliveryResponse struct {
 	// on serialization. It is only set true for higher-trust callers via
 	// NewEventDeliveryResponse, so a bare literal fails closed to redaction.
 	showRawHeaders bool
+
+	// hidePayload leaves the event body out of the delivery's metadata and
+	// event, for portal links without the view_payloads permission.
+	hidePayload bool
 }
 
 // NewEventDeliveryResponse wraps an event delivery for API serialization.
@@ -25,6 +29,12 @@ func NewEventDeliveryResponse(ed *datastore.EventDelivery, showRawHeaders bool)
 	return EventDeliveryResponse{EventDelivery: ed, showRawHeaders: showRawHeaders}
 }
 
+// WithoutPayload returns the response with the event body left out.
+func (e EventDeliveryResponse) WithoutPayload() EventDeliveryResponse {
+	e.hidePayload = true
+	return e
+}
+
 // MarshalJSON redacts sensitive request header values (auth tokens, API keys,
 // cookies) before serializing an event delivery, unless the caller is allowed
 // to see raw headers. It operates on a shallow copy with a fresh Headers map,
@@ -41,6 +51,20 @@ func (e EventDeliveryResponse) MarshalJSON() ([]byte, error) {
 		clone.Headers = m.RedactSensitiveMultiHeaders(clone.Headers)
 	}
 
+	if e.hidePayload {
+		if clone.Metadata != nil {
+			metadata := *clone.Metadata
+			metadata.Data, metadata.Raw = nil, ""
+			clone.Metadata = &metadata
+		}
+
+		if clone.Event != nil {
+			event := *clone.Event
+			event.Data, event.Raw = nil, ""
+			clone.Event = &event
+		}
+	}
+
 	return json.Marshal(&clone)
 }
 
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
 )
 
 func TestCreateEventValidateRequiresDeliveryTarget(t *testing.T) {
@@ -69,3 +71,17 @@ func TestCreateEventValidateRequiresDeliveryTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestEventResponse_MarshalJSON_WithoutPayload(t *testing.T) {
+	event := &datastore.Event{UID: "event-1", EventType: "user.created", Data: []byte(`{"email":"jane@example.com"}`), Raw: `{"email":"jane@example.com"}`}
+
+	b, err := json.Marshal(EventResponse{Event: event})
+	require.NoError(t, err)
+	require.Contains(t, string(b), "jane@example.com")
+
+	b, err = json.Marshal(EventResponse{Event: event}.WithoutPayload())
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "jane@example.com")
+	require.Contains(t, string(b), `"event_type":"user.created"`)
+	require.Contains(t, string(event.Data), "jane@example.com")
+}
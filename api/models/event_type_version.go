@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+type CreateEventTypeVersion struct {
+	// JSONSchema is the JSON structure of this version's payload
+	JSONSchema map[string]interface{} `json:"json_schema"`
+
+	// Downgrade is a JavaScript function named transform that converts a
+	// payload of the next version into this one. Subscriptions pinned to this
+	// version receive newer events through it.
+	Downgrade string `json:"downgrade"`
+
+	// SunsetAt is when this version stops being supported. A meta event
+	// announces it once the time has passed.
+	SunsetAt *time.Time `json:"sunset_at"`
+}
+
+func (cv *CreateEventTypeVersion) Validate() error {
+	if err := util.Validate(cv); err != nil {
+		return err
+	}
+
+	if cv.JSONSchema != nil {
+		if err := validateJSONSchema(cv.JSONSchema); err != nil {
+			return fmt.Errorf("invalid JSON schema: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (cv *CreateEventTypeVersion) Transform() (*datastore.EventTypeVersion, error) {
+	schema, err := marshalVersionSchema(cv.JSONSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.EventTypeVersion{
+		JSONSchema: schema,
+		Downgrade:  downgradeFunction(cv.Downgrade),
+		SunsetAt:   null.TimeFromPtr(cv.SunsetAt),
+	}, nil
+}
+
+// UpdateEventTypeVersion replaces a version's downgrade function and sunset
+// date. The schema is kept when it is left out.
+type UpdateEventTypeVersion struct {
+	// JSONSchema is the JSON structure of this version's payload
+	JSONSchema map[string]interface{} `json:"json_schema"`
+
+	// Downgrade is a JavaScript function named transform that converts a
+	// payload of the next version into this one
+	Downgrade string `json:"downgrade"`
+
+	// SunsetAt is when this version stops being supported
+	SunsetAt *time.Time `json:"sunset_at"`
+}
+
+func (uv *UpdateEventTypeVersion) Validate() error {
+	if err := util.Validate(uv); err != nil {
+		return err
+	}
+
+	if uv.JSONSchema != nil {
+		if err := validateJSONSchema(uv.JSONSchema); err != nil {
+			return fmt.Errorf("invalid JSON schema: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (uv *UpdateEventTypeVersion) Apply(version *datastore.EventTypeVersion) error {
+	if uv.JSONSchema != nil {
+		schema, err := marshalVersionSchema(uv.JSONSchema)
+		if err != nil {
+			return err
+		}
+		version.JSONSchema = schema
+	}
+
+	version.Downgrade = downgradeFunction(uv.Downgrade)
+	version.SunsetAt = null.TimeFromPtr(uv.SunsetAt)
+
+	return nil
+}
+
+type EventTypeVersionResponse struct {
+	*datastore.EventTypeVersion
+}
+
+type PinEventTypeVersion struct {
+	// EventType is the name of the event type to pin, e.g. invoice.paid
+	EventType string `json:"event_type" valid:"required~please provide an event type"`
+
+	// Version is the event type version the subscription receives
+	Version int `json:"version" valid:"required~please provide a version"`
+}
+
+func (pv *PinEventTypeVersion) Validate() error {
+	return util.Validate(pv)
+}
+
+type EventTypeVersionPinResponse struct {
+	*datastore.EventTypeVersionPin
+}
+
+func marshalVersionSchema(schema map[string]interface{}) (json.RawMessage, error) {
+	if schema == nil {
+		return []byte("{}"), nil
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %v", err)
+	}
+
+	return b, nil
+}
+
+func downgradeFunction(fn string) null.String {
+	fn = strings.TrimSpace(fn)
+	return null.NewString(fn, fn != "")
+}
@@ -43,6 +43,19 @@ type EventTypeResponse struct {
 	*datastore.ProjectEventType
 }
 
+// PortalEventType is an event type a portal link can subscribe its
+// endpoints to.
+type PortalEventType struct {
+	Name        string          `json:"name"`
+	Category    string          `json:"category"`
+	Description string          `json:"description"`
+	JSONSchema  json.RawMessage `json:"json_schema" swaggertype:"object"`
+
+	// Sample is a payload generated from the JSON schema, subscription
+	// filters can be previewed against it with the test filter API.
+	Sample json.RawMessage `json:"sample" swaggertype:"object"`
+}
+
 type UpdateEventType struct {
 	// Category is a product-specific grouping for the event type
 	Category string `json:"category"`
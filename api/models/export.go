@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type CreateExport struct {
+	// Export a saved search; its resource and filter replace the ones below,
+	// except for the filter's start and end dates
+	SavedSearchID string `json:"saved_search_id"`
+	// events or event_deliveries
+	Resource datastore.SearchResource `json:"resource"`
+	// csv (default) or jsonl
+	Format datastore.ExportFormat `json:"format"`
+	// The start date is required unless the saved search has one; the end
+	// date defaults to now
+	Filter datastore.SearchFilter `json:"filter"`
+}
+
+func (c *CreateExport) Transform() *datastore.ExportJob {
+	return &datastore.ExportJob{
+		SavedSearchID: c.SavedSearchID,
+		Resource:      c.Resource,
+		Format:        c.Format,
+		Filter:        c.Filter,
+	}
+}
+
+type ExportJobResponse struct {
+	*datastore.ExportJob
+}
@@ -33,6 +33,9 @@ type Filter struct {
 	// Path matching criteria (optional)
 	Path datastore.M `json:"path"`
 
+	// CEL expression matched instead of the criteria above (optional)
+	Expression string `json:"expression"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -56,6 +59,10 @@ type CreateFilterRequest struct {
 
 	// Path matching criteria (optional)
 	Path datastore.M `json:"path"`
+
+	// CEL expression matched instead of headers, body, query and path (optional),
+	// e.g. body.amount > 100 && headers["x-tenant"] in ["a", "b"]
+	Expression string `json:"expression,omitempty"`
 }
 
 // UpdateFilterRequest represents the request to update a filter
@@ -78,6 +85,11 @@ type UpdateFilterRequest struct {
 	// Path matching criteria (optional)
 	Path datastore.M `json:"path"`
 
+	// CEL expression matched instead of headers, body, query and path (optional).
+	// Setting it drops criteria not set in the same request; setting criteria
+	// without it drops the expression.
+	Expression *string `json:"expression,omitempty"`
+
 	// Whether the filter uses flattened JSON paths (optional)
 	IsFlattened *bool `json:"is_flattened"`
 }
@@ -131,15 +143,36 @@ type TestFilterResponse struct {
 	IsMatch bool `json:"is_match"`
 }
 
+// ExplainFiltersRequest represents the request to explain how each subscription's
+// filter in a project evaluates a request
+type ExplainFiltersRequest struct {
+	// Type of event the request is sent as (required)
+	EventType string `json:"event_type" validate:"required"`
+
+	TestFilterRequest
+}
+
+// FilterExplanationResponse describes which filter a subscription selected for
+// an event type and the outcome of each of its conditions
+type FilterExplanationResponse struct {
+	*datastore.FilterExplanation
+}
+
+// FilterRejectionResponse is a subscription whose filter turned an event away
+type FilterRejectionResponse struct {
+	*datastore.FilterRejection
+}
+
 // BulkUpdateFilterRequest is a request to update a filter in bulk
 type BulkUpdateFilterRequest struct {
-	UID       string                 `json:"uid" validate:"required"`
-	EventType string                 `json:"event_type,omitempty"`
-	EnabledAt OptionalTime           `json:"enabled_at,omitempty,omitzero"`
-	Headers   map[string]interface{} `json:"headers,omitempty"`
-	Body      map[string]interface{} `json:"body,omitempty"`
-	Query     map[string]interface{} `json:"query,omitempty"`
-	Path      map[string]interface{} `json:"path,omitempty"`
+	UID        string                 `json:"uid" validate:"required"`
+	EventType  string                 `json:"event_type,omitempty"`
+	EnabledAt  OptionalTime           `json:"enabled_at,omitempty,omitzero"`
+	Headers    map[string]interface{} `json:"headers,omitempty"`
+	Body       map[string]interface{} `json:"body,omitempty"`
+	Query      map[string]interface{} `json:"query,omitempty"`
+	Path       map[string]interface{} `json:"path,omitempty"`
+	Expression *string                `json:"expression,omitempty"`
 }
 
 type OptionalTime struct {
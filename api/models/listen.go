@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+)
+
+type CreateCLIKey struct {
+	// Name of the key, shown when listing the project's keys
+	Name string `json:"name"`
+	// Days until the key expires, defaults to 30
+	Expiration int `json:"expiration"`
+}
+
+func (c *CreateCLIKey) Validate() error {
+	if c.Expiration < 0 || c.Expiration > 365 {
+		return errors.New("expiration must be between 1 and 365 days")
+	}
+
+	return nil
+}
+
+type CLIKeyResponse struct {
+	UID        string    `json:"uid"`
+	Name       string    `json:"name"`
+	ProjectID  string    `json:"project_id"`
+	EndpointID string    `json:"endpoint_id"`
+	Key        string    `json:"key"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CLIDelivery is one event delivery streamed to a convoy listen session.
+type CLIDelivery struct {
+	UID            string                `json:"uid"`
+	EventID        string                `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	SourceID       string                `json:"source_id,omitempty"`
+	Headers        httpheader.HTTPHeader `json:"headers"`
+	URLQueryParams string                `json:"url_query_params,omitempty"`
+	Data           json.RawMessage       `json:"data"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+func NewCLIDelivery(d *datastore.EventDelivery) *CLIDelivery {
+	c := &CLIDelivery{
+		UID:            d.UID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Headers:        d.Headers,
+		URLQueryParams: d.URLQueryParams,
+		CreatedAt:      d.CreatedAt,
+	}
+
+	if d.CLIMetadata != nil {
+		c.SourceID = d.CLIMetadata.SourceID
+	}
+
+	if d.Metadata != nil {
+		c.Data = d.Metadata.Data
+	}
+
+	return c
+}
+
+// CLIDeliveryAck is what a convoy listen session reports after forwarding a
+// delivery to the local server.
+type CLIDeliveryAck struct {
+	// URL the delivery was forwarded to
+	ForwardURL string `json:"forward_url"`
+	// Status code the local server responded with, zero when it could not be reached
+	StatusCode int `json:"status_code"`
+	// Response headers from the local server
+	Headers httpheader.HTTPHeader `json:"headers"`
+	// Response body from the local server
+	Body string `json:"body"`
+	// Why the local server could not be reached
+	Error string `json:"error"`
+
+	RequestedAt time.Time `json:"requested_at"`
+	RespondedAt time.Time `json:"responded_at"`
+}
+
+func (a *CLIDeliveryAck) Validate() error {
+	if a.StatusCode == 0 && a.Error == "" {
+		return errors.New("please provide either a status code or an error")
+	}
+
+	if a.StatusCode != 0 && (a.StatusCode < 100 || a.StatusCode > 599) {
+		return errors.New("status code must be between 100 and 599")
+	}
+
+	return nil
+}
+
+// Succeeded reports whether the local server accepted the delivery.
+func (a *CLIDeliveryAck) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode <= 299
+}
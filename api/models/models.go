@@ -106,6 +106,8 @@ type DashboardSummary struct {
 	Applications int                        `json:"apps" bson:"apps"`
 	Period       string                     `json:"period" bson:"period"`
 	PeriodData   *[]datastore.EventInterval `json:"event_data,omitempty" bson:"event_data"`
+	// EndpointHealth is set when any endpoint in view has health checks.
+	EndpointHealth *EndpointHealthOverview `json:"endpoint_health,omitempty" bson:"-"`
 }
 
 type WebhookRequest struct {
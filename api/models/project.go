@@ -121,6 +121,15 @@ type ProjectConfig struct {
 	// default), a project with templates configured rejects unmatched URLs.
 	AllowUnmatchedDynamicURLs bool `json:"allow_unmatched_dynamic_urls"`
 
+	// EndpointMetricLabels labels the project's delivery metrics with the endpoint id.
+	// When false (the default), they carry an empty endpoint label.
+	EndpointMetricLabels bool `json:"endpoint_metric_labels"`
+
+	// RecordFilterRejections records which subscription filters rejected each
+	// event, kept for a few days. When false (the default), only events no
+	// subscription matched carry a rejection reason.
+	RecordFilterRejections bool `json:"record_filter_rejections"`
+
 	// CircuitBreaker is used to configure the project's circuit breaker settings
 	CircuitBreaker *datastore.CircuitBreakerConfiguration `json:"circuit_breaker"`
 }
@@ -162,6 +171,8 @@ func (pc *ProjectConfig) Transform() *datastore.ProjectConfig {
 		MultipleEndpointSubscriptions: pc.MultipleEndpointSubscriptions,
 		VerifyDynamicEvents:           pc.VerifyDynamicEvents,
 		AllowUnmatchedDynamicURLs:     pc.AllowUnmatchedDynamicURLs,
+		EndpointMetricLabels:          pc.EndpointMetricLabels,
+		RecordFilterRejections:        pc.RecordFilterRejections,
 		SSL:                           pc.SSL.transform(),
 		SearchPolicy:                  pc.SearchPolicy,
 		RateLimit:                     pc.RateLimit.Transform(),
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/frain-dev/convoy/internal/pkg/project_spec"
+)
+
+type ApplyProjectSpec struct {
+	// The document, as a JSON object or as YAML or JSON text
+	Spec json.RawMessage `json:"spec" swaggertype:"object"`
+
+	// Secret values by reference
+	Secrets map[string]string `json:"secrets"`
+
+	// Delete the objects missing from the sections in the document
+	Prune bool `json:"prune"`
+}
+
+func (a *ApplyProjectSpec) Validate() error {
+	if len(a.Spec) == 0 || string(a.Spec) == "null" {
+		return errors.New("please provide a spec")
+	}
+	return nil
+}
+
+// Document parses the spec, which is a JSON string when the document was
+// sent as text.
+func (a *ApplyProjectSpec) Document() (*project_spec.Document, error) {
+	data := []byte(a.Spec)
+
+	var text string
+	if err := json.Unmarshal(a.Spec, &text); err == nil {
+		data = []byte(text)
+	}
+
+	return project_spec.Parse(data)
+}
+
+type ProjectSpecPlanResponse struct {
+	*project_spec.Plan
+
+	// Whether the changes were made
+	Applied bool `json:"applied"`
+}
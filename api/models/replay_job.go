@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type CreateReplayJob struct {
+	// Replay events created at or after this time
+	StartDate time.Time `json:"start_date"`
+	// Replay events created before this time, defaults to now
+	EndDate time.Time `json:"end_date"`
+	// Only replay events of these types
+	EventTypes []string `json:"event_types"`
+	// Only replay events from these sources
+	SourceIDs []string `json:"source_ids"`
+	// Matches event id prefix, idempotency key, event type, and source name
+	Query string `json:"query"`
+	// JSON object the event payload must contain
+	Body json.RawMessage `json:"body" swaggertype:"object"`
+
+	Target ReplayJobTarget `json:"target"`
+
+	// Most events replayed per second, defaults to 50
+	RateLimit int `json:"rate_limit"`
+}
+
+type ReplayJobTarget struct {
+	// subscriptions (default) replays each event to the subscriptions that
+	// match it today; endpoint replays to an existing endpoint; new_endpoint
+	// creates the endpoint first
+	Type datastore.ReplayTargetType `json:"type"`
+	// Endpoint to replay to, for the endpoint target
+	EndpointID string `json:"endpoint_id"`
+	// Endpoint to create and replay to, for the new_endpoint target
+	Endpoint *CreateEndpoint `json:"endpoint,omitempty"`
+}
+
+func (c *CreateReplayJob) Validate() error {
+	if c.Target.Type != datastore.ReplayTargetNewEndpoint {
+		return nil
+	}
+
+	if c.Target.Endpoint == nil {
+		return errors.New("please provide the endpoint to create")
+	}
+
+	return c.Target.Endpoint.Validate()
+}
+
+func (c *CreateReplayJob) Transform() *datastore.ReplayJob {
+	return &datastore.ReplayJob{
+		TargetType: c.Target.Type,
+		EndpointID: c.Target.EndpointID,
+		RateLimit:  c.RateLimit,
+		Filter: datastore.ReplayJobFilter{
+			StartDate:  c.StartDate,
+			EndDate:    c.EndDate,
+			EventTypes: c.EventTypes,
+			SourceIDs:  c.SourceIDs,
+			Query:      c.Query,
+			Body:       c.Body,
+		},
+	}
+}
+
+type ReplayJobResponse struct {
+	*datastore.ReplayJob
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type CreateSavedSearch struct {
+	// Name of the search, unique within the project
+	Name string `json:"name"`
+	// events or event_deliveries
+	Resource datastore.SearchResource `json:"resource"`
+	// Dates are optional; an export of the search supplies its own
+	Filter datastore.SearchFilter `json:"filter"`
+}
+
+func (c *CreateSavedSearch) Transform() *datastore.SavedSearch {
+	return &datastore.SavedSearch{
+		Name:     c.Name,
+		Resource: c.Resource,
+		Filter:   c.Filter,
+	}
+}
+
+type UpdateSavedSearch struct {
+	Name     string                   `json:"name"`
+	Resource datastore.SearchResource `json:"resource"`
+	Filter   datastore.SearchFilter   `json:"filter"`
+}
+
+func (u *UpdateSavedSearch) Transform() *datastore.SavedSearch {
+	return &datastore.SavedSearch{
+		Name:     u.Name,
+		Resource: u.Resource,
+		Filter:   u.Filter,
+	}
+}
+
+type SavedSearchResponse struct {
+	*datastore.SavedSearch
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/frain-dev/convoy/datastore"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/celfilter"
+	"github.com/frain-dev/convoy/pkg/flatten"
 	"github.com/frain-dev/convoy/util"
 )
 
@@ -117,6 +119,27 @@ type FilterSchema struct {
 	Body    interface{} `json:"body"`
 	Query   interface{} `json:"query"`
 	Path    interface{} `json:"path"`
+
+	// CEL expression to test instead of the other schema fields. Only read
+	// from the schema.
+	Expression string `json:"expression,omitempty"`
+}
+
+// ExpressionInput returns the request in the shape a filter expression sees.
+// Path may be the request path itself or a {"path": ...} scope.
+func (fs FilterSchema) ExpressionInput() celfilter.Input {
+	in := celfilter.Input{Body: fs.Body}
+	in.Headers, _ = fs.Headers.(map[string]interface{})
+	in.Query, _ = fs.Query.(map[string]interface{})
+
+	switch p := fs.Path.(type) {
+	case string:
+		in.Path = p
+	case map[string]interface{}:
+		in.Path, _ = p["path"].(string)
+	}
+
+	return in
 }
 
 type TestFilter struct {
@@ -127,6 +150,40 @@ type TestFilter struct {
 	Schema FilterSchema `json:"schema"`
 }
 
+// Transform returns the sample request and the filter the schema describes,
+// flattened the way stored filters are.
+func (tf TestFilter) Transform() (datastore.FilterTestRequest, *datastore.EventTypeFilter, error) {
+	req := datastore.FilterTestRequest{Body: tf.Request.Body}
+	req.Headers, _ = tf.Request.Headers.(map[string]interface{})
+	req.Query, _ = tf.Request.Query.(map[string]interface{})
+
+	switch p := tf.Request.Path.(type) {
+	case string:
+		req.Path = datastore.M{"path": p}
+	case map[string]interface{}:
+		req.Path = p
+	}
+
+	filter := &datastore.EventTypeFilter{Expression: tf.Schema.Expression}
+	for _, scope := range []struct {
+		dst *datastore.M
+		src interface{}
+	}{
+		{&filter.Headers, tf.Schema.Headers},
+		{&filter.Body, tf.Schema.Body},
+		{&filter.Query, tf.Schema.Query},
+		{&filter.Path, tf.Schema.Path},
+	} {
+		flat, err := flatten.Flatten(scope.src)
+		if err != nil {
+			return req, nil, err
+		}
+		*scope.dst = flat
+	}
+
+	return req, filter, nil
+}
+
 type AlertConfiguration struct {
 	// Count
 	Count int `json:"count"`
@@ -206,6 +263,7 @@ func (fc *FilterConfiguration) Transform() *datastore.FilterConfiguration {
 			RawBody:    CloneFilterMap(fc.Filter.Body),
 			RawQuery:   CloneFilterMap(fc.Filter.Query),
 			RawPath:    CloneFilterMap(fc.Filter.Path),
+			Expression: fc.Filter.Expression,
 		},
 	}
 }
@@ -215,6 +273,10 @@ type FS struct {
 	Body    datastore.M `json:"body"`
 	Query   datastore.M `json:"query"`
 	Path    datastore.M `json:"path"`
+
+	// CEL expression matched instead of headers, body, query and path,
+	// e.g. body.amount > 100 && headers["x-tenant"] in ["a", "b"]
+	Expression string `json:"expression,omitempty"`
 }
 
 func (fs *FS) Transform() datastore.FilterSchema {
@@ -227,6 +289,7 @@ func (fs *FS) Transform() datastore.FilterSchema {
 		RawBody:    CloneFilterMap(fs.Body),
 		RawQuery:   CloneFilterMap(fs.Query),
 		RawPath:    CloneFilterMap(fs.Path),
+		Expression: fs.Expression,
 	}
 }
 
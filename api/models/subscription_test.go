@@ -45,3 +45,29 @@ func TestFSTransformDoesNotAliasRawMaps(t *testing.T) {
 
 	require.Equal(t, datastore.M{"meta": datastore.M{"event": "push"}}, schema.RawHeaders)
 }
+
+func TestTestFilterTransformFlattensSchema(t *testing.T) {
+	tf := TestFilter{
+		Request: FilterSchema{
+			Body:    map[string]interface{}{"person": map[string]interface{}{"age": 10}},
+			Headers: map[string]interface{}{"X-Tenant": "a"},
+			Path:    "/webhooks",
+		},
+		Schema: FilterSchema{
+			Body:    map[string]interface{}{"person": map[string]interface{}{"age": map[string]interface{}{"$gte": 5}}},
+			Headers: map[string]interface{}{"X-Tenant": "a"},
+		},
+	}
+
+	req, filter, err := tf.Transform()
+	require.NoError(t, err)
+
+	require.Equal(t, tf.Request.Body, req.Body)
+	require.Equal(t, datastore.M{"X-Tenant": "a"}, req.Headers)
+	require.Equal(t, datastore.M{"path": "/webhooks"}, req.Path)
+
+	require.Equal(t, datastore.M{"person.age": map[string]interface{}{"$gte": 5}}, filter.Body)
+	require.Equal(t, datastore.M{"X-Tenant": "a"}, filter.Headers)
+	require.Empty(t, filter.Query)
+	require.Empty(t, filter.Path)
+}
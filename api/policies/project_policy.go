@@ -78,6 +78,11 @@ func (pp *ProjectPolicy) checkAccess(ctx context.Context, res interface{}, check
 		return ErrNotAllowed
 	}
 
+	// CLI keys only open convoy listen sessions.
+	if apiKey.Type == datastore.CLIKey {
+		return ErrNotAllowed
+	}
+
 	if apiKey.Role.Project != project.UID {
 		return ErrNotAllowed
 	}
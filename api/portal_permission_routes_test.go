@@ -0,0 +1,93 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xdg-go/pbkdf2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/auth/realm_chain"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/testenv"
+)
+
+const (
+	probePortalMaskID = "portal-probe-mask"
+	probePortalSalt   = "portal-probe-salt"
+	probePortalKey    = "PRT." + probePortalMaskID + ".portal-probe-secret"
+)
+
+// initProbePortalRealm authenticates portal link tokens against a mock
+// repository holding link, so portal routes can be hit without a database.
+func initProbePortalRealm(t *testing.T, ctrl *gomock.Controller, cfg config.Configuration, link *datastore.PortalLink) {
+	t.Helper()
+
+	dk := pbkdf2.Key([]byte(probePortalKey), []byte(probePortalSalt), 4096, 32, sha256.New)
+	link.TokenMaskId = probePortalMaskID
+	link.TokenSalt = probePortalSalt
+	link.TokenHash = base64.URLEncoding.EncodeToString(dk)
+	link.AuthType = datastore.PortalAuthTypeRefreshToken
+
+	portalLinkRepo := mocks.NewMockPortalLinkRepository(ctrl)
+	portalLinkRepo.EXPECT().
+		FindPortalLinkByMaskId(gomock.Any(), probePortalMaskID).
+		Return(link, nil).
+		AnyTimes()
+
+	authCfg := cfg.Auth
+	authCfg.Native.Enabled = false
+	authCfg.Jwt.Enabled = false
+	authCfg.Portal.Enabled = true
+
+	require.NoError(t, realm_chain.Init(&authCfg, nil, nil, portalLinkRepo, nil, testenv.NewLogger(t)))
+}
+
+// The data plane serves the same portal replay and retry routes as the
+// control plane, so a link without the permission must be turned away there
+// too, before the handler touches the database.
+func TestDataPlanePortalRoutesRequireLinkPermissions(t *testing.T) {
+	tests := []struct {
+		method     string
+		path       string
+		permission datastore.PortalLinkPermission
+	}{
+		{http.MethodPost, "/portal-api/events/batchreplay", datastore.PortalLinkReplayEvents},
+		{http.MethodGet, "/portal-api/events/countbatchreplayevents", datastore.PortalLinkReplayEvents},
+		{http.MethodPut, "/portal-api/events/evt-1/replay", datastore.PortalLinkReplayEvents},
+		{http.MethodPost, "/portal-api/eventdeliveries/forceresend", datastore.PortalLinkRetryDeliveries},
+		{http.MethodPost, "/portal-api/eventdeliveries/batchretry", datastore.PortalLinkRetryDeliveries},
+		{http.MethodGet, "/portal-api/eventdeliveries/countbatchretryevents", datastore.PortalLinkRetryDeliveries},
+		{http.MethodPut, "/portal-api/eventdeliveries/dlv-1/resend", datastore.PortalLinkRetryDeliveries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := newRateLimitProbeHandler(t, mocks.NewMockRateLimiter(ctrl))
+			initProbePortalRealm(t, ctrl, handler.cfg, &datastore.PortalLink{
+				UID:         "portal-link-1",
+				ProjectID:   probeProjectID,
+				Permissions: []datastore.PortalLinkPermission{datastore.PortalLinkViewPayloads},
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+			req.Header.Set("Authorization", "Bearer "+probePortalKey)
+			w := httptest.NewRecorder()
+
+			handler.BuildDataPlaneRoutes().ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+			require.Contains(t, w.Body.String(), string(tt.permission))
+		})
+	}
+}
@@ -1,75 +1,201 @@
 package mcache
 
 import (
+	"container/list"
 	"context"
-	"errors"
+	"fmt"
 	"sync"
 	"time"
 
-	"github.com/go-redis/cache/v9"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
+// MemoryCache keeps values in process memory, encoded with msgpack like the
+// redis and postgres caches so a value reads back the same whichever backs the
+// instance. It holds at most cacheSize keys and evicts the least recently used
+// one to make room, so it is a cache in front of some other store rather than
+// a store itself. It is private to the process and gone on restart.
 type MemoryCache struct {
-	cache *cache.Cache
-	mu    sync.Mutex
+	mu        sync.Mutex
+	max       int
+	items     map[string]*list.Element
+	order     *list.List // front is most recently used
+	lastSweep time.Time
+}
+
+type item struct {
+	key string
+	raw []byte
+	// expiresAt is zero for a key with no ttl.
+	expiresAt time.Time
+}
+
+func (i *item) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
 }
 
 const cacheSize = 128000
 
+// sweepInterval is how often Set clears out expired keys. Reads skip an
+// expired key on their own; the sweep only returns its memory.
+const sweepInterval = time.Minute
+
 func NewMemoryCache() *MemoryCache {
-	c := cache.New(&cache.Options{
-		LocalCache: cache.NewTinyLFU(cacheSize, time.Second),
-	})
+	return newMemoryCache(cacheSize)
+}
+
+func newMemoryCache(max int) *MemoryCache {
+	return &MemoryCache{
+		max:       max,
+		items:     make(map[string]*list.Element),
+		order:     list.New(),
+		lastSweep: time.Now(),
+	}
+}
+
+// live returns key's bytes if it holds an unexpired value, marking it
+// recently used. Callers hold mu.
+func (m *MemoryCache) live(key string) ([]byte, bool) {
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	it := el.Value.(*item)
+	if it.expired(time.Now()) {
+		m.remove(el)
+		return nil, false
+	}
+	m.order.MoveToFront(el)
+	return it.raw, true
+}
+
+// put stores it under its key, evicting the least recently used keys past
+// max. Callers hold mu.
+func (m *MemoryCache) put(it *item) {
+	if el, ok := m.items[it.key]; ok {
+		el.Value = it
+		m.order.MoveToFront(el)
+		return
+	}
+	m.items[it.key] = m.order.PushFront(it)
+
+	for m.order.Len() > m.max {
+		m.remove(m.order.Back())
+	}
+}
 
-	return &MemoryCache{cache: c}
+// remove drops el from the cache. Callers hold mu.
+func (m *MemoryCache) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.items, el.Value.(*item).key)
 }
 
-func (m *MemoryCache) Set(ctx context.Context, key string, data interface{}, ttl time.Duration) error {
+func (m *MemoryCache) Set(_ context.Context, key string, data interface{}, ttl time.Duration) error {
+	raw, err := msgpack.Marshal(data)
+	if err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.cache.Set(&cache.Item{
-		Ctx:   ctx,
-		Key:   key,
-		Value: data,
-		TTL:   ttl,
-	})
+
+	now := time.Now()
+	it := &item{key: key, raw: raw}
+	if ttl > 0 {
+		it.expiresAt = now.Add(ttl)
+	}
+	m.put(it)
+
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for el := m.order.Front(); el != nil; {
+			next := el.Next()
+			if el.Value.(*item).expired(now) {
+				m.remove(el)
+			}
+			el = next
+		}
+		m.lastSweep = now
+	}
+	return nil
 }
 
-func (m *MemoryCache) Get(ctx context.Context, key string, data interface{}) error {
+// Get leaves data untouched on a miss. A value that will not decode is also a
+// miss, as it is for the postgres cache.
+func (m *MemoryCache) Get(_ context.Context, key string, data interface{}) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-	err := m.cache.Get(ctx, key, &data)
+	raw, ok := m.live(key)
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
 
-	if errors.Is(err, cache.ErrCacheMiss) {
+	if err := msgpack.Unmarshal(raw, data); err != nil {
 		return nil
 	}
+	return nil
+}
 
-	if err != nil {
-		return err
+// GetStrict is Get for authoritative records such as revocation markers: a
+// value that will not decode is an error rather than a miss.
+func (m *MemoryCache) GetStrict(_ context.Context, key string, data interface{}) error {
+	m.mu.Lock()
+	raw, ok := m.live(key)
+	m.mu.Unlock()
+	if !ok {
+		return nil
 	}
 
+	if err := msgpack.Unmarshal(raw, data); err != nil {
+		return fmt.Errorf("decode cache value: %w", err)
+	}
 	return nil
 }
 
-func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+func (m *MemoryCache) Delete(_ context.Context, key string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.cache.Delete(ctx, key)
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+	return nil
 }
 
-func (m *MemoryCache) Consume(ctx context.Context, key string, data interface{}) (bool, error) {
+// Consume reads and deletes a live value in one step, so a one-shot signal is
+// observed once.
+func (m *MemoryCache) Consume(_ context.Context, key string, data interface{}) (bool, error) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	err := m.cache.Get(ctx, key, &data)
-	if errors.Is(err, cache.ErrCacheMiss) {
-		return false, nil
+	raw, ok := m.live(key)
+	if el, found := m.items[key]; found {
+		m.remove(el)
 	}
-	if err != nil {
-		return false, err
+	m.mu.Unlock()
+	if !ok {
+		return false, nil
 	}
-	if err := m.cache.Delete(ctx, key); err != nil {
-		return false, err
+
+	if err := msgpack.Unmarshal(raw, data); err != nil {
+		return false, fmt.Errorf("decode consumed cache value: %w", err)
 	}
 	return true, nil
 }
+
+// GetBytes reads a raw byte value written by GetOrCreateBytes. Miss returns nil, nil.
+func (m *MemoryCache) GetBytes(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	raw, _ := m.live(key)
+	return raw, nil
+}
+
+// GetOrCreateBytes stores value when key is absent and returns what key holds.
+func (m *MemoryCache) GetOrCreateBytes(_ context.Context, key string, value []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if raw, ok := m.live(key); ok {
+		return raw, nil
+	}
+	m.put(&item{key: key, raw: value})
+	return value, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -57,3 +58,78 @@ func (s *MemoryCacheIntegrationTestSuite) Test_DeleteFromCache() {
 func TestMemoryCacheIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(MemoryCacheIntegrationTestSuite))
 }
+
+func (s *MemoryCacheIntegrationTestSuite) Test_ExpiredKeyIsAMiss() {
+	err := s.cache.Set(context.TODO(), key, &data{Name: "test_name"}, time.Millisecond)
+	s.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+
+	var item data
+	s.Require().NoError(s.cache.GetStrict(context.TODO(), key, &item))
+	s.Require().Equal("", item.Name)
+}
+
+func (s *MemoryCacheIntegrationTestSuite) Test_KeyWithoutTTLOutlivesReads() {
+	err := s.cache.Set(context.TODO(), key, &data{Name: "test_name"}, 0)
+	s.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+
+	var item data
+	s.Require().NoError(s.cache.Get(context.TODO(), key, &item))
+	s.Require().Equal("test_name", item.Name)
+}
+
+func (s *MemoryCacheIntegrationTestSuite) Test_ConsumeReadsOnce() {
+	err := s.cache.Set(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
+	s.Require().NoError(err)
+
+	var item data
+	ok, err := s.cache.Consume(context.TODO(), key, &item)
+	s.Require().NoError(err)
+	s.Require().True(ok)
+	s.Require().Equal("test_name", item.Name)
+
+	ok, err = s.cache.Consume(context.TODO(), key, &item)
+	s.Require().NoError(err)
+	s.Require().False(ok)
+}
+
+func (s *MemoryCacheIntegrationTestSuite) Test_GetOrCreateBytesKeepsTheFirstValue() {
+	raw, err := s.cache.GetOrCreateBytes(context.TODO(), key, []byte("first"))
+	s.Require().NoError(err)
+	s.Require().Equal("first", string(raw))
+
+	raw, err = s.cache.GetOrCreateBytes(context.TODO(), key, []byte("second"))
+	s.Require().NoError(err)
+	s.Require().Equal("first", string(raw))
+
+	raw, err = s.cache.GetBytes(context.TODO(), key)
+	s.Require().NoError(err)
+	s.Require().Equal("first", string(raw))
+}
+
+func TestMemoryCacheEvictsTheLeastRecentlyUsedKey(t *testing.T) {
+	c := newMemoryCache(2)
+	ctx := context.TODO()
+
+	require.NoError(t, c.Set(ctx, "a", &data{Name: "a"}, 0))
+	require.NoError(t, c.Set(ctx, "b", &data{Name: "b"}, 0))
+
+	// Reading a makes b the least recently used.
+	var item data
+	require.NoError(t, c.Get(ctx, "a", &item))
+	require.Equal(t, "a", item.Name)
+
+	require.NoError(t, c.Set(ctx, "c", &data{Name: "c"}, 0))
+	require.Len(t, c.items, 2)
+
+	var evicted data
+	require.NoError(t, c.Get(ctx, "b", &evicted))
+	require.Equal(t, "", evicted.Name)
+
+	for _, k := range []string{"a", "c"} {
+		var kept data
+		require.NoError(t, c.Get(ctx, k, &kept))
+		require.Equal(t, k, kept.Name)
+	}
+}
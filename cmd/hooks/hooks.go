@@ -20,6 +20,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/embedded"
 	dbhook "github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/database/listener"
 	"github.com/frain-dev/convoy/database/postgres"
@@ -30,10 +31,13 @@ import (
 	"github.com/frain-dev/convoy/internal/organisations"
 	"github.com/frain-dev/convoy/internal/pkg/broker"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/config_replication"
 	fflag2 "github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/license"
 	"github.com/frain-dev/convoy/internal/pkg/license/service"
 	licenseusage "github.com/frain-dev/convoy/internal/pkg/license/usage"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/internal/pkg/migrator"
 	"github.com/frain-dev/convoy/internal/pkg/tracer"
 	"github.com/frain-dev/convoy/internal/projects"
 	"github.com/frain-dev/convoy/internal/telemetry"
@@ -98,6 +102,14 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 
 		lo := log.New("convoy", logLevel)
 
+		if cfg.Embedded.Enabled {
+			embeddedDB, err := embedded.Start(cfg, lo)
+			if err != nil {
+				return err
+			}
+			app.EmbeddedDB = embeddedDB
+		}
+
 		postgresDB, err := postgres.NewDB(cfg)
 		if err != nil {
 			return errors.New("failed to connect to postgres with err: " + err.Error())
@@ -105,6 +117,15 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 
 		*db = *postgresDB
 
+		// An embedded instance has no separate deploy step to run them. The
+		// broker reads its tables as it starts, so they go first.
+		if cfg.Embedded.Enabled && shouldCheckMigration(cmd) {
+			err = migrator.NewWithLogger(db, lo).Up()
+			if err != nil {
+				return errors.New("embedded migrations failed: " + err.Error())
+			}
+		}
+
 		err = config.LoadCaCert(cfg.Dispatcher.CACertString, cfg.Dispatcher.CACertPath)
 		if err != nil {
 			return err
@@ -126,21 +147,55 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 
 		hooks := dbhook.Init()
 
-		// the order matters here
-		projectListener := listener.NewProjectListener(q, lo)
-		hooks.RegisterHook(datastore.ProjectUpdated, projectListener.AfterUpdate)
+		// Init publishes the hook, so repositories built from here on fire
+		// into it and listeners can use them.
 		projectRepo := projects.New(lo, postgresDB)
-
 		metaEventRepo := meta_events.New(lo, postgresDB)
 		attemptsRepo := delivery_attempts.New(lo, postgresDB)
+
+		projectListener := listener.NewProjectListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.ProjectUpdated, projectListener.AfterUpdate)
+
 		endpointListener := listener.NewEndpointListener(q, projectRepo, metaEventRepo, lo)
 		eventDeliveryListener := listener.NewEventDeliveryListener(q, projectRepo, metaEventRepo, attemptsRepo, lo)
 
 		hooks.RegisterHook(datastore.EndpointCreated, endpointListener.AfterCreate)
 		hooks.RegisterHook(datastore.EndpointUpdated, endpointListener.AfterUpdate)
 		hooks.RegisterHook(datastore.EndpointDeleted, endpointListener.AfterDelete)
+		hooks.RegisterHook(datastore.EndpointPaused, endpointListener.AfterPause)
+		hooks.RegisterHook(datastore.EndpointActivated, endpointListener.AfterActivate)
+		hooks.RegisterHook(datastore.EndpointDisabled, endpointListener.AfterDisable)
+		hooks.RegisterHook(datastore.EndpointSecretRotated, endpointListener.AfterSecretRotate)
+		hooks.RegisterHook(datastore.EndpointSecretExpired, endpointListener.AfterSecretExpire)
 		hooks.RegisterHook(datastore.EventDeliveryUpdated, eventDeliveryListener.AfterUpdate)
 
+		subscriptionListener := listener.NewSubscriptionListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.SubscriptionCreated, subscriptionListener.AfterCreate)
+		hooks.RegisterHook(datastore.SubscriptionUpdated, subscriptionListener.AfterUpdate)
+		hooks.RegisterHook(datastore.SubscriptionDeleted, subscriptionListener.AfterDelete)
+
+		sourceListener := listener.NewSourceListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.SourceCreated, sourceListener.AfterCreate)
+		hooks.RegisterHook(datastore.SourceUpdated, sourceListener.AfterUpdate)
+		hooks.RegisterHook(datastore.SourceDeleted, sourceListener.AfterDelete)
+
+		portalLinkListener := listener.NewPortalLinkListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.PortalLinkCreated, portalLinkListener.AfterCreate)
+		hooks.RegisterHook(datastore.PortalLinkUpdated, portalLinkListener.AfterUpdate)
+		hooks.RegisterHook(datastore.PortalLinkRevoked, portalLinkListener.AfterRevoke)
+
+		eventTypeListener := listener.NewEventTypeListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.EventTypeCreated, eventTypeListener.AfterCreate)
+		hooks.RegisterHook(datastore.EventTypeUpdated, eventTypeListener.AfterUpdate)
+		hooks.RegisterHook(datastore.EventTypeDeprecated, eventTypeListener.AfterDeprecate)
+
+		batchRetryListener := listener.NewBatchRetryListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.BatchRetryCompleted, batchRetryListener.AfterComplete)
+		hooks.RegisterHook(datastore.BatchRetryFailed, batchRetryListener.AfterFail)
+
+		backupJobListener := listener.NewBackupJobListener(q, projectRepo, metaEventRepo, lo)
+		hooks.RegisterHook(datastore.BackupJobCompleted, backupJobListener.AfterComplete)
+
 		if ok := shouldCheckMigration(cmd); ok {
 			err = checkPendingMigrations(lo, db)
 			if err != nil {
@@ -163,6 +218,13 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 		app.Broker = brokerDeps
 
 		if ok := shouldBootstrap(cmd); ok {
+			// A standby's configuration belongs to the replication stream
+			// until it is promoted; bootstrapping would write into it.
+			err = config_replication.EnsureNotStandby(context.Background(), postgresDB.GetConn())
+			if err != nil {
+				return err
+			}
+
 			err = ensureDefaultUser(context.Background(), app)
 			if err != nil {
 				return err
@@ -254,6 +316,11 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 			return err
 		}
 
+		app.Telemetry, err = tracer.InitTelemetry(cfg.Tracer.OTel, cmd.Name(), metrics.Reg())
+		if err != nil {
+			return err
+		}
+
 		return nil
 	}
 }
@@ -338,11 +405,17 @@ var skipHook = map[string]struct{}{
 
 func PostRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
+		if err := app.Telemetry.Shutdown(context.Background()); err != nil {
+			app.Logger.Error("failed to flush telemetry", "error", err)
+		}
+
 		if db == nil || db.GetDB() == nil {
+			StopEmbeddedDB(app)
 			os.Exit(0)
 		}
 
 		err := db.Close()
+		StopEmbeddedDB(app)
 		if err == nil {
 			os.Exit(0)
 		}
@@ -355,6 +428,19 @@ func PostRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args
 	}
 }
 
+// StopEmbeddedDB stops the Postgres an embedded instance started, if any. It
+// runs after the command, or from main when the command failed and PostRun
+// was skipped.
+func StopEmbeddedDB(app *cli.App) {
+	if app.EmbeddedDB == nil {
+		return
+	}
+	if err := app.EmbeddedDB.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+	app.EmbeddedDB = nil
+}
+
 func enableProfiling(cfg config.Configuration, cmd *cobra.Command) error {
 	_, err := pyro.Start(pyro.Config{
 		ApplicationName: cfg.Pyroscope.ProfileID,
@@ -468,6 +554,16 @@ func buildCliConfiguration(cmd *cobra.Command) (*config.Configuration, error) {
 		c.Logger.Level = logLevel
 	}
 
+	// CONVOY_EMBEDDED_ENABLED. Only the server has the flag, but it decides the broker
+	// this hook builds, so it is read here rather than in the server command.
+	if cmd.Flags().Lookup("embedded") != nil {
+		embedded, err := cmd.Flags().GetBool("embedded")
+		if err != nil {
+			return nil, err
+		}
+		c.Embedded.Enabled = embedded
+	}
+
 	// CONVOY_INSTANCE_INGEST_RATE
 	instanceIngestRate, err := cmd.Flags().GetInt("instance-ingest-rate")
 	if err != nil {
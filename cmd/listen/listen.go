@@ -0,0 +1,291 @@
+package listen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+)
+
+const (
+	// maxEventSize bounds one server-sent event, payloads included.
+	maxEventSize = 10 * 1024 * 1024
+	// maxResponseSize bounds the local response body reported back.
+	maxResponseSize = 64 * 1024
+)
+
+func AddListenCommand() *cobra.Command {
+	l := &Listener{}
+
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Forward an endpoint's event deliveries to a local server",
+		Long: `Stream the deliveries of the endpoint a CLI key belongs to and forward each one to a local URL,
+reporting the local server's response back to Convoy as a delivery attempt.
+Create a CLI key with POST /api/v1/projects/{projectID}/endpoints/{endpointID}/cli-keys.`,
+		Example: `convoy listen --host https://convoy.example.com --api-key $CONVOY_CLI_KEY --project 01H... --forward-to http://localhost:3000/webhooks`,
+		// listen only talks to a remote Convoy instance, it needs neither
+		// the local configuration nor a database.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if l.APIKey == "" {
+				l.APIKey = os.Getenv("CONVOY_CLI_KEY")
+			}
+
+			if l.APIKey == "" {
+				return errors.New("a cli key is required, pass --api-key or set CONVOY_CLI_KEY")
+			}
+
+			if _, err := url.ParseRequestURI(l.ForwardTo); err != nil {
+				return fmt.Errorf("invalid --forward-to url: %v", err)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			l.Out = cmd.OutOrStdout()
+			err := l.Run(ctx)
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&l.Host, "host", "http://localhost:5005", "Convoy instance URL")
+	cmd.Flags().StringVar(&l.APIKey, "api-key", "", "CLI key, defaults to the CONVOY_CLI_KEY environment variable")
+	cmd.Flags().StringVar(&l.ProjectID, "project", "", "Project ID the CLI key belongs to")
+	cmd.Flags().StringVar(&l.SourceID, "source", "", "Source ID to listen on, required for incoming projects")
+	cmd.Flags().StringSliceVar(&l.EventTypes, "events", []string{}, "Event types to listen for e.g. \"invoice.paid,invoice.failed\", defaults to all")
+	cmd.Flags().StringVar(&l.ForwardTo, "forward-to", "", "Local URL to forward deliveries to e.g. http://localhost:3000/webhooks")
+
+	_ = cmd.MarkFlagRequired("forward-to")
+
+	return cmd
+}
+
+// Listener streams deliveries from a Convoy instance and forwards them to a
+// local server one at a time, in the order they were created.
+type Listener struct {
+	Host       string
+	APIKey     string
+	ProjectID  string
+	SourceID   string
+	EventTypes []string
+	ForwardTo  string
+	Out        io.Writer
+
+	// Stream carries the long lived session and must not time out,
+	// Client forwards and acknowledges deliveries.
+	Stream *http.Client
+	Client *http.Client
+}
+
+func (l *Listener) Run(ctx context.Context) error {
+	if l.Stream == nil {
+		l.Stream = &http.Client{}
+	}
+	if l.Client == nil {
+		l.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if l.Out == nil {
+		l.Out = io.Discard
+	}
+
+	q := url.Values{}
+	if l.ProjectID != "" {
+		q.Set("project_id", l.ProjectID)
+	}
+	if l.SourceID != "" {
+		q.Set("source_id", l.SourceID)
+	}
+	if len(l.EventTypes) > 0 {
+		q.Set("event_types", strings.Join(l.EventTypes, ","))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.apiURL("/listen")+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+l.APIKey)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := l.Stream.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to open listen session: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to open listen session: %s", responseError(resp))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var event string
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if event != "" || data.Len() > 0 {
+				l.dispatch(ctx, event, data.Bytes())
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// comment, the server's heartbeat
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("listen session ended: %w", err)
+	}
+
+	return errors.New("listen session closed by the server")
+}
+
+func (l *Listener) dispatch(ctx context.Context, event string, data []byte) {
+	switch event {
+	case "ready":
+		fmt.Fprintf(l.Out, "Ready! Forwarding deliveries to %s (press Ctrl+C to quit)\n", l.ForwardTo)
+	case "delivery":
+		var d models.CLIDelivery
+		if err := json.Unmarshal(data, &d); err != nil {
+			fmt.Fprintf(l.Out, "failed to decode delivery: %v\n", err)
+			return
+		}
+
+		ack := l.forward(ctx, &d)
+		status := fmt.Sprintf("%d", ack.StatusCode)
+		if ack.Error != "" {
+			status = ack.Error
+		}
+		fmt.Fprintf(l.Out, "%s  --> %s [%s] %s\n", time.Now().Format(time.DateTime), d.EventType, d.UID, status)
+
+		if err := l.ack(ctx, d.UID, ack); err != nil {
+			fmt.Fprintf(l.Out, "failed to acknowledge delivery %s: %v\n", d.UID, err)
+		}
+	}
+}
+
+func (l *Listener) forward(ctx context.Context, d *models.CLIDelivery) *models.CLIDeliveryAck {
+	target := l.ForwardTo
+	if d.URLQueryParams != "" {
+		sep := "?"
+		if strings.Contains(target, "?") {
+			sep = "&"
+		}
+		target += sep + d.URLQueryParams
+	}
+
+	ack := &models.CLIDeliveryAck{ForwardURL: target, RequestedAt: time.Now()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(d.Data))
+	if err != nil {
+		ack.Error = err.Error()
+		return ack
+	}
+
+	for k, values := range d.Headers {
+		if strings.EqualFold(k, "Host") || strings.EqualFold(k, "Content-Length") {
+			continue
+		}
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Convoy-Event-Delivery-Id", d.UID)
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		ack.Error = err.Error()
+		return ack
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	ack.RespondedAt = time.Now()
+	ack.StatusCode = resp.StatusCode
+	ack.Headers = httpheader.HTTPHeader(resp.Header)
+	ack.Body = string(body)
+
+	return ack
+}
+
+func (l *Listener) ack(ctx context.Context, deliveryID string, ack *models.CLIDeliveryAck) error {
+	body, err := json.Marshal(ack)
+	if err != nil {
+		return err
+	}
+
+	u := l.apiURL("/listen/deliveries/" + url.PathEscape(deliveryID) + "/ack")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+l.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(responseError(resp))
+	}
+
+	return nil
+}
+
+func (l *Listener) apiURL(path string) string {
+	return strings.TrimSuffix(l.Host, "/") + "/api/v1" + path
+}
+
+// responseError prefers the message of a Convoy error response.
+func responseError(resp *http.Response) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err := json.Unmarshal(b, &body); err == nil && body.Message != "" {
+		return fmt.Sprintf("%s (%d)", body.Message, resp.StatusCode)
+	}
+
+	return resp.Status
+}
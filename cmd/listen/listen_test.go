@@ -0,0 +1,136 @@
+package listen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/api/models"
+)
+
+func TestListener_ForwardsAndAcknowledgesDeliveries(t *testing.T) {
+	type forwarded struct {
+		path, query, body, signature string
+	}
+
+	var mu sync.Mutex
+	var received []forwarded
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, forwarded{r.URL.Path, r.URL.RawQuery, string(body), r.Header.Get("X-Convoy-Signature")})
+		mu.Unlock()
+
+		if strings.Contains(string(body), "fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer local.Close()
+
+	acks := make(map[string]*models.CLIDeliveryAck)
+	done := make(chan struct{})
+	convoy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer CO.cli.key", r.Header.Get("Authorization"))
+
+		if r.Method == http.MethodPost {
+			var ack models.CLIDeliveryAck
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&ack))
+
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/listen/deliveries/"), "/ack")
+			mu.Lock()
+			acks[id] = &ack
+			if len(acks) == 2 {
+				close(done)
+			}
+			mu.Unlock()
+
+			_, _ = w.Write([]byte(`{"status":true,"message":"Event delivery acknowledged"}`))
+			return
+		}
+
+		require.Equal(t, "/api/v1/listen", r.URL.Path)
+		require.Equal(t, "project-1", r.URL.Query().Get("project_id"))
+		require.Equal(t, "invoice.paid,invoice.failed", r.URL.Query().Get("event_types"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "event: ready\ndata: {\"subscription_id\":\"sub-1\"}\n\n")
+		_, _ = fmt.Fprint(w, ": ping\n\n")
+		_, _ = fmt.Fprint(w, "id: delivery-1\nevent: delivery\ndata: {\"uid\":\"delivery-1\",\"event_type\":\"invoice.paid\",\"headers\":{\"X-Convoy-Signature\":[\"sig\"]},\"url_query_params\":\"a=b\",\"data\":{\"amount\":10}}\n\n")
+		_, _ = fmt.Fprint(w, "id: delivery-2\nevent: delivery\ndata: {\"uid\":\"delivery-2\",\"event_type\":\"invoice.failed\",\"data\":{\"status\":\"fail\"}}\n\n")
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer convoy.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var out strings.Builder
+	l := &Listener{
+		Host:       convoy.URL,
+		APIKey:     "CO.cli.key",
+		ProjectID:  "project-1",
+		EventTypes: []string{"invoice.paid", "invoice.failed"},
+		ForwardTo:  local.URL + "/webhooks",
+		Out:        &out,
+	}
+
+	err := l.Run(ctx)
+	require.EqualError(t, err, "listen session closed by the server")
+
+	require.Equal(t, []forwarded{
+		{"/webhooks", "a=b", `{"amount":10}`, "sig"},
+		{"/webhooks", "", `{"status":"fail"}`, ""},
+	}, received)
+
+	require.Equal(t, http.StatusOK, acks["delivery-1"].StatusCode)
+	require.Equal(t, "ok", acks["delivery-1"].Body)
+	require.Equal(t, local.URL+"/webhooks?a=b", acks["delivery-1"].ForwardURL)
+	require.True(t, acks["delivery-1"].Succeeded())
+
+	require.Equal(t, http.StatusInternalServerError, acks["delivery-2"].StatusCode)
+	require.False(t, acks["delivery-2"].Succeeded())
+
+	require.Contains(t, out.String(), "Ready! Forwarding deliveries to "+local.URL+"/webhooks")
+	require.Contains(t, out.String(), "invoice.paid [delivery-1] 200")
+}
+
+func TestListener_ReportsUnreachableLocalServer(t *testing.T) {
+	local := httptest.NewServer(http.NotFoundHandler())
+	forwardTo := local.URL
+	local.Close()
+
+	l := &Listener{ForwardTo: forwardTo, Client: &http.Client{Timeout: time.Second}}
+	ack := l.forward(context.Background(), &models.CLIDelivery{UID: "delivery-1", Data: []byte(`{}`)})
+
+	require.Zero(t, ack.StatusCode)
+	require.NotEmpty(t, ack.Error)
+	require.False(t, ack.Succeeded())
+}
+
+func TestListener_SessionRejected(t *testing.T) {
+	convoy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"status":false,"message":"a cli key is required"}`))
+	}))
+	defer convoy.Close()
+
+	l := &Listener{Host: convoy.URL, APIKey: "CO.project.key", ForwardTo: "http://localhost:3000"}
+	err := l.Run(context.Background())
+	require.EqualError(t, err, "failed to open listen session: a cli key is required (401)")
+}
@@ -14,8 +14,11 @@ import (
 	configCmd "github.com/frain-dev/convoy/cmd/config"
 	"github.com/frain-dev/convoy/cmd/ff"
 	"github.com/frain-dev/convoy/cmd/hooks"
+	"github.com/frain-dev/convoy/cmd/listen"
 	"github.com/frain-dev/convoy/cmd/migrate"
 	"github.com/frain-dev/convoy/cmd/openapi"
+	"github.com/frain-dev/convoy/cmd/projectspec"
+	"github.com/frain-dev/convoy/cmd/replication"
 	"github.com/frain-dev/convoy/cmd/retry"
 	"github.com/frain-dev/convoy/cmd/server"
 	"github.com/frain-dev/convoy/cmd/utils"
@@ -167,7 +170,10 @@ func main() {
 	c.AddCommand(version.AddVersionCommand())
 	c.AddCommand(server.AddServerCommand(app))
 	c.AddCommand(backup.AddBackupCommand(app))
+	c.AddCommand(replication.AddReplicationCommand(app))
 	c.AddCommand(retry.AddRetryCommand(app))
+	c.AddCommand(projectspec.AddExportCommand(app))
+	c.AddCommand(projectspec.AddApplyCommand(app))
 	c.AddCommand(migrate.AddMigrateCommand(app))
 	c.AddCommand(configCmd.AddConfigCommand())
 	c.AddCommand(bootstrap.AddBootstrapCommand(app))
@@ -175,8 +181,10 @@ func main() {
 	c.AddCommand(ff.AddFeatureFlagsCommand())
 	c.AddCommand(utils.AddUtilsCommand(app))
 	c.AddCommand(openapi.AddOpenAPICommand())
+	c.AddCommand(listen.AddListenCommand())
 
 	if err = c.Execute(); err != nil {
+		hooks.StopEmbeddedDB(app)
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,236 @@
+package projectspec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/datastore/cached"
+	"github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/internal/filters"
+	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/project_spec"
+	"github.com/frain-dev/convoy/internal/portal_links"
+	"github.com/frain-dev/convoy/internal/projects"
+	"github.com/frain-dev/convoy/internal/sources"
+	"github.com/frain-dev/convoy/internal/subscriptions"
+	"github.com/frain-dev/convoy/services"
+)
+
+func AddExportCommand(a *cli.App) *cobra.Command {
+	var projectID string
+	var format string
+	var output string
+	var secretsFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a project's configuration as a document",
+		Long: `Export writes a project's endpoints, subscriptions, sources, event types, portal links
+and project config as a YAML or JSON document that convoy apply accepts. Secrets are written
+as references. Their values go to --secrets-file when it is set, and are left out otherwise.`,
+		Annotations: map[string]string{
+			"ShouldBootstrap": "false",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != project_spec.FormatYAML && format != project_spec.FormatJSON {
+				return fmt.Errorf("unsupported format %q, use yaml or json", format)
+			}
+
+			svc, err := newService(cmd, a, projectID)
+			if err != nil {
+				return err
+			}
+
+			doc, values, err := svc.Export(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			b, err := project_spec.Encode(doc, format)
+			if err != nil {
+				return err
+			}
+
+			if secretsFile != "" {
+				if err = writeSecrets(secretsFile, values); err != nil {
+					return err
+				}
+			} else if len(values) > 0 {
+				refs := make([]string, 0, len(values))
+				for ref := range values {
+					refs = append(refs, ref)
+				}
+				sort.Strings(refs)
+				fmt.Fprintf(cmd.ErrOrStderr(), "Secret values were not exported, set these before applying: %s\n", strings.Join(refs, ", "))
+			}
+
+			if output == "" || output == "-" {
+				_, err = cmd.OutOrStdout().Write(b)
+				return err
+			}
+			return os.WriteFile(output, b, 0o644)
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "ID of the project to export")
+	cmd.Flags().StringVar(&format, "format", project_spec.FormatYAML, "Document format, yaml or json")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write the document to, stdout when empty")
+	cmd.Flags().StringVar(&secretsFile, "secrets-file", "", "File to write secret values to, by reference")
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func AddApplyCommand(a *cli.App) *cobra.Command {
+	var projectID string
+	var file string
+	var secretsFile string
+	var dryRun bool
+	var prune bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a configuration document to a project",
+		Long: `Apply makes a project match a document written by convoy export, printing the plan first.
+A section left out of the document is not touched. Objects missing from a section are only
+deleted with --prune. Secret references are read from --secrets-file, then the environment.`,
+		Annotations: map[string]string{
+			"ShouldBootstrap": "false",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := readDocument(cmd, file)
+			if err != nil {
+				return err
+			}
+
+			doc, err := project_spec.Parse(b)
+			if err != nil {
+				return err
+			}
+
+			resolver := project_spec.Resolvers{project_spec.Env}
+			if secretsFile != "" {
+				secrets, err := readSecrets(secretsFile)
+				if err != nil {
+					return err
+				}
+				resolver = project_spec.Resolvers{secrets, project_spec.Env}
+			}
+
+			svc, err := newService(cmd, a, projectID)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			plan, err := svc.Plan(cmd.Context(), doc, resolver, prune)
+			if err != nil {
+				return err
+			}
+			if err = plan.Write(out); err != nil {
+				return err
+			}
+
+			if dryRun || plan.Empty() {
+				return nil
+			}
+
+			applied, err := svc.Apply(cmd.Context(), doc, resolver, prune)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(out, "\nApplied %d change(s).\n", len(applied.Changes))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Document to apply, - for stdin")
+	cmd.Flags().StringVar(&projectID, "project", "", "ID of the project to apply the document to")
+	cmd.Flags().StringVar(&secretsFile, "secrets-file", "", "YAML or JSON file of secret values by reference")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan without applying it")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Delete objects missing from the sections in the document")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+// newService builds the service with the same repositories the API writes
+// through, so the changes invalidate what the server and agents cached.
+func newService(cmd *cobra.Command, a *cli.App, projectID string) (*services.ProjectSpecService, error) {
+	var projectRepo datastore.ProjectRepository = projects.New(a.Logger, a.DB)
+	var endpointRepo datastore.EndpointRepository = endpoints.New(a.Logger, a.DB)
+	var subRepo datastore.SubscriptionRepository = subscriptions.New(a.Logger, a.DB)
+	var filterRepo datastore.FilterRepository = filters.New(a.Logger, a.DB)
+	var portalLinkRepo datastore.PortalLinkRepository = portal_links.New(a.Logger, a.DB)
+
+	if a.Cache != nil {
+		projectRepo = cached.NewCachedProjectRepository(projectRepo, a.Cache, cached.DefaultProjectTTL, a.Logger)
+		endpointRepo = cached.NewInvalidatingEndpointRepository(endpointRepo, a.Cache, a.Logger)
+		subRepo = cached.NewCachedSubscriptionRepository(subRepo, a.Cache, cached.DefaultSubscriptionTTL, a.Logger)
+		filterRepo = cached.NewCachedFilterRepository(filterRepo, a.Cache, cached.DefaultFilterTTL, a.Logger)
+		portalLinkRepo = cached.NewCachedPortalLinkRepository(portalLinkRepo, a.Cache, 5*time.Minute, a.Logger)
+	}
+
+	project, err := projects.New(a.Logger, a.DB).FetchProjectByID(cmd.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrProjectNotFound) {
+			return nil, fmt.Errorf("project %s does not exist", projectID)
+		}
+		return nil, fmt.Errorf("failed to fetch project: %w", err)
+	}
+
+	return &services.ProjectSpecService{
+		ProjectRepo:    projectRepo,
+		EndpointRepo:   endpointRepo,
+		SourceRepo:     sources.New(a.Logger, a.DB),
+		SubRepo:        subRepo,
+		FilterRepo:     filterRepo,
+		EventTypesRepo: event_types.New(a.Logger, a.DB),
+		PortalLinkRepo: portalLinkRepo,
+		Licenser:       a.Licenser,
+		Logger:         a.Logger,
+		Project:        project,
+	}, nil
+}
+
+func readDocument(cmd *cobra.Command, file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	return os.ReadFile(filepath.Clean(file))
+}
+
+func readSecrets(file string) (project_spec.SecretMap, error) {
+	b, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, so this reads both.
+	secrets := project_spec.SecretMap{}
+	if err = yaml.Unmarshal(b, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid secrets file: %w", err)
+	}
+	return secrets, nil
+}
+
+func writeSecrets(file string, values map[string]string) error {
+	b, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, b, 0o600)
+}
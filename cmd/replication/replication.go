@@ -0,0 +1,214 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/config_replication"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+)
+
+// dropSlotTimeout bounds how long promote keeps trying to release the slot
+// on the source. A failover usually means the source is gone, so promote
+// warns and carries on rather than wait for it.
+const dropSlotTimeout = 30 * time.Second
+
+func AddReplicationCommand(a *cli.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replication",
+		Short: "Replicate configuration to a standby region",
+		Long: `Keep a standby region's projects, endpoints, subscriptions, sources and secrets in step
+with the primary region's over Postgres logical replication. Run it in the standby region,
+with database.* pointing at the standby and config_replication.source_dsn at the primary.`,
+		Annotations: map[string]string{
+			"ShouldBootstrap": "false",
+		},
+	}
+
+	cmd.AddCommand(addRunCommand(a))
+	cmd.AddCommand(addStatusCommand(a))
+	cmd.AddCommand(addPromoteCommand(a))
+
+	return cmd
+}
+
+func addRunCommand(a *cli.App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "run",
+		Short: "Stream configuration changes from the source into this standby",
+		Annotations: map[string]string{
+			"ShouldBootstrap": "false",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Get()
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			r := config_replication.New(a.DB.GetConn(), cfg.ConfigReplication, a.Logger)
+
+			srv := &http.Server{
+				Addr:              fmt.Sprintf(":%d", cfg.ConfigReplication.MetricsPort),
+				Handler:           metricsHandler(cfg, a, r),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			go func() {
+				if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					a.Logger.Error("config replication metrics server", "error", err)
+				}
+			}()
+			defer func() {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			return r.Run(ctx)
+		},
+	}
+}
+
+// metricsHandler serves /metrics under the same conditions as the API's.
+func metricsHandler(cfg config.Configuration, a *cli.App, r *config_replication.Replicator) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if !cfg.Metrics.IsEnabled || !a.Licenser.CanExportPrometheusMetrics() {
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "Prometheus metrics export is not enabled", http.StatusMethodNotAllowed)
+		})
+		return mux
+	}
+
+	if err := metrics.Reg().Register(r); err != nil {
+		a.Logger.Error("register config replication metrics", "error", err)
+	}
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Reg(), promhttp.HandlerOpts{Registry: metrics.Reg()}))
+	return mux
+}
+
+func addStatusCommand(a *cli.App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show how far this standby has replicated",
+		Annotations: map[string]string{
+			"ShouldBootstrap": "false",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Get()
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+
+			s, err := config_replication.LoadState(cmd.Context(), a.DB.GetConn(), cfg.ConfigReplication.SlotName)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Slot:               %s\n", s.SlotName)
+			fmt.Fprintf(out, "Applied LSN:        %s\n", s.AppliedLSN)
+			fmt.Fprintf(out, "Source WAL end:     %s\n", s.SourceWALEnd)
+			fmt.Fprintf(out, "Bytes behind:       %d\n", s.BytesBehind())
+			fmt.Fprintf(out, "Last source commit: %s\n", formatTime(s.SourceCommitAt))
+			fmt.Fprintf(out, "Last heartbeat:     %s (%s ago)\n", s.HeartbeatAt.Format(time.RFC3339), time.Since(s.HeartbeatAt).Round(time.Second))
+			fmt.Fprintf(out, "Promoted:           %s\n", formatTime(s.PromotedAt))
+			return nil
+		},
+	}
+}
+
+func addPromoteCommand(a *cli.App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "promote",
+		Short: "Make this standby writable and stop replicating into it",
+		Long: `Promote stops replication into this standby so the server and agent can start against it.
+It then drops the replication slot on the source, if the source can still be reached, so the
+source stops retaining WAL for a standby that will never read it again. Promotion cannot be
+undone: to fail back, seed the old primary again as a standby of this one.`,
+		Annotations: map[string]string{
+			"ShouldBootstrap": "false",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Get()
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+			slot := cfg.ConfigReplication.SlotName
+
+			s, err := config_replication.Promote(cmd.Context(), a.DB.GetConn(), slot)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Promoted at %s, applied up to %s (last source commit %s)\n",
+				s.PromotedAt.Format(time.RFC3339), s.AppliedLSN, formatTime(s.SourceCommitAt))
+
+			if cfg.ConfigReplication.SourceDSN == "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: no source DSN configured; drop slot %q on the old primary by hand\n", slot)
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), dropSlotTimeout)
+			defer cancel()
+			if err = dropSourceSlot(ctx, cfg.ConfigReplication.SourceDSN, slot); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not drop slot %q on the source: %v\nDrop it once the source is back, or it will retain WAL indefinitely.\n", slot, err)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Dropped slot %q on the source\n", slot)
+			return nil
+		},
+	}
+}
+
+// dropSourceSlot drops the slot, retrying while a replicator that has not yet
+// seen the promotion still holds it.
+func dropSourceSlot(ctx context.Context, dsn, slot string) error {
+	for {
+		err := func() error {
+			conn, err := pgx.Connect(ctx, dsn)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = conn.Close(context.Background()) }()
+
+			_, err = conn.Exec(ctx, `
+				SELECT pg_drop_replication_slot(slot_name)
+				FROM pg_replication_slots
+				WHERE slot_name = $1`, slot)
+			return err
+		}()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -32,8 +32,10 @@ func AddRetryCommand(a *cli.App) *cobra.Command {
 				os.Exit(1)
 			}
 
-			if len(cfg.Redis.BuildDsn()) == 0 {
-				fmt.Fprintf(os.Stderr, "Queue type error: Command is available for redis queue only: %v\n", err)
+			// Requeueing goes through the broker queue, so a postgres-backed
+			// deployment needs no Redis for it.
+			if cfg.QueueProvider != config.PostgresQueueProvider && len(cfg.Redis.BuildDsn()) == 0 {
+				fmt.Fprintln(os.Stderr, "Queue type error: Command needs a redis dsn or the postgres queue provider")
 				os.Exit(1)
 			}
 
@@ -18,6 +18,7 @@ import (
 	"github.com/frain-dev/convoy/datastore/cached"
 	"github.com/frain-dev/convoy/internal/api_keys"
 	"github.com/frain-dev/convoy/internal/configuration"
+	"github.com/frain-dev/convoy/internal/dataplane"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
 	"github.com/frain-dev/convoy/internal/pkg/exporter"
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
@@ -48,6 +49,7 @@ func AddServerCommand(a *cli.App) *cobra.Command {
 	var nativeRealmEnabled bool
 
 	var ssl bool
+	var embedded bool
 	var port uint32
 	var maxResponseSize uint64
 
@@ -91,6 +93,7 @@ func AddServerCommand(a *cli.App) *cobra.Command {
 
 	cmd.Flags().BoolVar(&ssl, "ssl", false, "Configure SSL")
 	cmd.Flags().BoolVar(&nativeRealmEnabled, "native", false, "Enable native-realm authentication")
+	cmd.Flags().BoolVar(&embedded, "embedded", false, "Run the API, worker and scheduler in one process on a Postgres it starts itself, applying migrations at boot. Needs --enable-feature-flag postgres-queue")
 
 	cmd.Flags().Uint32Var(&port, "port", 0, "Server port")
 	cmd.Flags().Uint64Var(&maxResponseSize, "max-response-size", 0, "Max response size")
@@ -195,6 +198,12 @@ func StartConvoyServer(a *cli.App) error {
 	s.RegisterTask("15 2 * * *", convoy.ScheduleQueue, convoy.SnapshotUsage)
 	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.RefreshEventDeliveryDailyCounts)
 	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.RefreshQueueMetricsSnapshot)
+	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.RunEndpointHealthChecks)
+	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.NotifyEventTypeVersionSunsets)
+	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.RunAlertRules)
+	s.RegisterTask("30 1 * * *", convoy.ScheduleQueue, convoy.PruneCircuitBreakerTransitions)
+	s.RegisterTask("45 * * * *", convoy.ScheduleQueue, convoy.PruneFilterRejections)
+	s.RegisterTask("* * * * *", convoy.ScheduleQueue, convoy.ReapCLISubscriptions)
 
 	err = metrics.RegisterQueueMetrics(a.Queue, a.DB, nil)
 	if err != nil {
@@ -204,6 +213,13 @@ func StartConvoyServer(a *cli.App) error {
 	// Start scheduler
 	s.Start()
 
+	if cfg.Embedded.Enabled {
+		err = startEmbeddedDataPlane(a, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
 	a.Logger.Infof("Started convoy server in %s", time.Since(start))
 
 	// Fail open: queries seq-scan until owed indexes are valid. Do not block
@@ -229,6 +245,44 @@ func StartConvoyServer(a *cli.App) error {
 	return nil
 }
 
+// embeddedSyncInterval is the agent command's default interval, in seconds,
+// for refreshing the in-memory store from the database.
+const embeddedSyncInterval = 10
+
+// startEmbeddedDataPlane runs the worker and ingest in this process, as the
+// agent command would in its own. The data plane routes listen on the agent
+// port beside the control plane. The process exits if the data plane stops,
+// rather than keep accepting events nothing will deliver.
+func startEmbeddedDataPlane(a *cli.App, cfg config.Configuration) error {
+	if cfg.Server.HTTP.AgentPort == cfg.Server.HTTP.Port {
+		return errors.New("embedded mode needs the agent port to differ from the server port")
+	}
+
+	ctx := context.Background()
+	runtime, err := dataplane.New(ctx, dataplane.RuntimeOpts{
+		DB:            a.DB,
+		Queue:         a.Queue,
+		Logger:        a.Logger,
+		Cache:         a.Cache,
+		Rate:          a.Rate,
+		Licenser:      a.Licenser,
+		TracerBackend: a.TracerBackend,
+		Broker:        a.Broker,
+	}, cfg, embeddedSyncInterval)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := runtime.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			a.Logger.Fatal("embedded data plane stopped", "error", err)
+		}
+	}()
+
+	a.Logger.Info("Started embedded data plane")
+	return nil
+}
+
 func buildServerCliConfiguration(cmd *cobra.Command) (*config.Configuration, error) {
 	c := &config.Configuration{}
 
@@ -10,11 +10,15 @@ import (
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/datastore/cached"
+	"github.com/frain-dev/convoy/internal/circuit_breakers"
+	"github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/meta_events"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
 	"github.com/frain-dev/convoy/internal/projects"
 	cb "github.com/frain-dev/convoy/pkg/circuit_breaker"
 	"github.com/frain-dev/convoy/pkg/clock"
 	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/services"
 )
 
 func circuitBreakerStore(a *cli.App) (cb.CircuitBreakerStore, error) {
@@ -37,6 +41,10 @@ func AddCircuitBreakersCommand(a *cli.App) *cobra.Command {
 
 	cmd.AddCommand(AddCircuitBreakersGetCommand(a))
 	cmd.AddCommand(AddCircuitBreakersUpdateCommand(a))
+	cmd.AddCommand(AddCircuitBreakersStateCommand(a, "force-open", "hold a circuit breaker open", services.CircuitBreakerForceOpen))
+	cmd.AddCommand(AddCircuitBreakersStateCommand(a, "force-close", "hold a circuit breaker closed", services.CircuitBreakerForceClose))
+	cmd.AddCommand(AddCircuitBreakersStateCommand(a, "reset", "reset a circuit breaker and lift any hold", services.CircuitBreakerReset))
+	cmd.AddCommand(AddCircuitBreakersHistoryCommand(a))
 
 	return cmd
 }
@@ -103,6 +111,7 @@ func AddCircuitBreakersGetCommand(a *cli.App) *cobra.Command {
 				"total_successes":      breaker.TotalSuccesses,
 				"consecutive_failures": breaker.ConsecutiveFailures,
 				"notifications_sent":   breaker.NotificationsSent,
+				"forced":               breaker.Forced,
 			}
 
 			// Print as JSON
@@ -266,3 +275,97 @@ func AddCircuitBreakersUpdateCommand(a *cli.App) *cobra.Command {
 
 	return cmd
 }
+
+func AddCircuitBreakersStateCommand(a *cli.App, use, short string, action services.CircuitBreakerAction) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   use + " [project-id] [endpoint-id]",
+		Short: short,
+		Long:  short + " for a specific endpoint",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID, endpointID := args[0], strings.TrimPrefix(args[1], "breaker:")
+
+			store, err := circuitBreakerStore(a)
+			if err != nil {
+				return fmt.Errorf("failed to create circuit breaker store: %v", err)
+			}
+
+			cbManager, err := cb.NewCircuitBreakerManager(
+				cb.ConfigProviderOption(func(string) *cb.CircuitBreakerConfig { return nil }),
+				cb.StoreOption(store),
+				cb.ClockOption(clock.NewRealClock()),
+				cb.LoggerOption(a.Logger),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create circuit breaker manager: %v", err)
+			}
+
+			projectRepo := projects.New(a.Logger, a.DB)
+			endpointRepo := endpoints.New(a.Logger, a.DB)
+
+			us := services.UpdateCircuitBreakerStateService{
+				Repo:         circuit_breakers.New(a.Logger, a.DB),
+				EndpointRepo: endpointRepo,
+				Manager:      cbManager,
+				ProjectID:    projectID,
+				EndpointID:   endpointID,
+				Action:       action,
+				Reason:       reason,
+				Notifier: &services.CircuitBreakerTransitionNotifier{
+					ProjectRepo:  projectRepo,
+					EndpointRepo: endpointRepo,
+					MetaEvent:    services.NewMetaEvent(a.Queue, projectRepo, meta_events.New(a.Logger, a.DB), a.Logger),
+					Queue:        a.Queue,
+					Licenser:     a.Licenser,
+					Logger:       a.Logger,
+				},
+				Logger: a.Logger,
+			}
+
+			breaker, err := us.Run(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to update circuit breaker: %v", err)
+			}
+
+			fmt.Printf("Circuit breaker for endpoint %s is now %s (forced: %t)\n", endpointID, breaker.State, breaker.Forced)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "why the circuit breaker is being changed")
+
+	return cmd
+}
+
+func AddCircuitBreakersHistoryCommand(a *cli.App) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history [project-id] [endpoint-id]",
+		Short: "list circuit breaker transitions",
+		Long:  "list the state transitions of an endpoint's circuit breaker, newest first",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectID, endpointID := args[0], strings.TrimPrefix(args[1], "breaker:")
+
+			transitions, err := circuit_breakers.New(a.Logger, a.DB).LoadCircuitBreakerTransitions(context.Background(), projectID, endpointID, limit)
+			if err != nil {
+				return fmt.Errorf("failed to load circuit breaker transitions: %v", err)
+			}
+
+			jsonOutput, err := json.MarshalIndent(transitions, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal output: %v", err)
+			}
+
+			fmt.Println(string(jsonOutput))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 50, "number of transitions to show")
+
+	return cmd
+}
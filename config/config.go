@@ -80,6 +80,10 @@ var DefaultConfiguration = Configuration{
 			ClaimBatchSize:      DefaultPostgresQueueClaimBatchSize,
 			PollIdleMs:          DefaultPostgresQueuePollIdleMs,
 		},
+		FairShare: FairShareConfiguration{
+			TenantScope:   ProjectTenantScope,
+			DefaultWeight: 1,
+		},
 	},
 	Cache: CacheConfiguration{
 		Postgres: PostgresCacheConfiguration{
@@ -104,6 +108,10 @@ var DefaultConfiguration = Configuration{
 		IsRetentionPolicyEnabled: false,
 		BackupInterval:           "1h",
 	},
+	ConfigReplication: ConfigReplicationConfiguration{
+		SlotName:    DefaultConfigReplicationSlot,
+		MetricsPort: 5010,
+	},
 	CircuitBreaker: CircuitBreakerConfiguration{
 		SampleRate:                  30,
 		ErrorTimeout:                30,
@@ -137,6 +145,9 @@ var DefaultConfiguration = Configuration{
 		OTel: OTelConfiguration{
 			SampleRate:         1.0,
 			InsecureSkipVerify: true,
+			Metrics: OTelMetricsConfiguration{
+				ExportInterval: 30,
+			},
 		},
 	},
 	EnableProfiling: false,
@@ -165,6 +176,10 @@ var DefaultConfiguration = Configuration{
 		APIKey:      "",
 		UsageSource: BillingUsageSourcePostgres,
 	},
+	Embedded: EmbeddedConfiguration{
+		DataDir: DefaultEmbeddedDataDir,
+		Port:    DefaultEmbeddedPort,
+	},
 	SSOService: SSOServiceConfiguration{
 		Host:            "https://overwatch.getconvoy.cloud",
 		RedirectPath:    "/sso/redirect",
@@ -212,7 +227,63 @@ func (dc DatabaseConfiguration) EffectiveMaxOpenConnections() int {
 }
 
 type QueueConfiguration struct {
-	Postgres PostgresQueueConfiguration `json:"postgres"`
+	Postgres  PostgresQueueConfiguration `json:"postgres"`
+	FairShare FairShareConfiguration     `json:"fair_share"`
+}
+
+// FairShareConfiguration schedules queue work round robin across tenants, so
+// one project's backlog cannot hold every worker. It applies to both queue
+// providers.
+type FairShareConfiguration struct {
+	Enabled bool `json:"enabled" envconfig:"CONVOY_QUEUE_FAIR_SHARE_ENABLED"`
+	// TenantScope is what a tenant is: each project, or each organisation with
+	// all of its projects together. It applies to jobs as they are written, so
+	// jobs already queued keep the scope they were written with.
+	TenantScope FairShareTenantScope `json:"tenant_scope" envconfig:"CONVOY_QUEUE_FAIR_SHARE_TENANT_SCOPE"`
+	// DefaultWeight is the weight of a tenant Weights does not name.
+	DefaultWeight int `json:"default_weight" envconfig:"CONVOY_QUEUE_FAIR_SHARE_DEFAULT_WEIGHT"`
+	// Weights maps project or organisation IDs, per TenantScope, to a weight.
+	// From the environment: "id1:4,id2:2".
+	Weights map[string]int `json:"weights" envconfig:"CONVOY_QUEUE_FAIR_SHARE_WEIGHTS"`
+	// MaxInFlight caps the jobs one tenant may hold at once; zero is no cap
+	// beyond its weighted share.
+	MaxInFlight int `json:"max_in_flight" envconfig:"CONVOY_QUEUE_FAIR_SHARE_MAX_IN_FLIGHT"`
+}
+
+type FairShareTenantScope string
+
+const (
+	ProjectTenantScope      FairShareTenantScope = "project"
+	OrganisationTenantScope FairShareTenantScope = "organisation"
+)
+
+// validateFairShare fills the defaults and rejects weights that would starve a
+// tenant outright; a weight of zero reads as "never", which is what a pause is
+// for.
+func validateFairShare(f *FairShareConfiguration) error {
+	switch f.TenantScope {
+	case "":
+		f.TenantScope = ProjectTenantScope
+	case ProjectTenantScope, OrganisationTenantScope:
+	default:
+		return fmt.Errorf("queue.fair_share.tenant_scope must be %q or %q, got %q", ProjectTenantScope, OrganisationTenantScope, f.TenantScope)
+	}
+
+	if f.DefaultWeight == 0 {
+		f.DefaultWeight = 1
+	}
+	if f.DefaultWeight < 1 {
+		return fmt.Errorf("queue.fair_share.default_weight must be at least 1, got %d", f.DefaultWeight)
+	}
+	for tenant, w := range f.Weights {
+		if w < 1 {
+			return fmt.Errorf("queue.fair_share.weights[%s] must be at least 1, got %d", tenant, w)
+		}
+	}
+	if f.MaxInFlight < 0 {
+		return fmt.Errorf("queue.fair_share.max_in_flight must not be negative, got %d", f.MaxInFlight)
+	}
+	return nil
 }
 
 // PostgresQueueConfiguration tunes the Postgres queue provider's write path.
@@ -596,6 +667,24 @@ type OTelConfiguration struct {
 	SampleRate         float64               `json:"sample_rate" envconfig:"CONVOY_OTEL_SAMPLE_RATE"`
 	CollectorURL       string                `json:"collector_url" envconfig:"CONVOY_OTEL_COLLECTOR_URL"`
 	InsecureSkipVerify bool                  `json:"insecure_skip_verify" envconfig:"CONVOY_OTEL_INSECURE_SKIP_VERIFY"`
+
+	// Metrics and Logs export the matching signal over OTLP to the same
+	// collector. They run whichever tracing backend is selected, so a pure
+	// collector pipeline does not need a Prometheus scrape.
+	Metrics OTelMetricsConfiguration `json:"metrics"`
+	Logs    OTelLogsConfiguration    `json:"logs"`
+}
+
+type OTelMetricsConfiguration struct {
+	// Enabled pushes everything registered for the Prometheus endpoint to the
+	// collector. Collectors only report once CONVOY_METRICS_ENABLED is set.
+	Enabled bool `json:"enabled" envconfig:"CONVOY_OTEL_METRICS_ENABLED"`
+	// ExportInterval is the push interval in seconds.
+	ExportInterval uint64 `json:"export_interval" envconfig:"CONVOY_OTEL_METRICS_EXPORT_INTERVAL"`
+}
+
+type OTelLogsConfiguration struct {
+	Enabled bool `json:"enabled" envconfig:"CONVOY_OTEL_LOGS_ENABLED"`
 }
 
 type OTelAuthConfiguration struct {
@@ -622,6 +711,89 @@ type RetentionPolicyConfiguration struct {
 	ReplicationDSN           string `json:"replication_dsn" envconfig:"CONVOY_REPLICATION_DSN"`
 }
 
+// ConfigReplicationConfiguration is read by `convoy replication run` in a
+// standby region. The database it applies to is the standby's own
+// database.*; SourceDSN is the primary region's database, reached over the
+// replication protocol.
+type ConfigReplicationConfiguration struct {
+	SourceDSN string `json:"source_dsn" envconfig:"CONVOY_CONFIG_REPLICATION_SOURCE_DSN"`
+	// SlotName is the logical replication slot held on the source. Each
+	// standby needs its own.
+	SlotName    string `json:"slot_name" envconfig:"CONVOY_CONFIG_REPLICATION_SLOT_NAME"`
+	MetricsPort uint32 `json:"metrics_port" envconfig:"CONVOY_CONFIG_REPLICATION_METRICS_PORT"`
+}
+
+// EmbeddedConfiguration runs the server as one process with no external
+// services: it starts its own Postgres as a child process, applies migrations
+// at boot and runs the worker in process on the postgres queue. It is for
+// development, CI and single-node edge sites; an embedded instance must not be
+// scaled out, since its database lives in DataDir on the one host.
+type EmbeddedConfiguration struct {
+	Enabled bool `json:"enabled" envconfig:"CONVOY_EMBEDDED_ENABLED"`
+	// DataDir holds the database between runs, along with the Postgres
+	// binaries and log.
+	DataDir string `json:"data_dir" envconfig:"CONVOY_EMBEDDED_DATA_DIR"`
+	// Port is where the embedded Postgres listens on localhost.
+	Port uint32 `json:"port" envconfig:"CONVOY_EMBEDDED_PORT"`
+}
+
+const (
+	DefaultEmbeddedDataDir = "./convoy-data"
+	DefaultEmbeddedPort    = 5433
+
+	// The embedded database is reachable only on localhost, so its
+	// credentials are fixed rather than configured.
+	EmbeddedDatabaseName     = "convoy"
+	EmbeddedDatabaseUsername = "convoy"
+	EmbeddedDatabasePassword = "convoy"
+)
+
+// applyEmbedded points the database at the embedded Postgres and the queue at
+// postgres. The pool settings are kept; the connection and any read replicas
+// configured for a shared database are not.
+func applyEmbedded(c *Configuration) {
+	e := &c.Embedded
+	if e.DataDir == "" {
+		e.DataDir = DefaultEmbeddedDataDir
+	}
+	if e.Port == 0 {
+		e.Port = DefaultEmbeddedPort
+	}
+
+	c.Database.Type = PostgresDatabaseProvider
+	c.Database.DSN = ""
+	c.Database.Scheme = "postgres"
+	c.Database.Host = "localhost"
+	c.Database.Port = int(e.Port)
+	c.Database.Username = EmbeddedDatabaseUsername
+	c.Database.Password = EmbeddedDatabasePassword
+	c.Database.Database = EmbeddedDatabaseName
+	c.Database.Options = "sslmode=disable"
+	c.Database.ReadReplicas = nil
+
+	c.QueueProvider = PostgresQueueProvider
+}
+
+// DefaultConfigReplicationSlot is the slot a standby uses unless told
+// otherwise.
+const DefaultConfigReplicationSlot = "convoy_config"
+
+var replicationSlotRegex = regexp.MustCompile(`^[a-z0-9_]{1,63}$`)
+
+// validateConfigReplication fills the slot name. Postgres allows only lower
+// case letters, digits and underscores in a slot name, and the name is also
+// the key of the standby's state row, so a bad one is rejected here rather
+// than on first connect.
+func validateConfigReplication(r *ConfigReplicationConfiguration) error {
+	if r.SlotName == "" {
+		r.SlotName = DefaultConfigReplicationSlot
+	}
+	if !replicationSlotRegex.MatchString(r.SlotName) {
+		return fmt.Errorf("config_replication.slot_name must be 1-63 lower case letters, digits or underscores, got %q", r.SlotName)
+	}
+	return nil
+}
+
 type CircuitBreakerConfiguration struct {
 	SampleRate                  uint64 `json:"sample_rate" envconfig:"CONVOY_CIRCUIT_BREAKER_SAMPLE_RATE"`
 	ErrorTimeout                uint64 `json:"error_timeout" envconfig:"CONVOY_CIRCUIT_BREAKER_ERROR_TIMEOUT"`
@@ -631,6 +803,9 @@ type CircuitBreakerConfiguration struct {
 	ObservabilityWindow         uint64 `json:"observability_window" envconfig:"CONVOY_CIRCUIT_BREAKER_OBSERVABILITY_WINDOW"`
 	ConsecutiveFailureThreshold uint64 `json:"consecutive_failure_threshold" envconfig:"CONVOY_CIRCUIT_BREAKER_CONSECUTIVE_FAILURE_THRESHOLD"`
 	SkipSleep                   bool   `json:"skip_sleep" envconfig:"CONVOY_CIRCUIT_BREAKER_SKIP_SLEEP"`
+	// DisableHalfOpenProbe lets an open breaker move to half-open once its
+	// error timeout passes without first pinging the endpoint.
+	DisableHalfOpenProbe bool `json:"disable_half_open_probe" envconfig:"CONVOY_CIRCUIT_BREAKER_DISABLE_HALF_OPEN_PROBE"`
 }
 
 type AnalyticsConfiguration struct {
@@ -740,35 +915,37 @@ const (
 )
 
 type Configuration struct {
-	InstanceId         string                       `json:"instance_id"`
-	APIVersion         string                       `json:"api_version" envconfig:"CONVOY_API_VERSION"`
-	Auth               AuthConfiguration            `json:"auth,omitempty"`
-	Database           DatabaseConfiguration        `json:"database"`
-	Redis              RedisConfiguration           `json:"redis"`
-	Prometheus         PrometheusConfiguration      `json:"prometheus"`
-	Server             ServerConfiguration          `json:"server"`
-	MaxResponseSize    uint64                       `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
-	SMTP               SMTPConfiguration            `json:"smtp"`
-	Environment        string                       `json:"env" envconfig:"CONVOY_ENV"`
-	Logger             LoggerConfiguration          `json:"logger"`
-	Tracer             TracerConfiguration          `json:"tracer"`
-	Host               string                       `json:"host" envconfig:"CONVOY_HOST"`
-	RootPath           string                       `json:"root_path" envconfig:"CONVOY_ROOT_PATH"`
-	Pyroscope          PyroscopeConfiguration       `json:"pyroscope"`
-	CustomDomainSuffix string                       `json:"custom_domain_suffix" envconfig:"CONVOY_CUSTOM_DOMAIN_SUFFIX"`
-	EnableFeatureFlag  []string                     `json:"enable_feature_flag" envconfig:"CONVOY_ENABLE_FEATURE_FLAG"`
-	RetentionPolicy    RetentionPolicyConfiguration `json:"retention_policy"`
-	CircuitBreaker     CircuitBreakerConfiguration  `json:"circuit_breaker"`
-	Analytics          AnalyticsConfiguration       `json:"analytics"`
-	StoragePolicy      StoragePolicyConfiguration   `json:"storage_policy"`
-	ConsumerPoolSize   int                          `json:"consumer_pool_size" envconfig:"CONVOY_CONSUMER_POOL_SIZE"`
-	QueueProvider      QueueProvider                `json:"queue_provider" envconfig:"CONVOY_QUEUE_PROVIDER"`
-	Queue              QueueConfiguration           `json:"queue"`
-	Cache              CacheConfiguration           `json:"cache"`
-	EnableProfiling    bool                         `json:"enable_profiling" envconfig:"CONVOY_ENABLE_PROFILING"`
-	Metrics            MetricsConfiguration         `json:"metrics" envconfig:"CONVOY_METRICS"`
-	InstanceIngestRate int                          `json:"instance_ingest_rate" envconfig:"CONVOY_INSTANCE_INGEST_RATE"`
-	ApiRateLimit       int                          `json:"api_rate_limit" envconfig:"CONVOY_API_RATE_LIMIT"`
+	InstanceId         string                         `json:"instance_id"`
+	APIVersion         string                         `json:"api_version" envconfig:"CONVOY_API_VERSION"`
+	Auth               AuthConfiguration              `json:"auth,omitempty"`
+	Database           DatabaseConfiguration          `json:"database"`
+	Redis              RedisConfiguration             `json:"redis"`
+	Prometheus         PrometheusConfiguration        `json:"prometheus"`
+	Server             ServerConfiguration            `json:"server"`
+	MaxResponseSize    uint64                         `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
+	SMTP               SMTPConfiguration              `json:"smtp"`
+	Environment        string                         `json:"env" envconfig:"CONVOY_ENV"`
+	Logger             LoggerConfiguration            `json:"logger"`
+	Tracer             TracerConfiguration            `json:"tracer"`
+	Host               string                         `json:"host" envconfig:"CONVOY_HOST"`
+	RootPath           string                         `json:"root_path" envconfig:"CONVOY_ROOT_PATH"`
+	Pyroscope          PyroscopeConfiguration         `json:"pyroscope"`
+	CustomDomainSuffix string                         `json:"custom_domain_suffix" envconfig:"CONVOY_CUSTOM_DOMAIN_SUFFIX"`
+	EnableFeatureFlag  []string                       `json:"enable_feature_flag" envconfig:"CONVOY_ENABLE_FEATURE_FLAG"`
+	RetentionPolicy    RetentionPolicyConfiguration   `json:"retention_policy"`
+	ConfigReplication  ConfigReplicationConfiguration `json:"config_replication"`
+	CircuitBreaker     CircuitBreakerConfiguration    `json:"circuit_breaker"`
+	Analytics          AnalyticsConfiguration         `json:"analytics"`
+	StoragePolicy      StoragePolicyConfiguration     `json:"storage_policy"`
+	ConsumerPoolSize   int                            `json:"consumer_pool_size" envconfig:"CONVOY_CONSUMER_POOL_SIZE"`
+	QueueProvider      QueueProvider                  `json:"queue_provider" envconfig:"CONVOY_QUEUE_PROVIDER"`
+	Queue              QueueConfiguration             `json:"queue"`
+	Cache              CacheConfiguration             `json:"cache"`
+	EnableProfiling    bool                           `json:"enable_profiling" envconfig:"CONVOY_ENABLE_PROFILING"`
+	Metrics            MetricsConfiguration           `json:"metrics" envconfig:"CONVOY_METRICS"`
+	InstanceIngestRate int                            `json:"instance_ingest_rate" envconfig:"CONVOY_INSTANCE_INGEST_RATE"`
+	ApiRateLimit       int                            `json:"api_rate_limit" envconfig:"CONVOY_API_RATE_LIMIT"`
+	Embedded           EmbeddedConfiguration          `json:"embedded"`
 	// VerifyDynamicEventsTimeout is the max seconds POST /events/dynamic waits for
 	// endpoint/subscription resolve when project config verify_dynamic_events is true.
 	VerifyDynamicEventsTimeout uint64                      `json:"verify_dynamic_events_timeout" envconfig:"CONVOY_VERIFY_DYNAMIC_EVENTS_TIMEOUT"`
@@ -991,6 +1168,18 @@ func Override(newCfg *Configuration) error {
 
 	overrideFields(ov, nv)
 
+	// --embedded arrives after the file and environment were validated, so
+	// the switch to the embedded database and postgres queue is checked here.
+	if c.Embedded.Enabled {
+		applyEmbedded(&c)
+		if err := validatePostgresQueue(&c); err != nil {
+			return err
+		}
+		if err := validatePostgresCache(&c); err != nil {
+			return err
+		}
+	}
+
 	cfgSingleton.Store(&c)
 	return nil
 }
@@ -1137,6 +1326,10 @@ func ensureMaxResponseSize(c *Configuration) {
 func validate(c *Configuration) error {
 	ensureMaxResponseSize(c)
 
+	if c.Embedded.Enabled {
+		applyEmbedded(c)
+	}
+
 	switch c.QueueProvider {
 	case "", RedisQueueProvider:
 		c.QueueProvider = RedisQueueProvider
@@ -1156,6 +1349,14 @@ func validate(c *Configuration) error {
 		return fmt.Errorf("unknown queue_provider %q (want redis or postgres)", c.QueueProvider)
 	}
 
+	if err := validateFairShare(&c.Queue.FairShare); err != nil {
+		return err
+	}
+
+	if err := validateConfigReplication(&c.ConfigReplication); err != nil {
+		return err
+	}
+
 	if err := ensureSSL(c.Server); err != nil {
 		return err
 	}
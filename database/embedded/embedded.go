@@ -0,0 +1,129 @@
+// Package embedded runs the Postgres an embedded instance stores its data in.
+// It is a real Postgres server, started by the convoy process from binaries
+// kept in the data directory, so the repositories, queue and migrations run
+// on it unchanged.
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+
+	"github.com/frain-dev/convoy/config"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// version is the Postgres an embedded instance runs. A data directory from
+// another major version is refused rather than reinitialised.
+const version = embeddedpostgres.V15
+
+const startTimeout = time.Minute
+
+// Postgres is a running embedded server. Close stops it.
+type Postgres struct {
+	db      *embeddedpostgres.EmbeddedPostgres
+	logFile *os.File
+	logger  log.Logger
+}
+
+// Start brings up the embedded Postgres for cfg, which applyEmbedded has
+// already pointed at it. The first start downloads the binaries and
+// initialises the database; later starts reuse both from the data directory.
+func Start(cfg config.Configuration, logger log.Logger) (*Postgres, error) {
+	dir, err := filepath.Abs(cfg.Embedded.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve embedded data dir: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create embedded data dir: %w", err)
+	}
+
+	dataPath := filepath.Join(dir, "data")
+	binPath := filepath.Join(dir, "bin")
+
+	if err := checkDataVersion(dataPath); err != nil {
+		return nil, err
+	}
+	stopOrphan(binPath, dataPath, logger)
+
+	logFile, err := os.OpenFile(filepath.Join(dir, "postgres.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open embedded postgres log: %w", err)
+	}
+
+	db := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
+		Version(version).
+		Port(cfg.Embedded.Port).
+		Database(cfg.Database.Database).
+		Username(cfg.Database.Username).
+		Password(cfg.Database.Password).
+		DataPath(dataPath).
+		BinariesPath(binPath).
+		RuntimePath(filepath.Join(dir, "run")).
+		StartTimeout(startTimeout).
+		Logger(logFile))
+
+	logger.Info("starting embedded postgres", "data_dir", dir, "port", cfg.Embedded.Port)
+	if err := db.Start(); err != nil {
+		_ = logFile.Close()
+		return nil, fmt.Errorf("start embedded postgres: %w", err)
+	}
+
+	return &Postgres{db: db, logFile: logFile, logger: logger}, nil
+}
+
+// Close stops the server, waiting for it to shut down cleanly.
+func (p *Postgres) Close() error {
+	err := p.db.Stop()
+	if closeErr := p.logFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("stop embedded postgres: %w", err)
+	}
+	p.logger.Info("embedded postgres stopped")
+	return nil
+}
+
+// checkDataVersion refuses a data directory initialised by another Postgres
+// major version. The library would otherwise wipe it and start over.
+func checkDataVersion(dataPath string) error {
+	raw, err := os.ReadFile(filepath.Join(dataPath, "PG_VERSION"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read embedded postgres version: %w", err)
+	}
+
+	v := strings.TrimSpace(string(raw))
+	if !strings.HasPrefix(string(version), v+".") {
+		return fmt.Errorf("embedded data dir %s holds a postgres %s database, this build runs postgres %s", dataPath, v, version)
+	}
+	return nil
+}
+
+// stopOrphan stops a server left running by a convoy process that did not
+// shut down, which would otherwise hold the port. Nothing is running in the
+// usual case, so a failed stop is not an error.
+func stopOrphan(binPath, dataPath string, logger log.Logger) {
+	if _, err := os.Stat(filepath.Join(dataPath, "postmaster.pid")); err != nil {
+		return
+	}
+	pgCtl := filepath.Join(binPath, "bin", "pg_ctl")
+	if _, err := os.Stat(pgCtl); err != nil {
+		return
+	}
+
+	if out, err := exec.Command(pgCtl, "stop", "-w", "-m", "fast", "-D", dataPath).CombinedOutput(); err == nil {
+		logger.Warn("stopped an embedded postgres left running by an earlier process")
+	} else {
+		logger.Debug("no embedded postgres to stop", "output", strings.TrimSpace(string(out)))
+	}
+}
@@ -0,0 +1,23 @@
+package embedded
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDataVersion(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, checkDataVersion(filepath.Join(dir, "missing")), "a fresh data dir is initialised")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "PG_VERSION"), []byte("15\n"), 0o600))
+	require.NoError(t, checkDataVersion(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "PG_VERSION"), []byte("14\n"), 0o600))
+	require.ErrorContains(t, checkDataVersion(dir), "postgres 14 database")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "PG_VERSION"), []byte("1\n"), 0o600))
+	require.Error(t, checkDataVersion(dir), "15 is not version 1")
+}
@@ -27,11 +27,20 @@ func Get() (*Hook, error) {
 	return ho, nil
 }
 
+// Init publishes an empty hook for Get, repositories built after Init fire
+// into it whether their listeners are registered before or after.
 func Init() *Hook {
-	return &Hook{fns: hookMap{}}
+	h := &Hook{fns: hookMap{}}
+	hookSingleton.Store(h)
+	return h
 }
 
 func (h *Hook) Fire(ctx context.Context, eventType datastore.HookEventType, data, changelog interface{}) {
+	// repositories built outside a command's PreRun have no hook
+	if h == nil {
+		return
+	}
+
 	if fn, ok := h.fns[eventType]; ok {
 		fn(ctx, data, changelog)
 	}
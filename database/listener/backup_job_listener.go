@@ -0,0 +1,56 @@
+package listener
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+// MetaEventBackupJob is what a project is told about a backup. The job itself
+// is instance wide, its record counts, agent and error describe every tenant
+// and stay with the operator.
+type MetaEventBackupJob struct {
+	ID          string     `json:"id"`
+	HourStart   time.Time  `json:"hour_start"`
+	HourEnd     time.Time  `json:"hour_end"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type BackupJobListener struct {
+	mEvent *services.MetaEvent
+	logger log.Logger
+}
+
+func NewBackupJobListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *BackupJobListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &BackupJobListener{mEvent: mEvent, logger: logger}
+}
+
+// AfterComplete announces the backup to every subscribed project, a backup
+// job covers the whole instance so each gets only the hour it covered.
+func (b *BackupJobListener) AfterComplete(ctx context.Context, data, _ interface{}) {
+	job, ok := data.(*datastore.BackupJob)
+	if !ok {
+		b.logger.Error("invalid type for event - backupjob.completed")
+		return
+	}
+
+	if err := b.mEvent.Broadcast(ctx, string(datastore.BackupJobCompleted), getMetaEventBackupJob(job)); err != nil {
+		b.logger.Error("backup job meta event failed", "error", err)
+	}
+}
+
+func getMetaEventBackupJob(job *datastore.BackupJob) *MetaEventBackupJob {
+	return &MetaEventBackupJob{
+		ID:          job.ID,
+		HourStart:   job.HourStart,
+		HourEnd:     job.HourEnd,
+		Status:      job.Status,
+		CompletedAt: job.CompletedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+type BatchRetryListener struct {
+	mEvent *services.MetaEvent
+	logger log.Logger
+}
+
+func NewBatchRetryListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *BatchRetryListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &BatchRetryListener{mEvent: mEvent, logger: logger}
+}
+
+func (b *BatchRetryListener) AfterComplete(ctx context.Context, data, _ interface{}) {
+	b.metaEvent(ctx, datastore.BatchRetryCompleted, data)
+}
+
+func (b *BatchRetryListener) AfterFail(ctx context.Context, data, _ interface{}) {
+	b.metaEvent(ctx, datastore.BatchRetryFailed, data)
+}
+
+func (b *BatchRetryListener) metaEvent(ctx context.Context, eventType datastore.HookEventType, data interface{}) {
+	batchRetry, ok := data.(*datastore.BatchRetry)
+	if !ok {
+		b.logger.Error(fmt.Sprintf("invalid type for event - %s", eventType))
+		return
+	}
+
+	if err := b.mEvent.Run(ctx, string(eventType), batchRetry.ProjectID, batchRetry); err != nil {
+		b.logger.Error("batch retry meta event failed", "error", err)
+	}
+}
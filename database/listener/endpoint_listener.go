@@ -32,6 +32,26 @@ func (e *EndpointListener) AfterDelete(ctx context.Context, data, _ interface{})
 	e.metaEvent(ctx, datastore.EndpointDeleted, data)
 }
 
+func (e *EndpointListener) AfterPause(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EndpointPaused, data)
+}
+
+func (e *EndpointListener) AfterActivate(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EndpointActivated, data)
+}
+
+func (e *EndpointListener) AfterDisable(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EndpointDisabled, data)
+}
+
+func (e *EndpointListener) AfterSecretRotate(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EndpointSecretRotated, data)
+}
+
+func (e *EndpointListener) AfterSecretExpire(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EndpointSecretExpired, data)
+}
+
 func (e *EndpointListener) metaEvent(ctx context.Context, eventType datastore.HookEventType, data interface{}) {
 	endpoint, ok := data.(*datastore.Endpoint)
 	if !ok {
@@ -0,0 +1,61 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+type EventTypeListener struct {
+	mEvent *services.MetaEvent
+	logger log.Logger
+}
+
+// MetaEventEventType carries the fields the event type's own JSON hides.
+type MetaEventEventType struct {
+	*datastore.ProjectEventType
+	ProjectID string    `json:"project_id"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+func NewEventTypeListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *EventTypeListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &EventTypeListener{mEvent: mEvent, logger: logger}
+}
+
+func (e *EventTypeListener) AfterCreate(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EventTypeCreated, data)
+}
+
+func (e *EventTypeListener) AfterUpdate(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EventTypeUpdated, data)
+}
+
+func (e *EventTypeListener) AfterDeprecate(ctx context.Context, data, _ interface{}) {
+	e.metaEvent(ctx, datastore.EventTypeDeprecated, data)
+}
+
+func (e *EventTypeListener) metaEvent(ctx context.Context, eventType datastore.HookEventType, data interface{}) {
+	et, ok := data.(*datastore.ProjectEventType)
+	if !ok {
+		e.logger.Error(fmt.Sprintf("invalid type for event - %s", eventType))
+		return
+	}
+
+	payload := &MetaEventEventType{
+		ProjectEventType: et,
+		ProjectID:        et.ProjectId,
+		CreatedAt:        et.CreatedAt,
+		UpdatedAt:        et.UpdatedAt,
+	}
+
+	if err := e.mEvent.Run(ctx, string(eventType), et.ProjectId, payload); err != nil {
+		e.logger.Error("event type meta event failed", "error", err)
+	}
+}
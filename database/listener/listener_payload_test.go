@@ -0,0 +1,88 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestMetaEventSubscription_IncludesHiddenIDs(t *testing.T) {
+	raw, err := json.Marshal(getMetaEventSubscription(&datastore.Subscription{
+		UID:        "sub-1",
+		Name:       "invoices",
+		ProjectID:  "proj-1",
+		SourceID:   "src-1",
+		EndpointID: "ep-1",
+	}))
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(raw, &payload))
+
+	require.Equal(t, "sub-1", payload["uid"])
+	require.Equal(t, "invoices", payload["name"])
+	require.Equal(t, "src-1", payload["source_id"])
+	require.Equal(t, "ep-1", payload["endpoint_id"])
+	require.NotContains(t, payload, "device_id")
+}
+
+func TestSubscriptionListener_SkipsCLISubscriptions(t *testing.T) {
+	// A nil meta event would panic if the CLI subscription were announced.
+	s := &SubscriptionListener{}
+	s.AfterCreate(context.Background(), &datastore.Subscription{UID: "sub-1", Type: datastore.SubscriptionTypeCLI}, nil)
+}
+
+func TestMetaEventPortalLink_DropsCredentials(t *testing.T) {
+	link := &datastore.PortalLink{
+		UID:       "pl-1",
+		ProjectID: "proj-1",
+		AuthKey:   "PRT.key",
+		TokenHash: "hash",
+		TokenSalt: "salt",
+	}
+
+	raw, err := json.Marshal(getMetaEventPortalLink(link))
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(raw, &payload))
+
+	require.Equal(t, "pl-1", payload["uid"])
+	require.Empty(t, payload["auth_key"])
+	require.Empty(t, payload["token_hash"])
+	require.Empty(t, payload["token_salt"])
+
+	// the link the repository returned is untouched
+	require.Equal(t, "PRT.key", link.AuthKey)
+}
+
+func TestMetaEventBackupJob_DropsInstanceData(t *testing.T) {
+	completedAt := time.Now()
+	job := &datastore.BackupJob{
+		ID:           "bj-1",
+		Status:       "completed",
+		AgentID:      "agent-1",
+		Error:        "partial upload",
+		RecordCounts: map[string]int64{"events": 42},
+		CompletedAt:  &completedAt,
+	}
+
+	raw, err := json.Marshal(getMetaEventBackupJob(job))
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(raw, &payload))
+
+	require.Equal(t, "bj-1", payload["id"])
+	require.Equal(t, "completed", payload["status"])
+	require.Contains(t, payload, "hour_start")
+	require.Contains(t, payload, "completed_at")
+	require.NotContains(t, payload, "agent_id")
+	require.NotContains(t, payload, "error")
+	require.NotContains(t, payload, "record_counts")
+}
@@ -0,0 +1,56 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+type PortalLinkListener struct {
+	mEvent *services.MetaEvent
+	logger log.Logger
+}
+
+func NewPortalLinkListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *PortalLinkListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &PortalLinkListener{mEvent: mEvent, logger: logger}
+}
+
+func (p *PortalLinkListener) AfterCreate(ctx context.Context, data, _ interface{}) {
+	p.metaEvent(ctx, datastore.PortalLinkCreated, data)
+}
+
+func (p *PortalLinkListener) AfterUpdate(ctx context.Context, data, _ interface{}) {
+	p.metaEvent(ctx, datastore.PortalLinkUpdated, data)
+}
+
+func (p *PortalLinkListener) AfterRevoke(ctx context.Context, data, _ interface{}) {
+	p.metaEvent(ctx, datastore.PortalLinkRevoked, data)
+}
+
+func (p *PortalLinkListener) metaEvent(ctx context.Context, eventType datastore.HookEventType, data interface{}) {
+	portalLink, ok := data.(*datastore.PortalLink)
+	if !ok {
+		p.logger.Error(fmt.Sprintf("invalid type for event - %s", eventType))
+		return
+	}
+
+	if err := p.mEvent.Run(ctx, string(eventType), portalLink.ProjectID, getMetaEventPortalLink(portalLink)); err != nil {
+		p.logger.Error("portal link meta event failed", "error", err)
+	}
+}
+
+// getMetaEventPortalLink drops the link's credentials, a meta event only
+// describes the link.
+func getMetaEventPortalLink(portalLink *datastore.PortalLink) *datastore.PortalLink {
+	pl := *portalLink
+	pl.AuthKey = ""
+	pl.TokenHash = ""
+	pl.TokenSalt = ""
+
+	return &pl
+}
@@ -6,20 +6,29 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	log "github.com/frain-dev/convoy/pkg/logger"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
 )
 
 type ProjectListener struct {
+	mEvent *services.MetaEvent
 	logger log.Logger
 }
 
-func NewProjectListener(_ queue.Queuer, logger log.Logger) *ProjectListener {
-	return &ProjectListener{logger: logger}
+func NewProjectListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *ProjectListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &ProjectListener{mEvent: mEvent, logger: logger}
 }
 
-func (e *ProjectListener) AfterUpdate(_ context.Context, data, _ interface{}) {
+func (e *ProjectListener) AfterUpdate(ctx context.Context, data, _ interface{}) {
 	// Project updates no longer enqueue TokenizeSearchForProject; payload search
 	// uses the active date filter and a license gate instead of search_policy.
-	if _, ok := data.(*datastore.Project); !ok {
+	project, ok := data.(*datastore.Project)
+	if !ok {
 		e.logger.Error("invalid type for project update")
+		return
+	}
+
+	if err := e.mEvent.Run(ctx, string(datastore.ProjectUpdated), project.UID, project); err != nil {
+		e.logger.Error("project meta event failed", "error", err)
 	}
 }
@@ -0,0 +1,45 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+type SourceListener struct {
+	mEvent *services.MetaEvent
+	logger log.Logger
+}
+
+func NewSourceListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *SourceListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &SourceListener{mEvent: mEvent, logger: logger}
+}
+
+func (s *SourceListener) AfterCreate(ctx context.Context, data, _ interface{}) {
+	s.metaEvent(ctx, datastore.SourceCreated, data)
+}
+
+func (s *SourceListener) AfterUpdate(ctx context.Context, data, _ interface{}) {
+	s.metaEvent(ctx, datastore.SourceUpdated, data)
+}
+
+func (s *SourceListener) AfterDelete(ctx context.Context, data, _ interface{}) {
+	s.metaEvent(ctx, datastore.SourceDeleted, data)
+}
+
+func (s *SourceListener) metaEvent(ctx context.Context, eventType datastore.HookEventType, data interface{}) {
+	source, ok := data.(*datastore.Source)
+	if !ok {
+		s.logger.Error(fmt.Sprintf("invalid type for event - %s", eventType))
+		return
+	}
+
+	if err := s.mEvent.Run(ctx, string(eventType), source.ProjectID, source); err != nil {
+		s.logger.Error("source meta event failed", "error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
+)
+
+type SubscriptionListener struct {
+	mEvent *services.MetaEvent
+	logger log.Logger
+}
+
+// MetaEventSubscription carries the IDs the subscription's own JSON hides.
+type MetaEventSubscription struct {
+	*datastore.Subscription
+	SourceID   string `json:"source_id,omitempty"`
+	EndpointID string `json:"endpoint_id,omitempty"`
+	DeviceID   string `json:"device_id,omitempty"`
+}
+
+func NewSubscriptionListener(queue queue.Queuer, projectRepo datastore.ProjectRepository, metaEventRepo datastore.MetaEventRepository, logger log.Logger) *SubscriptionListener {
+	mEvent := services.NewMetaEvent(queue, projectRepo, metaEventRepo, logger)
+	return &SubscriptionListener{mEvent: mEvent, logger: logger}
+}
+
+func (s *SubscriptionListener) AfterCreate(ctx context.Context, data, _ interface{}) {
+	s.metaEvent(ctx, datastore.SubscriptionCreated, data)
+}
+
+func (s *SubscriptionListener) AfterUpdate(ctx context.Context, data, _ interface{}) {
+	s.metaEvent(ctx, datastore.SubscriptionUpdated, data)
+}
+
+func (s *SubscriptionListener) AfterDelete(ctx context.Context, data, _ interface{}) {
+	s.metaEvent(ctx, datastore.SubscriptionDeleted, data)
+}
+
+func (s *SubscriptionListener) metaEvent(ctx context.Context, eventType datastore.HookEventType, data interface{}) {
+	subscription, ok := data.(*datastore.Subscription)
+	if !ok {
+		s.logger.Error(fmt.Sprintf("invalid type for event - %s", eventType))
+		return
+	}
+
+	// CLI subscriptions live only as long as a convoy listen session.
+	if subscription.Type == datastore.SubscriptionTypeCLI {
+		return
+	}
+
+	if err := s.mEvent.Run(ctx, string(eventType), subscription.ProjectID, getMetaEventSubscription(subscription)); err != nil {
+		s.logger.Error("subscription meta event failed", "error", err)
+	}
+}
+
+func getMetaEventSubscription(subscription *datastore.Subscription) *MetaEventSubscription {
+	return &MetaEventSubscription{
+		Subscription: subscription,
+		SourceID:     subscription.SourceID,
+		EndpointID:   subscription.EndpointID,
+		DeviceID:     subscription.DeviceID,
+	}
+}
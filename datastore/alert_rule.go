@@ -0,0 +1,96 @@
+package datastore
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAlertRuleNotFound      = errors.New("alert rule not found")
+	ErrDuplicateAlertRuleName = errors.New("an alert rule with this name already exists")
+)
+
+// AlertRuleType is the condition an alert rule watches.
+type AlertRuleType string
+
+const (
+	// FailureRateAlertRule fires when the percentage of failed delivery
+	// attempts in the window exceeds the threshold.
+	FailureRateAlertRule AlertRuleType = "failure_rate"
+	// ConsecutiveFailuresAlertRule fires when the latest threshold delivery
+	// attempts all failed.
+	ConsecutiveFailuresAlertRule AlertRuleType = "consecutive_failures"
+	// LatencyP95AlertRule fires when the p95 delivery latency in the window,
+	// in milliseconds, exceeds the threshold.
+	LatencyP95AlertRule AlertRuleType = "latency_p95"
+	// RetryBacklogAlertRule fires when more than threshold deliveries are
+	// waiting to be retried.
+	RetryBacklogAlertRule AlertRuleType = "retry_backlog"
+	// SourceSilenceAlertRule fires when the source has not received an event
+	// for the whole window.
+	SourceSilenceAlertRule AlertRuleType = "source_silence"
+)
+
+func (t AlertRuleType) IsValid() bool {
+	switch t {
+	case FailureRateAlertRule, ConsecutiveFailuresAlertRule, LatencyP95AlertRule,
+		RetryBacklogAlertRule, SourceSilenceAlertRule:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	AlertRuleOK     = "ok"
+	AlertRuleFiring = "firing"
+)
+
+// AlertRuleChannels are where an alert rule's notifications go. Empty fields
+// skip that channel.
+type AlertRuleChannels struct {
+	Email           string `json:"email,omitempty"`
+	SlackWebhookURL string `json:"slack_webhook_url,omitempty"`
+	TeamsWebhookURL string `json:"teams_webhook_url,omitempty"`
+	NotificationChannels
+}
+
+// AlertRule is a user-defined condition on a project's traffic, checked every
+// minute, together with the state the evaluator keeps between runs.
+type AlertRule struct {
+	UID       string        `json:"uid" db:"id"`
+	ProjectID string        `json:"project_id" db:"project_id"`
+	Name      string        `json:"name" db:"name"`
+	Type      AlertRuleType `json:"type" db:"type"`
+	// EndpointID narrows a delivery rule to one endpoint; empty covers every
+	// endpoint in the project.
+	EndpointID string `json:"endpoint_id" db:"endpoint_id"`
+	// SourceID is the source a source_silence rule watches.
+	SourceID string `json:"source_id" db:"source_id"`
+	// Threshold is a percentage for failure_rate, milliseconds for
+	// latency_p95 and a count for consecutive_failures and retry_backlog.
+	// source_silence does not use it.
+	Threshold     float64 `json:"threshold" db:"threshold"`
+	WindowSeconds uint64  `json:"window" db:"window_seconds"`
+	// CooldownSeconds is the least time between two notifications for a rule
+	// that stays firing.
+	CooldownSeconds uint64            `json:"cooldown" db:"cooldown_seconds"`
+	Channels        AlertRuleChannels `json:"channels" db:"channels"`
+	Enabled         bool              `json:"enabled" db:"enabled"`
+
+	State           string     `json:"state" db:"state"`
+	LastValue       float64    `json:"last_value" db:"last_value"`
+	LastEvaluatedAt *time.Time `json:"last_evaluated_at" db:"last_evaluated_at" swaggertype:"string"`
+	LastNotifiedAt  *time.Time `json:"last_notified_at" db:"last_notified_at" swaggertype:"string"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at" db:"last_triggered_at" swaggertype:"string"`
+	LastResolvedAt  *time.Time `json:"last_resolved_at" db:"last_resolved_at" swaggertype:"string"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+// DeliveryAttemptCounts counts delivery attempts and the failed ones among
+// them.
+type DeliveryAttemptCounts struct {
+	Attempts int64
+	Failures int64
+}
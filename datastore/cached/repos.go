@@ -345,6 +345,12 @@ func (r *CachedSubscriptionRepository) FindSubscriptionByID(ctx context.Context,
 func (r *CachedSubscriptionRepository) FindCLISubscriptions(ctx context.Context, projectID string) ([]datastore.Subscription, error) {
 	return r.inner.FindCLISubscriptions(ctx, projectID)
 }
+func (r *CachedSubscriptionRepository) ExtendCLISubscription(ctx context.Context, projectID, subscriptionID string, expiresAt time.Time) error {
+	return r.inner.ExtendCLISubscription(ctx, projectID, subscriptionID, expiresAt)
+}
+func (r *CachedSubscriptionRepository) FindExpiredCLISubscriptions(ctx context.Context, limit int) ([]datastore.Subscription, error) {
+	return r.inner.FindExpiredCLISubscriptions(ctx, limit)
+}
 func (r *CachedSubscriptionRepository) CountEndpointSubscriptions(ctx context.Context, a, b, d string) (int64, error) {
 	return r.inner.CountEndpointSubscriptions(ctx, a, b, d)
 }
@@ -469,6 +475,9 @@ func (r *CachedFilterRepository) FindFiltersBySubscriptionID(ctx context.Context
 func (r *CachedFilterRepository) TestFilter(ctx context.Context, subscriptionID, eventType string, payload interface{}) (bool, error) {
 	return r.inner.TestFilter(ctx, subscriptionID, eventType, payload)
 }
+func (r *CachedFilterRepository) ExplainFilter(ctx context.Context, subscriptionID, eventType string, payload interface{}) (*datastore.FilterExplanation, error) {
+	return r.inner.ExplainFilter(ctx, subscriptionID, eventType, payload)
+}
 
 // ============================================================================
 // APIKeyRepository
@@ -0,0 +1,60 @@
+package datastore
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCircuitBreakerOverrideNotFound = errors.New("circuit breaker override not found")
+
+const (
+	// CircuitBreakerTransitionSourceSampler marks a transition made by the
+	// sampler from delivery outcomes.
+	CircuitBreakerTransitionSourceSampler = "sampler"
+	// CircuitBreakerTransitionSourceManual marks a transition an operator made
+	// through the API or CLI.
+	CircuitBreakerTransitionSourceManual = "manual"
+)
+
+// CircuitBreakerOverride is an operator exception for one endpoint's breaker.
+type CircuitBreakerOverride struct {
+	ProjectID  string `json:"project_id" db:"project_id"`
+	EndpointID string `json:"endpoint_id" db:"endpoint_id"`
+	// ForcedState is "open" or "closed" while the breaker is held; empty
+	// leaves the breaker to the sampler.
+	ForcedState string                               `json:"forced_state" db:"forced_state"`
+	Config      *EndpointCircuitBreakerConfiguration `json:"config" db:"config" extensions:"x-nullable"`
+	Reason      string                               `json:"reason" db:"reason"`
+	CreatedAt   time.Time                            `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt   time.Time                            `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+// IsEmpty reports whether the override no longer changes anything.
+func (o *CircuitBreakerOverride) IsEmpty() bool {
+	return o.ForcedState == "" && (o.Config == nil || *o.Config == EndpointCircuitBreakerConfiguration{})
+}
+
+// EndpointCircuitBreakerConfiguration overlays the project's circuit breaker
+// thresholds for a single endpoint. Zero fields inherit the project value.
+// The sample rate and observability window are instance wide and cannot be
+// overridden per endpoint.
+type EndpointCircuitBreakerConfiguration struct {
+	ErrorTimeout                uint64 `json:"error_timeout"`
+	FailureThreshold            uint64 `json:"failure_threshold"`
+	SuccessThreshold            uint64 `json:"success_threshold"`
+	MinimumRequestCount         uint64 `json:"minimum_request_count"`
+	ConsecutiveFailureThreshold uint64 `json:"consecutive_failure_threshold"`
+}
+
+// CircuitBreakerTransition records one state change of an endpoint's breaker.
+type CircuitBreakerTransition struct {
+	UID         string    `json:"uid" db:"id"`
+	ProjectID   string    `json:"project_id" db:"project_id"`
+	EndpointID  string    `json:"endpoint_id" db:"endpoint_id"`
+	FromState   string    `json:"from_state" db:"from_state"`
+	ToState     string    `json:"to_state" db:"to_state"`
+	Source      string    `json:"source" db:"source"`
+	Reason      string    `json:"reason" db:"reason"`
+	FailureRate float64   `json:"failure_rate" db:"failure_rate"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+}
@@ -0,0 +1,34 @@
+package datastore
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailTemplateNotFound = errors.New("email template not found")
+	ErrEmailBrandingNotFound = errors.New("email branding not found")
+)
+
+// EmailTemplate is an organisation's override of one built-in email. Subject
+// and Body are Go templates over the email's variables; an empty one keeps the
+// built-in subject or body.
+type EmailTemplate struct {
+	UID            string    `json:"uid" db:"id"`
+	OrganisationID string    `json:"organisation_id" db:"organisation_id"`
+	TemplateName   string    `json:"template_name" db:"template_name"`
+	Subject        string    `json:"subject" db:"subject"`
+	Body           string    `json:"body" db:"body"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+// EmailBranding is the header colour and fallback logo of an organisation's
+// emails. A project's own logo still wins on emails about that project.
+type EmailBranding struct {
+	OrganisationID string    `json:"organisation_id" db:"organisation_id"`
+	BrandColor     string    `json:"brand_color" db:"brand_color"`
+	LogoURL        string    `json:"logo_url" db:"logo_url"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
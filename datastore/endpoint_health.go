@@ -0,0 +1,72 @@
+package datastore
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrEndpointHealthCheckNotFound = errors.New("endpoint health check not found")
+
+const (
+	EndpointHealthy   = "healthy"
+	EndpointUnhealthy = "unhealthy"
+)
+
+// EndpointHealthCheck configures periodic health checks for one endpoint and
+// carries the state the checker keeps between runs.
+type EndpointHealthCheck struct {
+	ProjectID  string `json:"project_id" db:"project_id"`
+	EndpointID string `json:"endpoint_id" db:"endpoint_id"`
+	Method     string `json:"method" db:"method"`
+	// Path is appended to the endpoint's url; empty checks the url itself.
+	Path string `json:"path" db:"path"`
+	// ExpectedStatus is the status code a healthy endpoint answers with; zero
+	// accepts any 2xx.
+	ExpectedStatus  int    `json:"expected_status" db:"expected_status"`
+	IntervalSeconds uint64 `json:"interval" db:"interval_seconds"`
+	TimeoutSeconds  uint64 `json:"timeout" db:"timeout_seconds"`
+	// FailureThreshold is how many checks in a row must fail before the
+	// endpoint is considered unhealthy.
+	FailureThreshold int  `json:"failure_threshold" db:"failure_threshold"`
+	AutoPause        bool `json:"auto_pause" db:"auto_pause"`
+	AutoActivate     bool `json:"auto_activate" db:"auto_activate"`
+
+	LastStatus          string     `json:"last_status" db:"last_status"`
+	ConsecutiveFailures int        `json:"consecutive_failures" db:"consecutive_failures"`
+	PausedByCheck       bool       `json:"paused_by_check" db:"paused_by_check"`
+	LastCheckedAt       *time.Time `json:"last_checked_at" db:"last_checked_at" swaggertype:"string"`
+	NextCheckAt         time.Time  `json:"next_check_at" db:"next_check_at" swaggertype:"string"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+// EndpointHealthCheckResult is a single health check run.
+type EndpointHealthCheckResult struct {
+	UID        string    `json:"uid" db:"id"`
+	ProjectID  string    `json:"project_id" db:"project_id"`
+	EndpointID string    `json:"endpoint_id" db:"endpoint_id"`
+	Healthy    bool      `json:"healthy" db:"healthy"`
+	StatusCode int       `json:"status_code" db:"status_code"`
+	LatencyMs  int64     `json:"latency_ms" db:"latency_ms"`
+	Error      string    `json:"error" db:"error"`
+	CheckedAt  time.Time `json:"checked_at" db:"checked_at" swaggertype:"string"`
+}
+
+// EndpointHealthStats aggregates health check results over a window.
+type EndpointHealthStats struct {
+	Checks int64 `json:"checks"`
+	// UptimePercentage is nil when no check ran in the window.
+	UptimePercentage *float64  `json:"uptime_percentage"`
+	LatencyP50Ms     float64   `json:"latency_p50_ms"`
+	LatencyP95Ms     float64   `json:"latency_p95_ms"`
+	LatencyP99Ms     float64   `json:"latency_p99_ms"`
+	Since            time.Time `json:"since" swaggertype:"string"`
+	Until            time.Time `json:"until" swaggertype:"string"`
+}
+
+// EndpointHealthCounts counts monitored endpoints by their last check outcome.
+type EndpointHealthCounts struct {
+	Monitored int64 `json:"monitored"`
+	Healthy   int64 `json:"healthy"`
+	Unhealthy int64 `json:"unhealthy"`
+}
@@ -0,0 +1,100 @@
+package datastore
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+var (
+	ErrEventTypeVersionNotFound    = errors.New("event type version not found")
+	ErrEventTypeVersionPinNotFound = errors.New("event type version pin not found")
+)
+
+// EventTypeVersionMetadataKey is the event metadata key holding the event
+// type version an event was published as.
+const EventTypeVersionMetadataKey = "eventTypeVersion"
+
+// EventTypeVersion is one revision of an event type's payload. Versions are
+// numbered from 1 in the order they are created.
+type EventTypeVersion struct {
+	UID         string          `json:"uid" db:"id"`
+	ProjectID   string          `json:"project_id" db:"project_id"`
+	EventTypeID string          `json:"event_type_id" db:"event_type_id"`
+	EventType   string          `json:"event_type" db:"event_type"`
+	Version     int             `json:"version" db:"version"`
+	JSONSchema  json.RawMessage `json:"json_schema" db:"json_schema" swaggertype:"object"`
+
+	// Downgrade is a JavaScript transform function that converts a payload of
+	// the next version into this one. Subscriptions pinned to this version
+	// receive newer events through it.
+	Downgrade null.String `json:"downgrade" db:"downgrade" swaggertype:"string" extensions:"x-nullable"`
+
+	// SunsetAt is when the version stops being supported. Publishing or
+	// pinning it is rejected from then on, and a meta event announces it.
+	SunsetAt         null.Time `json:"sunset_at" db:"sunset_at" swaggertype:"string" extensions:"x-nullable"`
+	SunsetNotifiedAt null.Time `json:"-" db:"sunset_notified_at"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+func (v *EventTypeVersion) IsSunset(now time.Time) bool {
+	return v.SunsetAt.Valid && !now.Before(v.SunsetAt.Time)
+}
+
+// EventTypeVersionPin pins a subscription to one version of an event type.
+// Events published as a newer version are downgraded before delivery.
+type EventTypeVersionPin struct {
+	UID            string    `json:"uid" db:"id"`
+	ProjectID      string    `json:"project_id" db:"project_id"`
+	SubscriptionID string    `json:"subscription_id" db:"subscription_id"`
+	EventType      string    `json:"event_type" db:"event_type"`
+	Version        int       `json:"version" db:"version"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+// DowngradeChain returns the transforms that convert a payload published as
+// version from into version to, in the order they must run. versions must be
+// ordered by version. It reports false when a version in between has no
+// downgrade transform.
+func DowngradeChain(versions []EventTypeVersion, from, to int) ([]string, bool) {
+	chain := make([]string, 0, from-to)
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		if v.Version < to || v.Version >= from {
+			continue
+		}
+
+		if !v.Downgrade.Valid || v.Downgrade.String == "" {
+			return nil, false
+		}
+		chain = append(chain, v.Downgrade.String)
+	}
+
+	return chain, len(chain) == from-to
+}
+
+// GetEventTypeVersion returns the event type version the event was published
+// as, or 0 when the publisher named none.
+func (e *Event) GetEventTypeVersion() int {
+	if e.Metadata == "" {
+		return 0
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal([]byte(e.Metadata), &m); err != nil {
+		return 0
+	}
+
+	version, err := strconv.Atoi(m[EventTypeVersionMetadataKey])
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
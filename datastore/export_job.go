@@ -0,0 +1,70 @@
+package datastore
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+var (
+	ErrExportJobNotFound = errors.New("export job not found")
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportFormatCSV, ExportFormatJSONL:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentType is the media type of a file in this format.
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+type ExportJobStatus string
+
+const (
+	ExportJobStatusPending    ExportJobStatus = "pending"
+	ExportJobStatusProcessing ExportJobStatus = "processing"
+	ExportJobStatusCompleted  ExportJobStatus = "completed"
+	ExportJobStatusFailed     ExportJobStatus = "failed"
+)
+
+// ExportJob writes every event or delivery a search matches to a file in
+// the instance's blob store.
+type ExportJob struct {
+	UID           string         `json:"uid" db:"id"`
+	ProjectID     string         `json:"project_id" db:"project_id"`
+	SavedSearchID string         `json:"saved_search_id,omitempty" db:"saved_search_id"`
+	Resource      SearchResource `json:"resource" db:"resource"`
+	Format        ExportFormat   `json:"format" db:"format"`
+	Filter        SearchFilter   `json:"filter" db:"filter"`
+
+	Status ExportJobStatus `json:"status" db:"status"`
+
+	// RowCount is how many events or deliveries were written.
+	RowCount int64 `json:"row_count" db:"row_count"`
+
+	// ObjectKey is where the file was written, relative to the blob store's
+	// bucket and prefix.
+	ObjectKey string `json:"object_key,omitempty" db:"object_key"`
+
+	Error       string    `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at" swaggertype:"string"`
+	CompletedAt null.Time `json:"completed_at" db:"completed_at" swaggertype:"string" extensions:"x-nullable"`
+}
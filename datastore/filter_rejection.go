@@ -0,0 +1,18 @@
+package datastore
+
+import "time"
+
+// FilterRejection records that a subscription's filter turned an event away.
+// Projects opt in with ProjectConfig.RecordFilterRejections, and rejections
+// are kept for a few days only.
+type FilterRejection struct {
+	UID            string `json:"uid" db:"id"`
+	ProjectID      string `json:"project_id" db:"project_id"`
+	EventID        string `json:"event_id" db:"event_id"`
+	SubscriptionID string `json:"subscription_id" db:"subscription_id"`
+	EventType      string `json:"event_type" db:"event_type"`
+	// Reason names the filter scope and condition that rejected the event. It
+	// never carries event content.
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/pkg/celfilter"
+	"github.com/frain-dev/convoy/pkg/compare"
 	"github.com/frain-dev/convoy/pkg/flatten"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 )
@@ -189,8 +191,69 @@ const (
 	EventDeliveryUpdated HookEventType = "eventdelivery.updated"
 	EventDeliverySuccess HookEventType = "eventdelivery.success"
 	EventDeliveryFailed  HookEventType = "eventdelivery.failed"
+
+	CircuitBreakerOpened     HookEventType = "circuitbreaker.opened"
+	CircuitBreakerHalfOpened HookEventType = "circuitbreaker.half_opened"
+	CircuitBreakerClosed     HookEventType = "circuitbreaker.closed"
+
+	EventTypeVersionSunset HookEventType = "eventtype.version_sunset"
+
+	EndpointPaused        HookEventType = "endpoint.paused"
+	EndpointActivated     HookEventType = "endpoint.activated"
+	EndpointDisabled      HookEventType = "endpoint.disabled"
+	EndpointSecretRotated HookEventType = "endpoint.secret_rotated"
+	EndpointSecretExpired HookEventType = "endpoint.secret_expired"
+
+	SubscriptionCreated HookEventType = "subscription.created"
+	SubscriptionUpdated HookEventType = "subscription.updated"
+	SubscriptionDeleted HookEventType = "subscription.deleted"
+
+	SourceCreated HookEventType = "source.created"
+	SourceUpdated HookEventType = "source.updated"
+	SourceDeleted HookEventType = "source.deleted"
+
+	PortalLinkCreated HookEventType = "portallink.created"
+	PortalLinkUpdated HookEventType = "portallink.updated"
+	PortalLinkRevoked HookEventType = "portallink.revoked"
+
+	EventTypeCreated    HookEventType = "eventtype.created"
+	EventTypeUpdated    HookEventType = "eventtype.updated"
+	EventTypeDeprecated HookEventType = "eventtype.deprecated"
+
+	BatchRetryCompleted HookEventType = "batchretry.completed"
+	BatchRetryFailed    HookEventType = "batchretry.failed"
+
+	BackupJobCompleted HookEventType = "backupjob.completed"
 )
 
+// MetaEventTypes are the event types a project can opt in to through
+// MetaEventConfiguration.EventType. eventdelivery.updated is internal, it
+// is announced as eventdelivery.success or eventdelivery.failed.
+var MetaEventTypes = []HookEventType{
+	ProjectUpdated,
+	EndpointCreated, EndpointUpdated, EndpointDeleted,
+	EndpointPaused, EndpointActivated, EndpointDisabled,
+	EndpointSecretRotated, EndpointSecretExpired,
+	EventDeliverySuccess, EventDeliveryFailed,
+	CircuitBreakerOpened, CircuitBreakerHalfOpened, CircuitBreakerClosed,
+	SubscriptionCreated, SubscriptionUpdated, SubscriptionDeleted,
+	SourceCreated, SourceUpdated, SourceDeleted,
+	PortalLinkCreated, PortalLinkUpdated, PortalLinkRevoked,
+	EventTypeCreated, EventTypeUpdated, EventTypeDeprecated, EventTypeVersionSunset,
+	BatchRetryCompleted, BatchRetryFailed,
+	BackupJobCompleted,
+}
+
+func IsValidMetaEventType(eventType string) bool {
+	for _, t := range MetaEventTypes {
+		if string(t) == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
 const (
 	GithubSourceProvider  SourceProvider = "github"
 	TwitterSourceProvider SourceProvider = "twitter"
@@ -445,6 +508,11 @@ type Endpoint struct {
 	SupportEmail       string  `json:"support_email,omitempty" db:"support_email"`
 	AppID              string  `json:"-" db:"app_id"` // Deprecated but necessary for backward compatibility
 
+	// NotificationChannels are the incident channels (generic webhook,
+	// PagerDuty, Opsgenie) told about status changes alongside the support
+	// email, Slack and Teams.
+	NotificationChannels *NotificationChannels `json:"notification_channels,omitempty" db:"notification_channels" extensions:"x-nullable"`
+
 	Status         EndpointStatus          `json:"status" db:"status"`
 	HttpTimeout    uint64                  `json:"http_timeout" db:"http_timeout"`
 	Events         int64                   `json:"events,omitempty" db:"event_count"`
@@ -494,6 +562,104 @@ func (e *Endpoint) FindSecret(secretID string) *Secret {
 	return nil
 }
 
+// NotificationChannels are incident channels that open an incident when an alert
+// triggers and close it when the alert resolves. Nil members skip that channel.
+//
+// They are set on endpoints and alert rules only. There is no project-level
+// set: an alert rule without an endpoint_id already covers the whole project,
+// and a second project-wide list would notify the same incident twice.
+type NotificationChannels struct {
+	Webhook   *WebhookChannel   `json:"webhook,omitempty" extensions:"x-nullable"`
+	PagerDuty *PagerDutyChannel `json:"pagerduty,omitempty" extensions:"x-nullable"`
+	Opsgenie  *OpsgenieChannel  `json:"opsgenie,omitempty" extensions:"x-nullable"`
+}
+
+// RedactedSecret replaces credentials in API responses.
+const RedactedSecret = "[REDACTED]"
+
+// IsEmpty reports whether no incident channel is configured.
+func (n *NotificationChannels) IsEmpty() bool {
+	return n == nil || (n.Webhook == nil && n.PagerDuty == nil && n.Opsgenie == nil)
+}
+
+// Redacted returns a copy with the webhook secret, PagerDuty routing key and
+// Opsgenie api key masked, for API responses. n is left untouched.
+func (n *NotificationChannels) Redacted() *NotificationChannels {
+	if n == nil {
+		return nil
+	}
+
+	c := *n
+	if c.Webhook != nil {
+		w := *c.Webhook
+		if w.Secret != "" {
+			w.Secret = RedactedSecret
+		}
+		c.Webhook = &w
+	}
+	if c.PagerDuty != nil {
+		p := *c.PagerDuty
+		if p.RoutingKey != "" {
+			p.RoutingKey = RedactedSecret
+		}
+		c.PagerDuty = &p
+	}
+	if c.Opsgenie != nil {
+		o := *c.Opsgenie
+		if o.APIKey != "" {
+			o.APIKey = RedactedSecret
+		}
+		c.Opsgenie = &o
+	}
+	return &c
+}
+
+// RestoreRedacted puts the stored credential back wherever n still carries
+// the mask, so sending a fetched endpoint or rule back unchanged keeps its
+// secrets. A mask with nothing stored behind it is left for validation to reject.
+func (n *NotificationChannels) RestoreRedacted(stored *NotificationChannels) {
+	if n == nil || stored == nil {
+		return
+	}
+
+	if n.Webhook != nil && n.Webhook.Secret == RedactedSecret && stored.Webhook != nil {
+		n.Webhook.Secret = stored.Webhook.Secret
+	}
+	if n.PagerDuty != nil && n.PagerDuty.RoutingKey == RedactedSecret && stored.PagerDuty != nil {
+		n.PagerDuty.RoutingKey = stored.PagerDuty.RoutingKey
+	}
+	if n.Opsgenie != nil && n.Opsgenie.APIKey == RedactedSecret && stored.Opsgenie != nil {
+		n.Opsgenie.APIKey = stored.Opsgenie.APIKey
+	}
+}
+
+// WebhookChannel is a generic HTTP target. Each alert is POSTed as JSON and
+// signed with Secret the same way Convoy signs advanced-signature deliveries.
+// Responses mask Secret, so set it yourself when the receiver verifies
+// signatures; one generated on save cannot be read back.
+type WebhookChannel struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+// PagerDutyChannel sends PagerDuty Events API v2 events to the service that
+// owns RoutingKey.
+type PagerDutyChannel struct {
+	RoutingKey string `json:"routing_key"`
+}
+
+const (
+	OpsgenieRegionUS = "us"
+	OpsgenieRegionEU = "eu"
+)
+
+// OpsgenieChannel creates and closes Opsgenie alerts with an API integration
+// key. Region picks the Opsgenie instance and defaults to us.
+type OpsgenieChannel struct {
+	APIKey string `json:"api_key"`
+	Region string `json:"region,omitempty"`
+}
+
 type EndpointConfig struct {
 	AdvancedSignatures bool                    `json:"advanced_signatures" db:"advanced_signatures"`
 	Secrets            []Secret                `json:"secrets" db:"secrets"`
@@ -645,6 +811,13 @@ type ProjectConfig struct {
 	// project's endpoint URL templates auto-create an endpoint. Default false
 	// rejects unmatched URLs.
 	AllowUnmatchedDynamicURLs bool                           `json:"allow_unmatched_dynamic_urls" db:"allow_unmatched_dynamic_urls"`
+	// EndpointMetricLabels labels the project's delivery metrics per endpoint.
+	// Default false reports them with an empty endpoint label, one series per
+	// project, so large projects do not blow up the series count.
+	EndpointMetricLabels bool                                `json:"endpoint_metric_labels" db:"endpoint_metric_labels"`
+	// RecordFilterRejections keeps, for a few days, which subscription filters
+	// turned each event away, including events another subscription received.
+	RecordFilterRejections bool                              `json:"record_filter_rejections" db:"record_filter_rejections"`
 	// SearchPolicy is an optional Go duration (e.g. "24h") shown in project settings.
 	// When set, the dashboard explains that payload/JSON search is additionally clamped
 	// to this lookback intersected with the Events log date picker. Empty means opt-out.
@@ -865,6 +1038,10 @@ func (s *EndpointMetadata) Scan(v interface{}) error {
 	return nil
 }
 
+// TestEventMetadataKey is the event metadata key that marks a test event
+// while it is matched to subscriptions.
+const TestEventMetadataKey = "test"
+
 // Event defines a payload to be sent to an application
 type Event struct {
 	UID       string    `json:"uid" db:"id"`
@@ -900,6 +1077,10 @@ type Event struct {
 	// credentials, headers, or payload content.
 	FailureReason string `json:"failure_reason,omitempty" db:"failure_reason"`
 
+	// IsTest marks an event sent to try an endpoint out. Test events are
+	// not counted towards the organisation's usage.
+	IsTest bool `json:"is_test,omitempty" db:"is_test"`
+
 	AcknowledgedAt null.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at,omitempty" swaggertype:"string" extensions:"x-nullable"`
 	CreatedAt      time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt      time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
@@ -1024,6 +1205,14 @@ type Metadata struct {
 	RetryLimit uint64 `json:"retry_limit" bson:"retry_limit"`
 
 	MaxRetrySeconds uint64 `json:"max_retry_seconds" bson:"max_retry_seconds"`
+
+	// ReplayJobID is set on deliveries created by a replay job.
+	ReplayJobID string `json:"replay_job_id,omitempty" bson:"replay_job_id"`
+
+	// Test is set on deliveries of a test event. They are sent like any
+	// other delivery but are left out of metrics and never disable the
+	// endpoint.
+	Test bool `json:"test,omitempty" bson:"test"`
 }
 
 func (m *Metadata) Scan(value interface{}) error {
@@ -1248,6 +1437,10 @@ type Subscription struct {
 
 	DeliveryMode DeliveryMode `json:"delivery_mode,omitempty" db:"delivery_mode"`
 
+	// CLIExpiresAt is when the listen session owning a CLI subscription is
+	// presumed gone, the session pushes it forward while it is open.
+	CLIExpiresAt null.Time `json:"-" db:"cli_expires_at"`
+
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at" swaggertype:"string"`
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string" extensions:"x-nullable"`
@@ -1458,7 +1651,9 @@ type FilterConfiguration struct {
 	Filter     FilterSchema   `json:"filter" db:"filter"`
 }
 
-// EventTypeFilter represents a filter configuration for a specific event type within a subscription
+// EventTypeFilter represents a filter configuration for a specific event type within a subscription.
+// A filter either matches the headers, body, query and path criteria or, when
+// Expression is set, evaluates that CEL expression instead.
 type EventTypeFilter struct {
 	UID            string     `json:"uid" db:"id"`
 	SubscriptionID string     `json:"subscription_id" db:"subscription_id"`
@@ -1473,6 +1668,7 @@ type EventTypeFilter struct {
 	RawBody        M          `json:"raw_body" db:"raw_body"`
 	RawQuery       M          `json:"raw_query" db:"raw_query"`
 	RawPath        M          `json:"raw_path" db:"raw_path"`
+	Expression     string     `json:"expression" db:"expression"`
 	CreatedAt      time.Time  `json:"-" db:"created_at" swaggertype:"string"`
 	UpdatedAt      time.Time  `json:"-" db:"updated_at" swaggertype:"string"`
 }
@@ -1481,6 +1677,31 @@ func (f *EventTypeFilter) IsEnabled() bool {
 	return f != nil && (f.EnabledAt != nil || !f.EnabledAtSet)
 }
 
+// HasConditions reports whether the filter restricts anything; a filter
+// without conditions matches every event.
+func (f *EventTypeFilter) HasConditions() bool {
+	return f.Expression != "" || len(f.Body) > 0 || len(f.Headers) > 0 || len(f.Query) > 0 || len(f.Path) > 0
+}
+
+// ValidateExpression checks the filter's CEL expression, if it has one.
+func (f *EventTypeFilter) ValidateExpression() error {
+	return validateFilterExpression(f.Expression, f.Headers, f.Body, f.Query, f.Path)
+}
+
+func validateFilterExpression(expression string, criteria ...M) error {
+	if expression == "" {
+		return nil
+	}
+
+	for _, c := range criteria {
+		if len(c) > 0 {
+			return ErrFilterExpressionWithCriteria
+		}
+	}
+
+	return celfilter.Check(expression)
+}
+
 type M map[string]interface{}
 
 func HasArrayWildcardSelector(filter M) bool {
@@ -1563,6 +1784,19 @@ type FilterSchema struct {
 	RawBody    M `json:"-" db:"raw_body"`
 	RawQuery   M `json:"-" db:"raw_query"`
 	RawPath    M `json:"-" db:"raw_path"`
+
+	// Expression is a CEL expression matched instead of the other criteria.
+	Expression string `json:"expression" db:"expression"`
+}
+
+// HasConditions reports whether the schema restricts anything.
+func (fs *FilterSchema) HasConditions() bool {
+	return fs.Expression != "" || len(fs.Body) > 0 || len(fs.Headers) > 0 || len(fs.Query) > 0 || len(fs.Path) > 0
+}
+
+// ValidateExpression checks the schema's CEL expression, if it has one.
+func (fs *FilterSchema) ValidateExpression() error {
+	return validateFilterExpression(fs.Expression, fs.Headers, fs.Body, fs.Query, fs.Path)
 }
 
 type FilterTestRequest struct {
@@ -1572,6 +1806,56 @@ type FilterTestRequest struct {
 	Path    M           `json:"path"`
 }
 
+// ExpressionInput returns the request in the shape a filter expression sees.
+// The path scope holds the request path under the "path" key.
+func (r FilterTestRequest) ExpressionInput() celfilter.Input {
+	path, _ := r.Path["path"].(string)
+	return celfilter.Input{Body: r.Body, Headers: r.Headers, Query: r.Query, Path: path}
+}
+
+// FilterSelection says which of a subscription's filters applies to an event type.
+type FilterSelection string
+
+const (
+	// FilterSelectionExact is a filter created for the event type itself.
+	FilterSelectionExact FilterSelection = "exact"
+
+	// FilterSelectionCatchAll is the subscription's "*" filter, used when it has
+	// no enabled filter for the event type.
+	FilterSelectionCatchAll FilterSelection = "catch_all"
+
+	// FilterSelectionNone means the subscription has no filter for the event
+	// type or "*", so it does not receive the event.
+	FilterSelectionNone FilterSelection = "none"
+
+	// FilterSelectionDisabled means every filter that could apply to the event
+	// type is disabled, so the subscription does not receive the event.
+	FilterSelectionDisabled FilterSelection = "disabled"
+)
+
+// FilterExplanation describes how a subscription's filter evaluated a request:
+// which filter was selected for the event type, and the outcome of each
+// condition in each scope. A nil scope had no conditions.
+type FilterExplanation struct {
+	SubscriptionID   string           `json:"subscription_id,omitempty"`
+	SubscriptionName string           `json:"subscription_name,omitempty"`
+	Selection        FilterSelection  `json:"selection,omitempty"`
+	Filter           *EventTypeFilter `json:"filter,omitempty"`
+	Matched          bool             `json:"matched"`
+
+	// Reason names the scope and condition that turned the request away. It
+	// never carries request content, so it is safe to store against an event.
+	Reason string `json:"reason,omitempty"`
+
+	// ExpressionError is set when the filter's expression does not compile.
+	ExpressionError string `json:"expression_error,omitempty"`
+
+	Body    *compare.Explanation `json:"body,omitempty"`
+	Headers *compare.Explanation `json:"headers,omitempty"`
+	Query   *compare.Explanation `json:"query,omitempty"`
+	Path    *compare.Explanation `json:"path,omitempty"`
+}
+
 type ProviderConfig struct {
 	Twitter *TwitterProviderConfig `json:"twitter" db:"twitter" extensions:"x-nullable"`
 }
@@ -1987,6 +2271,64 @@ const (
 	PortalAuthTypeStaticToken  PortalAuthType = "static_token"
 )
 
+// PortalLinkPermission is a feature of the portal a portal link may use.
+type PortalLinkPermission string
+
+const (
+	PortalLinkViewPayloads     PortalLinkPermission = "view_payloads"
+	PortalLinkRetryDeliveries  PortalLinkPermission = "retry_deliveries"
+	PortalLinkReplayEvents     PortalLinkPermission = "replay_events"
+	PortalLinkRotateSecrets    PortalLinkPermission = "rotate_secrets"
+	PortalLinkManageFilters    PortalLinkPermission = "manage_filters"
+	PortalLinkCreateEventTypes PortalLinkPermission = "create_event_types"
+)
+
+var portalLinkPermissions = []PortalLinkPermission{
+	PortalLinkViewPayloads,
+	PortalLinkRetryDeliveries,
+	PortalLinkReplayEvents,
+	PortalLinkRotateSecrets,
+	PortalLinkManageFilters,
+	PortalLinkCreateEventTypes,
+}
+
+func (p PortalLinkPermission) IsValid() bool {
+	for _, permission := range portalLinkPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPortalLinkPermissions are the permissions of a portal link created
+// without any, and of links created before permissions existed. They match
+// what such links could always do: secrets were rotated as part of endpoint
+// management and event types could not be created.
+func DefaultPortalLinkPermissions(canManageEndpoint bool) []PortalLinkPermission {
+	permissions := []PortalLinkPermission{
+		PortalLinkViewPayloads,
+		PortalLinkRetryDeliveries,
+		PortalLinkReplayEvents,
+		PortalLinkManageFilters,
+	}
+
+	if canManageEndpoint {
+		permissions = append(permissions, PortalLinkRotateSecrets)
+	}
+
+	return permissions
+}
+
+func validatePortalLinkPermissions(permissions []PortalLinkPermission) error {
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return fmt.Errorf("unknown portal link permission: %s", permission)
+		}
+	}
+	return nil
+}
+
 type PortalLink struct {
 	UID               string           `json:"uid" db:"id"`
 	Name              string           `json:"name" db:"name"`
@@ -1998,6 +2340,14 @@ type PortalLink struct {
 	EndpointCount     int              `json:"endpoint_count" db:"endpoint_count"`
 	CanManageEndpoint bool             `json:"can_manage_endpoint" db:"can_manage_endpoint"`
 
+	// EventTypes the portal link may subscribe its endpoints to, empty
+	// allows every event type.
+	EventTypes pq.StringArray `json:"event_types" db:"event_types"`
+
+	// Permissions are the features the portal link may use, nil means the
+	// defaults.
+	Permissions []PortalLinkPermission `json:"permissions" db:"permissions"`
+
 	// portal auth stuff
 	TokenExpiresAt null.Time      `json:"token_expires_at" db:"token_expires_at" extensions:"x-nullable"`
 	TokenMaskId    string         `json:"token_mask_id" db:"token_mask_id"`
@@ -2011,6 +2361,41 @@ type PortalLink struct {
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at,omitempty" swaggertype:"string" extensions:"x-nullable"`
 }
 
+// AllowsEventType reports whether endpoints in the portal link may be
+// subscribed to eventType.
+func (p *PortalLink) AllowsEventType(eventType string) bool {
+	if len(p.EventTypes) == 0 {
+		return true
+	}
+
+	for _, t := range p.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EffectivePermissions returns the permissions of the portal link, the
+// defaults when none were set.
+func (p *PortalLink) EffectivePermissions() []PortalLinkPermission {
+	if p.Permissions == nil {
+		return DefaultPortalLinkPermissions(p.CanManageEndpoint)
+	}
+	return p.Permissions
+}
+
+// HasPermission reports whether the portal link may use a feature.
+func (p *PortalLink) HasPermission(permission PortalLinkPermission) bool {
+	for _, granted := range p.EffectivePermissions() {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
 type PortalToken struct {
 	UID          string `json:"uid" db:"id"`
 	PortalLinkID string `json:"portal_link_id" db:"portal_link_id"`
@@ -2175,21 +2560,23 @@ func (p *Password) Matches() (bool, error) {
 }
 
 type PortalLinkResponse struct {
-	UID               string           `json:"uid"`
-	Name              string           `json:"name"`
-	ProjectID         string           `json:"project_id"`
-	OwnerID           string           `json:"owner_id"`
-	Endpoints         []string         `json:"endpoints"`
-	EndpointCount     int              `json:"endpoint_count"`
-	CanManageEndpoint bool             `json:"can_manage_endpoint"`
-	Token             string           `json:"token"`
-	EndpointsMetadata EndpointMetadata `json:"endpoints_metadata"`
-	URL               string           `json:"url"`
-	AuthType          PortalAuthType   `json:"auth_type"`
-	AuthKey           string           `json:"auth_key"`
-	CreatedAt         time.Time        `json:"created_at,omitempty"`
-	UpdatedAt         time.Time        `json:"updated_at,omitempty"`
-	DeletedAt         null.Time        `json:"deleted_at,omitempty" extensions:"x-nullable"`
+	UID               string                 `json:"uid"`
+	Name              string                 `json:"name"`
+	ProjectID         string                 `json:"project_id"`
+	OwnerID           string                 `json:"owner_id"`
+	Endpoints         []string               `json:"endpoints"`
+	EndpointCount     int                    `json:"endpoint_count"`
+	CanManageEndpoint bool                   `json:"can_manage_endpoint"`
+	EventTypes        []string               `json:"event_types"`
+	Permissions       []PortalLinkPermission `json:"permissions"`
+	Token             string                 `json:"token"`
+	EndpointsMetadata EndpointMetadata       `json:"endpoints_metadata"`
+	URL               string                 `json:"url"`
+	AuthType          PortalAuthType         `json:"auth_type"`
+	AuthKey           string                 `json:"auth_key"`
+	CreatedAt         time.Time              `json:"created_at,omitempty"`
+	UpdatedAt         time.Time              `json:"updated_at,omitempty"`
+	DeletedAt         null.Time              `json:"deleted_at,omitempty" extensions:"x-nullable"`
 }
 
 type UpdatePortalLinkRequest struct {
@@ -2207,6 +2594,16 @@ type UpdatePortalLinkRequest struct {
 
 	// Specify whether endpoint management can be done through the Portal Link UI
 	CanManageEndpoint bool `json:"can_manage_endpoint"`
+
+	// Event types the portal link may subscribe its endpoints to, leave
+	// empty to allow every event type
+	EventTypes []string `json:"event_types"`
+
+	// Features the portal link may use: view_payloads, retry_deliveries,
+	// replay_events, rotate_secrets, manage_filters and create_event_types.
+	// Leave out to keep the defaults, or the link's current permissions on
+	// update
+	Permissions []PortalLinkPermission `json:"permissions"`
 }
 
 func (p *UpdatePortalLinkRequest) Validate() error {
@@ -2218,6 +2615,10 @@ func (p *UpdatePortalLinkRequest) Validate() error {
 		return err
 	}
 
+	if err = validatePortalLinkPermissions(p.Permissions); err != nil {
+		return err
+	}
+
 	validAuthTypes := []PortalAuthType{
 		PortalAuthTypeRefreshToken,
 		PortalAuthTypeStaticToken,
@@ -2265,6 +2666,16 @@ type CreatePortalLinkRequest struct {
 
 	// Specify whether endpoint management can be done through the Portal Link UI
 	CanManageEndpoint bool `json:"can_manage_endpoint"`
+
+	// Event types the portal link may subscribe its endpoints to, leave
+	// empty to allow every event type
+	EventTypes []string `json:"event_types"`
+
+	// Features the portal link may use: view_payloads, retry_deliveries,
+	// replay_events, rotate_secrets, manage_filters and create_event_types.
+	// Leave out to keep the defaults, or the link's current permissions on
+	// update
+	Permissions []PortalLinkPermission `json:"permissions"`
 }
 
 func (p *CreatePortalLinkRequest) Validate() error {
@@ -2277,6 +2688,10 @@ func (p *CreatePortalLinkRequest) Validate() error {
 		return err
 	}
 
+	if err = validatePortalLinkPermissions(p.Permissions); err != nil {
+		return err
+	}
+
 	validAuthTypes := []PortalAuthType{
 		PortalAuthTypeRefreshToken,
 		PortalAuthTypeStaticToken,
@@ -302,3 +302,36 @@ func TestEvent_FailureReasonIsSerialized(t *testing.T) {
 	require.NoError(t, err)
 	require.NotContains(t, string(out), "failure_reason")
 }
+
+func TestPortalLink_HasPermission(t *testing.T) {
+	// Links without permissions keep what portal links could always do.
+	legacy := &PortalLink{}
+	require.True(t, legacy.HasPermission(PortalLinkViewPayloads))
+	require.True(t, legacy.HasPermission(PortalLinkRetryDeliveries))
+	require.False(t, legacy.HasPermission(PortalLinkRotateSecrets))
+	require.False(t, legacy.HasPermission(PortalLinkCreateEventTypes))
+
+	legacy.CanManageEndpoint = true
+	require.True(t, legacy.HasPermission(PortalLinkRotateSecrets))
+
+	support := &PortalLink{CanManageEndpoint: true, Permissions: []PortalLinkPermission{PortalLinkRetryDeliveries}}
+	require.True(t, support.HasPermission(PortalLinkRetryDeliveries))
+	require.False(t, support.HasPermission(PortalLinkViewPayloads))
+	require.False(t, support.HasPermission(PortalLinkRotateSecrets))
+
+	none := &PortalLink{Permissions: []PortalLinkPermission{}}
+	require.False(t, none.HasPermission(PortalLinkViewPayloads))
+}
+
+func TestCreatePortalLinkRequest_ValidatePermissions(t *testing.T) {
+	req := &CreatePortalLinkRequest{
+		Name:        "support",
+		OwnerID:     "owner-1",
+		AuthType:    string(PortalAuthTypeRefreshToken),
+		Permissions: []PortalLinkPermission{PortalLinkViewPayloads, PortalLinkManageFilters},
+	}
+	require.NoError(t, req.Validate())
+
+	req.Permissions = append(req.Permissions, "delete_project")
+	require.ErrorContains(t, req.Validate(), "unknown portal link permission: delete_project")
+}
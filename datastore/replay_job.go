@@ -0,0 +1,148 @@
+package datastore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+var (
+	ErrReplayJobNotFound = errors.New("replay job not found")
+)
+
+type ReplayJobStatus string
+
+const (
+	ReplayJobStatusPending    ReplayJobStatus = "pending"
+	ReplayJobStatusProcessing ReplayJobStatus = "processing"
+	ReplayJobStatusCompleted  ReplayJobStatus = "completed"
+	ReplayJobStatusFailed     ReplayJobStatus = "failed"
+	ReplayJobStatusCancelled  ReplayJobStatus = "cancelled"
+)
+
+// IsTerminal reports whether a job in this status will not run again.
+func (s ReplayJobStatus) IsTerminal() bool {
+	switch s {
+	case ReplayJobStatusCompleted, ReplayJobStatusFailed, ReplayJobStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReplayTargetType string
+
+const (
+	// ReplayTargetSubscriptions replays each event to the subscriptions that
+	// match it today, the same as replaying it by hand.
+	ReplayTargetSubscriptions ReplayTargetType = "subscriptions"
+
+	// ReplayTargetEndpoint replays events to one endpoint through its own
+	// subscriptions and filters, whichever endpoints the events first went to.
+	ReplayTargetEndpoint ReplayTargetType = "endpoint"
+
+	// ReplayTargetNewEndpoint is ReplayTargetEndpoint for an endpoint created
+	// with the job. An endpoint without subscriptions is given a catch-all
+	// subscription so the backfill has somewhere to go.
+	ReplayTargetNewEndpoint ReplayTargetType = "new_endpoint"
+)
+
+func (t ReplayTargetType) IsValid() bool {
+	switch t {
+	case ReplayTargetSubscriptions, ReplayTargetEndpoint, ReplayTargetNewEndpoint:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReplayJob replays the events a filter selects, in the background and at a
+// bounded rate. Deliveries it creates carry its id in Metadata.ReplayJobID.
+type ReplayJob struct {
+	UID        string           `json:"uid" db:"id"`
+	ProjectID  string           `json:"project_id" db:"project_id"`
+	Status     ReplayJobStatus  `json:"status" db:"status"`
+	Filter     ReplayJobFilter  `json:"filter" db:"filter"`
+	TargetType ReplayTargetType `json:"target_type" db:"target_type"`
+	EndpointID string           `json:"endpoint_id,omitempty" db:"endpoint_id"`
+
+	// RateLimit is the most events replayed per second.
+	RateLimit int `json:"rate_limit" db:"rate_limit"`
+
+	// TotalEvents counts the events in the filter's time range and sources
+	// when the job is created. Event types and payload search narrow the
+	// selection while the job runs, so it is an upper bound.
+	TotalEvents     int `json:"total_events" db:"total_events"`
+	ProcessedEvents int `json:"processed_events" db:"processed_events"`
+	FailedEvents    int `json:"failed_events" db:"failed_events"`
+	SkippedEvents   int `json:"skipped_events" db:"skipped_events"`
+
+	// Cursor is where the next page of events starts, so a job picked up
+	// again after a worker restart carries on instead of starting over.
+	Cursor string `json:"-" db:"cursor"`
+
+	Error       string    `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at" swaggertype:"string"`
+	CompletedAt null.Time `json:"completed_at" db:"completed_at" swaggertype:"string" extensions:"x-nullable"`
+}
+
+// ReplayJobFilter selects the events a replay job replays.
+type ReplayJobFilter struct {
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	EventTypes []string  `json:"event_types,omitempty"`
+	SourceIDs  []string  `json:"source_ids,omitempty"`
+
+	// Query and Body are the event list search: text matched against event
+	// ids, idempotency keys, event types and source names, and a JSON object
+	// the payload must contain.
+	Query string          `json:"query,omitempty"`
+	Body  json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+}
+
+// MatchesEventType reports whether the filter selects events of eventType.
+func (f ReplayJobFilter) MatchesEventType(eventType string) bool {
+	return len(f.EventTypes) == 0 || slices.Contains(f.EventTypes, eventType)
+}
+
+// EventFilter is the events list filter for the page of events that starts
+// at cursor, oldest first.
+func (f ReplayJobFilter) EventFilter(project *Project, cursor string, perPage int) *Filter {
+	filter := &Filter{
+		Project:   project,
+		ProjectID: project.UID,
+		Query:     f.Query,
+		Body:      f.Body,
+		SourceIDs: f.SourceIDs,
+		SearchParams: SearchParams{
+			CreatedAtStart: f.StartDate.Unix(),
+			CreatedAtEnd:   f.EndDate.Unix(),
+		},
+		Pageable: Pageable{
+			PerPage:    perPage,
+			Direction:  Next,
+			Sort:       "ASC",
+			NextCursor: cursor,
+		},
+	}
+
+	if len(f.SourceIDs) == 1 {
+		filter.SourceID = f.SourceIDs[0]
+	}
+
+	return filter
+}
+
+func (f *ReplayJobFilter) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	return json.Unmarshal(bytes, f)
+}
@@ -18,11 +18,20 @@ type EventDeliveryRepository interface {
 	FindEventDeliveryByIDSlim(ctx context.Context, projectID string, id string) (*EventDelivery, error)
 	FindEventDeliveriesByIDs(ctx context.Context, projectID string, ids []string) ([]EventDelivery, error)
 	FindEventDeliveriesByEventID(ctx context.Context, projectID string, id string) ([]EventDelivery, error)
+	// FindEventDeliveriesByEventIDs returns the deliveries of several events,
+	// grouped by event.
+	FindEventDeliveriesByEventIDs(ctx context.Context, projectID string, eventIDs []string) ([]EventDelivery, error)
 	CountDeliveriesByStatus(ctx context.Context, projectID string, status EventDeliveryStatus, params SearchParams) (int64, error)
 	UpdateStatusOfEventDelivery(ctx context.Context, projectID string, eventDelivery EventDelivery, status EventDeliveryStatus) error
 	UpdateStatusOfEventDeliveries(ctx context.Context, projectID string, ids []string, status EventDeliveryStatus) error
 	FindDiscardedEventDeliveries(ctx context.Context, projectID, deviceId string, params SearchParams) ([]EventDelivery, error)
 	FindStuckEventDeliveriesByStatus(ctx context.Context, status EventDeliveryStatus) ([]EventDelivery, error)
+	// ClaimScheduledEventDeliveries moves up to limit Scheduled deliveries of a
+	// CLI subscription to Processing and returns them, oldest first.
+	ClaimScheduledEventDeliveries(ctx context.Context, projectID, subscriptionID string, limit int) ([]EventDelivery, error)
+	// DiscardCLIDeliveries discards the deliveries of a CLI subscription
+	// that no listen session acknowledged, Scheduled or claimed.
+	DiscardCLIDeliveries(ctx context.Context, projectID, subscriptionID, reason string) error
 	UpdateEventDeliveryMetadata(ctx context.Context, projectID string, eventDelivery *EventDelivery) error
 	CountEventDeliveries(ctx context.Context, projectID string, endpointIDs []string, eventID string, status []EventDeliveryStatus, params SearchParams) (int64, error)
 	CountDeliveriesByEndpointAndStatus(ctx context.Context, projectID string, endpointIDs []string, statuses []EventDeliveryStatus, params SearchParams) ([]EndpointStatusDeliveryCount, error)
@@ -233,6 +242,12 @@ type SubscriptionRepository interface {
 	FindSubscriptionsBySourceID(ctx context.Context, projectID, sourceID string) ([]Subscription, error)
 	FindSubscriptionsByEndpointID(ctx context.Context, projectId string, endpointID string) ([]Subscription, error)
 	FindCLISubscriptions(ctx context.Context, projectID string) ([]Subscription, error)
+	// ExtendCLISubscription moves a CLI subscription's expiry to expiresAt,
+	// an open listen session calls it as it heartbeats.
+	ExtendCLISubscription(ctx context.Context, projectID, subscriptionID string, expiresAt time.Time) error
+	// FindExpiredCLISubscriptions returns up to limit CLI subscriptions whose
+	// listen session stopped heartbeating.
+	FindExpiredCLISubscriptions(ctx context.Context, limit int) ([]Subscription, error)
 	CountEndpointSubscriptions(context.Context, string, string, string) (int64, error)
 	TestSubscriptionFilter(ctx context.Context, payload, filter interface{}, isFlattened bool) (bool, error)
 	CompareFlattenedPayload(_ context.Context, payload, filter flatten.M, isFlattened bool) (bool, error)
@@ -252,6 +267,7 @@ type FilterRepository interface {
 	FindFiltersBySubscriptionID(ctx context.Context, subscriptionID string) ([]EventTypeFilter, error)
 	FindFilterBySubscriptionAndEventType(ctx context.Context, subscriptionID, eventType string) (*EventTypeFilter, error)
 	TestFilter(ctx context.Context, subscriptionID, eventType string, payload interface{}) (bool, error)
+	ExplainFilter(ctx context.Context, subscriptionID, eventType string, payload interface{}) (*FilterExplanation, error)
 }
 
 type SourceRepository interface {
@@ -328,6 +344,73 @@ type BackupJobRepository interface {
 	FindLatestCompletedBackup(ctx context.Context) (*BackupJob, error)
 }
 
+type CircuitBreakerRepository interface {
+	UpsertCircuitBreakerOverride(ctx context.Context, override *CircuitBreakerOverride) error
+	FindCircuitBreakerOverride(ctx context.Context, projectID, endpointID string) (*CircuitBreakerOverride, error)
+	DeleteCircuitBreakerOverride(ctx context.Context, projectID, endpointID string) error
+	LoadCircuitBreakerOverrides(ctx context.Context) ([]CircuitBreakerOverride, error)
+	CreateCircuitBreakerTransition(ctx context.Context, transition *CircuitBreakerTransition) error
+	LoadCircuitBreakerTransitions(ctx context.Context, projectID, endpointID string, limit int) ([]CircuitBreakerTransition, error)
+	DeleteCircuitBreakerTransitionsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx context.Context) (int64, error)
+}
+
+type EndpointHealthRepository interface {
+	UpsertEndpointHealthCheck(ctx context.Context, check *EndpointHealthCheck) error
+	FindEndpointHealthCheck(ctx context.Context, projectID, endpointID string) (*EndpointHealthCheck, error)
+	// FindEndpointHealthChecks returns the checks configured for any of
+	// endpointIDs; endpoints without a check are left out.
+	FindEndpointHealthChecks(ctx context.Context, projectID string, endpointIDs []string) ([]EndpointHealthCheck, error)
+	DeleteEndpointHealthCheck(ctx context.Context, projectID, endpointID string) error
+	// FetchDueEndpointHealthChecks returns up to limit checks whose next run
+	// is at or before now, oldest first.
+	FetchDueEndpointHealthChecks(ctx context.Context, now time.Time, limit int) ([]EndpointHealthCheck, error)
+	// UpdateEndpointHealthCheckState writes only the running state of a
+	// check, so a concurrent config change is not overwritten.
+	UpdateEndpointHealthCheckState(ctx context.Context, check *EndpointHealthCheck) error
+	CreateEndpointHealthCheckResult(ctx context.Context, result *EndpointHealthCheckResult) error
+	LoadEndpointHealthCheckResults(ctx context.Context, projectID, endpointID string, since time.Time, limit int) ([]EndpointHealthCheckResult, error)
+	DeleteEndpointHealthCheckResultsBefore(ctx context.Context, before time.Time) error
+	// SummarizeEndpointHealth aggregates results in [since, until]; empty
+	// endpointIDs covers the whole project.
+	SummarizeEndpointHealth(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) (*EndpointHealthStats, error)
+	// SummarizeEndpointHealthByEndpoint aggregates results in [since, until]
+	// per endpoint, keyed by endpoint id. Endpoints without results are left
+	// out.
+	SummarizeEndpointHealthByEndpoint(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) (map[string]*EndpointHealthStats, error)
+	CountEndpointHealth(ctx context.Context, projectID string, endpointIDs []string) (*EndpointHealthCounts, error)
+}
+
+type AlertRuleRepository interface {
+	// CreateAlertRule returns ErrDuplicateAlertRuleName when the project
+	// already has a rule with the same name.
+	CreateAlertRule(ctx context.Context, rule *AlertRule) error
+	UpdateAlertRule(ctx context.Context, rule *AlertRule) error
+	FindAlertRuleByID(ctx context.Context, projectID, id string) (*AlertRule, error)
+	// LoadAlertRules returns a project's rules ordered by name.
+	LoadAlertRules(ctx context.Context, projectID string) ([]AlertRule, error)
+	DeleteAlertRule(ctx context.Context, projectID, id string) error
+	// LoadEnabledAlertRules pages through the enabled rules of every project
+	// in id order, starting after cursor.
+	LoadEnabledAlertRules(ctx context.Context, cursor string, limit int) ([]AlertRule, error)
+	// UpdateAlertRuleState writes only the evaluation state of a rule, so a
+	// concurrent config change is not overwritten.
+	UpdateAlertRuleState(ctx context.Context, rule *AlertRule) error
+
+	// The metrics below cover one endpoint, or the whole project when
+	// endpointID is empty.
+	CountDeliveryAttempts(ctx context.Context, projectID, endpointID string, since time.Time) (*DeliveryAttemptCounts, error)
+	// LoadLatestAttemptStatuses returns whether each of the latest limit
+	// delivery attempts succeeded, newest first.
+	LoadLatestAttemptStatuses(ctx context.Context, projectID, endpointID string, limit int) ([]bool, error)
+	// DeliveryLatencyP95 returns the p95 latency, in seconds, of deliveries
+	// created since then, or zero when none completed.
+	DeliveryLatencyP95(ctx context.Context, projectID, endpointID string, since time.Time) (float64, error)
+	CountRetryingDeliveries(ctx context.Context, projectID, endpointID string) (int64, error)
+	// FindLatestSourceEventTime returns nil when the source has no events.
+	FindLatestSourceEventTime(ctx context.Context, projectID, sourceID string) (*time.Time, error)
+}
+
 type EventTypesRepository interface {
 	CreateEventType(context.Context, *ProjectEventType) error
 	UpdateEventType(context.Context, *ProjectEventType) error
@@ -346,8 +429,94 @@ type BatchRetryRepository interface {
 	FindActiveBatchRetry(ctx context.Context, projectID string) (*BatchRetry, error)
 }
 
+type ReplayJobRepository interface {
+	CreateReplayJob(ctx context.Context, job *ReplayJob) error
+	// UpdateReplayJob writes a job's status, progress and cursor. A cancelled
+	// job is left as it is and reported as ErrReplayJobNotFound, so a worker
+	// still running it finds out on its next write.
+	UpdateReplayJob(ctx context.Context, job *ReplayJob) error
+	FindReplayJobByID(ctx context.Context, projectID, id string) (*ReplayJob, error)
+	// LoadReplayJobs returns a project's most recent jobs, newest first.
+	LoadReplayJobs(ctx context.Context, projectID string, limit int) ([]ReplayJob, error)
+	// CancelReplayJob cancels a pending or processing job, returning
+	// ErrReplayJobNotFound if there is none with that id.
+	CancelReplayJob(ctx context.Context, projectID, id string) error
+}
+
+type EventTypeVersionRepository interface {
+	// CreateEventTypeVersion numbers the version after the event type's
+	// latest one.
+	CreateEventTypeVersion(ctx context.Context, version *EventTypeVersion) error
+	UpdateEventTypeVersion(ctx context.Context, version *EventTypeVersion) error
+	FindEventTypeVersion(ctx context.Context, projectID, eventTypeID string, version int) (*EventTypeVersion, error)
+	// LoadEventTypeVersions returns the versions of the named event type,
+	// oldest first.
+	LoadEventTypeVersions(ctx context.Context, projectID, eventType string) ([]EventTypeVersion, error)
+	// LoadDueEventTypeVersionSunsets returns the versions whose sunset has
+	// passed and has not been announced yet.
+	LoadDueEventTypeVersionSunsets(ctx context.Context, now time.Time, limit int) ([]EventTypeVersion, error)
+	MarkEventTypeVersionSunsetNotified(ctx context.Context, projectID, id string) error
+
+	// PinSubscriptionVersion creates the pin or moves an existing one for the
+	// same subscription and event type.
+	PinSubscriptionVersion(ctx context.Context, pin *EventTypeVersionPin) error
+	UnpinSubscriptionVersion(ctx context.Context, projectID, subscriptionID, eventType string) error
+	LoadSubscriptionVersionPins(ctx context.Context, projectID, subscriptionID string) ([]EventTypeVersionPin, error)
+	LoadEventTypeVersionPins(ctx context.Context, projectID, eventType string, subscriptionIDs []string) ([]EventTypeVersionPin, error)
+	// HasEventTypeVersionPins reports whether any of a project's
+	// subscriptions is pinned to a version. The answer may be up to half a
+	// minute stale.
+	HasEventTypeVersionPins(ctx context.Context, projectID string) (bool, error)
+}
+
+type SavedSearchRepository interface {
+	// CreateSavedSearch returns ErrDuplicateSavedSearchName when the project
+	// already has a search with the same name.
+	CreateSavedSearch(ctx context.Context, search *SavedSearch) error
+	UpdateSavedSearch(ctx context.Context, search *SavedSearch) error
+	FindSavedSearchByID(ctx context.Context, projectID, id string) (*SavedSearch, error)
+	// LoadSavedSearches returns a project's searches ordered by name.
+	LoadSavedSearches(ctx context.Context, projectID string) ([]SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, projectID, id string) error
+}
+
+type EmailTemplateRepository interface {
+	// UpsertEmailTemplate creates the organisation's override of the template
+	// or replaces the existing one.
+	UpsertEmailTemplate(ctx context.Context, template *EmailTemplate) error
+	FindEmailTemplate(ctx context.Context, orgID, templateName string) (*EmailTemplate, error)
+	// LoadEmailTemplates returns an organisation's overrides ordered by
+	// template name.
+	LoadEmailTemplates(ctx context.Context, orgID string) ([]EmailTemplate, error)
+	DeleteEmailTemplate(ctx context.Context, orgID, templateName string) error
+
+	UpsertEmailBranding(ctx context.Context, branding *EmailBranding) error
+	FindEmailBranding(ctx context.Context, orgID string) (*EmailBranding, error)
+}
+
+type ExportJobRepository interface {
+	CreateExportJob(ctx context.Context, job *ExportJob) error
+	UpdateExportJob(ctx context.Context, job *ExportJob) error
+	FindExportJobByID(ctx context.Context, projectID, id string) (*ExportJob, error)
+	// LoadExportJobs returns a project's most recent jobs, newest first.
+	LoadExportJobs(ctx context.Context, projectID string, limit int) ([]ExportJob, error)
+}
+
+type FilterRejectionRepository interface {
+	// CreateFilterRejections records rejections, skipping any already
+	// recorded for the same event and subscription.
+	CreateFilterRejections(ctx context.Context, rejections []FilterRejection) error
+	// LoadFilterRejectionsByEventID returns the rejections recorded for an
+	// event, oldest first.
+	LoadFilterRejectionsByEventID(ctx context.Context, projectID, eventID string) ([]FilterRejection, error)
+	// DeleteFilterRejectionsBefore deletes up to limit rejections recorded
+	// before the given time and returns how many it deleted.
+	DeleteFilterRejectionsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
 // Filter errors
 var (
-	ErrFilterNotFound  = errors.New("filter not found")
-	ErrDuplicateFilter = errors.New("duplicate filter")
+	ErrFilterNotFound               = errors.New("filter not found")
+	ErrDuplicateFilter              = errors.New("duplicate filter")
+	ErrFilterExpressionWithCriteria = errors.New("a filter expression cannot be combined with headers, body, query or path filters")
 )
@@ -0,0 +1,133 @@
+package datastore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrSavedSearchNotFound      = errors.New("saved search not found")
+	ErrDuplicateSavedSearchName = errors.New("a saved search with this name already exists")
+)
+
+// SearchResource is what a saved search or export lists.
+type SearchResource string
+
+const (
+	SearchResourceEvents          SearchResource = "events"
+	SearchResourceEventDeliveries SearchResource = "event_deliveries"
+)
+
+func (r SearchResource) IsValid() bool {
+	switch r {
+	case SearchResourceEvents, SearchResourceEventDeliveries:
+		return true
+	default:
+		return false
+	}
+}
+
+// SavedSearch is a named events or deliveries search kept on a project, so
+// the same question can be asked again, or exported, without rebuilding the
+// filter each time.
+type SavedSearch struct {
+	UID       string         `json:"uid" db:"id"`
+	ProjectID string         `json:"project_id" db:"project_id"`
+	Name      string         `json:"name" db:"name"`
+	Resource  SearchResource `json:"resource" db:"resource"`
+	Filter    SearchFilter   `json:"filter" db:"filter"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at" swaggertype:"string"`
+	UpdatedAt time.Time      `json:"updated_at" db:"updated_at" swaggertype:"string"`
+}
+
+// SearchFilter is the stored form of an events or deliveries list filter.
+// Dates are optional on a saved search; an export supplies its own window
+// when the search has none.
+type SearchFilter struct {
+	StartDate      time.Time             `json:"start_date"`
+	EndDate        time.Time             `json:"end_date"`
+	EndpointIDs    []string              `json:"endpoint_ids,omitempty"`
+	SourceIDs      []string              `json:"source_ids,omitempty"`
+	EventType      string                `json:"event_type,omitempty"`
+	Status         []EventDeliveryStatus `json:"status,omitempty"`
+	IdempotencyKey string                `json:"idempotency_key,omitempty"`
+
+	// Query and Body are the events list search: text matched against event
+	// ids, idempotency keys, event types and source names, and a JSON object
+	// the payload must contain.
+	Query string          `json:"query,omitempty"`
+	Body  json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+}
+
+// HasSearch reports whether the filter searches event metadata or payloads.
+func (f SearchFilter) HasSearch() bool {
+	return f.Query != "" || len(f.Body) > 0
+}
+
+// MatchesEvent reports whether event passes the parts of the filter the
+// events list query does not apply itself.
+func (f SearchFilter) MatchesEvent(event *Event) bool {
+	return f.EventType == "" || string(event.EventType) == f.EventType
+}
+
+// MatchesDelivery reports whether delivery passes the delivery filters. It
+// is used when deliveries are found through their events rather than the
+// deliveries list query, so the event filters have already been applied.
+func (f SearchFilter) MatchesDelivery(delivery *EventDelivery) bool {
+	if len(f.EndpointIDs) > 0 && !slices.Contains(f.EndpointIDs, delivery.EndpointID) {
+		return false
+	}
+	if len(f.Status) > 0 && !slices.Contains(f.Status, delivery.Status) {
+		return false
+	}
+	if f.EventType != "" && string(delivery.EventType) != f.EventType {
+		return false
+	}
+	return true
+}
+
+// EventFilter is the events list filter for the page of events that starts
+// at cursor, oldest first.
+func (f SearchFilter) EventFilter(project *Project, cursor string, perPage int) *Filter {
+	filter := &Filter{
+		Project:        project,
+		ProjectID:      project.UID,
+		Query:          f.Query,
+		Body:           f.Body,
+		EndpointIDs:    f.EndpointIDs,
+		SourceIDs:      f.SourceIDs,
+		IdempotencyKey: f.IdempotencyKey,
+		SearchParams:   f.SearchParams(),
+		Pageable: Pageable{
+			PerPage:    perPage,
+			Direction:  Next,
+			Sort:       "ASC",
+			NextCursor: cursor,
+		},
+	}
+
+	if len(f.SourceIDs) == 1 {
+		filter.SourceID = f.SourceIDs[0]
+	}
+
+	return filter
+}
+
+func (f SearchFilter) SearchParams() SearchParams {
+	return SearchParams{
+		CreatedAtStart: f.StartDate.Unix(),
+		CreatedAtEnd:   f.EndDate.Unix(),
+	}
+}
+
+func (f *SearchFilter) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	return json.Unmarshal(bytes, f)
+}
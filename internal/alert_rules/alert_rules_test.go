@@ -0,0 +1,150 @@
+package alert_rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func newAlertRule(projectID, name string) *datastore.AlertRule {
+	return &datastore.AlertRule{
+		UID:             ulid.Make().String(),
+		ProjectID:       projectID,
+		Name:            name,
+		Type:            datastore.FailureRateAlertRule,
+		Threshold:       25,
+		WindowSeconds:   300,
+		CooldownSeconds: 1800,
+		Channels:        datastore.AlertRuleChannels{Email: "oncall@example.com"},
+		Enabled:         true,
+	}
+}
+
+func TestAlertRule_RoundTrip(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	rule := newAlertRule(project.UID, "checkout failures")
+	require.NoError(t, service.CreateAlertRule(ctx, rule))
+
+	fetched, err := service.FindAlertRuleByID(ctx, project.UID, rule.UID)
+	require.NoError(t, err)
+	require.Equal(t, "checkout failures", fetched.Name)
+	require.Equal(t, datastore.FailureRateAlertRule, fetched.Type)
+	require.Equal(t, 25.0, fetched.Threshold)
+	require.Equal(t, "oncall@example.com", fetched.Channels.Email)
+	require.Equal(t, datastore.AlertRuleOK, fetched.State)
+	require.Nil(t, fetched.LastEvaluatedAt)
+
+	fetched.Type = datastore.RetryBacklogAlertRule
+	fetched.Threshold = 100
+	fetched.Channels = datastore.AlertRuleChannels{SlackWebhookURL: "https://hooks.example.com/services/T/B/X"}
+	require.NoError(t, service.UpdateAlertRule(ctx, fetched))
+
+	fetched, err = service.FindAlertRuleByID(ctx, project.UID, rule.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.RetryBacklogAlertRule, fetched.Type)
+	require.Empty(t, fetched.Channels.Email)
+	require.Equal(t, "https://hooks.example.com/services/T/B/X", fetched.Channels.SlackWebhookURL)
+
+	require.NoError(t, service.DeleteAlertRule(ctx, project.UID, rule.UID))
+
+	_, err = service.FindAlertRuleByID(ctx, project.UID, rule.UID)
+	require.ErrorIs(t, err, datastore.ErrAlertRuleNotFound)
+	require.ErrorIs(t, service.DeleteAlertRule(ctx, project.UID, rule.UID), datastore.ErrAlertRuleNotFound)
+}
+
+func TestAlertRule_DuplicateName(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	require.NoError(t, service.CreateAlertRule(ctx, newAlertRule(project.UID, "backlog")))
+
+	err := service.CreateAlertRule(ctx, newAlertRule(project.UID, "backlog"))
+	require.ErrorIs(t, err, datastore.ErrDuplicateAlertRuleName)
+}
+
+func TestUpdateAlertRuleState(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	rule := newAlertRule(project.UID, "checkout failures")
+	require.NoError(t, service.CreateAlertRule(ctx, rule))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	rule.State = datastore.AlertRuleFiring
+	rule.LastValue = 40
+	rule.LastEvaluatedAt = &now
+	rule.LastTriggeredAt = &now
+	rule.LastNotifiedAt = &now
+	// State writes leave the configuration alone.
+	rule.Name = "renamed"
+	require.NoError(t, service.UpdateAlertRuleState(ctx, rule))
+
+	fetched, err := service.FindAlertRuleByID(ctx, project.UID, rule.UID)
+	require.NoError(t, err)
+	require.Equal(t, "checkout failures", fetched.Name)
+	require.Equal(t, datastore.AlertRuleFiring, fetched.State)
+	require.Equal(t, 40.0, fetched.LastValue)
+	require.WithinDuration(t, now, *fetched.LastNotifiedAt, time.Millisecond)
+	require.Nil(t, fetched.LastResolvedAt)
+}
+
+func TestLoadEnabledAlertRules(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, service.CreateAlertRule(ctx, newAlertRule(project.UID, name)))
+	}
+	disabled := newAlertRule(project.UID, "d")
+	disabled.Enabled = false
+	require.NoError(t, service.CreateAlertRule(ctx, disabled))
+
+	page, err := service.LoadEnabledAlertRules(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+
+	rest, err := service.LoadEnabledAlertRules(ctx, page[1].UID, 2)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.NotEqual(t, "d", rest[0].Name)
+}
+
+func TestAlertRuleMetrics_NoTraffic(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+	since := time.Now().Add(-time.Hour)
+
+	// "" reads the whole project, anything else one endpoint.
+	for _, endpointID := range []string{"", ulid.Make().String()} {
+		counts, err := service.CountDeliveryAttempts(ctx, project.UID, endpointID, since)
+		require.NoError(t, err)
+		require.Equal(t, &datastore.DeliveryAttemptCounts{}, counts)
+
+		statuses, err := service.LoadLatestAttemptStatuses(ctx, project.UID, endpointID, 3)
+		require.NoError(t, err)
+		require.Empty(t, statuses)
+
+		p95, err := service.DeliveryLatencyP95(ctx, project.UID, endpointID, since)
+		require.NoError(t, err)
+		require.Zero(t, p95)
+
+		retrying, err := service.CountRetryingDeliveries(ctx, project.UID, endpointID)
+		require.NoError(t, err)
+		require.Zero(t, retrying)
+	}
+
+	latest, err := service.FindLatestSourceEventTime(ctx, project.UID, ulid.Make().String())
+	require.NoError(t, err)
+	require.Nil(t, latest)
+}
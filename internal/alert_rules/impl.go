@@ -0,0 +1,327 @@
+package alert_rules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/alert_rules/repo"
+	"github.com/frain-dev/convoy/internal/common"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// Service implements the AlertRuleRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.AlertRuleRepository at compile time
+var _ datastore.AlertRuleRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) CreateAlertRule(ctx context.Context, rule *datastore.AlertRule) error {
+	if rule == nil {
+		return errors.New("alert rule cannot be nil")
+	}
+
+	channels, err := json.Marshal(rule.Channels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert rule channels: %w", err)
+	}
+
+	err = s.repo.CreateAlertRule(ctx, repo.CreateAlertRuleParams{
+		ID:              rule.UID,
+		ProjectID:       rule.ProjectID,
+		Name:            rule.Name,
+		Type:            string(rule.Type),
+		EndpointID:      rule.EndpointID,
+		SourceID:        rule.SourceID,
+		Threshold:       rule.Threshold,
+		WindowSeconds:   int32(rule.WindowSeconds),
+		CooldownSeconds: int32(rule.CooldownSeconds),
+		Channels:        channels,
+		Enabled:         rule.Enabled,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateAlertRuleName
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) UpdateAlertRule(ctx context.Context, rule *datastore.AlertRule) error {
+	if rule == nil {
+		return errors.New("alert rule cannot be nil")
+	}
+
+	channels, err := json.Marshal(rule.Channels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert rule channels: %w", err)
+	}
+
+	result, err := s.repo.UpdateAlertRule(ctx, repo.UpdateAlertRuleParams{
+		Name:            rule.Name,
+		Type:            string(rule.Type),
+		EndpointID:      rule.EndpointID,
+		SourceID:        rule.SourceID,
+		Threshold:       rule.Threshold,
+		WindowSeconds:   int32(rule.WindowSeconds),
+		CooldownSeconds: int32(rule.CooldownSeconds),
+		Channels:        channels,
+		Enabled:         rule.Enabled,
+		ID:              rule.UID,
+		ProjectID:       rule.ProjectID,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateAlertRuleName
+		}
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrAlertRuleNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) FindAlertRuleByID(ctx context.Context, projectID, id string) (*datastore.AlertRule, error) {
+	row, err := s.repo.FindAlertRuleByID(ctx, repo.FindAlertRuleByIDParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrAlertRuleNotFound
+		}
+		return nil, err
+	}
+
+	return rowToAlertRule(repo.LoadAlertRulesRow(row))
+}
+
+func (s *Service) LoadAlertRules(ctx context.Context, projectID string) ([]datastore.AlertRule, error) {
+	rows, err := s.repo.LoadAlertRules(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowsToAlertRules(rows)
+}
+
+func (s *Service) DeleteAlertRule(ctx context.Context, projectID, id string) error {
+	result, err := s.repo.DeleteAlertRule(ctx, repo.DeleteAlertRuleParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrAlertRuleNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) LoadEnabledAlertRules(ctx context.Context, cursor string, limit int) ([]datastore.AlertRule, error) {
+	rows, err := s.repo.LoadEnabledAlertRules(ctx, repo.LoadEnabledAlertRulesParams{
+		Cursor:   cursor,
+		LimitVal: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make([]repo.LoadAlertRulesRow, 0, len(rows))
+	for _, row := range rows {
+		loaded = append(loaded, repo.LoadAlertRulesRow(row))
+	}
+
+	return rowsToAlertRules(loaded)
+}
+
+func (s *Service) UpdateAlertRuleState(ctx context.Context, rule *datastore.AlertRule) error {
+	return s.repo.UpdateAlertRuleState(ctx, repo.UpdateAlertRuleStateParams{
+		State:           rule.State,
+		LastValue:       rule.LastValue,
+		LastEvaluatedAt: timePtrToPgTimestamptz(rule.LastEvaluatedAt),
+		LastNotifiedAt:  timePtrToPgTimestamptz(rule.LastNotifiedAt),
+		LastTriggeredAt: timePtrToPgTimestamptz(rule.LastTriggeredAt),
+		LastResolvedAt:  timePtrToPgTimestamptz(rule.LastResolvedAt),
+		ID:              rule.UID,
+		ProjectID:       rule.ProjectID,
+	})
+}
+
+func (s *Service) CountDeliveryAttempts(ctx context.Context, projectID, endpointID string, since time.Time) (*datastore.DeliveryAttemptCounts, error) {
+	if endpointID == "" {
+		row, err := s.repo.CountDeliveryAttempts(ctx, repo.CountDeliveryAttemptsParams{
+			ProjectID: projectID,
+			Since:     common.TimeToPgTimestamptz(since),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &datastore.DeliveryAttemptCounts{Attempts: row.Attempts, Failures: row.Failures}, nil
+	}
+
+	row, err := s.repo.CountEndpointDeliveryAttempts(ctx, repo.CountEndpointDeliveryAttemptsParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+		Since:      common.TimeToPgTimestamptz(since),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.DeliveryAttemptCounts{Attempts: row.Attempts, Failures: row.Failures}, nil
+}
+
+func (s *Service) LoadLatestAttemptStatuses(ctx context.Context, projectID, endpointID string, limit int) ([]bool, error) {
+	var rows []pgtype.Bool
+	var err error
+	if endpointID == "" {
+		rows, err = s.repo.LoadLatestAttemptStatuses(ctx, repo.LoadLatestAttemptStatusesParams{
+			ProjectID: projectID,
+			LimitVal:  int32(limit),
+		})
+	} else {
+		rows, err = s.repo.LoadLatestEndpointAttemptStatuses(ctx, repo.LoadLatestEndpointAttemptStatusesParams{
+			ProjectID:  projectID,
+			EndpointID: endpointID,
+			LimitVal:   int32(limit),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]bool, 0, len(rows))
+	for _, status := range rows {
+		// An attempt with no recorded status never got a response.
+		statuses = append(statuses, status.Valid && status.Bool)
+	}
+
+	return statuses, nil
+}
+
+func (s *Service) DeliveryLatencyP95(ctx context.Context, projectID, endpointID string, since time.Time) (float64, error) {
+	if endpointID == "" {
+		return s.repo.DeliveryLatencyP95(ctx, repo.DeliveryLatencyP95Params{
+			ProjectID: projectID,
+			Since:     common.TimeToPgTimestamptz(since),
+		})
+	}
+
+	return s.repo.EndpointDeliveryLatencyP95(ctx, repo.EndpointDeliveryLatencyP95Params{
+		ProjectID:  projectID,
+		EndpointID: common.StringToPgText(endpointID),
+		Since:      common.TimeToPgTimestamptz(since),
+	})
+}
+
+func (s *Service) CountRetryingDeliveries(ctx context.Context, projectID, endpointID string) (int64, error) {
+	if endpointID == "" {
+		return s.repo.CountRetryingDeliveries(ctx, projectID)
+	}
+
+	return s.repo.CountEndpointRetryingDeliveries(ctx, repo.CountEndpointRetryingDeliveriesParams{
+		ProjectID:  projectID,
+		EndpointID: common.StringToPgText(endpointID),
+	})
+}
+
+func (s *Service) FindLatestSourceEventTime(ctx context.Context, projectID, sourceID string) (*time.Time, error) {
+	createdAt, err := s.repo.FindLatestSourceEventTime(ctx, repo.FindLatestSourceEventTimeParams{
+		ProjectID: projectID,
+		SourceID:  common.StringToPgText(sourceID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	t := createdAt.Time
+	return &t, nil
+}
+
+func rowsToAlertRules(rows []repo.LoadAlertRulesRow) ([]datastore.AlertRule, error) {
+	rules := make([]datastore.AlertRule, 0, len(rows))
+	for _, row := range rows {
+		rule, err := rowToAlertRule(row)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func rowToAlertRule(row repo.LoadAlertRulesRow) (*datastore.AlertRule, error) {
+	var channels datastore.AlertRuleChannels
+	if err := json.Unmarshal(row.Channels, &channels); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rule channels: %w", err)
+	}
+
+	return &datastore.AlertRule{
+		UID:             row.ID,
+		ProjectID:       row.ProjectID,
+		Name:            row.Name,
+		Type:            datastore.AlertRuleType(row.Type),
+		EndpointID:      row.EndpointID,
+		SourceID:        row.SourceID,
+		Threshold:       row.Threshold,
+		WindowSeconds:   uint64(row.WindowSeconds),
+		CooldownSeconds: uint64(row.CooldownSeconds),
+		Channels:        channels,
+		Enabled:         row.Enabled,
+		State:           row.State,
+		LastValue:       row.LastValue,
+		LastEvaluatedAt: pgTimestamptzToTimePtr(row.LastEvaluatedAt),
+		LastNotifiedAt:  pgTimestamptzToTimePtr(row.LastNotifiedAt),
+		LastTriggeredAt: pgTimestamptzToTimePtr(row.LastTriggeredAt),
+		LastResolvedAt:  pgTimestamptzToTimePtr(row.LastResolvedAt),
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}, nil
+}
+
+func timePtrToPgTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return common.TimeToPgTimestamptz(*t)
+}
+
+func pgTimestamptzToTimePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
@@ -0,0 +1,144 @@
+-- Alert Rule Repository SQLc Queries
+-- This file contains all SQL queries for alert rules and the metrics they watch
+
+-- name: CreateAlertRule :exec
+INSERT INTO convoy.alert_rules (
+    id, project_id, name, type, endpoint_id, source_id, threshold,
+    window_seconds, cooldown_seconds, channels, enabled, created_at, updated_at
+) VALUES (
+    @id, @project_id, @name, @type, @endpoint_id, @source_id, @threshold,
+    @window_seconds, @cooldown_seconds, @channels, @enabled, NOW(), NOW()
+);
+
+-- name: UpdateAlertRule :execresult
+UPDATE convoy.alert_rules SET
+    name = @name,
+    type = @type,
+    endpoint_id = @endpoint_id,
+    source_id = @source_id,
+    threshold = @threshold,
+    window_seconds = @window_seconds,
+    cooldown_seconds = @cooldown_seconds,
+    channels = @channels,
+    enabled = @enabled,
+    updated_at = NOW()
+WHERE id = @id AND project_id = @project_id;
+
+-- name: FindAlertRuleByID :one
+SELECT id, project_id, name, type, endpoint_id, source_id, threshold,
+       window_seconds, cooldown_seconds, channels, enabled, state, last_value,
+       last_evaluated_at, last_notified_at, last_triggered_at, last_resolved_at,
+       created_at, updated_at
+FROM convoy.alert_rules
+WHERE id = @id AND project_id = @project_id;
+
+-- name: LoadAlertRules :many
+SELECT id, project_id, name, type, endpoint_id, source_id, threshold,
+       window_seconds, cooldown_seconds, channels, enabled, state, last_value,
+       last_evaluated_at, last_notified_at, last_triggered_at, last_resolved_at,
+       created_at, updated_at
+FROM convoy.alert_rules
+WHERE project_id = @project_id
+ORDER BY name ASC;
+
+-- name: DeleteAlertRule :execresult
+DELETE FROM convoy.alert_rules
+WHERE id = @id AND project_id = @project_id;
+
+-- name: LoadEnabledAlertRules :many
+SELECT id, project_id, name, type, endpoint_id, source_id, threshold,
+       window_seconds, cooldown_seconds, channels, enabled, state, last_value,
+       last_evaluated_at, last_notified_at, last_triggered_at, last_resolved_at,
+       created_at, updated_at
+FROM convoy.alert_rules
+WHERE enabled AND id > @cursor
+ORDER BY id ASC
+LIMIT @limit_val;
+
+-- name: UpdateAlertRuleState :exec
+UPDATE convoy.alert_rules SET
+    state = @state,
+    last_value = @last_value,
+    last_evaluated_at = @last_evaluated_at,
+    last_notified_at = @last_notified_at,
+    last_triggered_at = @last_triggered_at,
+    last_resolved_at = @last_resolved_at
+WHERE id = @id AND project_id = @project_id;
+
+-- The project and endpoint variants of each metric are separate queries so
+-- the planner picks the index for the scope instead of one plan for both.
+
+-- name: CountDeliveryAttempts :one
+SELECT
+    COUNT(*) AS attempts,
+    COUNT(*) FILTER (WHERE status IS NOT TRUE) AS failures
+FROM convoy.delivery_attempts
+WHERE project_id = @project_id
+  AND created_at >= @since
+  AND deleted_at IS NULL;
+
+-- name: CountEndpointDeliveryAttempts :one
+SELECT
+    COUNT(*) AS attempts,
+    COUNT(*) FILTER (WHERE status IS NOT TRUE) AS failures
+FROM convoy.delivery_attempts
+WHERE project_id = @project_id
+  AND endpoint_id = @endpoint_id
+  AND created_at >= @since
+  AND deleted_at IS NULL;
+
+-- name: LoadLatestAttemptStatuses :many
+SELECT status
+FROM convoy.delivery_attempts
+WHERE project_id = @project_id
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT @limit_val;
+
+-- name: LoadLatestEndpointAttemptStatuses :many
+SELECT status
+FROM convoy.delivery_attempts
+WHERE project_id = @project_id
+  AND endpoint_id = @endpoint_id
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT @limit_val;
+
+-- name: DeliveryLatencyP95 :one
+SELECT COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_seconds), 0)::float8 AS latency_p95
+FROM convoy.event_deliveries
+WHERE project_id = @project_id
+  AND created_at >= @since
+  AND latency_seconds IS NOT NULL
+  AND deleted_at IS NULL;
+
+-- name: EndpointDeliveryLatencyP95 :one
+SELECT COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_seconds), 0)::float8 AS latency_p95
+FROM convoy.event_deliveries
+WHERE project_id = @project_id
+  AND endpoint_id = @endpoint_id
+  AND created_at >= @since
+  AND latency_seconds IS NOT NULL
+  AND deleted_at IS NULL;
+
+-- name: CountRetryingDeliveries :one
+SELECT COUNT(*) AS retrying
+FROM convoy.event_deliveries
+WHERE project_id = @project_id
+  AND status = 'Retry'
+  AND deleted_at IS NULL;
+
+-- name: CountEndpointRetryingDeliveries :one
+SELECT COUNT(*) AS retrying
+FROM convoy.event_deliveries
+WHERE project_id = @project_id
+  AND endpoint_id = @endpoint_id
+  AND status = 'Retry'
+  AND deleted_at IS NULL;
+
+-- name: FindLatestSourceEventTime :one
+SELECT created_at
+FROM convoy.events
+WHERE project_id = @project_id AND source_id = @source_id AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
@@ -0,0 +1,90 @@
+package alert_rules
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/organisations"
+	"github.com/frain-dev/convoy/internal/projects"
+	"github.com/frain-dev/convoy/internal/users"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/testenv"
+)
+
+var testEnv *testenv.Environment
+
+func TestMain(m *testing.M) {
+	res, cleanup, err := testenv.Launch(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	testEnv = res
+
+	code := m.Run()
+
+	if err := cleanup(); err != nil {
+		fmt.Printf("failed to cleanup: %v\n", err)
+	}
+
+	os.Exit(code)
+}
+
+func setupTestDB(t *testing.T) (database.Database, context.Context) {
+	t.Helper()
+
+	err := config.LoadConfig("")
+	require.NoError(t, err)
+
+	conn, err := testEnv.CloneTestDatabase(t, "convoy")
+	require.NoError(t, err)
+
+	return postgres.NewFromConnection(conn), context.Background()
+}
+
+func createService(t *testing.T, db database.Database) *Service {
+	t.Helper()
+	return New(log.New("convoy", log.LevelInfo), db)
+}
+
+func seedProject(t *testing.T, db database.Database) *datastore.Project {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.New("convoy", log.LevelInfo)
+
+	user := &datastore.User{
+		UID:       ulid.Make().String(),
+		FirstName: "Test",
+		LastName:  "User",
+		Email:     fmt.Sprintf("test-%s@example.com", ulid.Make().String()),
+	}
+	require.NoError(t, users.New(logger, db).CreateUser(ctx, user))
+
+	org := &datastore.Organisation{
+		UID:     ulid.Make().String(),
+		Name:    "Test Org",
+		OwnerID: user.UID,
+	}
+	require.NoError(t, organisations.New(logger, db).CreateOrganisation(ctx, org))
+
+	projectConfig := datastore.DefaultProjectConfig
+	project := &datastore.Project{
+		UID:            ulid.Make().String(),
+		Name:           "Test Project",
+		Type:           datastore.OutgoingProject,
+		OrganisationID: org.UID,
+		Config:         &projectConfig,
+	}
+	require.NoError(t, projects.New(logger, db).CreateProject(ctx, project))
+
+	return project
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/backup_jobs/repo"
 	"github.com/frain-dev/convoy/internal/common"
@@ -22,6 +23,7 @@ type Service struct {
 	logger log.Logger
 	repo   repo.Querier
 	db     *pgxpool.Pool
+	hook   *hooks.Hook
 }
 
 func New(logger log.Logger, db database.Database) *Service {
@@ -29,6 +31,7 @@ func New(logger log.Logger, db database.Database) *Service {
 		logger: logger,
 		repo:   repo.New(db.GetConn()),
 		db:     db.GetConn(),
+		hook:   db.GetHook(),
 	}
 }
 
@@ -57,10 +60,16 @@ func (s *Service) CompleteBackupJob(ctx context.Context, jobID string, recordCou
 		return fmt.Errorf("marshal record counts: %w", err)
 	}
 
-	return s.repo.CompleteBackupJob(ctx, repo.CompleteBackupJobParams{
+	row, err := s.repo.CompleteBackupJob(ctx, repo.CompleteBackupJobParams{
 		ID:           common.StringToPgText(jobID),
 		RecordCounts: countsJSON,
 	})
+	if err != nil {
+		return err
+	}
+
+	go s.hook.Fire(context.Background(), datastore.BackupJobCompleted, rowToBackupJob(repo.ClaimBackupJobRow(row)), nil)
+	return nil
 }
 
 func (s *Service) FailBackupJob(ctx context.Context, jobID, errMsg string) error {
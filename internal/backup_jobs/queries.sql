@@ -14,10 +14,11 @@ WHERE id = (
 )
 RETURNING id, hour_start, hour_end, status, agent_id, claimed_at, completed_at, error, record_counts, created_at, updated_at;
 
--- name: CompleteBackupJob :exec
+-- name: CompleteBackupJob :one
 UPDATE convoy.backup_jobs
 SET status = 'completed', completed_at = NOW(), record_counts = @record_counts
-WHERE id = @id;
+WHERE id = @id
+RETURNING id, hour_start, hour_end, status, agent_id, claimed_at, completed_at, error, record_counts, created_at, updated_at;
 
 -- name: FailBackupJob :exec
 UPDATE convoy.backup_jobs
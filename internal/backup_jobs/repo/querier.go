@@ -13,7 +13,7 @@ import (
 
 type Querier interface {
 	ClaimBackupJob(ctx context.Context, agentID pgtype.Text) (ClaimBackupJobRow, error)
-	CompleteBackupJob(ctx context.Context, arg CompleteBackupJobParams) error
+	CompleteBackupJob(ctx context.Context, arg CompleteBackupJobParams) (CompleteBackupJobRow, error)
 	EnqueueBackupJob(ctx context.Context, arg EnqueueBackupJobParams) error
 	FailBackupJob(ctx context.Context, arg FailBackupJobParams) error
 	FindLatestCompletedBackup(ctx context.Context) (FindLatestCompletedBackupRow, error)
@@ -58,10 +58,11 @@ func (q *Queries) ClaimBackupJob(ctx context.Context, agentID pgtype.Text) (Clai
 	return i, err
 }
 
-const completeBackupJob = `-- name: CompleteBackupJob :exec
+const completeBackupJob = `-- name: CompleteBackupJob :one
 UPDATE convoy.backup_jobs
 SET status = 'completed', completed_at = NOW(), record_counts = $1
 WHERE id = $2
+RETURNING id, hour_start, hour_end, status, agent_id, claimed_at, completed_at, error, record_counts, created_at, updated_at
 `
 
 type CompleteBackupJobParams struct {
@@ -69,9 +70,37 @@ type CompleteBackupJobParams struct {
 	ID           pgtype.Text
 }
 
-func (q *Queries) CompleteBackupJob(ctx context.Context, arg CompleteBackupJobParams) error {
-	_, err := q.db.Exec(ctx, completeBackupJob, arg.RecordCounts, arg.ID)
-	return err
+type CompleteBackupJobRow struct {
+	ID           string
+	HourStart    pgtype.Timestamptz
+	HourEnd      pgtype.Timestamptz
+	Status       string
+	AgentID      pgtype.Text
+	ClaimedAt    pgtype.Timestamptz
+	CompletedAt  pgtype.Timestamptz
+	Error        pgtype.Text
+	RecordCounts []byte
+	CreatedAt    pgtype.Timestamptz
+	UpdatedAt    pgtype.Timestamptz
+}
+
+func (q *Queries) CompleteBackupJob(ctx context.Context, arg CompleteBackupJobParams) (CompleteBackupJobRow, error) {
+	row := q.db.QueryRow(ctx, completeBackupJob, arg.RecordCounts, arg.ID)
+	var i CompleteBackupJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.HourStart,
+		&i.HourEnd,
+		&i.Status,
+		&i.AgentID,
+		&i.ClaimedAt,
+		&i.CompletedAt,
+		&i.Error,
+		&i.RecordCounts,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
 }
 
 const enqueueBackupJob = `-- name: EnqueueBackupJob :exec
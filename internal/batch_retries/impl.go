@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/batch_retries/repo"
 	"github.com/frain-dev/convoy/internal/common"
@@ -23,6 +24,7 @@ type Service struct {
 	logger log.Logger
 	repo   repo.Querier  // SQLc-generated interface
 	db     *pgxpool.Pool // Connection pool
+	hook   *hooks.Hook
 }
 
 // Ensure Service implements datastore.BatchRetryRepository at compile time
@@ -34,6 +36,7 @@ func New(logger log.Logger, db database.Database) *Service {
 		logger: logger,
 		repo:   repo.New(db.GetConn()),
 		db:     db.GetConn(),
+		hook:   db.GetHook(),
 	}
 }
 
@@ -165,6 +168,13 @@ func (s *Service) UpdateBatchRetry(ctx context.Context, batchRetry *datastore.Ba
 		return fmt.Errorf("no rows affected")
 	}
 
+	switch batchRetry.Status {
+	case datastore.BatchRetryStatusCompleted:
+		go s.hook.Fire(context.Background(), datastore.BatchRetryCompleted, batchRetry, nil)
+	case datastore.BatchRetryStatusFailed:
+		go s.hook.Fire(context.Background(), datastore.BatchRetryFailed, batchRetry, nil)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,123 @@
+package circuit_breakers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/endpoints"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestCircuitBreakerOverride_RoundTrip(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+
+	endpoint := seedEndpoint(t, db)
+	projectID, endpointID := endpoint.ProjectID, endpoint.UID
+
+	_, err := service.FindCircuitBreakerOverride(ctx, projectID, endpointID)
+	require.ErrorIs(t, err, datastore.ErrCircuitBreakerOverrideNotFound)
+
+	override := &datastore.CircuitBreakerOverride{
+		ProjectID:   projectID,
+		EndpointID:  endpointID,
+		ForcedState: "open",
+		Reason:      "upstream outage",
+	}
+	require.NoError(t, service.UpsertCircuitBreakerOverride(ctx, override))
+
+	override.Config = &datastore.EndpointCircuitBreakerConfiguration{FailureThreshold: 90}
+	require.NoError(t, service.UpsertCircuitBreakerOverride(ctx, override))
+
+	fetched, err := service.FindCircuitBreakerOverride(ctx, projectID, endpointID)
+	require.NoError(t, err)
+	require.Equal(t, "open", fetched.ForcedState)
+	require.Equal(t, "upstream outage", fetched.Reason)
+	require.Equal(t, uint64(90), fetched.Config.FailureThreshold)
+
+	all, err := service.LoadCircuitBreakerOverrides(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+
+	// scoped to the project
+	_, err = service.FindCircuitBreakerOverride(ctx, ulid.Make().String(), endpointID)
+	require.ErrorIs(t, err, datastore.ErrCircuitBreakerOverrideNotFound)
+
+	require.NoError(t, service.DeleteCircuitBreakerOverride(ctx, projectID, endpointID))
+	_, err = service.FindCircuitBreakerOverride(ctx, projectID, endpointID)
+	require.ErrorIs(t, err, datastore.ErrCircuitBreakerOverrideNotFound)
+}
+
+func TestCircuitBreakerTransitions_NewestFirst(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+
+	endpoint := seedEndpoint(t, db)
+	projectID, endpointID := endpoint.ProjectID, endpoint.UID
+
+	states := [][2]string{{"closed", "open"}, {"open", "half-open"}, {"half-open", "closed"}}
+	for _, s := range states {
+		require.NoError(t, service.CreateCircuitBreakerTransition(ctx, &datastore.CircuitBreakerTransition{
+			ProjectID:  projectID,
+			EndpointID: endpointID,
+			FromState:  s[0],
+			ToState:    s[1],
+			Source:     datastore.CircuitBreakerTransitionSourceSampler,
+		}))
+	}
+
+	transitions, err := service.LoadCircuitBreakerTransitions(ctx, projectID, endpointID, 2)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	require.Equal(t, "closed", transitions[0].ToState)
+	require.Equal(t, "half-open", transitions[1].ToState)
+}
+
+func TestCircuitBreakerRetention(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+
+	endpoint := seedEndpoint(t, db)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, service.CreateCircuitBreakerTransition(ctx, &datastore.CircuitBreakerTransition{
+			ProjectID:  endpoint.ProjectID,
+			EndpointID: endpoint.UID,
+			FromState:  "closed",
+			ToState:    "open",
+			Source:     datastore.CircuitBreakerTransitionSourceSampler,
+		}))
+	}
+
+	n, err := service.DeleteCircuitBreakerTransitionsBefore(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	require.Zero(t, n, "recent transitions are kept")
+
+	n, err = service.DeleteCircuitBreakerTransitionsBefore(ctx, time.Now().Add(time.Hour), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n, "one batch at most")
+
+	n, err = service.DeleteCircuitBreakerTransitionsBefore(ctx, time.Now().Add(time.Hour), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	require.NoError(t, service.UpsertCircuitBreakerOverride(ctx, &datastore.CircuitBreakerOverride{
+		ProjectID:   endpoint.ProjectID,
+		EndpointID:  endpoint.UID,
+		ForcedState: "open",
+	}))
+
+	n, err = service.DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, endpoints.New(log.New("convoy", log.LevelInfo), db).DeleteEndpoint(ctx, endpoint, endpoint.ProjectID))
+
+	n, err = service.DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
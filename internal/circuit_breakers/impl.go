@@ -0,0 +1,183 @@
+package circuit_breakers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/circuit_breakers/repo"
+	"github.com/frain-dev/convoy/internal/common"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// defaultTransitionLimit bounds a history read when the caller passes no limit.
+const defaultTransitionLimit = 50
+
+// Service implements the CircuitBreakerRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.CircuitBreakerRepository at compile time
+var _ datastore.CircuitBreakerRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) UpsertCircuitBreakerOverride(ctx context.Context, override *datastore.CircuitBreakerOverride) error {
+	var config []byte
+	if override.Config != nil {
+		var err error
+		config, err = json.Marshal(override.Config)
+		if err != nil {
+			return fmt.Errorf("marshal circuit breaker override config: %w", err)
+		}
+	}
+
+	return s.repo.UpsertCircuitBreakerOverride(ctx, repo.UpsertCircuitBreakerOverrideParams{
+		ProjectID:   override.ProjectID,
+		EndpointID:  override.EndpointID,
+		ForcedState: common.StringToPgTextNullable(override.ForcedState),
+		Config:      config,
+		Reason:      common.StringToPgTextNullable(override.Reason),
+	})
+}
+
+func (s *Service) FindCircuitBreakerOverride(ctx context.Context, projectID, endpointID string) (*datastore.CircuitBreakerOverride, error) {
+	row, err := s.repo.FindCircuitBreakerOverride(ctx, repo.FindCircuitBreakerOverrideParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrCircuitBreakerOverrideNotFound
+		}
+		return nil, err
+	}
+
+	return rowToOverride(repo.LoadCircuitBreakerOverridesRow(row))
+}
+
+func (s *Service) DeleteCircuitBreakerOverride(ctx context.Context, projectID, endpointID string) error {
+	return s.repo.DeleteCircuitBreakerOverride(ctx, repo.DeleteCircuitBreakerOverrideParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+	})
+}
+
+func (s *Service) LoadCircuitBreakerOverrides(ctx context.Context) ([]datastore.CircuitBreakerOverride, error) {
+	rows, err := s.repo.LoadCircuitBreakerOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]datastore.CircuitBreakerOverride, 0, len(rows))
+	for _, row := range rows {
+		o, err := rowToOverride(row)
+		if err != nil {
+			// one bad row must not hide every other override from the sampler
+			s.logger.Error("failed to decode circuit breaker override", "endpoint_id", row.EndpointID, "error", err)
+			continue
+		}
+		overrides = append(overrides, *o)
+	}
+
+	return overrides, nil
+}
+
+func (s *Service) CreateCircuitBreakerTransition(ctx context.Context, transition *datastore.CircuitBreakerTransition) error {
+	if transition.UID == "" {
+		transition.UID = ulid.Make().String()
+	}
+
+	return s.repo.CreateCircuitBreakerTransition(ctx, repo.CreateCircuitBreakerTransitionParams{
+		ID:          transition.UID,
+		ProjectID:   transition.ProjectID,
+		EndpointID:  transition.EndpointID,
+		FromState:   transition.FromState,
+		ToState:     transition.ToState,
+		Source:      transition.Source,
+		Reason:      common.StringToPgTextNullable(transition.Reason),
+		FailureRate: transition.FailureRate,
+	})
+}
+
+func (s *Service) LoadCircuitBreakerTransitions(ctx context.Context, projectID, endpointID string, limit int) ([]datastore.CircuitBreakerTransition, error) {
+	if limit <= 0 {
+		limit = defaultTransitionLimit
+	}
+
+	rows, err := s.repo.LoadCircuitBreakerTransitions(ctx, repo.LoadCircuitBreakerTransitionsParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+		LimitVal:   int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]datastore.CircuitBreakerTransition, 0, len(rows))
+	for _, row := range rows {
+		transitions = append(transitions, datastore.CircuitBreakerTransition{
+			UID:         row.ID,
+			ProjectID:   row.ProjectID,
+			EndpointID:  row.EndpointID,
+			FromState:   row.FromState,
+			ToState:     row.ToState,
+			Source:      row.Source,
+			Reason:      common.PgTextToString(row.Reason),
+			FailureRate: row.FailureRate,
+			CreatedAt:   common.PgTimestamptzToTime(row.CreatedAt),
+		})
+	}
+
+	return transitions, nil
+}
+
+// DeleteCircuitBreakerTransitionsBefore deletes up to limit transitions
+// recorded before the cutoff and reports how many it removed.
+func (s *Service) DeleteCircuitBreakerTransitionsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.repo.DeleteCircuitBreakerTransitionsBefore(ctx, repo.DeleteCircuitBreakerTransitionsBeforeParams{
+		Before:   pgtype.Timestamptz{Time: before, Valid: true},
+		LimitVal: int32(limit),
+	})
+}
+
+// DeleteCircuitBreakerOverridesForDeletedEndpoints removes the overrides of
+// soft-deleted endpoints, which the foreign key cascade never sees.
+func (s *Service) DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx context.Context) (int64, error) {
+	return s.repo.DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx)
+}
+
+func rowToOverride(row repo.LoadCircuitBreakerOverridesRow) (*datastore.CircuitBreakerOverride, error) {
+	o := &datastore.CircuitBreakerOverride{
+		ProjectID:   row.ProjectID,
+		EndpointID:  row.EndpointID,
+		ForcedState: common.PgTextToString(row.ForcedState),
+		Reason:      common.PgTextToString(row.Reason),
+		CreatedAt:   common.PgTimestamptzToTime(row.CreatedAt),
+		UpdatedAt:   common.PgTimestamptzToTime(row.UpdatedAt),
+	}
+
+	if len(row.Config) > 0 {
+		o.Config = &datastore.EndpointCircuitBreakerConfiguration{}
+		if err := json.Unmarshal(row.Config, o.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
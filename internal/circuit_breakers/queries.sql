@@ -0,0 +1,46 @@
+-- name: UpsertCircuitBreakerOverride :exec
+INSERT INTO convoy.circuit_breaker_overrides (project_id, endpoint_id, forced_state, config, reason)
+VALUES (@project_id, @endpoint_id, @forced_state, @config, @reason)
+ON CONFLICT (endpoint_id) DO UPDATE SET
+    project_id = EXCLUDED.project_id,
+    forced_state = EXCLUDED.forced_state,
+    config = EXCLUDED.config,
+    reason = EXCLUDED.reason,
+    updated_at = NOW();
+
+-- name: FindCircuitBreakerOverride :one
+SELECT project_id, endpoint_id, forced_state, config, reason, created_at, updated_at
+FROM convoy.circuit_breaker_overrides
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id;
+
+-- name: DeleteCircuitBreakerOverride :exec
+DELETE FROM convoy.circuit_breaker_overrides
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id;
+
+-- name: LoadCircuitBreakerOverrides :many
+SELECT project_id, endpoint_id, forced_state, config, reason, created_at, updated_at
+FROM convoy.circuit_breaker_overrides;
+
+-- name: CreateCircuitBreakerTransition :exec
+INSERT INTO convoy.circuit_breaker_transitions (id, project_id, endpoint_id, from_state, to_state, source, reason, failure_rate)
+VALUES (@id, @project_id, @endpoint_id, @from_state, @to_state, @source, @reason, @failure_rate);
+
+-- name: LoadCircuitBreakerTransitions :many
+SELECT id, project_id, endpoint_id, from_state, to_state, source, reason, failure_rate, created_at
+FROM convoy.circuit_breaker_transitions
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id
+ORDER BY created_at DESC
+LIMIT @limit_val;
+
+-- name: DeleteCircuitBreakerTransitionsBefore :execrows
+DELETE FROM convoy.circuit_breaker_transitions
+WHERE id IN (
+    SELECT id FROM convoy.circuit_breaker_transitions
+    WHERE created_at < @before
+    LIMIT @limit_val
+);
+
+-- name: DeleteCircuitBreakerOverridesForDeletedEndpoints :execrows
+DELETE FROM convoy.circuit_breaker_overrides o
+USING convoy.endpoints e
+WHERE e.id = o.endpoint_id AND e.deleted_at IS NOT NULL;
@@ -0,0 +1,144 @@
+package dataplane
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/net"
+	cb "github.com/frain-dev/convoy/pkg/circuit_breaker"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+const halfOpenProbeTimeout = 10 * time.Second
+
+// pinger is the part of net.Dispatcher the half-open probe needs.
+type pinger interface {
+	Ping(ctx context.Context, opts net.PingOptions) error
+}
+
+// authHeaderGetter is the part of services.OAuth2TokenService the half-open
+// probe needs to authenticate against OAuth2 endpoints.
+type authHeaderGetter interface {
+	GetAuthorizationHeader(ctx context.Context, endpoint *datastore.Endpoint) (string, error)
+}
+
+// NewHalfOpenProbe pings a breaker's endpoint before the breaker lets real
+// deliveries through again. The ping carries the endpoint's credentials so
+// endpoints that authenticate requests are probed like any other.
+func NewHalfOpenProbe(endpointRepo datastore.EndpointRepository, dispatcher pinger, tokens authHeaderGetter, licenser license.Licenser, lo log.Logger) cb.ProbeFunc {
+	return func(ctx context.Context, b cb.CircuitBreaker) error {
+		endpointID := strings.TrimPrefix(b.Key, "breaker:")
+		endpoint, err := endpointRepo.FindEndpointByID(ctx, endpointID, b.TenantId)
+		if err != nil {
+			// Not the endpoint's fault; let trial deliveries decide.
+			lo.Error("failed to find endpoint for half-open probe", "endpoint_id", endpointID, "error", err)
+			return nil
+		}
+
+		var mtlsCert *tls.Certificate
+		if endpoint.MtlsClientCert != nil && licenser.MutualTLS() {
+			mtlsCert, err = config.LoadClientCertificateWithCache(endpoint.UID, endpoint.MtlsClientCert.ClientCert, endpoint.MtlsClientCert.ClientKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		opts := net.PingOptions{
+			Endpoint:    endpoint.Url,
+			Timeout:     halfOpenProbeTimeout,
+			ContentType: endpoint.ContentType,
+			MtlsCert:    mtlsCert,
+			Headers:     http.Header{},
+		}
+
+		if auth := endpoint.Authentication; auth != nil {
+			switch auth.Type {
+			case datastore.APIKeyAuthentication:
+				if auth.ApiKey != nil {
+					opts.Headers.Set(auth.ApiKey.HeaderName, auth.ApiKey.HeaderValue)
+				}
+			case datastore.BasicAuthentication:
+				if auth.BasicAuth != nil {
+					req := &http.Request{Header: opts.Headers}
+					req.SetBasicAuth(auth.BasicAuth.UserName, auth.BasicAuth.Password)
+				}
+			case datastore.OAuth2Authentication:
+				if tokens != nil {
+					opts.OAuth2TokenGetter = func(ctx context.Context) (string, error) {
+						return tokens.GetAuthorizationHeader(ctx, endpoint)
+					}
+				}
+			}
+		}
+
+		return dispatcher.Ping(ctx, opts)
+	}
+}
+
+const (
+	transitionRecorderWorkers = 4
+	transitionRecorderBuffer  = 256
+)
+
+// transitionRecorder persists and announces the sampler's breaker transitions
+// off the sampling tick on a fixed pool of workers, so a large outage that
+// trips many breakers at once cannot start a goroutine and a database write
+// per breaker. Transitions that arrive while the buffer is full are dropped
+// and logged rather than stalling the tick.
+type transitionRecorder struct {
+	queue  chan *datastore.CircuitBreakerTransition
+	record func(ctx context.Context, transition *datastore.CircuitBreakerTransition)
+	lo     log.Logger
+}
+
+func newTransitionRecorder(ctx context.Context, repo datastore.CircuitBreakerRepository, notifier transitionNotifier, lo log.Logger) *transitionRecorder {
+	r := &transitionRecorder{
+		queue: make(chan *datastore.CircuitBreakerTransition, transitionRecorderBuffer),
+		record: func(ctx context.Context, transition *datastore.CircuitBreakerTransition) {
+			if err := repo.CreateCircuitBreakerTransition(ctx, transition); err != nil {
+				lo.Error("failed to record circuit breaker transition", "error", err)
+			}
+			notifier.Run(ctx, transition)
+		},
+		lo: lo,
+	}
+	r.start(ctx, transitionRecorderWorkers)
+	return r
+}
+
+// transitionNotifier is the part of services.CircuitBreakerTransitionNotifier
+// the recorder needs.
+type transitionNotifier interface {
+	Run(ctx context.Context, transition *datastore.CircuitBreakerTransition)
+}
+
+func (r *transitionRecorder) start(ctx context.Context, workers int) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case transition := <-r.queue:
+					r.record(ctx, transition)
+				}
+			}
+		}()
+	}
+}
+
+// Record queues a transition without blocking.
+func (r *transitionRecorder) Record(transition *datastore.CircuitBreakerTransition) {
+	select {
+	case r.queue <- transition:
+	default:
+		r.lo.Error("circuit breaker transition dropped, recorder is backed up",
+			"endpoint_id", transition.EndpointID, "from", transition.FromState, "to", transition.ToState)
+	}
+}
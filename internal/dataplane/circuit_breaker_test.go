@@ -0,0 +1,49 @@
+package dataplane
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestTransitionRecorderDropsWhenBackedUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	var recorded atomic.Int32
+	r := &transitionRecorder{
+		queue: make(chan *datastore.CircuitBreakerTransition, 2),
+		record: func(context.Context, *datastore.CircuitBreakerTransition) {
+			<-release
+			recorded.Add(1)
+		},
+		lo: log.New("convoy", log.LevelError),
+	}
+	r.start(ctx, 1)
+
+	done := make(chan struct{})
+	go func() {
+		// one in flight, two buffered, the rest dropped
+		for i := 0; i < 10; i++ {
+			r.Record(&datastore.CircuitBreakerTransition{EndpointID: "123"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Record blocked while the recorder was backed up")
+	}
+
+	close(release)
+	require.Eventually(t, func() bool { return recorded.Load() >= 2 }, 5*time.Second, 10*time.Millisecond)
+	require.LessOrEqual(t, recorded.Load(), int32(3))
+}
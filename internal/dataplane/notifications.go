@@ -14,9 +14,9 @@ import (
 const breakerFailureMsg = "Circuit breaker threshold exceeded"
 
 // EnqueueCircuitBreakerNotifications tells the endpoint's own channels (support
-// email, Slack webhook and Teams webhook) that the breaker disabled it, and sends
-// the organisation owner a separate email. ownerEmail may be empty when it could
-// not be resolved; missing channels are skipped.
+// email, Slack, Teams and the incident channels) that the breaker disabled it,
+// and sends the organisation owner a separate email. ownerEmail may be empty
+// when it could not be resolved; missing channels are skipped.
 //
 // Returns true when at least one notification job was written to the queue.
 // Per-channel failures are logged rather than returned.
@@ -26,11 +26,15 @@ func EnqueueCircuitBreakerNotifications(ctx context.Context, q queue.Queuer, lo
 	if endpoint != nil && licenser.AdvancedEndpointMgmt() {
 		enqueued = notification.DispatchEndpointAlert(ctx, q, lo, notification.EndpointAlert{
 			EmailRecipient:  endpoint.SupportEmail,
+			OrganisationID:  project.OrganisationID,
 			SlackWebhookURL: endpoint.SlackWebhookURL,
 			TeamsWebhookURL: endpoint.TeamsWebhookURL,
+			Channels:        endpoint.NotificationChannels,
+			DedupKey:        notification.EndpointStatusDedupKey(endpoint.UID),
 			EmailSubject:    "Endpoint Disabled - Circuit Breaker Triggered",
 			EmailParams: map[string]string{
 				"name":            endpoint.Name,
+				"project_name":    project.Name,
 				"logo_url":        project.LogoURL,
 				"target_url":      endpoint.Url,
 				"failure_msg":     breakerFailureMsg,
@@ -60,9 +64,11 @@ func EnqueueCircuitBreakerNotifications(ctx context.Context, q queue.Queuer, lo
 	// The owner is reached by email only; webhook channels are per endpoint.
 	return notification.DispatchEndpointAlert(ctx, q, lo, notification.EndpointAlert{
 		EmailRecipient: ownerEmail,
+		OrganisationID: project.OrganisationID,
 		EmailSubject:   "Project Endpoint Disabled - Circuit Breaker Triggered",
 		EmailParams: map[string]string{
 			"name":            nameParam,
+			"project_name":    project.Name,
 			"logo_url":        project.LogoURL,
 			"target_url":      targetURL,
 			"failure_msg":     breakerFailureMsg,
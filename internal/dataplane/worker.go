@@ -12,14 +12,21 @@ import (
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/datastore/cached"
+	"github.com/frain-dev/convoy/internal/alert_rules"
 	"github.com/frain-dev/convoy/internal/backup_jobs"
 	"github.com/frain-dev/convoy/internal/batch_retries"
+	"github.com/frain-dev/convoy/internal/circuit_breakers"
 	"github.com/frain-dev/convoy/internal/configuration"
 	"github.com/frain-dev/convoy/internal/delivery_attempts"
+	"github.com/frain-dev/convoy/internal/email_templates"
+	"github.com/frain-dev/convoy/internal/endpoint_health"
 	"github.com/frain-dev/convoy/internal/endpoints"
 	"github.com/frain-dev/convoy/internal/endpoints/disable"
 	"github.com/frain-dev/convoy/internal/event_deliveries"
+	"github.com/frain-dev/convoy/internal/event_type_versions"
 	"github.com/frain-dev/convoy/internal/events"
+	"github.com/frain-dev/convoy/internal/export_jobs"
+	"github.com/frain-dev/convoy/internal/filter_rejections"
 	"github.com/frain-dev/convoy/internal/filters"
 	"github.com/frain-dev/convoy/internal/meta_events"
 	"github.com/frain-dev/convoy/internal/organisations"
@@ -37,6 +44,7 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/retention"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
 	"github.com/frain-dev/convoy/internal/projects"
+	"github.com/frain-dev/convoy/internal/replay_jobs"
 	"github.com/frain-dev/convoy/internal/subscriptions"
 	"github.com/frain-dev/convoy/internal/telemetry"
 	"github.com/frain-dev/convoy/internal/users"
@@ -132,6 +140,9 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 	backupJobRepo := backup_jobs.New(opts.Logger, opts.DB)
 	filterRepo := cached.NewCachedFilterRepository(filters.New(opts.Logger, opts.DB), opts.Cache, cached.DefaultFilterTTL, lo)
 	batchRetryRepo := batch_retries.New(lo, opts.DB)
+	eventTypeVersionRepo := event_type_versions.New(lo, opts.DB)
+	filterRejectionRepo := filter_rejections.New(lo, opts.DB)
+	emailTemplateRepo := email_templates.New(lo, opts.DB)
 
 	rateLimiter := opts.Broker.RateLimiter
 
@@ -186,6 +197,24 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 		return nil, fmt.Errorf("failed to create new net dispatcher: %w", err)
 	}
 
+	// Route OAuth2 token exchange through a netjail dispatcher so the outbound
+	// request to authentication.oauth2.url is subject to the IP allow/block
+	// rules when IpRules is enabled. Without this the token endpoint is an
+	// unfiltered outbound request (SSRF bypass). A dedicated dispatcher is used
+	// so the token hop always validates TLS, instead of inheriting the webhook
+	// insecure_skip_verify setting.
+	oauth2Dispatcher, err := net.NewOAuth2Dispatcher(opts.Licenser, featureFlag, lo, cfg, caCertTLSCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth2 dispatcher: %w", err)
+	}
+
+	oauth2TokenService := services.NewOAuth2TokenService(
+		opts.Cache,
+		lo,
+		services.WithOAuth2HTTPClient(oauth2Dispatcher.HTTPClient()),
+		services.WithOAuth2Context(oauth2Dispatcher.ContextWithRules),
+	)
+
 	// Single source of truth for circuit-breaker enablement: env folded into the
 	// instance DB flag, with per-org overrides winning. Shared by the sampler gate,
 	// per-delivery enforcement, and dashboard display so they never disagree.
@@ -206,7 +235,18 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 	// The manager is always constructed and started. Each sampling tick is gated
 	// live by EnabledFuncOption, so toggling the instance flag or an org override
 	// takes effect without restarting the worker.
-	circuitBreakerManager, err := cb.NewCircuitBreakerManager(
+	circuitBreakerRepo := circuit_breakers.New(lo, opts.DB)
+	circuitBreakerNotifier := &services.CircuitBreakerTransitionNotifier{
+		ProjectRepo:  projectRepo,
+		EndpointRepo: endpointRepo,
+		MetaEvent:    services.NewMetaEvent(opts.Queue, projectRepo, metaEventRepo, lo),
+		Queue:        opts.Queue,
+		Licenser:     opts.Licenser,
+		Logger:       lo,
+	}
+	transitionRecorder := newTransitionRecorder(ctx, circuitBreakerRepo, circuitBreakerNotifier, lo)
+
+	circuitBreakerOptions := []cb.CircuitBreakerOption{
 		cb.SkipSleepOption(masterDefaults.SkipSleep),
 		cb.MasterConfigOption(masterDefaults),
 		cb.ConfigProviderOption(func(projectID string) *cb.CircuitBreakerConfig {
@@ -233,6 +273,29 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 		cb.ClockOption(clock.NewRealClock()),
 		cb.LoggerOption(lo),
 		cb.EnabledFuncOption(cbEnablement.EnabledAnywhere),
+		cb.OverrideFunctionOption(services.CircuitBreakerOverrideFunc(circuitBreakerRepo)),
+		cb.TransitionFunctionOption(func(from cb.State, b cb.CircuitBreaker) {
+			metrics.GetDPInstance(opts.Licenser).IncrementCircuitBreakerTransition(b.TenantId, from.String(), b.State.String())
+
+			// Holds are recorded by whoever placed them; only the sampler's
+			// own decisions land here.
+			if b.Forced {
+				return
+			}
+
+			transition := &datastore.CircuitBreakerTransition{
+				ProjectID:   b.TenantId,
+				EndpointID:  strings.Split(b.Key, ":")[1],
+				FromState:   from.String(),
+				ToState:     b.State.String(),
+				Source:      datastore.CircuitBreakerTransitionSourceSampler,
+				FailureRate: b.FailureRate,
+			}
+
+			// The sampler holds its lock while transitions are observed, so
+			// recording and announcing happen off the tick.
+			transitionRecorder.Record(transition)
+		}),
 		// Returns true only when the alert was dispatched, so the manager counts an
 		// alert that this tick actually produced. Every other exit reports false and
 		// leaves the window's one alert unspent.
@@ -296,7 +359,13 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 			sent := EnqueueCircuitBreakerNotifications(ctx, opts.Queue, lo, opts.Licenser, project, endpoint, ownerEmail, b.FailureRate)
 			return sent, nil
 		}),
-	)
+	}
+
+	if !cfg.CircuitBreaker.DisableHalfOpenProbe {
+		circuitBreakerOptions = append(circuitBreakerOptions, cb.ProbeFunctionOption(NewHalfOpenProbe(endpointRepo, dispatcher, oauth2TokenService, opts.Licenser, lo)))
+	}
+
+	circuitBreakerManager, err := cb.NewCircuitBreakerManager(circuitBreakerOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create circuit breaker manager: %w", err)
 	}
@@ -332,24 +401,6 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 	channels["broadcast"] = broadcastCh
 	channels["dynamic"] = dynamicCh
 
-	// Route OAuth2 token exchange through a netjail dispatcher so the outbound
-	// request to authentication.oauth2.url is subject to the IP allow/block
-	// rules when IpRules is enabled. Without this the token endpoint is an
-	// unfiltered outbound request (SSRF bypass). A dedicated dispatcher is used
-	// so the token hop always validates TLS, instead of inheriting the webhook
-	// insecure_skip_verify setting.
-	oauth2Dispatcher, err := net.NewOAuth2Dispatcher(opts.Licenser, featureFlag, lo, cfg, caCertTLSCfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create oauth2 dispatcher: %w", err)
-	}
-
-	oauth2TokenService := services.NewOAuth2TokenService(
-		opts.Cache,
-		lo,
-		services.WithOAuth2HTTPClient(oauth2Dispatcher.HTTPClient()),
-		services.WithOAuth2Context(oauth2Dispatcher.ContextWithRules),
-	)
-
 	locker := opts.Broker.JobLocker
 
 	eventDeliveryProcessorDeps := task.EventDeliveryProcessorDeps{
@@ -411,6 +462,8 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 		EventQueue:                 opts.Queue,
 		SubRepo:                    subRepo,
 		FilterRepo:                 filterRepo,
+		EventTypeVersionRepo:       eventTypeVersionRepo,
+		FilterRejectionRepo:        filterRejectionRepo,
 		Licenser:                   opts.Licenser,
 		OAuth2TokenService:         oauth2TokenService,
 		FeatureFlag:                featureFlag,
@@ -427,17 +480,73 @@ func NewWorker(ctx context.Context, opts RuntimeOpts, cfg config.Configuration)
 	consumer.RegisterHandlers(convoy.SnapshotUsage, task.SnapshotUsage(lo, opts.DB, opts.Cache, locker), nil)
 	consumer.RegisterHandlers(convoy.RefreshEventDeliveryDailyCounts, task.RefreshEventDeliveryDailyCounts(lo, opts.DB, locker), nil)
 	consumer.RegisterHandlers(convoy.RefreshQueueMetricsSnapshot, task.RefreshQueueMetricsSnapshot(lo, opts.DB, locker), nil)
-	consumer.RegisterHandlers(convoy.EmailProcessor, task.ProcessEmails(sc), nil)
+
+	endpointHealthChecker := &services.EndpointHealthChecker{
+		Repo:               endpoint_health.New(lo, opts.DB),
+		EndpointRepo:       endpointRepo,
+		Dispatcher:         dispatcher,
+		OAuth2TokenService: oauth2TokenService,
+		Licenser:           opts.Licenser,
+		Clock:              clock.NewRealClock(),
+		Logger:             lo,
+	}
+	consumer.RegisterHandlers(convoy.RunEndpointHealthChecks, task.RunEndpointHealthChecks(endpointHealthChecker, locker), nil)
+
+	alertRuleEvaluator := &services.AlertRuleEvaluator{
+		Repo:        alert_rules.New(lo, opts.DB),
+		ProjectRepo: projectRepo,
+		Queue:       opts.Queue,
+		Clock:       clock.NewRealClock(),
+		Logger:      lo,
+	}
+	consumer.RegisterHandlers(convoy.RunAlertRules, task.RunAlertRules(alertRuleEvaluator, locker), nil)
+	consumer.RegisterHandlers(convoy.PruneCircuitBreakerTransitions, task.PruneCircuitBreakerTransitions(circuitBreakerRepo, locker, lo), nil)
+	consumer.RegisterHandlers(convoy.PruneFilterRejections, task.PruneFilterRejections(filterRejectionRepo, locker, lo), nil)
+
+	cliSubscriptionReaper := &services.CLISubscriptionReaper{
+		SubRepo:           subRepo,
+		EventDeliveryRepo: eventDeliveryRepo,
+		Logger:            lo,
+	}
+	consumer.RegisterHandlers(convoy.ReapCLISubscriptions, task.ReapCLISubscriptions(cliSubscriptionReaper, locker), nil)
+
+	eventTypeVersionSunsetNotifier := &services.EventTypeVersionSunsetNotifier{
+		VersionRepo: eventTypeVersionRepo,
+		MetaEvent:   services.NewMetaEvent(opts.Queue, projectRepo, metaEventRepo, lo),
+		Logger:      lo,
+	}
+	consumer.RegisterHandlers(convoy.NotifyEventTypeVersionSunsets, task.NotifyEventTypeVersionSunsets(eventTypeVersionSunsetNotifier, locker), nil)
+	consumer.RegisterHandlers(convoy.EmailProcessor, task.ProcessEmails(sc, emailTemplateRepo, lo), nil)
 
 	// events_search tokenization is legacy FTS copy; unified list search (PDE-1009) reads
 	// convoy.events directly and no longer enqueues TokenizeSearch jobs.
 
-	consumer.RegisterHandlers(convoy.NotificationProcessor, task.ProcessNotifications(sc, dispatcher), nil)
+	consumer.RegisterHandlers(convoy.NotificationProcessor, task.ProcessNotifications(sc, dispatcher, emailTemplateRepo, lo), nil)
 	consumer.RegisterHandlers(convoy.MetaEventProcessor, task.ProcessMetaEvent(projectRepo, metaEventRepo, dispatcher, lo), nil)
 	consumer.RegisterHandlers(convoy.DeleteArchivedTasksProcessor, task.DeleteArchivedTasks(opts.Queue, locker, lo), nil)
 
 	consumer.RegisterHandlers(convoy.BatchRetryProcessor, task.ProcessBatchRetry(batchRetryRepo, eventDeliveryRepo, opts.Queue, lo), nil)
 
+	replayJobDeps := task.ReplayJobDeps{
+		ReplayJobRepo:              replay_jobs.New(lo, opts.DB),
+		EndpointRepo:               endpointRepo,
+		EventRepo:                  eventRepo,
+		ProjectRepo:                projectRepo,
+		EventDeliveryRepo:          eventDeliveryRepo,
+		SubRepo:                    subRepo,
+		FilterRepo:                 filterRepo,
+		EventTypeVersionRepo:       eventTypeVersionRepo,
+		EventQueue:                 opts.Queue,
+		Licenser:                   opts.Licenser,
+		OAuth2TokenService:         oauth2TokenService,
+		FeatureFlag:                featureFlag,
+		FeatureFlagFetcher:         postgres.NewFeatureFlagFetcher(opts.DB),
+		EarlyAdopterFeatureFetcher: postgres.NewEarlyAdopterFeatureFetcher(opts.DB),
+		Logger:                     lo,
+	}
+	consumer.RegisterHandlers(convoy.ReplayJobProcessor, task.ProcessReplayJob(replayJobDeps), nil)
+	consumer.RegisterHandlers(convoy.ExportJobProcessor, task.ProcessExportJob(configRepo, projectRepo, export_jobs.New(lo, opts.DB), eventRepo, eventDeliveryRepo, lo), nil)
+
 	bulkOnboardDeps := task.BulkOnboardDeps{
 		EndpointRepo:               endpointRepo,
 		SubRepo:                    subRepo,
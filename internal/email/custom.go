@@ -0,0 +1,245 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+)
+
+// templateCustom is the shell an overridden body is rendered into, so a
+// custom email keeps the branded header, footer and responsive layout.
+const templateCustom = "custom"
+
+var (
+	ErrTemplateNotCustomisable = errors.New("email template cannot be customised")
+	ErrInvalidBrandColor       = errors.New("brand colour must be a hex colour like #0082F9")
+
+	brandColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// customisable lists the emails an organisation can override and the
+// variables each one is rendered with. Account emails (verification, password
+// reset) go out before there is an organisation to brand them.
+var customisable = map[TemplateName][]string{
+	TemplateEndpointUpdate: {
+		"name", "project_name", "target_url", "failure_msg", "response_body",
+		"failure_rate", "status_code", "endpoint_status",
+	},
+	TemplateAlertRule: {
+		"rule_name", "alert_state", "project_name", "condition", "current_value",
+	},
+	TemplateOrganisationInvite: {
+		"organisation_name", "inviter_name", "invite_url", "expires_at",
+	},
+}
+
+// brandingVariables are available to every customised email.
+var brandingVariables = []string{"brand_color", "logo_url"}
+
+// sampleParams fill previews and the render check run before an override is
+// saved.
+var sampleParams = map[string]string{
+	"name":              "payments-endpoint",
+	"project_name":      "Payments",
+	"target_url":        "https://example.com/webhooks",
+	"failure_msg":       "connection refused",
+	"response_body":     "",
+	"failure_rate":      "12.50",
+	"status_code":       "502",
+	"endpoint_status":   "inactive",
+	"rule_name":         "checkout failures",
+	"alert_state":       "firing",
+	"condition":         "failure rate above 5%",
+	"current_value":     "12.50",
+	"organisation_name": "Acme",
+	"inviter_name":      "Jane Doe",
+	"invite_url":        "https://example.com/accept-invite?invite-token=sample",
+	"expires_at":        "Mon, 02 Jan 2006 15:04:05 UTC",
+	"brand_color":       "",
+	"logo_url":          "",
+}
+
+// Customisation is an organisation's override of one email. Empty fields keep
+// the built-in subject, body, logo and colour.
+type Customisation struct {
+	Subject    string
+	Body       string
+	LogoURL    string
+	BrandColor string
+}
+
+// IsCustomisable reports whether organisations can override name.
+func IsCustomisable(name TemplateName) bool {
+	_, ok := customisable[name]
+	return ok
+}
+
+// CustomisableTemplates returns the emails organisations can override.
+func CustomisableTemplates() []TemplateName {
+	return []TemplateName{TemplateAlertRule, TemplateEndpointUpdate, TemplateOrganisationInvite}
+}
+
+// Variables returns the template variables name is rendered with, including
+// the branding ones.
+func Variables(name TemplateName) []string {
+	vars, ok := customisable[name]
+	if !ok {
+		return nil
+	}
+	return append(append([]string{}, vars...), brandingVariables...)
+}
+
+// SampleParams returns example values for every variable of name.
+func SampleParams(name TemplateName) map[string]string {
+	params := make(map[string]string)
+	for _, v := range Variables(name) {
+		params[v] = sampleParams[v]
+	}
+	return params
+}
+
+// ValidBrandColor reports whether color is usable as a header colour. An
+// empty colour keeps the default.
+func ValidBrandColor(color string) bool {
+	return color == "" || brandColorRegex.MatchString(color)
+}
+
+// ValidateCustomisation parses the override and renders it against
+// SampleParams, so a typo in a variable name is rejected when the override is
+// saved rather than when the email is due.
+func ValidateCustomisation(name TemplateName, c Customisation) error {
+	if !IsCustomisable(name) {
+		return ErrTemplateNotCustomisable
+	}
+
+	if !ValidBrandColor(c.BrandColor) {
+		return ErrInvalidBrandColor
+	}
+
+	data := templateData(SampleParams(name))
+	if _, err := renderSubject(c.Subject, data); err != nil {
+		return err
+	}
+
+	if _, err := renderBody(c.Body, data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BuildCustomised renders name with params like Build, applying c on top of
+// it. It returns the subject to send with: subject, unless c overrides it. A
+// nil c builds the built-in email.
+func (e *Email) BuildCustomised(name TemplateName, subject string, params interface{}, c *Customisation) (string, error) {
+	if c == nil {
+		return subject, e.Build(string(name), params)
+	}
+
+	if !ValidBrandColor(c.BrandColor) {
+		return "", ErrInvalidBrandColor
+	}
+
+	data := templateData(params)
+
+	// A project's own logo wins over the organisation's.
+	if logo, _ := data["logo_url"].(string); logo == "" {
+		data["logo_url"] = c.LogoURL
+	}
+	if c.BrandColor != "" {
+		data["brand_color"] = c.BrandColor
+	}
+
+	if c.Subject != "" {
+		custom, err := renderSubject(c.Subject, data)
+		if err != nil {
+			return "", err
+		}
+		if custom != "" {
+			subject = custom
+		}
+	}
+
+	if c.Body == "" {
+		return subject, e.Build(string(name), data)
+	}
+
+	body, err := renderBody(c.Body, data)
+	if err != nil {
+		return "", err
+	}
+
+	data["custom_body"] = body
+	data["custom_title"] = subject
+
+	return subject, e.Build(templateCustom, data)
+}
+
+// HTML returns the rendered email body.
+func (e *Email) HTML() string {
+	return e.body.String()
+}
+
+// templateData copies params into a map the branding and custom body
+// variables can be added to. Queued params arrive as whatever the queue
+// decoded them into, so they go through JSON rather than a type switch.
+func templateData(params interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return data
+	}
+
+	_ = json.Unmarshal(b, &data)
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	return data
+}
+
+// renderSubject renders a subject override as plain text on one line, so an
+// endpoint name or failure message can never inject another header.
+func renderSubject(subject string, data map[string]interface{}) (string, error) {
+	if subject == "" {
+		return "", nil
+	}
+
+	t, err := texttemplate.New("subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return "", fmt.Errorf("invalid subject template: %v", err)
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render subject template: %v", err)
+	}
+
+	return strings.Join(strings.Fields(b.String()), " "), nil
+}
+
+// renderBody renders a body override with html/template, which escapes every
+// variable, since endpoint responses and failure messages come from outside.
+func renderBody(body string, data map[string]interface{}) (template.HTML, error) {
+	if body == "" {
+		return "", nil
+	}
+
+	t, err := template.New("body").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("invalid body template: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render body template: %v", err)
+	}
+
+	return template.HTML(b.String()), nil
+}
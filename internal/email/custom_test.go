@@ -0,0 +1,140 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_BuildCustomised_Branding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	params := emailParams()
+	params["logo_url"] = ""
+
+	e := NewEmail(buildClient(ctrl))
+	subject, err := e.BuildCustomised(TemplateEndpointUpdate, "Endpoint Status Update", params, &Customisation{
+		LogoURL:    "https://example.com/acme.png",
+		BrandColor: "#112233",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Endpoint Status Update", subject)
+
+	body := e.HTML()
+	require.Contains(t, body, `bgcolor="#112233"`)
+	require.Contains(t, body, "background-color: #112233")
+	require.Contains(t, body, `src="https://example.com/acme.png"`)
+	require.NotContains(t, body, "email-logo-white.png")
+	// The built-in copy is kept when only the branding is customised.
+	require.Contains(t, body, "test-endpoint")
+}
+
+func Test_BuildCustomised_ProjectLogoWins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	params := emailParams()
+	params["logo_url"] = "https://example.com/project.png"
+
+	e := NewEmail(buildClient(ctrl))
+	_, err := e.BuildCustomised(TemplateEndpointUpdate, "", params, &Customisation{LogoURL: "https://example.com/acme.png"})
+	require.NoError(t, err)
+	require.Contains(t, e.HTML(), `src="https://example.com/project.png"`)
+	require.NotContains(t, e.HTML(), "acme.png")
+}
+
+func Test_BuildCustomised_Override(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	params := emailParams()
+	params["failure_msg"] = "<script>alert(1)</script>"
+	params["name"] = "payments\r\nBcc: attacker@example.com"
+
+	e := NewEmail(buildClient(ctrl))
+	subject, err := e.BuildCustomised(TemplateEndpointUpdate, "Endpoint Status Update", params, &Customisation{
+		Subject: "Acme: {{ .name }} is {{ .endpoint_status }}",
+		Body:    "<p>We stopped sending to {{ .target_url }}: {{ .failure_msg }}</p>",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Acme: payments Bcc: attacker@example.com is inactive", subject)
+
+	body := e.HTML()
+	require.Contains(t, body, "<p>We stopped sending to https://example.com/endpoint: &lt;script&gt;alert(1)&lt;/script&gt;</p>")
+	require.NotContains(t, body, "<script>")
+	require.Contains(t, body, "<title>Acme: payments Bcc: attacker@example.com is inactive</title>")
+	// The override sits inside the usual shell.
+	require.Contains(t, body, `class="card-foot"`)
+	require.Contains(t, body, "email-logo-white.png")
+}
+
+func Test_BuildCustomised_NilKeepsBuiltIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	builtIn := NewEmail(buildClient(ctrl))
+	require.NoError(t, builtIn.Build(string(TemplateAlertRule), emailParams()))
+
+	e := NewEmail(buildClient(ctrl))
+	subject, err := e.BuildCustomised(TemplateAlertRule, "Alert Rule firing", emailParams(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "Alert Rule firing", subject)
+	require.Equal(t, builtIn.HTML(), e.HTML())
+}
+
+func Test_ValidateCustomisation(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    TemplateName
+		c       Customisation
+		wantErr error
+	}{
+		{
+			name: "valid",
+			tmpl: TemplateAlertRule,
+			c: Customisation{
+				Subject:    "{{ .rule_name }} is {{ .alert_state }}",
+				Body:       `{{ if eq .alert_state "firing" }}<b>{{ .condition }}</b>{{ end }}`,
+				BrandColor: "#AbCdEf",
+			},
+		},
+		{
+			name:    "not customisable",
+			tmpl:    TemplateResetPassword,
+			wantErr: ErrTemplateNotCustomisable,
+		},
+		{
+			name:    "invalid colour",
+			tmpl:    TemplateEndpointUpdate,
+			c:       Customisation{BrandColor: "red; background: url(x)"},
+			wantErr: ErrInvalidBrandColor,
+		},
+		{
+			name: "unknown variable",
+			tmpl: TemplateEndpointUpdate,
+			c:    Customisation{Body: "{{ .rule_name }}"},
+		},
+		{
+			name: "parse error",
+			tmpl: TemplateOrganisationInvite,
+			c:    Customisation{Subject: "{{ .inviter_name "},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCustomisation(tc.tmpl, tc.c)
+
+			switch {
+			case tc.wantErr != nil:
+				require.ErrorIs(t, err, tc.wantErr)
+			case tc.name == "valid":
+				require.NoError(t, err)
+			default:
+				require.Error(t, err)
+			}
+		})
+	}
+}
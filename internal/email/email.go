@@ -25,6 +25,7 @@ type TemplateName string
 
 const (
 	TemplateEndpointUpdate     TemplateName = "endpoint.update"
+	TemplateAlertRule          TemplateName = "alert.rule"
 	TemplateEmailVerification  TemplateName = "user.verify.email"
 	TemplateOrganisationInvite TemplateName = "organisation.invite"
 	TemplateResetPassword      TemplateName = "reset.password"
@@ -49,6 +50,10 @@ type Message struct {
 
 	// Glob represents which template to use in building the email
 	TemplateName TemplateName `json:"template_name,omitempty"`
+
+	// OrganisationID selects the organisation's override and branding of the
+	// template. Empty sends the built-in email.
+	OrganisationID string `json:"organisation_id,omitempty"`
 }
 
 type Email struct {
@@ -69,6 +69,7 @@ var emailTemplates = []string{
 	"organisation.invite.html",
 	"endpoint.update.html",
 	"twitter.source.html",
+	"alert.rule.html",
 }
 
 func emailParams() map[string]string {
@@ -87,6 +88,11 @@ func emailParams() map[string]string {
 		"failure_msg":            "connection refused",
 		"source_name":            "twitter-source",
 		"crc_verified_at":        "2026-01-01",
+		"rule_name":              "checkout failures",
+		"alert_state":            "firing",
+		"project_name":           "default",
+		"condition":              "failure rate above 5%",
+		"current_value":          "12.50",
 	}
 }
 
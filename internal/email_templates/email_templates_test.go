@@ -0,0 +1,92 @@
+package email_templates
+
+import (
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestEmailTemplate_UpsertReplacesOverride(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	org := seedOrganisation(t, db)
+
+	require.NoError(t, service.UpsertEmailTemplate(ctx, &datastore.EmailTemplate{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		TemplateName:   "endpoint.update",
+		Subject:        "{{ .name }} was disabled",
+	}))
+
+	// A second upsert for the same email keeps one row and takes the new copy.
+	require.NoError(t, service.UpsertEmailTemplate(ctx, &datastore.EmailTemplate{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		TemplateName:   "endpoint.update",
+		Subject:        "Your endpoint {{ .name }} needs attention",
+		Body:           "<p>{{ .failure_msg }}</p>",
+	}))
+
+	templates, err := service.LoadEmailTemplates(ctx, org.UID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	fetched, err := service.FindEmailTemplate(ctx, org.UID, "endpoint.update")
+	require.NoError(t, err)
+	require.Equal(t, "Your endpoint {{ .name }} needs attention", fetched.Subject)
+	require.Equal(t, "<p>{{ .failure_msg }}</p>", fetched.Body)
+
+	require.NoError(t, service.DeleteEmailTemplate(ctx, org.UID, "endpoint.update"))
+
+	_, err = service.FindEmailTemplate(ctx, org.UID, "endpoint.update")
+	require.ErrorIs(t, err, datastore.ErrEmailTemplateNotFound)
+	require.ErrorIs(t, service.DeleteEmailTemplate(ctx, org.UID, "endpoint.update"), datastore.ErrEmailTemplateNotFound)
+}
+
+func TestEmailTemplate_ScopedToOrganisation(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	org := seedOrganisation(t, db)
+	other := seedOrganisation(t, db)
+
+	require.NoError(t, service.UpsertEmailTemplate(ctx, &datastore.EmailTemplate{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		TemplateName:   "alert.rule",
+		Subject:        "{{ .rule_name }}",
+	}))
+
+	_, err := service.FindEmailTemplate(ctx, other.UID, "alert.rule")
+	require.ErrorIs(t, err, datastore.ErrEmailTemplateNotFound)
+
+	templates, err := service.LoadEmailTemplates(ctx, other.UID)
+	require.NoError(t, err)
+	require.Empty(t, templates)
+}
+
+func TestEmailBranding_Upsert(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	org := seedOrganisation(t, db)
+
+	_, err := service.FindEmailBranding(ctx, org.UID)
+	require.ErrorIs(t, err, datastore.ErrEmailBrandingNotFound)
+
+	require.NoError(t, service.UpsertEmailBranding(ctx, &datastore.EmailBranding{
+		OrganisationID: org.UID,
+		BrandColor:     "#112233",
+		LogoURL:        "https://example.com/logo.png",
+	}))
+	require.NoError(t, service.UpsertEmailBranding(ctx, &datastore.EmailBranding{
+		OrganisationID: org.UID,
+		BrandColor:     "#445566",
+	}))
+
+	branding, err := service.FindEmailBranding(ctx, org.UID)
+	require.NoError(t, err)
+	require.Equal(t, "#445566", branding.BrandColor)
+	require.Empty(t, branding.LogoURL)
+}
@@ -0,0 +1,131 @@
+package email_templates
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/email_templates/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// Service implements the EmailTemplateRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.EmailTemplateRepository at compile time
+var _ datastore.EmailTemplateRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) UpsertEmailTemplate(ctx context.Context, template *datastore.EmailTemplate) error {
+	if template == nil {
+		return errors.New("email template cannot be nil")
+	}
+
+	return s.repo.UpsertEmailTemplate(ctx, repo.UpsertEmailTemplateParams{
+		ID:             template.UID,
+		OrganisationID: template.OrganisationID,
+		TemplateName:   template.TemplateName,
+		Subject:        template.Subject,
+		Body:           template.Body,
+	})
+}
+
+func (s *Service) FindEmailTemplate(ctx context.Context, orgID, templateName string) (*datastore.EmailTemplate, error) {
+	row, err := s.repo.FindEmailTemplate(ctx, repo.FindEmailTemplateParams{
+		OrganisationID: orgID,
+		TemplateName:   templateName,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+
+	template := rowToEmailTemplate(repo.LoadEmailTemplatesRow(row))
+	return &template, nil
+}
+
+func (s *Service) LoadEmailTemplates(ctx context.Context, orgID string) ([]datastore.EmailTemplate, error) {
+	rows, err := s.repo.LoadEmailTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]datastore.EmailTemplate, 0, len(rows))
+	for _, row := range rows {
+		templates = append(templates, rowToEmailTemplate(row))
+	}
+
+	return templates, nil
+}
+
+func (s *Service) DeleteEmailTemplate(ctx context.Context, orgID, templateName string) error {
+	result, err := s.repo.DeleteEmailTemplate(ctx, repo.DeleteEmailTemplateParams{
+		OrganisationID: orgID,
+		TemplateName:   templateName,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrEmailTemplateNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) UpsertEmailBranding(ctx context.Context, branding *datastore.EmailBranding) error {
+	if branding == nil {
+		return errors.New("email branding cannot be nil")
+	}
+
+	return s.repo.UpsertEmailBranding(ctx, repo.UpsertEmailBrandingParams{
+		OrganisationID: branding.OrganisationID,
+		BrandColor:     branding.BrandColor,
+		LogoUrl:        branding.LogoURL,
+	})
+}
+
+func (s *Service) FindEmailBranding(ctx context.Context, orgID string) (*datastore.EmailBranding, error) {
+	row, err := s.repo.FindEmailBranding(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrEmailBrandingNotFound
+		}
+		return nil, err
+	}
+
+	return &datastore.EmailBranding{
+		OrganisationID: row.OrganisationID,
+		BrandColor:     row.BrandColor,
+		LogoURL:        row.LogoUrl,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}, nil
+}
+
+func rowToEmailTemplate(row repo.LoadEmailTemplatesRow) datastore.EmailTemplate {
+	return datastore.EmailTemplate{
+		UID:            row.ID,
+		OrganisationID: row.OrganisationID,
+		TemplateName:   row.TemplateName,
+		Subject:        row.Subject,
+		Body:           row.Body,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,44 @@
+-- Email Template Repository SQLc Queries
+-- This file contains all SQL queries for organisation email overrides and branding
+
+-- name: UpsertEmailTemplate :exec
+INSERT INTO convoy.email_templates (
+    id, organisation_id, template_name, subject, body, created_at, updated_at
+) VALUES (
+    @id, @organisation_id, @template_name, @subject, @body, NOW(), NOW()
+)
+ON CONFLICT (organisation_id, template_name) DO UPDATE SET
+    subject = EXCLUDED.subject,
+    body = EXCLUDED.body,
+    updated_at = NOW();
+
+-- name: FindEmailTemplate :one
+SELECT id, organisation_id, template_name, subject, body, created_at, updated_at
+FROM convoy.email_templates
+WHERE organisation_id = @organisation_id AND template_name = @template_name;
+
+-- name: LoadEmailTemplates :many
+SELECT id, organisation_id, template_name, subject, body, created_at, updated_at
+FROM convoy.email_templates
+WHERE organisation_id = @organisation_id
+ORDER BY template_name ASC;
+
+-- name: DeleteEmailTemplate :execresult
+DELETE FROM convoy.email_templates
+WHERE organisation_id = @organisation_id AND template_name = @template_name;
+
+-- name: UpsertEmailBranding :exec
+INSERT INTO convoy.email_branding (
+    organisation_id, brand_color, logo_url, created_at, updated_at
+) VALUES (
+    @organisation_id, @brand_color, @logo_url, NOW(), NOW()
+)
+ON CONFLICT (organisation_id) DO UPDATE SET
+    brand_color = EXCLUDED.brand_color,
+    logo_url = EXCLUDED.logo_url,
+    updated_at = NOW();
+
+-- name: FindEmailBranding :one
+SELECT organisation_id, brand_color, logo_url, created_at, updated_at
+FROM convoy.email_branding
+WHERE organisation_id = @organisation_id;
@@ -0,0 +1,124 @@
+package endpoint_health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestEndpointHealthCheck_RoundTrip(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+
+	projectID, endpointID := ulid.Make().String(), ulid.Make().String()
+
+	_, err := service.FindEndpointHealthCheck(ctx, projectID, endpointID)
+	require.ErrorIs(t, err, datastore.ErrEndpointHealthCheckNotFound)
+
+	check := &datastore.EndpointHealthCheck{
+		ProjectID:        projectID,
+		EndpointID:       endpointID,
+		Method:           "GET",
+		Path:             "/health",
+		ExpectedStatus:   204,
+		IntervalSeconds:  120,
+		TimeoutSeconds:   5,
+		FailureThreshold: 3,
+		AutoPause:        true,
+	}
+	require.NoError(t, service.UpsertEndpointHealthCheck(ctx, check))
+
+	due, err := service.FetchDueEndpointHealthChecks(ctx, time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, "/health", due[0].Path)
+	require.Nil(t, due[0].LastCheckedAt)
+
+	checkedAt := time.Now().UTC().Truncate(time.Microsecond)
+	due[0].LastStatus = datastore.EndpointUnhealthy
+	due[0].ConsecutiveFailures = 3
+	due[0].PausedByCheck = true
+	due[0].LastCheckedAt = &checkedAt
+	due[0].NextCheckAt = checkedAt.Add(2 * time.Minute)
+	require.NoError(t, service.UpdateEndpointHealthCheckState(ctx, &due[0]))
+
+	due, err = service.FetchDueEndpointHealthChecks(ctx, checkedAt.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	fetched, err := service.FindEndpointHealthCheck(ctx, projectID, endpointID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.EndpointUnhealthy, fetched.LastStatus)
+	require.Equal(t, 3, fetched.ConsecutiveFailures)
+	require.True(t, fetched.PausedByCheck)
+	require.True(t, checkedAt.Equal(*fetched.LastCheckedAt))
+
+	checks, err := service.FindEndpointHealthChecks(ctx, projectID, []string{endpointID, ulid.Make().String()})
+	require.NoError(t, err)
+	require.Len(t, checks, 1)
+	require.Equal(t, endpointID, checks[0].EndpointID)
+
+	counts, err := service.CountEndpointHealth(ctx, projectID, nil)
+	require.NoError(t, err)
+	require.Equal(t, datastore.EndpointHealthCounts{Monitored: 1, Unhealthy: 1}, *counts)
+
+	require.NoError(t, service.DeleteEndpointHealthCheck(ctx, projectID, endpointID))
+	_, err = service.FindEndpointHealthCheck(ctx, projectID, endpointID)
+	require.ErrorIs(t, err, datastore.ErrEndpointHealthCheckNotFound)
+}
+
+func TestEndpointHealthCheckResults_Summary(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+
+	projectID, endpointID := ulid.Make().String(), ulid.Make().String()
+	now := time.Now()
+
+	for i, latency := range []int64{10, 20, 30, 40} {
+		require.NoError(t, service.CreateEndpointHealthCheckResult(ctx, &datastore.EndpointHealthCheckResult{
+			ProjectID:  projectID,
+			EndpointID: endpointID,
+			Healthy:    i != 3,
+			StatusCode: 200,
+			LatencyMs:  latency,
+			CheckedAt:  now.Add(-time.Duration(i) * time.Minute),
+		}))
+	}
+
+	// outside the window
+	require.NoError(t, service.CreateEndpointHealthCheckResult(ctx, &datastore.EndpointHealthCheckResult{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+		LatencyMs:  1000,
+		CheckedAt:  now.Add(-48 * time.Hour),
+	}))
+
+	stats, err := service.SummarizeEndpointHealth(ctx, projectID, []string{endpointID}, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats.Checks)
+	require.InDelta(t, 75, *stats.UptimePercentage, 0.001)
+	require.InDelta(t, 25, stats.LatencyP50Ms, 0.001)
+
+	byEndpoint, err := service.SummarizeEndpointHealthByEndpoint(ctx, projectID, []string{endpointID, ulid.Make().String()}, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, byEndpoint, 1)
+	require.Equal(t, stats, byEndpoint[endpointID])
+
+	results, err := service.LoadEndpointHealthCheckResults(ctx, projectID, endpointID, now.Add(-time.Hour), 2)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, int64(10), results[0].LatencyMs)
+
+	require.NoError(t, service.DeleteEndpointHealthCheckResultsBefore(ctx, now.Add(-24*time.Hour)))
+	stats, err = service.SummarizeEndpointHealth(ctx, projectID, nil, now.Add(-72*time.Hour), now)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats.Checks)
+
+	empty, err := service.SummarizeEndpointHealth(ctx, ulid.Make().String(), nil, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	require.Nil(t, empty.UptimePercentage)
+}
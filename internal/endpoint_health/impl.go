@@ -0,0 +1,294 @@
+package endpoint_health
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/common"
+	"github.com/frain-dev/convoy/internal/endpoint_health/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// defaultResultLimit bounds a results read when the caller passes no limit.
+const defaultResultLimit = 100
+
+// Service implements the EndpointHealthRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.EndpointHealthRepository at compile time
+var _ datastore.EndpointHealthRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) UpsertEndpointHealthCheck(ctx context.Context, check *datastore.EndpointHealthCheck) error {
+	return s.repo.UpsertEndpointHealthCheck(ctx, repo.UpsertEndpointHealthCheckParams{
+		ProjectID:        check.ProjectID,
+		EndpointID:       check.EndpointID,
+		Method:           check.Method,
+		Path:             check.Path,
+		ExpectedStatus:   int32(check.ExpectedStatus),
+		IntervalSeconds:  int32(check.IntervalSeconds),
+		TimeoutSeconds:   int32(check.TimeoutSeconds),
+		FailureThreshold: int32(check.FailureThreshold),
+		AutoPause:        check.AutoPause,
+		AutoActivate:     check.AutoActivate,
+	})
+}
+
+func (s *Service) FindEndpointHealthCheck(ctx context.Context, projectID, endpointID string) (*datastore.EndpointHealthCheck, error) {
+	row, err := s.repo.FindEndpointHealthCheck(ctx, repo.FindEndpointHealthCheckParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrEndpointHealthCheckNotFound
+		}
+		return nil, err
+	}
+
+	return rowToHealthCheck(repo.FetchDueEndpointHealthChecksRow(row)), nil
+}
+
+func (s *Service) FindEndpointHealthChecks(ctx context.Context, projectID string, endpointIDs []string) ([]datastore.EndpointHealthCheck, error) {
+	if len(endpointIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.repo.FindEndpointHealthChecks(ctx, repo.FindEndpointHealthChecksParams{
+		ProjectID:   projectID,
+		EndpointIds: endpointIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]datastore.EndpointHealthCheck, 0, len(rows))
+	for _, row := range rows {
+		checks = append(checks, *rowToHealthCheck(repo.FetchDueEndpointHealthChecksRow(row)))
+	}
+
+	return checks, nil
+}
+
+func (s *Service) DeleteEndpointHealthCheck(ctx context.Context, projectID, endpointID string) error {
+	return s.repo.DeleteEndpointHealthCheck(ctx, repo.DeleteEndpointHealthCheckParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+	})
+}
+
+func (s *Service) FetchDueEndpointHealthChecks(ctx context.Context, now time.Time, limit int) ([]datastore.EndpointHealthCheck, error) {
+	rows, err := s.repo.FetchDueEndpointHealthChecks(ctx, repo.FetchDueEndpointHealthChecksParams{
+		Now:      common.TimeToPgTimestamptz(now),
+		LimitVal: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]datastore.EndpointHealthCheck, 0, len(rows))
+	for _, row := range rows {
+		checks = append(checks, *rowToHealthCheck(row))
+	}
+
+	return checks, nil
+}
+
+func (s *Service) UpdateEndpointHealthCheckState(ctx context.Context, check *datastore.EndpointHealthCheck) error {
+	var lastCheckedAt pgtype.Timestamptz
+	if check.LastCheckedAt != nil {
+		lastCheckedAt = common.TimeToPgTimestamptz(*check.LastCheckedAt)
+	}
+
+	return s.repo.UpdateEndpointHealthCheckState(ctx, repo.UpdateEndpointHealthCheckStateParams{
+		LastStatus:          check.LastStatus,
+		ConsecutiveFailures: int32(check.ConsecutiveFailures),
+		PausedByCheck:       check.PausedByCheck,
+		LastCheckedAt:       lastCheckedAt,
+		NextCheckAt:         common.TimeToPgTimestamptz(check.NextCheckAt),
+		ProjectID:           check.ProjectID,
+		EndpointID:          check.EndpointID,
+	})
+}
+
+func (s *Service) CreateEndpointHealthCheckResult(ctx context.Context, result *datastore.EndpointHealthCheckResult) error {
+	if result.UID == "" {
+		result.UID = ulid.Make().String()
+	}
+
+	return s.repo.CreateEndpointHealthCheckResult(ctx, repo.CreateEndpointHealthCheckResultParams{
+		ID:         result.UID,
+		ProjectID:  result.ProjectID,
+		EndpointID: result.EndpointID,
+		Healthy:    result.Healthy,
+		StatusCode: int32(result.StatusCode),
+		LatencyMs:  result.LatencyMs,
+		Error:      common.StringToPgTextNullable(result.Error),
+		CheckedAt:  common.TimeToPgTimestamptz(result.CheckedAt),
+	})
+}
+
+func (s *Service) LoadEndpointHealthCheckResults(ctx context.Context, projectID, endpointID string, since time.Time, limit int) ([]datastore.EndpointHealthCheckResult, error) {
+	if limit <= 0 {
+		limit = defaultResultLimit
+	}
+
+	rows, err := s.repo.LoadEndpointHealthCheckResults(ctx, repo.LoadEndpointHealthCheckResultsParams{
+		ProjectID:  projectID,
+		EndpointID: endpointID,
+		Since:      common.TimeToPgTimestamptz(since),
+		LimitVal:   int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]datastore.EndpointHealthCheckResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, datastore.EndpointHealthCheckResult{
+			UID:        row.ID,
+			ProjectID:  row.ProjectID,
+			EndpointID: row.EndpointID,
+			Healthy:    row.Healthy,
+			StatusCode: int(row.StatusCode),
+			LatencyMs:  row.LatencyMs,
+			Error:      common.PgTextToString(row.Error),
+			CheckedAt:  common.PgTimestamptzToTime(row.CheckedAt),
+		})
+	}
+
+	return results, nil
+}
+
+func (s *Service) DeleteEndpointHealthCheckResultsBefore(ctx context.Context, before time.Time) error {
+	return s.repo.DeleteEndpointHealthCheckResultsBefore(ctx, common.TimeToPgTimestamptz(before))
+}
+
+func (s *Service) SummarizeEndpointHealth(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) (*datastore.EndpointHealthStats, error) {
+	if endpointIDs == nil {
+		endpointIDs = []string{}
+	}
+
+	row, err := s.repo.SummarizeEndpointHealth(ctx, repo.SummarizeEndpointHealthParams{
+		ProjectID:   projectID,
+		EndpointIds: endpointIDs,
+		Since:       common.TimeToPgTimestamptz(since),
+		Until:       common.TimeToPgTimestamptz(until),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toHealthStats(row, since, until), nil
+}
+
+func (s *Service) SummarizeEndpointHealthByEndpoint(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) (map[string]*datastore.EndpointHealthStats, error) {
+	stats := map[string]*datastore.EndpointHealthStats{}
+	if len(endpointIDs) == 0 {
+		return stats, nil
+	}
+
+	rows, err := s.repo.SummarizeEndpointHealthByEndpoint(ctx, repo.SummarizeEndpointHealthByEndpointParams{
+		ProjectID:   projectID,
+		EndpointIds: endpointIDs,
+		Since:       common.TimeToPgTimestamptz(since),
+		Until:       common.TimeToPgTimestamptz(until),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stats[row.EndpointID] = toHealthStats(repo.SummarizeEndpointHealthRow{
+			Checks:        row.Checks,
+			HealthyChecks: row.HealthyChecks,
+			LatencyP50:    row.LatencyP50,
+			LatencyP95:    row.LatencyP95,
+			LatencyP99:    row.LatencyP99,
+		}, since, until)
+	}
+
+	return stats, nil
+}
+
+func (s *Service) CountEndpointHealth(ctx context.Context, projectID string, endpointIDs []string) (*datastore.EndpointHealthCounts, error) {
+	if endpointIDs == nil {
+		endpointIDs = []string{}
+	}
+
+	row, err := s.repo.CountEndpointHealth(ctx, repo.CountEndpointHealthParams{
+		ProjectID:   projectID,
+		EndpointIds: endpointIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.EndpointHealthCounts{
+		Monitored: row.Monitored,
+		Healthy:   row.Healthy,
+		Unhealthy: row.Unhealthy,
+	}, nil
+}
+
+func toHealthStats(row repo.SummarizeEndpointHealthRow, since, until time.Time) *datastore.EndpointHealthStats {
+	stats := &datastore.EndpointHealthStats{
+		Checks:       row.Checks,
+		LatencyP50Ms: row.LatencyP50,
+		LatencyP95Ms: row.LatencyP95,
+		LatencyP99Ms: row.LatencyP99,
+		Since:        since,
+		Until:        until,
+	}
+
+	if row.Checks > 0 {
+		uptime := float64(row.HealthyChecks) / float64(row.Checks) * 100
+		stats.UptimePercentage = &uptime
+	}
+
+	return stats
+}
+
+func rowToHealthCheck(row repo.FetchDueEndpointHealthChecksRow) *datastore.EndpointHealthCheck {
+	c := &datastore.EndpointHealthCheck{
+		ProjectID:           row.ProjectID,
+		EndpointID:          row.EndpointID,
+		Method:              row.Method,
+		Path:                row.Path,
+		ExpectedStatus:      int(row.ExpectedStatus),
+		IntervalSeconds:     uint64(row.IntervalSeconds),
+		TimeoutSeconds:      uint64(row.TimeoutSeconds),
+		FailureThreshold:    int(row.FailureThreshold),
+		AutoPause:           row.AutoPause,
+		AutoActivate:        row.AutoActivate,
+		LastStatus:          row.LastStatus,
+		ConsecutiveFailures: int(row.ConsecutiveFailures),
+		PausedByCheck:       row.PausedByCheck,
+		NextCheckAt:         common.PgTimestamptzToTime(row.NextCheckAt),
+		CreatedAt:           common.PgTimestamptzToTime(row.CreatedAt),
+		UpdatedAt:           common.PgTimestamptzToTime(row.UpdatedAt),
+	}
+
+	if row.LastCheckedAt.Valid {
+		t := row.LastCheckedAt.Time
+		c.LastCheckedAt = &t
+	}
+
+	return c
+}
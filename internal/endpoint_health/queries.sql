@@ -0,0 +1,110 @@
+-- name: UpsertEndpointHealthCheck :exec
+INSERT INTO convoy.endpoint_health_checks (
+    project_id, endpoint_id, method, path, expected_status, interval_seconds,
+    timeout_seconds, failure_threshold, auto_pause, auto_activate, next_check_at
+)
+VALUES (
+    @project_id, @endpoint_id, @method, @path, @expected_status, @interval_seconds,
+    @timeout_seconds, @failure_threshold, @auto_pause, @auto_activate, NOW()
+)
+ON CONFLICT (endpoint_id) DO UPDATE SET
+    project_id = EXCLUDED.project_id,
+    method = EXCLUDED.method,
+    path = EXCLUDED.path,
+    expected_status = EXCLUDED.expected_status,
+    interval_seconds = EXCLUDED.interval_seconds,
+    timeout_seconds = EXCLUDED.timeout_seconds,
+    failure_threshold = EXCLUDED.failure_threshold,
+    auto_pause = EXCLUDED.auto_pause,
+    auto_activate = EXCLUDED.auto_activate,
+    next_check_at = NOW(),
+    updated_at = NOW();
+
+-- name: FindEndpointHealthCheck :one
+SELECT project_id, endpoint_id, method, path, expected_status, interval_seconds,
+       timeout_seconds, failure_threshold, auto_pause, auto_activate, last_status,
+       consecutive_failures, paused_by_check, last_checked_at, next_check_at,
+       created_at, updated_at
+FROM convoy.endpoint_health_checks
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id;
+
+-- name: FindEndpointHealthChecks :many
+SELECT project_id, endpoint_id, method, path, expected_status, interval_seconds,
+       timeout_seconds, failure_threshold, auto_pause, auto_activate, last_status,
+       consecutive_failures, paused_by_check, last_checked_at, next_check_at,
+       created_at, updated_at
+FROM convoy.endpoint_health_checks
+WHERE project_id = @project_id AND endpoint_id = ANY(@endpoint_ids::text[]);
+
+-- name: DeleteEndpointHealthCheck :exec
+DELETE FROM convoy.endpoint_health_checks
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id;
+
+-- name: FetchDueEndpointHealthChecks :many
+SELECT project_id, endpoint_id, method, path, expected_status, interval_seconds,
+       timeout_seconds, failure_threshold, auto_pause, auto_activate, last_status,
+       consecutive_failures, paused_by_check, last_checked_at, next_check_at,
+       created_at, updated_at
+FROM convoy.endpoint_health_checks
+WHERE next_check_at <= @now
+ORDER BY next_check_at
+LIMIT @limit_val;
+
+-- name: UpdateEndpointHealthCheckState :exec
+UPDATE convoy.endpoint_health_checks SET
+    last_status = @last_status,
+    consecutive_failures = @consecutive_failures,
+    paused_by_check = @paused_by_check,
+    last_checked_at = @last_checked_at,
+    next_check_at = @next_check_at
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id;
+
+-- name: CreateEndpointHealthCheckResult :exec
+INSERT INTO convoy.endpoint_health_check_results (id, project_id, endpoint_id, healthy, status_code, latency_ms, error, checked_at)
+VALUES (@id, @project_id, @endpoint_id, @healthy, @status_code, @latency_ms, @error, @checked_at);
+
+-- name: LoadEndpointHealthCheckResults :many
+SELECT id, project_id, endpoint_id, healthy, status_code, latency_ms, error, checked_at
+FROM convoy.endpoint_health_check_results
+WHERE project_id = @project_id AND endpoint_id = @endpoint_id AND checked_at >= @since
+ORDER BY checked_at DESC
+LIMIT @limit_val;
+
+-- name: DeleteEndpointHealthCheckResultsBefore :exec
+DELETE FROM convoy.endpoint_health_check_results
+WHERE checked_at < @before;
+
+-- name: SummarizeEndpointHealth :one
+SELECT
+    COUNT(*) AS checks,
+    COUNT(*) FILTER (WHERE healthy) AS healthy_checks,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms), 0)::float8 AS latency_p50,
+    COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ms), 0)::float8 AS latency_p95,
+    COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms), 0)::float8 AS latency_p99
+FROM convoy.endpoint_health_check_results
+WHERE project_id = @project_id
+  AND (cardinality(@endpoint_ids::text[]) = 0 OR endpoint_id = ANY(@endpoint_ids::text[]))
+  AND checked_at >= @since AND checked_at <= @until;
+
+-- name: SummarizeEndpointHealthByEndpoint :many
+SELECT
+    endpoint_id,
+    COUNT(*) AS checks,
+    COUNT(*) FILTER (WHERE healthy) AS healthy_checks,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms), 0)::float8 AS latency_p50,
+    COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ms), 0)::float8 AS latency_p95,
+    COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms), 0)::float8 AS latency_p99
+FROM convoy.endpoint_health_check_results
+WHERE project_id = @project_id
+  AND endpoint_id = ANY(@endpoint_ids::text[])
+  AND checked_at >= @since AND checked_at <= @until
+GROUP BY endpoint_id;
+
+-- name: CountEndpointHealth :one
+SELECT
+    COUNT(*) AS monitored,
+    COUNT(*) FILTER (WHERE last_status = 'healthy') AS healthy,
+    COUNT(*) FILTER (WHERE last_status = 'unhealthy') AS unhealthy
+FROM convoy.endpoint_health_checks
+WHERE project_id = @project_id
+  AND (cardinality(@endpoint_ids::text[]) = 0 OR endpoint_id = ANY(@endpoint_ids::text[]));
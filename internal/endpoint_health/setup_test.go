@@ -0,0 +1,51 @@
+package endpoint_health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/postgres"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/testenv"
+)
+
+var testEnv *testenv.Environment
+
+func TestMain(m *testing.M) {
+	res, cleanup, err := testenv.Launch(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	testEnv = res
+
+	code := m.Run()
+
+	if err := cleanup(); err != nil {
+		fmt.Printf("failed to cleanup: %v\n", err)
+	}
+
+	os.Exit(code)
+}
+
+func setupTestDB(t *testing.T) (database.Database, context.Context) {
+	t.Helper()
+
+	err := config.LoadConfig("")
+	require.NoError(t, err)
+
+	conn, err := testEnv.CloneTestDatabase(t, "convoy")
+	require.NoError(t, err)
+
+	return postgres.NewFromConnection(conn), context.Background()
+}
+
+func createService(t *testing.T, db database.Database) *Service {
+	t.Helper()
+	return New(log.New("convoy", log.LevelInfo), db)
+}
@@ -45,6 +45,7 @@ type endpointFields struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 // ============================================================================
@@ -71,7 +72,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	case repo.FindEndpointsByIDsRow:
 		f = endpointFields{
@@ -86,7 +87,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	case repo.FindEndpointsByAppIDRow:
 		f = endpointFields{
@@ -101,7 +102,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	case repo.FindEndpointsByOwnerIDRow:
 		f = endpointFields{
@@ -116,7 +117,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	case repo.FindEndpointByTargetURLRow:
 		f = endpointFields{
@@ -131,7 +132,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	case repo.FetchEndpointsPagedForwardRow:
 		f = endpointFields{
@@ -146,7 +147,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	case repo.FetchEndpointsPagedBackwardRow:
 		f = endpointFields{
@@ -161,7 +162,7 @@ func rowToEndpoint(row any) (*datastore.Endpoint, error) {
 			AuthenticationTypeApiKeyHeaderValue: r.AuthenticationTypeApiKeyHeaderValue,
 			MtlsClientCert:                      r.MtlsClientCert, Oauth2Config: r.Oauth2Config,
 			BasicAuthConfig: r.BasicAuthConfig, ContentType: r.ContentType,
-			TeamsWebhookUrl: r.TeamsWebhookUrl,
+			TeamsWebhookUrl: r.TeamsWebhookUrl, NotificationChannels: r.NotificationChannels,
 		}
 	default:
 		return nil, fmt.Errorf("unsupported row type: %T", row)
@@ -212,6 +213,15 @@ func fieldsToEndpoint(f endpointFields) (*datastore.Endpoint, error) {
 		endpoint.MtlsClientCert = &mtls
 	}
 
+	// Unmarshal incident notification channels
+	if len(f.NotificationChannels) > 0 {
+		var channels datastore.NotificationChannels
+		if err := json.Unmarshal(f.NotificationChannels, &channels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification_channels: %w", err)
+		}
+		endpoint.NotificationChannels = &channels
+	}
+
 	// Build authentication
 	auth := &datastore.EndpointAuthentication{
 		Type: datastore.EndpointAuthenticationType(common.PgTextToString(f.AuthenticationType)),
@@ -320,3 +330,18 @@ func secretsToJSON(secrets datastore.Secrets) ([]byte, error) {
 
 	return data, nil
 }
+
+// notificationChannelsToJSON marshals the endpoint's incident channels, storing
+// NULL when none are configured.
+func notificationChannelsToJSON(channels *datastore.NotificationChannels) ([]byte, error) {
+	if channels.IsEmpty() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification_channels: %w", err)
+	}
+
+	return data, nil
+}
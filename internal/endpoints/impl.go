@@ -95,6 +95,11 @@ func (s *Service) CreateEndpoint(ctx context.Context, endpoint *datastore.Endpoi
 		}
 	}
 
+	notificationChannels, err := notificationChannelsToJSON(endpoint.NotificationChannels)
+	if err != nil {
+		return err
+	}
+
 	params := repo.CreateEndpointParams{
 		ID:                                  common.StringToPgTextNullable(endpoint.UID),
 		Name:                                common.StringToPgTextNullable(endpoint.Name),
@@ -121,6 +126,7 @@ func (s *Service) CreateEndpoint(ctx context.Context, endpoint *datastore.Endpoi
 		BasicAuthConfig:                     basicAuthConfig,
 		ContentType:                         common.StringToPgText(contentType),
 		TeamsWebhookUrl:                     common.StringToPgTextNullable(endpoint.TeamsWebhookURL),
+		NotificationChannels:                notificationChannels,
 	}
 
 	err = s.repo.CreateEndpoint(ctx, params)
@@ -333,7 +339,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL
     AND e.project_id = $2
@@ -377,6 +383,7 @@ ORDER BY e.id DESC`, common.StringToPgTextNullable(key), common.StringToPgTextNu
 			&f.BasicAuthConfig,
 			&f.ContentType,
 			&f.TeamsWebhookUrl,
+			&f.NotificationChannels,
 		); err != nil {
 			return nil, err
 		}
@@ -428,6 +435,11 @@ func (s *Service) UpdateEndpoint(ctx context.Context, endpoint *datastore.Endpoi
 		}
 	}
 
+	notificationChannels, err := notificationChannelsToJSON(endpoint.NotificationChannels)
+	if err != nil {
+		return err
+	}
+
 	params := repo.UpdateEndpointParams{
 		Name:                                common.StringToPgTextNullable(endpoint.Name),
 		Status:                              common.StringToPgTextNullable(string(endpoint.Status)),
@@ -450,6 +462,7 @@ func (s *Service) UpdateEndpoint(ctx context.Context, endpoint *datastore.Endpoi
 		BasicAuthConfigText:                 basicAuthConfig,
 		ContentType:                         common.StringToPgText(contentType),
 		TeamsWebhookUrl:                     common.StringToPgTextNullable(endpoint.TeamsWebhookURL),
+		NotificationChannels:                notificationChannels,
 		ID:                                  common.StringToPgTextNullable(endpoint.UID),
 		ProjectID:                           common.StringToPgTextNullable(projectID),
 	}
@@ -484,7 +497,43 @@ func (s *Service) UpdateEndpointStatus(ctx context.Context, projectID, endpointI
 		return false, err
 	}
 
-	return tag.RowsAffected() > 0, nil
+	changed := tag.RowsAffected() > 0
+	if changed {
+		if eventType, ok := endpointStatusHookEvent(status); ok {
+			go s.fireWithEndpoint(eventType, endpointID, projectID)
+		}
+	}
+
+	return changed, nil
+}
+
+// endpointStatusHookEvent names a status transition. An endpoint only goes
+// inactive when the circuit breaker or an exhausted retry limit disables it.
+func endpointStatusHookEvent(status datastore.EndpointStatus) (datastore.HookEventType, bool) {
+	switch status {
+	case datastore.PausedEndpointStatus:
+		return datastore.EndpointPaused, true
+	case datastore.ActiveEndpointStatus:
+		return datastore.EndpointActivated, true
+	case datastore.InactiveEndpointStatus:
+		return datastore.EndpointDisabled, true
+	default:
+		return "", false
+	}
+}
+
+// fireWithEndpoint fires a hook for a change made by ID, with the endpoint
+// as it is after the change.
+func (s *Service) fireWithEndpoint(eventType datastore.HookEventType, endpointID, projectID string) {
+	ctx := context.Background()
+
+	endpoint, err := s.FindEndpointByID(ctx, endpointID, projectID)
+	if err != nil {
+		s.logger.Error("failed to load endpoint for hook", "event_type", eventType, "endpoint_id", endpointID, "error", err)
+		return
+	}
+
+	s.hook.Fire(ctx, eventType, endpoint, nil)
 }
 
 // DeleteEndpoint soft-deletes an endpoint and its associated subscriptions
@@ -660,8 +709,7 @@ func (s *Service) LoadEndpointsPaged(ctx context.Context, projectID string, filt
 	return endpoints, *pagination, nil
 }
 
-// UpdateSecrets replaces all secrets on an endpoint.
-func (s *Service) UpdateSecrets(ctx context.Context, endpointID, projectID string, secrets datastore.Secrets) error {
+func (s *Service) updateSecrets(ctx context.Context, endpointID, projectID string, secrets datastore.Secrets) error {
 	key, err := s.km.GetCurrentKeyFromCache()
 	if err != nil {
 		return err
@@ -682,6 +730,18 @@ func (s *Service) UpdateSecrets(ctx context.Context, endpointID, projectID strin
 	return err
 }
 
+// UpdateSecrets replaces all secrets on an endpoint. Secret rotation is its
+// only caller, so it announces the rotation.
+func (s *Service) UpdateSecrets(ctx context.Context, endpointID, projectID string, secrets datastore.Secrets) error {
+	err := s.updateSecrets(ctx, endpointID, projectID, secrets)
+	if err != nil {
+		return err
+	}
+
+	go s.fireWithEndpoint(datastore.EndpointSecretRotated, endpointID, projectID)
+	return nil
+}
+
 // DeleteSecret marks a single secret as deleted and persists the change.
 func (s *Service) DeleteSecret(ctx context.Context, endpoint *datastore.Endpoint, secretID, projectID string) error {
 	sc := endpoint.FindSecret(secretID)
@@ -691,7 +751,13 @@ func (s *Service) DeleteSecret(ctx context.Context, endpoint *datastore.Endpoint
 
 	sc.DeletedAt = null.NewTime(time.Now(), true)
 
-	return s.UpdateSecrets(ctx, endpoint.UID, projectID, endpoint.Secrets)
+	err := s.updateSecrets(ctx, endpoint.UID, projectID, endpoint.Secrets)
+	if err != nil {
+		return err
+	}
+
+	go s.hook.Fire(context.Background(), datastore.EndpointSecretExpired, endpoint, nil)
+	return nil
 }
 
 // isEncryptionError checks whether an error is caused by a missing encryption
@@ -12,7 +12,7 @@ INSERT INTO convoy.endpoints (
     mtls_client_cert, mtls_client_cert_cipher,
     oauth2_config, oauth2_config_cipher,
     basic_auth_config, basic_auth_config_cipher,
-    content_type, teams_webhook_url
+    content_type, teams_webhook_url, notification_channels
 )
 VALUES (
     @id, @name, @status,
@@ -32,7 +32,7 @@ VALUES (
     CASE WHEN @is_encrypted::boolean THEN NULL ELSE @basic_auth_config::jsonb END,
     CASE WHEN @is_encrypted::boolean THEN pgp_sym_encrypt(@basic_auth_config::TEXT, @encryption_key) END,
     CAST(@content_type AS text)::convoy.endpoint_content_types,
-    @teams_webhook_url
+    @teams_webhook_url, @notification_channels::jsonb
 );
 
 -- name: FindEndpointByID :one
@@ -63,7 +63,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.id = @id AND e.project_id = @project_id;
 
@@ -95,7 +95,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.id = ANY(@ids::text[]) AND e.project_id = @project_id
 ORDER BY e.id;
@@ -128,7 +128,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.app_id = @app_id AND e.project_id = @project_id
 ORDER BY e.id;
@@ -161,7 +161,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.project_id = @project_id AND e.owner_id = @owner_id
 ORDER BY e.id;
@@ -200,7 +200,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.url = @url AND e.project_id = @project_id;
 
@@ -249,7 +249,8 @@ UPDATE convoy.endpoints SET
         WHEN is_encrypted THEN pgp_sym_encrypt(@basic_auth_config_text::TEXT, @encryption_key)
     END,
     updated_at = NOW(), content_type = CAST(@content_type AS text)::convoy.endpoint_content_types,
-    teams_webhook_url = @teams_webhook_url
+    teams_webhook_url = @teams_webhook_url,
+    notification_channels = @notification_channels::jsonb
 WHERE id = @id AND project_id = @project_id AND deleted_at IS NULL;
 
 -- name: UpdateEndpointStatus :execresult
@@ -321,7 +322,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL
     AND e.project_id = @project_id
@@ -361,7 +362,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, @encryption_key)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL
     AND e.project_id = @project_id
@@ -104,7 +104,7 @@ INSERT INTO convoy.endpoints (
     mtls_client_cert, mtls_client_cert_cipher,
     oauth2_config, oauth2_config_cipher,
     basic_auth_config, basic_auth_config_cipher,
-    content_type, teams_webhook_url
+    content_type, teams_webhook_url, notification_channels
 )
 VALUES (
     $1, $2, $3,
@@ -124,7 +124,7 @@ VALUES (
     CASE WHEN $4::boolean THEN NULL ELSE $23::jsonb END,
     CASE WHEN $4::boolean THEN pgp_sym_encrypt($23::TEXT, $20) END,
     CAST($24 AS text)::convoy.endpoint_content_types,
-    $25
+    $25, $26::jsonb
 )
 `
 
@@ -154,6 +154,7 @@ type CreateEndpointParams struct {
 	BasicAuthConfig                     []byte
 	ContentType                         pgtype.Text
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 // Endpoints Queries
@@ -184,6 +185,7 @@ func (q *Queries) CreateEndpoint(ctx context.Context, arg CreateEndpointParams)
 		arg.BasicAuthConfig,
 		arg.ContentType,
 		arg.TeamsWebhookUrl,
+		arg.NotificationChannels,
 	)
 	return err
 }
@@ -287,7 +289,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL
     AND e.project_id = $2
@@ -338,6 +340,7 @@ type FetchEndpointsPagedBackwardRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 // Note: Returns results in ASC order. Caller must reverse to get DESC order.
@@ -387,6 +390,7 @@ func (q *Queries) FetchEndpointsPagedBackward(ctx context.Context, arg FetchEndp
 			&i.BasicAuthConfig,
 			&i.ContentType,
 			&i.TeamsWebhookUrl,
+			&i.NotificationChannels,
 		); err != nil {
 			return nil, err
 		}
@@ -426,7 +430,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL
     AND e.project_id = $2
@@ -477,6 +481,7 @@ type FetchEndpointsPagedForwardRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 func (q *Queries) FetchEndpointsPagedForward(ctx context.Context, arg FetchEndpointsPagedForwardParams) ([]FetchEndpointsPagedForwardRow, error) {
@@ -525,6 +530,7 @@ func (q *Queries) FetchEndpointsPagedForward(ctx context.Context, arg FetchEndpo
 			&i.BasicAuthConfig,
 			&i.ContentType,
 			&i.TeamsWebhookUrl,
+			&i.NotificationChannels,
 		); err != nil {
 			return nil, err
 		}
@@ -564,7 +570,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.id = $2 AND e.project_id = $3
 `
@@ -601,6 +607,7 @@ type FindEndpointByIDRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 func (q *Queries) FindEndpointByID(ctx context.Context, arg FindEndpointByIDParams) (FindEndpointByIDRow, error) {
@@ -632,6 +639,7 @@ func (q *Queries) FindEndpointByID(ctx context.Context, arg FindEndpointByIDPara
 		&i.BasicAuthConfig,
 		&i.ContentType,
 		&i.TeamsWebhookUrl,
+		&i.NotificationChannels,
 	)
 	return i, err
 }
@@ -664,7 +672,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.url = $2 AND e.project_id = $3
 `
@@ -701,6 +709,7 @@ type FindEndpointByTargetURLRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 func (q *Queries) FindEndpointByTargetURL(ctx context.Context, arg FindEndpointByTargetURLParams) (FindEndpointByTargetURLRow, error) {
@@ -732,6 +741,7 @@ func (q *Queries) FindEndpointByTargetURL(ctx context.Context, arg FindEndpointB
 		&i.BasicAuthConfig,
 		&i.ContentType,
 		&i.TeamsWebhookUrl,
+		&i.NotificationChannels,
 	)
 	return i, err
 }
@@ -764,7 +774,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.app_id = $2 AND e.project_id = $3
 ORDER BY e.id
@@ -802,6 +812,7 @@ type FindEndpointsByAppIDRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 func (q *Queries) FindEndpointsByAppID(ctx context.Context, arg FindEndpointsByAppIDParams) ([]FindEndpointsByAppIDRow, error) {
@@ -839,6 +850,7 @@ func (q *Queries) FindEndpointsByAppID(ctx context.Context, arg FindEndpointsByA
 			&i.BasicAuthConfig,
 			&i.ContentType,
 			&i.TeamsWebhookUrl,
+			&i.NotificationChannels,
 		); err != nil {
 			return nil, err
 		}
@@ -878,7 +890,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.id = ANY($2::text[]) AND e.project_id = $3
 ORDER BY e.id
@@ -916,6 +928,7 @@ type FindEndpointsByIDsRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 func (q *Queries) FindEndpointsByIDs(ctx context.Context, arg FindEndpointsByIDsParams) ([]FindEndpointsByIDsRow, error) {
@@ -953,6 +966,7 @@ func (q *Queries) FindEndpointsByIDs(ctx context.Context, arg FindEndpointsByIDs
 			&i.BasicAuthConfig,
 			&i.ContentType,
 			&i.TeamsWebhookUrl,
+			&i.NotificationChannels,
 		); err != nil {
 			return nil, err
 		}
@@ -992,7 +1006,7 @@ SELECT
         WHEN e.is_encrypted THEN pgp_sym_decrypt(e.basic_auth_config_cipher::bytea, $1)::jsonb
         ELSE e.basic_auth_config
     END AS basic_auth_config,
-    e.content_type, e.teams_webhook_url
+    e.content_type, e.teams_webhook_url, e.notification_channels
 FROM convoy.endpoints AS e
 WHERE e.deleted_at IS NULL AND e.project_id = $2 AND e.owner_id = $3
 ORDER BY e.id
@@ -1030,6 +1044,7 @@ type FindEndpointsByOwnerIDRow struct {
 	BasicAuthConfig                     []byte
 	ContentType                         string
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 }
 
 func (q *Queries) FindEndpointsByOwnerID(ctx context.Context, arg FindEndpointsByOwnerIDParams) ([]FindEndpointsByOwnerIDRow, error) {
@@ -1067,6 +1082,7 @@ func (q *Queries) FindEndpointsByOwnerID(ctx context.Context, arg FindEndpointsB
 			&i.BasicAuthConfig,
 			&i.ContentType,
 			&i.TeamsWebhookUrl,
+			&i.NotificationChannels,
 		); err != nil {
 			return nil, err
 		}
@@ -1123,8 +1139,9 @@ UPDATE convoy.endpoints SET
         WHEN is_encrypted THEN pgp_sym_encrypt($19::TEXT, $15)
     END,
     updated_at = NOW(), content_type = CAST($20 AS text)::convoy.endpoint_content_types,
-    teams_webhook_url = $21
-WHERE id = $22 AND project_id = $23 AND deleted_at IS NULL
+    teams_webhook_url = $21,
+    notification_channels = $22::jsonb
+WHERE id = $23 AND project_id = $24 AND deleted_at IS NULL
 `
 
 type UpdateEndpointParams struct {
@@ -1149,6 +1166,7 @@ type UpdateEndpointParams struct {
 	BasicAuthConfigText                 []byte
 	ContentType                         pgtype.Text
 	TeamsWebhookUrl                     pgtype.Text
+	NotificationChannels                []byte
 	ID                                  pgtype.Text
 	ProjectID                           pgtype.Text
 }
@@ -1176,6 +1194,7 @@ func (q *Queries) UpdateEndpoint(ctx context.Context, arg UpdateEndpointParams)
 		arg.BasicAuthConfigText,
 		arg.ContentType,
 		arg.TeamsWebhookUrl,
+		arg.NotificationChannels,
 		arg.ID,
 		arg.ProjectID,
 	)
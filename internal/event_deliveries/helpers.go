@@ -168,6 +168,16 @@ func rowToEventDelivery(row interface{}) (*datastore.EventDelivery, error) {
 			CreatedAt:    r.CreatedAt, UpdatedAt: r.UpdatedAt, AcknowledgedAt: r.AcknowledgedAt,
 		}), nil
 
+	case repo.FindEventDeliveriesByEventIDsRow:
+		return buildEventDelivery(eventDeliveryFields{
+			ID: r.ID, ProjectID: r.ProjectID, EventID: r.EventID, SubscriptionID: r.SubscriptionID,
+			Headers: r.Headers, Attempts: r.Attempts, Status: r.Status, Metadata: r.Metadata,
+			CliMetadata: r.CliMetadata, TargetUrl: r.TargetUrl, UrlQueryParams: r.UrlQueryParams, IdempotencyKey: r.IdempotencyKey,
+			Description: r.Description, EventType: r.EventType, DeviceID: r.DeviceID, EndpointID: r.EndpointID,
+			DeliveryMode: r.DeliveryMode,
+			CreatedAt:    r.CreatedAt, UpdatedAt: r.UpdatedAt, AcknowledgedAt: r.AcknowledgedAt,
+		}), nil
+
 	case repo.FindDiscardedEventDeliveriesRow:
 		return buildEventDelivery(eventDeliveryFields{
 			ID: r.ID, ProjectID: r.ProjectID, EventID: r.EventID, SubscriptionID: r.SubscriptionID,
@@ -178,6 +188,16 @@ func rowToEventDelivery(row interface{}) (*datastore.EventDelivery, error) {
 			CreatedAt:    r.CreatedAt, UpdatedAt: r.UpdatedAt, AcknowledgedAt: r.AcknowledgedAt,
 		}), nil
 
+	case repo.FindScheduledEventDeliveriesBySubscriptionIDRow:
+		return buildEventDelivery(eventDeliveryFields{
+			ID: r.ID, ProjectID: r.ProjectID, EventID: r.EventID, SubscriptionID: r.SubscriptionID,
+			Headers: r.Headers, Attempts: r.Attempts, Status: r.Status, Metadata: r.Metadata,
+			CliMetadata: r.CliMetadata, TargetUrl: r.TargetUrl, UrlQueryParams: r.UrlQueryParams, IdempotencyKey: r.IdempotencyKey,
+			Description: r.Description, EventType: r.EventType, DeviceID: r.DeviceID, EndpointID: r.EndpointID,
+			DeliveryMode: r.DeliveryMode,
+			CreatedAt:    r.CreatedAt, UpdatedAt: r.UpdatedAt, AcknowledgedAt: r.AcknowledgedAt,
+		}), nil
+
 	case repo.FindStuckEventDeliveriesByStatusRow:
 		return &datastore.EventDelivery{
 			UID:       r.ID,
@@ -166,6 +166,31 @@ func (s *Service) FindEventDeliveriesByEventID(ctx context.Context, projectID, e
 	return deliveries, nil
 }
 
+func (s *Service) FindEventDeliveriesByEventIDs(ctx context.Context, projectID string, eventIDs []string) ([]datastore.EventDelivery, error) {
+	if len(eventIDs) == 0 {
+		return []datastore.EventDelivery{}, nil
+	}
+
+	params := repo.FindEventDeliveriesByEventIDsParams{
+		EventIds:  eventIDs,
+		ProjectID: common.StringToPgTextNullable(projectID),
+	}
+	rows, err := s.repo.FindEventDeliveriesByEventIDs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]datastore.EventDelivery, 0, len(rows))
+	for _, row := range rows {
+		d, err := rowToEventDelivery(row)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, nil
+}
+
 func (s *Service) CountDeliveriesByStatus(ctx context.Context, projectID string, status datastore.EventDeliveryStatus, params datastore.SearchParams) (int64, error) {
 	start, end := getCreatedDateFilter(params.CreatedAtStart, params.CreatedAtEnd)
 
@@ -203,6 +228,14 @@ func (s *Service) UpdateStatusOfEventDeliveries(ctx context.Context, projectID s
 	return s.repo.UpdateStatusOfEventDeliveries(ctx, params)
 }
 
+func (s *Service) DiscardCLIDeliveries(ctx context.Context, projectID, subscriptionID, reason string) error {
+	return s.repo.DiscardCLIDeliveries(ctx, repo.DiscardCLIDeliveriesParams{
+		Description:    common.StringToPgText(reason),
+		ProjectID:      common.StringToPgText(projectID),
+		SubscriptionID: common.StringToPgText(subscriptionID),
+	})
+}
+
 func (s *Service) FindDiscardedEventDeliveries(ctx context.Context, projectID, deviceID string, searchParams datastore.SearchParams) ([]datastore.EventDelivery, error) {
 	start, end := getCreatedDateFilter(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
 
@@ -275,6 +308,58 @@ func (s *Service) FindStuckEventDeliveriesByStatus(ctx context.Context, status d
 	return deliveries, nil
 }
 
+// ClaimScheduledEventDeliveries hands a listen session the Scheduled deliveries
+// of its CLI subscription, moving them to Processing in the same transaction so
+// a delivery is streamed once even when sessions share the subscription.
+func (s *Service) ClaimScheduledEventDeliveries(ctx context.Context, projectID, subscriptionID string, limit int) ([]datastore.EventDelivery, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := repo.New(tx)
+
+	rows, err := txRepo.FindScheduledEventDeliveriesBySubscriptionID(ctx, repo.FindScheduledEventDeliveriesBySubscriptionIDParams{
+		ProjectID:      common.StringToPgText(projectID),
+		SubscriptionID: common.StringToPgText(subscriptionID),
+		RowLimit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]datastore.EventDelivery, 0, len(rows))
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		d, err := rowToEventDelivery(row)
+		if err != nil {
+			return nil, err
+		}
+		d.Status = datastore.ProcessingEventStatus
+		deliveries = append(deliveries, *d)
+		ids = append(ids, row.ID)
+	}
+
+	if len(ids) > 0 {
+		err = txRepo.UpdateStatusOfEventDeliveries(ctx, repo.UpdateStatusOfEventDeliveriesParams{
+			Status:      common.StringToPgText(string(datastore.ProcessingEventStatus)),
+			Description: common.StringToPgText(""),
+			ProjectID:   common.StringToPgText(projectID),
+			Ids:         ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
 func (s *Service) UpdateEventDeliveryMetadata(ctx context.Context, projectID string, delivery *datastore.EventDelivery) error {
 	params := repo.UpdateEventDeliveryMetadataParams{
 		Status:         common.StringToPgText(string(delivery.Status)),
@@ -59,6 +59,26 @@ FROM updated
 WHERE created_at < DATE_TRUNC('day', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
 ON CONFLICT (day) DO NOTHING;
 
+-- Listen sessions that end or are reaped leave these behind, nothing will
+-- claim or acknowledge them, and FindStuckEventDeliveriesByStatus skips CLI
+-- deliveries.
+-- name: DiscardCLIDeliveries :exec
+WITH updated AS (
+    UPDATE convoy.event_deliveries
+    SET status = 'Discarded', description = @description, updated_at = NOW()
+    WHERE project_id = @project_id
+      AND subscription_id = @subscription_id
+      AND status IN ('Scheduled', 'Processing')
+      AND cli_metadata IS NOT NULL
+      AND deleted_at IS NULL
+    RETURNING created_at
+)
+INSERT INTO convoy.event_delivery_daily_counts_stale (day)
+SELECT DISTINCT (created_at AT TIME ZONE 'UTC')::date
+FROM updated
+WHERE created_at < DATE_TRUNC('day', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+ON CONFLICT (day) DO NOTHING;
+
 -- ============================================================================
 -- Group 2: Find Operations
 -- ============================================================================
@@ -156,6 +176,25 @@ WHERE event_id = @event_id
   AND project_id = @project_id
   AND deleted_at IS NULL;
 
+-- name: FindEventDeliveriesByEventIDs :many
+SELECT
+    id, project_id, event_id, subscription_id,
+    headers, attempts, status, metadata, cli_metadata,
+	COALESCE(target_url, '') AS target_url,
+    COALESCE(idempotency_key, '') AS idempotency_key,
+    COALESCE(url_query_params, '') AS url_query_params,
+    description, created_at, updated_at,
+    COALESCE(event_type, '') AS event_type,
+    COALESCE(device_id, '') AS device_id,
+    COALESCE(endpoint_id, '') AS endpoint_id,
+    COALESCE(delivery_mode, 'at_least_once')::TEXT AS delivery_mode,
+    acknowledged_at
+FROM convoy.event_deliveries
+WHERE event_id = ANY(@event_ids::TEXT[])
+  AND project_id = @project_id
+  AND deleted_at IS NULL
+ORDER BY event_id, id;
+
 -- name: FindDiscardedEventDeliveries :many
 SELECT
     id, project_id, event_id, subscription_id,
@@ -177,11 +216,38 @@ WHERE status = 'Discarded'
   AND created_at <= @end_date
   AND deleted_at IS NULL;
 
+-- Oldest first, and locked so two listen sessions sharing a subscription
+-- never claim the same delivery.
+-- name: FindScheduledEventDeliveriesBySubscriptionID :many
+SELECT
+    id, project_id, event_id, subscription_id,
+    headers, attempts, status, metadata, cli_metadata,
+	COALESCE(target_url, '') AS target_url,
+    COALESCE(idempotency_key, '') AS idempotency_key,
+    COALESCE(url_query_params, '') AS url_query_params,
+    description, created_at, updated_at,
+    COALESCE(event_type, '') AS event_type,
+    COALESCE(device_id, '') AS device_id,
+    COALESCE(endpoint_id, '') AS endpoint_id,
+    COALESCE(delivery_mode, 'at_least_once')::TEXT AS delivery_mode,
+    acknowledged_at
+FROM convoy.event_deliveries
+WHERE status = 'Scheduled'
+  AND project_id = @project_id
+  AND subscription_id = @subscription_id
+  AND deleted_at IS NULL
+ORDER BY created_at ASC
+LIMIT @row_limit
+FOR UPDATE SKIP LOCKED;
+
+-- CLI deliveries are left Scheduled for a convoy listen session to claim, so
+-- they are not stuck and must never be queued for HTTP dispatch.
 -- name: FindStuckEventDeliveriesByStatus :many
 SELECT id, project_id
 FROM convoy.event_deliveries
 WHERE status = @status
   AND created_at <= now() - make_interval(secs := 30)
+  AND cli_metadata IS NULL
   AND deleted_at IS NULL
 FOR UPDATE SKIP LOCKED
 LIMIT 1000;
@@ -33,12 +33,17 @@ type Querier interface {
 	// payload. NULL for deliveries of pre-migration events; usage reads COALESCE those
 	// to a join-time fallback.
 	CreateEventDelivery(ctx context.Context, arg []CreateEventDeliveryParams) *CreateEventDeliveryBatchResults
+	// Listen sessions that end or are reaped leave these behind, nothing will
+	// claim or acknowledge them, and FindStuckEventDeliveriesByStatus skips CLI
+	// deliveries.
+	DiscardCLIDeliveries(ctx context.Context, arg DiscardCLIDeliveriesParams) error
 	// ============================================================================
 	// Group 7: Export Operations
 	// ============================================================================
 	ExportEventDeliveries(ctx context.Context, arg ExportEventDeliveriesParams) ([]ExportEventDeliveriesRow, error)
 	FindDiscardedEventDeliveries(ctx context.Context, arg FindDiscardedEventDeliveriesParams) ([]FindDiscardedEventDeliveriesRow, error)
 	FindEventDeliveriesByEventID(ctx context.Context, arg FindEventDeliveriesByEventIDParams) ([]FindEventDeliveriesByEventIDRow, error)
+	FindEventDeliveriesByEventIDs(ctx context.Context, arg FindEventDeliveriesByEventIDsParams) ([]FindEventDeliveriesByEventIDsRow, error)
 	FindEventDeliveriesByIDs(ctx context.Context, arg FindEventDeliveriesByIDsParams) ([]FindEventDeliveriesByIDsRow, error)
 	// ============================================================================
 	// Group 2: Find Operations
@@ -48,6 +53,11 @@ type Querier interface {
 	// description so the worker's write-back via UpdateEventDeliveryMetadata stays
 	// faithful and does not clobber a stored description with an empty value.
 	FindEventDeliveryByIDSlim(ctx context.Context, arg FindEventDeliveryByIDSlimParams) (FindEventDeliveryByIDSlimRow, error)
+	// Oldest first, and locked so two listen sessions sharing a subscription
+	// never claim the same delivery.
+	FindScheduledEventDeliveriesBySubscriptionID(ctx context.Context, arg FindScheduledEventDeliveriesBySubscriptionIDParams) ([]FindScheduledEventDeliveriesBySubscriptionIDRow, error)
+	// CLI deliveries are left Scheduled for a convoy listen session to claim, so
+	// they are not stuck and must never be queued for HTTP dispatch.
 	FindStuckEventDeliveriesByStatus(ctx context.Context, status pgtype.Text) ([]FindStuckEventDeliveriesByStatusRow, error)
 	// Same as LoadEventDeliveriesPagedInnerDesc but inner scan uses ORDER BY created_at ASC, id ASC.
 	LoadEventDeliveriesPagedInnerAsc(ctx context.Context, arg LoadEventDeliveriesPagedInnerAscParams) ([]LoadEventDeliveriesPagedInnerAscRow, error)
@@ -229,6 +229,38 @@ func (q *Queries) CountPrevEventDeliveries(ctx context.Context, arg CountPrevEve
 	return count, err
 }
 
+const discardCLIDeliveries = `-- name: DiscardCLIDeliveries :exec
+WITH updated AS (
+    UPDATE convoy.event_deliveries
+    SET status = 'Discarded', description = $1, updated_at = NOW()
+    WHERE project_id = $2
+      AND subscription_id = $3
+      AND status IN ('Scheduled', 'Processing')
+      AND cli_metadata IS NOT NULL
+      AND deleted_at IS NULL
+    RETURNING created_at
+)
+INSERT INTO convoy.event_delivery_daily_counts_stale (day)
+SELECT DISTINCT (created_at AT TIME ZONE 'UTC')::date
+FROM updated
+WHERE created_at < DATE_TRUNC('day', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+ON CONFLICT (day) DO NOTHING
+`
+
+type DiscardCLIDeliveriesParams struct {
+	Description    pgtype.Text
+	ProjectID      pgtype.Text
+	SubscriptionID pgtype.Text
+}
+
+// Listen sessions that end or are reaped leave these behind, nothing will
+// claim or acknowledge them, and FindStuckEventDeliveriesByStatus skips CLI
+// deliveries.
+func (q *Queries) DiscardCLIDeliveries(ctx context.Context, arg DiscardCLIDeliveriesParams) error {
+	_, err := q.db.Exec(ctx, discardCLIDeliveries, arg.Description, arg.ProjectID, arg.SubscriptionID)
+	return err
+}
+
 const exportEventDeliveries = `-- name: ExportEventDeliveries :many
 
 SELECT ed.id,
@@ -490,6 +522,95 @@ func (q *Queries) FindEventDeliveriesByEventID(ctx context.Context, arg FindEven
 	return items, nil
 }
 
+const findEventDeliveriesByEventIDs = `-- name: FindEventDeliveriesByEventIDs :many
+SELECT
+    id, project_id, event_id, subscription_id,
+    headers, attempts, status, metadata, cli_metadata,
+	COALESCE(target_url, '') AS target_url,
+    COALESCE(idempotency_key, '') AS idempotency_key,
+    COALESCE(url_query_params, '') AS url_query_params,
+    description, created_at, updated_at,
+    COALESCE(event_type, '') AS event_type,
+    COALESCE(device_id, '') AS device_id,
+    COALESCE(endpoint_id, '') AS endpoint_id,
+    COALESCE(delivery_mode, 'at_least_once')::TEXT AS delivery_mode,
+    acknowledged_at
+FROM convoy.event_deliveries
+WHERE event_id = ANY($1::TEXT[])
+  AND project_id = $2
+  AND deleted_at IS NULL
+ORDER BY event_id, id
+`
+
+type FindEventDeliveriesByEventIDsParams struct {
+	EventIds  []string
+	ProjectID pgtype.Text
+}
+
+type FindEventDeliveriesByEventIDsRow struct {
+	ID             string
+	ProjectID      string
+	EventID        string
+	SubscriptionID string
+	Headers        []byte
+	Attempts       []byte
+	Status         string
+	Metadata       []byte
+	CliMetadata    []byte
+	TargetUrl      pgtype.Text
+	IdempotencyKey pgtype.Text
+	UrlQueryParams pgtype.Text
+	Description    string
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	EventType      pgtype.Text
+	DeviceID       pgtype.Text
+	EndpointID     pgtype.Text
+	DeliveryMode   pgtype.Text
+	AcknowledgedAt pgtype.Timestamptz
+}
+
+func (q *Queries) FindEventDeliveriesByEventIDs(ctx context.Context, arg FindEventDeliveriesByEventIDsParams) ([]FindEventDeliveriesByEventIDsRow, error) {
+	rows, err := q.db.Query(ctx, findEventDeliveriesByEventIDs, arg.EventIds, arg.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindEventDeliveriesByEventIDsRow
+	for rows.Next() {
+		var i FindEventDeliveriesByEventIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.EventID,
+			&i.SubscriptionID,
+			&i.Headers,
+			&i.Attempts,
+			&i.Status,
+			&i.Metadata,
+			&i.CliMetadata,
+			&i.TargetUrl,
+			&i.IdempotencyKey,
+			&i.UrlQueryParams,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EventType,
+			&i.DeviceID,
+			&i.EndpointID,
+			&i.DeliveryMode,
+			&i.AcknowledgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findEventDeliveriesByIDs = `-- name: FindEventDeliveriesByIDs :many
 SELECT
     id, project_id, event_id, subscription_id,
@@ -787,11 +908,107 @@ func (q *Queries) FindEventDeliveryByIDSlim(ctx context.Context, arg FindEventDe
 	return i, err
 }
 
+const findScheduledEventDeliveriesBySubscriptionID = `-- name: FindScheduledEventDeliveriesBySubscriptionID :many
+SELECT
+    id, project_id, event_id, subscription_id,
+    headers, attempts, status, metadata, cli_metadata,
+	COALESCE(target_url, '') AS target_url,
+    COALESCE(idempotency_key, '') AS idempotency_key,
+    COALESCE(url_query_params, '') AS url_query_params,
+    description, created_at, updated_at,
+    COALESCE(event_type, '') AS event_type,
+    COALESCE(device_id, '') AS device_id,
+    COALESCE(endpoint_id, '') AS endpoint_id,
+    COALESCE(delivery_mode, 'at_least_once')::TEXT AS delivery_mode,
+    acknowledged_at
+FROM convoy.event_deliveries
+WHERE status = 'Scheduled'
+  AND project_id = $1
+  AND subscription_id = $2
+  AND deleted_at IS NULL
+ORDER BY created_at ASC
+LIMIT $3
+FOR UPDATE SKIP LOCKED
+`
+
+type FindScheduledEventDeliveriesBySubscriptionIDParams struct {
+	ProjectID      pgtype.Text
+	SubscriptionID pgtype.Text
+	RowLimit       int32
+}
+
+type FindScheduledEventDeliveriesBySubscriptionIDRow struct {
+	ID             string
+	ProjectID      string
+	EventID        string
+	SubscriptionID string
+	Headers        []byte
+	Attempts       []byte
+	Status         string
+	Metadata       []byte
+	CliMetadata    []byte
+	TargetUrl      pgtype.Text
+	IdempotencyKey pgtype.Text
+	UrlQueryParams pgtype.Text
+	Description    string
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	EventType      pgtype.Text
+	DeviceID       pgtype.Text
+	EndpointID     pgtype.Text
+	DeliveryMode   pgtype.Text
+	AcknowledgedAt pgtype.Timestamptz
+}
+
+// Oldest first, and locked so two listen sessions sharing a subscription
+// never claim the same delivery.
+func (q *Queries) FindScheduledEventDeliveriesBySubscriptionID(ctx context.Context, arg FindScheduledEventDeliveriesBySubscriptionIDParams) ([]FindScheduledEventDeliveriesBySubscriptionIDRow, error) {
+	rows, err := q.db.Query(ctx, findScheduledEventDeliveriesBySubscriptionID, arg.ProjectID, arg.SubscriptionID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindScheduledEventDeliveriesBySubscriptionIDRow
+	for rows.Next() {
+		var i FindScheduledEventDeliveriesBySubscriptionIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.EventID,
+			&i.SubscriptionID,
+			&i.Headers,
+			&i.Attempts,
+			&i.Status,
+			&i.Metadata,
+			&i.CliMetadata,
+			&i.TargetUrl,
+			&i.IdempotencyKey,
+			&i.UrlQueryParams,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EventType,
+			&i.DeviceID,
+			&i.EndpointID,
+			&i.DeliveryMode,
+			&i.AcknowledgedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findStuckEventDeliveriesByStatus = `-- name: FindStuckEventDeliveriesByStatus :many
 SELECT id, project_id
 FROM convoy.event_deliveries
 WHERE status = $1
   AND created_at <= now() - make_interval(secs := 30)
+  AND cli_metadata IS NULL
   AND deleted_at IS NULL
 FOR UPDATE SKIP LOCKED
 LIMIT 1000
@@ -802,6 +1019,8 @@ type FindStuckEventDeliveriesByStatusRow struct {
 	ProjectID string
 }
 
+// CLI deliveries are left Scheduled for a convoy listen session to claim, so
+// they are not stuck and must never be queued for HTTP dispatch.
 func (q *Queries) FindStuckEventDeliveriesByStatus(ctx context.Context, status pgtype.Text) ([]FindStuckEventDeliveriesByStatusRow, error) {
 	rows, err := q.db.Query(ctx, findStuckEventDeliveriesByStatus, status)
 	if err != nil {
@@ -0,0 +1,138 @@
+package event_type_versions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func newVersion(projectID, eventTypeID string) *datastore.EventTypeVersion {
+	return &datastore.EventTypeVersion{
+		UID:         ulid.Make().String(),
+		ProjectID:   projectID,
+		EventTypeID: eventTypeID,
+		JSONSchema:  []byte(`{"type":"object"}`),
+	}
+}
+
+func TestEventTypeVersion_NumbersVersions(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+	eventType := seedEventType(t, db, project.UID, "invoice.paid")
+
+	v1 := newVersion(project.UID, eventType.UID)
+	require.NoError(t, service.CreateEventTypeVersion(ctx, v1))
+	require.Equal(t, 1, v1.Version)
+
+	v2 := newVersion(project.UID, eventType.UID)
+	require.NoError(t, service.CreateEventTypeVersion(ctx, v2))
+	require.Equal(t, 2, v2.Version)
+
+	v1.Downgrade = null.StringFrom("function transform(payload) { return payload }")
+	require.NoError(t, service.UpdateEventTypeVersion(ctx, v1))
+
+	found, err := service.FindEventTypeVersion(ctx, project.UID, eventType.UID, 1)
+	require.NoError(t, err)
+	require.Equal(t, "invoice.paid", found.EventType)
+	require.Equal(t, v1.Downgrade, found.Downgrade)
+	require.JSONEq(t, `{"type":"object"}`, string(found.JSONSchema))
+
+	versions, err := service.LoadEventTypeVersions(ctx, project.UID, "invoice.paid")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, 1, versions[0].Version)
+	require.Equal(t, 2, versions[1].Version)
+
+	_, err = service.FindEventTypeVersion(ctx, project.UID, eventType.UID, 3)
+	require.ErrorIs(t, err, datastore.ErrEventTypeVersionNotFound)
+}
+
+func TestEventTypeVersion_Sunsets(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+	eventType := seedEventType(t, db, project.UID, "invoice.paid")
+
+	due := newVersion(project.UID, eventType.UID)
+	due.SunsetAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	require.NoError(t, service.CreateEventTypeVersion(ctx, due))
+
+	later := newVersion(project.UID, eventType.UID)
+	later.SunsetAt = null.TimeFrom(time.Now().Add(time.Hour))
+	require.NoError(t, service.CreateEventTypeVersion(ctx, later))
+
+	versions, err := service.LoadDueEventTypeVersionSunsets(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, due.UID, versions[0].UID)
+
+	require.NoError(t, service.MarkEventTypeVersionSunsetNotified(ctx, project.UID, due.UID))
+
+	versions, err = service.LoadDueEventTypeVersionSunsets(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	// Moving the sunset date announces it again once it passes.
+	due.SunsetAt = null.TimeFrom(time.Now().Add(-time.Minute))
+	require.NoError(t, service.UpdateEventTypeVersion(ctx, due))
+
+	versions, err = service.LoadDueEventTypeVersionSunsets(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+}
+
+func TestEventTypeVersion_Pins(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+	subscription := seedSubscription(t, db, project.UID)
+
+	pinned, err := service.HasEventTypeVersionPins(ctx, project.UID)
+	require.NoError(t, err)
+	require.False(t, pinned)
+
+	pin := &datastore.EventTypeVersionPin{
+		UID:            ulid.Make().String(),
+		ProjectID:      project.UID,
+		SubscriptionID: subscription.UID,
+		EventType:      "invoice.paid",
+		Version:        1,
+	}
+	require.NoError(t, service.PinSubscriptionVersion(ctx, pin))
+	firstID := pin.UID
+
+	// pinning is seen at once by the same service
+	pinned, err = service.HasEventTypeVersionPins(ctx, project.UID)
+	require.NoError(t, err)
+	require.True(t, pinned)
+
+	moved := &datastore.EventTypeVersionPin{
+		UID:            ulid.Make().String(),
+		ProjectID:      project.UID,
+		SubscriptionID: subscription.UID,
+		EventType:      "invoice.paid",
+		Version:        2,
+	}
+	require.NoError(t, service.PinSubscriptionVersion(ctx, moved))
+	require.Equal(t, firstID, moved.UID)
+
+	pins, err := service.LoadEventTypeVersionPins(ctx, project.UID, "invoice.paid", []string{subscription.UID, ulid.Make().String()})
+	require.NoError(t, err)
+	require.Len(t, pins, 1)
+	require.Equal(t, 2, pins[0].Version)
+
+	require.NoError(t, service.UnpinSubscriptionVersion(ctx, project.UID, subscription.UID, "invoice.paid"))
+
+	pins, err = service.LoadSubscriptionVersionPins(ctx, project.UID, subscription.UID)
+	require.NoError(t, err)
+	require.Empty(t, pins)
+
+	err = service.UnpinSubscriptionVersion(ctx, project.UID, subscription.UID, "invoice.paid")
+	require.ErrorIs(t, err, datastore.ErrEventTypeVersionPinNotFound)
+}
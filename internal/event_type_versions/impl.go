@@ -0,0 +1,330 @@
+package event_type_versions
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/common"
+	"github.com/frain-dev/convoy/internal/event_type_versions/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// ErrConcurrentVersionCreate is returned when two versions of the same event
+// type are created at once and the other one took the next number.
+var ErrConcurrentVersionCreate = errors.New("another version of this event type was created at the same time, please retry")
+
+// pinnedProjectsTTL is how long HasEventTypeVersionPins trusts its answer
+// for a project. A pin made by another process takes effect after at most
+// this long.
+const pinnedProjectsTTL = 30 * time.Second
+
+// Service implements the EventTypeVersionRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+	pinned *pinnedProjects
+}
+
+// Ensure Service implements datastore.EventTypeVersionRepository at compile time
+var _ datastore.EventTypeVersionRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+		pinned: newPinnedProjects(pinnedProjectsTTL),
+	}
+}
+
+func (s *Service) CreateEventTypeVersion(ctx context.Context, version *datastore.EventTypeVersion) error {
+	if version == nil {
+		return errors.New("event type version cannot be nil")
+	}
+
+	row, err := s.repo.CreateEventTypeVersion(ctx, repo.CreateEventTypeVersionParams{
+		ID:          version.UID,
+		ProjectID:   version.ProjectID,
+		EventTypeID: version.EventTypeID,
+		JsonSchema:  version.JSONSchema,
+		Downgrade:   common.NullStringToPgText(version.Downgrade),
+		SunsetAt:    common.NullTimeToPgTimestamptz(version.SunsetAt),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return ErrConcurrentVersionCreate
+		}
+		return err
+	}
+
+	version.Version = int(row.Version)
+	version.CreatedAt = row.CreatedAt.Time
+	version.UpdatedAt = row.UpdatedAt.Time
+
+	return nil
+}
+
+func (s *Service) UpdateEventTypeVersion(ctx context.Context, version *datastore.EventTypeVersion) error {
+	if version == nil {
+		return errors.New("event type version cannot be nil")
+	}
+
+	result, err := s.repo.UpdateEventTypeVersion(ctx, repo.UpdateEventTypeVersionParams{
+		JsonSchema: version.JSONSchema,
+		Downgrade:  common.NullStringToPgText(version.Downgrade),
+		SunsetAt:   common.NullTimeToPgTimestamptz(version.SunsetAt),
+		ID:         version.UID,
+		ProjectID:  version.ProjectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrEventTypeVersionNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) FindEventTypeVersion(ctx context.Context, projectID, eventTypeID string, version int) (*datastore.EventTypeVersion, error) {
+	row, err := s.repo.FindEventTypeVersion(ctx, repo.FindEventTypeVersionParams{
+		ProjectID:   projectID,
+		EventTypeID: eventTypeID,
+		Version:     int32(version),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrEventTypeVersionNotFound
+		}
+		return nil, err
+	}
+
+	v := rowToEventTypeVersion(repo.LoadEventTypeVersionsRow(row))
+	return &v, nil
+}
+
+func (s *Service) LoadEventTypeVersions(ctx context.Context, projectID, eventType string) ([]datastore.EventTypeVersion, error) {
+	rows, err := s.repo.LoadEventTypeVersions(ctx, repo.LoadEventTypeVersionsParams{
+		ProjectID: projectID,
+		EventType: eventType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]datastore.EventTypeVersion, 0, len(rows))
+	for _, row := range rows {
+		versions = append(versions, rowToEventTypeVersion(row))
+	}
+
+	return versions, nil
+}
+
+func (s *Service) LoadDueEventTypeVersionSunsets(ctx context.Context, now time.Time, limit int) ([]datastore.EventTypeVersion, error) {
+	rows, err := s.repo.LoadDueEventTypeVersionSunsets(ctx, repo.LoadDueEventTypeVersionSunsetsParams{
+		Now:      common.TimeToPgTimestamptz(now),
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]datastore.EventTypeVersion, 0, len(rows))
+	for _, row := range rows {
+		versions = append(versions, rowToEventTypeVersion(repo.LoadEventTypeVersionsRow(row)))
+	}
+
+	return versions, nil
+}
+
+func (s *Service) MarkEventTypeVersionSunsetNotified(ctx context.Context, projectID, id string) error {
+	result, err := s.repo.MarkEventTypeVersionSunsetNotified(ctx, repo.MarkEventTypeVersionSunsetNotifiedParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrEventTypeVersionNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) PinSubscriptionVersion(ctx context.Context, pin *datastore.EventTypeVersionPin) error {
+	if pin == nil {
+		return errors.New("event type version pin cannot be nil")
+	}
+
+	row, err := s.repo.PinSubscriptionVersion(ctx, repo.PinSubscriptionVersionParams{
+		ID:             pin.UID,
+		ProjectID:      pin.ProjectID,
+		SubscriptionID: pin.SubscriptionID,
+		EventType:      pin.EventType,
+		Version:        int32(pin.Version),
+	})
+	if err != nil {
+		return err
+	}
+
+	pin.UID = row.ID
+	pin.CreatedAt = row.CreatedAt.Time
+	pin.UpdatedAt = row.UpdatedAt.Time
+	s.pinned.set(pin.ProjectID, true, time.Now())
+
+	return nil
+}
+
+func (s *Service) UnpinSubscriptionVersion(ctx context.Context, projectID, subscriptionID, eventType string) error {
+	result, err := s.repo.UnpinSubscriptionVersion(ctx, repo.UnpinSubscriptionVersionParams{
+		ProjectID:      projectID,
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrEventTypeVersionPinNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) LoadSubscriptionVersionPins(ctx context.Context, projectID, subscriptionID string) ([]datastore.EventTypeVersionPin, error) {
+	rows, err := s.repo.LoadSubscriptionVersionPins(ctx, repo.LoadSubscriptionVersionPinsParams{
+		ProjectID:      projectID,
+		SubscriptionID: subscriptionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pins := make([]datastore.EventTypeVersionPin, 0, len(rows))
+	for _, row := range rows {
+		pins = append(pins, rowToEventTypeVersionPin(row))
+	}
+
+	return pins, nil
+}
+
+func (s *Service) LoadEventTypeVersionPins(ctx context.Context, projectID, eventType string, subscriptionIDs []string) ([]datastore.EventTypeVersionPin, error) {
+	if len(subscriptionIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.repo.LoadEventTypeVersionPins(ctx, repo.LoadEventTypeVersionPinsParams{
+		ProjectID:       projectID,
+		EventType:       eventType,
+		SubscriptionIds: subscriptionIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pins := make([]datastore.EventTypeVersionPin, 0, len(rows))
+	for _, row := range rows {
+		pins = append(pins, rowToEventTypeVersionPin(repo.LoadSubscriptionVersionPinsRow(row)))
+	}
+
+	return pins, nil
+}
+
+func (s *Service) HasEventTypeVersionPins(ctx context.Context, projectID string) (bool, error) {
+	now := time.Now()
+	if pinned, ok := s.pinned.get(projectID, now); ok {
+		return pinned, nil
+	}
+
+	pinned, err := s.repo.HasEventTypeVersionPins(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+
+	s.pinned.set(projectID, pinned, now)
+	return pinned, nil
+}
+
+func rowToEventTypeVersion(row repo.LoadEventTypeVersionsRow) datastore.EventTypeVersion {
+	return datastore.EventTypeVersion{
+		UID:              row.ID,
+		ProjectID:        row.ProjectID,
+		EventTypeID:      row.EventTypeID,
+		EventType:        row.EventType,
+		Version:          int(row.Version),
+		JSONSchema:       row.JsonSchema,
+		Downgrade:        common.PgTextToNullString(row.Downgrade),
+		SunsetAt:         common.PgTimestamptzToNullTime(row.SunsetAt),
+		SunsetNotifiedAt: common.PgTimestamptzToNullTime(row.SunsetNotifiedAt),
+		CreatedAt:        row.CreatedAt.Time,
+		UpdatedAt:        row.UpdatedAt.Time,
+	}
+}
+
+func rowToEventTypeVersionPin(row repo.LoadSubscriptionVersionPinsRow) datastore.EventTypeVersionPin {
+	return datastore.EventTypeVersionPin{
+		UID:            row.ID,
+		ProjectID:      row.ProjectID,
+		SubscriptionID: row.SubscriptionID,
+		EventType:      row.EventType,
+		Version:        int(row.Version),
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
+
+// pinnedProjects remembers which projects have version pins, so the
+// delivery path does not ask for every event.
+type pinnedProjects struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]pinnedProject
+}
+
+type pinnedProject struct {
+	pinned    bool
+	expiresAt time.Time
+}
+
+func newPinnedProjects(ttl time.Duration) *pinnedProjects {
+	return &pinnedProjects{ttl: ttl, entries: map[string]pinnedProject{}}
+}
+
+func (p *pinnedProjects) get(projectID string, now time.Time) (bool, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[projectID]
+	if !ok || now.After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.pinned, true
+}
+
+func (p *pinnedProjects) set(projectID string, pinned bool, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Expired entries go once the map grows, so projects that stop
+	// sending events do not stay in it.
+	if len(p.entries) >= 1024 {
+		for id, entry := range p.entries {
+			if now.After(entry.expiresAt) {
+				delete(p.entries, id)
+			}
+		}
+	}
+
+	p.entries[projectID] = pinnedProject{pinned: pinned, expiresAt: now.Add(p.ttl)}
+}
@@ -0,0 +1,85 @@
+-- Event Type Version Repository SQLc Queries
+-- This file contains all SQL queries for event type versions and the
+-- subscription pins that select them
+
+-- name: CreateEventTypeVersion :one
+INSERT INTO convoy.event_type_versions (
+    id, project_id, event_type_id, version, json_schema, downgrade, sunset_at, created_at, updated_at
+)
+SELECT @id::VARCHAR, @project_id::VARCHAR, @event_type_id::VARCHAR, COALESCE(MAX(v.version), 0) + 1,
+       @json_schema::JSONB, sqlc.narg('downgrade')::TEXT, sqlc.narg('sunset_at')::TIMESTAMPTZ, NOW(), NOW()
+FROM convoy.event_type_versions v
+WHERE v.event_type_id = @event_type_id::VARCHAR
+RETURNING version, created_at, updated_at;
+
+-- name: UpdateEventTypeVersion :execresult
+-- Moving the sunset date re-arms its meta event.
+UPDATE convoy.event_type_versions SET
+    json_schema = @json_schema,
+    downgrade = @downgrade,
+    sunset_notified_at = CASE WHEN sunset_at IS DISTINCT FROM @sunset_at THEN NULL ELSE sunset_notified_at END,
+    sunset_at = @sunset_at,
+    updated_at = NOW()
+WHERE id = @id AND project_id = @project_id;
+
+-- name: FindEventTypeVersion :one
+SELECT v.id, v.project_id, v.event_type_id, et.name AS event_type, v.version, v.json_schema,
+       v.downgrade, v.sunset_at, v.sunset_notified_at, v.created_at, v.updated_at
+FROM convoy.event_type_versions v
+JOIN convoy.event_types et ON et.id = v.event_type_id
+WHERE v.project_id = @project_id AND v.event_type_id = @event_type_id AND v.version = @version;
+
+-- name: LoadEventTypeVersions :many
+SELECT v.id, v.project_id, v.event_type_id, et.name AS event_type, v.version, v.json_schema,
+       v.downgrade, v.sunset_at, v.sunset_notified_at, v.created_at, v.updated_at
+FROM convoy.event_type_versions v
+JOIN convoy.event_types et ON et.id = v.event_type_id
+WHERE v.project_id = @project_id AND et.name = @event_type
+ORDER BY v.version ASC;
+
+-- name: LoadDueEventTypeVersionSunsets :many
+SELECT v.id, v.project_id, v.event_type_id, et.name AS event_type, v.version, v.json_schema,
+       v.downgrade, v.sunset_at, v.sunset_notified_at, v.created_at, v.updated_at
+FROM convoy.event_type_versions v
+JOIN convoy.event_types et ON et.id = v.event_type_id
+WHERE v.sunset_at IS NOT NULL AND v.sunset_at <= @now AND v.sunset_notified_at IS NULL
+ORDER BY v.sunset_at ASC
+LIMIT @row_limit;
+
+-- name: MarkEventTypeVersionSunsetNotified :execresult
+UPDATE convoy.event_type_versions SET
+    sunset_notified_at = NOW()
+WHERE id = @id AND project_id = @project_id;
+
+-- name: PinSubscriptionVersion :one
+INSERT INTO convoy.subscription_event_type_versions (
+    id, project_id, subscription_id, event_type, version, created_at, updated_at
+) VALUES (
+    @id, @project_id, @subscription_id, @event_type, @version, NOW(), NOW()
+)
+ON CONFLICT (subscription_id, event_type) DO UPDATE SET
+    version = EXCLUDED.version,
+    updated_at = NOW()
+RETURNING id, created_at, updated_at;
+
+-- name: UnpinSubscriptionVersion :execresult
+DELETE FROM convoy.subscription_event_type_versions
+WHERE project_id = @project_id AND subscription_id = @subscription_id AND event_type = @event_type;
+
+-- name: LoadSubscriptionVersionPins :many
+SELECT id, project_id, subscription_id, event_type, version, created_at, updated_at
+FROM convoy.subscription_event_type_versions
+WHERE project_id = @project_id AND subscription_id = @subscription_id
+ORDER BY event_type ASC;
+
+-- name: LoadEventTypeVersionPins :many
+SELECT id, project_id, subscription_id, event_type, version, created_at, updated_at
+FROM convoy.subscription_event_type_versions
+WHERE project_id = @project_id AND event_type = @event_type
+  AND subscription_id = ANY(@subscription_ids::VARCHAR[]);
+
+-- name: HasEventTypeVersionPins :one
+SELECT EXISTS (
+    SELECT 1 FROM convoy.subscription_event_type_versions
+    WHERE project_id = @project_id
+) AS has_pins;
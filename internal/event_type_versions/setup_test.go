@@ -0,0 +1,142 @@
+package event_type_versions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/endpoints"
+	"github.com/frain-dev/convoy/internal/event_types"
+	"github.com/frain-dev/convoy/internal/organisations"
+	"github.com/frain-dev/convoy/internal/projects"
+	"github.com/frain-dev/convoy/internal/subscriptions"
+	"github.com/frain-dev/convoy/internal/users"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/testenv"
+)
+
+var testEnv *testenv.Environment
+
+func TestMain(m *testing.M) {
+	res, cleanup, err := testenv.Launch(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	testEnv = res
+
+	code := m.Run()
+
+	if err := cleanup(); err != nil {
+		fmt.Printf("failed to cleanup: %v\n", err)
+	}
+
+	os.Exit(code)
+}
+
+func setupTestDB(t *testing.T) (database.Database, context.Context) {
+	t.Helper()
+
+	err := config.LoadConfig("")
+	require.NoError(t, err)
+
+	conn, err := testEnv.CloneTestDatabase(t, "convoy")
+	require.NoError(t, err)
+
+	return postgres.NewFromConnection(conn), context.Background()
+}
+
+func createService(t *testing.T, db database.Database) *Service {
+	t.Helper()
+	return New(log.New("convoy", log.LevelInfo), db)
+}
+
+func seedProject(t *testing.T, db database.Database) *datastore.Project {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.New("convoy", log.LevelInfo)
+
+	user := &datastore.User{
+		UID:       ulid.Make().String(),
+		FirstName: "Test",
+		LastName:  "User",
+		Email:     fmt.Sprintf("test-%s@example.com", ulid.Make().String()),
+	}
+	require.NoError(t, users.New(logger, db).CreateUser(ctx, user))
+
+	org := &datastore.Organisation{
+		UID:     ulid.Make().String(),
+		Name:    "Test Org",
+		OwnerID: user.UID,
+	}
+	require.NoError(t, organisations.New(logger, db).CreateOrganisation(ctx, org))
+
+	projectConfig := datastore.DefaultProjectConfig
+	project := &datastore.Project{
+		UID:            ulid.Make().String(),
+		Name:           "Test Project",
+		Type:           datastore.OutgoingProject,
+		OrganisationID: org.UID,
+		Config:         &projectConfig,
+	}
+	require.NoError(t, projects.New(logger, db).CreateProject(ctx, project))
+
+	return project
+}
+
+func seedEventType(t *testing.T, db database.Database, projectID, name string) *datastore.ProjectEventType {
+	t.Helper()
+
+	eventType := &datastore.ProjectEventType{
+		UID:        ulid.Make().String(),
+		ProjectId:  projectID,
+		Name:       name,
+		JSONSchema: []byte(`{}`),
+	}
+	require.NoError(t, event_types.New(log.New("convoy", log.LevelInfo), db).CreateEventType(context.Background(), eventType))
+
+	return eventType
+}
+
+func seedSubscription(t *testing.T, db database.Database, projectID string) *datastore.Subscription {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.New("convoy", log.LevelInfo)
+
+	endpoint := &datastore.Endpoint{
+		UID:       ulid.Make().String(),
+		ProjectID: projectID,
+		Name:      "Test Endpoint",
+		Status:    datastore.ActiveEndpointStatus,
+		Url:       "https://example.com/webhook",
+		Secrets:   []datastore.Secret{{Value: "test-secret"}},
+	}
+	require.NoError(t, endpoints.New(logger, db).CreateEndpoint(ctx, endpoint, projectID))
+
+	subscription := &datastore.Subscription{
+		UID:        ulid.Make().String(),
+		ProjectID:  projectID,
+		Name:       "Test Subscription",
+		Type:       datastore.SubscriptionTypeAPI,
+		EndpointID: endpoint.UID,
+		FilterConfig: &datastore.FilterConfiguration{
+			EventTypes: []string{"*"},
+			Filter: datastore.FilterSchema{
+				Headers: datastore.M{},
+				Body:    datastore.M{},
+			},
+		},
+	}
+	require.NoError(t, subscriptions.New(logger, db).CreateSubscription(ctx, projectID, subscription))
+
+	return subscription
+}
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/common"
 	"github.com/frain-dev/convoy/internal/event_types/repo"
@@ -28,6 +29,7 @@ type Service struct {
 	logger log.Logger
 	repo   repo.Querier
 	db     *pgxpool.Pool
+	hook   *hooks.Hook
 }
 
 // Ensure Service implements datastore.EventTypesRepository at compile time
@@ -38,6 +40,7 @@ func New(logger log.Logger, db database.Database) *Service {
 		logger: logger,
 		repo:   repo.New(db.GetConn()),
 		db:     db.GetConn(),
+		hook:   db.GetHook(),
 	}
 }
 
@@ -111,6 +114,7 @@ func (s *Service) CreateEventType(ctx context.Context, eventType *datastore.Proj
 		return util.NewServiceError(500, err)
 	}
 
+	go s.hook.Fire(context.Background(), datastore.EventTypeCreated, eventType, nil)
 	return nil
 }
 
@@ -152,6 +156,7 @@ func (s *Service) UpdateEventType(ctx context.Context, eventType *datastore.Proj
 		return util.NewServiceError(404, ErrEventTypeNotUpdated)
 	}
 
+	go s.hook.Fire(context.Background(), datastore.EventTypeUpdated, eventType, nil)
 	return nil
 }
 
@@ -169,6 +174,7 @@ func (s *Service) DeprecateEventType(ctx context.Context, id, projectId string)
 	}
 
 	eventType := rowToEventType(row)
+	go s.hook.Fire(context.Background(), datastore.EventTypeDeprecated, &eventType, nil)
 	return &eventType, nil
 }
 
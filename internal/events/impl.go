@@ -125,6 +125,7 @@ func (s *Service) CreateEvent(ctx context.Context, event *datastore.Event) error
 		// columns instead of re-scanning raw/data.
 		RawBytes:  pgtype.Int8{Int64: int64(len(event.Raw)), Valid: true},
 		DataBytes: pgtype.Int8{Int64: int64(len(event.Data)), Valid: true},
+		IsTest:    event.IsTest,
 	}
 
 	// Insert event
@@ -728,7 +729,8 @@ BEGIN
         metadata           TEXT,
         failure_reason     TEXT,
         raw_bytes          BIGINT,
-        data_bytes         BIGINT
+        data_bytes         BIGINT,
+        is_test            BOOLEAN NOT NULL DEFAULT FALSE
     );
 
     ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS url_path VARCHAR NOT NULL DEFAULT '';
@@ -737,11 +739,11 @@ BEGIN
     INSERT INTO convoy.events_new (
         id, event_type, endpoints, project_id, source_id, headers, raw, data,
         created_at, updated_at, deleted_at, url_query_params, url_path, idempotency_key,
-        is_duplicate_event, acknowledged_at, status, metadata, failure_reason, raw_bytes, data_bytes
+        is_duplicate_event, acknowledged_at, status, metadata, failure_reason, raw_bytes, data_bytes, is_test
     )
     SELECT id, event_type, endpoints, project_id, source_id, headers, raw, data,
            created_at, updated_at, deleted_at, url_query_params, COALESCE(url_path, ''), idempotency_key,
-           is_duplicate_event, acknowledged_at, status, metadata, failure_reason, raw_bytes, data_bytes
+           is_duplicate_event, acknowledged_at, status, metadata, failure_reason, raw_bytes, data_bytes, is_test
     FROM convoy.events;
 
     -- Drop the inbound FK so events_old can go. Do not add a real FK onto
@@ -11,11 +11,11 @@
 INSERT INTO convoy.events (id, event_type, endpoints, project_id, source_id,
                            headers, raw, data, url_query_params, url_path, idempotency_key,
                            is_duplicate_event, acknowledged_at, metadata, status,
-                           raw_bytes, data_bytes)
+                           raw_bytes, data_bytes, is_test)
 VALUES (@id, @event_type, @endpoints, @project_id, @source_id,
         @headers, @raw, @data, @url_query_params, @url_path, @idempotency_key,
         @is_duplicate_event, @acknowledged_at, @metadata, @status,
-        @raw_bytes, @data_bytes);
+        @raw_bytes, @data_bytes, @is_test);
 
 -- name: CreateEventEndpoint :batchexec
 INSERT INTO convoy.events_endpoints (event_id, endpoint_id)
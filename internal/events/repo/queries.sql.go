@@ -286,11 +286,11 @@ const createEvent = `-- name: CreateEvent :exec
 INSERT INTO convoy.events (id, event_type, endpoints, project_id, source_id,
                            headers, raw, data, url_query_params, url_path, idempotency_key,
                            is_duplicate_event, acknowledged_at, metadata, status,
-                           raw_bytes, data_bytes)
+                           raw_bytes, data_bytes, is_test)
 VALUES ($1, $2, $3, $4, $5,
         $6, $7, $8, $9, $10, $11,
         $12, $13, $14, $15,
-        $16, $17)
+        $16, $17, $18)
 `
 
 type CreateEventParams struct {
@@ -311,6 +311,7 @@ type CreateEventParams struct {
 	Status           pgtype.Text
 	RawBytes         pgtype.Int8
 	DataBytes        pgtype.Int8
+	IsTest           bool
 }
 
 // Events Repository SQL Queries
@@ -339,6 +340,7 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error
 		arg.Status,
 		arg.RawBytes,
 		arg.DataBytes,
+		arg.IsTest,
 	)
 	return err
 }
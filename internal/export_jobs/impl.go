@@ -0,0 +1,144 @@
+package export_jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/common"
+	"github.com/frain-dev/convoy/internal/export_jobs/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// defaultLoadLimit bounds a jobs list when the caller passes no limit.
+const defaultLoadLimit = 50
+
+// Service implements the ExportJobRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.ExportJobRepository at compile time
+var _ datastore.ExportJobRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) CreateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	if job == nil {
+		return errors.New("export job cannot be nil")
+	}
+
+	filter, err := json.Marshal(job.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export job filter: %w", err)
+	}
+
+	return s.repo.CreateExportJob(ctx, repo.CreateExportJobParams{
+		ID:            job.UID,
+		ProjectID:     job.ProjectID,
+		SavedSearchID: job.SavedSearchID,
+		Resource:      string(job.Resource),
+		Format:        string(job.Format),
+		Filter:        filter,
+		Status:        string(job.Status),
+	})
+}
+
+func (s *Service) UpdateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	if job == nil {
+		return errors.New("export job cannot be nil")
+	}
+
+	result, err := s.repo.UpdateExportJob(ctx, repo.UpdateExportJobParams{
+		Status:      string(job.Status),
+		RowCount:    job.RowCount,
+		ObjectKey:   job.ObjectKey,
+		Error:       common.StringToPgTextNullable(job.Error),
+		CompletedAt: common.NullTimeToPgTimestamptz(job.CompletedAt),
+		ID:          job.UID,
+		ProjectID:   job.ProjectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrExportJobNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) FindExportJobByID(ctx context.Context, projectID, id string) (*datastore.ExportJob, error) {
+	row, err := s.repo.FindExportJobByID(ctx, repo.FindExportJobByIDParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrExportJobNotFound
+		}
+		return nil, err
+	}
+
+	return rowToExportJob(repo.LoadExportJobsRow(row))
+}
+
+func (s *Service) LoadExportJobs(ctx context.Context, projectID string, limit int) ([]datastore.ExportJob, error) {
+	if limit <= 0 {
+		limit = defaultLoadLimit
+	}
+
+	rows, err := s.repo.LoadExportJobs(ctx, repo.LoadExportJobsParams{
+		ProjectID: projectID,
+		LimitVal:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]datastore.ExportJob, 0, len(rows))
+	for _, row := range rows {
+		job, err := rowToExportJob(row)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, nil
+}
+
+func rowToExportJob(row repo.LoadExportJobsRow) (*datastore.ExportJob, error) {
+	var filter datastore.SearchFilter
+	if err := json.Unmarshal(row.Filter, &filter); err != nil {
+		return nil, fmt.Errorf("failed to parse export job filter: %w", err)
+	}
+
+	return &datastore.ExportJob{
+		UID:           row.ID,
+		ProjectID:     row.ProjectID,
+		SavedSearchID: row.SavedSearchID,
+		Resource:      datastore.SearchResource(row.Resource),
+		Format:        datastore.ExportFormat(row.Format),
+		Filter:        filter,
+		Status:        datastore.ExportJobStatus(row.Status),
+		RowCount:      row.RowCount,
+		ObjectKey:     row.ObjectKey,
+		Error:         row.Error.String,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+		CompletedAt:   common.PgTimestamptzToNullTime(row.CompletedAt),
+	}, nil
+}
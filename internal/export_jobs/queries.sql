@@ -0,0 +1,37 @@
+-- Export Job Repository SQLc Queries
+-- This file contains all SQL queries for export job operations
+
+-- name: CreateExportJob :exec
+INSERT INTO convoy.export_jobs (
+    id, project_id, saved_search_id, resource, format, filter, status,
+    created_at, updated_at
+) VALUES (
+    @id, @project_id, @saved_search_id, @resource, @format, @filter, @status,
+    NOW(), NOW()
+);
+
+-- name: UpdateExportJob :execresult
+UPDATE convoy.export_jobs SET
+    status = @status,
+    row_count = @row_count,
+    object_key = @object_key,
+    error = @error,
+    completed_at = @completed_at,
+    updated_at = NOW()
+WHERE id = @id AND project_id = @project_id;
+
+-- name: FindExportJobByID :one
+SELECT
+    id, project_id, saved_search_id, resource, format, filter, status,
+    row_count, object_key, error, created_at, updated_at, completed_at
+FROM convoy.export_jobs
+WHERE id = @id AND project_id = @project_id;
+
+-- name: LoadExportJobs :many
+SELECT
+    id, project_id, saved_search_id, resource, format, filter, status,
+    row_count, object_key, error, created_at, updated_at, completed_at
+FROM convoy.export_jobs
+WHERE project_id = @project_id
+ORDER BY created_at DESC
+LIMIT @limit_val;
@@ -0,0 +1,50 @@
+package filter_rejections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestFilterRejections(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	eventID := ulid.Make().String()
+	rejections := []datastore.FilterRejection{
+		{ProjectID: project.UID, EventID: eventID, SubscriptionID: "sub-1", EventType: "payment.created", Reason: "body: amount $gte"},
+		{ProjectID: project.UID, EventID: eventID, SubscriptionID: "sub-2", EventType: "payment.created", Reason: "expression: did not match"},
+	}
+	require.NoError(t, service.CreateFilterRejections(ctx, rejections))
+
+	// a retried match records nothing twice
+	retry := []datastore.FilterRejection{{ProjectID: project.UID, EventID: eventID, SubscriptionID: "sub-1", Reason: "body: amount $gte"}}
+	require.NoError(t, service.CreateFilterRejections(ctx, retry))
+
+	loaded, err := service.LoadFilterRejectionsByEventID(ctx, project.UID, eventID)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	require.Equal(t, "payment.created", loaded[0].EventType)
+	require.ElementsMatch(t, []string{"sub-1", "sub-2"}, []string{loaded[0].SubscriptionID, loaded[1].SubscriptionID})
+
+	other, err := service.LoadFilterRejectionsByEventID(ctx, ulid.Make().String(), eventID)
+	require.NoError(t, err)
+	require.Empty(t, other)
+
+	n, err := service.DeleteFilterRejectionsBefore(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	n, err = service.DeleteFilterRejectionsBefore(ctx, time.Now().Add(time.Hour), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	loaded, err = service.LoadFilterRejectionsByEventID(ctx, project.UID, eventID)
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+}
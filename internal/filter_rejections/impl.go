@@ -0,0 +1,94 @@
+package filter_rejections
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/common"
+	"github.com/frain-dev/convoy/internal/filter_rejections/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// Service implements the FilterRejectionRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.FilterRejectionRepository at compile time
+var _ datastore.FilterRejectionRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) CreateFilterRejections(ctx context.Context, rejections []datastore.FilterRejection) error {
+	if len(rejections) == 0 {
+		return nil
+	}
+
+	params := repo.CreateFilterRejectionsParams{
+		Ids:             make([]string, len(rejections)),
+		ProjectIds:      make([]string, len(rejections)),
+		EventIds:        make([]string, len(rejections)),
+		SubscriptionIds: make([]string, len(rejections)),
+		EventTypes:      make([]string, len(rejections)),
+		Reasons:         make([]string, len(rejections)),
+	}
+
+	for i := range rejections {
+		if rejections[i].UID == "" {
+			rejections[i].UID = ulid.Make().String()
+		}
+		params.Ids[i] = rejections[i].UID
+		params.ProjectIds[i] = rejections[i].ProjectID
+		params.EventIds[i] = rejections[i].EventID
+		params.SubscriptionIds[i] = rejections[i].SubscriptionID
+		params.EventTypes[i] = rejections[i].EventType
+		params.Reasons[i] = rejections[i].Reason
+	}
+
+	return s.repo.CreateFilterRejections(ctx, params)
+}
+
+func (s *Service) LoadFilterRejectionsByEventID(ctx context.Context, projectID, eventID string) ([]datastore.FilterRejection, error) {
+	rows, err := s.repo.LoadFilterRejectionsByEventID(ctx, repo.LoadFilterRejectionsByEventIDParams{
+		ProjectID: projectID,
+		EventID:   eventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rejections := make([]datastore.FilterRejection, 0, len(rows))
+	for _, row := range rows {
+		rejections = append(rejections, datastore.FilterRejection{
+			UID:            row.ID,
+			ProjectID:      row.ProjectID,
+			EventID:        row.EventID,
+			SubscriptionID: row.SubscriptionID,
+			EventType:      row.EventType,
+			Reason:         row.Reason,
+			CreatedAt:      common.PgTimestamptzToTime(row.CreatedAt),
+		})
+	}
+
+	return rejections, nil
+}
+
+// DeleteFilterRejectionsBefore deletes up to limit rejections recorded before
+// the cutoff and reports how many it removed.
+func (s *Service) DeleteFilterRejectionsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return s.repo.DeleteFilterRejectionsBefore(ctx, repo.DeleteFilterRejectionsBeforeParams{
+		Before:   pgtype.Timestamptz{Time: before, Valid: true},
+		LimitVal: int32(limit),
+	})
+}
@@ -0,0 +1,22 @@
+-- name: CreateFilterRejections :exec
+INSERT INTO convoy.event_filter_rejections (id, project_id, event_id, subscription_id, event_type, reason)
+SELECT r.id, r.project_id, r.event_id, r.subscription_id, r.event_type, r.reason
+FROM unnest(
+    @ids::text[], @project_ids::text[], @event_ids::text[],
+    @subscription_ids::text[], @event_types::text[], @reasons::text[]
+) AS r(id, project_id, event_id, subscription_id, event_type, reason)
+ON CONFLICT (event_id, subscription_id) DO NOTHING;
+
+-- name: LoadFilterRejectionsByEventID :many
+SELECT id, project_id, event_id, subscription_id, event_type, reason, created_at
+FROM convoy.event_filter_rejections
+WHERE project_id = @project_id AND event_id = @event_id
+ORDER BY created_at ASC, id ASC;
+
+-- name: DeleteFilterRejectionsBefore :execrows
+DELETE FROM convoy.event_filter_rejections
+WHERE id IN (
+    SELECT id FROM convoy.event_filter_rejections
+    WHERE created_at < @before
+    LIMIT @limit_val
+);
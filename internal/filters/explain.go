@@ -0,0 +1,152 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/common"
+	"github.com/frain-dev/convoy/pkg/celfilter"
+	"github.com/frain-dev/convoy/pkg/compare"
+	"github.com/frain-dev/convoy/pkg/flatten"
+)
+
+// ExplainFilter reports which of a subscription's filters applies to
+// eventType and how each of its conditions evaluated the request. Filter
+// selection follows event matching in the worker: an enabled filter for the
+// event type, then an enabled "*" filter, otherwise the subscription does not
+// receive the event.
+func (s *Service) ExplainFilter(ctx context.Context, subscriptionID, eventType string, payload any) (*datastore.FilterExplanation, error) {
+	filter, hasInactiveFilter, err := s.findFilterForEventType(ctx, subscriptionID, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		e := &datastore.FilterExplanation{
+			SubscriptionID: subscriptionID,
+			Selection:      datastore.FilterSelectionNone,
+			Reason:         fmt.Sprintf("no filter for %s or *", eventType),
+		}
+
+		if hasInactiveFilter {
+			e.Selection = datastore.FilterSelectionDisabled
+			e.Reason = fmt.Sprintf("filters for %s are disabled", eventType)
+		}
+
+		return e, nil
+	}
+
+	e := Explain(normalizeFilterTestRequest(payload), filter)
+	e.SubscriptionID = subscriptionID
+	e.Selection = datastore.FilterSelectionCatchAll
+	if filter.EventType == eventType {
+		e.Selection = datastore.FilterSelectionExact
+	}
+
+	return e, nil
+}
+
+// Explain evaluates a stored filter against req the way matchStoredFilter
+// does, recording the outcome of every scope and condition.
+func Explain(req datastore.FilterTestRequest, filter *datastore.EventTypeFilter) *datastore.FilterExplanation {
+	e := &datastore.FilterExplanation{Filter: filter}
+
+	if !filter.HasConditions() {
+		e.Matched = true
+		return e
+	}
+
+	if filter.Expression != "" {
+		matched, err := celfilter.Match(filter.Expression, req.ExpressionInput())
+		switch {
+		case err != nil:
+			e.ExpressionError = err.Error()
+			e.Reason = "expression: does not compile"
+		case !matched:
+			e.Reason = "expression: did not match"
+		}
+		e.Matched = matched
+		return e
+	}
+
+	e.Body = explainStoredFilterBody(req.Body, filter.Body)
+	e.Headers = explainStoredFilterScope(req.Headers, filter.Headers, "headers")
+	e.Query = explainStoredFilterScope(req.Query, filter.Query, "query")
+	e.Path = explainStoredFilterScope(req.Path, filter.Path, "path")
+
+	e.Matched = true
+	for _, scope := range []struct {
+		name string
+		e    *compare.Explanation
+	}{
+		{"body", e.Body},
+		{"headers", e.Headers},
+		{"query", e.Query},
+		{"path", e.Path},
+	} {
+		if scope.e == nil || scope.e.Matched {
+			continue
+		}
+
+		e.Matched = false
+		e.Reason = scopeReason(scope.name, scope.e)
+		break
+	}
+
+	return e
+}
+
+func explainStoredFilterBody(payload any, filter datastore.M) *compare.Explanation {
+	if len(filter) == 0 {
+		return nil
+	}
+
+	if payload == nil {
+		return &compare.Explanation{Error: "the request has no body"}
+	}
+
+	p, err := flatten.Flatten(payload)
+	if err != nil {
+		return &compare.Explanation{Error: err.Error()}
+	}
+
+	return compare.Explain(p, filter)
+}
+
+func explainStoredFilterScope(payload, filter datastore.M, scope string) *compare.Explanation {
+	if len(filter) == 0 {
+		return nil
+	}
+
+	if datastore.HasArrayWildcardSelector(filter) {
+		return &compare.Explanation{Error: fmt.Sprintf("array wildcard selectors are not supported in %s filters", scope)}
+	}
+
+	if len(payload) == 0 {
+		return &compare.Explanation{Error: fmt.Sprintf("the request has no %s", scope)}
+	}
+
+	flatPayload, err := common.FlattenM(payload)
+	if err != nil {
+		return &compare.Explanation{Error: err.Error()}
+	}
+
+	return compare.Explain(flatPayload, filter)
+}
+
+// scopeReason names the condition that failed in a scope. Values and errors
+// are left out, since either can echo request content.
+func scopeReason(scope string, e *compare.Explanation) string {
+	c := e.FirstFailure()
+	switch {
+	case c == nil && e.Error != "":
+		return fmt.Sprintf("%s: could not be evaluated", scope)
+	case c == nil:
+		return fmt.Sprintf("%s: none of the filtered fields are present", scope)
+	case c.Field == "":
+		return fmt.Sprintf("%s: %s", scope, c.Operator)
+	default:
+		return fmt.Sprintf("%s: %s %s", scope, c.Field, c.Operator)
+	}
+}
@@ -0,0 +1,120 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// TestExplainFilter_ReportsFailingCondition tests that the condition rejecting a payload is named
+func TestExplainFilter_ReportsFailingCondition(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	project, subscription := seedTestData(t, db)
+	seedEventType(t, db, project.UID, "invoice.paid")
+
+	service := createFilterService(t, db)
+
+	filter := &datastore.EventTypeFilter{
+		SubscriptionID: subscription.UID,
+		EventType:      "invoice.paid",
+		Headers:        datastore.M{},
+		Body: datastore.M{
+			"amount":   map[string]any{"$gte": 100},
+			"currency": "usd",
+		},
+		RawHeaders: datastore.M{},
+		RawBody: datastore.M{
+			"amount":   map[string]any{"$gte": 100},
+			"currency": "usd",
+		},
+	}
+	err := service.CreateFilter(ctx, filter)
+	require.NoError(t, err)
+
+	e, err := service.ExplainFilter(ctx, subscription.UID, "invoice.paid", map[string]any{
+		"amount":   50,
+		"currency": "usd",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, subscription.UID, e.SubscriptionID)
+	require.Equal(t, datastore.FilterSelectionExact, e.Selection)
+	require.Equal(t, filter.UID, e.Filter.UID)
+	require.False(t, e.Matched)
+	require.Equal(t, "body: amount $gte", e.Reason)
+	require.NotNil(t, e.Body)
+	require.Len(t, e.Body.Clauses, 2)
+	require.Nil(t, e.Headers)
+}
+
+// TestExplainFilter_CatchAll tests that the catch-all filter is reported as selected
+func TestExplainFilter_CatchAll(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	project, subscription := seedTestData(t, db)
+	seedEventType(t, db, project.UID, "*")
+
+	service := createFilterService(t, db)
+
+	filter := &datastore.EventTypeFilter{
+		SubscriptionID: subscription.UID,
+		EventType:      "*",
+		Headers:        datastore.M{},
+		Body:           datastore.M{"priority": "high"},
+		RawHeaders:     datastore.M{},
+		RawBody:        datastore.M{"priority": "high"},
+	}
+	err := service.CreateFilter(ctx, filter)
+	require.NoError(t, err)
+
+	e, err := service.ExplainFilter(ctx, subscription.UID, "any.event.type", map[string]any{"priority": "high"})
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.FilterSelectionCatchAll, e.Selection)
+	require.True(t, e.Matched)
+	require.Empty(t, e.Reason)
+}
+
+// TestExplainFilter_NoFilter tests that a subscription without a filter for the event type is reported as not receiving it
+func TestExplainFilter_NoFilter(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	_, subscription := seedTestData(t, db)
+
+	service := createFilterService(t, db)
+
+	e, err := service.ExplainFilter(ctx, subscription.UID, "non.existent", map[string]any{"event": "test"})
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.FilterSelectionNone, e.Selection)
+	require.False(t, e.Matched)
+	require.Nil(t, e.Filter)
+}
+
+func TestExplain_Scopes(t *testing.T) {
+	filter := &datastore.EventTypeFilter{
+		Body:    datastore.M{"type": "order.created"},
+		Headers: datastore.M{"X-Tenant": "a"},
+	}
+
+	e := Explain(datastore.FilterTestRequest{
+		Body:    map[string]any{"type": "order.created"},
+		Headers: datastore.M{"X-Tenant": "b"},
+	}, filter)
+
+	require.False(t, e.Matched)
+	require.True(t, e.Body.Matched)
+	require.False(t, e.Headers.Matched)
+	require.Equal(t, "headers: X-Tenant $eq", e.Reason)
+}
+
+func TestExplain_Expression(t *testing.T) {
+	e := Explain(datastore.FilterTestRequest{Body: map[string]any{"amount": 10}}, &datastore.EventTypeFilter{Expression: "body.amount > 100"})
+	require.False(t, e.Matched)
+	require.Equal(t, "expression: did not match", e.Reason)
+	require.Empty(t, e.ExpressionError)
+
+	e = Explain(datastore.FilterTestRequest{}, &datastore.EventTypeFilter{Expression: "body.amount >"})
+	require.False(t, e.Matched)
+	require.NotEmpty(t, e.ExpressionError)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/common"
 	"github.com/frain-dev/convoy/internal/filters/repo"
+	"github.com/frain-dev/convoy/pkg/celfilter"
 	"github.com/frain-dev/convoy/pkg/compare"
 	"github.com/frain-dev/convoy/pkg/flatten"
 	log "github.com/frain-dev/convoy/pkg/logger"
@@ -44,7 +45,7 @@ func New(logger log.Logger, db database.Database) *Service {
 // rowToEventTypeFilter converts SQLc-generated row types to datastore.EventTypeFilter
 func rowToEventTypeFilter(row interface{}) (*datastore.EventTypeFilter, error) {
 	var (
-		id, subscriptionID, eventType                                      string
+		id, subscriptionID, eventType, expression                          string
 		headers, body, query, path, rawHeaders, rawBody, rawQuery, rawPath []byte
 		enabledAt, createdAt, updatedAt                                    pgtype.Timestamptz
 	)
@@ -55,18 +56,21 @@ func rowToEventTypeFilter(row interface{}) (*datastore.EventTypeFilter, error) {
 		enabledAt = r.EnabledAt
 		headers, body, query, path = r.Headers, r.Body, r.Query, r.Path
 		rawHeaders, rawBody, rawQuery, rawPath = r.RawHeaders, r.RawBody, r.RawQuery, r.RawPath
+		expression = r.Expression
 		createdAt, updatedAt = r.CreatedAt, r.UpdatedAt
 	case repo.FindFiltersBySubscriptionIDRow:
 		id, subscriptionID, eventType = r.ID, r.SubscriptionID, r.EventType
 		enabledAt = r.EnabledAt
 		headers, body, query, path = r.Headers, r.Body, r.Query, r.Path
 		rawHeaders, rawBody, rawQuery, rawPath = r.RawHeaders, r.RawBody, r.RawQuery, r.RawPath
+		expression = r.Expression
 		createdAt, updatedAt = r.CreatedAt, r.UpdatedAt
 	case repo.FindFilterBySubscriptionAndEventTypeRow:
 		id, subscriptionID, eventType = r.ID, r.SubscriptionID, r.EventType
 		enabledAt = r.EnabledAt
 		headers, body, query, path = r.Headers, r.Body, r.Query, r.Path
 		rawHeaders, rawBody, rawQuery, rawPath = r.RawHeaders, r.RawBody, r.RawQuery, r.RawPath
+		expression = r.Expression
 		createdAt, updatedAt = r.CreatedAt, r.UpdatedAt
 	default:
 		return nil, fmt.Errorf("unsupported row type: %T", row)
@@ -131,6 +135,7 @@ func rowToEventTypeFilter(row interface{}) (*datastore.EventTypeFilter, error) {
 		RawBody:        rawBodyMap,
 		RawQuery:       rawQueryMap,
 		RawPath:        rawPathMap,
+		Expression:     expression,
 		CreatedAt:      createdAt.Time,
 		UpdatedAt:      updatedAt.Time,
 	}, nil
@@ -142,6 +147,10 @@ type preparedFilterMaps struct {
 }
 
 func prepareFilterMaps(filter *datastore.EventTypeFilter) (*preparedFilterMaps, error) {
+	if err := filter.ValidateExpression(); err != nil {
+		return nil, err
+	}
+
 	flatBody, err := common.FlattenM(filter.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to flatten body filter: %w", err)
@@ -293,6 +302,7 @@ func (s *Service) CreateFilter(ctx context.Context, filter *datastore.EventTypeF
 		RawBody:        maps.rawBody,
 		RawQuery:       maps.rawQuery,
 		RawPath:        maps.rawPath,
+		Expression:     filter.Expression,
 		CreatedAt:      pgtype.Timestamptz{Time: filter.CreatedAt, Valid: true},
 		UpdatedAt:      pgtype.Timestamptz{Time: filter.UpdatedAt, Valid: true},
 	})
@@ -359,6 +369,7 @@ func (s *Service) CreateFilters(ctx context.Context, filters []datastore.EventTy
 			RawBody:        maps.rawBody,
 			RawQuery:       maps.rawQuery,
 			RawPath:        maps.rawPath,
+			Expression:     filter.Expression,
 			CreatedAt:      pgtype.Timestamptz{Time: filter.CreatedAt, Valid: true},
 			UpdatedAt:      pgtype.Timestamptz{Time: filter.UpdatedAt, Valid: true},
 		})
@@ -402,6 +413,7 @@ func (s *Service) UpdateFilter(ctx context.Context, filter *datastore.EventTypeF
 		RawBody:    maps.rawBody,
 		RawQuery:   maps.rawQuery,
 		RawPath:    maps.rawPath,
+		Expression: filter.Expression,
 		EventType:  common.StringToPgText(filter.EventType),
 		UpdatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 	})
@@ -458,6 +470,7 @@ func (s *Service) UpdateFilters(ctx context.Context, filters []datastore.EventTy
 			RawBody:    maps.rawBody,
 			RawQuery:   maps.rawQuery,
 			RawPath:    maps.rawPath,
+			Expression: filter.Expression,
 			EventType:  common.StringToPgText(filter.EventType),
 			UpdatedAt:  pgtype.Timestamptz{Time: filter.UpdatedAt, Valid: true},
 		})
@@ -575,7 +588,7 @@ func (s *Service) TestFilter(ctx context.Context, subscriptionID, eventType stri
 	}
 
 	// Empty filter means it matches everything
-	if len(filter.Body) == 0 && len(filter.Headers) == 0 && len(filter.Query) == 0 && len(filter.Path) == 0 {
+	if !filter.HasConditions() {
 		return true, nil
 	}
 
@@ -636,6 +649,10 @@ func normalizeFilterTestRequest(payload any) datastore.FilterTestRequest {
 }
 
 func matchStoredFilter(req datastore.FilterTestRequest, filter *datastore.EventTypeFilter) (bool, error) {
+	if filter.Expression != "" {
+		return celfilter.Match(filter.Expression, req.ExpressionInput())
+	}
+
 	isBodyMatched, err := compareStoredFilterBody(req.Body, filter.Body)
 	if err != nil || !isBodyMatched {
 		return isBodyMatched, err
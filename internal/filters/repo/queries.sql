@@ -7,10 +7,11 @@ INSERT INTO convoy.filters (
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 )
 VALUES (@id, @subscription_id, @event_type, @enabled_at, @headers, @body, @query, @path,
-        @raw_headers, @raw_body, @raw_query, @raw_path, @created_at, @updated_at);
+        @raw_headers, @raw_body, @raw_query, @raw_path, @expression, @created_at, @updated_at);
 
 -- name: FindFilterByID :one
 SELECT
@@ -18,6 +19,7 @@ SELECT
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 FROM convoy.filters
 WHERE id = @id;
@@ -28,6 +30,7 @@ SELECT
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 FROM convoy.filters
 WHERE subscription_id = @subscription_id
@@ -39,6 +42,7 @@ SELECT
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 FROM convoy.filters
 WHERE subscription_id = @subscription_id AND event_type = @event_type;
@@ -55,6 +59,7 @@ SET
     raw_body = @raw_body,
     raw_query = @raw_query,
     raw_path = @raw_path,
+    expression = @expression,
     event_type = @event_type,
     updated_at = @updated_at
 WHERE id = @id;
@@ -18,10 +18,11 @@ INSERT INTO convoy.filters (
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 )
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
-        $9, $10, $11, $12, $13, $14)
+        $9, $10, $11, $12, $13, $14, $15)
 `
 
 type CreateFilterParams struct {
@@ -37,6 +38,7 @@ type CreateFilterParams struct {
 	RawBody        []byte
 	RawQuery       []byte
 	RawPath        []byte
+	Expression     string
 	CreatedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
 }
@@ -57,6 +59,7 @@ func (q *Queries) CreateFilter(ctx context.Context, arg CreateFilterParams) erro
 		arg.RawBody,
 		arg.RawQuery,
 		arg.RawPath,
+		arg.Expression,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -82,6 +85,7 @@ SELECT
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 FROM convoy.filters
 WHERE id = $1
@@ -100,6 +104,7 @@ type FindFilterByIDRow struct {
 	RawBody        []byte
 	RawQuery       []byte
 	RawPath        []byte
+	Expression     string
 	CreatedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
 }
@@ -120,6 +125,7 @@ func (q *Queries) FindFilterByID(ctx context.Context, id pgtype.Text) (FindFilte
 		&i.RawBody,
 		&i.RawQuery,
 		&i.RawPath,
+		&i.Expression,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -132,6 +138,7 @@ SELECT
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 FROM convoy.filters
 WHERE subscription_id = $1 AND event_type = $2
@@ -155,6 +162,7 @@ type FindFilterBySubscriptionAndEventTypeRow struct {
 	RawBody        []byte
 	RawQuery       []byte
 	RawPath        []byte
+	Expression     string
 	CreatedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
 }
@@ -175,6 +183,7 @@ func (q *Queries) FindFilterBySubscriptionAndEventType(ctx context.Context, arg
 		&i.RawBody,
 		&i.RawQuery,
 		&i.RawPath,
+		&i.Expression,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -187,6 +196,7 @@ SELECT
     enabled_at,
     headers, body, query, path,
     raw_headers, raw_body, raw_query, raw_path,
+    expression,
     created_at, updated_at
 FROM convoy.filters
 WHERE subscription_id = $1
@@ -206,6 +216,7 @@ type FindFiltersBySubscriptionIDRow struct {
 	RawBody        []byte
 	RawQuery       []byte
 	RawPath        []byte
+	Expression     string
 	CreatedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
 }
@@ -232,6 +243,7 @@ func (q *Queries) FindFiltersBySubscriptionID(ctx context.Context, subscriptionI
 			&i.RawBody,
 			&i.RawQuery,
 			&i.RawPath,
+			&i.Expression,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -257,9 +269,10 @@ SET
     raw_body = $7,
     raw_query = $8,
     raw_path = $9,
-    event_type = $10,
-    updated_at = $11
-WHERE id = $12
+    expression = $10,
+    event_type = $11,
+    updated_at = $12
+WHERE id = $13
 `
 
 type UpdateFilterParams struct {
@@ -272,6 +285,7 @@ type UpdateFilterParams struct {
 	RawBody    []byte
 	RawQuery   []byte
 	RawPath    []byte
+	Expression string
 	EventType  pgtype.Text
 	UpdatedAt  pgtype.Timestamptz
 	ID         pgtype.Text
@@ -288,6 +302,7 @@ func (q *Queries) UpdateFilter(ctx context.Context, arg UpdateFilterParams) (int
 		arg.RawBody,
 		arg.RawQuery,
 		arg.RawPath,
+		arg.Expression,
 		arg.EventType,
 		arg.UpdatedAt,
 		arg.ID,
@@ -0,0 +1,153 @@
+package notifications
+
+import "unicode/utf8"
+
+const (
+	// WebhookAlertTriggered and WebhookAlertResolved are the event names the
+	// generic webhook channel sends.
+	WebhookAlertTriggered = "alert.triggered"
+	WebhookAlertResolved  = "alert.resolved"
+
+	// incidentSource names Convoy as the sender on PagerDuty and Opsgenie.
+	incidentSource = "convoy"
+
+	// pagerDutySeverity is the severity every PagerDuty trigger is raised with.
+	// Convoy alerts all mean deliveries are at risk, so there is no finer scale
+	// to map onto PagerDuty's.
+	pagerDutySeverity = "error"
+
+	// The provider field limits. PagerDuty rejects a summary over 1024
+	// characters; Opsgenie truncates silently, which would cut the alias that
+	// later closes the alert, so every field is trimmed here instead.
+	maxPagerDutySummaryBytes    = 1024
+	maxOpsgenieMessageBytes     = 130
+	maxOpsgenieAliasBytes       = 512
+	maxOpsgenieDescriptionBytes = 15000
+)
+
+// WebhookNotification is a queued alert for the generic webhook channel.
+type WebhookNotification struct {
+	URL    string `json:"url,omitempty"`
+	Secret string `json:"secret,omitempty"`
+
+	Alert WebhookAlert `json:"alert"`
+}
+
+// WebhookAlert is the JSON body the generic webhook channel receives.
+type WebhookAlert struct {
+	// Event is alert.triggered or alert.resolved.
+	Event string `json:"event"`
+
+	// DedupKey is shared by a trigger and its resolution.
+	DedupKey string `json:"dedup_key"`
+
+	Title string `json:"title"`
+	Text  string `json:"text"`
+
+	// SentAt is when the alert was raised, in RFC 3339.
+	SentAt string `json:"sent_at"`
+}
+
+// PagerDutyNotification is a queued PagerDuty Events API v2 event.
+type PagerDutyNotification struct {
+	RoutingKey string `json:"routing_key,omitempty"`
+	DedupKey   string `json:"dedup_key,omitempty"`
+	Resolved   bool   `json:"resolved,omitempty"`
+	Summary    string `json:"summary,omitempty"`
+}
+
+// OpsgenieNotification is a queued Opsgenie alert creation or closure.
+type OpsgenieNotification struct {
+	APIKey      string `json:"api_key,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Alias       string `json:"alias,omitempty"`
+	Resolved    bool   `json:"resolved,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PagerDutyEvent is the Events API v2 request body.
+type PagerDutyEvent struct {
+	RoutingKey  string                 `json:"routing_key"`
+	EventAction string                 `json:"event_action"`
+	DedupKey    string                 `json:"dedup_key"`
+	Payload     *PagerDutyEventPayload `json:"payload,omitempty"`
+}
+
+type PagerDutyEventPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
+
+// BuildPagerDutyEvent turns a queued notification into a trigger, or a resolve
+// for the same dedup key. A resolve carries no payload; PagerDuty only needs
+// the key to find the incident.
+func BuildPagerDutyEvent(n PagerDutyNotification) PagerDutyEvent {
+	if n.Resolved {
+		return PagerDutyEvent{
+			RoutingKey:  n.RoutingKey,
+			EventAction: "resolve",
+			DedupKey:    n.DedupKey,
+		}
+	}
+
+	return PagerDutyEvent{
+		RoutingKey:  n.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    n.DedupKey,
+		Payload: &PagerDutyEventPayload{
+			Summary:  truncateIncidentText(n.Summary, maxPagerDutySummaryBytes),
+			Source:   incidentSource,
+			Severity: pagerDutySeverity,
+		},
+	}
+}
+
+// OpsgenieAlert is the Opsgenie create-alert request body.
+type OpsgenieAlert struct {
+	Message     string `json:"message"`
+	Alias       string `json:"alias"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source"`
+}
+
+// OpsgenieCloseAlert is the Opsgenie close-alert request body.
+type OpsgenieCloseAlert struct {
+	Source string `json:"source"`
+}
+
+// BuildOpsgenieCloseAlert is the close-alert body; the alias goes in the URL.
+func BuildOpsgenieCloseAlert() OpsgenieCloseAlert {
+	return OpsgenieCloseAlert{Source: incidentSource}
+}
+
+// BuildOpsgenieAlert turns a queued notification into a create-alert body.
+func BuildOpsgenieAlert(n OpsgenieNotification) OpsgenieAlert {
+	return OpsgenieAlert{
+		Message:     truncateIncidentText(n.Message, maxOpsgenieMessageBytes),
+		Alias:       OpsgenieAlias(n.Alias),
+		Description: truncateIncidentText(n.Description, maxOpsgenieDescriptionBytes),
+		Source:      incidentSource,
+	}
+}
+
+// OpsgenieAlias trims a dedup key to Opsgenie's alias limit. Creation and
+// closure both go through it, so they always address the same alert.
+func OpsgenieAlias(dedupKey string) string {
+	return truncateIncidentText(dedupKey, maxOpsgenieAliasBytes)
+}
+
+// truncateIncidentText trims text to limit bytes, cutting on a rune boundary.
+func truncateIncidentText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+
+	keep := limit
+	for keep > 0 && !utf8.RuneStart(text[keep]) {
+		keep--
+	}
+
+	return text[:keep]
+}
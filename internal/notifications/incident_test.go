@@ -0,0 +1,58 @@
+package notifications
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildPagerDutyEvent pins the Events API v2 bodies. A resolve must carry
+// the trigger's dedup key and no payload.
+func TestBuildPagerDutyEvent(t *testing.T) {
+	trigger, err := json.Marshal(BuildPagerDutyEvent(PagerDutyNotification{
+		RoutingKey: "R0UT1NGKEY",
+		DedupKey:   "convoy:endpoint:ep-1:status",
+		Summary:    "endpoint disabled",
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"routing_key": "R0UT1NGKEY",
+		"event_action": "trigger",
+		"dedup_key": "convoy:endpoint:ep-1:status",
+		"payload": {"summary": "endpoint disabled", "source": "convoy", "severity": "error"}
+	}`, string(trigger))
+
+	resolve, err := json.Marshal(BuildPagerDutyEvent(PagerDutyNotification{
+		RoutingKey: "R0UT1NGKEY",
+		DedupKey:   "convoy:endpoint:ep-1:status",
+		Resolved:   true,
+		Summary:    "endpoint reactivated",
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"routing_key": "R0UT1NGKEY",
+		"event_action": "resolve",
+		"dedup_key": "convoy:endpoint:ep-1:status"
+	}`, string(resolve))
+}
+
+func TestBuildIncidentBodies_TruncateToProviderLimits(t *testing.T) {
+	long := strings.Repeat("é", 20000)
+
+	event := BuildPagerDutyEvent(PagerDutyNotification{Summary: long})
+	require.LessOrEqual(t, len(event.Payload.Summary), maxPagerDutySummaryBytes)
+	require.True(t, utf8.ValidString(event.Payload.Summary))
+
+	alert := BuildOpsgenieAlert(OpsgenieNotification{Message: long, Alias: long, Description: long})
+	require.LessOrEqual(t, len(alert.Message), maxOpsgenieMessageBytes)
+	require.LessOrEqual(t, len(alert.Alias), maxOpsgenieAliasBytes)
+	require.LessOrEqual(t, len(alert.Description), maxOpsgenieDescriptionBytes)
+	require.True(t, utf8.ValidString(alert.Message))
+	require.True(t, utf8.ValidString(alert.Description))
+
+	// The close request addresses the alert by the same trimmed alias.
+	require.Equal(t, alert.Alias, OpsgenieAlias(long))
+}
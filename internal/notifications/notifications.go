@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 
@@ -22,6 +23,10 @@ const (
 	SlackNotificationType NotificationType = "slack"
 	EmailNotificationType NotificationType = "email"
 	TeamsNotificationType NotificationType = "teams"
+
+	WebhookNotificationType   NotificationType = "webhook"
+	PagerDutyNotificationType NotificationType = "pagerduty"
+	OpsgenieNotificationType  NotificationType = "opsgenie"
 )
 
 // failureRateValue returns the failure rate or 0 when it was not computed (nil).
@@ -60,6 +65,10 @@ type EndpointAlert struct {
 	// EmailRecipient is the address for the email channel. Empty skips email.
 	EmailRecipient string
 
+	// OrganisationID selects the organisation's email template overrides and
+	// branding for the email channel.
+	OrganisationID string
+
 	// SlackWebhookURL is the target for the Slack channel. Empty skips Slack.
 	SlackWebhookURL string
 
@@ -67,15 +76,34 @@ type EndpointAlert struct {
 	// Teams.
 	TeamsWebhookURL string
 
-	// EmailSubject is the subject line of the email channel message.
+	// Channels are the incident channels: generic webhook, PagerDuty and
+	// Opsgenie. Nil skips all three.
+	Channels *datastore.NotificationChannels
+
+	// DedupKey names the incident on the incident channels. A trigger and the
+	// alert that later resolves it must share the key, which is how PagerDuty
+	// and Opsgenie find the incident to close.
+	DedupKey string
+
+	// Resolved sends the alert as the resolution of DedupKey rather than as a
+	// new trigger.
+	Resolved bool
+
+	// EmailSubject is the subject line of the email channel message. It also
+	// titles the incident on the incident channels.
 	EmailSubject string
 
-	// EmailParams fills the endpoint update email template.
+	// EmailTemplate is the template the email channel renders. Empty uses the
+	// endpoint update template.
+	EmailTemplate email.TemplateName
+
+	// EmailParams fills the email template.
 	EmailParams map[string]string
 
 	// AlertText is the message body for every webhook channel: the Slack
-	// attachment text and the Teams card TextBlock are rendered from this one
-	// string, so the two channels cannot drift in wording.
+	// attachment text, the Teams card TextBlock and the incident descriptions
+	// are rendered from this one string, so the channels cannot drift in
+	// wording.
 	AlertText string
 }
 
@@ -87,15 +115,20 @@ type EndpointAlert struct {
 func DispatchEndpointAlert(ctx context.Context, q queue.Queuer, logger log.Logger, alert EndpointAlert) bool {
 	var enqueued bool
 
-	// Every endpoint alert trigger renders the same endpoint update template.
 	if !util.IsStringEmpty(alert.EmailRecipient) {
+		templateName := alert.EmailTemplate
+		if templateName == "" {
+			templateName = email.TemplateEndpointUpdate
+		}
+
 		enqueued = enqueueNotification(ctx, q, logger, &Notification{
 			NotificationType: EmailNotificationType,
 			Payload: email.Message{
-				Email:        alert.EmailRecipient,
-				Subject:      alert.EmailSubject,
-				TemplateName: email.TemplateEndpointUpdate,
-				Params:       alert.EmailParams,
+				Email:          alert.EmailRecipient,
+				Subject:        alert.EmailSubject,
+				TemplateName:   templateName,
+				Params:         alert.EmailParams,
+				OrganisationID: alert.OrganisationID,
 			},
 		}) || enqueued
 	}
@@ -120,9 +153,76 @@ func DispatchEndpointAlert(ctx context.Context, q queue.Queuer, logger log.Logge
 		}) || enqueued
 	}
 
+	if alert.Channels != nil {
+		enqueued = dispatchIncidentAlert(ctx, q, logger, alert) || enqueued
+	}
+
+	return enqueued
+}
+
+// dispatchIncidentAlert enqueues the incident channel jobs, under the same
+// per-channel independence as DispatchEndpointAlert.
+func dispatchIncidentAlert(ctx context.Context, q queue.Queuer, logger log.Logger, alert EndpointAlert) bool {
+	var enqueued bool
+	channels := alert.Channels
+
+	if channels.Webhook != nil && !util.IsStringEmpty(channels.Webhook.URL) {
+		event := WebhookAlertTriggered
+		if alert.Resolved {
+			event = WebhookAlertResolved
+		}
+
+		enqueued = enqueueNotification(ctx, q, logger, &Notification{
+			NotificationType: WebhookNotificationType,
+			Payload: WebhookNotification{
+				URL:    channels.Webhook.URL,
+				Secret: channels.Webhook.Secret,
+				Alert: WebhookAlert{
+					Event:    event,
+					DedupKey: alert.DedupKey,
+					Title:    alert.EmailSubject,
+					Text:     alert.AlertText,
+					SentAt:   time.Now().UTC().Format(time.RFC3339),
+				},
+			},
+		}) || enqueued
+	}
+
+	if channels.PagerDuty != nil && !util.IsStringEmpty(channels.PagerDuty.RoutingKey) {
+		enqueued = enqueueNotification(ctx, q, logger, &Notification{
+			NotificationType: PagerDutyNotificationType,
+			Payload: PagerDutyNotification{
+				RoutingKey: channels.PagerDuty.RoutingKey,
+				DedupKey:   alert.DedupKey,
+				Resolved:   alert.Resolved,
+				Summary:    alert.AlertText,
+			},
+		}) || enqueued
+	}
+
+	if channels.Opsgenie != nil && !util.IsStringEmpty(channels.Opsgenie.APIKey) {
+		enqueued = enqueueNotification(ctx, q, logger, &Notification{
+			NotificationType: OpsgenieNotificationType,
+			Payload: OpsgenieNotification{
+				APIKey:      channels.Opsgenie.APIKey,
+				Region:      channels.Opsgenie.Region,
+				Alias:       alert.DedupKey,
+				Resolved:    alert.Resolved,
+				Message:     alert.EmailSubject,
+				Description: alert.AlertText,
+			},
+		}) || enqueued
+	}
+
 	return enqueued
 }
 
+// EndpointStatusDedupKey names the incident for an endpoint being disabled, so
+// reactivating it resolves the incident the disable opened.
+func EndpointStatusDedupKey(endpointID string) string {
+	return "convoy:endpoint:" + endpointID + ":status"
+}
+
 // enqueueNotification writes one notification job to the queue. The webhook legs
 // are consumed by the notification processor, which posts through the SSRF-guarded
 // notification HTTP client; producers never dial the webhook URL themselves.
@@ -176,11 +276,16 @@ func SendEndpointNotification(
 
 	DispatchEndpointAlert(ctx, q, logger, EndpointAlert{
 		EmailRecipient:  endpoint.SupportEmail,
+		OrganisationID:  project.OrganisationID,
 		SlackWebhookURL: endpoint.SlackWebhookURL,
 		TeamsWebhookURL: endpoint.TeamsWebhookURL,
+		Channels:        endpoint.NotificationChannels,
+		DedupKey:        EndpointStatusDedupKey(endpoint.UID),
+		Resolved:        !failure,
 		EmailSubject:    "Endpoint Status Update",
 		EmailParams: map[string]string{
 			"name":            endpoint.Name,
+			"project_name":    project.Name,
 			"logo_url":        project.LogoURL,
 			"target_url":      endpoint.Url,
 			"failure_msg":     failureMsg,
@@ -192,3 +297,95 @@ func SendEndpointNotification(
 		AlertText: alertText,
 	})
 }
+
+// SendCircuitBreakerNotification tells the endpoint's channels that its circuit
+// breaker changed state. An open breaker holds deliveries back, so owners hear
+// about it here rather than by noticing missing events.
+func SendCircuitBreakerNotification(
+	ctx context.Context,
+	endpoint *datastore.Endpoint,
+	project *datastore.Project,
+	transition *datastore.CircuitBreakerTransition,
+	q queue.Queuer,
+	logger log.Logger,
+) bool {
+	var summary string
+	switch transition.ToState {
+	case "open":
+		summary = "deliveries are paused until the endpoint recovers"
+	case "half-open":
+		summary = "trial deliveries are being let through to check the endpoint has recovered"
+	default:
+		summary = "deliveries have resumed"
+	}
+
+	reason := ""
+	if transition.Reason != "" {
+		reason = fmt.Sprintf(", reason given was %q", transition.Reason)
+	}
+
+	return DispatchEndpointAlert(ctx, q, logger, EndpointAlert{
+		EmailRecipient:  endpoint.SupportEmail,
+		OrganisationID:  project.OrganisationID,
+		SlackWebhookURL: endpoint.SlackWebhookURL,
+		TeamsWebhookURL: endpoint.TeamsWebhookURL,
+		Channels:        endpoint.NotificationChannels,
+		DedupKey:        "convoy:endpoint:" + endpoint.UID + ":circuit-breaker",
+		Resolved:        transition.ToState == "closed",
+		EmailSubject:    fmt.Sprintf("Endpoint Circuit Breaker Update - %s", transition.ToState),
+		EmailParams: map[string]string{
+			"name":            endpoint.Name,
+			"project_name":    project.Name,
+			"logo_url":        project.LogoURL,
+			"target_url":      endpoint.Url,
+			"failure_msg":     fmt.Sprintf("Circuit breaker moved from %s to %s (%s)", transition.FromState, transition.ToState, transition.Source),
+			"response_body":   "",
+			"failure_rate":    fmt.Sprintf("%.2f", transition.FailureRate),
+			"status_code":     "0",
+			"endpoint_status": string(endpoint.Status),
+		},
+		AlertText: fmt.Sprintf("circuit breaker for endpoint url (%s) moved from %s to %s with a failure rate of %.2f%%%s, %s",
+			endpoint.Url, transition.FromState, transition.ToState, transition.FailureRate, reason, summary),
+	})
+}
+
+// SendAlertRuleNotification tells an alert rule's channels that the rule started
+// firing or has resolved. condition describes what the rule watches and value is
+// the latest reading, both already worded for people.
+func SendAlertRuleNotification(
+	ctx context.Context,
+	rule *datastore.AlertRule,
+	project *datastore.Project,
+	firing bool,
+	condition string,
+	value string,
+	q queue.Queuer,
+	logger log.Logger,
+) bool {
+	state, summary := datastore.AlertRuleFiring, "is firing"
+	if !firing {
+		state, summary = "resolved", "has resolved"
+	}
+
+	return DispatchEndpointAlert(ctx, q, logger, EndpointAlert{
+		EmailRecipient:  rule.Channels.Email,
+		OrganisationID:  project.OrganisationID,
+		SlackWebhookURL: rule.Channels.SlackWebhookURL,
+		TeamsWebhookURL: rule.Channels.TeamsWebhookURL,
+		Channels:        &rule.Channels.NotificationChannels,
+		DedupKey:        "convoy:alert-rule:" + rule.UID,
+		Resolved:        !firing,
+		EmailSubject:    fmt.Sprintf("Alert Rule %s - %s", state, rule.Name),
+		EmailTemplate:   email.TemplateAlertRule,
+		EmailParams: map[string]string{
+			"rule_name":     rule.Name,
+			"alert_state":   state,
+			"project_name":  project.Name,
+			"logo_url":      project.LogoURL,
+			"condition":     condition,
+			"current_value": value,
+		},
+		AlertText: fmt.Sprintf("alert rule %q in project %s %s, condition is %s and the current value is %s",
+			rule.Name, project.Name, summary, condition, value),
+	})
+}
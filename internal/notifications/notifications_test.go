@@ -85,6 +85,17 @@ func teamsPayload(n *Notification) (*TeamsNotification, error) {
 	return payload, nil
 }
 
+// incidentPayload re-reads an incident channel payload into out the way the
+// notification processor does.
+func incidentPayload(t *testing.T, n *Notification, out interface{}) {
+	t.Helper()
+
+	require.NotNil(t, n)
+	buf, err := json.Marshal(n.Payload)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(buf, out))
+}
+
 func emailPayload(n *Notification) (*email.Message, error) {
 	buf, err := json.Marshal(n.Payload)
 	if err != nil {
@@ -328,3 +339,202 @@ func TestSendEndpointNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestSendCircuitBreakerNotification(t *testing.T) {
+	lo := log.New("convoy", log.LevelError)
+	project := &datastore.Project{Name: "P1", LogoURL: "https://logo.example.com"}
+	endpoint := &datastore.Endpoint{
+		Name: "E1", Url: "https://e1.example.com",
+		Status:          datastore.ActiveEndpointStatus,
+		SupportEmail:    "support@example.com",
+		SlackWebhookURL: "https://hooks.example.com/services/T/B/X",
+	}
+
+	q := &testQueue{}
+	sent := SendCircuitBreakerNotification(context.Background(), endpoint, project, &datastore.CircuitBreakerTransition{
+		FromState:   "closed",
+		ToState:     "open",
+		Source:      datastore.CircuitBreakerTransitionSourceSampler,
+		FailureRate: 82.5,
+	}, q, lo)
+	require.True(t, sent)
+
+	decoded := decodeJobs(t, q.wrote)
+	require.Len(t, decoded, 2)
+
+	slack, err := slackPayload(decoded[SlackNotificationType])
+	require.NoError(t, err)
+	require.Contains(t, slack.Text, "moved from closed to open with a failure rate of 82.50%")
+	require.Contains(t, slack.Text, "deliveries are paused")
+
+	msg, err := emailPayload(decoded[EmailNotificationType])
+	require.NoError(t, err)
+	require.Equal(t, "Endpoint Circuit Breaker Update - open", msg.Subject)
+	require.Equal(t, "82.50", msg.Params.(map[string]interface{})["failure_rate"])
+}
+
+func TestSendAlertRuleNotification(t *testing.T) {
+	lo := log.New("convoy", log.LevelError)
+	project := &datastore.Project{Name: "P1", LogoURL: "https://logo.example.com"}
+	rule := &datastore.AlertRule{
+		Name: "checkout failures",
+		Channels: datastore.AlertRuleChannels{
+			Email:           "oncall@example.com",
+			TeamsWebhookURL: teamsWebhookURL,
+		},
+	}
+
+	q := &testQueue{}
+	require.True(t, SendAlertRuleNotification(context.Background(), rule, project, true, "failure rate above 5% over 5m", "12.50%", q, lo))
+
+	decoded := decodeJobs(t, q.wrote)
+	require.Len(t, decoded, 2)
+
+	teams, err := teamsPayload(decoded[TeamsNotificationType])
+	require.NoError(t, err)
+	require.Contains(t, teams.Text, `alert rule "checkout failures" in project P1 is firing`)
+	require.Contains(t, teams.Text, "current value is 12.50%")
+
+	msg, err := emailPayload(decoded[EmailNotificationType])
+	require.NoError(t, err)
+	require.Equal(t, email.TemplateAlertRule, msg.TemplateName)
+	require.Equal(t, "Alert Rule firing - checkout failures", msg.Subject)
+	require.Equal(t, "firing", msg.Params.(map[string]interface{})["alert_state"])
+
+	q = &testQueue{}
+	require.True(t, SendAlertRuleNotification(context.Background(), rule, project, false, "failure rate above 5% over 5m", "1.00%", q, lo))
+
+	msg, err = emailPayload(decodeJobs(t, q.wrote)[EmailNotificationType])
+	require.NoError(t, err)
+	require.Equal(t, "Alert Rule resolved - checkout failures", msg.Subject)
+	require.Equal(t, "resolved", msg.Params.(map[string]interface{})["alert_state"])
+}
+
+// incidentChannels configures all three incident channels.
+func incidentChannels() *datastore.NotificationChannels {
+	return &datastore.NotificationChannels{
+		Webhook:   &datastore.WebhookChannel{URL: "https://alerts.example.com/convoy", Secret: "whsec"},
+		PagerDuty: &datastore.PagerDutyChannel{RoutingKey: "R0UT1NGKEY"},
+		Opsgenie:  &datastore.OpsgenieChannel{APIKey: "genie-key", Region: datastore.OpsgenieRegionEU},
+	}
+}
+
+func TestSendEndpointNotification_IncidentChannels(t *testing.T) {
+	lo := log.New("convoy", log.LevelError)
+	project := &datastore.Project{Name: "P1"}
+	endpoint := &datastore.Endpoint{
+		UID: "ep-1", Name: "E1", Url: "https://e1.example.com",
+		NotificationChannels: incidentChannels(),
+	}
+
+	q := &testQueue{}
+	SendEndpointNotification(context.Background(), endpoint, project,
+		datastore.InactiveEndpointStatus, q, true, "connection refused", "", 0, lo)
+
+	decoded := decodeJobs(t, q.wrote)
+	require.Len(t, decoded, 3)
+
+	var webhook WebhookNotification
+	incidentPayload(t, decoded[WebhookNotificationType], &webhook)
+	require.Equal(t, "https://alerts.example.com/convoy", webhook.URL)
+	require.Equal(t, "whsec", webhook.Secret)
+	require.Equal(t, WebhookAlertTriggered, webhook.Alert.Event)
+	require.Equal(t, "convoy:endpoint:ep-1:status", webhook.Alert.DedupKey)
+	require.Equal(t, "Endpoint Status Update", webhook.Alert.Title)
+	require.Contains(t, webhook.Alert.Text, "after retry limit was hit")
+
+	var pd PagerDutyNotification
+	incidentPayload(t, decoded[PagerDutyNotificationType], &pd)
+	require.Equal(t, PagerDutyNotification{
+		RoutingKey: "R0UT1NGKEY",
+		DedupKey:   "convoy:endpoint:ep-1:status",
+		Summary:    webhook.Alert.Text,
+	}, pd)
+
+	var og OpsgenieNotification
+	incidentPayload(t, decoded[OpsgenieNotificationType], &og)
+	require.Equal(t, "genie-key", og.APIKey)
+	require.Equal(t, datastore.OpsgenieRegionEU, og.Region)
+	require.Equal(t, "convoy:endpoint:ep-1:status", og.Alias)
+	require.False(t, og.Resolved)
+
+	// Reactivation resolves the incident the disable opened.
+	q = &testQueue{}
+	SendEndpointNotification(context.Background(), endpoint, project,
+		datastore.ActiveEndpointStatus, q, false, "", "", 0, lo)
+
+	decoded = decodeJobs(t, q.wrote)
+	incidentPayload(t, decoded[WebhookNotificationType], &webhook)
+	require.Equal(t, WebhookAlertResolved, webhook.Alert.Event)
+	require.Equal(t, "convoy:endpoint:ep-1:status", webhook.Alert.DedupKey)
+
+	pd = PagerDutyNotification{}
+	incidentPayload(t, decoded[PagerDutyNotificationType], &pd)
+	require.True(t, pd.Resolved)
+	require.Equal(t, "convoy:endpoint:ep-1:status", pd.DedupKey)
+
+	og = OpsgenieNotification{}
+	incidentPayload(t, decoded[OpsgenieNotificationType], &og)
+	require.True(t, og.Resolved)
+	require.Equal(t, "convoy:endpoint:ep-1:status", og.Alias)
+}
+
+func TestSendCircuitBreakerNotification_ClosedResolves(t *testing.T) {
+	lo := log.New("convoy", log.LevelError)
+	project := &datastore.Project{Name: "P1"}
+	endpoint := &datastore.Endpoint{
+		UID: "ep-1", Name: "E1", Url: "https://e1.example.com",
+		NotificationChannels: &datastore.NotificationChannels{
+			PagerDuty: &datastore.PagerDutyChannel{RoutingKey: "R0UT1NGKEY"},
+		},
+	}
+
+	for _, tc := range []struct {
+		toState  string
+		resolved bool
+	}{
+		{toState: "open", resolved: false},
+		{toState: "half-open", resolved: false},
+		{toState: "closed", resolved: true},
+	} {
+		q := &testQueue{}
+		require.True(t, SendCircuitBreakerNotification(context.Background(), endpoint, project,
+			&datastore.CircuitBreakerTransition{FromState: "open", ToState: tc.toState}, q, lo))
+
+		var pd PagerDutyNotification
+		incidentPayload(t, decodeJobs(t, q.wrote)[PagerDutyNotificationType], &pd)
+		require.Equal(t, "convoy:endpoint:ep-1:circuit-breaker", pd.DedupKey, tc.toState)
+		require.Equal(t, tc.resolved, pd.Resolved, tc.toState)
+	}
+}
+
+func TestSendAlertRuleNotification_IncidentChannels(t *testing.T) {
+	lo := log.New("convoy", log.LevelError)
+	project := &datastore.Project{Name: "P1"}
+	rule := &datastore.AlertRule{
+		UID:  "rule-1",
+		Name: "checkout failures",
+		Channels: datastore.AlertRuleChannels{
+			NotificationChannels: datastore.NotificationChannels{
+				Opsgenie: &datastore.OpsgenieChannel{APIKey: "genie-key"},
+			},
+		},
+	}
+
+	q := &testQueue{}
+	require.True(t, SendAlertRuleNotification(context.Background(), rule, project, true, "failure rate above 5% over 5m", "12.50%", q, lo))
+
+	var og OpsgenieNotification
+	incidentPayload(t, decodeJobs(t, q.wrote)[OpsgenieNotificationType], &og)
+	require.Equal(t, "convoy:alert-rule:rule-1", og.Alias)
+	require.Equal(t, "Alert Rule firing - checkout failures", og.Message)
+	require.False(t, og.Resolved)
+
+	q = &testQueue{}
+	require.True(t, SendAlertRuleNotification(context.Background(), rule, project, false, "failure rate above 5% over 5m", "1.00%", q, lo))
+
+	og = OpsgenieNotification{}
+	incidentPayload(t, decodeJobs(t, q.wrote)[OpsgenieNotificationType], &og)
+	require.Equal(t, "convoy:alert-rule:rule-1", og.Alias)
+	require.True(t, og.Resolved)
+}
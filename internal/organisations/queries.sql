@@ -172,6 +172,7 @@ WHERE p.organisation_id = @organisation_id
   AND e.created_at >= @start_time
   AND e.created_at <= @end_time
   AND e.deleted_at IS NULL
+  AND NOT e.is_test
   AND p.deleted_at IS NULL;
 
 -- name: CalculateEgressBytes :one
@@ -186,6 +187,7 @@ WHERE p.organisation_id = @organisation_id
   AND d.status = 'Success'
   AND d.created_at >= @start_time
   AND d.created_at <= @end_time
+  AND NOT e.is_test
   AND p.deleted_at IS NULL;
 
 -- name: CountOrgEvents :one
@@ -196,6 +198,7 @@ WHERE p.organisation_id = @organisation_id
   AND e.created_at >= @start_time
   AND e.created_at <= @end_time
   AND e.deleted_at IS NULL
+  AND NOT e.is_test
   AND p.deleted_at IS NULL;
 
 -- name: CountOrgDeliveries :one
@@ -207,4 +210,5 @@ WHERE p.organisation_id = @organisation_id
   AND d.status = 'Success'
   AND d.created_at >= @start_time
   AND d.created_at <= @end_time
+  AND NOT e.is_test
   AND p.deleted_at IS NULL;
@@ -21,6 +21,7 @@ WHERE p.organisation_id = $1
   AND d.status = 'Success'
   AND d.created_at >= $2
   AND d.created_at <= $3
+  AND NOT e.is_test
   AND p.deleted_at IS NULL
 `
 
@@ -51,6 +52,7 @@ WHERE p.organisation_id = $1
   AND e.created_at >= $2
   AND e.created_at <= $3
   AND e.deleted_at IS NULL
+  AND NOT e.is_test
   AND p.deleted_at IS NULL
 `
 
@@ -85,6 +87,7 @@ WHERE p.organisation_id = $1
   AND d.status = 'Success'
   AND d.created_at >= $2
   AND d.created_at <= $3
+  AND NOT e.is_test
   AND p.deleted_at IS NULL
 `
 
@@ -109,6 +112,7 @@ WHERE p.organisation_id = $1
   AND e.created_at >= $2
   AND e.created_at <= $3
   AND e.deleted_at IS NULL
+  AND NOT e.is_test
   AND p.deleted_at IS NULL
 `
 
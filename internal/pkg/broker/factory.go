@@ -114,6 +114,8 @@ func newPostgres(cfg config.Configuration, db *sqlx.DB, logger log.Logger) (*Dep
 		return nil, err
 	}
 	opts.DB = db
+	opts.Logger = logger
+	opts.FairShare, opts.TenantResolver = fairShareOptions(cfg.Queue.FairShare, db)
 	q, err := pgqueue.NewQueue(opts)
 	if err != nil {
 		return nil, err
@@ -124,6 +126,8 @@ func newPostgres(cfg config.Configuration, db *sqlx.DB, logger log.Logger) (*Dep
 	if err != nil {
 		return nil, err
 	}
+	// Results live in the cache; LISTEN/NOTIFY only wakes the waiting request.
+	acker := dynamiceventack.NewPostgresAcker(db, c, opts.PostgresConnString, logger)
 
 	return &Dependencies{
 		Queue:               q,
@@ -132,7 +136,7 @@ func newPostgres(cfg config.Configuration, db *sqlx.DB, logger log.Logger) (*Dep
 		RateLimiter:         pglimiter.New(db),
 		CircuitBreakerStore: circuit_breaker.NewPostgresStore(db),
 		JobLocker:           newPostgresJobLockerWithLimit(lockDB, logger, jobLockMaxConns),
-		Acker:               dynamiceventack.NewCacheAcker(c),
+		Acker:               acker,
 		TrialEvents:         license.NewPostgresTrialEventLimiter(db, logger),
 		ConsumerBackend:     worker.NewPostgresConsumerBackend(q),
 		Scheduler:           worker.NewPostgresScheduler(q, logger),
@@ -140,11 +144,11 @@ func newPostgres(cfg config.Configuration, db *sqlx.DB, logger log.Logger) (*Dep
 		ResendClaims:        services.NewPostgresResendClaimStore(db),
 		BatchTracker:        batch_tracker.NewPostgresTracker(db),
 		// q first: its batchers must stop before the lock pool goes.
-		closers: []io.Closer{q, lockDB},
+		closers: []io.Closer{q, acker, lockDB},
 	}, nil
 }
 
-func newRedis(cfg config.Configuration, _ *sqlx.DB, logger log.Logger) (*Dependencies, error) {
+func newRedis(cfg config.Configuration, db *sqlx.DB, logger log.Logger) (*Dependencies, error) {
 	rd, err := newRedisClient(cfg.Redis)
 	if err != nil {
 		return nil, fmt.Errorf("connect redis broker: %w", err)
@@ -154,13 +158,15 @@ func newRedis(cfg config.Configuration, _ *sqlx.DB, logger log.Logger) (*Depende
 		return nil, err
 	}
 	opts.RedisClient = rd
+	opts.Logger = logger
 	opts.RedisAddress = cfg.Redis.BuildDsn()
+	opts.FairShare, opts.TenantResolver = fairShareOptions(cfg.Queue.FairShare, db)
 	if cfg.Redis.IsSentinel() {
 		// An unset database means 0, the same reading BuildDsn takes. Only a
 		// non-empty value that will not parse is a misconfiguration.
-		var db int
+		var redisDB int
 		if cfg.Redis.Database != "" {
-			db, err = strconv.Atoi(cfg.Redis.Database)
+			redisDB, err = strconv.Atoi(cfg.Redis.Database)
 			if err != nil {
 				return nil, fmt.Errorf("parse redis database %q: %w", cfg.Redis.Database, err)
 			}
@@ -171,7 +177,7 @@ func newRedis(cfg config.Configuration, _ *sqlx.DB, logger log.Logger) (*Depende
 			Username:         cfg.Redis.Username,
 			Password:         cfg.Redis.Password,
 			SentinelPassword: cfg.Redis.SentinelPassword,
-			DB:               db,
+			DB:               redisDB,
 		}
 	}
 	q := redisqueue.NewQueue(opts)
@@ -119,6 +119,16 @@ func TestPostgresProviderWithoutFeatureFlagDoesNotFallbackToRedis(t *testing.T)
 	require.False(t, called)
 }
 
+func TestEmbeddedStillNeedsThePostgresQueueFeatureFlag(t *testing.T) {
+	cfg := testConfig(config.PostgresQueueProvider)
+	cfg.EnableFeatureFlag = nil
+	cfg.Embedded.Enabled = true
+
+	deps, err := New(cfg, sqlx.NewDb(&sql.DB{}, "postgres"), log.New("test", log.LevelError))
+	require.Nil(t, deps)
+	require.ErrorIs(t, err, fflag.ErrPostgresQueueNotEnabled)
+}
+
 type denyPostgresQueueLicenser struct {
 	*noop.Licenser
 }
@@ -0,0 +1,60 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/queue"
+)
+
+const (
+	// A project never changes organisation, so the cache only bounds memory
+	// and how long a deleted project's entry lingers.
+	tenantCacheSize = 10000
+	tenantCacheTTL  = 10 * time.Minute
+
+	tenantLookupTimeout = time.Second
+)
+
+// fairShareOptions maps the fair-share config onto the queue. Organisation
+// scope resolves each job's project to its organisation on the way in.
+func fairShareOptions(cfg config.FairShareConfiguration, db *sqlx.DB) (queue.FairShare, queue.TenantResolver) {
+	share := queue.FairShare{
+		Enabled:       cfg.Enabled,
+		DefaultWeight: cfg.DefaultWeight,
+		Weights:       cfg.Weights,
+		MaxInFlight:   cfg.MaxInFlight,
+	}
+	if cfg.TenantScope != config.OrganisationTenantScope || db == nil {
+		return share, nil
+	}
+	return share, organisationResolver(db)
+}
+
+// organisationResolver looks a project's organisation up, caching the answer.
+// It fails open: a failed lookup leaves the job tenanted by its project, which
+// skews that one job's share rather than failing an enqueue over scheduling.
+func organisationResolver(db *sqlx.DB) queue.TenantResolver {
+	orgs := expirable.NewLRU[string, string](tenantCacheSize, nil, tenantCacheTTL)
+
+	return func(ctx context.Context, projectID string) string {
+		if org, ok := orgs.Get(projectID); ok {
+			return org
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, tenantLookupTimeout)
+		defer cancel()
+
+		var org string
+		err := db.GetContext(ctx, &org, `SELECT organisation_id FROM convoy.projects WHERE id = $1`, projectID)
+		if err != nil {
+			return ""
+		}
+		orgs.Add(projectID, org)
+		return org
+	}
+}
@@ -0,0 +1,53 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/config"
+)
+
+func TestFairShareOptionsProjectScopeHasNoResolver(t *testing.T) {
+	share, resolve := fairShareOptions(config.FairShareConfiguration{
+		Enabled:       true,
+		TenantScope:   config.ProjectTenantScope,
+		DefaultWeight: 2,
+		Weights:       map[string]int{"project-1": 5},
+		MaxInFlight:   10,
+	}, sqlx.NewDb(nil, "postgres"))
+
+	require.True(t, share.Enabled)
+	require.Equal(t, 5, share.Weight("project-1"))
+	require.Equal(t, 2, share.Weight("project-2"))
+	require.Equal(t, 10, share.MaxInFlight)
+	require.Nil(t, resolve)
+}
+
+func TestOrganisationResolverCachesAndFailsOpen(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, resolve := fairShareOptions(config.FairShareConfiguration{
+		TenantScope: config.OrganisationTenantScope,
+	}, sqlx.NewDb(db, "postgres"))
+	require.NotNil(t, resolve)
+
+	mock.ExpectQuery(`SELECT organisation_id FROM convoy.projects`).
+		WithArgs("project-1").
+		WillReturnRows(sqlmock.NewRows([]string{"organisation_id"}).AddRow("org-1"))
+	mock.ExpectQuery(`SELECT organisation_id FROM convoy.projects`).
+		WithArgs("project-2").
+		WillReturnError(errors.New("connection refused"))
+
+	ctx := context.Background()
+	require.Equal(t, "org-1", resolve(ctx, "project-1"))
+	require.Equal(t, "org-1", resolve(ctx, "project-1"), "the second lookup is served from the cache")
+	require.Empty(t, resolve(ctx, "project-2"), "a failed lookup leaves the project as the tenant")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"io"
+
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/otel/trace"
@@ -30,7 +32,12 @@ type App struct {
 	Licenser license.Licenser
 	Broker   *broker.Dependencies
 
+	// EmbeddedDB is the Postgres server an embedded instance started for
+	// itself, nil otherwise. It is stopped after DB is closed.
+	EmbeddedDB io.Closer
+
 	TracerBackend tracer.Backend
+	Telemetry     *tracer.Telemetry
 
 	// JobTracker is an optional field used only in E2E tests
 	// to capture job IDs for verification
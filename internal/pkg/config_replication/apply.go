@@ -0,0 +1,150 @@
+package config_replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pglogrepl"
+)
+
+type changeKind int
+
+const (
+	changeUpsert changeKind = iota
+	changeDelete
+)
+
+// change is one row change from the source, decoded against the relation
+// the stream described it with.
+type change struct {
+	kind  changeKind
+	table string
+	// row is the new row of an upsert, or the identity of a deleted row.
+	row row
+	// old is the identity an update replaced, sent when the update changed
+	// the key or the table is keyed on every column.
+	old row
+}
+
+// decodeTuple turns a tuple into a row by the relation's column names.
+func decodeTuple(rel *pglogrepl.RelationMessage, tuple *pglogrepl.TupleData) row {
+	if tuple == nil {
+		return nil
+	}
+	r := make(row, len(rel.Columns))
+	for i, col := range rel.Columns {
+		if i >= len(tuple.Columns) {
+			break
+		}
+		tc := tuple.Columns[i]
+		switch tc.DataType {
+		case pglogrepl.TupleDataTypeText:
+			v := string(tc.Data)
+			r[col.Name] = &v
+		case pglogrepl.TupleDataTypeNull:
+			r[col.Name] = nil
+		case pglogrepl.TupleDataTypeToast:
+			// Unchanged TOAST value: leave the standby's copy alone.
+		}
+	}
+	return r
+}
+
+// decodeChange turns a row message into a change. Messages that are not row
+// changes, and rows outside the convoy schema, yield false.
+func decodeChange(relations map[uint32]*pglogrepl.RelationMessage, msg pglogrepl.Message) (change, bool, error) {
+	var (
+		relID uint32
+		c     change
+	)
+	switch m := msg.(type) {
+	case *pglogrepl.InsertMessage:
+		relID = m.RelationID
+	case *pglogrepl.UpdateMessage:
+		relID = m.RelationID
+	case *pglogrepl.DeleteMessage:
+		relID = m.RelationID
+	default:
+		return change{}, false, nil
+	}
+
+	rel, ok := relations[relID]
+	if !ok {
+		return change{}, false, fmt.Errorf("row change for unknown relation %d", relID)
+	}
+	if rel.Namespace != schema {
+		return change{}, false, nil
+	}
+	c.table = rel.RelationName
+
+	switch m := msg.(type) {
+	case *pglogrepl.InsertMessage:
+		c.kind, c.row = changeUpsert, decodeTuple(rel, m.Tuple)
+	case *pglogrepl.UpdateMessage:
+		c.kind, c.row, c.old = changeUpsert, decodeTuple(rel, m.NewTuple), decodeTuple(rel, m.OldTuple)
+	case *pglogrepl.DeleteMessage:
+		c.kind, c.row = changeDelete, decodeTuple(rel, m.OldTuple)
+	}
+	return c, true, nil
+}
+
+// applier writes changes into the standby, reading each table's shape from
+// the standby the first time it is written.
+type applier struct {
+	tables map[string]*table
+}
+
+func newApplier() *applier {
+	return &applier{tables: make(map[string]*table)}
+}
+
+// forget drops a table's cached shape. The stream re-describes a relation
+// after the source alters it, which is the cue to read the standby again.
+func (a *applier) forget(name string) {
+	delete(a.tables, name)
+}
+
+func (a *applier) table(ctx context.Context, q querier, name string) (*table, error) {
+	if t, ok := a.tables[name]; ok {
+		return t, nil
+	}
+	t, err := loadTable(ctx, q, name)
+	if err != nil {
+		return nil, err
+	}
+	a.tables[name] = t
+	return t, nil
+}
+
+type txQuerier interface {
+	querier
+	execer
+}
+
+// apply writes one change in tx.
+func (a *applier) apply(ctx context.Context, tx txQuerier, c change) error {
+	t, err := a.table(ctx, tx, c.table)
+	if err != nil {
+		return err
+	}
+
+	if c.kind == changeDelete {
+		return exec(ctx, tx, t.remove, c.row)
+	}
+
+	if t.rekeyed(c.old, c.row) {
+		if err = exec(ctx, tx, t.remove, c.old); err != nil {
+			return err
+		}
+	}
+	return exec(ctx, tx, t.upsert, c.row)
+}
+
+func exec(ctx context.Context, tx execer, build func(row) (string, []any, error), r row) error {
+	query, args, err := build(r)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,140 @@
+package config_replication
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+var endpointsRelation = &pglogrepl.RelationMessage{
+	RelationID:   7,
+	Namespace:    "convoy",
+	RelationName: "endpoints",
+	Columns: []*pglogrepl.RelationMessageColumn{
+		{Name: "id", Flags: 1},
+		{Name: "name"},
+		{Name: "secrets"},
+		{Name: "deleted_at"},
+	},
+}
+
+func tuple(cols ...*pglogrepl.TupleDataColumn) *pglogrepl.TupleData {
+	return &pglogrepl.TupleData{ColumnNum: uint16(len(cols)), Columns: cols}
+}
+
+func text(s string) *pglogrepl.TupleDataColumn {
+	return &pglogrepl.TupleDataColumn{DataType: pglogrepl.TupleDataTypeText, Data: []byte(s)}
+}
+
+var (
+	null  = &pglogrepl.TupleDataColumn{DataType: pglogrepl.TupleDataTypeNull}
+	toast = &pglogrepl.TupleDataColumn{DataType: pglogrepl.TupleDataTypeToast}
+)
+
+func TestDecodeTuple(t *testing.T) {
+	got := decodeTuple(endpointsRelation, tuple(text("ep-1"), text("orders"), toast, null))
+	require.Equal(t, row{"id": str("ep-1"), "name": str("orders"), "deleted_at": nil}, got)
+
+	require.Nil(t, decodeTuple(endpointsRelation, nil))
+}
+
+func TestDecodeChange(t *testing.T) {
+	relations := map[uint32]*pglogrepl.RelationMessage{7: endpointsRelation}
+
+	c, ok, err := decodeChange(relations, &pglogrepl.InsertMessage{RelationID: 7, Tuple: tuple(text("ep-1"), text("a"), text("[]"), null)})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, change{kind: changeUpsert, table: "endpoints", row: row{"id": str("ep-1"), "name": str("a"), "secrets": str("[]"), "deleted_at": nil}}, c)
+
+	c, ok, err = decodeChange(relations, &pglogrepl.UpdateMessage{
+		RelationID:   7,
+		OldTupleType: pglogrepl.UpdateMessageTupleTypeKey,
+		OldTuple:     tuple(text("ep-1"), null, null, null),
+		NewTuple:     tuple(text("ep-2"), text("a"), toast, null),
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, changeUpsert, c.kind)
+	require.Equal(t, row{"id": str("ep-2"), "name": str("a"), "deleted_at": nil}, c.row)
+	require.Equal(t, str("ep-1"), c.old["id"])
+
+	c, ok, err = decodeChange(relations, &pglogrepl.DeleteMessage{RelationID: 7, OldTuple: tuple(text("ep-1"), null, null, null)})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, changeDelete, c.kind)
+	require.Equal(t, str("ep-1"), c.row["id"])
+
+	_, ok, err = decodeChange(relations, &pglogrepl.BeginMessage{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = decodeChange(relations, &pglogrepl.InsertMessage{RelationID: 9})
+	require.EqualError(t, err, "row change for unknown relation 9")
+
+	relations[8] = &pglogrepl.RelationMessage{RelationID: 8, Namespace: "public", RelationName: "other"}
+	_, ok, err = decodeChange(relations, &pglogrepl.InsertMessage{RelationID: 8})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+// recordingTx records the statements a change applies as.
+type recordingTx struct {
+	queries []string
+	args    [][]any
+}
+
+func (r *recordingTx) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected catalog read")
+}
+
+func (r *recordingTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	r.queries = append(r.queries, sql)
+	r.args = append(r.args, args)
+	return pgconn.CommandTag{}, nil
+}
+
+func TestApply(t *testing.T) {
+	a := newApplier()
+	a.tables["endpoints"] = endpointsTable()
+
+	t.Run("update that keeps its key is one upsert", func(t *testing.T) {
+		tx := &recordingTx{}
+		err := a.apply(context.Background(), tx, change{kind: changeUpsert, table: "endpoints", row: row{"id": str("ep-1"), "name": str("b")}})
+		require.NoError(t, err)
+		require.Len(t, tx.queries, 1)
+		require.Contains(t, tx.queries[0], "INSERT INTO")
+	})
+
+	t.Run("update that moves its key deletes the old row first", func(t *testing.T) {
+		tx := &recordingTx{}
+		err := a.apply(context.Background(), tx, change{
+			kind:  changeUpsert,
+			table: "endpoints",
+			row:   row{"id": str("ep-2"), "name": str("b")},
+			old:   row{"id": str("ep-1")},
+		})
+		require.NoError(t, err)
+		require.Len(t, tx.queries, 2)
+		require.Contains(t, tx.queries[0], "DELETE FROM")
+		require.Equal(t, []any{str("ep-1")}, tx.args[0])
+		require.Contains(t, tx.queries[1], "INSERT INTO")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		tx := &recordingTx{}
+		err := a.apply(context.Background(), tx, change{kind: changeDelete, table: "endpoints", row: row{"id": str("ep-1")}})
+		require.NoError(t, err)
+		require.Equal(t, []string{`DELETE FROM "convoy"."endpoints" WHERE "id" = $1::text::character varying`}, tx.queries)
+	})
+
+	t.Run("forgotten table is read from the standby again", func(t *testing.T) {
+		a.forget("endpoints")
+		err := a.apply(context.Background(), &recordingTx{}, change{kind: changeDelete, table: "endpoints", row: row{"id": str("ep-1")}})
+		require.ErrorContains(t, err, "unexpected catalog read")
+	})
+}
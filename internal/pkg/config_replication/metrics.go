@@ -0,0 +1,81 @@
+package config_replication
+
+import (
+	"time"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "convoy"
+	subsystem = "config_replication"
+)
+
+var (
+	lagSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "lag_seconds"),
+		"How long after its commit on the source the last transaction was applied to the standby, or 0 once the standby has everything the source has sent",
+		nil, nil,
+	)
+	lagBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "lag_bytes"),
+		"Source WAL the standby has yet to apply",
+		nil, nil,
+	)
+	transactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "transactions_applied_total"),
+		"Source transactions applied to the standby by this process",
+		nil, nil,
+	)
+	upDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "up"),
+		"Whether the replicator is streaming from the source (1) or reconnecting (0)",
+		nil, nil,
+	)
+)
+
+// bytesBehind is how much source WAL lies past what the standby has applied.
+// The applied position moves with the source's keepalives between
+// transactions, so a standby with nothing to apply reads up to one status
+// interval's worth of unrelated WAL behind, not zero.
+func bytesBehind(applied, walEnd pglogrepl.LSN) uint64 {
+	if applied >= walEnd {
+		return 0
+	}
+	return uint64(walEnd - applied)
+}
+
+// commitLag is how long after its source commit a transaction was applied.
+// Clocks on the two sides may disagree by a little; a standby cannot be ahead
+// of the source, so a negative lag reads as none.
+func commitLag(commitTime, appliedAt time.Time) time.Duration {
+	if commitTime.IsZero() || appliedAt.Before(commitTime) {
+		return 0
+	}
+	return appliedAt.Sub(commitTime)
+}
+
+func (r *Replicator) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lagSecondsDesc
+	ch <- lagBytesDesc
+	ch <- transactionsDesc
+	ch <- upDesc
+}
+
+func (r *Replicator) Collect(ch chan<- prometheus.Metric) {
+	r.mu.Lock()
+	seconds, bytes := r.lastLag, bytesBehind(r.applied, r.walEnd)
+	transactions, up := r.transactions, r.up
+	r.mu.Unlock()
+
+	upValue := 0.0
+	if up {
+		upValue = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(lagSecondsDesc, prometheus.GaugeValue, seconds.Seconds())
+	ch <- prometheus.MustNewConstMetric(lagBytesDesc, prometheus.GaugeValue, float64(bytes))
+	ch <- prometheus.MustNewConstMetric(transactionsDesc, prometheus.CounterValue, float64(transactions))
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, upValue)
+}
@@ -0,0 +1,65 @@
+package config_replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytesBehind(t *testing.T) {
+	require.Equal(t, uint64(0), bytesBehind(100, 100))
+	require.Equal(t, uint64(0), bytesBehind(200, 100))
+	require.Equal(t, uint64(50), bytesBehind(100, 150))
+}
+
+func TestCommitLag(t *testing.T) {
+	now := time.Now()
+	require.Equal(t, 3*time.Second, commitLag(now.Add(-3*time.Second), now))
+	require.Zero(t, commitLag(now.Add(time.Second), now), "a source clock ahead of ours is no lag")
+	require.Zero(t, commitLag(time.Time{}, now))
+}
+
+func TestObserveWALEnd(t *testing.T) {
+	r := &Replicator{applied: 100, walEnd: 100, lastLag: 4 * time.Second}
+
+	r.observeWALEnd(300, false)
+	require.EqualValues(t, 100, r.applied, "a data message's WAL end is not progress")
+	require.EqualValues(t, 300, r.walEnd)
+	require.Equal(t, 4*time.Second, r.lastLag)
+
+	r.observeWALEnd(250, true)
+	require.EqualValues(t, 250, r.applied)
+	require.EqualValues(t, 300, r.walEnd)
+	require.Zero(t, r.lastLag)
+}
+
+func TestCollect(t *testing.T) {
+	r := &Replicator{applied: 100, walEnd: 160, lastLag: 2 * time.Second, transactions: 3, up: true}
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(r))
+
+	require.Equal(t, 4, testutil.CollectAndCount(r))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	got := map[string]float64{}
+	for _, f := range families {
+		m := f.GetMetric()[0]
+		if m.GetGauge() != nil {
+			got[f.GetName()] = m.GetGauge().GetValue()
+		} else {
+			got[f.GetName()] = m.GetCounter().GetValue()
+		}
+	}
+	require.Equal(t, map[string]float64{
+		"convoy_config_replication_lag_seconds":                2,
+		"convoy_config_replication_lag_bytes":                  60,
+		"convoy_config_replication_transactions_applied_total": 3,
+		"convoy_config_replication_up":                         1,
+	}, got)
+}
@@ -0,0 +1,407 @@
+// Package config_replication keeps a standby region's configuration in step
+// with the primary region's over logical replication, so the standby can take
+// over when the primary is lost. Projects, endpoints (and their secrets),
+// subscriptions, sources and the users and organisations that own them are
+// replicated; event data stays in the region that received it.
+//
+// The standby is written only by the replicator until it is promoted, so
+// every change applies without conflict: a row change is an upsert or a
+// delete by key, and each source transaction is applied in one standby
+// transaction together with the position it reached.
+package config_replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/frain-dev/convoy/config"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+const (
+	standbyStatusInterval = 10 * time.Second
+	receiveTimeout        = 5 * time.Second
+
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = time.Minute
+)
+
+// Replicator streams the source's configuration changes into the standby
+// database it was built with.
+type Replicator struct {
+	pool      *pgxpool.Pool
+	sourceDSN string
+	slotName  string
+	logger    log.Logger
+
+	// mu guards the progress the metrics read; the stream is the only writer.
+	mu sync.Mutex
+	// applied is the source position the standby has everything up to.
+	applied pglogrepl.LSN
+	// walEnd is the end of the source's WAL as last reported.
+	walEnd pglogrepl.LSN
+	// lastLag is the commit lag of the last applied transaction, reset once
+	// a keepalive shows the standby caught up.
+	lastLag      time.Duration
+	transactions uint64
+	up           bool
+
+	// Per stream session.
+	relations map[uint32]*pglogrepl.RelationMessage
+	applier   *applier
+	txn       *pendingTxn
+}
+
+// pendingTxn is a source transaction received but not yet committed.
+type pendingTxn struct {
+	commitTime time.Time
+	changes    []change
+}
+
+// New builds a replicator into pool, the standby's database.
+func New(pool *pgxpool.Pool, cfg config.ConfigReplicationConfiguration, logger log.Logger) *Replicator {
+	return &Replicator{
+		pool:      pool,
+		sourceDSN: cfg.SourceDSN,
+		slotName:  cfg.SlotName,
+		logger:    logger,
+	}
+}
+
+// Run replicates until ctx is done or the standby is promoted. A failed
+// stream is reconnected with backoff; the errors it returns are the ones
+// retrying cannot fix.
+func (r *Replicator) Run(ctx context.Context) error {
+	if r.sourceDSN == "" {
+		return errors.New("config_replication.source_dsn is required")
+	}
+
+	delay := minRetryDelay
+	for {
+		started := time.Now()
+		err := r.session(ctx)
+		r.setUp(false)
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.Is(err, ErrPromoted):
+			r.logger.Info("config replication: standby promoted, stopping")
+			return nil
+		case errors.Is(err, ErrSlotLost), errors.Is(err, ErrStandbyNotEmpty):
+			return err
+		}
+
+		if time.Since(started) > maxRetryDelay {
+			delay = minRetryDelay
+		}
+		r.logger.Error(fmt.Sprintf("config replication: %v; retrying in %s", err, delay))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// session is one connection to the source: seed the standby if it has never
+// been seeded, then stream until something fails.
+func (r *Replicator) session(ctx context.Context) error {
+	state, err := LoadState(ctx, r.pool, r.slotName)
+	if err != nil && !errors.Is(err, ErrNoState) {
+		return fmt.Errorf("load state: %w", err)
+	}
+	if state != nil && state.PromotedAt != nil {
+		return ErrPromoted
+	}
+
+	src, err := pgx.Connect(ctx, r.sourceDSN)
+	if err != nil {
+		return fmt.Errorf("connect to source: %w", err)
+	}
+	defer func() { _ = src.Close(context.Background()) }()
+
+	if err = r.checkSchema(ctx, src); err != nil {
+		return err
+	}
+	if err = r.checkPublication(ctx, src); err != nil {
+		return err
+	}
+
+	replConn, err := connectReplication(ctx, r.sourceDSN)
+	if err != nil {
+		return fmt.Errorf("replication connect: %w", err)
+	}
+	defer func() { _ = replConn.Close(context.Background()) }()
+
+	var slotExists bool
+	err = src.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1)", r.slotName).Scan(&slotExists)
+	if err != nil {
+		return fmt.Errorf("check replication slot: %w", err)
+	}
+
+	var startLSN pglogrepl.LSN
+	switch {
+	case state != nil && !slotExists:
+		return ErrSlotLost
+
+	case state != nil:
+		startLSN = state.AppliedLSN
+		r.logger.Info(fmt.Sprintf("config replication: resuming slot %q at %s", r.slotName, startLSN))
+
+	default:
+		// A slot without state is left over from a copy that did not
+		// finish; its snapshot is gone, so it starts over.
+		if slotExists {
+			err = pglogrepl.DropReplicationSlot(ctx, replConn, r.slotName, pglogrepl.DropReplicationSlotOptions{Wait: true})
+			if err != nil {
+				return fmt.Errorf("drop unfinished replication slot: %w", err)
+			}
+		}
+
+		result, err := pglogrepl.CreateReplicationSlot(ctx, replConn, r.slotName, "pgoutput",
+			pglogrepl.CreateReplicationSlotOptions{SnapshotAction: "EXPORT_SNAPSHOT"})
+		if err != nil {
+			return fmt.Errorf("create replication slot: %w", err)
+		}
+		startLSN, err = pglogrepl.ParseLSN(result.ConsistentPoint)
+		if err != nil {
+			return fmt.Errorf("parse consistent point: %w", err)
+		}
+
+		// The snapshot lives only until the replication connection runs its
+		// next command, so the copy has to finish before streaming starts.
+		r.logger.Info(fmt.Sprintf("config replication: created slot %q at %s, copying configuration", r.slotName, startLSN))
+		if err = r.seed(ctx, src, result.SnapshotName, startLSN); err != nil {
+			return fmt.Errorf("initial copy: %w", err)
+		}
+		r.logger.Info("config replication: initial copy complete")
+
+		if state, err = LoadState(ctx, r.pool, r.slotName); err != nil {
+			return fmt.Errorf("load state: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	r.applied, r.walEnd = startLSN, max(startLSN, state.SourceWALEnd)
+	r.mu.Unlock()
+
+	err = pglogrepl.StartReplication(ctx, replConn, r.slotName, startLSN, pglogrepl.StartReplicationOptions{
+		PluginArgs: []string{
+			"proto_version '1'",
+			fmt.Sprintf("publication_names '%s'", publication),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("start replication: %w", err)
+	}
+
+	r.relations = make(map[uint32]*pglogrepl.RelationMessage)
+	r.applier = newApplier()
+	r.txn = nil
+	r.setUp(true)
+
+	return r.stream(ctx, replConn)
+}
+
+// connectReplication opens a connection with the replication protocol.
+func connectReplication(ctx context.Context, dsn string) (*pgconn.PgConn, error) {
+	cfg, err := pgconn.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse dsn: %w", err)
+	}
+	cfg.RuntimeParams["replication"] = "database"
+	return pgconn.ConnectConfig(ctx, cfg)
+}
+
+// stream receives and applies changes, reporting progress to the source and
+// the state row every standbyStatusInterval.
+func (r *Replicator) stream(ctx context.Context, conn *pgconn.PgConn) error {
+	nextStatus := time.Now()
+
+	for {
+		if time.Now().After(nextStatus) {
+			if err := r.sendStatus(ctx, conn); err != nil {
+				return err
+			}
+			nextStatus = time.Now().Add(standbyStatusInterval)
+		}
+
+		recvCtx, cancel := context.WithDeadline(ctx, time.Now().Add(receiveTimeout))
+		rawMsg, err := conn.ReceiveMessage(recvCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if pgconn.Timeout(err) || recvCtx.Err() != nil {
+				continue
+			}
+			return fmt.Errorf("receive WAL message: %w", err)
+		}
+
+		if errMsg, ok := rawMsg.(*pgproto3.ErrorResponse); ok {
+			return fmt.Errorf("WAL stream error: %s (%s)", errMsg.Message, errMsg.Code)
+		}
+
+		msg, ok := rawMsg.(*pgproto3.CopyData)
+		if !ok || len(msg.Data) == 0 {
+			continue
+		}
+
+		switch msg.Data[0] {
+		case pglogrepl.XLogDataByteID:
+			xld, err := pglogrepl.ParseXLogData(msg.Data[1:])
+			if err != nil {
+				return fmt.Errorf("parse XLogData: %w", err)
+			}
+			r.observeWALEnd(xld.ServerWALEnd, false)
+			if err = r.handle(ctx, xld.WALData); err != nil {
+				return err
+			}
+
+		case pglogrepl.PrimaryKeepaliveMessageByteID:
+			pkm, err := pglogrepl.ParsePrimaryKeepaliveMessage(msg.Data[1:])
+			if err != nil {
+				return fmt.Errorf("parse keepalive: %w", err)
+			}
+			r.observeWALEnd(pkm.ServerWALEnd, r.txn == nil)
+			if pkm.ReplyRequested {
+				nextStatus = time.Now()
+			}
+		}
+	}
+}
+
+// handle applies one logical message. Row changes are held until their
+// transaction commits, and the whole transaction is applied at once.
+func (r *Replicator) handle(ctx context.Context, data []byte) error {
+	msg, err := pglogrepl.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse logical message: %w", err)
+	}
+
+	switch m := msg.(type) {
+	case *pglogrepl.RelationMessage:
+		r.relations[m.RelationID] = m
+		r.applier.forget(m.RelationName)
+		return nil
+
+	case *pglogrepl.BeginMessage:
+		r.txn = &pendingTxn{commitTime: m.CommitTime}
+		return nil
+
+	case *pglogrepl.CommitMessage:
+		if r.txn == nil {
+			return errors.New("commit without a transaction")
+		}
+		txn := r.txn
+		r.txn = nil
+		return r.commit(ctx, txn, m.TransactionEndLSN, m.CommitTime)
+	}
+
+	c, ok, err := decodeChange(r.relations, msg)
+	if err != nil || !ok {
+		return err
+	}
+	if r.txn == nil {
+		return fmt.Errorf("change to %s outside a transaction", c.table)
+	}
+	r.txn.changes = append(r.txn.changes, c)
+	return nil
+}
+
+// commit applies a source transaction and records its end as applied, in
+// one standby transaction.
+func (r *Replicator) commit(ctx context.Context, txn *pendingTxn, end pglogrepl.LSN, commitTime time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	if err = lockState(ctx, tx, r.slotName); err != nil {
+		return err
+	}
+
+	for _, c := range txn.changes {
+		if err = r.applier.apply(ctx, tx, c); err != nil {
+			return fmt.Errorf("apply change to %s.%s: %w", schema, c.table, err)
+		}
+	}
+
+	if err = saveApplied(ctx, tx, r.slotName, end, commitTime); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.applied = max(r.applied, end)
+	r.lastLag = commitLag(commitTime, time.Now())
+	r.transactions++
+	r.mu.Unlock()
+	return nil
+}
+
+// observeWALEnd records how far the source's WAL reaches. A keepalive
+// between transactions reports how far the source has decoded, and every
+// change up to there has been received and applied, so caughtUp moves the
+// applied position with it and the slot may release that WAL. The end a data
+// message carries is the source's WAL end, which decoding may not have
+// reached yet.
+func (r *Replicator) observeWALEnd(end pglogrepl.LSN, caughtUp bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.walEnd = max(r.walEnd, end)
+	if caughtUp {
+		r.applied = max(r.applied, end)
+		r.lastLag = 0
+	}
+}
+
+// sendStatus confirms the applied position to the source, so the slot stops
+// retaining WAL for it, and records a heartbeat. A promotion found here stops
+// an idle stream that no transaction would otherwise stop.
+func (r *Replicator) sendStatus(ctx context.Context, conn *pgconn.PgConn) error {
+	r.mu.Lock()
+	applied, walEnd := r.applied, r.walEnd
+	r.mu.Unlock()
+
+	err := pglogrepl.SendStandbyStatusUpdate(ctx, conn, pglogrepl.StandbyStatusUpdate{
+		WALWritePosition: applied,
+		WALFlushPosition: applied,
+		WALApplyPosition: applied,
+	})
+	if err != nil {
+		return fmt.Errorf("send standby status: %w", err)
+	}
+
+	promoted, err := heartbeat(ctx, r.pool, r.slotName, applied, walEnd)
+	if err != nil {
+		return fmt.Errorf("record heartbeat: %w", err)
+	}
+	if promoted {
+		return ErrPromoted
+	}
+	return nil
+}
+
+func (r *Replicator) setUp(up bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.up = up
+}
@@ -0,0 +1,215 @@
+package config_replication
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/api/testdb"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/internal/pkg/keys"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/testenv"
+)
+
+var infra *testenv.Environment
+
+func TestMain(m *testing.M) {
+	res, cleanup, err := testenv.Launch(context.Background(), testenv.WithoutRedis())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to launch test infrastructure: %v\n", err)
+		os.Exit(1)
+	}
+
+	infra = res
+	code := m.Run()
+
+	if err := cleanup(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to cleanup: %v\n", err)
+	}
+
+	os.Exit(code)
+}
+
+// setupRegions clones a source and a standby database and returns the
+// replication configuration between them. The slot is dropped before the
+// source database, which cannot be dropped while a slot holds it.
+func setupRegions(t *testing.T) (*pgxpool.Pool, *pgxpool.Pool, config.ConfigReplicationConfiguration) {
+	t.Helper()
+
+	require.NoError(t, config.LoadConfig(""))
+
+	km, err := keys.NewLocalKeyManager("test")
+	require.NoError(t, err)
+	require.NoError(t, keys.Set(km))
+
+	source, err := infra.CloneTestDatabase(t, "convoy")
+	require.NoError(t, err)
+	standby, err := infra.CloneTestDatabase(t, "convoy")
+	require.NoError(t, err)
+
+	c := source.Config().ConnConfig
+	cfg := config.ConfigReplicationConfiguration{
+		SourceDSN: fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", c.User, c.Password, c.Host, c.Port, c.Database),
+		SlotName:  fmt.Sprintf("convoy_config_test_%d", time.Now().UnixNano()),
+	}
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_, _ = source.Exec(ctx, `
+			SELECT pg_drop_replication_slot(slot_name)
+			FROM pg_replication_slots
+			WHERE slot_name = $1`, cfg.SlotName)
+	})
+
+	return source, standby, cfg
+}
+
+func startReplicator(t *testing.T, standby *pgxpool.Pool, cfg config.ConfigReplicationConfiguration) (*Replicator, <-chan error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := New(standby, cfg, log.New("config_replication", log.LevelError))
+
+	done := make(chan error, 1)
+	stopped := make(chan struct{})
+	go func() {
+		done <- r.Run(ctx)
+		close(stopped)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return r, done
+}
+
+func count(t *testing.T, pool *pgxpool.Pool, query string, args ...any) int {
+	t.Helper()
+	var n int
+	require.NoError(t, pool.QueryRow(context.Background(), query, args...).Scan(&n))
+	return n
+}
+
+func TestReplicator_CopiesThenStreams(t *testing.T) {
+	source, standby, cfg := setupRegions(t)
+	ctx := context.Background()
+
+	db := postgres.NewFromConnection(source)
+	user, err := testdb.SeedDefaultUser(db)
+	require.NoError(t, err)
+	org, err := testdb.SeedDefaultOrganisation(db, user)
+	require.NoError(t, err)
+	project, err := testdb.SeedDefaultProject(db, org.UID)
+	require.NoError(t, err)
+	endpoint, err := testdb.SeedEndpoint(db, project, "", "orders", "", false, "active")
+	require.NoError(t, err)
+	link, err := testdb.SeedPortalLink(db, project, "owner-1")
+	require.NoError(t, err)
+
+	startReplicator(t, standby, cfg)
+
+	// The initial copy carries everything that existed when the slot was made.
+	require.Eventually(t, func() bool {
+		return count(t, standby, "SELECT COUNT(*) FROM convoy.endpoints WHERE id = $1", endpoint.UID) == 1
+	}, 30*time.Second, 200*time.Millisecond)
+	require.Equal(t, 1, count(t, standby, "SELECT COUNT(*) FROM convoy.projects WHERE id = $1", project.UID))
+	require.Equal(t, count(t, source, "SELECT COUNT(*) FROM convoy.organisation_members"),
+		count(t, standby, "SELECT COUNT(*) FROM convoy.organisation_members"))
+
+	var sourceSecrets, standbySecrets string
+	require.NoError(t, source.QueryRow(ctx, "SELECT secrets::text FROM convoy.endpoints WHERE id = $1", endpoint.UID).Scan(&sourceSecrets))
+	require.NoError(t, standby.QueryRow(ctx, "SELECT secrets::text FROM convoy.endpoints WHERE id = $1", endpoint.UID).Scan(&standbySecrets))
+	require.Equal(t, sourceSecrets, standbySecrets)
+
+	// Changes after the copy stream in.
+	_, err = source.Exec(ctx, "UPDATE convoy.organisations SET name = 'renamed' WHERE id = $1", org.UID)
+	require.NoError(t, err)
+	_, err = source.Exec(ctx, "INSERT INTO convoy.portal_links_endpoints (portal_link_id, endpoint_id) VALUES ($1, $2)", link.UID, endpoint.UID)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return count(t, standby, "SELECT COUNT(*) FROM convoy.organisations WHERE id = $1 AND name = 'renamed'", org.UID) == 1 &&
+			count(t, standby, "SELECT COUNT(*) FROM convoy.portal_links_endpoints WHERE portal_link_id = $1", link.UID) == 1
+	}, 30*time.Second, 200*time.Millisecond)
+
+	// Deletes, including from a table without a primary key.
+	tx, err := source.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "DELETE FROM convoy.portal_links_endpoints WHERE portal_link_id = $1", link.UID)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "DELETE FROM convoy.endpoints WHERE id = $1", endpoint.UID)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	require.Eventually(t, func() bool {
+		return count(t, standby, "SELECT COUNT(*) FROM convoy.endpoints WHERE id = $1", endpoint.UID) == 0 &&
+			count(t, standby, "SELECT COUNT(*) FROM convoy.portal_links_endpoints WHERE portal_link_id = $1", link.UID) == 0
+	}, 30*time.Second, 200*time.Millisecond)
+
+	s, err := LoadState(ctx, standby, cfg.SlotName)
+	require.NoError(t, err)
+	require.NotNil(t, s.SourceCommitAt)
+	require.Nil(t, s.PromotedAt)
+}
+
+func TestReplicator_PromoteStopsTheStream(t *testing.T) {
+	source, standby, cfg := setupRegions(t)
+	ctx := context.Background()
+
+	_, err := testdb.SeedDefaultUser(postgres.NewFromConnection(source))
+	require.NoError(t, err)
+
+	_, done := startReplicator(t, standby, cfg)
+
+	require.Eventually(t, func() bool {
+		_, err := LoadState(ctx, standby, cfg.SlotName)
+		return err == nil
+	}, 30*time.Second, 200*time.Millisecond)
+
+	require.ErrorIs(t, EnsureNotStandby(ctx, standby), ErrUnpromotedStandby)
+
+	s, err := Promote(ctx, standby, cfg.SlotName)
+	require.NoError(t, err)
+	require.NotNil(t, s.PromotedAt)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * standbyStatusInterval):
+		t.Fatal("replicator did not stop after promotion")
+	}
+
+	require.NoError(t, EnsureNotStandby(ctx, standby))
+
+	again, err := Promote(ctx, standby, cfg.SlotName)
+	require.NoError(t, err)
+	require.Equal(t, s.PromotedAt.UTC(), again.PromotedAt.UTC(), "promoting twice keeps the first time")
+}
+
+func TestReplicator_RefusesAStandbyWithConfiguration(t *testing.T) {
+	_, standby, cfg := setupRegions(t)
+
+	_, err := testdb.SeedDefaultUser(postgres.NewFromConnection(standby))
+	require.NoError(t, err)
+
+	_, done := startReplicator(t, standby, cfg)
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, ErrStandbyNotEmpty)
+	case <-time.After(30 * time.Second):
+		t.Fatal("replicator did not refuse a non-empty standby")
+	}
+
+	_, err = LoadState(context.Background(), standby, cfg.SlotName)
+	require.ErrorIs(t, err, ErrNoState)
+}
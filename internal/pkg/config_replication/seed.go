@@ -0,0 +1,203 @@
+package config_replication
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgx/v5"
+)
+
+// seedBatchSize is how many rows the initial copy sends the standby per round
+// trip.
+const seedBatchSize = 500
+
+// checkSchema refuses a source that has run migrations the standby has not.
+// Changes from such a source may name columns the standby lacks; the apply
+// would catch that too, but only once such a change arrived, and the initial
+// copy would already have failed part way.
+func (r *Replicator) checkSchema(ctx context.Context, src *pgx.Conn) error {
+	const migrationsSQL = "SELECT id FROM convoy.gorp_migrations"
+
+	rows, err := src.Query(ctx, migrationsSQL)
+	if err != nil {
+		return fmt.Errorf("read source migrations: %w", err)
+	}
+	sourceIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("read source migrations: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, migrationsSQL)
+	if err != nil {
+		return fmt.Errorf("read standby migrations: %w", err)
+	}
+	standbyIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("read standby migrations: %w", err)
+	}
+
+	if missing := missingMigrations(sourceIDs, standbyIDs); len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrSchemaBehind, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkPublication refuses a source whose publication does not carry every
+// replicated table, since changes to a table left out would never arrive.
+func (r *Replicator) checkPublication(ctx context.Context, src *pgx.Conn) error {
+	rows, err := src.Query(ctx, "SELECT tablename FROM pg_publication_tables WHERE pubname = $1 AND schemaname = $2", publication, schema)
+	if err != nil {
+		return fmt.Errorf("read source publication: %w", err)
+	}
+	published, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("read source publication: %w", err)
+	}
+
+	if missing := unpublishedTables(published); len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrPublicationIncomplete, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// unpublishedTables is the replicated tables the publication does not carry,
+// qualified so they can be pasted into ALTER PUBLICATION.
+func unpublishedTables(published []string) []string {
+	have := make(map[string]struct{}, len(published))
+	for _, name := range published {
+		have[name] = struct{}{}
+	}
+
+	var missing []string
+	for _, name := range tables {
+		if _, ok := have[name]; !ok {
+			missing = append(missing, schema+"."+name)
+		}
+	}
+	return missing
+}
+
+// missingMigrations is the source's migrations the standby has not run.
+func missingMigrations(source, standby []string) []string {
+	have := make(map[string]struct{}, len(standby))
+	for _, id := range standby {
+		have[id] = struct{}{}
+	}
+
+	var missing []string
+	for _, id := range source {
+		if _, ok := have[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// seed copies the source's configuration into the standby as of the
+// snapshot the slot was created with, so the stream picks up exactly where
+// the copy leaves off. The copy and the state row commit together: a copy
+// that fails part way leaves the standby empty, and the next attempt starts
+// over with a new slot.
+func (r *Replicator) seed(ctx context.Context, src *pgx.Conn, snapshot string, lsn pglogrepl.LSN) error {
+	srcTx, err := src.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("begin source snapshot: %w", err)
+	}
+	defer func() { _ = srcTx.Rollback(ctx) }()
+
+	_, err = srcTx.Exec(ctx, "SET TRANSACTION SNAPSHOT '"+strings.ReplaceAll(snapshot, "'", "''")+"'")
+	if err != nil {
+		return fmt.Errorf("import snapshot %s: %w", snapshot, err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, name := range tables {
+		var exists bool
+		err = tx.QueryRow(ctx, fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", pgx.Identifier{schema, name}.Sanitize())).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("check %s.%s is empty: %w", schema, name, err)
+		}
+		if exists {
+			return fmt.Errorf("%s.%s has rows: %w", schema, name, ErrStandbyNotEmpty)
+		}
+	}
+
+	a := newApplier()
+	for _, name := range tables {
+		n, err := copyTable(ctx, srcTx, tx, a, name)
+		if err != nil {
+			return fmt.Errorf("copy %s.%s: %w", schema, name, err)
+		}
+		r.logger.Info(fmt.Sprintf("config replication: copied %d rows of %s.%s", n, schema, name))
+	}
+
+	if err = createState(ctx, tx, r.slotName, lsn); err != nil {
+		return fmt.Errorf("record replication state: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// copyTable upserts every row of one source table into the standby. Rows are
+// read in text form, the same form the stream sends, so they go through the
+// same statements as streamed changes.
+func copyTable(ctx context.Context, src pgx.Tx, dst pgx.Tx, a *applier, name string) (int, error) {
+	t, err := a.table(ctx, dst, name)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := src.Query(ctx, fmt.Sprintf("SELECT * FROM %s", pgx.Identifier{schema, name}.Sanitize()),
+		pgx.QueryResultFormats{pgx.TextFormatCode})
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	batch := &pgx.Batch{}
+	n := 0
+	for rows.Next() {
+		raw := rows.RawValues()
+		r := make(row, len(fields))
+		for i, f := range fields {
+			if raw[i] == nil {
+				r[f.Name] = nil
+				continue
+			}
+			v := string(raw[i])
+			r[f.Name] = &v
+		}
+
+		query, args, err := t.upsert(r)
+		if err != nil {
+			return n, err
+		}
+		batch.Queue(query, args...)
+		n++
+
+		if batch.Len() >= seedBatchSize {
+			if err = dst.SendBatch(ctx, batch).Close(); err != nil {
+				return n, err
+			}
+			batch = &pgx.Batch{}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+	if batch.Len() > 0 {
+		if err = dst.SendBatch(ctx, batch).Close(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,26 @@
+package config_replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingMigrations(t *testing.T) {
+	require.Empty(t, missingMigrations([]string{"1.sql", "2.sql"}, []string{"2.sql", "1.sql"}))
+	require.Empty(t, missingMigrations([]string{"1.sql"}, []string{"1.sql", "2.sql"}), "a standby ahead of the source is fine")
+	require.Equal(t, []string{"2.sql", "3.sql"}, missingMigrations([]string{"3.sql", "1.sql", "2.sql"}, []string{"1.sql"}))
+}
+
+func TestUnpublishedTables(t *testing.T) {
+	require.Empty(t, unpublishedTables(tables))
+	require.Len(t, unpublishedTables(nil), len(tables), "a missing publication lacks every table")
+
+	published := make([]string, 0, len(tables))
+	for _, name := range tables {
+		if name != "circuit_breaker_overrides" && name != "saved_searches" {
+			published = append(published, name)
+		}
+	}
+	require.Equal(t, []string{"convoy.saved_searches", "convoy.circuit_breaker_overrides"}, unpublishedTables(published))
+}
@@ -0,0 +1,199 @@
+package config_replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	// ErrPromoted stops replication into a standby that has been promoted.
+	ErrPromoted = errors.New("standby has been promoted")
+
+	// ErrUnpromotedStandby keeps the server and agent off a standby until
+	// it is promoted: their writes would be overwritten by the stream, and
+	// would make the configuration diverge from the source's.
+	ErrUnpromotedStandby = errors.New("this database is a configuration standby that has not been promoted; run `convoy replication promote` to fail over to it")
+
+	// ErrNoState means no standby has been seeded on this database for the
+	// slot.
+	ErrNoState = errors.New("no configuration replication state for this slot")
+
+	// ErrSchemaBehind means the source has migrations the standby lacks.
+	// Migrations go to the standby first.
+	ErrSchemaBehind = errors.New("standby schema is behind the source; run migrations on the standby first")
+
+	// ErrStandbyNotEmpty refuses an initial copy over existing
+	// configuration, which the copy would otherwise merge with.
+	ErrStandbyNotEmpty = errors.New("standby already holds configuration; seed into a freshly migrated database")
+
+	// ErrSlotLost means the source no longer has the slot a seeded standby
+	// was streaming from, so the changes since are gone and the standby
+	// must be seeded again.
+	ErrSlotLost = errors.New("replication slot is missing on the source; re-seed the standby into a fresh database")
+
+	// ErrPublicationIncomplete means the source's publication is missing or
+	// lacks replicated tables, which happens when the role that migrated the
+	// source could not create or alter it.
+	ErrPublicationIncomplete = errors.New("source publication convoy_config is missing replicated tables; create or alter it with a role that owns the tables")
+)
+
+// State is how far a standby has got.
+type State struct {
+	SlotName   string
+	AppliedLSN pglogrepl.LSN
+	// SourceWALEnd is the end of the source's WAL at the last heartbeat.
+	SourceWALEnd pglogrepl.LSN
+	// SourceCommitAt is when the last applied transaction committed on the
+	// source.
+	SourceCommitAt *time.Time
+	HeartbeatAt    time.Time
+	PromotedAt     *time.Time
+}
+
+// BytesBehind is how much source WAL the standby had yet to read at the last
+// heartbeat.
+func (s *State) BytesBehind() uint64 {
+	if s.SourceWALEnd <= s.AppliedLSN {
+		return 0
+	}
+	return uint64(s.SourceWALEnd - s.AppliedLSN)
+}
+
+const stateSQL = `
+	SELECT slot_name, applied_lsn::text, COALESCE(source_wal_end, applied_lsn)::text,
+	       source_commit_at, heartbeat_at, promoted_at
+	FROM convoy.config_replication_state
+	WHERE slot_name = $1`
+
+// LoadState reads slot's state, or ErrNoState.
+func LoadState(ctx context.Context, pool *pgxpool.Pool, slot string) (*State, error) {
+	var (
+		s               State
+		applied, walEnd string
+	)
+	err := pool.QueryRow(ctx, stateSQL, slot).Scan(
+		&s.SlotName, &applied, &walEnd, &s.SourceCommitAt, &s.HeartbeatAt, &s.PromotedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNoState
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.AppliedLSN, err = pglogrepl.ParseLSN(applied); err != nil {
+		return nil, err
+	}
+	if s.SourceWALEnd, err = pglogrepl.ParseLSN(walEnd); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Promote marks slot's standby promoted. The stream stops at its next
+// transaction or heartbeat, whichever comes first, and the server and agent
+// may start. Promoting twice is not an error; the first time is kept.
+func Promote(ctx context.Context, pool *pgxpool.Pool, slot string) (*State, error) {
+	tag, err := pool.Exec(ctx, `
+		UPDATE convoy.config_replication_state
+		SET promoted_at = COALESCE(promoted_at, NOW()),
+		    updated_at = NOW()
+		WHERE slot_name = $1`, slot)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrNoState
+	}
+	return LoadState(ctx, pool, slot)
+}
+
+// EnsureNotStandby fails with ErrUnpromotedStandby when this database is a
+// standby still being replicated into. A database that predates the state
+// table is not a standby.
+func EnsureNotStandby(ctx context.Context, pool *pgxpool.Pool) error {
+	var slot string
+	err := pool.QueryRow(ctx, `
+		SELECT slot_name
+		FROM convoy.config_replication_state
+		WHERE promoted_at IS NULL
+		LIMIT 1`).Scan(&slot)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil
+	case errors.As(err, &pgErr) && pgErr.Code == "42P01": // undefined_table
+		return nil
+	case err != nil:
+		return fmt.Errorf("check configuration replication state: %w", err)
+	}
+	return fmt.Errorf("slot %q: %w", slot, ErrUnpromotedStandby)
+}
+
+// lockState takes the state row for the transaction applying a change, so a
+// promote either lands before it, and the change is refused, or waits for it.
+func lockState(ctx context.Context, tx pgx.Tx, slot string) error {
+	var promotedAt *time.Time
+	err := tx.QueryRow(ctx, `
+		SELECT promoted_at
+		FROM convoy.config_replication_state
+		WHERE slot_name = $1
+		FOR UPDATE`, slot).Scan(&promotedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNoState
+	}
+	if err != nil {
+		return err
+	}
+	if promotedAt != nil {
+		return ErrPromoted
+	}
+	return nil
+}
+
+// saveApplied records a transaction as applied, in the transaction that
+// applied it, so a change is never applied without its position being kept
+// or the other way round.
+func saveApplied(ctx context.Context, tx pgx.Tx, slot string, lsn pglogrepl.LSN, commitAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE convoy.config_replication_state
+		SET applied_lsn = $2::pg_lsn,
+		    source_commit_at = $3,
+		    updated_at = NOW()
+		WHERE slot_name = $1`, slot, lsn.String(), commitAt)
+	return err
+}
+
+// createState records a freshly seeded standby in the seeding transaction.
+func createState(ctx context.Context, tx pgx.Tx, slot string, lsn pglogrepl.LSN) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO convoy.config_replication_state (slot_name, applied_lsn, source_wal_end)
+		VALUES ($1, $2::pg_lsn, $2::pg_lsn)`, slot, lsn.String())
+	return err
+}
+
+// heartbeat records that the stream is alive and how far the source's WAL
+// reaches. While no transaction is in flight, everything up to walEnd has
+// been seen, so applied moves up with it. It reports whether the standby has
+// been promoted since.
+func heartbeat(ctx context.Context, pool *pgxpool.Pool, slot string, applied, walEnd pglogrepl.LSN) (bool, error) {
+	var promoted bool
+	err := pool.QueryRow(ctx, `
+		UPDATE convoy.config_replication_state
+		SET applied_lsn = GREATEST(applied_lsn, $2::pg_lsn),
+		    source_wal_end = $3::pg_lsn,
+		    heartbeat_at = NOW()
+		WHERE slot_name = $1
+		RETURNING promoted_at IS NOT NULL`, slot, applied.String(), walEnd.String()).Scan(&promoted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrNoState
+	}
+	return promoted, err
+}
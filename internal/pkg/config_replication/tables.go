@@ -0,0 +1,289 @@
+package config_replication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// publication is what the standby subscribes to; sql/1788750400.sql
+	// creates it on every database whose role may, so the one the standby
+	// streams from has it whichever region that is.
+	publication = "convoy_config"
+	schema      = "convoy"
+)
+
+// tables are the tables the publication carries, parents before children.
+// The initial copy loads them in this order, so the standby's foreign keys
+// hold throughout; after that, changes arrive in the order the source
+// committed them.
+var tables = []string{
+	"users",
+	"organisations",
+	"project_configurations",
+	"projects",
+	"saved_searches",
+	"source_verifiers",
+	"sources",
+	"endpoints",
+	"circuit_breaker_overrides",
+	"devices",
+	"event_types",
+	"event_type_versions",
+	"subscriptions",
+	"subscription_event_type_versions",
+	"filters",
+	"portal_links",
+	"portal_links_endpoints",
+	"portal_tokens",
+	"organisation_members",
+	"api_keys",
+	"alert_rules",
+	"email_templates",
+	"email_branding",
+	"endpoint_health_checks",
+}
+
+// querier is what reads the standby's catalog: the pool, or the transaction
+// a copy or a change is applied in.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// table is a replicated table as the standby has it. Values from the source
+// arrive as text and are cast to the standby's column types, so a column
+// whose type differs between regions still applies as long as its text form
+// parses.
+type table struct {
+	name    string
+	columns []string
+	types   map[string]string
+	// generated columns are computed by the standby, so values the source
+	// sends for them are dropped.
+	generated map[string]bool
+	// key is the primary key. A table without one is keyed on every column,
+	// which is what its FULL replica identity sends for updates and deletes.
+	key   []string
+	keyed bool
+}
+
+const columnsSQL = `
+	SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attgenerated <> ''
+	FROM pg_attribute a
+	WHERE a.attrelid = to_regclass($1)
+	  AND a.attnum > 0
+	  AND NOT a.attisdropped
+	ORDER BY a.attnum`
+
+const primaryKeySQL = `
+	SELECT a.attname
+	FROM pg_index i
+	CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, n)
+	JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+	WHERE i.indrelid = to_regclass($1)
+	  AND i.indisprimary
+	ORDER BY k.n`
+
+// loadTable reads name's columns and primary key from the standby. A table
+// the standby does not have means its migrations are behind the source's.
+func loadTable(ctx context.Context, q querier, name string) (*table, error) {
+	qualified := schema + "." + name
+
+	rows, err := q.Query(ctx, columnsSQL, qualified)
+	if err != nil {
+		return nil, fmt.Errorf("read columns of %s: %w", qualified, err)
+	}
+	t := &table{name: name, types: make(map[string]string), generated: make(map[string]bool)}
+	for rows.Next() {
+		var (
+			col, typ  string
+			generated bool
+		)
+		if err = rows.Scan(&col, &typ, &generated); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if generated {
+			t.generated[col] = true
+			continue
+		}
+		t.columns = append(t.columns, col)
+		t.types[col] = typ
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.columns) == 0 {
+		return nil, fmt.Errorf("%s does not exist on the standby: %w", qualified, ErrSchemaBehind)
+	}
+
+	rows, err = q.Query(ctx, primaryKeySQL, qualified)
+	if err != nil {
+		return nil, fmt.Errorf("read primary key of %s: %w", qualified, err)
+	}
+	t.key, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	t.keyed = len(t.key) > 0
+	if !t.keyed {
+		t.key = t.columns
+	}
+	return t, nil
+}
+
+// row is one row from the source by column name. A nil value is NULL. A
+// column that is absent was not sent, which for an update means an unchanged
+// TOAST value, and is left as the standby has it.
+type row map[string]*string
+
+// check rejects a row with a column the standby lacks, which means the
+// source has run a migration the standby has not.
+func (t *table) check(r row) error {
+	for col := range r {
+		if _, ok := t.types[col]; !ok && !t.generated[col] {
+			return fmt.Errorf("%s.%s.%s does not exist on the standby: %w", schema, t.name, col, ErrSchemaBehind)
+		}
+	}
+	return nil
+}
+
+// present is r's columns in the standby's column order, so the statement
+// for a given set of columns is always the same text.
+func (t *table) present(r row) []string {
+	cols := make([]string, 0, len(r))
+	for _, col := range t.columns {
+		if _, ok := r[col]; ok {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+func (t *table) ident() string {
+	return pgx.Identifier{schema, t.name}.Sanitize()
+}
+
+// param is the placeholder for column col at position n.
+func (t *table) param(n int, col string) string {
+	return fmt.Sprintf("$%d::text::%s", n, t.types[col])
+}
+
+// upsert writes r: inserted if the standby lacks it, otherwise the columns r
+// carries are overwritten. Replaying a change that was already applied
+// leaves the row as it was, which is what makes a retried transaction safe.
+func (t *table) upsert(r row) (string, []any, error) {
+	if err := t.check(r); err != nil {
+		return "", nil, err
+	}
+
+	cols := t.present(r)
+	names := make([]string, len(cols))
+	params := make([]string, len(cols))
+	args := make([]any, len(cols))
+	for i, col := range cols {
+		names[i] = pgx.Identifier{col}.Sanitize()
+		params[i] = t.param(i+1, col)
+		args[i] = r[col]
+	}
+
+	if !t.keyed {
+		conds := make([]string, len(cols))
+		for i := range cols {
+			conds[i] = fmt.Sprintf("%s IS NOT DISTINCT FROM %s", names[i], params[i])
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s) SELECT %s WHERE NOT EXISTS (SELECT 1 FROM %s WHERE %s)",
+			t.ident(), strings.Join(names, ", "), strings.Join(params, ", "), t.ident(), strings.Join(conds, " AND ")), args, nil
+	}
+
+	for _, k := range t.key {
+		if _, ok := r[k]; !ok {
+			return "", nil, fmt.Errorf("%s.%s row has no %s", schema, t.name, k)
+		}
+	}
+
+	keys := make([]string, len(t.key))
+	for i, k := range t.key {
+		keys[i] = pgx.Identifier{k}.Sanitize()
+	}
+
+	var sets []string
+	for i, col := range cols {
+		if !t.isKey(col) {
+			sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", names[i], names[i]))
+		}
+	}
+	conflict := "DO NOTHING"
+	if len(sets) > 0 {
+		conflict = "DO UPDATE SET " + strings.Join(sets, ", ")
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) %s",
+		t.ident(), strings.Join(names, ", "), strings.Join(params, ", "), strings.Join(keys, ", "), conflict), args, nil
+}
+
+// remove deletes the row old identifies. A row the standby does not have is
+// not an error: a cascade on the standby may have removed it already.
+func (t *table) remove(old row) (string, []any, error) {
+	if err := t.check(old); err != nil {
+		return "", nil, err
+	}
+
+	conds := make([]string, len(t.key))
+	args := make([]any, len(t.key))
+	for i, k := range t.key {
+		v, ok := old[k]
+		if !ok {
+			return "", nil, fmt.Errorf("%s.%s delete has no %s", schema, t.name, k)
+		}
+		op := "="
+		if !t.keyed {
+			op = "IS NOT DISTINCT FROM"
+		}
+		conds[i] = fmt.Sprintf("%s %s %s", pgx.Identifier{k}.Sanitize(), op, t.param(i+1, k))
+		args[i] = v
+	}
+
+	if !t.keyed {
+		// Identical rows are indistinguishable, so each delete takes one.
+		return fmt.Sprintf("DELETE FROM %s WHERE ctid = (SELECT ctid FROM %s WHERE %s LIMIT 1)",
+			t.ident(), t.ident(), strings.Join(conds, " AND ")), args, nil
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE %s", t.ident(), strings.Join(conds, " AND ")), args, nil
+}
+
+// rekeyed reports whether an update moved its row to a new key, in which
+// case the row under the old key is removed before the new one is written.
+func (t *table) rekeyed(old, updated row) bool {
+	if old == nil {
+		return false
+	}
+	for _, k := range t.key {
+		o, n := old[k], updated[k]
+		if (o == nil) != (n == nil) || (o != nil && *o != *n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *table) isKey(col string) bool {
+	if !t.keyed {
+		return false
+	}
+	for _, k := range t.key {
+		if k == col {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package config_replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func str(s string) *string { return &s }
+
+func endpointsTable() *table {
+	return &table{
+		name:      "endpoints",
+		columns:   []string{"id", "name", "secrets", "deleted_at"},
+		types:     map[string]string{"id": "character varying", "name": "text", "secrets": "jsonb", "deleted_at": "timestamp with time zone"},
+		generated: map[string]bool{},
+		key:       []string{"id"},
+		keyed:     true,
+	}
+}
+
+func portalLinksEndpointsTable() *table {
+	cols := []string{"portal_link_id", "endpoint_id"}
+	return &table{
+		name:      "portal_links_endpoints",
+		columns:   cols,
+		types:     map[string]string{"portal_link_id": "character varying(26)", "endpoint_id": "character varying(26)"},
+		generated: map[string]bool{},
+		key:       cols,
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     *table
+		row       row
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:  "keyed row",
+			table: endpointsTable(),
+			row:   row{"id": str("ep-1"), "name": str("orders"), "secrets": str(`[{"value":"s"}]`), "deleted_at": nil},
+			wantQuery: `INSERT INTO "convoy"."endpoints" ("id", "name", "secrets", "deleted_at") ` +
+				`VALUES ($1::text::character varying, $2::text::text, $3::text::jsonb, $4::text::timestamp with time zone) ` +
+				`ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "secrets" = EXCLUDED."secrets", "deleted_at" = EXCLUDED."deleted_at"`,
+			wantArgs: []any{str("ep-1"), str("orders"), str(`[{"value":"s"}]`), (*string)(nil)},
+		},
+		{
+			name:  "unchanged toast column is left alone",
+			table: endpointsTable(),
+			row:   row{"id": str("ep-1"), "name": str("orders")},
+			wantQuery: `INSERT INTO "convoy"."endpoints" ("id", "name") ` +
+				`VALUES ($1::text::character varying, $2::text::text) ` +
+				`ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+			wantArgs: []any{str("ep-1"), str("orders")},
+		},
+		{
+			name:  "key only",
+			table: endpointsTable(),
+			row:   row{"id": str("ep-1")},
+			wantQuery: `INSERT INTO "convoy"."endpoints" ("id") ` +
+				`VALUES ($1::text::character varying) ON CONFLICT ("id") DO NOTHING`,
+			wantArgs: []any{str("ep-1")},
+		},
+		{
+			name:  "table without a primary key",
+			table: portalLinksEndpointsTable(),
+			row:   row{"portal_link_id": str("pl-1"), "endpoint_id": str("ep-1")},
+			wantQuery: `INSERT INTO "convoy"."portal_links_endpoints" ("portal_link_id", "endpoint_id") ` +
+				`SELECT $1::text::character varying(26), $2::text::character varying(26) ` +
+				`WHERE NOT EXISTS (SELECT 1 FROM "convoy"."portal_links_endpoints" ` +
+				`WHERE "portal_link_id" IS NOT DISTINCT FROM $1::text::character varying(26) ` +
+				`AND "endpoint_id" IS NOT DISTINCT FROM $2::text::character varying(26))`,
+			wantArgs: []any{str("pl-1"), str("ep-1")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.table.upsert(tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUpsertRejects(t *testing.T) {
+	_, _, err := endpointsTable().upsert(row{"id": str("ep-1"), "added_on_source": str("x")})
+	require.ErrorIs(t, err, ErrSchemaBehind)
+
+	_, _, err = endpointsTable().upsert(row{"name": str("orders")})
+	require.EqualError(t, err, "convoy.endpoints row has no id")
+}
+
+func TestUpsertDropsGeneratedColumns(t *testing.T) {
+	tbl := endpointsTable()
+	tbl.generated["search"] = true
+
+	query, args, err := tbl.upsert(row{"id": str("ep-1"), "search": str("'orders'")})
+	require.NoError(t, err)
+	require.Equal(t, `INSERT INTO "convoy"."endpoints" ("id") VALUES ($1::text::character varying) ON CONFLICT ("id") DO NOTHING`, query)
+	require.Equal(t, []any{str("ep-1")}, args)
+}
+
+func TestRemove(t *testing.T) {
+	query, args, err := endpointsTable().remove(row{"id": str("ep-1"), "name": nil, "secrets": nil, "deleted_at": nil})
+	require.NoError(t, err)
+	require.Equal(t, `DELETE FROM "convoy"."endpoints" WHERE "id" = $1::text::character varying`, query)
+	require.Equal(t, []any{str("ep-1")}, args)
+
+	query, args, err = portalLinksEndpointsTable().remove(row{"portal_link_id": str("pl-1"), "endpoint_id": str("ep-1")})
+	require.NoError(t, err)
+	require.Equal(t, `DELETE FROM "convoy"."portal_links_endpoints" WHERE ctid = (SELECT ctid FROM "convoy"."portal_links_endpoints" `+
+		`WHERE "portal_link_id" IS NOT DISTINCT FROM $1::text::character varying(26) `+
+		`AND "endpoint_id" IS NOT DISTINCT FROM $2::text::character varying(26) LIMIT 1)`, query)
+	require.Equal(t, []any{str("pl-1"), str("ep-1")}, args)
+
+	_, _, err = endpointsTable().remove(row{"name": str("orders")})
+	require.EqualError(t, err, "convoy.endpoints delete has no id")
+}
+
+func TestRekeyed(t *testing.T) {
+	tbl := endpointsTable()
+
+	require.False(t, tbl.rekeyed(nil, row{"id": str("ep-1")}))
+	require.False(t, tbl.rekeyed(row{"id": str("ep-1")}, row{"id": str("ep-1"), "name": str("x")}))
+	require.True(t, tbl.rekeyed(row{"id": str("ep-1")}, row{"id": str("ep-2")}))
+
+	unkeyed := portalLinksEndpointsTable()
+	require.True(t, unkeyed.rekeyed(
+		row{"portal_link_id": str("pl-1"), "endpoint_id": str("ep-1")},
+		row{"portal_link_id": str("pl-1"), "endpoint_id": str("ep-2")}))
+}
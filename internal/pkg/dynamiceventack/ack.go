@@ -100,16 +100,9 @@ func (a *cacheAcker) Wait(ctx context.Context, projectID, eventID string, timeou
 	defer ticker.Stop()
 
 	for {
-		var cached cachedResult
-		found, err := a.cache.Consume(ctx, key, &cached)
-		if err != nil {
-			return Result{}, fmt.Errorf("wait for dynamic event ack: %w", err)
-		}
-		if found {
-			if !cached.Published {
-				return Result{}, ErrInvalidCached
-			}
-			return cached.Result, nil
+		result, found, err := consumeCached(ctx, a.cache, key)
+		if err != nil || found {
+			return result, err
 		}
 
 		select {
@@ -122,6 +115,22 @@ func (a *cacheAcker) Wait(ctx context.Context, projectID, eventID string, timeou
 	}
 }
 
+// consumeCached takes a published result out of c, so no other waiter sees it.
+func consumeCached(ctx context.Context, c ConsumingCache, key string) (Result, bool, error) {
+	var cached cachedResult
+	found, err := c.Consume(ctx, key, &cached)
+	if err != nil {
+		return Result{}, false, fmt.Errorf("wait for dynamic event ack: %w", err)
+	}
+	if !found {
+		return Result{}, false, nil
+	}
+	if !cached.Published {
+		return Result{}, false, ErrInvalidCached
+	}
+	return cached.Result, true, nil
+}
+
 // Publish stores the resolve outcome for a waiting HTTP request.
 // Failure policy: callers should log publish errors; the waiter fail-closes on timeout.
 func Publish(ctx context.Context, rdb redis.UniversalClient, projectID, eventID string, result Result) error {
@@ -0,0 +1,280 @@
+package dynamiceventack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+const (
+	ackNotifyChannel = "convoy_dynamic_event_ack"
+
+	// ackBackstopInterval is how often a waiter re-reads the cache without a
+	// notification, which covers a NOTIFY lost while the listener reconnects.
+	ackBackstopInterval = time.Second
+
+	ackListenerMinReconnect = 10 * time.Second
+	ackListenerMaxReconnect = time.Minute
+	ackListenerPingInterval = 90 * time.Second
+	ackNotifyTimeout        = time.Second
+)
+
+// PostgresAcker stores results in the Postgres cache like the cache acker, but
+// wakes waiters with LISTEN/NOTIFY instead of polling, so a deployment with
+// only Postgres answers a dynamic event as soon as it is resolved without a
+// query every few milliseconds per request.
+//
+// One listener connection serves every waiter in the process; each
+// notification carries the result's key, and only the waiters on that key
+// re-read the cache.
+type PostgresAcker struct {
+	cache    ConsumingCache
+	db       *sqlx.DB
+	logger   log.Logger
+	backstop time.Duration
+
+	// notify publishes a wakeup for key. It is pg_notify in production.
+	notify func(ctx context.Context, key string) error
+
+	mu      sync.Mutex
+	waiters map[string]map[chan struct{}]struct{}
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// NewPostgresAcker starts the listener on connString. An empty connString
+// leaves waiters on the backstop poll alone.
+func NewPostgresAcker(db *sqlx.DB, c ConsumingCache, connString string, logger log.Logger) *PostgresAcker {
+	a := &PostgresAcker{
+		cache:    c,
+		db:       db,
+		logger:   logger,
+		backstop: ackBackstopInterval,
+		waiters:  make(map[string]map[chan struct{}]struct{}),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	a.notify = a.pgNotify
+
+	if connString == "" {
+		close(a.done)
+		return a
+	}
+
+	go a.runListener(connString)
+	return a
+}
+
+// Publish stores result and wakes the waiters on it. Failure policy: a failed
+// store is returned; a failed NOTIFY is not, since the waiter's backstop poll
+// still finds the stored result.
+func (a *PostgresAcker) Publish(ctx context.Context, projectID, eventID string, result Result) error {
+	if a.cache == nil {
+		return ErrNilCache
+	}
+
+	key := redisKey(projectID, eventID)
+	if err := a.cache.Set(ctx, key, cachedResult{Published: true, Result: result}, resultTTL); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ackNotifyTimeout)
+	defer cancel()
+	_ = a.notify(ctx, key)
+
+	return nil
+}
+
+// Wait blocks until a result is published or timeout elapses. It subscribes
+// before the first read, so a result published between the read and the
+// subscription cannot be missed. Failure policy: timeout and store errors fail
+// closed (no 201).
+func (a *PostgresAcker) Wait(ctx context.Context, projectID, eventID string, timeout time.Duration) (Result, error) {
+	if a.cache == nil {
+		return Result{}, ErrNilCache
+	}
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	key := redisKey(projectID, eventID)
+	wake, unsubscribe := a.subscribe(key)
+	defer unsubscribe()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	backstop := time.NewTicker(a.backstop)
+	defer backstop.Stop()
+
+	for {
+		result, found, err := consumeCached(ctx, a.cache, key)
+		if err != nil || found {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return Result{}, fmt.Errorf("wait for dynamic event ack: %w", ctx.Err())
+		case <-timer.C:
+			return Result{}, ErrTimeout
+		case <-wake:
+		case <-backstop.C:
+		}
+	}
+}
+
+// Close stops the listener.
+func (a *PostgresAcker) Close() error {
+	select {
+	case <-a.quit:
+	default:
+		close(a.quit)
+	}
+	<-a.done
+	return nil
+}
+
+func (a *PostgresAcker) subscribe(key string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	a.mu.Lock()
+	if a.waiters[key] == nil {
+		a.waiters[key] = make(map[chan struct{}]struct{})
+	}
+	a.waiters[key][ch] = struct{}{}
+	a.mu.Unlock()
+
+	return ch, func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		delete(a.waiters[key], ch)
+		if len(a.waiters[key]) == 0 {
+			delete(a.waiters, key)
+		}
+	}
+}
+
+// wake signals the waiters on key, or every waiter when key is empty.
+func (a *PostgresAcker) wake(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for k, chans := range a.waiters {
+		if key != "" && k != key {
+			continue
+		}
+		for ch := range chans {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (a *PostgresAcker) pgNotify(ctx context.Context, key string) error {
+	if a.db == nil {
+		return nil
+	}
+	_, err := a.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", ackNotifyChannel, key)
+	return err
+}
+
+func (a *PostgresAcker) runListener(connString string) {
+	defer close(a.done)
+
+	listener := pq.NewListener(connString, ackListenerMinReconnect, ackListenerMaxReconnect, a.logListenerEvent)
+	defer listener.Close()
+
+	// Listen blocks while the connection is down; closing the listener is
+	// what lets Close end that wait.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-a.quit:
+			_ = listener.Close()
+		case <-stop:
+		}
+	}()
+
+	if !a.listen(listener) {
+		return
+	}
+
+	ping := time.NewTicker(ackListenerPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-a.quit:
+			return
+		case <-ping.C:
+			_ = listener.Ping()
+		case n, ok := <-listener.Notify:
+			if !ok {
+				return
+			}
+			// A nil notification follows a reconnect, after which anything sent
+			// while disconnected is lost; every waiter re-reads.
+			if n == nil {
+				a.wake("")
+				continue
+			}
+			a.wake(n.Extra)
+		}
+	}
+}
+
+// listen subscribes to the ack channel, retrying a refused LISTEN with backoff
+// until it succeeds or the acker closes. Until then waiters are answered by
+// the backstop poll alone, so every failure is logged.
+func (a *PostgresAcker) listen(listener *pq.Listener) bool {
+	delay := ackListenerMinReconnect
+	for {
+		err := listener.Listen(ackNotifyChannel)
+		if err == nil || errors.Is(err, pq.ErrChannelAlreadyOpen) {
+			return true
+		}
+
+		select {
+		case <-a.quit:
+			return false
+		default:
+		}
+		a.logger.Error("dynamic event ack listener could not LISTEN, waiters fall back to polling", "retry_in", delay.String(), "error", err.Error())
+
+		select {
+		case <-a.quit:
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, ackListenerMaxReconnect)
+	}
+}
+
+// logListenerEvent reports the listener's connection failures; pq reconnects
+// on its own, and waiters poll until it does.
+func (a *PostgresAcker) logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		a.logger.Warn("dynamic event ack listener disconnected, waiters fall back to polling", "error", errString(err))
+	case pq.ListenerEventConnectionAttemptFailed:
+		a.logger.Error("dynamic event ack listener could not connect", "error", errString(err))
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,149 @@
+package dynamiceventack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// loopbackAcker is a PostgresAcker whose NOTIFY is delivered straight to its
+// own waiters, as the listener would, and whose backstop is too slow to be
+// what answers a test.
+func loopbackAcker(t *testing.T) *PostgresAcker {
+	t.Helper()
+
+	a := NewPostgresAcker(nil, mcache.NewMemoryCache(), "", log.New("convoy", log.LevelError))
+	a.backstop = time.Hour
+	a.notify = func(_ context.Context, key string) error {
+		a.wake(key)
+		return nil
+	}
+	t.Cleanup(func() { _ = a.Close() })
+
+	return a
+}
+
+func TestPostgresAckerWakesWaiterOnNotify(t *testing.T) {
+	a := loopbackAcker(t)
+	ctx := context.Background()
+
+	done := make(chan Result, 1)
+	go func() {
+		result, err := a.Wait(ctx, "project", "event", 5*time.Second)
+		require.NoError(t, err)
+		done <- result
+	}()
+
+	require.Eventually(t, func() bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return len(a.waiters) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	start := time.Now()
+	require.NoError(t, a.Publish(ctx, "project", "event", Result{Error: "bad template"}))
+
+	select {
+	case result := <-done:
+		require.Equal(t, "bad template", result.Error)
+		require.Less(t, time.Since(start), time.Second)
+	case <-time.After(2 * time.Second):
+		t.Fatal("waiter was not woken by the notification")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	require.Empty(t, a.waiters)
+}
+
+func TestPostgresAckerFindsResultPublishedBeforeWait(t *testing.T) {
+	a := loopbackAcker(t)
+	ctx := context.Background()
+
+	require.NoError(t, a.Publish(ctx, "project", "early", Result{OK: true}))
+
+	result, err := a.Wait(ctx, "project", "early", time.Second)
+	require.NoError(t, err)
+	require.True(t, result.OK)
+}
+
+func TestPostgresAckerBackstopCoversLostNotify(t *testing.T) {
+	a := NewPostgresAcker(nil, mcache.NewMemoryCache(), "", log.New("convoy", log.LevelError))
+	a.backstop = 20 * time.Millisecond
+	a.notify = func(context.Context, string) error { return errors.New("connection reset") }
+	t.Cleanup(func() { _ = a.Close() })
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = a.Publish(context.Background(), "project", "lost", Result{OK: true})
+	}()
+
+	result, err := a.Wait(context.Background(), "project", "lost", 2*time.Second)
+	require.NoError(t, err)
+	require.True(t, result.OK)
+}
+
+func TestPostgresAckerOnlyWakesWaitersOnKey(t *testing.T) {
+	a := loopbackAcker(t)
+
+	other, unsubscribe := a.subscribe(redisKey("project", "other"))
+	defer unsubscribe()
+
+	require.NoError(t, a.Publish(context.Background(), "project", "event", Result{OK: true}))
+
+	select {
+	case <-other:
+		t.Fatal("waiter on another key was woken")
+	default:
+	}
+}
+
+func TestPostgresAckerTimeout(t *testing.T) {
+	a := loopbackAcker(t)
+
+	_, err := a.Wait(context.Background(), "project", "missing", 50*time.Millisecond)
+	require.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestPostgresAckerRequiresCache(t *testing.T) {
+	a := NewPostgresAcker(nil, nil, "", log.New("convoy", log.LevelError))
+	t.Cleanup(func() { _ = a.Close() })
+
+	require.ErrorIs(t, a.Publish(context.Background(), "project", "event", Result{OK: true}), ErrNilCache)
+
+	_, err := a.Wait(context.Background(), "project", "event", time.Second)
+	require.ErrorIs(t, err, ErrNilCache)
+}
+
+// TestPostgresAckerClosesWhileDisconnected covers a listener that never
+// reaches its database: waiters are left to the backstop poll, and Close does
+// not hang on the LISTEN still waiting for a connection.
+func TestPostgresAckerClosesWhileDisconnected(t *testing.T) {
+	a := NewPostgresAcker(nil, mcache.NewMemoryCache(), "postgres://convoy@127.0.0.1:1/convoy?sslmode=disable&connect_timeout=1", log.New("convoy", log.LevelError))
+	a.backstop = 10 * time.Millisecond
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = a.cache.Set(context.Background(), redisKey("project", "event"), cachedResult{Published: true, Result: Result{OK: true}}, resultTTL)
+	}()
+	result, err := a.Wait(context.Background(), "project", "event", 2*time.Second)
+	require.NoError(t, err)
+	require.True(t, result.OK)
+
+	closed := make(chan struct{})
+	go func() {
+		_ = a.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close hung on a listener with no connection")
+	}
+}
@@ -1,7 +1,9 @@
 package metrics
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -21,6 +23,34 @@ const (
 	endpointLabel = "endpoint"
 	bucketLabel   = "bucket"
 	outcomeLabel  = "outcome"
+
+	statusClassLabel = "status_class"
+	reasonLabel      = "reason"
+	fromLabel        = "from"
+	toLabel          = "to"
+	kindLabel        = "kind"
+)
+
+// StatusClassNetworkError is the status class of an attempt that never got an
+// HTTP response: DNS, connect, TLS and timeout failures all land here.
+const StatusClassNetworkError = "network_error"
+
+// Reasons a delivery is discarded. The set is closed on purpose: the reason is
+// a label, so it must never carry free text such as an error message.
+const (
+	DiscardReasonProjectNotFound     = "project_not_found"
+	DiscardReasonEndpointNotFound    = "endpoint_not_found"
+	DiscardReasonEndpointInactive    = "endpoint_inactive"
+	DiscardReasonUnresolvedTargetURL = "unresolved_target_url"
+	DiscardReasonAuthUnavailable     = "auth_unavailable"
+	DiscardReasonVersionUnreachable  = "version_unreachable"
+	DiscardReasonDowngradeFailed     = "downgrade_failed"
+)
+
+// Kinds of user-supplied code evaluated on the delivery path.
+const (
+	EvaluationKindFilter    = "filter"
+	EvaluationKindTransform = "transform"
 )
 
 // Rate limiter outcomes. A saturated limiter backend and a genuine over-limit
@@ -39,6 +69,14 @@ type Metrics struct {
 	IngestLatency        *prometheus.HistogramVec
 	EventDeliveryLatency *prometheus.HistogramVec
 	RateLimitTotal       *prometheus.CounterVec
+
+	DeliveryAttemptsTotal          *prometheus.CounterVec
+	DeliveryResponseLatency        *prometheus.HistogramVec
+	RetriesScheduledTotal          *prometheus.CounterVec
+	DiscardedTotal                 *prometheus.CounterVec
+	CircuitBreakerTransitionsTotal *prometheus.CounterVec
+	EvaluationLatency              *prometheus.HistogramVec
+	IngestConsumerLag              *prometheus.HistogramVec
 }
 
 func GetDPInstance(licenser license.Licenser) *Metrics {
@@ -48,6 +86,12 @@ func GetDPInstance(licenser license.Licenser) *Metrics {
 	return m
 }
 
+// Noop returns a Metrics that records nothing, for work that is kept out of
+// the data plane metrics such as test deliveries.
+func Noop() *Metrics {
+	return &Metrics{}
+}
+
 func newMetrics(pr prometheus.Registerer, licenser license.Licenser) *Metrics {
 	m := InitMetrics(licenser)
 
@@ -56,8 +100,16 @@ func newMetrics(pr prometheus.Registerer, licenser license.Licenser) *Metrics {
 			m.IngestTotal,
 			m.IngestConsumedTotal,
 			m.IngestErrorsTotal,
+			m.IngestLatency,
 			m.EventDeliveryLatency,
 			m.RateLimitTotal,
+			m.DeliveryAttemptsTotal,
+			m.DeliveryResponseLatency,
+			m.RetriesScheduledTotal,
+			m.DiscardedTotal,
+			m.CircuitBreakerTransitionsTotal,
+			m.EvaluationLatency,
+			m.IngestConsumerLag,
 		)
 	}
 	return m
@@ -123,10 +175,83 @@ func InitMetrics(licenser license.Licenser) *Metrics {
 			},
 			[]string{projectLabel, endpointLabel},
 		),
+		DeliveryAttemptsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "convoy_delivery_attempts_total",
+				Help: "Total number of delivery attempts, by the class of the HTTP status the endpoint returned (network_error when there was no response)",
+			},
+			[]string{projectLabel, endpointLabel, statusClassLabel},
+		),
+		DeliveryResponseLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "convoy_delivery_response_latency_seconds",
+				Help:    "Time (in seconds) an endpoint took to respond to a delivery attempt.",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{projectLabel, endpointLabel},
+		),
+		RetriesScheduledTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "convoy_delivery_retries_scheduled_total",
+				Help: "Total number of delivery retries scheduled after a failed attempt",
+			},
+			[]string{projectLabel, endpointLabel},
+		),
+		DiscardedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "convoy_delivery_discarded_total",
+				Help: "Total number of event deliveries discarded without being sent, by reason",
+			},
+			[]string{projectLabel, reasonLabel},
+		),
+		CircuitBreakerTransitionsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "convoy_circuit_breaker_transitions_total",
+				Help: "Total number of circuit breaker state transitions",
+			},
+			[]string{projectLabel, fromLabel, toLabel},
+		),
+		EvaluationLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "convoy_evaluation_latency_seconds",
+				Help:    "Time (in seconds) spent evaluating subscription filters and transform functions.",
+				Buckets: []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+			},
+			[]string{projectLabel, kindLabel},
+		),
+		IngestConsumerLag: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "convoy_ingest_consumer_lag_seconds",
+				Help:    "Time (in seconds) between a message being published to a broker source and Convoy consuming it.",
+				Buckets: []float64{.1, .5, 1, 5, 15, 30, 60, 300, 900, 3600},
+			},
+			[]string{projectLabel, sourceLabel},
+		),
 	}
+
 	return m
 }
 
+// StatusClass buckets an HTTP status code into the label delivery metrics use.
+// Anything below 100 means the attempt never got a response.
+func StatusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return StatusClassNetworkError
+	}
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
+// endpointLabelFor returns the endpoint label value for a delivery metric.
+// Projects that have not opted in with endpoint_metric_labels are reported
+// with an empty endpoint, which keeps them at one series per project rather
+// than one per endpoint.
+func endpointLabelFor(project *datastore.Project, endpointID string) string {
+	if project.Config != nil && project.Config.EndpointMetricLabels {
+		return endpointID
+	}
+	return ""
+}
+
 func (m *Metrics) RecordEndToEndLatency(ev *datastore.EventDelivery) {
 	if !m.IsEnabled {
 		return
@@ -168,3 +293,60 @@ func (m *Metrics) IncrementIngestErrorsTotal(source *datastore.Source) {
 	}
 	m.IngestErrorsTotal.With(prometheus.Labels{projectLabel: source.ProjectID, sourceLabel: source.UID}).Inc()
 }
+
+// RecordDeliveryAttempt counts one attempt by status class and records how long
+// the endpoint took to answer. A statusCode below 100 is a network error.
+func (m *Metrics) RecordDeliveryAttempt(project *datastore.Project, endpointID string, statusCode int, latency time.Duration) {
+	if !m.IsEnabled {
+		return
+	}
+	endpoint := endpointLabelFor(project, endpointID)
+	m.DeliveryAttemptsTotal.With(prometheus.Labels{projectLabel: project.UID, endpointLabel: endpoint, statusClassLabel: StatusClass(statusCode)}).Inc()
+	m.DeliveryResponseLatency.With(prometheus.Labels{projectLabel: project.UID, endpointLabel: endpoint}).Observe(latency.Seconds())
+}
+
+func (m *Metrics) IncrementRetriesScheduled(project *datastore.Project, endpointID string) {
+	if !m.IsEnabled {
+		return
+	}
+	m.RetriesScheduledTotal.With(prometheus.Labels{projectLabel: project.UID, endpointLabel: endpointLabelFor(project, endpointID)}).Inc()
+}
+
+// IncrementDiscarded counts a delivery dropped without being sent. reason must
+// be one of the DiscardReason constants.
+func (m *Metrics) IncrementDiscarded(projectID, reason string) {
+	if !m.IsEnabled {
+		return
+	}
+	m.DiscardedTotal.With(prometheus.Labels{projectLabel: projectID, reasonLabel: reason}).Inc()
+}
+
+func (m *Metrics) IncrementCircuitBreakerTransition(projectID, from, to string) {
+	if !m.IsEnabled {
+		return
+	}
+	m.CircuitBreakerTransitionsTotal.With(prometheus.Labels{projectLabel: projectID, fromLabel: from, toLabel: to}).Inc()
+}
+
+// RecordEvaluationLatency records time spent in a filter or transform. kind
+// must be one of the EvaluationKind constants.
+func (m *Metrics) RecordEvaluationLatency(projectID, kind string, latency time.Duration) {
+	if !m.IsEnabled {
+		return
+	}
+	m.EvaluationLatency.With(prometheus.Labels{projectLabel: projectID, kindLabel: kind}).Observe(latency.Seconds())
+}
+
+// RecordIngestConsumerLag records how far behind the broker a source consumer
+// is. Brokers that did not stamp the message report a zero publishedAt, which
+// is skipped rather than recorded as a lag of decades.
+func (m *Metrics) RecordIngestConsumerLag(source *datastore.Source, publishedAt time.Time) {
+	if !m.IsEnabled || publishedAt.IsZero() {
+		return
+	}
+	lag := time.Since(publishedAt)
+	if lag < 0 {
+		lag = 0
+	}
+	m.IngestConsumerLag.With(prometheus.Labels{projectLabel: source.ProjectID, sourceLabel: source.UID}).Observe(lag.Seconds())
+}
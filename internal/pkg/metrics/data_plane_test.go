@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestStatusClass(t *testing.T) {
+	tests := map[int]string{
+		0:   StatusClassNetworkError,
+		99:  StatusClassNetworkError,
+		200: "2xx",
+		204: "2xx",
+		301: "3xx",
+		404: "4xx",
+		503: "5xx",
+		600: StatusClassNetworkError,
+	}
+
+	for code, want := range tests {
+		require.Equal(t, want, StatusClass(code), "status code %d", code)
+	}
+}
+
+func TestEndpointLabelFor(t *testing.T) {
+	t.Run("projects that opted in are labelled per endpoint", func(t *testing.T) {
+		project := &datastore.Project{UID: "project-a", Config: &datastore.ProjectConfig{EndpointMetricLabels: true}}
+
+		require.Equal(t, "endpoint-1", endpointLabelFor(project, "endpoint-1"))
+	})
+
+	t.Run("projects that did not opt in collapse to one series", func(t *testing.T) {
+		project := &datastore.Project{UID: "project-b", Config: &datastore.ProjectConfig{}}
+
+		require.Equal(t, "", endpointLabelFor(project, "endpoint-1"))
+	})
+
+	t.Run("no config means no endpoint labels", func(t *testing.T) {
+		require.Equal(t, "", endpointLabelFor(&datastore.Project{UID: "project-c"}, "endpoint-1"))
+	})
+}
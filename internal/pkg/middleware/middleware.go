@@ -906,6 +906,19 @@ func RequireValidPortalLinksLicense(l license.Licenser, logger log.Logger) func(
 	}
 }
 
+func RequireValidCircuitBreakingLicense(l license.Licenser, logger log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !l.CircuitBreaking() {
+				logger.WarnContext(r.Context(), "Circuit breaking access denied - license required")
+				_ = render.Render(w, r, util.NewErrorResponse("Access denied", http.StatusUnauthorized))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireAsynqMonitoring gates queue monitoring at request time so a runtime
 // licenser refresh (e.g. after self-hosted trial start) can unlock routes without
 // a process restart. The licenser is resolved per request via the getter because
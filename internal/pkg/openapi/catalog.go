@@ -0,0 +1,281 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	openAPIVersion  = "3.1.0"
+	asyncAPIVersion = "3.0.0"
+)
+
+// componentKeyPattern matches the characters AsyncAPI allows in component
+// and channel keys.
+var componentKeyPattern = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+
+// CatalogEventType is an event type as it is published in the catalog.
+type CatalogEventType struct {
+	Name        string
+	Description string
+	Category    string
+	Schema      json.RawMessage
+	Deprecated  bool
+
+	// Example is a sample payload. When it is nil the first example the
+	// schema declares is used instead, or one generated from the schema.
+	Example interface{}
+}
+
+// CatalogSignatureScheme is one of the schemes a project signs payloads with.
+type CatalogSignatureScheme struct {
+	Hash     string
+	Encoding string
+}
+
+// Catalog describes the webhooks a project sends, ready to be rendered as an
+// OpenAPI or AsyncAPI document.
+type Catalog struct {
+	Title       string
+	Version     string
+	Description string
+
+	// SignatureHeader is the header each request is signed in; it is left out
+	// of the documents when empty.
+	SignatureHeader  string
+	SignatureSchemes []CatalogSignatureScheme
+
+	EventTypes []CatalogEventType
+}
+
+type CatalogInfo struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// OpenAPIDocument is an OpenAPI 3.1 document that lists every event type
+// under webhooks.
+type OpenAPIDocument struct {
+	OpenAPI  string                            `json:"openapi" yaml:"openapi"`
+	Info     CatalogInfo                       `json:"info" yaml:"info"`
+	Webhooks map[string]map[string]interface{} `json:"webhooks" yaml:"webhooks"`
+}
+
+// AsyncAPIDocument is an AsyncAPI 3 document with a channel, a receive
+// operation and a message per event type.
+type AsyncAPIDocument struct {
+	AsyncAPI           string                            `json:"asyncapi" yaml:"asyncapi"`
+	Info               CatalogInfo                       `json:"info" yaml:"info"`
+	DefaultContentType string                            `json:"defaultContentType" yaml:"defaultContentType"`
+	Channels           map[string]map[string]interface{} `json:"channels" yaml:"channels"`
+	Operations         map[string]map[string]interface{} `json:"operations" yaml:"operations"`
+	Components         map[string]interface{}            `json:"components" yaml:"components"`
+}
+
+func (c *Catalog) info() CatalogInfo {
+	return CatalogInfo{Title: c.Title, Version: c.Version, Description: c.Description}
+}
+
+// OpenAPI renders the catalog as an OpenAPI 3.1 webhooks document.
+func (c *Catalog) OpenAPI() (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{
+		OpenAPI:  openAPIVersion,
+		Info:     c.info(),
+		Webhooks: make(map[string]map[string]interface{}, len(c.EventTypes)),
+	}
+
+	for _, et := range c.sortedEventTypes() {
+		schema, err := decodeSchema(et.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("event type %s: %w", et.Name, err)
+		}
+
+		media := map[string]interface{}{"schema": schema}
+		if example := eventTypeExample(et, schema); example != nil {
+			media["example"] = example
+		}
+
+		op := map[string]interface{}{
+			"operationId": componentKey(et.Name),
+			"summary":     et.Name,
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content":  map[string]interface{}{"application/json": media},
+			},
+			"responses": map[string]interface{}{
+				"2XX": map[string]interface{}{
+					"description": "Return any 2XX status to acknowledge the event. Any other status, or no response, is retried.",
+				},
+			},
+		}
+		if et.Description != "" {
+			op["description"] = et.Description
+		}
+		if et.Category != "" {
+			op["tags"] = []string{et.Category}
+		}
+		if et.Deprecated {
+			op["deprecated"] = true
+		}
+		if c.SignatureHeader != "" {
+			op["parameters"] = []interface{}{
+				map[string]interface{}{
+					"name":        c.SignatureHeader,
+					"in":          "header",
+					"required":    true,
+					"description": c.signatureDescription(),
+					"schema":      map[string]interface{}{"type": "string"},
+				},
+			}
+		}
+
+		doc.Webhooks[et.Name] = map[string]interface{}{"post": op}
+	}
+
+	return doc, nil
+}
+
+// AsyncAPI renders the catalog as an AsyncAPI 3 document.
+func (c *Catalog) AsyncAPI() (*AsyncAPIDocument, error) {
+	doc := &AsyncAPIDocument{
+		AsyncAPI:           asyncAPIVersion,
+		Info:               c.info(),
+		DefaultContentType: "application/json",
+		Channels:           make(map[string]map[string]interface{}, len(c.EventTypes)),
+		Operations:         make(map[string]map[string]interface{}, len(c.EventTypes)),
+	}
+
+	messages := make(map[string]interface{}, len(c.EventTypes))
+	for _, et := range c.sortedEventTypes() {
+		schema, err := decodeSchema(et.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("event type %s: %w", et.Name, err)
+		}
+
+		key := componentKey(et.Name)
+
+		message := map[string]interface{}{
+			"name":        et.Name,
+			"title":       et.Name,
+			"contentType": "application/json",
+			"payload":     schema,
+		}
+		if et.Description != "" {
+			message["description"] = et.Description
+		}
+		if et.Category != "" {
+			message["tags"] = []interface{}{map[string]interface{}{"name": et.Category}}
+		}
+		if et.Deprecated {
+			message["x-deprecated"] = true
+		}
+		if example := eventTypeExample(et, schema); example != nil {
+			message["examples"] = []interface{}{map[string]interface{}{"payload": example}}
+		}
+		if c.SignatureHeader != "" {
+			message["headers"] = map[string]interface{}{
+				"type":     "object",
+				"required": []string{c.SignatureHeader},
+				"properties": map[string]interface{}{
+					c.SignatureHeader: map[string]interface{}{
+						"type":        "string",
+						"description": c.signatureDescription(),
+					},
+				},
+			}
+		}
+		messages[key] = message
+
+		doc.Channels[key] = map[string]interface{}{
+			"address": et.Name,
+			"messages": map[string]interface{}{
+				key: map[string]interface{}{"$ref": "#/components/messages/" + key},
+			},
+		}
+
+		doc.Operations["receive_"+key] = map[string]interface{}{
+			"action":  "receive",
+			"summary": fmt.Sprintf("Receive %s events", et.Name),
+			"channel": map[string]interface{}{"$ref": "#/channels/" + key},
+			"messages": []interface{}{
+				map[string]interface{}{"$ref": fmt.Sprintf("#/channels/%s/messages/%s", key, key)},
+			},
+		}
+	}
+
+	doc.Components = map[string]interface{}{"messages": messages}
+	return doc, nil
+}
+
+// signatureDescription explains how the signature header is computed, for
+// both the simple and the advanced (timestamped) header formats.
+func (c *Catalog) signatureDescription() string {
+	var sb strings.Builder
+	sb.WriteString("HMAC signature of the raw request body")
+
+	if len(c.SignatureSchemes) > 0 {
+		latest := c.SignatureSchemes[len(c.SignatureSchemes)-1]
+		sb.WriteString(fmt.Sprintf(", computed with %s and %s encoded", latest.Hash, latest.Encoding))
+	}
+	sb.WriteString(". Endpoints with advanced signatures receive `t=<unix timestamp>,v1=<signature>[,v2=...]` instead, ")
+	sb.WriteString("where each signature covers `<timestamp>,<body>`")
+
+	if len(c.SignatureSchemes) > 1 {
+		versions := make([]string, 0, len(c.SignatureSchemes))
+		for i, s := range c.SignatureSchemes {
+			versions = append(versions, fmt.Sprintf("v%d: %s/%s", i+1, s.Hash, s.Encoding))
+		}
+		sb.WriteString(" (" + strings.Join(versions, ", ") + ")")
+	}
+	sb.WriteString(".")
+
+	return sb.String()
+}
+
+func (c *Catalog) sortedEventTypes() []CatalogEventType {
+	eventTypes := make([]CatalogEventType, len(c.EventTypes))
+	copy(eventTypes, c.EventTypes)
+	sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i].Name < eventTypes[j].Name })
+	return eventTypes
+}
+
+// decodeSchema turns a stored JSON schema into a value both the JSON and the
+// YAML encoders render as a document. A missing schema is an open object.
+func decodeSchema(raw json.RawMessage) (map[string]interface{}, error) {
+	schema := map[string]interface{}{}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return nil, fmt.Errorf("invalid JSON schema: %v", err)
+		}
+	}
+
+	if len(schema) == 0 {
+		schema["type"] = "object"
+	}
+
+	return schema, nil
+}
+
+func eventTypeExample(et CatalogEventType, schema map[string]interface{}) interface{} {
+	if et.Example != nil {
+		return et.Example
+	}
+
+	if examples, ok := schema["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0]
+	}
+
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+
+	return (&sampler{root: schema}).sample(schema, 0)
+}
+
+func componentKey(name string) string {
+	return componentKeyPattern.ReplaceAllString(name, "_")
+}
@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testCatalog() *Catalog {
+	return &Catalog{
+		Title:           "Acme webhooks",
+		Version:         "2026-10-01",
+		SignatureHeader: "X-Acme-Signature",
+		SignatureSchemes: []CatalogSignatureScheme{
+			{Hash: "SHA256", Encoding: "hex"},
+			{Hash: "SHA512", Encoding: "base64"},
+		},
+		EventTypes: []CatalogEventType{
+			{
+				Name:        "invoice.paid",
+				Description: "An invoice was paid",
+				Category:    "billing",
+				Schema:      json.RawMessage(`{"type":"object","properties":{"amount":{"type":"integer"}},"required":["amount"],"examples":[{"amount":100}]}`),
+			},
+			{
+				Name:       "customer created",
+				Deprecated: true,
+			},
+		},
+	}
+}
+
+func TestCatalog_OpenAPI(t *testing.T) {
+	doc, err := testCatalog().OpenAPI()
+	require.NoError(t, err)
+
+	require.Equal(t, "3.1.0", doc.OpenAPI)
+	require.Equal(t, "Acme webhooks", doc.Info.Title)
+	require.Len(t, doc.Webhooks, 2)
+
+	op := doc.Webhooks["invoice.paid"]["post"].(map[string]interface{})
+	require.Equal(t, "invoice.paid", op["operationId"])
+	require.Equal(t, []string{"billing"}, op["tags"])
+	require.NotContains(t, op, "deprecated")
+
+	media := op["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"amount": float64(100)}, media["example"])
+
+	param := op["parameters"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "X-Acme-Signature", param["name"])
+	require.Contains(t, param["description"], "computed with SHA512 and base64 encoded")
+	require.Contains(t, param["description"], "v1: SHA256/hex, v2: SHA512/base64")
+
+	deprecated := doc.Webhooks["customer created"]["post"].(map[string]interface{})
+	require.Equal(t, true, deprecated["deprecated"])
+	require.Equal(t, "customer_created", deprecated["operationId"])
+
+	// The rendered document must load as OpenAPI.
+	b, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	loaded, err := openapi3.NewLoader().LoadFromData(b)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", loaded.OpenAPI)
+
+	// And it must extract back into the same event types on import.
+	converter, err := New(loaded)
+	require.NoError(t, err)
+
+	collection, err := converter.ExtractWebhooks()
+	require.NoError(t, err)
+	require.Contains(t, collection.Webhooks, "invoice.paid")
+	require.Equal(t, []string{"amount"}, collection.Webhooks["invoice.paid"].Schema.Required)
+}
+
+func TestCatalog_AsyncAPI(t *testing.T) {
+	doc, err := testCatalog().AsyncAPI()
+	require.NoError(t, err)
+
+	require.Equal(t, "3.0.0", doc.AsyncAPI)
+	require.Len(t, doc.Channels, 2)
+	require.Equal(t, "customer created", doc.Channels["customer_created"]["address"])
+
+	op := doc.Operations["receive_invoice.paid"]
+	require.Equal(t, "receive", op["action"])
+	require.Equal(t, map[string]interface{}{"$ref": "#/channels/invoice.paid"}, op["channel"])
+
+	messages := doc.Components["messages"].(map[string]interface{})
+	message := messages["invoice.paid"].(map[string]interface{})
+	require.Equal(t, []interface{}{map[string]interface{}{"payload": map[string]interface{}{"amount": float64(100)}}}, message["examples"])
+	require.Contains(t, message, "headers")
+
+	// An event type without a schema accepts any object.
+	untyped := messages["customer_created"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"type": "object"}, untyped["payload"])
+	require.Equal(t, true, untyped["x-deprecated"])
+
+	// Without a declared example one is generated from the schema.
+	require.Equal(t, []interface{}{map[string]interface{}{"payload": map[string]interface{}{}}}, untyped["examples"])
+
+	b, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(b), "asyncapi: 3.0.0")
+}
+
+func TestCatalog_WithoutSignatureHeader(t *testing.T) {
+	c := testCatalog()
+	c.SignatureHeader = ""
+
+	doc, err := c.OpenAPI()
+	require.NoError(t, err)
+	require.NotContains(t, doc.Webhooks["invoice.paid"]["post"], "parameters")
+}
+
+func TestCatalog_RejectsInvalidSchema(t *testing.T) {
+	c := &Catalog{EventTypes: []CatalogEventType{{Name: "broken", Schema: json.RawMessage(`{`)}}}
+
+	_, err := c.OpenAPI()
+	require.ErrorContains(t, err, "event type broken")
+
+	_, err = c.AsyncAPI()
+	require.ErrorContains(t, err, "event type broken")
+}
@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// maxSampleDepth stops recursive schemas, which a $ref can describe, from
+// generating forever.
+const maxSampleDepth = 16
+
+// sampleFormats are the values generated for the string formats a receiver is
+// most likely to validate.
+var sampleFormats = map[string]string{
+	"date-time": "2026-01-01T12:00:00Z",
+	"date":      "2026-01-01",
+	"time":      "12:00:00Z",
+	"email":     "user@example.com",
+	"idn-email": "user@example.com",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+}
+
+// SamplePayload generates a payload that satisfies a JSON schema. Examples,
+// defaults, constants and enums the schema declares are preferred over
+// generated values, so the sample reads like a real event. A missing schema
+// yields an empty object.
+func SamplePayload(schema json.RawMessage) (interface{}, error) {
+	root, err := decodeSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &sampler{root: root}
+	return g.sample(root, 0), nil
+}
+
+type sampler struct {
+	root map[string]interface{}
+}
+
+func (g *sampler) sample(schema map[string]interface{}, depth int) interface{} {
+	if depth > maxSampleDepth {
+		return nil
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, ok := g.resolve(ref)
+		if !ok {
+			return nil
+		}
+		return g.sample(resolved, depth+1)
+	}
+
+	if examples, ok := schema["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	for _, key := range []string{"example", "default", "const"} {
+		if v, ok := schema[key]; ok {
+			return v
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok && len(all) > 0 {
+		return g.sample(g.merge(schema, all), depth+1)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[key].([]interface{}); ok && len(options) > 0 {
+			if option, ok := options[0].(map[string]interface{}); ok {
+				return g.sample(option, depth+1)
+			}
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return g.sampleObject(schema, depth)
+	case "array":
+		return g.sampleArray(schema, depth)
+	case "string":
+		return sampleString(schema)
+	case "integer":
+		return int64(sampleNumber(schema, 1))
+	case "number":
+		return sampleNumber(schema, 1.5)
+	case "boolean":
+		return true
+	default:
+		return nil
+	}
+}
+
+func (g *sampler) sampleObject(schema map[string]interface{}, depth int) interface{} {
+	out := map[string]interface{}{}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		out[name] = g.sample(property, depth+1)
+	}
+
+	return out
+}
+
+func (g *sampler) sampleArray(schema map[string]interface{}, depth int) interface{} {
+	n := 1
+	if lower, ok := schema["minItems"].(float64); ok && int(lower) > n {
+		n = int(lower)
+	}
+
+	items, ok := schema["items"].(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	out := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, g.sample(items, depth+1))
+	}
+	return out
+}
+
+// merge folds the members of an allOf into one schema, combining their
+// properties. Every property is sampled, so required lists need no merging.
+func (g *sampler) merge(schema map[string]interface{}, all []interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	properties := map[string]interface{}{}
+	for k, v := range schema {
+		if k != "allOf" {
+			merged[k] = v
+		}
+	}
+
+	members := make([]map[string]interface{}, 0, len(all)+1)
+	members = append(members, schema)
+	for _, member := range all {
+		m, ok := member.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := m["$ref"].(string); ok {
+			if m, ok = g.resolve(ref); !ok {
+				continue
+			}
+		}
+		members = append(members, m)
+	}
+
+	for _, m := range members {
+		if props, ok := m["properties"].(map[string]interface{}); ok {
+			for name, prop := range props {
+				properties[name] = prop
+			}
+		}
+		if t, ok := m["type"]; ok {
+			merged["type"] = t
+		}
+	}
+
+	if len(properties) > 0 {
+		merged["properties"] = properties
+		merged["type"] = "object"
+	}
+	return merged
+}
+
+// resolve follows a local reference such as #/$defs/address. References to
+// other documents cannot be followed and resolve to nothing.
+func (g *sampler) resolve(ref string) (map[string]interface{}, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+
+	var node interface{} = g.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+
+	resolved, ok := node.(map[string]interface{})
+	return resolved, ok
+}
+
+// schemaType returns the type a schema describes. A list of types resolves to
+// its first non-null member, and an untyped schema is inferred from the
+// keywords it uses.
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s
+			}
+		}
+		return "null"
+	}
+
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+func sampleString(schema map[string]interface{}) string {
+	s := "string"
+	if format, ok := schema["format"].(string); ok {
+		if v, ok := sampleFormats[format]; ok {
+			return v
+		}
+	}
+
+	if lower, ok := schema["minLength"].(float64); ok && len(s) < int(lower) {
+		s += strings.Repeat("x", int(lower)-len(s))
+	}
+	if upper, ok := schema["maxLength"].(float64); ok && len(s) > int(upper) {
+		s = s[:int(upper)]
+	}
+	return s
+}
+
+// sampleNumber picks a value inside the schema's bounds, or fallback when the
+// schema has none.
+func sampleNumber(schema map[string]interface{}, fallback float64) float64 {
+	if lower, ok := schema["minimum"].(float64); ok {
+		return lower
+	}
+	if lower, ok := schema["exclusiveMinimum"].(float64); ok {
+		return lower + 1
+	}
+	if upper, ok := schema["maximum"].(float64); ok {
+		return upper
+	}
+	if upper, ok := schema["exclusiveMaximum"].(float64); ok {
+		return upper - 1
+	}
+	return fallback
+}
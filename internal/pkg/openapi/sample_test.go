@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamplePayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "empty schema",
+			schema: ``,
+			want:   `{}`,
+		},
+		{
+			name: "object with formats and enums",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"id": {"type": "string", "format": "uuid"},
+					"email": {"type": "string", "format": "email"},
+					"created_at": {"type": "string", "format": "date-time"},
+					"status": {"type": "string", "enum": ["active", "disabled"]},
+					"amount": {"type": "integer", "minimum": 100},
+					"rate": {"type": "number"},
+					"live": {"type": "boolean"},
+					"note": {"type": ["null", "string"], "maxLength": 3}
+				}
+			}`,
+			want: `{
+				"id": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+				"email": "user@example.com",
+				"created_at": "2026-01-01T12:00:00Z",
+				"status": "active",
+				"amount": 100,
+				"rate": 1.5,
+				"live": true,
+				"note": "str"
+			}`,
+		},
+		{
+			name: "declared examples and defaults win",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"currency": {"type": "string", "default": "USD"},
+					"country": {"type": "string", "examples": ["NG", "GB"]},
+					"kind": {"const": "invoice"}
+				}
+			}`,
+			want: `{"currency": "USD", "country": "NG", "kind": "invoice"}`,
+		},
+		{
+			name:   "schema level example",
+			schema: `{"type": "object", "properties": {"a": {"type": "string"}}, "examples": [{"a": "b"}]}`,
+			want:   `{"a": "b"}`,
+		},
+		{
+			name: "arrays refs and compositions",
+			schema: `{
+				"type": "object",
+				"$defs": {"line": {"type": "object", "properties": {"sku": {"type": "string", "minLength": 8}}}},
+				"properties": {
+					"lines": {"type": "array", "minItems": 2, "items": {"$ref": "#/$defs/line"}},
+					"customer": {"allOf": [
+						{"type": "object", "properties": {"id": {"type": "integer"}}},
+						{"properties": {"name": {"type": "string"}}}
+					]},
+					"payment": {"oneOf": [{"type": "string", "format": "uri"}, {"type": "integer"}]}
+				}
+			}`,
+			want: `{
+				"lines": [{"sku": "stringxx"}, {"sku": "stringxx"}],
+				"customer": {"id": 1, "name": "string"},
+				"payment": "https://example.com"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := SamplePayload(json.RawMessage(tt.schema))
+			require.NoError(t, err)
+
+			b, err := json.Marshal(sample)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestSamplePayload_RecursiveSchema(t *testing.T) {
+	schema := `{"$defs": {"node": {"type": "object", "properties": {"child": {"$ref": "#/$defs/node"}}}}, "$ref": "#/$defs/node"}`
+
+	sample, err := SamplePayload(json.RawMessage(schema))
+	require.NoError(t, err)
+	require.NotNil(t, sample)
+}
+
+func TestSamplePayload_InvalidSchema(t *testing.T) {
+	_, err := SamplePayload(json.RawMessage(`{`))
+	require.Error(t, err)
+}
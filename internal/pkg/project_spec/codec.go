@@ -0,0 +1,91 @@
+package project_spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Parse reads a YAML or JSON document. Unknown fields are rejected so a
+// misspelt setting is not silently ignored.
+func Parse(data []byte) (*Document, error) {
+	// YAML is a superset of JSON, going through JSON afterwards gives both
+	// formats the same field names and number handling.
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("invalid document: document is empty")
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	var doc Document
+	if err = dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// Encode writes the document as JSON or YAML, keeping the field order of
+// the JSON encoding in both.
+func Encode(doc *Document, format string) ([]byte, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatJSON:
+		return append(b, '\n'), nil
+	case FormatYAML:
+		var node yaml.Node
+		if err = yaml.Unmarshal(b, &node); err != nil {
+			return nil, err
+		}
+		blockStyle(&node)
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err = enc.Encode(&node); err != nil {
+			return nil, err
+		}
+		if err = enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, must be one of json, yaml", format)
+	}
+}
+
+// blockStyle drops the flow style and quoting the JSON source gave the
+// nodes, the encoder still quotes strings that would otherwise read as
+// another type.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && strings.Contains(n.Value, "\n") {
+		n.Style = yaml.LiteralStyle
+	}
+
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
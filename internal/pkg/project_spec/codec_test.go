@@ -0,0 +1,108 @@
+package project_spec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const ordersYAML = `
+version: v1
+event_types:
+  - name: order.created
+    category: orders
+    json_schema:
+      type: object
+      required: [id]
+endpoints:
+  - name: orders
+    url: https://example.com/webhooks
+    owner_id: tenant-1
+    http_timeout: 30
+    secret:
+      secret_ref: ORDERS_SECRET
+subscriptions:
+  - name: orders
+    endpoint: orders
+    event_types: [order.created]
+    filter:
+      body:
+        amount:
+          $gte: 100
+`
+
+func TestParse_YAML(t *testing.T) {
+	doc, err := Parse([]byte(ordersYAML))
+	require.NoError(t, err)
+
+	require.Equal(t, Version, doc.Version)
+	require.Nil(t, doc.ProjectConfig)
+	require.Nil(t, doc.Sources, "a section left out is not managed")
+	require.Len(t, doc.Endpoints, 1)
+	require.Equal(t, uint64(30), doc.Endpoints[0].HttpTimeout)
+	require.Equal(t, "ORDERS_SECRET", doc.Endpoints[0].Secret.Ref)
+	require.Equal(t, []interface{}{"id"}, doc.EventTypes[0].JSONSchema["required"])
+	require.Equal(t, datastore.M{"amount": map[string]interface{}{"$gte": float64(100)}}, doc.Subscriptions[0].Filter.Body)
+}
+
+func TestParse_EmptySectionIsManaged(t *testing.T) {
+	doc, err := Parse([]byte("version: v1\nendpoints: []\n"))
+	require.NoError(t, err)
+
+	require.NotNil(t, doc.Endpoints)
+	require.Empty(t, doc.Endpoints)
+}
+
+func TestParse_RejectsUnknownFields(t *testing.T) {
+	_, err := Parse([]byte("version: v1\nendpoints:\n  - name: orders\n    uri: https://example.com\n"))
+	require.ErrorContains(t, err, `unknown field "uri"`)
+}
+
+func TestParse_JSON(t *testing.T) {
+	doc, err := Parse([]byte(`{"version":"v1","portal_links":[{"name":"tenant","owner_id":"tenant-1"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, "tenant-1", doc.PortalLinks[0].OwnerID)
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	doc, err := Parse([]byte(ordersYAML))
+	require.NoError(t, err)
+	doc.Subscriptions[0].Function = "function transform(payload) {\n  return payload;\n}"
+
+	for _, format := range []string{FormatYAML, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			b, err := Encode(doc, format)
+			require.NoError(t, err)
+
+			again, err := Parse(b)
+			require.NoError(t, err)
+			require.Equal(t, doc, again)
+		})
+	}
+}
+
+func TestEncode_YAMLIsBlockStyle(t *testing.T) {
+	doc := &Document{
+		Version:   Version,
+		Endpoints: []Endpoint{{Name: "true", URL: "https://example.com", Secret: NewSecret("ORDERS_SECRET", "value")}},
+		Sources:   []Source{},
+	}
+
+	b, err := Encode(doc, FormatYAML)
+	require.NoError(t, err)
+
+	out := string(b)
+	require.True(t, strings.HasPrefix(out, "version: v1\n"), out)
+	require.Contains(t, out, `- name: "true"`, "strings that read as another type stay quoted")
+	require.Contains(t, out, "secret_ref: ORDERS_SECRET")
+	require.Contains(t, out, "sources: []")
+	require.NotContains(t, out, "value", "secret values are never encoded")
+}
+
+func TestEncode_UnsupportedFormat(t *testing.T) {
+	_, err := Encode(&Document{Version: Version}, "toml")
+	require.Error(t, err)
+}
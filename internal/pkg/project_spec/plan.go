@@ -0,0 +1,448 @@
+package project_spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionDeprecate Action = "deprecate"
+)
+
+// Change is one write applying a document makes. Fields lists the top-level
+// fields an update changes.
+type Change struct {
+	Kind   Kind     `json:"kind"`
+	Name   string   `json:"name"`
+	Action Action   `json:"action"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Plan is the changes applying a document makes, in the order they are made:
+// objects are created before what refers to them and deleted after.
+type Plan struct {
+	Changes  []Change `json:"changes"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *Plan) Warn(format string, args ...interface{}) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Write prints the plan for people to read.
+func (p *Plan) Write(w io.Writer) error {
+	var buf bytes.Buffer
+	counts := map[Action]int{}
+
+	for _, c := range p.Changes {
+		counts[c.Action]++
+
+		symbol := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-", ActionDeprecate: "!"}[c.Action]
+		fmt.Fprintf(&buf, "%s %s", symbol, c.Kind)
+		if c.Name != "" {
+			fmt.Fprintf(&buf, " %s", c.Name)
+		}
+		if c.Action == ActionDeprecate {
+			buf.WriteString(" (deprecate)")
+		}
+		if len(c.Fields) > 0 {
+			fmt.Fprintf(&buf, " %v", c.Fields)
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(&buf, "warning: %s\n", warning)
+	}
+
+	if p.Empty() {
+		buf.WriteString("No changes, the project matches the document.\n")
+	} else {
+		fmt.Fprintf(&buf, "Plan: %d to create, %d to update, %d to delete, %d to deprecate.\n",
+			counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete], counts[ActionDeprecate])
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Inherit fills in what desired leaves out to keep the current value: the
+// project config's nested sections and secrets, and endpoint secrets.
+func (d *Document) Inherit(current *Document) {
+	if d.ProjectConfig != nil && current.ProjectConfig != nil {
+		want, have := d.ProjectConfig, current.ProjectConfig
+		if want.MaxPayloadReadSize == 0 {
+			want.MaxPayloadReadSize = have.MaxPayloadReadSize
+		}
+		if want.SSL == nil {
+			want.SSL = have.SSL
+		}
+		if want.RateLimit == nil {
+			want.RateLimit = have.RateLimit
+		}
+		if want.Strategy == nil {
+			want.Strategy = have.Strategy
+		}
+		if want.CircuitBreaker == nil {
+			want.CircuitBreaker = have.CircuitBreaker
+		}
+		if want.Signature == nil {
+			want.Signature = have.Signature
+		}
+		if want.MetaEvent == nil {
+			want.MetaEvent = have.MetaEvent
+		} else if want.MetaEvent.Secret == nil && have.MetaEvent != nil {
+			want.MetaEvent.Secret = have.MetaEvent.Secret
+		}
+	}
+
+	endpoints := make(map[string]*Endpoint, len(current.Endpoints))
+	for i := range current.Endpoints {
+		endpoints[current.Endpoints[i].Name] = &current.Endpoints[i]
+	}
+	for i := range d.Endpoints {
+		e := &d.Endpoints[i]
+		if have, ok := endpoints[e.Name]; ok && e.Secret == nil {
+			e.Secret = have.Secret
+		}
+	}
+}
+
+// Normalize fills in the defaults convoy applies when an object is written,
+// so a document that leaves them out compares equal to an exported one.
+func (d *Document) Normalize() {
+	for i := range d.EventTypes {
+		if len(d.EventTypes[i].JSONSchema) == 0 {
+			d.EventTypes[i].JSONSchema = nil
+		}
+	}
+
+	for i := range d.Sources {
+		s := &d.Sources[i]
+		if s.Verifier != nil && (s.Verifier.Type == "" || s.Verifier.Type == datastore.NoopVerifier) {
+			s.Verifier = nil
+		}
+		if s.CustomResponse != nil && *s.CustomResponse == (datastore.CustomResponse{}) {
+			s.CustomResponse = nil
+		}
+	}
+
+	for i := range d.Endpoints {
+		e := &d.Endpoints[i]
+		if e.ContentType == "" {
+			e.ContentType = "application/json"
+		}
+		if e.AdvancedSignatures == nil {
+			advanced := true
+			e.AdvancedSignatures = &advanced
+		}
+		if e.Authentication != nil && e.Authentication.Type == "" {
+			e.Authentication = nil
+		}
+	}
+
+	for i := range d.Subscriptions {
+		s := &d.Subscriptions[i]
+		if s.DeliveryMode == "" {
+			s.DeliveryMode = datastore.AtLeastOnceDeliveryMode
+		}
+		if len(s.EventTypes) == 0 {
+			s.EventTypes = []string{"*"}
+		}
+		if !s.Filter.hasConditions() {
+			s.Filter = nil
+		}
+		if s.RateLimit != nil && *s.RateLimit == (datastore.RateLimitConfiguration{}) {
+			s.RateLimit = nil
+		}
+		if s.AlertConfig != nil && *s.AlertConfig == (datastore.AlertConfiguration{}) {
+			s.AlertConfig = nil
+		}
+
+		// An override matching the subscription's filter is what the event
+		// type gets anyway.
+		var subFilter Filter
+		if s.Filter != nil {
+			subFilter = *s.Filter
+		}
+		overrides := s.EventTypeFilters[:0]
+		for _, f := range s.EventTypeFilters {
+			if !f.Disabled && jsonEqual(f.Filter, subFilter) {
+				continue
+			}
+			overrides = append(overrides, f)
+		}
+		sort.SliceStable(overrides, func(a, b int) bool { return overrides[a].EventType < overrides[b].EventType })
+		s.EventTypeFilters = overrides
+		if len(s.EventTypeFilters) == 0 {
+			s.EventTypeFilters = nil
+		}
+	}
+
+	for i := range d.PortalLinks {
+		p := &d.PortalLinks[i]
+		if p.AuthType == "" {
+			p.AuthType = datastore.PortalAuthTypeStaticToken
+		}
+		if len(p.EventTypes) == 0 {
+			p.EventTypes = nil
+		}
+		if p.Permissions == nil {
+			p.Permissions = datastore.DefaultPortalLinkPermissions(p.CanManageEndpoint)
+		}
+	}
+}
+
+// Order in which changes are applied.
+var steps = []struct {
+	kind   Kind
+	action Action
+}{
+	{KindProjectConfig, ActionUpdate},
+	{KindEventType, ActionCreate},
+	{KindEventType, ActionUpdate},
+	{KindSource, ActionCreate},
+	{KindSource, ActionUpdate},
+	{KindEndpoint, ActionCreate},
+	{KindEndpoint, ActionUpdate},
+	{KindSubscription, ActionDelete},
+	{KindSubscription, ActionCreate},
+	{KindSubscription, ActionUpdate},
+	{KindPortalLink, ActionDelete},
+	{KindPortalLink, ActionCreate},
+	{KindPortalLink, ActionUpdate},
+	{KindEndpoint, ActionDelete},
+	{KindSource, ActionDelete},
+	{KindEventType, ActionDeprecate},
+}
+
+func stepOf(c Change) int {
+	for i, s := range steps {
+		if s.kind == c.Kind && s.action == c.Action {
+			return i
+		}
+	}
+	return len(steps)
+}
+
+// Diff plans the changes that turn current into desired, both documents are
+// normalised first. Objects desired leaves out of a managed section are only
+// deleted, or deprecated for event types, when prune is set.
+func Diff(current, desired *Document, prune bool) *Plan {
+	current.Normalize()
+	desired.Normalize()
+
+	plan := &Plan{Changes: []Change{}}
+
+	if desired.ProjectConfig != nil {
+		have := current.ProjectConfig
+		if have == nil {
+			have = &ProjectConfig{}
+		}
+		if fields := changedFields(have, desired.ProjectConfig, metaEventSecrets(have), metaEventSecrets(desired.ProjectConfig)); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Kind: KindProjectConfig, Action: ActionUpdate, Fields: fields})
+		}
+	}
+
+	if desired.EventTypes != nil {
+		have := make(map[string]*EventType, len(current.EventTypes))
+		for i := range current.EventTypes {
+			have[current.EventTypes[i].Name] = &current.EventTypes[i]
+		}
+
+		for i := range desired.EventTypes {
+			want := &desired.EventTypes[i]
+			cur, ok := have[want.Name]
+			if !ok {
+				plan.Changes = append(plan.Changes, Change{Kind: KindEventType, Name: want.Name, Action: ActionCreate})
+				continue
+			}
+
+			delete(have, want.Name)
+			if cur.Deprecated && !want.Deprecated {
+				plan.Warn("event type %s is deprecated and cannot be restored", want.Name)
+				want.Deprecated = true
+			}
+
+			fields := changedFields(cur, want, nil, nil)
+			switch {
+			case len(fields) == 1 && fields[0] == "deprecated":
+				plan.Changes = append(plan.Changes, Change{Kind: KindEventType, Name: want.Name, Action: ActionDeprecate})
+			case len(fields) > 0:
+				plan.Changes = append(plan.Changes, Change{Kind: KindEventType, Name: want.Name, Action: ActionUpdate, Fields: fields})
+			}
+		}
+
+		if prune {
+			for name, cur := range have {
+				if !cur.Deprecated {
+					plan.Changes = append(plan.Changes, Change{Kind: KindEventType, Name: name, Action: ActionDeprecate})
+				}
+			}
+		}
+	}
+
+	if desired.Sources != nil {
+		diffSection(plan, KindSource, current.Sources, desired.Sources, prune, func(s *Source) string { return s.Name },
+			func(cur, want *Source) []string { return changedFields(cur, want, cur.secrets(), want.secrets()) })
+	}
+
+	// Portal links take in endpoints by owner ID when they are written, so a
+	// link gaining or losing an endpoint has to be rewritten.
+	owners := map[string]bool{}
+	if desired.Endpoints != nil {
+		diffSection(plan, KindEndpoint, current.Endpoints, desired.Endpoints, prune, func(e *Endpoint) string { return e.Name },
+			func(cur, want *Endpoint) []string { return changedFields(cur, want, cur.secrets(), want.secrets()) })
+
+		have := make(map[string]string, len(current.Endpoints))
+		for _, e := range current.Endpoints {
+			have[e.Name] = e.OwnerID
+		}
+		for _, e := range desired.Endpoints {
+			if owner, ok := have[e.Name]; !ok || owner != e.OwnerID {
+				owners[e.OwnerID] = true
+				owners[owner] = true
+			}
+		}
+	}
+
+	if desired.Subscriptions != nil {
+		diffSection(plan, KindSubscription, current.Subscriptions, desired.Subscriptions, prune, func(s *Subscription) string { return s.Name },
+			func(cur, want *Subscription) []string { return changedFields(cur, want, nil, nil) })
+	}
+
+	written := map[string]bool{}
+	if desired.PortalLinks != nil {
+		written = diffSection(plan, KindPortalLink, current.PortalLinks, desired.PortalLinks, prune, func(p *PortalLink) string { return p.Name },
+			func(cur, want *PortalLink) []string { return changedFields(cur, want, nil, nil) })
+	}
+
+	for _, link := range current.PortalLinks {
+		if link.OwnerID == "" || !owners[link.OwnerID] || written[link.Name] {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Kind: KindPortalLink, Name: link.Name, Action: ActionUpdate, Fields: []string{"endpoints"}})
+	}
+
+	sort.SliceStable(plan.Changes, func(a, b int) bool {
+		sa, sb := stepOf(plan.Changes[a]), stepOf(plan.Changes[b])
+		if sa != sb {
+			return sa < sb
+		}
+		return plan.Changes[a].Name < plan.Changes[b].Name
+	})
+
+	return plan
+}
+
+// diffSection plans the creates and updates for one managed section, and
+// the deletes when pruning. It returns the names of the objects written or
+// deleted.
+func diffSection[T any](plan *Plan, kind Kind, current, desired []T, prune bool, name func(*T) string, fields func(cur, want *T) []string) map[string]bool {
+	have := make(map[string]*T, len(current))
+	for i := range current {
+		have[name(&current[i])] = &current[i]
+	}
+
+	touched := map[string]bool{}
+	for i := range desired {
+		want := &desired[i]
+		n := name(want)
+
+		cur, ok := have[n]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: kind, Name: n, Action: ActionCreate})
+			touched[n] = true
+			continue
+		}
+
+		delete(have, n)
+		if changed := fields(cur, want); len(changed) > 0 {
+			plan.Changes = append(plan.Changes, Change{Kind: kind, Name: n, Action: ActionUpdate, Fields: changed})
+			touched[n] = true
+		}
+	}
+
+	if prune {
+		for n := range have {
+			plan.Changes = append(plan.Changes, Change{Kind: kind, Name: n, Action: ActionDelete})
+			touched[n] = true
+		}
+	}
+
+	return touched
+}
+
+func metaEventSecrets(c *ProjectConfig) []secretField {
+	if c == nil || c.MetaEvent == nil || c.MetaEvent.Secret == nil {
+		return nil
+	}
+	return []secretField{{secret: c.MetaEvent.Secret}}
+}
+
+// changedFields lists the top-level JSON fields that differ between two
+// objects. Secrets are compared by a digest of their values, so changing only
+// a reference is not a change.
+func changedFields(have, want interface{}, haveSecrets, wantSecrets []secretField) []string {
+	a, b := fieldsOf(have, haveSecrets), fieldsOf(want, wantSecrets)
+
+	var fields []string
+	for k, v := range b {
+		if !bytes.Equal(v, a[k]) {
+			fields = append(fields, k)
+		}
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
+func fieldsOf(v interface{}, secrets []secretField) map[string]json.RawMessage {
+	refs := make([]string, len(secrets))
+	for i, s := range secrets {
+		refs[i] = s.secret.Ref
+		s.secret.Ref = s.secret.digest()
+	}
+	defer func() {
+		for i, s := range secrets {
+			s.secret.Ref = refs[i]
+		}
+	}()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("project_spec: %v", err))
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(b, &fields); err != nil {
+		panic(fmt.Sprintf("project_spec: %v", err))
+	}
+
+	return fields
+}
+
+func jsonEqual(a, b interface{}) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	return errX == nil && errY == nil && bytes.Equal(x, y)
+}
@@ -0,0 +1,169 @@
+package project_spec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func currentDocument() *Document {
+	advanced := true
+	return &Document{
+		Version: Version,
+		ProjectConfig: &ProjectConfig{
+			MaxPayloadReadSize: 51200,
+			Strategy:           &datastore.StrategyConfiguration{Type: datastore.LinearStrategyProvider, Duration: 100, RetryCount: 10},
+			MetaEvent:          &MetaEvent{IsEnabled: true, URL: "https://example.com/meta", Secret: NewSecret("PROJECT_META_EVENT_SECRET", "meta")},
+		},
+		EventTypes: []EventType{
+			{Name: "order.created", Category: "orders"},
+			{Name: "order.legacy", Deprecated: true},
+			{Name: "order.paid"},
+		},
+		Endpoints: []Endpoint{
+			{Name: "orders", URL: "https://example.com/orders", OwnerID: "tenant-1", ContentType: "application/json", AdvancedSignatures: &advanced, Secret: NewSecret("ENDPOINT_ORDERS_SECRET", "orders")},
+			{Name: "billing", URL: "https://example.com/billing", OwnerID: "tenant-2", ContentType: "application/json", AdvancedSignatures: &advanced, Secret: NewSecret("ENDPOINT_BILLING_SECRET", "billing")},
+		},
+		Subscriptions: []Subscription{
+			{Name: "orders", Endpoint: "orders", DeliveryMode: datastore.AtLeastOnceDeliveryMode, EventTypes: []string{"*"}},
+		},
+		PortalLinks: []PortalLink{
+			{Name: "tenant-1", OwnerID: "tenant-1", AuthType: datastore.PortalAuthTypeStaticToken},
+			{Name: "tenant-3", OwnerID: "tenant-3", AuthType: datastore.PortalAuthTypeStaticToken},
+		},
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	desired := &Document{
+		Version:       Version,
+		ProjectConfig: &ProjectConfig{},
+		EventTypes:    []EventType{{Name: "order.created", Category: "orders"}, {Name: "order.legacy", Deprecated: true}, {Name: "order.paid"}},
+		Endpoints: []Endpoint{
+			{Name: "orders", URL: "https://example.com/orders", OwnerID: "tenant-1", Secret: &Secret{Ref: "RENAMED_REF"}},
+			{Name: "billing", URL: "https://example.com/billing", OwnerID: "tenant-2"},
+		},
+		Subscriptions: []Subscription{{Name: "orders", Endpoint: "orders"}},
+		PortalLinks: []PortalLink{
+			{Name: "tenant-1", OwnerID: "tenant-1"},
+			{Name: "tenant-3", OwnerID: "tenant-3", Permissions: datastore.DefaultPortalLinkPermissions(false)},
+		},
+	}
+	require.NoError(t, desired.Resolve(SecretMap{"RENAMED_REF": "orders"}))
+
+	current := currentDocument()
+	desired.Inherit(current)
+	plan := Diff(current, desired, true)
+
+	require.True(t, plan.Empty(), "%+v", plan.Changes)
+	require.Empty(t, plan.Warnings)
+}
+
+func TestDiff_Changes(t *testing.T) {
+	desired := &Document{
+		Version: Version,
+		EventTypes: []EventType{
+			{Name: "order.created", Category: "orders", Description: "An order was placed"},
+			{Name: "order.legacy"},
+			{Name: "order.refunded"},
+		},
+		Endpoints: []Endpoint{
+			{Name: "orders", URL: "https://example.com/orders", OwnerID: "tenant-1", Secret: &Secret{Ref: "ENDPOINT_ORDERS_SECRET"}},
+			{Name: "shipping", URL: "https://example.com/shipping", OwnerID: "tenant-3"},
+		},
+		Subscriptions: []Subscription{
+			{Name: "orders", Endpoint: "orders", EventTypes: []string{"order.created"}},
+			{Name: "shipping", Endpoint: "shipping"},
+		},
+	}
+	require.NoError(t, desired.Resolve(SecretMap{"ENDPOINT_ORDERS_SECRET": "rotated"}))
+
+	current := currentDocument()
+	desired.Inherit(current)
+	plan := Diff(current, desired, true)
+
+	require.Equal(t, []Change{
+		{Kind: KindEventType, Name: "order.refunded", Action: ActionCreate},
+		{Kind: KindEventType, Name: "order.created", Action: ActionUpdate, Fields: []string{"description"}},
+		{Kind: KindEndpoint, Name: "shipping", Action: ActionCreate},
+		{Kind: KindEndpoint, Name: "orders", Action: ActionUpdate, Fields: []string{"secret"}},
+		{Kind: KindSubscription, Name: "shipping", Action: ActionCreate},
+		{Kind: KindSubscription, Name: "orders", Action: ActionUpdate, Fields: []string{"event_types"}},
+		{Kind: KindPortalLink, Name: "tenant-3", Action: ActionUpdate, Fields: []string{"endpoints"}},
+		{Kind: KindEndpoint, Name: "billing", Action: ActionDelete},
+		{Kind: KindEventType, Name: "order.paid", Action: ActionDeprecate},
+	}, plan.Changes)
+	require.Equal(t, []string{"event type order.legacy is deprecated and cannot be restored"}, plan.Warnings)
+
+	var out bytes.Buffer
+	require.NoError(t, plan.Write(&out))
+	require.Contains(t, out.String(), "~ endpoint orders [secret]\n")
+	require.Contains(t, out.String(), "Plan: 3 to create, 4 to update, 1 to delete, 1 to deprecate.\n")
+	require.NotContains(t, out.String(), "rotated")
+}
+
+func TestDiff_WithoutPruneKeepsUnlistedObjects(t *testing.T) {
+	desired := &Document{Version: Version, EventTypes: []EventType{}, Endpoints: []Endpoint{}, PortalLinks: []PortalLink{}}
+
+	plan := Diff(currentDocument(), desired, false)
+	require.True(t, plan.Empty(), "%+v", plan.Changes)
+}
+
+func TestDiff_ProjectConfig(t *testing.T) {
+	desired := &Document{
+		Version: Version,
+		ProjectConfig: &ProjectConfig{
+			DisableEndpoint: true,
+			MetaEvent:       &MetaEvent{IsEnabled: true, URL: "https://example.com/meta"},
+		},
+	}
+
+	current := currentDocument()
+	desired.Inherit(current)
+	plan := Diff(current, desired, false)
+
+	require.Equal(t, []Change{{Kind: KindProjectConfig, Action: ActionUpdate, Fields: []string{"disable_endpoint"}}}, plan.Changes)
+	require.Equal(t, "meta", desired.ProjectConfig.MetaEvent.Secret.Value(), "an omitted secret keeps the current one")
+	require.Equal(t, uint64(51200), desired.ProjectConfig.MaxPayloadReadSize)
+}
+
+func TestDiff_EventTypeFilters(t *testing.T) {
+	filter := &Filter{Body: datastore.M{"tenant": "acme"}}
+	current := &Document{
+		Version: Version,
+		Subscriptions: []Subscription{{
+			Name:       "orders",
+			Endpoint:   "orders",
+			EventTypes: []string{"order.created", "order.paid"},
+			Filter:     filter,
+		}},
+	}
+
+	same := &Document{
+		Version: Version,
+		Subscriptions: []Subscription{{
+			Name:             "orders",
+			Endpoint:         "orders",
+			EventTypes:       []string{"order.created", "order.paid"},
+			Filter:           filter,
+			EventTypeFilters: []EventTypeFilter{{EventType: "order.paid", Filter: *filter}},
+		}},
+	}
+	require.True(t, Diff(current, same, false).Empty(), "an override matching the subscription filter changes nothing")
+
+	disabled := &Document{
+		Version: Version,
+		Subscriptions: []Subscription{{
+			Name:             "orders",
+			Endpoint:         "orders",
+			EventTypes:       []string{"order.created", "order.paid"},
+			Filter:           filter,
+			EventTypeFilters: []EventTypeFilter{{EventType: "order.paid", Filter: *filter, Disabled: true}},
+		}},
+	}
+	plan := Diff(current, disabled, false)
+	require.Equal(t, []Change{{Kind: KindSubscription, Name: "orders", Action: ActionUpdate, Fields: []string{"event_type_filters"}}}, plan.Changes)
+}
@@ -0,0 +1,210 @@
+package project_spec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Secret names a secret held outside the document. Its value is only set by
+// Resolve, or when a document is exported, and is never encoded.
+type Secret struct {
+	Ref   string `json:"secret_ref"`
+	value string
+}
+
+func NewSecret(ref, value string) *Secret {
+	return &Secret{Ref: ref, value: value}
+}
+
+func (s *Secret) Value() string {
+	if s == nil {
+		return ""
+	}
+	return s.value
+}
+
+// digest stands in for the value when documents are compared, so a changed
+// value shows up in a plan without the value appearing in it.
+func (s *Secret) digest() string {
+	if s.value == "" {
+		return s.Ref
+	}
+
+	sum := sha256.Sum256([]byte(s.value))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// SecretResolver looks up the value of a secret reference.
+type SecretResolver interface {
+	LookupSecret(ref string) (string, bool)
+}
+
+// SecretMap resolves references from a map, usually read from a secrets file.
+type SecretMap map[string]string
+
+func (m SecretMap) LookupSecret(ref string) (string, bool) {
+	v, ok := m[ref]
+	return v, ok && v != ""
+}
+
+type envResolver struct{}
+
+func (envResolver) LookupSecret(ref string) (string, bool) {
+	v, ok := os.LookupEnv(ref)
+	return v, ok && v != ""
+}
+
+// Env resolves references from environment variables of the same name.
+var Env SecretResolver = envResolver{}
+
+// Resolvers tries each resolver in turn.
+type Resolvers []SecretResolver
+
+func (r Resolvers) LookupSecret(ref string) (string, bool) {
+	for _, resolver := range r {
+		if resolver == nil {
+			continue
+		}
+		if v, ok := resolver.LookupSecret(ref); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Resolve sets the value of every secret in the document, reporting every
+// reference the resolver does not know.
+func (d *Document) Resolve(resolver SecretResolver) error {
+	var missing []string
+	for _, s := range d.secrets() {
+		if s.secret.value != "" {
+			continue
+		}
+
+		v, ok := resolver.LookupSecret(s.secret.Ref)
+		if !ok {
+			missing = append(missing, s.secret.Ref)
+			continue
+		}
+		s.secret.value = v
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("secret references are not set: %s", strings.Join(unique(missing), ", "))
+	}
+
+	return nil
+}
+
+// NameSecrets gives every secret a reference named after where it is used,
+// e.g. ENDPOINT_ORDERS_SECRET, and returns the values by reference.
+func (d *Document) NameSecrets() map[string]string {
+	values := map[string]string{}
+	for _, s := range d.secrets() {
+		ref := refName(s.name...)
+		for i := 2; ; i++ {
+			if _, taken := values[ref]; !taken {
+				break
+			}
+			ref = fmt.Sprintf("%s_%d", refName(s.name...), i)
+		}
+
+		s.secret.Ref = ref
+		values[ref] = s.secret.value
+	}
+
+	return values
+}
+
+var refUnsafe = regexp.MustCompile(`[^A-Z0-9]+`)
+
+func refName(parts ...string) string {
+	ref := strings.Trim(refUnsafe.ReplaceAllString(strings.ToUpper(strings.Join(parts, "_")), "_"), "_")
+	if ref == "" || (ref[0] >= '0' && ref[0] <= '9') {
+		ref = "_" + ref
+	}
+	return ref
+}
+
+type secretField struct {
+	path   string
+	name   []string
+	secret *Secret
+}
+
+// secrets lists the document's secrets in a stable order.
+func (d *Document) secrets() []secretField {
+	var fields []secretField
+	add := func(s *Secret, path string, name ...string) {
+		if s != nil {
+			fields = append(fields, secretField{path: path, name: name, secret: s})
+		}
+	}
+
+	if d.ProjectConfig != nil && d.ProjectConfig.MetaEvent != nil {
+		add(d.ProjectConfig.MetaEvent.Secret, "project_config.meta_event.secret", "project", "meta_event", "secret")
+	}
+
+	for i := range d.Sources {
+		s := &d.Sources[i]
+		for _, f := range s.secrets() {
+			add(f.secret, fmt.Sprintf("sources[%s].%s", s.Name, f.path), append([]string{"source", s.Name}, f.name...)...)
+		}
+	}
+
+	for i := range d.Endpoints {
+		e := &d.Endpoints[i]
+		for _, f := range e.secrets() {
+			add(f.secret, fmt.Sprintf("endpoints[%s].%s", e.Name, f.path), append([]string{"endpoint", e.Name}, f.name...)...)
+		}
+	}
+
+	return fields
+}
+
+func (s *Source) secrets() []secretField {
+	if s.Verifier == nil {
+		return nil
+	}
+
+	var fields []secretField
+	if s.Verifier.HMac != nil {
+		fields = append(fields, secretField{path: "verifier.hmac.secret", name: []string{"hmac", "secret"}, secret: &s.Verifier.HMac.Secret})
+	}
+	if s.Verifier.BasicAuth != nil {
+		fields = append(fields, secretField{path: "verifier.basic_auth.password", name: []string{"basic_auth", "password"}, secret: &s.Verifier.BasicAuth.Password})
+	}
+	if s.Verifier.ApiKey != nil {
+		fields = append(fields, secretField{path: "verifier.api_key.header_value", name: []string{"api_key"}, secret: &s.Verifier.ApiKey.HeaderValue})
+	}
+	return fields
+}
+
+func (e *Endpoint) secrets() []secretField {
+	var fields []secretField
+	if e.Secret != nil {
+		fields = append(fields, secretField{path: "secret", name: []string{"secret"}, secret: e.Secret})
+	}
+	if e.Authentication != nil && e.Authentication.ApiKey != nil {
+		fields = append(fields, secretField{path: "authentication.api_key.header_value", name: []string{"auth", "header_value"}, secret: &e.Authentication.ApiKey.HeaderValue})
+	}
+	return fields
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
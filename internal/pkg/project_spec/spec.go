@@ -0,0 +1,311 @@
+// Package project_spec describes a project's configuration as a document that
+// can be kept in version control: its settings, event types, sources,
+// endpoints, subscriptions and portal links. Secrets are never part of the
+// document, it names them with references resolved when it is applied.
+//
+// A section left out of a document is not managed by it, applying the
+// document leaves those objects alone. A section that is present, even when
+// empty, describes every object of its kind.
+package project_spec
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// Version is the document version this package reads and writes.
+const Version = "v1"
+
+// Kind is the kind of object a change applies to.
+type Kind string
+
+const (
+	KindProjectConfig Kind = "project_config"
+	KindEventType     Kind = "event_type"
+	KindSource        Kind = "source"
+	KindEndpoint      Kind = "endpoint"
+	KindSubscription  Kind = "subscription"
+	KindPortalLink    Kind = "portal_link"
+)
+
+// Document is a project's desired configuration.
+type Document struct {
+	Version       string         `json:"version"`
+	ProjectConfig *ProjectConfig `json:"project_config,omitempty"`
+	EventTypes    []EventType    `json:"event_types"`
+	Sources       []Source       `json:"sources"`
+	Endpoints     []Endpoint     `json:"endpoints"`
+	Subscriptions []Subscription `json:"subscriptions"`
+	PortalLinks   []PortalLink   `json:"portal_links"`
+}
+
+// ProjectConfig is the project's settings. Leaving out max_payload_read_size
+// or one of the nested sections keeps the project's current value.
+type ProjectConfig struct {
+	MaxPayloadReadSize             uint64                                 `json:"max_payload_read_size,omitempty"`
+	ReplayAttacksPreventionEnabled bool                                   `json:"replay_attacks_prevention_enabled"`
+	AddEventIDTraceHeaders         bool                                   `json:"add_event_id_trace_headers"`
+	DisableEndpoint                bool                                   `json:"disable_endpoint"`
+	MultipleEndpointSubscriptions  bool                                   `json:"multiple_endpoint_subscriptions"`
+	VerifyDynamicEvents            bool                                   `json:"verify_dynamic_events"`
+	AllowUnmatchedDynamicURLs      bool                                   `json:"allow_unmatched_dynamic_urls"`
+	EndpointMetricLabels           bool                                   `json:"endpoint_metric_labels"`
+	RecordFilterRejections         bool                                   `json:"record_filter_rejections"`
+	SearchPolicy                   string                                 `json:"search_policy,omitempty"`
+	RequestIDHeader                string                                 `json:"request_id_header,omitempty"`
+	SSL                            *datastore.SSLConfiguration            `json:"ssl,omitempty"`
+	RateLimit                      *datastore.RateLimitConfiguration      `json:"ratelimit,omitempty"`
+	Strategy                       *datastore.StrategyConfiguration       `json:"strategy,omitempty"`
+	CircuitBreaker                 *datastore.CircuitBreakerConfiguration `json:"circuit_breaker,omitempty"`
+	Signature                      *Signature                             `json:"signature,omitempty"`
+	MetaEvent                      *MetaEvent                             `json:"meta_event,omitempty"`
+}
+
+// Signature is the project's signature header and versions, oldest first.
+type Signature struct {
+	Header   string             `json:"header"`
+	Versions []SignatureVersion `json:"versions"`
+}
+
+type SignatureVersion struct {
+	Hash     string                 `json:"hash"`
+	Encoding datastore.EncodingType `json:"encoding"`
+}
+
+// MetaEvent configures the project's HTTP meta events. Leaving out the secret
+// keeps the current one.
+type MetaEvent struct {
+	IsEnabled bool     `json:"is_enabled"`
+	URL       string   `json:"url,omitempty"`
+	EventType []string `json:"event_type,omitempty"`
+	Secret    *Secret  `json:"secret,omitempty"`
+}
+
+type EventType struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Category    string                 `json:"category,omitempty"`
+	JSONSchema  map[string]interface{} `json:"json_schema,omitempty"`
+
+	// Deprecated event types stay in the catalog but cannot be restored.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+type Source struct {
+	Name              string                    `json:"name"`
+	Type              datastore.SourceType      `json:"type"`
+	Provider          datastore.SourceProvider  `json:"provider,omitempty"`
+	IsDisabled        bool                      `json:"is_disabled,omitempty"`
+	Verifier          *Verifier                 `json:"verifier,omitempty"`
+	CustomResponse    *datastore.CustomResponse `json:"custom_response,omitempty"`
+	ForwardHeaders    []string                  `json:"forward_headers,omitempty"`
+	IdempotencyKeys   []string                  `json:"idempotency_keys,omitempty"`
+	EventTypeLocation string                    `json:"event_type_location,omitempty"`
+	BodyFunction      string                    `json:"body_function,omitempty"`
+	HeaderFunction    string                    `json:"header_function,omitempty"`
+}
+
+type Verifier struct {
+	Type      datastore.VerifierType `json:"type"`
+	HMac      *HMac                  `json:"hmac,omitempty"`
+	BasicAuth *BasicAuth             `json:"basic_auth,omitempty"`
+	ApiKey    *ApiKey                `json:"api_key,omitempty"`
+}
+
+type HMac struct {
+	Header   string                 `json:"header"`
+	Hash     string                 `json:"hash"`
+	Encoding datastore.EncodingType `json:"encoding"`
+	Secret   Secret                 `json:"secret"`
+}
+
+type BasicAuth struct {
+	UserName string `json:"username"`
+	Password Secret `json:"password"`
+}
+
+type ApiKey struct {
+	HeaderName  string `json:"header_name"`
+	HeaderValue Secret `json:"header_value"`
+}
+
+// Endpoint is an endpoint and its signing secret. Leaving out the secret
+// keeps the current one, changing it expires the current one straight away.
+// Slack, Teams, notification channels and mTLS settings are not part of the
+// document and are left as they are.
+type Endpoint struct {
+	Name               string                  `json:"name"`
+	URL                string                  `json:"url"`
+	Description        string                  `json:"description,omitempty"`
+	OwnerID            string                  `json:"owner_id,omitempty"`
+	ContentType        string                  `json:"content_type,omitempty"`
+	HttpTimeout        uint64                  `json:"http_timeout,omitempty"`
+	RateLimit          int                     `json:"rate_limit,omitempty"`
+	RateLimitDuration  uint64                  `json:"rate_limit_duration,omitempty"`
+	AdvancedSignatures *bool                   `json:"advanced_signatures,omitempty"`
+	SupportEmail       string                  `json:"support_email,omitempty"`
+	Secret             *Secret                 `json:"secret,omitempty"`
+	Authentication     *EndpointAuthentication `json:"authentication,omitempty"`
+}
+
+// EndpointAuthentication only carries api_key settings, OAuth2 and basic
+// auth are exported by type and have to be configured through the API.
+type EndpointAuthentication struct {
+	Type   datastore.EndpointAuthenticationType `json:"type"`
+	ApiKey *ApiKey                              `json:"api_key,omitempty"`
+}
+
+// Subscription refers to its endpoint and source by name.
+type Subscription struct {
+	Name         string                            `json:"name"`
+	Endpoint     string                            `json:"endpoint"`
+	Source       string                            `json:"source,omitempty"`
+	DeliveryMode datastore.DeliveryMode            `json:"delivery_mode,omitempty"`
+	Function     string                            `json:"function,omitempty"`
+	EventTypes   []string                          `json:"event_types,omitempty"`
+	Filter       *Filter                           `json:"filter,omitempty"`
+	RateLimit    *datastore.RateLimitConfiguration `json:"rate_limit,omitempty"`
+	AlertConfig  *datastore.AlertConfiguration     `json:"alert_config,omitempty"`
+
+	// EventTypeFilters override the subscription's filter for one of its
+	// event types, every other event type uses Filter.
+	EventTypeFilters []EventTypeFilter `json:"event_type_filters,omitempty"`
+}
+
+type Filter struct {
+	Headers    datastore.M `json:"headers,omitempty"`
+	Body       datastore.M `json:"body,omitempty"`
+	Query      datastore.M `json:"query,omitempty"`
+	Path       datastore.M `json:"path,omitempty"`
+	Expression string      `json:"expression,omitempty"`
+}
+
+func (f *Filter) hasConditions() bool {
+	return f != nil && (f.Expression != "" || len(f.Headers) > 0 || len(f.Body) > 0 || len(f.Query) > 0 || len(f.Path) > 0)
+}
+
+type EventTypeFilter struct {
+	EventType string `json:"event_type"`
+	Filter
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// PortalLink takes in every endpoint with its owner ID.
+type PortalLink struct {
+	Name              string                           `json:"name"`
+	OwnerID           string                           `json:"owner_id"`
+	AuthType          datastore.PortalAuthType         `json:"auth_type,omitempty"`
+	CanManageEndpoint bool                             `json:"can_manage_endpoint,omitempty"`
+	EventTypes        []string                         `json:"event_types,omitempty"`
+	Permissions       []datastore.PortalLinkPermission `json:"permissions,omitempty"`
+}
+
+var secretRefPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks the document without looking at the project it is applied
+// to, every problem is reported.
+func (d *Document) Validate() error {
+	var errs []error
+	addErr := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if d.Version != Version {
+		addErr("version must be %q", Version)
+	}
+
+	checkNames := func(section string, names []string) {
+		seen := make(map[string]bool, len(names))
+		for i, name := range names {
+			if name == "" {
+				addErr("%s[%d]: name is required", section, i)
+				continue
+			}
+			if seen[name] {
+				addErr("%s[%s]: name is used more than once", section, name)
+			}
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, len(d.EventTypes))
+	for i, et := range d.EventTypes {
+		names[i] = et.Name
+		if et.Name == "*" {
+			addErr("event_types[*]: the catch-all event type is managed by convoy")
+		}
+	}
+	checkNames("event_types", names)
+
+	names = make([]string, len(d.Sources))
+	for i, s := range d.Sources {
+		names[i] = s.Name
+		if s.Type == datastore.PubSubSource {
+			addErr("sources[%s]: pub_sub sources are not supported", s.Name)
+		}
+	}
+	checkNames("sources", names)
+
+	names = make([]string, len(d.Endpoints))
+	for i, e := range d.Endpoints {
+		names[i] = e.Name
+		if e.URL == "" {
+			addErr("endpoints[%s]: url is required", e.Name)
+		}
+	}
+	checkNames("endpoints", names)
+
+	names = make([]string, len(d.Subscriptions))
+	for i, s := range d.Subscriptions {
+		names[i] = s.Name
+		if s.Endpoint == "" {
+			addErr("subscriptions[%s]: endpoint is required", s.Name)
+		}
+
+		eventTypes := s.EventTypes
+		if len(eventTypes) == 0 {
+			eventTypes = []string{"*"}
+		}
+		filtered := make(map[string]bool, len(s.EventTypeFilters))
+		for _, f := range s.EventTypeFilters {
+			if filtered[f.EventType] {
+				addErr("subscriptions[%s]: event type %q has more than one filter", s.Name, f.EventType)
+			}
+			filtered[f.EventType] = true
+
+			if !contains(eventTypes, f.EventType) {
+				addErr("subscriptions[%s]: event type %q has a filter but is not one of the subscription's event types", s.Name, f.EventType)
+			}
+		}
+	}
+	checkNames("subscriptions", names)
+
+	names = make([]string, len(d.PortalLinks))
+	for i, p := range d.PortalLinks {
+		names[i] = p.Name
+		if p.OwnerID == "" {
+			addErr("portal_links[%s]: owner_id is required", p.Name)
+		}
+	}
+	checkNames("portal_links", names)
+
+	for _, s := range d.secrets() {
+		if !secretRefPattern.MatchString(s.secret.Ref) {
+			addErr("%s: secret_ref must be a letter or underscore followed by letters, digits or underscores", s.path)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
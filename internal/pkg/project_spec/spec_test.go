@@ -0,0 +1,165 @@
+package project_spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestDocument_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     Document
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			doc: Document{
+				Version:       Version,
+				EventTypes:    []EventType{{Name: "order.created"}},
+				Endpoints:     []Endpoint{{Name: "orders", URL: "https://example.com", Secret: &Secret{Ref: "ORDERS_SECRET"}}},
+				Subscriptions: []Subscription{{Name: "orders", Endpoint: "orders", EventTypes: []string{"order.created"}, EventTypeFilters: []EventTypeFilter{{EventType: "order.created"}}}},
+				PortalLinks:   []PortalLink{{Name: "tenant", OwnerID: "tenant-1"}},
+			},
+		},
+		{
+			name:    "wrong version",
+			doc:     Document{Version: "v2"},
+			wantErr: []string{`version must be "v1"`},
+		},
+		{
+			name: "every problem is reported",
+			doc: Document{
+				Version:    Version,
+				EventTypes: []EventType{{Name: "*"}},
+				Sources:    []Source{{Name: "queue", Type: datastore.PubSubSource}},
+				Endpoints: []Endpoint{
+					{Name: "orders", URL: "https://example.com"},
+					{Name: "orders"},
+					{URL: "https://example.com"},
+				},
+				Subscriptions: []Subscription{{
+					Name:             "orders",
+					EventTypes:       []string{"order.created"},
+					EventTypeFilters: []EventTypeFilter{{EventType: "order.paid"}, {EventType: "order.paid"}},
+				}},
+				PortalLinks: []PortalLink{{Name: "tenant"}},
+			},
+			wantErr: []string{
+				"event_types[*]: the catch-all event type is managed by convoy",
+				"sources[queue]: pub_sub sources are not supported",
+				"endpoints[orders]: url is required",
+				"endpoints[orders]: name is used more than once",
+				"endpoints[2]: name is required",
+				"subscriptions[orders]: endpoint is required",
+				`subscriptions[orders]: event type "order.paid" has more than one filter`,
+				`subscriptions[orders]: event type "order.paid" has a filter but is not one of the subscription's event types`,
+				"portal_links[tenant]: owner_id is required",
+			},
+		},
+		{
+			name: "filters on the catch-all event type",
+			doc: Document{
+				Version:       Version,
+				Subscriptions: []Subscription{{Name: "all", Endpoint: "orders", EventTypeFilters: []EventTypeFilter{{EventType: "*", Disabled: true}}}},
+			},
+		},
+		{
+			name: "invalid secret reference",
+			doc: Document{
+				Version:   Version,
+				Endpoints: []Endpoint{{Name: "orders", URL: "https://example.com", Secret: &Secret{Ref: "orders-secret"}}},
+			},
+			wantErr: []string{"endpoints[orders].secret: secret_ref must be"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.doc.Validate()
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				require.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func secretDocument() *Document {
+	return &Document{
+		Version: Version,
+		ProjectConfig: &ProjectConfig{
+			MetaEvent: &MetaEvent{IsEnabled: true, Secret: &Secret{Ref: "META_SECRET"}},
+		},
+		Sources: []Source{{
+			Name: "github",
+			Verifier: &Verifier{
+				Type: datastore.HMacVerifier,
+				HMac: &HMac{Header: "X-Hub-Signature", Secret: Secret{Ref: "GITHUB_SECRET"}},
+			},
+		}},
+		Endpoints: []Endpoint{{
+			Name:   "orders",
+			Secret: &Secret{Ref: "ORDERS_SECRET"},
+			Authentication: &EndpointAuthentication{
+				Type:   datastore.APIKeyAuthentication,
+				ApiKey: &ApiKey{HeaderName: "X-Api-Key", HeaderValue: Secret{Ref: "ORDERS_API_KEY"}},
+			},
+		}},
+	}
+}
+
+func TestDocument_Resolve(t *testing.T) {
+	t.Setenv("ORDERS_API_KEY", "from-env")
+
+	doc := secretDocument()
+	resolver := Resolvers{SecretMap{"META_SECRET": "meta", "GITHUB_SECRET": "github", "ORDERS_SECRET": "orders"}, Env}
+	require.NoError(t, doc.Resolve(resolver))
+
+	require.Equal(t, "meta", doc.ProjectConfig.MetaEvent.Secret.Value())
+	require.Equal(t, "github", doc.Sources[0].Verifier.HMac.Secret.Value())
+	require.Equal(t, "orders", doc.Endpoints[0].Secret.Value())
+	require.Equal(t, "from-env", doc.Endpoints[0].Authentication.ApiKey.HeaderValue.Value())
+}
+
+func TestDocument_ResolveReportsEveryMissingReference(t *testing.T) {
+	doc := secretDocument()
+	err := doc.Resolve(SecretMap{"GITHUB_SECRET": "github", "ORDERS_SECRET": ""})
+	require.EqualError(t, err, "secret references are not set: META_SECRET, ORDERS_API_KEY, ORDERS_SECRET")
+}
+
+func TestDocument_NameSecrets(t *testing.T) {
+	doc := &Document{
+		Version: Version,
+		Endpoints: []Endpoint{
+			{Name: "orders-api", URL: "https://example.com/1", Secret: NewSecret("", "one")},
+			{Name: "orders_api", URL: "https://example.com/2", Secret: NewSecret("", "two")},
+			{Name: "2fa", URL: "https://example.com/3", Secret: NewSecret("", "three")},
+		},
+		Sources: []Source{{
+			Name:     "partner",
+			Verifier: &Verifier{Type: datastore.BasicAuthVerifier, BasicAuth: &BasicAuth{UserName: "partner", Password: *NewSecret("", "four")}},
+		}},
+	}
+
+	values := doc.NameSecrets()
+
+	require.Equal(t, "SOURCE_PARTNER_BASIC_AUTH_PASSWORD", doc.Sources[0].Verifier.BasicAuth.Password.Ref)
+	require.Equal(t, "ENDPOINT_ORDERS_API_SECRET", doc.Endpoints[0].Secret.Ref)
+	require.Equal(t, "ENDPOINT_ORDERS_API_SECRET_2", doc.Endpoints[1].Secret.Ref)
+	require.Equal(t, "ENDPOINT_2FA_SECRET", doc.Endpoints[2].Secret.Ref)
+	require.Equal(t, map[string]string{
+		"SOURCE_PARTNER_BASIC_AUTH_PASSWORD": "four",
+		"ENDPOINT_ORDERS_API_SECRET":         "one",
+		"ENDPOINT_ORDERS_API_SECRET_2":       "two",
+		"ENDPOINT_2FA_SECRET":                "three",
+	}, values)
+	require.NoError(t, doc.Validate())
+}
@@ -237,6 +237,7 @@ func (a *Amqp) consumeWithConnection() (connectedSuccessfully bool, err error) {
 // header marshaling, handler invocation, and acknowledgment.
 func (a *Amqp) processMessage(d amqp.Delivery, mm *metrics.Metrics) {
 	a.log.Debugf("AMQP message received, body length: %d bytes", len(d.Body))
+	mm.RecordIngestConsumerLag(a.source, d.Timestamp)
 
 	if d.Headers == nil {
 		d.Headers = amqp.Table{}
@@ -124,6 +124,7 @@ func (g *Google) consume() {
 
 		mm := metrics.GetDPInstance(g.licenser)
 		mm.IncrementIngestTotal(g.source.UID, g.source.ProjectID)
+		mm.RecordIngestConsumerLag(g.source, m.PublishTime)
 
 		if err := g.handler(ctx, g.source, string(m.Data), attributes); err != nil {
 			g.log.Error("failed to write message to create event queue - google pub sub", "error", err)
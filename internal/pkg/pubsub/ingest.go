@@ -340,8 +340,9 @@ func (i *Ingest) handler(ctx context.Context, source *datastore.Source, msg stri
 		}
 
 		job := &queue.Job{
-			ID:      jobId,
-			Payload: eventByte,
+			ID:        jobId,
+			Payload:   eventByte,
+			ProjectID: source.ProjectID,
 		}
 
 		// Enforce the cloud-trial daily cap immediately before enqueue, after payload
@@ -392,8 +393,9 @@ func (i *Ingest) handler(ctx context.Context, source *datastore.Source, msg stri
 		}
 
 		job := &queue.Job{
-			ID:      jobId,
-			Payload: eventByte,
+			ID:        jobId,
+			Payload:   eventByte,
+			ProjectID: source.ProjectID,
 		}
 
 		// Trial cap immediately before enqueue (see the single-message branch).
@@ -430,8 +432,9 @@ func (i *Ingest) handler(ctx context.Context, source *datastore.Source, msg stri
 		}
 
 		job := &queue.Job{
-			ID:      jobId,
-			Payload: eventByte,
+			ID:        jobId,
+			Payload:   eventByte,
+			ProjectID: source.ProjectID,
 		}
 
 		// Trial cap immediately before enqueue (see the single-message branch).
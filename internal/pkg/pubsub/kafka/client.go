@@ -165,6 +165,7 @@ func (k *Kafka) consume() {
 
 			mm := metrics.GetDPInstance(k.licenser)
 			mm.IncrementIngestTotal(k.source.UID, k.source.ProjectID)
+			mm.RecordIngestConsumerLag(k.source, m.Time)
 
 			var d D = m.Headers
 			if d == nil {
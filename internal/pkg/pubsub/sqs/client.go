@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -159,6 +160,7 @@ func (s *Sqs) consume() {
 				QueueUrl:              queueURL,
 				WaitTimeSeconds:       aws.Int64(1),
 				MessageAttributeNames: []*string{&allAttr},
+				AttributeNames:        []*string{aws.String(sqs.MessageSystemAttributeNameSentTimestamp)},
 			})
 			if err != nil {
 				s.log.Error("failed to fetch message - sqs", "error", err)
@@ -176,6 +178,8 @@ func (s *Sqs) consume() {
 
 					defer s.handleError()
 
+					mm.RecordIngestConsumerLag(s.source, sentTimestamp(m))
+
 					var d Attrs = m.MessageAttributes
 
 					if d == nil {
@@ -231,6 +235,21 @@ func (s *Sqs) handleError() {
 	}
 }
 
+// sentTimestamp reads when SQS accepted the message. It is only present when
+// requested through AttributeNames; a missing or malformed value is reported as
+// the zero time, which the lag metric skips.
+func sentTimestamp(m *sqs.Message) time.Time {
+	v, ok := m.Attributes[sqs.MessageSystemAttributeNameSentTimestamp]
+	if !ok || v == nil {
+		return time.Time{}
+	}
+	ms, err := strconv.ParseInt(*v, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
 type M map[string]any
 
 // Attrs is a representation of Sqs MessageAttributes.
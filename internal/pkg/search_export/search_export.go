@@ -0,0 +1,273 @@
+// Package search_export streams every event or delivery a search matches
+// as CSV or JSON Lines, a page at a time, so an export never holds more than
+// one page in memory.
+package search_export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/events"
+)
+
+// pageSize is how many rows an export reads from the database at a time.
+const pageSize = 500
+
+var ErrInvalidResource = errors.New("invalid export resource")
+
+var (
+	eventColumns = []string{
+		"id", "event_type", "source_id", "idempotency_key", "status",
+		"endpoints", "created_at", "data",
+	}
+
+	deliveryColumns = []string{
+		"id", "event_id", "event_type", "endpoint_id", "subscription_id",
+		"status", "attempts", "description", "idempotency_key",
+		"created_at", "updated_at",
+	}
+)
+
+type Exporter struct {
+	eventRepo         datastore.EventRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+}
+
+func New(eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository) *Exporter {
+	return &Exporter{eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo}
+}
+
+// Export writes every row of resource that filter matches to w, oldest
+// first, and returns how many rows it wrote. The filter's dates must be
+// set. A CSV export always starts with a header row.
+func (e *Exporter) Export(ctx context.Context, project *datastore.Project, resource datastore.SearchResource, format datastore.ExportFormat, filter datastore.SearchFilter, w io.Writer) (int64, error) {
+	var rw *rowWriter
+
+	switch resource {
+	case datastore.SearchResourceEvents:
+		rw = newRowWriter(format, eventColumns, w)
+		err := e.eachEvent(ctx, project, filter, func(event *datastore.Event) error {
+			return rw.write(event, eventRecord(event))
+		})
+		if err != nil {
+			return rw.count, err
+		}
+
+	case datastore.SearchResourceEventDeliveries:
+		rw = newRowWriter(format, deliveryColumns, w)
+		err := e.eachDelivery(ctx, project, filter, func(delivery *datastore.EventDelivery) error {
+			return rw.write(delivery, deliveryRecord(delivery))
+		})
+		if err != nil {
+			return rw.count, err
+		}
+
+	default:
+		return 0, ErrInvalidResource
+	}
+
+	return rw.count, rw.flush()
+}
+
+func (e *Exporter) eachEvent(ctx context.Context, project *datastore.Project, filter datastore.SearchFilter, fn func(*datastore.Event) error) error {
+	return e.eachEventPage(ctx, project, filter, func(page []*datastore.Event) error {
+		for _, event := range page {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// eachEventPage calls fn with the events of each page the filter matches.
+func (e *Exporter) eachEventPage(ctx context.Context, project *datastore.Project, filter datastore.SearchFilter, fn func([]*datastore.Event) error) error {
+	var cursor string
+	for {
+		page, pagination, err := e.loadEvents(ctx, project, filter.EventFilter(project, cursor, pageSize))
+		if err != nil {
+			return err
+		}
+
+		matched := make([]*datastore.Event, 0, len(page))
+		for i := range page {
+			if filter.MatchesEvent(&page[i]) {
+				matched = append(matched, &page[i])
+			}
+		}
+
+		if len(matched) > 0 {
+			if err = fn(matched); err != nil {
+				return err
+			}
+		}
+
+		if !pagination.HasNextPage {
+			return nil
+		}
+		cursor = pagination.NextPageCursor
+	}
+}
+
+// loadEvents reads one page of events, holding payload searches to the
+// same per-query timeout the events list uses.
+func (e *Exporter) loadEvents(ctx context.Context, project *datastore.Project, f *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+	if events.NeedsSearchTimeout(f, project) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, events.SearchTimeout)
+		defer cancel()
+	}
+
+	return e.eventRepo.LoadEventsPaged(ctx, project.UID, f)
+}
+
+// eachDelivery reads deliveries straight from the deliveries list unless
+// the filter needs something only the events list can do, a search or a
+// source, in which case it walks the matching events' deliveries instead,
+// loading a page of events' deliveries at a time.
+func (e *Exporter) eachDelivery(ctx context.Context, project *datastore.Project, filter datastore.SearchFilter, fn func(*datastore.EventDelivery) error) error {
+	if filter.HasSearch() || len(filter.SourceIDs) > 0 {
+		return e.eachEventPage(ctx, project, filter, func(page []*datastore.Event) error {
+			ids := make([]string, len(page))
+			for i, event := range page {
+				ids[i] = event.UID
+			}
+
+			deliveries, err := e.eventDeliveryRepo.FindEventDeliveriesByEventIDs(ctx, project.UID, ids)
+			if err != nil {
+				return err
+			}
+
+			for i := range deliveries {
+				if !filter.MatchesDelivery(&deliveries[i]) {
+					continue
+				}
+				if err = fn(&deliveries[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	var cursor string
+	for {
+		pageable := datastore.Pageable{
+			PerPage:    pageSize,
+			Direction:  datastore.Next,
+			Sort:       "ASC",
+			NextCursor: cursor,
+		}
+
+		page, pagination, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(
+			ctx, project.UID, filter.EndpointIDs, "", "", filter.Status,
+			filter.SearchParams(), pageable, filter.IdempotencyKey, filter.EventType, "",
+		)
+		if err != nil {
+			return err
+		}
+
+		for i := range page {
+			if err = fn(&page[i]); err != nil {
+				return err
+			}
+		}
+
+		if !pagination.HasNextPage {
+			return nil
+		}
+		cursor = pagination.NextPageCursor
+	}
+}
+
+// rowWriter writes rows as CSV records or as one JSON document per line.
+type rowWriter struct {
+	csv   *csv.Writer
+	json  *json.Encoder
+	count int64
+	err   error
+}
+
+func newRowWriter(format datastore.ExportFormat, columns []string, w io.Writer) *rowWriter {
+	if format != datastore.ExportFormatCSV {
+		return &rowWriter{json: json.NewEncoder(w)}
+	}
+
+	rw := &rowWriter{csv: csv.NewWriter(w)}
+	rw.err = rw.csv.Write(columns)
+	return rw
+}
+
+func (rw *rowWriter) write(value any, record []string) error {
+	if rw.err != nil {
+		return rw.err
+	}
+
+	if rw.csv != nil {
+		rw.err = rw.csv.Write(record)
+	} else {
+		rw.err = rw.json.Encode(value)
+	}
+
+	if rw.err == nil {
+		rw.count++
+	}
+	return rw.err
+}
+
+func (rw *rowWriter) flush() error {
+	if rw.err != nil || rw.csv == nil {
+		return rw.err
+	}
+
+	rw.csv.Flush()
+	return rw.csv.Error()
+}
+
+func eventRecord(event *datastore.Event) []string {
+	return []string{
+		event.UID,
+		string(event.EventType),
+		event.SourceID,
+		event.IdempotencyKey,
+		string(event.Status),
+		strings.Join(event.Endpoints, ";"),
+		formatTime(event.CreatedAt),
+		string(event.Data),
+	}
+}
+
+func deliveryRecord(delivery *datastore.EventDelivery) []string {
+	var attempts uint64
+	if delivery.Metadata != nil {
+		attempts = delivery.Metadata.NumTrials
+	}
+
+	return []string{
+		delivery.UID,
+		delivery.EventID,
+		string(delivery.EventType),
+		delivery.EndpointID,
+		delivery.SubscriptionID,
+		string(delivery.Status),
+		strconv.FormatUint(attempts, 10),
+		delivery.Description,
+		delivery.IdempotencyKey,
+		formatTime(delivery.CreatedAt),
+		formatTime(delivery.UpdatedAt),
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
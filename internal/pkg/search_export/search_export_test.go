@@ -0,0 +1,147 @@
+package search_export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+)
+
+var (
+	project = &datastore.Project{UID: "project-1"}
+	window  = datastore.SearchFilter{
+		StartDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+func TestExport_EventsCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+
+	created := time.Date(2026, 9, 2, 10, 0, 0, 0, time.UTC)
+	first := []datastore.Event{
+		{UID: "evt-1", EventType: "invoice.paid", SourceID: "src-1", Endpoints: []string{"ep-1", "ep-2"}, Status: datastore.SuccessStatus, Data: json.RawMessage(`{"amount":10}`), CreatedAt: created},
+		{UID: "evt-2", EventType: "invoice.created", CreatedAt: created},
+	}
+	second := []datastore.Event{
+		{UID: "evt-3", EventType: "invoice.paid", CreatedAt: created},
+	}
+
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), project.UID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, f *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+			require.Equal(t, "ASC", f.Pageable.Sort)
+			require.Empty(t, f.Pageable.NextCursor)
+			return first, datastore.PaginationData{HasNextPage: true, NextPageCursor: "evt-2"}, nil
+		})
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), project.UID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, f *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+			require.Equal(t, "evt-2", f.Pageable.NextCursor)
+			return second, datastore.PaginationData{}, nil
+		})
+
+	filter := window
+	filter.EventType = "invoice.paid"
+
+	var buf bytes.Buffer
+	n, err := New(eventRepo, nil).Export(context.Background(), project, datastore.SearchResourceEvents, datastore.ExportFormatCSV, filter, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, eventColumns, records[0])
+	require.Equal(t, []string{"evt-1", "invoice.paid", "src-1", "", "Success", "ep-1;ep-2", "2026-09-02T10:00:00Z", `{"amount":10}`}, records[1])
+	require.Equal(t, "evt-3", records[2][0])
+}
+
+func TestExport_EmptyCSVHasHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), project.UID, gomock.Any()).Return(nil, datastore.PaginationData{}, nil)
+
+	var buf bytes.Buffer
+	n, err := New(eventRepo, nil).Export(context.Background(), project, datastore.SearchResourceEvents, datastore.ExportFormatCSV, window, &buf)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Equal(t, strings.Join(eventColumns, ",")+"\n", buf.String())
+}
+
+func TestExport_DeliveriesJSONL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	deliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+	filter := window
+	filter.EndpointIDs = []string{"ep-1"}
+	filter.Status = []datastore.EventDeliveryStatus{datastore.FailureEventStatus}
+
+	deliveryRepo.EXPECT().LoadEventDeliveriesPaged(
+		gomock.Any(), project.UID, []string{"ep-1"}, "", "", filter.Status,
+		filter.SearchParams(), gomock.Any(), "", "", "",
+	).Return([]datastore.EventDelivery{
+		{UID: "dlv-1", EventID: "evt-1", EndpointID: "ep-1", Status: datastore.FailureEventStatus},
+		{UID: "dlv-2", EventID: "evt-2", EndpointID: "ep-1", Status: datastore.FailureEventStatus},
+	}, datastore.PaginationData{}, nil)
+
+	var buf bytes.Buffer
+	n, err := New(nil, deliveryRepo).Export(context.Background(), project, datastore.SearchResourceEventDeliveries, datastore.ExportFormatJSONL, filter, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var delivery datastore.EventDelivery
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &delivery))
+	require.Equal(t, "dlv-2", delivery.UID)
+}
+
+func TestExport_DeliveriesBySearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	deliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+	filter := window
+	filter.Body = json.RawMessage(`{"customer":"cus_1"}`)
+	filter.EndpointIDs = []string{"ep-1"}
+	filter.Status = []datastore.EventDeliveryStatus{datastore.FailureEventStatus}
+
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), project.UID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, f *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
+			require.JSONEq(t, `{"customer":"cus_1"}`, string(f.Body))
+			return []datastore.Event{{UID: "evt-1"}, {UID: "evt-2"}}, datastore.PaginationData{}, nil
+		})
+	// one query loads the whole page's deliveries
+	deliveryRepo.EXPECT().FindEventDeliveriesByEventIDs(gomock.Any(), project.UID, []string{"evt-1", "evt-2"}).Return([]datastore.EventDelivery{
+		{UID: "dlv-1", EventID: "evt-1", EndpointID: "ep-1", Status: datastore.FailureEventStatus},
+		{UID: "dlv-2", EventID: "evt-1", EndpointID: "ep-1", Status: datastore.SuccessEventStatus},
+		{UID: "dlv-3", EventID: "evt-1", EndpointID: "ep-2", Status: datastore.FailureEventStatus},
+		{UID: "dlv-4", EventID: "evt-2", EndpointID: "ep-1", Status: datastore.FailureEventStatus},
+	}, nil)
+
+	var buf bytes.Buffer
+	n, err := New(eventRepo, deliveryRepo).Export(context.Background(), project, datastore.SearchResourceEventDeliveries, datastore.ExportFormatCSV, filter, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "dlv-1", records[1][0])
+	require.Equal(t, "dlv-4", records[2][0])
+}
+
+func TestExport_InvalidResource(t *testing.T) {
+	_, err := New(nil, nil).Export(context.Background(), project, "endpoints", datastore.ExportFormatCSV, window, &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrInvalidResource)
+}
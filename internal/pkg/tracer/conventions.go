@@ -59,6 +59,14 @@ const (
 	SpanWorkerTaskBatchRetry                    = "worker.task.batch_retry"
 	SpanWorkerTaskBulkOnboard                   = "worker.task.bulk_onboard"
 	SpanWorkerTaskUpdateOrganisationStatus      = "worker.task.update_organisation_status"
+	SpanWorkerTaskRunEndpointHealthChecks       = "worker.task.run_endpoint_health_checks"
+	SpanWorkerTaskReplayJob                     = "worker.task.replay_job"
+	SpanWorkerTaskExportJob                     = "worker.task.export_job"
+	SpanWorkerTaskNotifyEventTypeVersionSunsets = "worker.task.notify_event_type_version_sunsets"
+	SpanWorkerTaskRunAlertRules                 = "worker.task.run_alert_rules"
+	SpanWorkerTaskPruneCircuitBreakers          = "worker.task.prune_circuit_breaker_transitions"
+	SpanWorkerTaskPruneFilterRejections         = "worker.task.prune_filter_rejections"
+	SpanWorkerTaskReapCLISubscriptions          = "worker.task.reap_cli_subscriptions"
 	SpanWorkerTaskUnknown                       = "worker.task.unknown"
 )
 
@@ -92,6 +100,14 @@ var taskNameSpans = map[convoy.TaskName]string{
 	convoy.BatchRetryProcessor:              SpanWorkerTaskBatchRetry,
 	convoy.BulkOnboardProcessor:             SpanWorkerTaskBulkOnboard,
 	convoy.UpdateOrganisationStatus:         SpanWorkerTaskUpdateOrganisationStatus,
+	convoy.RunEndpointHealthChecks:          SpanWorkerTaskRunEndpointHealthChecks,
+	convoy.ReplayJobProcessor:               SpanWorkerTaskReplayJob,
+	convoy.ExportJobProcessor:               SpanWorkerTaskExportJob,
+	convoy.NotifyEventTypeVersionSunsets:    SpanWorkerTaskNotifyEventTypeVersionSunsets,
+	convoy.RunAlertRules:                    SpanWorkerTaskRunAlertRules,
+	convoy.PruneCircuitBreakerTransitions:   SpanWorkerTaskPruneCircuitBreakers,
+	convoy.PruneFilterRejections:            SpanWorkerTaskPruneFilterRejections,
+	convoy.ReapCLISubscriptions:             SpanWorkerTaskReapCLISubscriptions,
 }
 
 // SpanForTaskName returns the span name constant that should wrap a worker
@@ -70,19 +70,7 @@ func (ot *OTelTracer) Init(componentName string) error {
 	}
 
 	// Configure Resources.
-	resources, err := resource.New(
-		context.Background(),
-		resource.WithAttributes(
-			attribute.KeyValue{
-				Key:   semconv.ServiceNameKey,
-				Value: attribute.StringValue(componentName),
-			},
-			attribute.KeyValue{
-				Key:   semconv.ServiceVersionKey,
-				Value: attribute.StringValue(convoy.GetVersion()),
-			},
-		),
-	)
+	resources, err := newResource(componentName)
 	if err != nil {
 		return err
 	}
@@ -106,6 +94,24 @@ func (ot *OTelTracer) Init(componentName string) error {
 	return nil
 }
 
+// newResource describes this process to the collector; traces, metrics and
+// logs share it so they can be joined on service name and version.
+func newResource(componentName string) (*resource.Resource, error) {
+	return resource.New(
+		context.Background(),
+		resource.WithAttributes(
+			attribute.KeyValue{
+				Key:   semconv.ServiceNameKey,
+				Value: attribute.StringValue(componentName),
+			},
+			attribute.KeyValue{
+				Key:   semconv.ServiceVersionKey,
+				Value: attribute.StringValue(convoy.GetVersion()),
+			},
+		),
+	)
+}
+
 func (ot *OTelTracer) Type() config.TracerProvider {
 	return config.OTelTracerProvider
 }
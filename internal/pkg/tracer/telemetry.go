@@ -0,0 +1,148 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"google.golang.org/grpc/credentials"
+
+	otelprom "go.opentelemetry.io/contrib/bridges/prometheus"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/frain-dev/convoy/config"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/util"
+)
+
+const defaultMetricsExportInterval = 30 * time.Second
+
+// Telemetry owns the OTLP metric and log pipelines. It is independent of the
+// tracing Backend so metrics and logs can go to a collector while traces go to
+// Sentry or Datadog, or nowhere at all.
+type Telemetry struct {
+	mp *sdkmetric.MeterProvider
+	lp *sdklog.LoggerProvider
+}
+
+// InitTelemetry starts the OTLP exporters enabled in cfg. Metrics are read from
+// gatherer, so every collector registered for the Prometheus endpoint is pushed
+// unchanged. Log records from every pkg/logger logger are mirrored to the
+// collector and carry the trace and span id of the context they were logged
+// with. It returns a usable (no-op) Telemetry when nothing is enabled.
+func InitTelemetry(cfg config.OTelConfiguration, componentName string, gatherer prometheus.Gatherer) (*Telemetry, error) {
+	t := &Telemetry{}
+	if !cfg.Metrics.Enabled && !cfg.Logs.Enabled {
+		return t, nil
+	}
+
+	if util.IsStringEmpty(cfg.CollectorURL) {
+		return t, ErrInvalidCollectorURL
+	}
+
+	resources, err := newResource(componentName)
+	if err != nil {
+		return t, err
+	}
+
+	ctx := context.Background()
+
+	if cfg.Metrics.Enabled {
+		exporter, err := otlpmetricgrpc.New(ctx, metricExporterOptions(cfg)...)
+		if err != nil {
+			return t, err
+		}
+
+		interval := time.Duration(cfg.Metrics.ExportInterval) * time.Second
+		if interval <= 0 {
+			interval = defaultMetricsExportInterval
+		}
+
+		reader := sdkmetric.NewPeriodicReader(exporter,
+			sdkmetric.WithInterval(interval),
+			sdkmetric.WithProducer(otelprom.NewMetricProducer(otelprom.WithGatherer(gatherer))),
+		)
+
+		t.mp = sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(reader),
+			sdkmetric.WithResource(resources),
+		)
+		otel.SetMeterProvider(t.mp)
+	}
+
+	if cfg.Logs.Enabled {
+		exporter, err := otlploggrpc.New(ctx, logExporterOptions(cfg)...)
+		if err != nil {
+			return t, errors.Join(err, t.Shutdown(ctx))
+		}
+
+		t.lp = sdklog.NewLoggerProvider(
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+			sdklog.WithResource(resources),
+		)
+		log.SetExportHandler(otelslog.NewHandler(componentName, otelslog.WithLoggerProvider(t.lp)))
+	}
+
+	return t, nil
+}
+
+// Shutdown flushes and stops the enabled pipelines. It is safe to call on a
+// nil Telemetry.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	var errs []error
+	if t.lp != nil {
+		log.SetExportHandler(nil)
+		errs = append(errs, t.lp.Shutdown(ctx))
+	}
+	if t.mp != nil {
+		errs = append(errs, t.mp.Shutdown(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+func metricExporterOptions(cfg config.OTelConfiguration) []otlpmetricgrpc.Option {
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.CollectorURL)}
+
+	if cfg.OTelAuth != (config.OTelAuthConfiguration{}) {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(
+			map[string]string{
+				cfg.OTelAuth.HeaderName: cfg.OTelAuth.HeaderValue}))
+	}
+
+	if cfg.InsecureSkipVerify {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+
+	return opts
+}
+
+func logExporterOptions(cfg config.OTelConfiguration) []otlploggrpc.Option {
+	opts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(cfg.CollectorURL)}
+
+	if cfg.OTelAuth != (config.OTelAuthConfiguration{}) {
+		opts = append(opts, otlploggrpc.WithHeaders(
+			map[string]string{
+				cfg.OTelAuth.HeaderName: cfg.OTelAuth.HeaderValue}))
+	}
+
+	if cfg.InsecureSkipVerify {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+
+	return opts
+}
@@ -0,0 +1,34 @@
+package tracer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/config"
+)
+
+func TestInitTelemetry_DisabledIsNoOp(t *testing.T) {
+	tel, err := InitTelemetry(config.OTelConfiguration{}, "test", prometheus.NewRegistry())
+	require.NoError(t, err)
+	require.NotNil(t, tel)
+	require.Nil(t, tel.mp)
+	require.Nil(t, tel.lp)
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestInitTelemetry_RequiresCollectorURL(t *testing.T) {
+	cfg := config.OTelConfiguration{
+		Metrics: config.OTelMetricsConfiguration{Enabled: true},
+	}
+
+	_, err := InitTelemetry(cfg, "test", prometheus.NewRegistry())
+	require.ErrorIs(t, err, ErrInvalidCollectorURL)
+}
+
+func TestTelemetry_ShutdownNil(t *testing.T) {
+	var tel *Telemetry
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/common"
 	endpointspkg "github.com/frain-dev/convoy/internal/endpoints"
@@ -47,6 +48,7 @@ type Service struct {
 	repo         repo.Querier
 	db           *pgxpool.Pool
 	endpointRepo datastore.EndpointRepository
+	hook         *hooks.Hook
 }
 
 // Ensure Service implements datastore.PortalLinkRepository at compile time
@@ -58,6 +60,7 @@ func New(logger log.Logger, db database.Database) *Service {
 		repo:         repo.New(db.GetConn()),
 		db:           db.GetConn(),
 		endpointRepo: endpointspkg.New(logger, db),
+		hook:         db.GetHook(),
 	}
 }
 
@@ -92,6 +95,45 @@ func pgTextToStrings(pt pgtype.Text) []string {
 	return arr
 }
 
+// permissionsToPgText stores portal link permissions like stringsToPgText, but
+// keeps an empty set apart from nil, which stores NULL for the defaults.
+func permissionsToPgText(permissions []datastore.PortalLinkPermission) pgtype.Text {
+	if permissions == nil {
+		return pgtype.Text{String: "", Valid: false}
+	}
+
+	arr := make(pq.StringArray, len(permissions))
+	for i, permission := range permissions {
+		arr[i] = string(permission)
+	}
+
+	val, err := arr.Value()
+	if err != nil {
+		return pgtype.Text{String: "", Valid: false}
+	}
+
+	str, ok := val.(string)
+	return pgtype.Text{String: str, Valid: ok}
+}
+
+// pgTextToPermissions reverses permissionsToPgText, NULL gives nil.
+func pgTextToPermissions(pt pgtype.Text) []datastore.PortalLinkPermission {
+	if !pt.Valid {
+		return nil
+	}
+
+	var arr pq.StringArray
+	if err := arr.Scan(pt.String); err != nil {
+		return nil
+	}
+
+	permissions := make([]datastore.PortalLinkPermission, len(arr))
+	for i, permission := range arr {
+		permissions[i] = datastore.PortalLinkPermission(permission)
+	}
+	return permissions
+}
+
 // Helper function to convert []byte JSON to EndpointMetadata
 func bytesToEndpointMetadata(b []byte) datastore.EndpointMetadata {
 	var metadata datastore.EndpointMetadata
@@ -185,6 +227,8 @@ func (s *Service) CreatePortalLink(ctx context.Context, projectId string, reques
 		AuthType:          request.AuthType,
 		CanManageEndpoint: pgtype.Bool{Bool: request.CanManageEndpoint, Valid: true},
 		Endpoints:         stringsToPgText(request.Endpoints),
+		EventTypes:        stringsToPgText(request.EventTypes),
+		Permissions:       permissionsToPgText(request.Permissions),
 	})
 	if err != nil {
 		s.logger.Error("failed to create portal link", "error", err)
@@ -252,7 +296,7 @@ func (s *Service) CreatePortalLink(ctx context.Context, projectId string, reques
 		endpoints = []string{}
 	}
 
-	return &datastore.PortalLink{
+	portalLink := &datastore.PortalLink{
 		UID:               uid,
 		Name:              request.Name,
 		ProjectID:         projectId,
@@ -261,8 +305,13 @@ func (s *Service) CreatePortalLink(ctx context.Context, projectId string, reques
 		Endpoints:         endpoints,
 		AuthType:          datastore.PortalAuthType(request.AuthType),
 		CanManageEndpoint: request.CanManageEndpoint,
+		EventTypes:        request.EventTypes,
+		Permissions:       request.Permissions,
 		AuthKey:           authKey,
-	}, nil
+	}
+
+	go s.hook.Fire(context.Background(), datastore.PortalLinkCreated, portalLink, nil)
+	return portalLink, nil
 }
 
 func (s *Service) UpdatePortalLink(ctx context.Context, projectID string, portalLink *datastore.PortalLink, request *datastore.UpdatePortalLinkRequest) (*datastore.PortalLink, error) {
@@ -289,6 +338,8 @@ func (s *Service) UpdatePortalLink(ctx context.Context, projectID string, portal
 		CanManageEndpoint: pgtype.Bool{Bool: request.CanManageEndpoint, Valid: true},
 		Name:              common.StringToPgText(request.Name),
 		AuthType:          request.AuthType,
+		EventTypes:        stringsToPgText(request.EventTypes),
+		Permissions:       permissionsToPgText(request.Permissions),
 	})
 	if err != nil {
 		s.logger.Error("failed to update portal link", "error", err)
@@ -343,7 +394,12 @@ func (s *Service) UpdatePortalLink(ctx context.Context, projectID string, portal
 	portalLink.AuthType = datastore.PortalAuthType(request.AuthType)
 	portalLink.CanManageEndpoint = request.CanManageEndpoint
 	portalLink.Endpoints = endpoints
+	portalLink.EventTypes = request.EventTypes
+	if request.Permissions != nil {
+		portalLink.Permissions = request.Permissions
+	}
 
+	go s.hook.Fire(context.Background(), datastore.PortalLinkUpdated, portalLink, nil)
 	return portalLink, nil
 }
 
@@ -557,6 +613,7 @@ func (s *Service) RevokePortalLink(ctx context.Context, projectID, portalLinkID
 		return &ServiceError{ErrMsg: "portal link not found", Err: datastore.ErrPortalLinkNotFound}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.PortalLinkRevoked, &datastore.PortalLink{UID: portalLinkID, ProjectID: projectID}, nil)
 	return nil
 }
 
@@ -808,6 +865,8 @@ func (s *Service) FindPortalLinkByMaskId(ctx context.Context, maskId string) (*d
 		TokenMaskId:       row.TokenMaskID.String,
 		TokenHash:         row.TokenHash.String,
 		CanManageEndpoint: row.CanManageEndpoint.Bool,
+		EventTypes:        pgTextToStrings(row.EventTypes),
+		Permissions:       pgTextToPermissions(row.Permissions),
 		TokenExpiresAt:    null.NewTime(row.TokenExpiresAt.Time, row.TokenExpiresAt.Valid),
 	}, nil
 }
@@ -818,7 +877,7 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 	// Extract fields based on row type
 	var (
 		id, projectID, name, token, ownerID string
-		endpoints                           pgtype.Text
+		endpoints, eventTypes, permissions  pgtype.Text
 		authType                            interface{}
 		canManageEndpoint                   bool
 		endpointCount                       pgtype.Int8
@@ -829,7 +888,8 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 	switch r := row.(type) {
 	case repo.FetchPortalLinkByIdRow:
 		id, projectID, name, token = r.ID, r.ProjectID, r.Name, r.Token
-		endpoints, authType = r.Endpoints, r.AuthType
+		endpoints, eventTypes, authType = r.Endpoints, r.EventTypes, r.AuthType
+		permissions = r.Permissions
 		canManageEndpoint = r.CanManageEndpoint.Bool
 		ownerID = common.PgTextToString(r.OwnerID)
 		endpointCount = r.EndpointCount
@@ -837,7 +897,8 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 		endpointsMetadata = r.EndpointsMetadata
 	case repo.FetchPortalLinkByTokenRow:
 		id, projectID, name, token = r.ID, r.ProjectID, r.Name, r.Token
-		endpoints, authType = r.Endpoints, r.AuthType
+		endpoints, eventTypes, authType = r.Endpoints, r.EventTypes, r.AuthType
+		permissions = r.Permissions
 		canManageEndpoint = r.CanManageEndpoint.Bool
 		ownerID = common.PgTextToString(r.OwnerID)
 		endpointCount = r.EndpointCount
@@ -845,7 +906,8 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 		endpointsMetadata = r.EndpointsMetadata
 	case repo.FetchPortalLinkByOwnerIDRow:
 		id, projectID, name, token = r.ID, r.ProjectID, r.Name, r.Token
-		endpoints, authType = r.Endpoints, r.AuthType
+		endpoints, eventTypes, authType = r.Endpoints, r.EventTypes, r.AuthType
+		permissions = r.Permissions
 		canManageEndpoint = r.CanManageEndpoint.Bool
 		ownerID = common.PgTextToString(r.OwnerID)
 		endpointCount = r.EndpointCount
@@ -853,7 +915,8 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 		endpointsMetadata = r.EndpointsMetadata
 	case repo.FetchPortalLinksPaginatedRow:
 		id, projectID, name, token = r.ID, r.ProjectID, r.Name, r.Token
-		endpoints, authType = r.Endpoints, r.AuthType
+		endpoints, eventTypes, authType = r.Endpoints, r.EventTypes, r.AuthType
+		permissions = r.Permissions
 		canManageEndpoint = r.CanManageEndpoint.Bool
 		ownerID = common.PgTextToString(r.OwnerID)
 		endpointCount = r.EndpointCount
@@ -861,7 +924,8 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 		endpointsMetadata = r.EndpointsMetadata
 	case repo.FetchPortalLinksByOwnerIDRow:
 		id, projectID, name, token = r.ID, r.ProjectID, r.Name, r.Token
-		endpoints, authType = r.Endpoints, r.AuthType
+		endpoints, eventTypes, authType = r.Endpoints, r.EventTypes, r.AuthType
+		permissions = r.Permissions
 		canManageEndpoint = r.CanManageEndpoint.Bool
 		ownerID = common.PgTextToString(r.OwnerID)
 		endpointCount = r.EndpointCount
@@ -885,6 +949,8 @@ func rowToPortalLink(row interface{}) datastore.PortalLink {
 		Endpoints:         endpointsSlice,
 		AuthType:          datastore.PortalAuthType(authType.(string)),
 		CanManageEndpoint: canManageEndpoint,
+		EventTypes:        pgTextToStrings(eventTypes),
+		Permissions:       pgTextToPermissions(permissions),
 		OwnerID:           ownerID,
 		EndpointCount:     int(endpointCount.Int64),
 		CreatedAt:         createdAt.Time,
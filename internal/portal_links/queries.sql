@@ -1,8 +1,8 @@
 -- Portal Links Queries
 
 -- name: CreatePortalLink :exec
-INSERT INTO convoy.portal_links (id, project_id, name, token, endpoints, owner_id, can_manage_endpoint, auth_type)
-VALUES (@id, @project_id, @name, @token, @endpoints, @owner_id, @can_manage_endpoint, @auth_type);
+INSERT INTO convoy.portal_links (id, project_id, name, token, endpoints, owner_id, can_manage_endpoint, auth_type, event_types, permissions)
+VALUES (@id, @project_id, @name, @token, @endpoints, @owner_id, @can_manage_endpoint, @auth_type, @event_types, @permissions);
 
 -- name: CreatePortalLinkAuthToken :exec
 INSERT INTO convoy.portal_tokens (id, portal_link_id, token_mask_id, token_hash, token_salt, token_expires_at)
@@ -24,6 +24,8 @@ SET
     can_manage_endpoint = @can_manage_endpoint,
     name = @name,
     auth_type = @auth_type,
+    event_types = @event_types,
+    permissions = COALESCE(@permissions, permissions),
     updated_at = NOW()
 WHERE id = @id AND project_id = @project_id AND deleted_at IS NULL;
 
@@ -44,6 +46,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -71,6 +75,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -98,6 +104,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -129,6 +137,8 @@ SELECT
     pl.token,
     pl.endpoints,
     pl.auth_type,
+    pl.event_types,
+    pl.permissions,
     COALESCE(pl.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(pl.owner_id, '') AS owner_id,
     CASE
@@ -152,6 +162,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -190,6 +202,8 @@ WITH filtered_portal_links AS (
         p.token,
         p.endpoints,
         p.auth_type,
+        p.event_types,
+        p.permissions,
         COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
         COALESCE(p.owner_id, '') AS owner_id,
         CASE
@@ -236,7 +250,7 @@ WITH filtered_portal_links AS (
 )
 -- Final select: reverse order for backward pagination to get DESC order
 SELECT
-    id, project_id, name, token, endpoints, auth_type, can_manage_endpoint,
+    id, project_id, name, token, endpoints, auth_type, event_types, permissions, can_manage_endpoint,
     owner_id, endpoint_count, created_at, updated_at, endpoints_metadata
 FROM filtered_portal_links
 ORDER BY
@@ -79,8 +79,8 @@ func (q *Queries) CountPrevPortalLinks(ctx context.Context, arg CountPrevPortalL
 
 const createPortalLink = `-- name: CreatePortalLink :exec
 
-INSERT INTO convoy.portal_links (id, project_id, name, token, endpoints, owner_id, can_manage_endpoint, auth_type)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO convoy.portal_links (id, project_id, name, token, endpoints, owner_id, can_manage_endpoint, auth_type, event_types, permissions)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 `
 
 type CreatePortalLinkParams struct {
@@ -92,6 +92,8 @@ type CreatePortalLinkParams struct {
 	OwnerID           pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	AuthType          interface{}
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 }
 
 // Portal Links Queries
@@ -105,6 +107,8 @@ func (q *Queries) CreatePortalLink(ctx context.Context, arg CreatePortalLinkPara
 		arg.OwnerID,
 		arg.CanManageEndpoint,
 		arg.AuthType,
+		arg.EventTypes,
+		arg.Permissions,
 	)
 	return err
 }
@@ -188,6 +192,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -220,6 +226,8 @@ type FetchPortalLinkByIdRow struct {
 	Token             string
 	Endpoints         pgtype.Text
 	AuthType          string
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	OwnerID           pgtype.Text
 	EndpointCount     pgtype.Int8
@@ -238,6 +246,8 @@ func (q *Queries) FetchPortalLinkById(ctx context.Context, arg FetchPortalLinkBy
 		&i.Token,
 		&i.Endpoints,
 		&i.AuthType,
+		&i.EventTypes,
+		&i.Permissions,
 		&i.CanManageEndpoint,
 		&i.OwnerID,
 		&i.EndpointCount,
@@ -260,6 +270,8 @@ SELECT
     pl.token,
     pl.endpoints,
     pl.auth_type,
+    pl.event_types,
+    pl.permissions,
     COALESCE(pl.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(pl.owner_id, '') AS owner_id,
     CASE
@@ -282,6 +294,8 @@ type FetchPortalLinkByMaskIdRow struct {
 	Token             string
 	Endpoints         pgtype.Text
 	AuthType          string
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	OwnerID           pgtype.Text
 	EndpointCount     pgtype.Int8
@@ -301,6 +315,8 @@ func (q *Queries) FetchPortalLinkByMaskId(ctx context.Context, tokenMaskID pgtyp
 		&i.Token,
 		&i.Endpoints,
 		&i.AuthType,
+		&i.EventTypes,
+		&i.Permissions,
 		&i.CanManageEndpoint,
 		&i.OwnerID,
 		&i.EndpointCount,
@@ -342,6 +358,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -374,6 +392,8 @@ type FetchPortalLinkByOwnerIDRow struct {
 	Token             string
 	Endpoints         pgtype.Text
 	AuthType          string
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	OwnerID           pgtype.Text
 	EndpointCount     pgtype.Int8
@@ -392,6 +412,8 @@ func (q *Queries) FetchPortalLinkByOwnerID(ctx context.Context, arg FetchPortalL
 		&i.Token,
 		&i.Endpoints,
 		&i.AuthType,
+		&i.EventTypes,
+		&i.Permissions,
 		&i.CanManageEndpoint,
 		&i.OwnerID,
 		&i.EndpointCount,
@@ -410,6 +432,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -437,6 +461,8 @@ type FetchPortalLinkByTokenRow struct {
 	Token             string
 	Endpoints         pgtype.Text
 	AuthType          string
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	OwnerID           pgtype.Text
 	EndpointCount     pgtype.Int8
@@ -455,6 +481,8 @@ func (q *Queries) FetchPortalLinkByToken(ctx context.Context, token pgtype.Text)
 		&i.Token,
 		&i.Endpoints,
 		&i.AuthType,
+		&i.EventTypes,
+		&i.Permissions,
 		&i.CanManageEndpoint,
 		&i.OwnerID,
 		&i.EndpointCount,
@@ -473,6 +501,8 @@ SELECT
     p.token,
     p.endpoints,
     p.auth_type,
+    p.event_types,
+    p.permissions,
     COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
     COALESCE(p.owner_id, '') AS owner_id,
     CASE
@@ -500,6 +530,8 @@ type FetchPortalLinksByOwnerIDRow struct {
 	Token             string
 	Endpoints         pgtype.Text
 	AuthType          string
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	OwnerID           pgtype.Text
 	EndpointCount     pgtype.Int8
@@ -524,6 +556,8 @@ func (q *Queries) FetchPortalLinksByOwnerID(ctx context.Context, ownerID pgtype.
 			&i.Token,
 			&i.Endpoints,
 			&i.AuthType,
+			&i.EventTypes,
+			&i.Permissions,
 			&i.CanManageEndpoint,
 			&i.OwnerID,
 			&i.EndpointCount,
@@ -551,6 +585,8 @@ WITH filtered_portal_links AS (
         p.token,
         p.endpoints,
         p.auth_type,
+        p.event_types,
+        p.permissions,
         COALESCE(p.can_manage_endpoint, FALSE) AS can_manage_endpoint,
         COALESCE(p.owner_id, '') AS owner_id,
         CASE
@@ -596,7 +632,7 @@ WITH filtered_portal_links AS (
     LIMIT $6
 )
 SELECT
-    id, project_id, name, token, endpoints, auth_type, can_manage_endpoint,
+    id, project_id, name, token, endpoints, auth_type, event_types, permissions, can_manage_endpoint,
     owner_id, endpoint_count, created_at, updated_at, endpoints_metadata
 FROM filtered_portal_links
 ORDER BY
@@ -624,6 +660,8 @@ type FetchPortalLinksPaginatedRow struct {
 	Token             string
 	Endpoints         pgtype.Text
 	AuthType          string
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	CanManageEndpoint pgtype.Bool
 	OwnerID           pgtype.Text
 	EndpointCount     pgtype.Int8
@@ -660,6 +698,8 @@ func (q *Queries) FetchPortalLinksPaginated(ctx context.Context, arg FetchPortal
 			&i.Token,
 			&i.Endpoints,
 			&i.AuthType,
+			&i.EventTypes,
+			&i.Permissions,
 			&i.CanManageEndpoint,
 			&i.OwnerID,
 			&i.EndpointCount,
@@ -702,8 +742,10 @@ SET
     can_manage_endpoint = $3,
     name = $4,
     auth_type = $5,
+    event_types = $6,
+    permissions = COALESCE($7, permissions),
     updated_at = NOW()
-WHERE id = $6 AND project_id = $7 AND deleted_at IS NULL
+WHERE id = $8 AND project_id = $9 AND deleted_at IS NULL
 `
 
 type UpdatePortalLinkParams struct {
@@ -712,6 +754,8 @@ type UpdatePortalLinkParams struct {
 	CanManageEndpoint pgtype.Bool
 	Name              pgtype.Text
 	AuthType          interface{}
+	EventTypes        pgtype.Text
+	Permissions       pgtype.Text
 	ID                pgtype.Text
 	ProjectID         pgtype.Text
 }
@@ -723,6 +767,8 @@ func (q *Queries) UpdatePortalLink(ctx context.Context, arg UpdatePortalLinkPara
 		arg.CanManageEndpoint,
 		arg.Name,
 		arg.AuthType,
+		arg.EventTypes,
+		arg.Permissions,
 		arg.ID,
 		arg.ProjectID,
 	)
@@ -516,3 +516,59 @@ func TestUpdatePortalLink_AddEndpointsToExisting(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, fetchedPortalLink.EndpointCount)
 }
+
+func TestUpdatePortalLink_Permissions(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	project := seedTestData(t, db)
+
+	logger := log.New("convoy", log.LevelInfo)
+	service := New(logger, db)
+
+	// Created without permissions, the link keeps the defaults.
+	createRequest := &datastore.CreatePortalLinkRequest{
+		Name:              "Support Portal Link",
+		OwnerID:           ulid.Make().String(),
+		AuthType:          string(datastore.PortalAuthTypeStaticToken),
+		CanManageEndpoint: true,
+	}
+
+	portalLink, err := service.CreatePortalLink(ctx, project.UID, createRequest)
+	require.NoError(t, err)
+
+	fetchedPortalLink, err := service.GetPortalLink(ctx, project.UID, portalLink.UID)
+	require.NoError(t, err)
+	require.Nil(t, fetchedPortalLink.Permissions)
+	require.True(t, fetchedPortalLink.HasPermission(datastore.PortalLinkRotateSecrets))
+
+	// An empty set revokes every permission.
+	updateRequest := &datastore.UpdatePortalLinkRequest{
+		Name:              createRequest.Name,
+		OwnerID:           createRequest.OwnerID,
+		AuthType:          createRequest.AuthType,
+		CanManageEndpoint: true,
+		Permissions:       []datastore.PortalLinkPermission{},
+	}
+
+	_, err = service.UpdatePortalLink(ctx, project.UID, fetchedPortalLink, updateRequest)
+	require.NoError(t, err)
+
+	fetchedPortalLink, err = service.GetPortalLink(ctx, project.UID, portalLink.UID)
+	require.NoError(t, err)
+	require.Equal(t, []datastore.PortalLinkPermission{}, fetchedPortalLink.Permissions)
+	require.False(t, fetchedPortalLink.HasPermission(datastore.PortalLinkViewPayloads))
+
+	updateRequest.Permissions = []datastore.PortalLinkPermission{datastore.PortalLinkRetryDeliveries}
+	_, err = service.UpdatePortalLink(ctx, project.UID, fetchedPortalLink, updateRequest)
+	require.NoError(t, err)
+
+	// Leaving permissions out of an update keeps them.
+	updateRequest.Permissions = nil
+	updateRequest.Name = "Renamed Support Portal Link"
+	_, err = service.UpdatePortalLink(ctx, project.UID, fetchedPortalLink, updateRequest)
+	require.NoError(t, err)
+
+	fetchedPortalLink, err = service.GetPortalLink(ctx, project.UID, portalLink.UID)
+	require.NoError(t, err)
+	require.Equal(t, "Renamed Support Portal Link", fetchedPortalLink.Name)
+	require.Equal(t, []datastore.PortalLinkPermission{datastore.PortalLinkRetryDeliveries}, fetchedPortalLink.Permissions)
+}
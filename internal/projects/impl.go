@@ -158,6 +158,8 @@ func projectConfigToCreateParams(id string, config *datastore.ProjectConfig) rep
 		// stays the only value read back.
 		SyncDynamicEventAck:           pgtype.Bool{Bool: config.VerifyDynamicEvents, Valid: true},
 		AllowUnmatchedDynamicUrls:     pgtype.Bool{Bool: config.AllowUnmatchedDynamicURLs, Valid: true},
+		RecordFilterRejections:        pgtype.Bool{Bool: config.RecordFilterRejections, Valid: true},
+		EndpointMetricLabels:          pgtype.Bool{Bool: config.EndpointMetricLabels, Valid: true},
 		CbSampleRate:                  pgtype.Int4{Int32: int32(cb.SampleRate), Valid: true},
 		CbErrorTimeout:                pgtype.Int4{Int32: int32(cb.ErrorTimeout), Valid: true},
 		CbFailureThreshold:            pgtype.Int4{Int32: int32(cb.FailureThreshold), Valid: true},
@@ -206,6 +208,8 @@ func projectConfigToUpdateParams(id string, config *datastore.ProjectConfig) rep
 		// stays the only value read back.
 		SyncDynamicEventAck:           pgtype.Bool{Bool: config.VerifyDynamicEvents, Valid: true},
 		AllowUnmatchedDynamicUrls:     pgtype.Bool{Bool: config.AllowUnmatchedDynamicURLs, Valid: true},
+		RecordFilterRejections:        pgtype.Bool{Bool: config.RecordFilterRejections, Valid: true},
+		EndpointMetricLabels:          pgtype.Bool{Bool: config.EndpointMetricLabels, Valid: true},
 		CbSampleRate:                  pgtype.Int4{Int32: int32(cb.SampleRate), Valid: true},
 		CbErrorTimeout:                pgtype.Int4{Int32: int32(cb.ErrorTimeout), Valid: true},
 		CbFailureThreshold:            pgtype.Int4{Int32: int32(cb.FailureThreshold), Valid: true},
@@ -231,6 +235,8 @@ func rowToProject(row interface{}) (*datastore.Project, error) {
 		multipleEndpointSubscriptions                  bool
 		verifyDynamicEvents                            bool
 		allowUnmatchedDynamicURLs                      bool
+		recordFilterRejections                         bool
+		endpointMetricLabels                           bool
 		replayAttacks                                  bool
 		ratelimitCount                                 int32
 		ratelimitDuration                              int32
@@ -263,6 +269,8 @@ func rowToProject(row interface{}) (*datastore.Project, error) {
 		multipleEndpointSubscriptions = r.ConfigMultipleEndpointSubscriptions
 		verifyDynamicEvents = r.ConfigVerifyDynamicEvents
 		allowUnmatchedDynamicURLs = r.ConfigAllowUnmatchedDynamicUrls
+		recordFilterRejections = r.ConfigRecordFilterRejections
+		endpointMetricLabels = r.ConfigEndpointMetricLabels
 		replayAttacks = r.ConfigReplayAttacksPreventionEnabled
 		ratelimitCount = r.ConfigRatelimitCount
 		ratelimitDuration = r.ConfigRatelimitDuration
@@ -298,6 +306,8 @@ func rowToProject(row interface{}) (*datastore.Project, error) {
 		multipleEndpointSubscriptions = r.ConfigMultipleEndpointSubscriptions
 		verifyDynamicEvents = r.ConfigVerifyDynamicEvents
 		allowUnmatchedDynamicURLs = r.ConfigAllowUnmatchedDynamicUrls
+		recordFilterRejections = r.ConfigRecordFilterRejections
+		endpointMetricLabels = r.ConfigEndpointMetricLabels
 		replayAttacks = r.ConfigReplayAttacksPreventionEnabled
 		ratelimitCount = r.ConfigRatelimitCount
 		ratelimitDuration = r.ConfigRatelimitDuration
@@ -347,6 +357,8 @@ func rowToProject(row interface{}) (*datastore.Project, error) {
 		MultipleEndpointSubscriptions: multipleEndpointSubscriptions,
 		VerifyDynamicEvents:           verifyDynamicEvents,
 		AllowUnmatchedDynamicURLs:     allowUnmatchedDynamicURLs,
+		RecordFilterRejections:        recordFilterRejections,
+		EndpointMetricLabels:          endpointMetricLabels,
 		ReplayAttacks:                 replayAttacks,
 		DisableEndpoint:               disableEndpoint,
 		RateLimit: &datastore.RateLimitConfiguration{
@@ -12,7 +12,9 @@ INSERT INTO convoy.project_configurations (
     verify_dynamic_events, sync_dynamic_event_ack, allow_unmatched_dynamic_urls,
     cb_sample_rate, cb_error_timeout, cb_failure_threshold,
     cb_success_threshold, cb_observability_window,
-    cb_minimum_request_count, cb_consecutive_failure_threshold
+    cb_minimum_request_count, cb_consecutive_failure_threshold,
+    endpoint_metric_labels,
+    record_filter_rejections
 )
 VALUES (
     @id, @search_policy, @max_payload_read_size,
@@ -25,7 +27,9 @@ VALUES (
     @verify_dynamic_events, @sync_dynamic_event_ack, @allow_unmatched_dynamic_urls,
     @cb_sample_rate, @cb_error_timeout, @cb_failure_threshold,
     @cb_success_threshold, @cb_observability_window,
-    @cb_minimum_request_count, @cb_consecutive_failure_threshold
+    @cb_minimum_request_count, @cb_consecutive_failure_threshold,
+    @endpoint_metric_labels,
+    @record_filter_rejections
 );
 
 -- name: UpdateProjectConfiguration :execresult
@@ -59,6 +63,8 @@ UPDATE convoy.project_configurations SET
     cb_observability_window = @cb_observability_window,
     cb_minimum_request_count = @cb_minimum_request_count,
     cb_consecutive_failure_threshold = @cb_consecutive_failure_threshold,
+    endpoint_metric_labels = @endpoint_metric_labels,
+    record_filter_rejections = @record_filter_rejections,
     updated_at = NOW()
 WHERE id = @id AND deleted_at IS NULL;
 
@@ -82,6 +88,8 @@ SELECT
     c.multiple_endpoint_subscriptions AS "config_multiple_endpoint_subscriptions",
     c.verify_dynamic_events AS "config_verify_dynamic_events",
     c.allow_unmatched_dynamic_urls AS "config_allow_unmatched_dynamic_urls",
+    c.record_filter_rejections AS "config_record_filter_rejections",
+    c.endpoint_metric_labels AS "config_endpoint_metric_labels",
     c.replay_attacks_prevention_enabled AS "config_replay_attacks_prevention_enabled",
     c.ratelimit_count AS "config_ratelimit_count",
     c.ratelimit_duration AS "config_ratelimit_duration",
@@ -129,6 +137,8 @@ SELECT
     c.multiple_endpoint_subscriptions AS "config_multiple_endpoint_subscriptions",
     c.verify_dynamic_events AS "config_verify_dynamic_events",
     c.allow_unmatched_dynamic_urls AS "config_allow_unmatched_dynamic_urls",
+    c.record_filter_rejections AS "config_record_filter_rejections",
+    c.endpoint_metric_labels AS "config_endpoint_metric_labels",
     c.replay_attacks_prevention_enabled AS "config_replay_attacks_prevention_enabled",
     c.ratelimit_count AS "config_ratelimit_count",
     c.ratelimit_duration AS "config_ratelimit_duration",
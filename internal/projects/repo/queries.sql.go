@@ -64,7 +64,9 @@ INSERT INTO convoy.project_configurations (
     verify_dynamic_events, sync_dynamic_event_ack, allow_unmatched_dynamic_urls,
     cb_sample_rate, cb_error_timeout, cb_failure_threshold,
     cb_success_threshold, cb_observability_window,
-    cb_minimum_request_count, cb_consecutive_failure_threshold
+    cb_minimum_request_count, cb_consecutive_failure_threshold,
+    endpoint_metric_labels,
+    record_filter_rejections
 )
 VALUES (
     $1, $2, $3,
@@ -77,7 +79,7 @@ VALUES (
     $21, $22, $23,
     $24, $25, $26,
     $27, $28,
-    $29, $30
+    $29, $30, $31, $32
 )
 `
 
@@ -112,6 +114,8 @@ type CreateProjectConfigurationParams struct {
 	CbObservabilityWindow          pgtype.Int4
 	CbMinimumRequestCount          pgtype.Int4
 	CbConsecutiveFailureThreshold  pgtype.Int4
+	EndpointMetricLabels           pgtype.Bool
+	RecordFilterRejections         pgtype.Bool
 }
 
 // Project Configuration Queries
@@ -147,6 +151,8 @@ func (q *Queries) CreateProjectConfiguration(ctx context.Context, arg CreateProj
 		arg.CbObservabilityWindow,
 		arg.CbMinimumRequestCount,
 		arg.CbConsecutiveFailureThreshold,
+		arg.EndpointMetricLabels,
+		arg.RecordFilterRejections,
 	)
 	return err
 }
@@ -207,6 +213,8 @@ SELECT
     c.multiple_endpoint_subscriptions AS "config_multiple_endpoint_subscriptions",
     c.verify_dynamic_events AS "config_verify_dynamic_events",
     c.allow_unmatched_dynamic_urls AS "config_allow_unmatched_dynamic_urls",
+    c.record_filter_rejections AS "config_record_filter_rejections",
+    c.endpoint_metric_labels AS "config_endpoint_metric_labels",
     c.replay_attacks_prevention_enabled AS "config_replay_attacks_prevention_enabled",
     c.ratelimit_count AS "config_ratelimit_count",
     c.ratelimit_duration AS "config_ratelimit_duration",
@@ -254,6 +262,8 @@ type FetchProjectByIDRow struct {
 	ConfigMultipleEndpointSubscriptions  bool
 	ConfigVerifyDynamicEvents            bool
 	ConfigAllowUnmatchedDynamicUrls      bool
+	ConfigRecordFilterRejections         bool
+	ConfigEndpointMetricLabels           bool
 	ConfigReplayAttacksPreventionEnabled bool
 	ConfigRatelimitCount                 int32
 	ConfigRatelimitDuration              int32
@@ -299,6 +309,8 @@ func (q *Queries) FetchProjectByID(ctx context.Context, id pgtype.Text) (FetchPr
 		&i.ConfigMultipleEndpointSubscriptions,
 		&i.ConfigVerifyDynamicEvents,
 		&i.ConfigAllowUnmatchedDynamicUrls,
+		&i.ConfigRecordFilterRejections,
+		&i.ConfigEndpointMetricLabels,
 		&i.ConfigReplayAttacksPreventionEnabled,
 		&i.ConfigRatelimitCount,
 		&i.ConfigRatelimitDuration,
@@ -373,6 +385,8 @@ SELECT
     c.multiple_endpoint_subscriptions AS "config_multiple_endpoint_subscriptions",
     c.verify_dynamic_events AS "config_verify_dynamic_events",
     c.allow_unmatched_dynamic_urls AS "config_allow_unmatched_dynamic_urls",
+    c.record_filter_rejections AS "config_record_filter_rejections",
+    c.endpoint_metric_labels AS "config_endpoint_metric_labels",
     c.replay_attacks_prevention_enabled AS "config_replay_attacks_prevention_enabled",
     c.ratelimit_count AS "config_ratelimit_count",
     c.ratelimit_duration AS "config_ratelimit_duration",
@@ -420,6 +434,8 @@ type FetchProjectsRow struct {
 	ConfigMultipleEndpointSubscriptions  bool
 	ConfigVerifyDynamicEvents            bool
 	ConfigAllowUnmatchedDynamicUrls      bool
+	ConfigRecordFilterRejections         bool
+	ConfigEndpointMetricLabels           bool
 	ConfigReplayAttacksPreventionEnabled bool
 	ConfigRatelimitCount                 int32
 	ConfigRatelimitDuration              int32
@@ -471,6 +487,8 @@ func (q *Queries) FetchProjects(ctx context.Context, orgID pgtype.Text) ([]Fetch
 			&i.ConfigMultipleEndpointSubscriptions,
 			&i.ConfigVerifyDynamicEvents,
 			&i.ConfigAllowUnmatchedDynamicUrls,
+			&i.ConfigRecordFilterRejections,
+			&i.ConfigEndpointMetricLabels,
 			&i.ConfigReplayAttacksPreventionEnabled,
 			&i.ConfigRatelimitCount,
 			&i.ConfigRatelimitDuration,
@@ -602,8 +620,10 @@ UPDATE convoy.project_configurations SET
     cb_observability_window = $27,
     cb_minimum_request_count = $28,
     cb_consecutive_failure_threshold = $29,
+    endpoint_metric_labels = $30,
+    record_filter_rejections = $31,
     updated_at = NOW()
-WHERE id = $30 AND deleted_at IS NULL
+WHERE id = $32 AND deleted_at IS NULL
 `
 
 type UpdateProjectConfigurationParams struct {
@@ -636,6 +656,8 @@ type UpdateProjectConfigurationParams struct {
 	CbObservabilityWindow          pgtype.Int4
 	CbMinimumRequestCount          pgtype.Int4
 	CbConsecutiveFailureThreshold  pgtype.Int4
+	EndpointMetricLabels           pgtype.Bool
+	RecordFilterRejections         pgtype.Bool
 	ID                             pgtype.Text
 }
 
@@ -670,6 +692,8 @@ func (q *Queries) UpdateProjectConfiguration(ctx context.Context, arg UpdateProj
 		arg.CbObservabilityWindow,
 		arg.CbMinimumRequestCount,
 		arg.CbConsecutiveFailureThreshold,
+		arg.EndpointMetricLabels,
+		arg.RecordFilterRejections,
 		arg.ID,
 	)
 }
@@ -0,0 +1,166 @@
+package replay_jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/common"
+	"github.com/frain-dev/convoy/internal/replay_jobs/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// defaultLoadLimit bounds a jobs list when the caller passes no limit.
+const defaultLoadLimit = 50
+
+// Service implements the ReplayJobRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.ReplayJobRepository at compile time
+var _ datastore.ReplayJobRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) CreateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	if job == nil {
+		return errors.New("replay job cannot be nil")
+	}
+
+	filter, err := json.Marshal(job.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal replay job filter: %w", err)
+	}
+
+	return s.repo.CreateReplayJob(ctx, repo.CreateReplayJobParams{
+		ID:          job.UID,
+		ProjectID:   job.ProjectID,
+		Status:      string(job.Status),
+		Filter:      filter,
+		TargetType:  string(job.TargetType),
+		EndpointID:  job.EndpointID,
+		RateLimit:   int32(job.RateLimit),
+		TotalEvents: int32(job.TotalEvents),
+	})
+}
+
+func (s *Service) UpdateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	if job == nil {
+		return errors.New("replay job cannot be nil")
+	}
+
+	result, err := s.repo.UpdateReplayJob(ctx, repo.UpdateReplayJobParams{
+		Status:          string(job.Status),
+		ProcessedEvents: int32(job.ProcessedEvents),
+		FailedEvents:    int32(job.FailedEvents),
+		SkippedEvents:   int32(job.SkippedEvents),
+		Cursor:          job.Cursor,
+		Error:           common.StringToPgTextNullable(job.Error),
+		CompletedAt:     common.NullTimeToPgTimestamptz(job.CompletedAt),
+		ID:              job.UID,
+		ProjectID:       job.ProjectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrReplayJobNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) FindReplayJobByID(ctx context.Context, projectID, id string) (*datastore.ReplayJob, error) {
+	row, err := s.repo.FindReplayJobByID(ctx, repo.FindReplayJobByIDParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrReplayJobNotFound
+		}
+		return nil, err
+	}
+
+	return rowToReplayJob(repo.LoadReplayJobsRow(row))
+}
+
+func (s *Service) LoadReplayJobs(ctx context.Context, projectID string, limit int) ([]datastore.ReplayJob, error) {
+	if limit <= 0 {
+		limit = defaultLoadLimit
+	}
+
+	rows, err := s.repo.LoadReplayJobs(ctx, repo.LoadReplayJobsParams{
+		ProjectID: projectID,
+		LimitVal:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]datastore.ReplayJob, 0, len(rows))
+	for _, row := range rows {
+		job, err := rowToReplayJob(row)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, nil
+}
+
+func (s *Service) CancelReplayJob(ctx context.Context, projectID, id string) error {
+	result, err := s.repo.CancelReplayJob(ctx, repo.CancelReplayJobParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrReplayJobNotFound
+	}
+
+	return nil
+}
+
+func rowToReplayJob(row repo.LoadReplayJobsRow) (*datastore.ReplayJob, error) {
+	var filter datastore.ReplayJobFilter
+	if err := json.Unmarshal(row.Filter, &filter); err != nil {
+		return nil, fmt.Errorf("failed to parse replay job filter: %w", err)
+	}
+
+	return &datastore.ReplayJob{
+		UID:             row.ID,
+		ProjectID:       row.ProjectID,
+		Status:          datastore.ReplayJobStatus(row.Status),
+		Filter:          filter,
+		TargetType:      datastore.ReplayTargetType(row.TargetType),
+		EndpointID:      row.EndpointID,
+		RateLimit:       int(row.RateLimit),
+		TotalEvents:     int(row.TotalEvents),
+		ProcessedEvents: int(row.ProcessedEvents),
+		FailedEvents:    int(row.FailedEvents),
+		SkippedEvents:   int(row.SkippedEvents),
+		Cursor:          row.Cursor,
+		Error:           row.Error.String,
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+		CompletedAt:     common.PgTimestamptzToNullTime(row.CompletedAt),
+	}, nil
+}
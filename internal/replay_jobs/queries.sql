@@ -0,0 +1,49 @@
+-- Replay Job Repository SQLc Queries
+-- This file contains all SQL queries for replay job operations
+
+-- name: CreateReplayJob :exec
+INSERT INTO convoy.replay_jobs (
+    id, project_id, status, filter, target_type, endpoint_id, rate_limit,
+    total_events, created_at, updated_at
+) VALUES (
+    @id, @project_id, @status, @filter, @target_type, @endpoint_id, @rate_limit,
+    @total_events, NOW(), NOW()
+);
+
+-- name: UpdateReplayJob :execresult
+UPDATE convoy.replay_jobs SET
+    status = @status,
+    processed_events = @processed_events,
+    failed_events = @failed_events,
+    skipped_events = @skipped_events,
+    cursor = @cursor,
+    error = @error,
+    completed_at = @completed_at,
+    updated_at = NOW()
+WHERE id = @id AND project_id = @project_id AND status <> 'cancelled';
+
+-- name: FindReplayJobByID :one
+SELECT
+    id, project_id, status, filter, target_type, endpoint_id, rate_limit,
+    total_events, processed_events, failed_events, skipped_events, cursor,
+    error, created_at, updated_at, completed_at
+FROM convoy.replay_jobs
+WHERE id = @id AND project_id = @project_id;
+
+-- name: LoadReplayJobs :many
+SELECT
+    id, project_id, status, filter, target_type, endpoint_id, rate_limit,
+    total_events, processed_events, failed_events, skipped_events, cursor,
+    error, created_at, updated_at, completed_at
+FROM convoy.replay_jobs
+WHERE project_id = @project_id
+ORDER BY created_at DESC
+LIMIT @limit_val;
+
+-- name: CancelReplayJob :execresult
+UPDATE convoy.replay_jobs SET
+    status = 'cancelled',
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = @id AND project_id = @project_id
+AND status IN ('pending', 'processing');
@@ -0,0 +1,108 @@
+package replay_jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func newReplayJob(projectID string) *datastore.ReplayJob {
+	return &datastore.ReplayJob{
+		UID:        ulid.Make().String(),
+		ProjectID:  projectID,
+		Status:     datastore.ReplayJobStatusPending,
+		TargetType: datastore.ReplayTargetEndpoint,
+		EndpointID: ulid.Make().String(),
+		RateLimit:  10,
+		Filter: datastore.ReplayJobFilter{
+			StartDate:  time.Now().Add(-30 * 24 * time.Hour).UTC().Truncate(time.Second),
+			EndDate:    time.Now().UTC().Truncate(time.Second),
+			EventTypes: []string{"invoice.paid"},
+			Body:       []byte(`{"currency":"usd"}`),
+		},
+		TotalEvents: 42,
+	}
+}
+
+func TestReplayJob_RoundTrip(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	job := newReplayJob(project.UID)
+	require.NoError(t, service.CreateReplayJob(ctx, job))
+
+	fetched, err := service.FindReplayJobByID(ctx, project.UID, job.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ReplayJobStatusPending, fetched.Status)
+	require.Equal(t, job.EndpointID, fetched.EndpointID)
+	require.Equal(t, 42, fetched.TotalEvents)
+	require.Equal(t, []string{"invoice.paid"}, fetched.Filter.EventTypes)
+	require.True(t, job.Filter.StartDate.Equal(fetched.Filter.StartDate))
+	require.JSONEq(t, `{"currency":"usd"}`, string(fetched.Filter.Body))
+
+	fetched.Status = datastore.ReplayJobStatusCompleted
+	fetched.ProcessedEvents = 40
+	fetched.FailedEvents = 1
+	fetched.SkippedEvents = 1
+	fetched.Cursor = "01HZZ"
+	fetched.CompletedAt = null.TimeFrom(time.Now())
+	require.NoError(t, service.UpdateReplayJob(ctx, fetched))
+
+	fetched, err = service.FindReplayJobByID(ctx, project.UID, job.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ReplayJobStatusCompleted, fetched.Status)
+	require.Equal(t, 40, fetched.ProcessedEvents)
+	require.Equal(t, "01HZZ", fetched.Cursor)
+	require.True(t, fetched.CompletedAt.Valid)
+
+	_, err = service.FindReplayJobByID(ctx, ulid.Make().String(), job.UID)
+	require.ErrorIs(t, err, datastore.ErrReplayJobNotFound)
+}
+
+func TestReplayJob_Cancel(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	job := newReplayJob(project.UID)
+	require.NoError(t, service.CreateReplayJob(ctx, job))
+
+	require.NoError(t, service.CancelReplayJob(ctx, project.UID, job.UID))
+
+	// a cancelled job cannot be cancelled again, nor overwritten by a worker
+	require.ErrorIs(t, service.CancelReplayJob(ctx, project.UID, job.UID), datastore.ErrReplayJobNotFound)
+
+	job.Status = datastore.ReplayJobStatusProcessing
+	job.ProcessedEvents = 5
+	require.ErrorIs(t, service.UpdateReplayJob(ctx, job), datastore.ErrReplayJobNotFound)
+
+	fetched, err := service.FindReplayJobByID(ctx, project.UID, job.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ReplayJobStatusCancelled, fetched.Status)
+	require.Zero(t, fetched.ProcessedEvents)
+	require.True(t, fetched.CompletedAt.Valid)
+}
+
+func TestLoadReplayJobs(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, service.CreateReplayJob(ctx, newReplayJob(project.UID)))
+	}
+
+	jobs, err := service.LoadReplayJobs(ctx, project.UID, 2)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	jobs, err = service.LoadReplayJobs(ctx, ulid.Make().String(), 0)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+}
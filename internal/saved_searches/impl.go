@@ -0,0 +1,156 @@
+package saved_searches
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/saved_searches/repo"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// Service implements the SavedSearchRepository using SQLc-generated queries
+type Service struct {
+	logger log.Logger
+	repo   repo.Querier
+}
+
+// Ensure Service implements datastore.SavedSearchRepository at compile time
+var _ datastore.SavedSearchRepository = (*Service)(nil)
+
+func New(logger log.Logger, db database.Database) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo.New(db.GetConn()),
+	}
+}
+
+func (s *Service) CreateSavedSearch(ctx context.Context, search *datastore.SavedSearch) error {
+	if search == nil {
+		return errors.New("saved search cannot be nil")
+	}
+
+	filter, err := json.Marshal(search.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal saved search filter: %w", err)
+	}
+
+	err = s.repo.CreateSavedSearch(ctx, repo.CreateSavedSearchParams{
+		ID:        search.UID,
+		ProjectID: search.ProjectID,
+		Name:      search.Name,
+		Resource:  string(search.Resource),
+		Filter:    filter,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateSavedSearchName
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) UpdateSavedSearch(ctx context.Context, search *datastore.SavedSearch) error {
+	if search == nil {
+		return errors.New("saved search cannot be nil")
+	}
+
+	filter, err := json.Marshal(search.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal saved search filter: %w", err)
+	}
+
+	result, err := s.repo.UpdateSavedSearch(ctx, repo.UpdateSavedSearchParams{
+		Name:      search.Name,
+		Resource:  string(search.Resource),
+		Filter:    filter,
+		ID:        search.UID,
+		ProjectID: search.ProjectID,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateSavedSearchName
+		}
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) FindSavedSearchByID(ctx context.Context, projectID, id string) (*datastore.SavedSearch, error) {
+	row, err := s.repo.FindSavedSearchByID(ctx, repo.FindSavedSearchByIDParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, datastore.ErrSavedSearchNotFound
+		}
+		return nil, err
+	}
+
+	return rowToSavedSearch(repo.LoadSavedSearchesRow(row))
+}
+
+func (s *Service) LoadSavedSearches(ctx context.Context, projectID string) ([]datastore.SavedSearch, error) {
+	rows, err := s.repo.LoadSavedSearches(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	searches := make([]datastore.SavedSearch, 0, len(rows))
+	for _, row := range rows {
+		search, err := rowToSavedSearch(row)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, *search)
+	}
+
+	return searches, nil
+}
+
+func (s *Service) DeleteSavedSearch(ctx context.Context, projectID, id string) error {
+	result, err := s.repo.DeleteSavedSearch(ctx, repo.DeleteSavedSearchParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+func rowToSavedSearch(row repo.LoadSavedSearchesRow) (*datastore.SavedSearch, error) {
+	var filter datastore.SearchFilter
+	if err := json.Unmarshal(row.Filter, &filter); err != nil {
+		return nil, fmt.Errorf("failed to parse saved search filter: %w", err)
+	}
+
+	return &datastore.SavedSearch{
+		UID:       row.ID,
+		ProjectID: row.ProjectID,
+		Name:      row.Name,
+		Resource:  datastore.SearchResource(row.Resource),
+		Filter:    filter,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}, nil
+}
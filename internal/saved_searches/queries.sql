@@ -0,0 +1,32 @@
+-- Saved Search Repository SQLc Queries
+-- This file contains all SQL queries for saved search operations
+
+-- name: CreateSavedSearch :exec
+INSERT INTO convoy.saved_searches (
+    id, project_id, name, resource, filter, created_at, updated_at
+) VALUES (
+    @id, @project_id, @name, @resource, @filter, NOW(), NOW()
+);
+
+-- name: UpdateSavedSearch :execresult
+UPDATE convoy.saved_searches SET
+    name = @name,
+    resource = @resource,
+    filter = @filter,
+    updated_at = NOW()
+WHERE id = @id AND project_id = @project_id;
+
+-- name: FindSavedSearchByID :one
+SELECT id, project_id, name, resource, filter, created_at, updated_at
+FROM convoy.saved_searches
+WHERE id = @id AND project_id = @project_id;
+
+-- name: LoadSavedSearches :many
+SELECT id, project_id, name, resource, filter, created_at, updated_at
+FROM convoy.saved_searches
+WHERE project_id = @project_id
+ORDER BY name ASC;
+
+-- name: DeleteSavedSearch :execresult
+DELETE FROM convoy.saved_searches
+WHERE id = @id AND project_id = @project_id;
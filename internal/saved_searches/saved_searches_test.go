@@ -0,0 +1,82 @@
+package saved_searches
+
+import (
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func newSavedSearch(projectID, name string) *datastore.SavedSearch {
+	return &datastore.SavedSearch{
+		UID:       ulid.Make().String(),
+		ProjectID: projectID,
+		Name:      name,
+		Resource:  datastore.SearchResourceEventDeliveries,
+		Filter: datastore.SearchFilter{
+			EndpointIDs: []string{ulid.Make().String()},
+			Status:      []datastore.EventDeliveryStatus{datastore.FailureEventStatus},
+			Body:        []byte(`{"customer":"cus_1"}`),
+		},
+	}
+}
+
+func TestSavedSearch_RoundTrip(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	search := newSavedSearch(project.UID, "failed deliveries")
+	require.NoError(t, service.CreateSavedSearch(ctx, search))
+
+	fetched, err := service.FindSavedSearchByID(ctx, project.UID, search.UID)
+	require.NoError(t, err)
+	require.Equal(t, "failed deliveries", fetched.Name)
+	require.Equal(t, datastore.SearchResourceEventDeliveries, fetched.Resource)
+	require.Equal(t, search.Filter.EndpointIDs, fetched.Filter.EndpointIDs)
+	require.Equal(t, search.Filter.Status, fetched.Filter.Status)
+	require.JSONEq(t, `{"customer":"cus_1"}`, string(fetched.Filter.Body))
+
+	fetched.Name = "failed deliveries to customer X"
+	fetched.Filter.Status = nil
+	require.NoError(t, service.UpdateSavedSearch(ctx, fetched))
+
+	fetched, err = service.FindSavedSearchByID(ctx, project.UID, search.UID)
+	require.NoError(t, err)
+	require.Equal(t, "failed deliveries to customer X", fetched.Name)
+	require.Empty(t, fetched.Filter.Status)
+
+	require.NoError(t, service.DeleteSavedSearch(ctx, project.UID, search.UID))
+
+	_, err = service.FindSavedSearchByID(ctx, project.UID, search.UID)
+	require.ErrorIs(t, err, datastore.ErrSavedSearchNotFound)
+	require.ErrorIs(t, service.DeleteSavedSearch(ctx, project.UID, search.UID), datastore.ErrSavedSearchNotFound)
+}
+
+func TestSavedSearch_DuplicateName(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	require.NoError(t, service.CreateSavedSearch(ctx, newSavedSearch(project.UID, "weekly")))
+
+	err := service.CreateSavedSearch(ctx, newSavedSearch(project.UID, "weekly"))
+	require.ErrorIs(t, err, datastore.ErrDuplicateSavedSearchName)
+}
+
+func TestLoadSavedSearches(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	service := createService(t, db)
+	project := seedProject(t, db)
+
+	require.NoError(t, service.CreateSavedSearch(ctx, newSavedSearch(project.UID, "b")))
+	require.NoError(t, service.CreateSavedSearch(ctx, newSavedSearch(project.UID, "a")))
+
+	searches, err := service.LoadSavedSearches(ctx, project.UID)
+	require.NoError(t, err)
+	require.Len(t, searches, 2)
+	require.Equal(t, "a", searches[0].Name)
+	require.Equal(t, "b", searches[1].Name)
+}
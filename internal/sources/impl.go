@@ -12,6 +12,7 @@ import (
 	"github.com/oklog/ulid/v2"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/common"
 	"github.com/frain-dev/convoy/internal/sources/repo"
@@ -38,6 +39,7 @@ type Service struct {
 	logger log.Logger
 	repo   repo.Querier
 	db     *pgxpool.Pool
+	hook   *hooks.Hook
 }
 
 // Ensure Service implements datastore.SourceRepository at compile time
@@ -49,6 +51,7 @@ func New(logger log.Logger, db database.Database) *Service {
 		logger: logger,
 		repo:   repo.New(db.GetConn()),
 		db:     db.GetConn(),
+		hook:   db.GetHook(),
 	}
 }
 
@@ -353,6 +356,7 @@ func (s *Service) CreateSource(ctx context.Context, source *datastore.Source) er
 		return &ServiceError{ErrMsg: "failed to create source", Err: err}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SourceCreated, source, nil)
 	return nil
 }
 
@@ -429,6 +433,7 @@ func (s *Service) UpdateSource(ctx context.Context, projectID string, source *da
 		return &ServiceError{ErrMsg: "failed to update source", Err: err}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SourceUpdated, source, nil)
 	return nil
 }
 
@@ -540,6 +545,7 @@ func (s *Service) DeleteSourceByID(ctx context.Context, projectID, id, verifierI
 		return &ServiceError{ErrMsg: "failed to delete source", Err: err}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SourceDeleted, &datastore.Source{UID: id, ProjectID: projectID}, nil)
 	return nil
 }
 
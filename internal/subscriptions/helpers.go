@@ -104,6 +104,7 @@ type filterConfigParams struct {
 	rawBody     []byte
 	rawQuery    []byte
 	rawPath     []byte
+	expression  string
 }
 
 // filterConfigToParams converts FilterConfiguration to database parameters
@@ -139,12 +140,13 @@ func filterConfigToParams(fc *datastore.FilterConfiguration) filterConfigParams
 		rawBody:     mToPgJSON(fc.Filter.RawBody),
 		rawQuery:    mToPgJSON(fc.Filter.RawQuery),
 		rawPath:     mToPgJSON(fc.Filter.RawPath),
+		expression:  fc.Filter.Expression,
 	}
 }
 
 // paramsToFilterConfig converts database parameters to FilterConfiguration
-func paramsToFilterConfig(eventTypes []string, headers, body, query, path []byte, isFlattened pgtype.Bool, rawHeaders, rawBody, rawQuery, rawPath []byte) *datastore.FilterConfiguration {
-	if len(eventTypes) == 0 && len(headers) == 0 && len(body) == 0 && len(query) == 0 && len(path) == 0 {
+func paramsToFilterConfig(eventTypes []string, headers, body, query, path []byte, isFlattened pgtype.Bool, rawHeaders, rawBody, rawQuery, rawPath []byte, expression string) *datastore.FilterConfiguration {
+	if len(eventTypes) == 0 && len(headers) == 0 && len(body) == 0 && len(query) == 0 && len(path) == 0 && expression == "" {
 		return &datastore.FilterConfiguration{
 			EventTypes: []string{},
 			Filter: datastore.FilterSchema{
@@ -173,6 +175,7 @@ func paramsToFilterConfig(eventTypes []string, headers, body, query, path []byte
 			RawQuery:    pgJSONToM(rawQuery),
 			RawPath:     pgJSONToM(rawPath),
 			IsFlattened: isFlattened.Bool,
+			Expression:  expression,
 		},
 	}
 }
@@ -369,6 +372,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigFilterIsFlattened                                   pgtype.Bool
 		filterConfigFilterHeaders, filterConfigFilterBody               []byte
 		filterConfigFilterQuery, filterConfigFilterPath                 []byte
+		filterConfigFilterExpression                                    string
 		rateLimitConfigCount, rateLimitConfigDuration                   int32
 		endpointMetadataID, endpointMetadataName                        string
 		endpointMetadataProjectID, endpointMetadataSupportEmail         string
@@ -402,6 +406,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigEventTypes = r.FilterConfigEventTypes
 		filterConfigFilterRawHeaders, filterConfigFilterRawBody = r.FilterConfigFilterRawHeaders, r.FilterConfigFilterRawBody
 		filterConfigFilterRawQuery, filterConfigFilterRawPath = r.FilterConfigFilterRawQuery, r.FilterConfigFilterRawPath
+		filterConfigFilterExpression = r.FilterConfigFilterExpression
 		filterConfigFilterIsFlattened = r.FilterConfigFilterIsFlattened
 		filterConfigFilterHeaders, filterConfigFilterBody = r.FilterConfigFilterHeaders, r.FilterConfigFilterBody
 		filterConfigFilterQuery, filterConfigFilterPath = r.FilterConfigFilterQuery, r.FilterConfigFilterPath
@@ -442,6 +447,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigEventTypes = r.FilterConfigEventTypes
 		filterConfigFilterRawHeaders, filterConfigFilterRawBody = r.FilterConfigFilterRawHeaders, r.FilterConfigFilterRawBody
 		filterConfigFilterRawQuery, filterConfigFilterRawPath = r.FilterConfigFilterRawQuery, r.FilterConfigFilterRawPath
+		filterConfigFilterExpression = r.FilterConfigFilterExpression
 		filterConfigFilterIsFlattened = r.FilterConfigFilterIsFlattened
 		filterConfigFilterHeaders, filterConfigFilterBody = r.FilterConfigFilterHeaders, r.FilterConfigFilterBody
 		filterConfigFilterQuery, filterConfigFilterPath = r.FilterConfigFilterQuery, r.FilterConfigFilterPath
@@ -482,6 +488,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigEventTypes = r.FilterConfigEventTypes
 		filterConfigFilterRawHeaders, filterConfigFilterRawBody = r.FilterConfigFilterRawHeaders, r.FilterConfigFilterRawBody
 		filterConfigFilterRawQuery, filterConfigFilterRawPath = r.FilterConfigFilterRawQuery, r.FilterConfigFilterRawPath
+		filterConfigFilterExpression = r.FilterConfigFilterExpression
 		filterConfigFilterIsFlattened = r.FilterConfigFilterIsFlattened
 		filterConfigFilterHeaders, filterConfigFilterBody = r.FilterConfigFilterHeaders, r.FilterConfigFilterBody
 		filterConfigFilterQuery, filterConfigFilterPath = r.FilterConfigFilterQuery, r.FilterConfigFilterPath
@@ -522,6 +529,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigEventTypes = r.FilterConfigEventTypes
 		filterConfigFilterRawHeaders, filterConfigFilterRawBody = r.FilterConfigFilterRawHeaders, r.FilterConfigFilterRawBody
 		filterConfigFilterRawQuery, filterConfigFilterRawPath = r.FilterConfigFilterRawQuery, r.FilterConfigFilterRawPath
+		filterConfigFilterExpression = r.FilterConfigFilterExpression
 		filterConfigFilterIsFlattened = r.FilterConfigFilterIsFlattened
 		filterConfigFilterHeaders, filterConfigFilterBody = r.FilterConfigFilterHeaders, r.FilterConfigFilterBody
 		filterConfigFilterQuery, filterConfigFilterPath = r.FilterConfigFilterQuery, r.FilterConfigFilterPath
@@ -562,6 +570,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigEventTypes = r.FilterConfigEventTypes
 		filterConfigFilterRawHeaders, filterConfigFilterRawBody = r.FilterConfigFilterRawHeaders, r.FilterConfigFilterRawBody
 		filterConfigFilterRawQuery, filterConfigFilterRawPath = r.FilterConfigFilterRawQuery, r.FilterConfigFilterRawPath
+		filterConfigFilterExpression = r.FilterConfigFilterExpression
 		filterConfigFilterIsFlattened = r.FilterConfigFilterIsFlattened
 		filterConfigFilterHeaders, filterConfigFilterBody = r.FilterConfigFilterHeaders, r.FilterConfigFilterBody
 		filterConfigFilterQuery, filterConfigFilterPath = r.FilterConfigFilterQuery, r.FilterConfigFilterPath
@@ -623,6 +632,7 @@ func rowToSubscription(row interface{}) (*datastore.Subscription, error) {
 		filterConfigFilterRawBody,
 		filterConfigFilterRawQuery,
 		filterConfigFilterRawPath,
+		filterConfigFilterExpression,
 	)
 	subscription.RateLimitConfig = paramsToRateLimitConfig(rateLimitConfigCount, rateLimitConfigDuration)
 
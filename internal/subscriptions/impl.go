@@ -14,6 +14,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/common"
 	"github.com/frain-dev/convoy/internal/subscriptions/repo"
@@ -48,6 +49,7 @@ type Service struct {
 	logger log.Logger
 	repo   repo.Querier
 	db     *pgxpool.Pool
+	hook   *hooks.Hook
 }
 
 // Ensure Service implements datastore.SubscriptionRepository at compile time
@@ -59,6 +61,7 @@ func New(logger log.Logger, db database.Database) *Service {
 		logger: logger,
 		repo:   repo.New(db.GetConn()),
 		db:     db.GetConn(),
+		hook:   db.GetHook(),
 	}
 }
 
@@ -93,6 +96,7 @@ func (s *Service) CreateSubscription(ctx context.Context, projectID string, subs
 		return &ServiceError{ErrMsg: "failed to create subscription", Err: err}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SubscriptionCreated, subscription, nil)
 	return nil
 }
 
@@ -147,6 +151,7 @@ func (s *Service) FindOrCreateDynamicSubscription(ctx context.Context, projectID
 		return nil, &ServiceError{ErrMsg: "failed to create subscription", Err: err}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SubscriptionCreated, subscription, nil)
 	return subscription, nil
 }
 
@@ -208,10 +213,12 @@ func (s *Service) createSubscriptionInTx(ctx context.Context, qtx repo.Querier,
 		FilterConfigFilterRawBody:     filterParams.rawBody,
 		FilterConfigFilterRawQuery:    filterParams.rawQuery,
 		FilterConfigFilterRawPath:     filterParams.rawPath,
+		FilterConfigFilterExpression:  filterParams.expression,
 		RateLimitConfigCount:          rateLimitCount,
 		RateLimitConfigDuration:       rateLimitDuration,
 		Function:                      common.StringToPgTextNullable(subscription.Function.String),
 		DeliveryMode:                  common.StringToPgTextNullable(string(subscription.DeliveryMode)),
+		CliExpiresAt:                  common.NullTimeToPgTimestamptz(subscription.CLIExpiresAt),
 	})
 	if err != nil {
 		s.logger.Error("failed to create subscription", "error", err)
@@ -326,6 +333,7 @@ func (s *Service) UpdateSubscription(ctx context.Context, projectID string, subs
 		FilterConfigFilterRawBody:     filterParams.rawBody,
 		FilterConfigFilterRawQuery:    filterParams.rawQuery,
 		FilterConfigFilterRawPath:     filterParams.rawPath,
+		FilterConfigFilterExpression:  filterParams.expression,
 		RateLimitConfigCount:          rateLimitCount,
 		RateLimitConfigDuration:       rateLimitDuration,
 		Function:                      common.StringToPgTextNullable(subscription.Function.String),
@@ -387,6 +395,7 @@ func (s *Service) UpdateSubscription(ctx context.Context, projectID string, subs
 		return &ServiceError{ErrMsg: "failed to update subscription", Err: err}
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SubscriptionUpdated, subscription, nil)
 	return nil
 }
 
@@ -485,6 +494,45 @@ func (s *Service) FindCLISubscriptions(ctx context.Context, projectID string) ([
 	return subscriptions, nil
 }
 
+func (s *Service) ExtendCLISubscription(ctx context.Context, projectID, subscriptionID string, expiresAt time.Time) error {
+	result, err := s.repo.ExtendCLISubscription(ctx, repo.ExtendCLISubscriptionParams{
+		CliExpiresAt: common.TimeToPgTimestamptz(expiresAt),
+		ID:           subscriptionID,
+		ProjectID:    projectID,
+	})
+	if err != nil {
+		s.logger.Error("failed to extend CLI subscription", "error", err)
+		return &ServiceError{ErrMsg: "failed to extend CLI subscription", Err: err}
+	}
+
+	if result.RowsAffected() < 1 {
+		return datastore.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (s *Service) FindExpiredCLISubscriptions(ctx context.Context, limit int) ([]datastore.Subscription, error) {
+	rows, err := s.repo.FetchExpiredCLISubscriptions(ctx, int32(limit))
+	if err != nil {
+		s.logger.Error("failed to fetch expired CLI subscriptions", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to fetch expired CLI subscriptions", Err: err}
+	}
+
+	subscriptions := make([]datastore.Subscription, 0, len(rows))
+	for _, row := range rows {
+		subscriptions = append(subscriptions, datastore.Subscription{
+			UID:        row.ID,
+			ProjectID:  row.ProjectID,
+			Type:       datastore.SubscriptionTypeCLI,
+			EndpointID: row.EndpointID,
+			SourceID:   row.SourceID,
+		})
+	}
+
+	return subscriptions, nil
+}
+
 // ============================================================================
 // PAGINATED Operations
 // ============================================================================
@@ -588,6 +636,7 @@ func (s *Service) DeleteSubscription(ctx context.Context, projectID string, subs
 		return datastore.ErrSubscriptionNotFound
 	}
 
+	go s.hook.Fire(context.Background(), datastore.SubscriptionDeleted, subscription, nil)
 	return nil
 }
 
@@ -633,6 +682,7 @@ func (s *Service) FetchSubscriptionsForBroadcast(ctx context.Context, projectID,
 					[]byte("{}"),
 					[]byte("{}"),
 					[]byte("{}"),
+					"",
 				),
 			}
 			allSubs = append(allSubs, *sub)
@@ -695,6 +745,7 @@ func (s *Service) LoadAllSubscriptionConfig(ctx context.Context, projectIDs []st
 					[]byte("{}"),
 					[]byte("{}"),
 					[]byte("{}"),
+					"",
 				),
 			}
 			subs = append(subs, *sub)
@@ -797,6 +848,7 @@ updated_existing AS (
         s.filter_config_filter_raw_body,
         s.filter_config_filter_raw_query,
         s.filter_config_filter_raw_path,
+        s.filter_config_filter_expression,
         s.rate_limit_config_count,
         s.rate_limit_config_duration
     FROM convoy.subscriptions s
@@ -833,6 +885,7 @@ new_subscriptions AS (
         s.filter_config_filter_raw_body,
         s.filter_config_filter_raw_query,
         s.filter_config_filter_raw_path,
+        s.filter_config_filter_expression,
         s.rate_limit_config_count,
         s.rate_limit_config_duration
     FROM convoy.subscriptions s
@@ -866,6 +919,7 @@ LIMIT $%d`, valuesSQL, argIdx, argIdx, argIdx+1)
 		var filterHeaders, filterBody, filterQuery, filterPath []byte
 		var filterIsFlattened pgtype.Bool
 		var filterRawHeaders, filterRawBody, filterRawQuery, filterRawPath []byte
+		var filterExpression string
 		var rateLimitCount, rateLimitDuration int32
 
 		if err := rows.Scan(
@@ -874,7 +928,7 @@ LIMIT $%d`, valuesSQL, argIdx, argIdx, argIdx+1)
 			&alertCount, &alertThreshold,
 			&retryType, &retryDuration, &retryRetryCount,
 			&eventTypes, &filterHeaders, &filterBody, &filterQuery, &filterPath, &filterIsFlattened,
-			&filterRawHeaders, &filterRawBody, &filterRawQuery, &filterRawPath,
+			&filterRawHeaders, &filterRawBody, &filterRawQuery, &filterRawPath, &filterExpression,
 			&rateLimitCount, &rateLimitDuration,
 		); err != nil {
 			s.logger.Error("failed to scan updated subscription", "error", err)
@@ -893,7 +947,7 @@ LIMIT $%d`, valuesSQL, argIdx, argIdx, argIdx+1)
 			DeliveryMode:    datastore.DeliveryMode(common.PgTextToString(deliveryMode)),
 			AlertConfig:     paramsToAlertConfig(alertCount, alertThreshold),
 			RetryConfig:     paramsToRetryConfig(retryType, retryDuration, retryRetryCount),
-			FilterConfig:    paramsToFilterConfig(eventTypes, filterHeaders, filterBody, filterQuery, filterPath, filterIsFlattened, filterRawHeaders, filterRawBody, filterRawQuery, filterRawPath),
+			FilterConfig:    paramsToFilterConfig(eventTypes, filterHeaders, filterBody, filterQuery, filterPath, filterIsFlattened, filterRawHeaders, filterRawBody, filterRawQuery, filterRawPath, filterExpression),
 			RateLimitConfig: paramsToRateLimitConfig(rateLimitCount, rateLimitDuration),
 			CreatedAt:       common.PgTimestamptzToTime(createdAt),
 			UpdatedAt:       common.PgTimestamptzToTime(updatedAt),
@@ -958,6 +1012,7 @@ func (s *Service) FetchNewSubscriptions(ctx context.Context, projectIDs, knownSu
 				row.FilterConfigFilterRawBody,
 				row.FilterConfigFilterRawQuery,
 				row.FilterConfigFilterRawPath,
+				row.FilterConfigFilterExpression,
 			),
 		}
 		subs = append(subs, *sub)
@@ -27,10 +27,12 @@ INSERT INTO convoy.subscriptions (
     filter_config_filter_raw_body,
     filter_config_filter_raw_query,
     filter_config_filter_raw_path,
+    filter_config_filter_expression,
     rate_limit_config_count,
     rate_limit_config_duration,
     function,
-    delivery_mode
+    delivery_mode,
+    cli_expires_at
 )
 VALUES (
     @id,
@@ -54,13 +56,15 @@ VALUES (
     @filter_config_filter_raw_body,
     @filter_config_filter_raw_query,
     @filter_config_filter_raw_path,
+    @filter_config_filter_expression,
     @rate_limit_config_count,
     @rate_limit_config_duration,
     @function,
     CASE
         WHEN @delivery_mode = '' OR @delivery_mode IS NULL THEN 'at_least_once'::convoy.delivery_mode
         ELSE @delivery_mode::convoy.delivery_mode
-    END
+    END,
+    @cli_expires_at
 );
 
 -- name: UpsertSubscriptionEventTypes :exec
@@ -80,7 +84,8 @@ INSERT INTO convoy.filters (
     raw_headers,
     raw_body,
     raw_query,
-    raw_path
+    raw_path,
+    expression
 )
 SELECT
     convoy.generate_ulid()::VARCHAR,
@@ -93,7 +98,8 @@ SELECT
     filter_config_filter_raw_headers,
     filter_config_filter_raw_body,
     filter_config_filter_raw_query,
-    filter_config_filter_raw_path
+    filter_config_filter_raw_path,
+    filter_config_filter_expression
 FROM convoy.subscriptions s
 WHERE s.id = @subscription_id AND s.deleted_at IS NULL
 ON CONFLICT DO NOTHING;
@@ -123,6 +129,7 @@ SET
     filter_config_filter_raw_body = @filter_config_filter_raw_body,
     filter_config_filter_raw_query = @filter_config_filter_raw_query,
     filter_config_filter_raw_path = @filter_config_filter_raw_path,
+    filter_config_filter_expression = @filter_config_filter_expression,
     rate_limit_config_count = @rate_limit_config_count,
     rate_limit_config_duration = @rate_limit_config_duration,
     function = @function,
@@ -143,6 +150,11 @@ WHERE id IN (
         AND f.event_type <> ALL(s.filter_config_event_types)
 );
 
+-- name: ExtendCLISubscription :execresult
+UPDATE convoy.subscriptions
+SET cli_expires_at = @cli_expires_at
+WHERE id = @id AND project_id = @project_id AND type = 'cli' AND deleted_at IS NULL;
+
 -- ============================================================================
 -- FETCH Operations
 -- ============================================================================
@@ -169,6 +181,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -227,6 +240,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -285,6 +299,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -343,6 +358,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -379,6 +395,20 @@ LEFT JOIN convoy.sources sm ON s.source_id = sm.id
 LEFT JOIN convoy.source_verifiers sv ON sv.id = sm.source_verifier_id
 WHERE s.project_id = @project_id AND s.type = 'cli' AND s.deleted_at IS NULL;
 
+-- name: FetchExpiredCLISubscriptions :many
+-- CLI subscriptions whose listen session stopped heartbeating
+SELECT
+    s.id,
+    s.project_id,
+    COALESCE(s.endpoint_id, '') AS endpoint_id,
+    COALESCE(s.source_id, '') AS source_id
+FROM convoy.subscriptions s
+WHERE s.type = 'cli'
+    AND s.deleted_at IS NULL
+    AND s.cli_expires_at < NOW()
+ORDER BY s.cli_expires_at
+LIMIT @row_limit;
+
 -- ============================================================================
 -- PAGINATED Operations
 -- ============================================================================
@@ -409,6 +439,7 @@ WITH filtered_subscriptions AS (
         s.filter_config_filter_raw_body,
         s.filter_config_filter_raw_query,
         s.filter_config_filter_raw_path,
+        s.filter_config_filter_expression,
         s.filter_config_filter_is_flattened,
         s.filter_config_filter_headers,
         s.filter_config_filter_body,
@@ -485,7 +516,8 @@ SELECT
     retry_config_type, retry_config_duration, retry_config_retry_count,
     filter_config_event_types, filter_config_filter_raw_headers,
     filter_config_filter_raw_body, filter_config_filter_raw_query,
-    filter_config_filter_raw_path, filter_config_filter_is_flattened,
+    filter_config_filter_raw_path, filter_config_filter_expression,
+    filter_config_filter_is_flattened,
     filter_config_filter_headers, filter_config_filter_body,
     filter_config_filter_query, filter_config_filter_path,
     rate_limit_config_count, rate_limit_config_duration,
@@ -597,7 +629,8 @@ SELECT
     s.filter_config_filter_raw_headers,
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
-    s.filter_config_filter_raw_path
+    s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression
 FROM convoy.subscriptions s
 WHERE s.created_at > @last_sync_time
     AND (@has_known_ids::boolean = false OR s.id <> ALL(@known_subscription_ids::text[]))
@@ -30,9 +30,12 @@ type Querier interface {
 	// ============================================================================
 	DeleteSubscription(ctx context.Context, arg DeleteSubscriptionParams) (pgconn.CommandTag, error)
 	DeleteSubscriptionEventTypes(ctx context.Context, subscriptionID string) error
+	ExtendCLISubscription(ctx context.Context, arg ExtendCLISubscriptionParams) (pgconn.CommandTag, error)
 	FetchCLISubscriptions(ctx context.Context, projectID string) ([]FetchCLISubscriptionsRow, error)
 	// Fetch subscriptions that have been deleted
 	FetchDeletedSubscriptions(ctx context.Context, arg FetchDeletedSubscriptionsParams) ([]FetchDeletedSubscriptionsRow, error)
+	// CLI subscriptions whose listen session stopped heartbeating
+	FetchExpiredCLISubscriptions(ctx context.Context, rowLimit int32) ([]FetchExpiredCLISubscriptionsRow, error)
 	// Fetch new subscriptions created after last sync time
 	FetchNewSubscriptions(ctx context.Context, arg FetchNewSubscriptionsParams) ([]FetchNewSubscriptionsRow, error)
 	// ============================================================================
@@ -125,10 +125,12 @@ INSERT INTO convoy.subscriptions (
     filter_config_filter_raw_body,
     filter_config_filter_raw_query,
     filter_config_filter_raw_path,
+    filter_config_filter_expression,
     rate_limit_config_count,
     rate_limit_config_duration,
     function,
-    delivery_mode
+    delivery_mode,
+    cli_expires_at
 )
 VALUES (
     $1,
@@ -155,10 +157,12 @@ VALUES (
     $22,
     $23,
     $24,
+    $25,
     CASE
-        WHEN $25 = '' OR $25 IS NULL THEN 'at_least_once'::convoy.delivery_mode
-        ELSE $25::convoy.delivery_mode
-    END
+        WHEN $26 = '' OR $26 IS NULL THEN 'at_least_once'::convoy.delivery_mode
+        ELSE $26::convoy.delivery_mode
+    END,
+    $27
 )
 `
 
@@ -184,10 +188,12 @@ type CreateSubscriptionParams struct {
 	FilterConfigFilterRawBody     []byte
 	FilterConfigFilterRawQuery    []byte
 	FilterConfigFilterRawPath     []byte
+	FilterConfigFilterExpression  string
 	RateLimitConfigCount          int32
 	RateLimitConfigDuration       int32
 	Function                      pgtype.Text
 	DeliveryMode                  interface{}
+	CliExpiresAt                  pgtype.Timestamptz
 }
 
 // Subscriptions Queries
@@ -217,10 +223,12 @@ func (q *Queries) CreateSubscription(ctx context.Context, arg CreateSubscription
 		arg.FilterConfigFilterRawBody,
 		arg.FilterConfigFilterRawQuery,
 		arg.FilterConfigFilterRawPath,
+		arg.FilterConfigFilterExpression,
 		arg.RateLimitConfigCount,
 		arg.RateLimitConfigDuration,
 		arg.Function,
 		arg.DeliveryMode,
+		arg.CliExpiresAt,
 	)
 	return err
 }
@@ -260,6 +268,22 @@ func (q *Queries) DeleteSubscriptionEventTypes(ctx context.Context, subscription
 	return err
 }
 
+const extendCLISubscription = `-- name: ExtendCLISubscription :execresult
+UPDATE convoy.subscriptions
+SET cli_expires_at = $1
+WHERE id = $2 AND project_id = $3 AND type = 'cli' AND deleted_at IS NULL
+`
+
+type ExtendCLISubscriptionParams struct {
+	CliExpiresAt pgtype.Timestamptz
+	ID           string
+	ProjectID    string
+}
+
+func (q *Queries) ExtendCLISubscription(ctx context.Context, arg ExtendCLISubscriptionParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, extendCLISubscription, arg.CliExpiresAt, arg.ID, arg.ProjectID)
+}
+
 const fetchCLISubscriptions = `-- name: FetchCLISubscriptions :many
 SELECT
     s.id,
@@ -282,6 +306,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -340,6 +365,7 @@ type FetchCLISubscriptionsRow struct {
 	FilterConfigFilterRawBody       []byte
 	FilterConfigFilterRawQuery      []byte
 	FilterConfigFilterRawPath       []byte
+	FilterConfigFilterExpression    string
 	FilterConfigFilterIsFlattened   pgtype.Bool
 	FilterConfigFilterHeaders       []byte
 	FilterConfigFilterBody          []byte
@@ -402,6 +428,7 @@ func (q *Queries) FetchCLISubscriptions(ctx context.Context, projectID string) (
 			&i.FilterConfigFilterRawBody,
 			&i.FilterConfigFilterRawQuery,
 			&i.FilterConfigFilterRawPath,
+			&i.FilterConfigFilterExpression,
 			&i.FilterConfigFilterIsFlattened,
 			&i.FilterConfigFilterHeaders,
 			&i.FilterConfigFilterBody,
@@ -496,6 +523,53 @@ func (q *Queries) FetchDeletedSubscriptions(ctx context.Context, arg FetchDelete
 	return items, nil
 }
 
+const fetchExpiredCLISubscriptions = `-- name: FetchExpiredCLISubscriptions :many
+SELECT
+    s.id,
+    s.project_id,
+    COALESCE(s.endpoint_id, '') AS endpoint_id,
+    COALESCE(s.source_id, '') AS source_id
+FROM convoy.subscriptions s
+WHERE s.type = 'cli'
+    AND s.deleted_at IS NULL
+    AND s.cli_expires_at < NOW()
+ORDER BY s.cli_expires_at
+LIMIT $1
+`
+
+type FetchExpiredCLISubscriptionsRow struct {
+	ID         string
+	ProjectID  string
+	EndpointID string
+	SourceID   string
+}
+
+// CLI subscriptions whose listen session stopped heartbeating
+func (q *Queries) FetchExpiredCLISubscriptions(ctx context.Context, rowLimit int32) ([]FetchExpiredCLISubscriptionsRow, error) {
+	rows, err := q.db.Query(ctx, fetchExpiredCLISubscriptions, rowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FetchExpiredCLISubscriptionsRow
+	for rows.Next() {
+		var i FetchExpiredCLISubscriptionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.EndpointID,
+			&i.SourceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const fetchNewSubscriptions = `-- name: FetchNewSubscriptions :many
 SELECT
     s.name,
@@ -514,7 +588,8 @@ SELECT
     s.filter_config_filter_raw_headers,
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
-    s.filter_config_filter_raw_path
+    s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression
 FROM convoy.subscriptions s
 WHERE s.created_at > $1
     AND ($2::boolean = false OR s.id <> ALL($3::text[]))
@@ -550,6 +625,7 @@ type FetchNewSubscriptionsRow struct {
 	FilterConfigFilterRawBody     []byte
 	FilterConfigFilterRawQuery    []byte
 	FilterConfigFilterRawPath     []byte
+	FilterConfigFilterExpression  string
 }
 
 // Fetch new subscriptions created after last sync time
@@ -586,6 +662,7 @@ func (q *Queries) FetchNewSubscriptions(ctx context.Context, arg FetchNewSubscri
 			&i.FilterConfigFilterRawBody,
 			&i.FilterConfigFilterRawQuery,
 			&i.FilterConfigFilterRawPath,
+			&i.FilterConfigFilterExpression,
 		); err != nil {
 			return nil, err
 		}
@@ -620,6 +697,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -683,6 +761,7 @@ type FetchSubscriptionByIDRow struct {
 	FilterConfigFilterRawBody       []byte
 	FilterConfigFilterRawQuery      []byte
 	FilterConfigFilterRawPath       []byte
+	FilterConfigFilterExpression    string
 	FilterConfigFilterIsFlattened   pgtype.Bool
 	FilterConfigFilterHeaders       []byte
 	FilterConfigFilterBody          []byte
@@ -742,6 +821,7 @@ func (q *Queries) FetchSubscriptionByID(ctx context.Context, arg FetchSubscripti
 		&i.FilterConfigFilterRawBody,
 		&i.FilterConfigFilterRawQuery,
 		&i.FilterConfigFilterRawPath,
+		&i.FilterConfigFilterExpression,
 		&i.FilterConfigFilterIsFlattened,
 		&i.FilterConfigFilterHeaders,
 		&i.FilterConfigFilterBody,
@@ -798,6 +878,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -861,6 +942,7 @@ type FetchSubscriptionsByEndpointIDRow struct {
 	FilterConfigFilterRawBody       []byte
 	FilterConfigFilterRawQuery      []byte
 	FilterConfigFilterRawPath       []byte
+	FilterConfigFilterExpression    string
 	FilterConfigFilterIsFlattened   pgtype.Bool
 	FilterConfigFilterHeaders       []byte
 	FilterConfigFilterBody          []byte
@@ -923,6 +1005,7 @@ func (q *Queries) FetchSubscriptionsByEndpointID(ctx context.Context, arg FetchS
 			&i.FilterConfigFilterRawBody,
 			&i.FilterConfigFilterRawQuery,
 			&i.FilterConfigFilterRawPath,
+			&i.FilterConfigFilterExpression,
 			&i.FilterConfigFilterIsFlattened,
 			&i.FilterConfigFilterHeaders,
 			&i.FilterConfigFilterBody,
@@ -986,6 +1069,7 @@ SELECT
     s.filter_config_filter_raw_body,
     s.filter_config_filter_raw_query,
     s.filter_config_filter_raw_path,
+    s.filter_config_filter_expression,
     s.filter_config_filter_is_flattened,
     s.filter_config_filter_headers,
     s.filter_config_filter_body,
@@ -1049,6 +1133,7 @@ type FetchSubscriptionsBySourceIDRow struct {
 	FilterConfigFilterRawBody       []byte
 	FilterConfigFilterRawQuery      []byte
 	FilterConfigFilterRawPath       []byte
+	FilterConfigFilterExpression    string
 	FilterConfigFilterIsFlattened   pgtype.Bool
 	FilterConfigFilterHeaders       []byte
 	FilterConfigFilterBody          []byte
@@ -1111,6 +1196,7 @@ func (q *Queries) FetchSubscriptionsBySourceID(ctx context.Context, arg FetchSub
 			&i.FilterConfigFilterRawBody,
 			&i.FilterConfigFilterRawQuery,
 			&i.FilterConfigFilterRawPath,
+			&i.FilterConfigFilterExpression,
 			&i.FilterConfigFilterIsFlattened,
 			&i.FilterConfigFilterHeaders,
 			&i.FilterConfigFilterBody,
@@ -1261,6 +1347,7 @@ WITH filtered_subscriptions AS (
         s.filter_config_filter_raw_body,
         s.filter_config_filter_raw_query,
         s.filter_config_filter_raw_path,
+        s.filter_config_filter_expression,
         s.filter_config_filter_is_flattened,
         s.filter_config_filter_headers,
         s.filter_config_filter_body,
@@ -1336,7 +1423,8 @@ SELECT
     retry_config_type, retry_config_duration, retry_config_retry_count,
     filter_config_event_types, filter_config_filter_raw_headers,
     filter_config_filter_raw_body, filter_config_filter_raw_query,
-    filter_config_filter_raw_path, filter_config_filter_is_flattened,
+    filter_config_filter_raw_path, filter_config_filter_expression,
+    filter_config_filter_is_flattened,
     filter_config_filter_headers, filter_config_filter_body,
     filter_config_filter_query, filter_config_filter_path,
     rate_limit_config_count, rate_limit_config_duration,
@@ -1391,6 +1479,7 @@ type FetchSubscriptionsPaginatedRow struct {
 	FilterConfigFilterRawBody       []byte
 	FilterConfigFilterRawQuery      []byte
 	FilterConfigFilterRawPath       []byte
+	FilterConfigFilterExpression    string
 	FilterConfigFilterIsFlattened   pgtype.Bool
 	FilterConfigFilterHeaders       []byte
 	FilterConfigFilterBody          []byte
@@ -1469,6 +1558,7 @@ func (q *Queries) FetchSubscriptionsPaginated(ctx context.Context, arg FetchSubs
 			&i.FilterConfigFilterRawBody,
 			&i.FilterConfigFilterRawQuery,
 			&i.FilterConfigFilterRawPath,
+			&i.FilterConfigFilterExpression,
 			&i.FilterConfigFilterIsFlattened,
 			&i.FilterConfigFilterHeaders,
 			&i.FilterConfigFilterBody,
@@ -1522,7 +1612,8 @@ INSERT INTO convoy.filters (
     raw_headers,
     raw_body,
     raw_query,
-    raw_path
+    raw_path,
+    expression
 )
 SELECT
     convoy.generate_ulid()::VARCHAR,
@@ -1535,7 +1626,8 @@ SELECT
     filter_config_filter_raw_headers,
     filter_config_filter_raw_body,
     filter_config_filter_raw_query,
-    filter_config_filter_raw_path
+    filter_config_filter_raw_path,
+    filter_config_filter_expression
 FROM convoy.subscriptions s
 WHERE s.id = $1 AND s.deleted_at IS NULL
 ON CONFLICT DO NOTHING
@@ -1648,15 +1740,16 @@ SET
     filter_config_filter_raw_body = $16,
     filter_config_filter_raw_query = $17,
     filter_config_filter_raw_path = $18,
-    rate_limit_config_count = $19,
-    rate_limit_config_duration = $20,
-    function = $21,
+    filter_config_filter_expression = $19,
+    rate_limit_config_count = $20,
+    rate_limit_config_duration = $21,
+    function = $22,
     delivery_mode = CASE
-        WHEN $22 = '' OR $22 IS NULL THEN 'at_least_once'::convoy.delivery_mode
-        ELSE $22::convoy.delivery_mode
+        WHEN $23 = '' OR $23 IS NULL THEN 'at_least_once'::convoy.delivery_mode
+        ELSE $23::convoy.delivery_mode
     END,
     updated_at = NOW()
-WHERE id = $23 AND project_id = $24 AND deleted_at IS NULL
+WHERE id = $24 AND project_id = $25 AND deleted_at IS NULL
 `
 
 type UpdateSubscriptionParams struct {
@@ -1678,6 +1771,7 @@ type UpdateSubscriptionParams struct {
 	FilterConfigFilterRawBody     []byte
 	FilterConfigFilterRawQuery    []byte
 	FilterConfigFilterRawPath     []byte
+	FilterConfigFilterExpression  string
 	RateLimitConfigCount          int32
 	RateLimitConfigDuration       int32
 	Function                      pgtype.Text
@@ -1709,6 +1803,7 @@ func (q *Queries) UpdateSubscription(ctx context.Context, arg UpdateSubscription
 		arg.FilterConfigFilterRawBody,
 		arg.FilterConfigFilterRawQuery,
 		arg.FilterConfigFilterRawPath,
+		arg.FilterConfigFilterExpression,
 		arg.RateLimitConfigCount,
 		arg.RateLimitConfigDuration,
 		arg.Function,
@@ -2,9 +2,13 @@ package subscriptions
 
 import (
 	"testing"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
 )
 
 func TestCountEndpointSubscriptions(t *testing.T) {
@@ -414,3 +418,58 @@ func TestCompareFlattenedPayload(t *testing.T) {
 		require.True(t, matches)
 	})
 }
+
+func TestCLISubscriptionExpiry(t *testing.T) {
+	db, ctx, service := setupTestDB(t)
+	defer db.GetConn().Close()
+
+	project, endpoint, source, device := seedTestData(t, db)
+
+	expiredIDs := func(t *testing.T) []string {
+		t.Helper()
+		subs, err := service.FindExpiredCLISubscriptions(ctx, 1000)
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(subs))
+		for _, sub := range subs {
+			ids = append(ids, sub.UID)
+		}
+		return ids
+	}
+
+	stale := createTestCLISubscription(project, device)
+	stale.CLIExpiresAt = null.TimeFrom(time.Now().Add(-time.Minute))
+	require.NoError(t, service.CreateSubscription(ctx, project.UID, stale))
+
+	live := createTestCLISubscription(project, device)
+	live.CLIExpiresAt = null.TimeFrom(time.Now().Add(time.Minute))
+	require.NoError(t, service.CreateSubscription(ctx, project.UID, live))
+
+	t.Run("should_find_only_expired_cli_subscriptions", func(t *testing.T) {
+		ids := expiredIDs(t)
+		require.Contains(t, ids, stale.UID)
+		require.NotContains(t, ids, live.UID)
+	})
+
+	t.Run("should_not_find_an_extended_subscription", func(t *testing.T) {
+		err := service.ExtendCLISubscription(ctx, project.UID, stale.UID, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		require.NotContains(t, expiredIDs(t), stale.UID)
+	})
+
+	t.Run("should_not_extend_other_subscriptions", func(t *testing.T) {
+		sub := createTestSubscription(project, endpoint, source)
+		require.NoError(t, service.CreateSubscription(ctx, project.UID, sub))
+
+		err := service.ExtendCLISubscription(ctx, project.UID, sub.UID, time.Now().Add(time.Minute))
+		require.ErrorIs(t, err, datastore.ErrSubscriptionNotFound)
+	})
+
+	t.Run("should_not_extend_deleted_subscriptions", func(t *testing.T) {
+		require.NoError(t, service.DeleteSubscription(ctx, project.UID, live))
+
+		err := service.ExtendCLISubscription(ctx, project.UID, live.UID, time.Now().Add(time.Minute))
+		require.ErrorIs(t, err, datastore.ErrSubscriptionNotFound)
+	})
+}
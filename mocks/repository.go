@@ -45,6 +45,21 @@ func (m *MockEventDeliveryRepository) EXPECT() *MockEventDeliveryRepositoryMockR
 	return m.recorder
 }
 
+// ClaimScheduledEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) ClaimScheduledEventDeliveries(ctx context.Context, projectID, subscriptionID string, limit int) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduledEventDeliveries", ctx, projectID, subscriptionID, limit)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduledEventDeliveries indicates an expected call of ClaimScheduledEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) ClaimScheduledEventDeliveries(ctx, projectID, subscriptionID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduledEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).ClaimScheduledEventDeliveries), ctx, projectID, subscriptionID, limit)
+}
+
 // CountDeliveriesByEndpointAndStatus mocks base method.
 func (m *MockEventDeliveryRepository) CountDeliveriesByEndpointAndStatus(ctx context.Context, projectID string, endpointIDs []string, statuses []datastore.EventDeliveryStatus, params datastore.SearchParams) ([]datastore.EndpointStatusDeliveryCount, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +133,20 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) CreateEventDelivery(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CreateEventDelivery), arg0, arg1)
 }
 
+// DiscardCLIDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) DiscardCLIDeliveries(ctx context.Context, projectID, subscriptionID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardCLIDeliveries", ctx, projectID, subscriptionID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardCLIDeliveries indicates an expected call of DiscardCLIDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) DiscardCLIDeliveries(ctx, projectID, subscriptionID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardCLIDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).DiscardCLIDeliveries), ctx, projectID, subscriptionID, reason)
+}
+
 // ExportRecords mocks base method.
 func (m *MockEventDeliveryRepository) ExportRecords(ctx context.Context, start, end time.Time, w io.Writer) (int64, error) {
 	m.ctrl.T.Helper()
@@ -163,6 +192,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByEventID(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByEventID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByEventID), ctx, projectID, id)
 }
 
+// FindEventDeliveriesByEventIDs mocks base method.
+func (m *MockEventDeliveryRepository) FindEventDeliveriesByEventIDs(ctx context.Context, projectID string, eventIDs []string) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventDeliveriesByEventIDs", ctx, projectID, eventIDs)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventDeliveriesByEventIDs indicates an expected call of FindEventDeliveriesByEventIDs.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByEventIDs(ctx, projectID, eventIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByEventIDs", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByEventIDs), ctx, projectID, eventIDs)
+}
+
 // FindEventDeliveriesByIDs mocks base method.
 func (m *MockEventDeliveryRepository) FindEventDeliveriesByIDs(ctx context.Context, projectID string, ids []string) ([]datastore.EventDelivery, error) {
 	m.ctrl.T.Helper()
@@ -1956,6 +2000,20 @@ func (mr *MockSubscriptionRepositoryMockRecorder) DeleteSubscription(ctx, projec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).DeleteSubscription), ctx, projectID, subscription)
 }
 
+// ExtendCLISubscription mocks base method.
+func (m *MockSubscriptionRepository) ExtendCLISubscription(ctx context.Context, projectID, subscriptionID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendCLISubscription", ctx, projectID, subscriptionID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendCLISubscription indicates an expected call of ExtendCLISubscription.
+func (mr *MockSubscriptionRepositoryMockRecorder) ExtendCLISubscription(ctx, projectID, subscriptionID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendCLISubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).ExtendCLISubscription), ctx, projectID, subscriptionID, expiresAt)
+}
+
 // FetchDeletedSubscriptions mocks base method.
 func (m *MockSubscriptionRepository) FetchDeletedSubscriptions(ctx context.Context, projectIDs []string, subscriptionUpdates []datastore.SubscriptionUpdate, pageSize int64) ([]datastore.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -2016,6 +2074,21 @@ func (mr *MockSubscriptionRepositoryMockRecorder) FindCLISubscriptions(ctx, proj
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCLISubscriptions", reflect.TypeOf((*MockSubscriptionRepository)(nil).FindCLISubscriptions), ctx, projectID)
 }
 
+// FindExpiredCLISubscriptions mocks base method.
+func (m *MockSubscriptionRepository) FindExpiredCLISubscriptions(ctx context.Context, limit int) ([]datastore.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredCLISubscriptions", ctx, limit)
+	ret0, _ := ret[0].([]datastore.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredCLISubscriptions indicates an expected call of FindExpiredCLISubscriptions.
+func (mr *MockSubscriptionRepositoryMockRecorder) FindExpiredCLISubscriptions(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredCLISubscriptions", reflect.TypeOf((*MockSubscriptionRepository)(nil).FindExpiredCLISubscriptions), ctx, limit)
+}
+
 // FindOrCreateDynamicSubscription mocks base method.
 func (m *MockSubscriptionRepository) FindOrCreateDynamicSubscription(ctx context.Context, projectID string, subscription *datastore.Subscription) (*datastore.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -2202,6 +2275,21 @@ func (mr *MockFilterRepositoryMockRecorder) DeleteFilter(ctx, filterID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilter", reflect.TypeOf((*MockFilterRepository)(nil).DeleteFilter), ctx, filterID)
 }
 
+// ExplainFilter mocks base method.
+func (m *MockFilterRepository) ExplainFilter(ctx context.Context, subscriptionID, eventType string, payload any) (*datastore.FilterExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainFilter", ctx, subscriptionID, eventType, payload)
+	ret0, _ := ret[0].(*datastore.FilterExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainFilter indicates an expected call of ExplainFilter.
+func (mr *MockFilterRepositoryMockRecorder) ExplainFilter(ctx, subscriptionID, eventType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainFilter", reflect.TypeOf((*MockFilterRepository)(nil).ExplainFilter), ctx, subscriptionID, eventType, payload)
+}
+
 // FindFilterByID mocks base method.
 func (m *MockFilterRepository) FindFilterByID(ctx context.Context, filterID string) (*datastore.EventTypeFilter, error) {
 	m.ctrl.T.Helper()
@@ -3184,225 +3272,1402 @@ func (mr *MockBackupJobRepositoryMockRecorder) ReclaimStaleJobs(ctx, staleMinute
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReclaimStaleJobs", reflect.TypeOf((*MockBackupJobRepository)(nil).ReclaimStaleJobs), ctx, staleMinutes)
 }
 
-// MockEventTypesRepository is a mock of EventTypesRepository interface.
-type MockEventTypesRepository struct {
+// MockCircuitBreakerRepository is a mock of CircuitBreakerRepository interface.
+type MockCircuitBreakerRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockEventTypesRepositoryMockRecorder
+	recorder *MockCircuitBreakerRepositoryMockRecorder
 	isgomock struct{}
 }
 
-// MockEventTypesRepositoryMockRecorder is the mock recorder for MockEventTypesRepository.
-type MockEventTypesRepositoryMockRecorder struct {
-	mock *MockEventTypesRepository
+// MockCircuitBreakerRepositoryMockRecorder is the mock recorder for MockCircuitBreakerRepository.
+type MockCircuitBreakerRepositoryMockRecorder struct {
+	mock *MockCircuitBreakerRepository
 }
 
-// NewMockEventTypesRepository creates a new mock instance.
-func NewMockEventTypesRepository(ctrl *gomock.Controller) *MockEventTypesRepository {
-	mock := &MockEventTypesRepository{ctrl: ctrl}
-	mock.recorder = &MockEventTypesRepositoryMockRecorder{mock}
+// NewMockCircuitBreakerRepository creates a new mock instance.
+func NewMockCircuitBreakerRepository(ctrl *gomock.Controller) *MockCircuitBreakerRepository {
+	mock := &MockCircuitBreakerRepository{ctrl: ctrl}
+	mock.recorder = &MockCircuitBreakerRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockEventTypesRepository) EXPECT() *MockEventTypesRepositoryMockRecorder {
+func (m *MockCircuitBreakerRepository) EXPECT() *MockCircuitBreakerRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CheckEventTypeExists mocks base method.
-func (m *MockEventTypesRepository) CheckEventTypeExists(arg0 context.Context, arg1, arg2 string) (bool, error) {
+// CreateCircuitBreakerTransition mocks base method.
+func (m *MockCircuitBreakerRepository) CreateCircuitBreakerTransition(ctx context.Context, transition *datastore.CircuitBreakerTransition) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckEventTypeExists", arg0, arg1, arg2)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CreateCircuitBreakerTransition", ctx, transition)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CheckEventTypeExists indicates an expected call of CheckEventTypeExists.
-func (mr *MockEventTypesRepositoryMockRecorder) CheckEventTypeExists(arg0, arg1, arg2 any) *gomock.Call {
+// CreateCircuitBreakerTransition indicates an expected call of CreateCircuitBreakerTransition.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) CreateCircuitBreakerTransition(ctx, transition any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEventTypeExists", reflect.TypeOf((*MockEventTypesRepository)(nil).CheckEventTypeExists), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCircuitBreakerTransition", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).CreateCircuitBreakerTransition), ctx, transition)
 }
 
-// CreateDefaultEventType mocks base method.
-func (m *MockEventTypesRepository) CreateDefaultEventType(ctx context.Context, projectId string) error {
+// DeleteCircuitBreakerOverride mocks base method.
+func (m *MockCircuitBreakerRepository) DeleteCircuitBreakerOverride(ctx context.Context, projectID, endpointID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDefaultEventType", ctx, projectId)
+	ret := m.ctrl.Call(m, "DeleteCircuitBreakerOverride", ctx, projectID, endpointID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateDefaultEventType indicates an expected call of CreateDefaultEventType.
-func (mr *MockEventTypesRepositoryMockRecorder) CreateDefaultEventType(ctx, projectId any) *gomock.Call {
+// DeleteCircuitBreakerOverride indicates an expected call of DeleteCircuitBreakerOverride.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) DeleteCircuitBreakerOverride(ctx, projectID, endpointID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaultEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).CreateDefaultEventType), ctx, projectId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCircuitBreakerOverride", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).DeleteCircuitBreakerOverride), ctx, projectID, endpointID)
 }
 
-// CreateEventType mocks base method.
-func (m *MockEventTypesRepository) CreateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
+// DeleteCircuitBreakerOverridesForDeletedEndpoints mocks base method.
+func (m *MockCircuitBreakerRepository) DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEventType", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteCircuitBreakerOverridesForDeletedEndpoints", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateEventType indicates an expected call of CreateEventType.
-func (mr *MockEventTypesRepositoryMockRecorder) CreateEventType(arg0, arg1 any) *gomock.Call {
+// DeleteCircuitBreakerOverridesForDeletedEndpoints indicates an expected call of DeleteCircuitBreakerOverridesForDeletedEndpoints.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).CreateEventType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCircuitBreakerOverridesForDeletedEndpoints", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).DeleteCircuitBreakerOverridesForDeletedEndpoints), ctx)
 }
 
-// DeprecateEventType mocks base method.
-func (m *MockEventTypesRepository) DeprecateEventType(arg0 context.Context, arg1, arg2 string) (*datastore.ProjectEventType, error) {
+// DeleteCircuitBreakerTransitionsBefore mocks base method.
+func (m *MockCircuitBreakerRepository) DeleteCircuitBreakerTransitionsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeprecateEventType", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret := m.ctrl.Call(m, "DeleteCircuitBreakerTransitionsBefore", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeprecateEventType indicates an expected call of DeprecateEventType.
-func (mr *MockEventTypesRepositoryMockRecorder) DeprecateEventType(arg0, arg1, arg2 any) *gomock.Call {
+// DeleteCircuitBreakerTransitionsBefore indicates an expected call of DeleteCircuitBreakerTransitionsBefore.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) DeleteCircuitBreakerTransitionsBefore(ctx, before, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeprecateEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).DeprecateEventType), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCircuitBreakerTransitionsBefore", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).DeleteCircuitBreakerTransitionsBefore), ctx, before, limit)
 }
 
-// FetchAllEventTypes mocks base method.
-func (m *MockEventTypesRepository) FetchAllEventTypes(arg0 context.Context, arg1 string) ([]datastore.ProjectEventType, error) {
+// FindCircuitBreakerOverride mocks base method.
+func (m *MockCircuitBreakerRepository) FindCircuitBreakerOverride(ctx context.Context, projectID, endpointID string) (*datastore.CircuitBreakerOverride, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchAllEventTypes", arg0, arg1)
-	ret0, _ := ret[0].([]datastore.ProjectEventType)
+	ret := m.ctrl.Call(m, "FindCircuitBreakerOverride", ctx, projectID, endpointID)
+	ret0, _ := ret[0].(*datastore.CircuitBreakerOverride)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchAllEventTypes indicates an expected call of FetchAllEventTypes.
-func (mr *MockEventTypesRepositoryMockRecorder) FetchAllEventTypes(arg0, arg1 any) *gomock.Call {
+// FindCircuitBreakerOverride indicates an expected call of FindCircuitBreakerOverride.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) FindCircuitBreakerOverride(ctx, projectID, endpointID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllEventTypes", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchAllEventTypes), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCircuitBreakerOverride", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).FindCircuitBreakerOverride), ctx, projectID, endpointID)
 }
 
-// FetchEventTypeById mocks base method.
-func (m *MockEventTypesRepository) FetchEventTypeById(arg0 context.Context, arg1, arg2 string) (*datastore.ProjectEventType, error) {
+// LoadCircuitBreakerOverrides mocks base method.
+func (m *MockCircuitBreakerRepository) LoadCircuitBreakerOverrides(ctx context.Context) ([]datastore.CircuitBreakerOverride, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchEventTypeById", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret := m.ctrl.Call(m, "LoadCircuitBreakerOverrides", ctx)
+	ret0, _ := ret[0].([]datastore.CircuitBreakerOverride)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchEventTypeById indicates an expected call of FetchEventTypeById.
-func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeById(arg0, arg1, arg2 any) *gomock.Call {
+// LoadCircuitBreakerOverrides indicates an expected call of LoadCircuitBreakerOverrides.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) LoadCircuitBreakerOverrides(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeById", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeById), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCircuitBreakerOverrides", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).LoadCircuitBreakerOverrides), ctx)
 }
 
-// FetchEventTypeByName mocks base method.
-func (m *MockEventTypesRepository) FetchEventTypeByName(arg0 context.Context, arg1, arg2 string) (*datastore.ProjectEventType, error) {
+// LoadCircuitBreakerTransitions mocks base method.
+func (m *MockCircuitBreakerRepository) LoadCircuitBreakerTransitions(ctx context.Context, projectID, endpointID string, limit int) ([]datastore.CircuitBreakerTransition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchEventTypeByName", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret := m.ctrl.Call(m, "LoadCircuitBreakerTransitions", ctx, projectID, endpointID, limit)
+	ret0, _ := ret[0].([]datastore.CircuitBreakerTransition)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchEventTypeByName indicates an expected call of FetchEventTypeByName.
-func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeByName(arg0, arg1, arg2 any) *gomock.Call {
+// LoadCircuitBreakerTransitions indicates an expected call of LoadCircuitBreakerTransitions.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) LoadCircuitBreakerTransitions(ctx, projectID, endpointID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeByName", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeByName), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCircuitBreakerTransitions", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).LoadCircuitBreakerTransitions), ctx, projectID, endpointID, limit)
 }
 
-// UpdateEventType mocks base method.
-func (m *MockEventTypesRepository) UpdateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
+// UpsertCircuitBreakerOverride mocks base method.
+func (m *MockCircuitBreakerRepository) UpsertCircuitBreakerOverride(ctx context.Context, override *datastore.CircuitBreakerOverride) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEventType", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpsertCircuitBreakerOverride", ctx, override)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateEventType indicates an expected call of UpdateEventType.
-func (mr *MockEventTypesRepositoryMockRecorder) UpdateEventType(arg0, arg1 any) *gomock.Call {
+// UpsertCircuitBreakerOverride indicates an expected call of UpsertCircuitBreakerOverride.
+func (mr *MockCircuitBreakerRepositoryMockRecorder) UpsertCircuitBreakerOverride(ctx, override any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).UpdateEventType), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCircuitBreakerOverride", reflect.TypeOf((*MockCircuitBreakerRepository)(nil).UpsertCircuitBreakerOverride), ctx, override)
 }
 
-// MockBatchRetryRepository is a mock of BatchRetryRepository interface.
-type MockBatchRetryRepository struct {
+// MockEndpointHealthRepository is a mock of EndpointHealthRepository interface.
+type MockEndpointHealthRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockBatchRetryRepositoryMockRecorder
+	recorder *MockEndpointHealthRepositoryMockRecorder
 	isgomock struct{}
 }
 
-// MockBatchRetryRepositoryMockRecorder is the mock recorder for MockBatchRetryRepository.
-type MockBatchRetryRepositoryMockRecorder struct {
-	mock *MockBatchRetryRepository
+// MockEndpointHealthRepositoryMockRecorder is the mock recorder for MockEndpointHealthRepository.
+type MockEndpointHealthRepositoryMockRecorder struct {
+	mock *MockEndpointHealthRepository
 }
 
-// NewMockBatchRetryRepository creates a new mock instance.
-func NewMockBatchRetryRepository(ctrl *gomock.Controller) *MockBatchRetryRepository {
-	mock := &MockBatchRetryRepository{ctrl: ctrl}
-	mock.recorder = &MockBatchRetryRepositoryMockRecorder{mock}
+// NewMockEndpointHealthRepository creates a new mock instance.
+func NewMockEndpointHealthRepository(ctrl *gomock.Controller) *MockEndpointHealthRepository {
+	mock := &MockEndpointHealthRepository{ctrl: ctrl}
+	mock.recorder = &MockEndpointHealthRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockBatchRetryRepository) EXPECT() *MockBatchRetryRepositoryMockRecorder {
+func (m *MockEndpointHealthRepository) EXPECT() *MockEndpointHealthRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreateBatchRetry mocks base method.
-func (m *MockBatchRetryRepository) CreateBatchRetry(ctx context.Context, batchRetry *datastore.BatchRetry) error {
+// CountEndpointHealth mocks base method.
+func (m *MockEndpointHealthRepository) CountEndpointHealth(ctx context.Context, projectID string, endpointIDs []string) (*datastore.EndpointHealthCounts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBatchRetry", ctx, batchRetry)
+	ret := m.ctrl.Call(m, "CountEndpointHealth", ctx, projectID, endpointIDs)
+	ret0, _ := ret[0].(*datastore.EndpointHealthCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEndpointHealth indicates an expected call of CountEndpointHealth.
+func (mr *MockEndpointHealthRepositoryMockRecorder) CountEndpointHealth(ctx, projectID, endpointIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEndpointHealth", reflect.TypeOf((*MockEndpointHealthRepository)(nil).CountEndpointHealth), ctx, projectID, endpointIDs)
+}
+
+// CreateEndpointHealthCheckResult mocks base method.
+func (m *MockEndpointHealthRepository) CreateEndpointHealthCheckResult(ctx context.Context, result *datastore.EndpointHealthCheckResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEndpointHealthCheckResult", ctx, result)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateBatchRetry indicates an expected call of CreateBatchRetry.
-func (mr *MockBatchRetryRepositoryMockRecorder) CreateBatchRetry(ctx, batchRetry any) *gomock.Call {
+// CreateEndpointHealthCheckResult indicates an expected call of CreateEndpointHealthCheckResult.
+func (mr *MockEndpointHealthRepositoryMockRecorder) CreateEndpointHealthCheckResult(ctx, result any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchRetry", reflect.TypeOf((*MockBatchRetryRepository)(nil).CreateBatchRetry), ctx, batchRetry)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEndpointHealthCheckResult", reflect.TypeOf((*MockEndpointHealthRepository)(nil).CreateEndpointHealthCheckResult), ctx, result)
 }
 
-// FindActiveBatchRetry mocks base method.
-func (m *MockBatchRetryRepository) FindActiveBatchRetry(ctx context.Context, projectID string) (*datastore.BatchRetry, error) {
+// DeleteEndpointHealthCheck mocks base method.
+func (m *MockEndpointHealthRepository) DeleteEndpointHealthCheck(ctx context.Context, projectID, endpointID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindActiveBatchRetry", ctx, projectID)
-	ret0, _ := ret[0].(*datastore.BatchRetry)
+	ret := m.ctrl.Call(m, "DeleteEndpointHealthCheck", ctx, projectID, endpointID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEndpointHealthCheck indicates an expected call of DeleteEndpointHealthCheck.
+func (mr *MockEndpointHealthRepositoryMockRecorder) DeleteEndpointHealthCheck(ctx, projectID, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEndpointHealthCheck", reflect.TypeOf((*MockEndpointHealthRepository)(nil).DeleteEndpointHealthCheck), ctx, projectID, endpointID)
+}
+
+// DeleteEndpointHealthCheckResultsBefore mocks base method.
+func (m *MockEndpointHealthRepository) DeleteEndpointHealthCheckResultsBefore(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEndpointHealthCheckResultsBefore", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEndpointHealthCheckResultsBefore indicates an expected call of DeleteEndpointHealthCheckResultsBefore.
+func (mr *MockEndpointHealthRepositoryMockRecorder) DeleteEndpointHealthCheckResultsBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEndpointHealthCheckResultsBefore", reflect.TypeOf((*MockEndpointHealthRepository)(nil).DeleteEndpointHealthCheckResultsBefore), ctx, before)
+}
+
+// FetchDueEndpointHealthChecks mocks base method.
+func (m *MockEndpointHealthRepository) FetchDueEndpointHealthChecks(ctx context.Context, now time.Time, limit int) ([]datastore.EndpointHealthCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDueEndpointHealthChecks", ctx, now, limit)
+	ret0, _ := ret[0].([]datastore.EndpointHealthCheck)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindActiveBatchRetry indicates an expected call of FindActiveBatchRetry.
-func (mr *MockBatchRetryRepositoryMockRecorder) FindActiveBatchRetry(ctx, projectID any) *gomock.Call {
+// FetchDueEndpointHealthChecks indicates an expected call of FetchDueEndpointHealthChecks.
+func (mr *MockEndpointHealthRepositoryMockRecorder) FetchDueEndpointHealthChecks(ctx, now, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveBatchRetry", reflect.TypeOf((*MockBatchRetryRepository)(nil).FindActiveBatchRetry), ctx, projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDueEndpointHealthChecks", reflect.TypeOf((*MockEndpointHealthRepository)(nil).FetchDueEndpointHealthChecks), ctx, now, limit)
 }
 
-// FindBatchRetryByID mocks base method.
-func (m *MockBatchRetryRepository) FindBatchRetryByID(ctx context.Context, id string) (*datastore.BatchRetry, error) {
+// FindEndpointHealthCheck mocks base method.
+func (m *MockEndpointHealthRepository) FindEndpointHealthCheck(ctx context.Context, projectID, endpointID string) (*datastore.EndpointHealthCheck, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindBatchRetryByID", ctx, id)
-	ret0, _ := ret[0].(*datastore.BatchRetry)
+	ret := m.ctrl.Call(m, "FindEndpointHealthCheck", ctx, projectID, endpointID)
+	ret0, _ := ret[0].(*datastore.EndpointHealthCheck)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindBatchRetryByID indicates an expected call of FindBatchRetryByID.
-func (mr *MockBatchRetryRepositoryMockRecorder) FindBatchRetryByID(ctx, id any) *gomock.Call {
+// FindEndpointHealthCheck indicates an expected call of FindEndpointHealthCheck.
+func (mr *MockEndpointHealthRepositoryMockRecorder) FindEndpointHealthCheck(ctx, projectID, endpointID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBatchRetryByID", reflect.TypeOf((*MockBatchRetryRepository)(nil).FindBatchRetryByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEndpointHealthCheck", reflect.TypeOf((*MockEndpointHealthRepository)(nil).FindEndpointHealthCheck), ctx, projectID, endpointID)
 }
 
-// UpdateBatchRetry mocks base method.
-func (m *MockBatchRetryRepository) UpdateBatchRetry(ctx context.Context, batchRetry *datastore.BatchRetry) error {
+// FindEndpointHealthChecks mocks base method.
+func (m *MockEndpointHealthRepository) FindEndpointHealthChecks(ctx context.Context, projectID string, endpointIDs []string) ([]datastore.EndpointHealthCheck, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBatchRetry", ctx, batchRetry)
+	ret := m.ctrl.Call(m, "FindEndpointHealthChecks", ctx, projectID, endpointIDs)
+	ret0, _ := ret[0].([]datastore.EndpointHealthCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEndpointHealthChecks indicates an expected call of FindEndpointHealthChecks.
+func (mr *MockEndpointHealthRepositoryMockRecorder) FindEndpointHealthChecks(ctx, projectID, endpointIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEndpointHealthChecks", reflect.TypeOf((*MockEndpointHealthRepository)(nil).FindEndpointHealthChecks), ctx, projectID, endpointIDs)
+}
+
+// LoadEndpointHealthCheckResults mocks base method.
+func (m *MockEndpointHealthRepository) LoadEndpointHealthCheckResults(ctx context.Context, projectID, endpointID string, since time.Time, limit int) ([]datastore.EndpointHealthCheckResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEndpointHealthCheckResults", ctx, projectID, endpointID, since, limit)
+	ret0, _ := ret[0].([]datastore.EndpointHealthCheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEndpointHealthCheckResults indicates an expected call of LoadEndpointHealthCheckResults.
+func (mr *MockEndpointHealthRepositoryMockRecorder) LoadEndpointHealthCheckResults(ctx, projectID, endpointID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEndpointHealthCheckResults", reflect.TypeOf((*MockEndpointHealthRepository)(nil).LoadEndpointHealthCheckResults), ctx, projectID, endpointID, since, limit)
+}
+
+// SummarizeEndpointHealth mocks base method.
+func (m *MockEndpointHealthRepository) SummarizeEndpointHealth(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) (*datastore.EndpointHealthStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeEndpointHealth", ctx, projectID, endpointIDs, since, until)
+	ret0, _ := ret[0].(*datastore.EndpointHealthStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeEndpointHealth indicates an expected call of SummarizeEndpointHealth.
+func (mr *MockEndpointHealthRepositoryMockRecorder) SummarizeEndpointHealth(ctx, projectID, endpointIDs, since, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeEndpointHealth", reflect.TypeOf((*MockEndpointHealthRepository)(nil).SummarizeEndpointHealth), ctx, projectID, endpointIDs, since, until)
+}
+
+// SummarizeEndpointHealthByEndpoint mocks base method.
+func (m *MockEndpointHealthRepository) SummarizeEndpointHealthByEndpoint(ctx context.Context, projectID string, endpointIDs []string, since, until time.Time) (map[string]*datastore.EndpointHealthStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeEndpointHealthByEndpoint", ctx, projectID, endpointIDs, since, until)
+	ret0, _ := ret[0].(map[string]*datastore.EndpointHealthStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeEndpointHealthByEndpoint indicates an expected call of SummarizeEndpointHealthByEndpoint.
+func (mr *MockEndpointHealthRepositoryMockRecorder) SummarizeEndpointHealthByEndpoint(ctx, projectID, endpointIDs, since, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeEndpointHealthByEndpoint", reflect.TypeOf((*MockEndpointHealthRepository)(nil).SummarizeEndpointHealthByEndpoint), ctx, projectID, endpointIDs, since, until)
+}
+
+// UpdateEndpointHealthCheckState mocks base method.
+func (m *MockEndpointHealthRepository) UpdateEndpointHealthCheckState(ctx context.Context, check *datastore.EndpointHealthCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEndpointHealthCheckState", ctx, check)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateBatchRetry indicates an expected call of UpdateBatchRetry.
-func (mr *MockBatchRetryRepositoryMockRecorder) UpdateBatchRetry(ctx, batchRetry any) *gomock.Call {
+// UpdateEndpointHealthCheckState indicates an expected call of UpdateEndpointHealthCheckState.
+func (mr *MockEndpointHealthRepositoryMockRecorder) UpdateEndpointHealthCheckState(ctx, check any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatchRetry", reflect.TypeOf((*MockBatchRetryRepository)(nil).UpdateBatchRetry), ctx, batchRetry)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEndpointHealthCheckState", reflect.TypeOf((*MockEndpointHealthRepository)(nil).UpdateEndpointHealthCheckState), ctx, check)
+}
+
+// UpsertEndpointHealthCheck mocks base method.
+func (m *MockEndpointHealthRepository) UpsertEndpointHealthCheck(ctx context.Context, check *datastore.EndpointHealthCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEndpointHealthCheck", ctx, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertEndpointHealthCheck indicates an expected call of UpsertEndpointHealthCheck.
+func (mr *MockEndpointHealthRepositoryMockRecorder) UpsertEndpointHealthCheck(ctx, check any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEndpointHealthCheck", reflect.TypeOf((*MockEndpointHealthRepository)(nil).UpsertEndpointHealthCheck), ctx, check)
+}
+
+// MockAlertRuleRepository is a mock of AlertRuleRepository interface.
+type MockAlertRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRuleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertRuleRepositoryMockRecorder is the mock recorder for MockAlertRuleRepository.
+type MockAlertRuleRepositoryMockRecorder struct {
+	mock *MockAlertRuleRepository
+}
+
+// NewMockAlertRuleRepository creates a new mock instance.
+func NewMockAlertRuleRepository(ctrl *gomock.Controller) *MockAlertRuleRepository {
+	mock := &MockAlertRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRuleRepository) EXPECT() *MockAlertRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountDeliveryAttempts mocks base method.
+func (m *MockAlertRuleRepository) CountDeliveryAttempts(ctx context.Context, projectID, endpointID string, since time.Time) (*datastore.DeliveryAttemptCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeliveryAttempts", ctx, projectID, endpointID, since)
+	ret0, _ := ret[0].(*datastore.DeliveryAttemptCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeliveryAttempts indicates an expected call of CountDeliveryAttempts.
+func (mr *MockAlertRuleRepositoryMockRecorder) CountDeliveryAttempts(ctx, projectID, endpointID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeliveryAttempts", reflect.TypeOf((*MockAlertRuleRepository)(nil).CountDeliveryAttempts), ctx, projectID, endpointID, since)
+}
+
+// CountRetryingDeliveries mocks base method.
+func (m *MockAlertRuleRepository) CountRetryingDeliveries(ctx context.Context, projectID, endpointID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRetryingDeliveries", ctx, projectID, endpointID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRetryingDeliveries indicates an expected call of CountRetryingDeliveries.
+func (mr *MockAlertRuleRepositoryMockRecorder) CountRetryingDeliveries(ctx, projectID, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRetryingDeliveries", reflect.TypeOf((*MockAlertRuleRepository)(nil).CountRetryingDeliveries), ctx, projectID, endpointID)
+}
+
+// CreateAlertRule mocks base method.
+func (m *MockAlertRuleRepository) CreateAlertRule(ctx context.Context, rule *datastore.AlertRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlertRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAlertRule indicates an expected call of CreateAlertRule.
+func (mr *MockAlertRuleRepositoryMockRecorder) CreateAlertRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlertRule", reflect.TypeOf((*MockAlertRuleRepository)(nil).CreateAlertRule), ctx, rule)
+}
+
+// DeleteAlertRule mocks base method.
+func (m *MockAlertRuleRepository) DeleteAlertRule(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlertRule", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlertRule indicates an expected call of DeleteAlertRule.
+func (mr *MockAlertRuleRepositoryMockRecorder) DeleteAlertRule(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlertRule", reflect.TypeOf((*MockAlertRuleRepository)(nil).DeleteAlertRule), ctx, projectID, id)
+}
+
+// DeliveryLatencyP95 mocks base method.
+func (m *MockAlertRuleRepository) DeliveryLatencyP95(ctx context.Context, projectID, endpointID string, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryLatencyP95", ctx, projectID, endpointID, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryLatencyP95 indicates an expected call of DeliveryLatencyP95.
+func (mr *MockAlertRuleRepositoryMockRecorder) DeliveryLatencyP95(ctx, projectID, endpointID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryLatencyP95", reflect.TypeOf((*MockAlertRuleRepository)(nil).DeliveryLatencyP95), ctx, projectID, endpointID, since)
+}
+
+// FindAlertRuleByID mocks base method.
+func (m *MockAlertRuleRepository) FindAlertRuleByID(ctx context.Context, projectID, id string) (*datastore.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAlertRuleByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAlertRuleByID indicates an expected call of FindAlertRuleByID.
+func (mr *MockAlertRuleRepositoryMockRecorder) FindAlertRuleByID(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAlertRuleByID", reflect.TypeOf((*MockAlertRuleRepository)(nil).FindAlertRuleByID), ctx, projectID, id)
+}
+
+// FindLatestSourceEventTime mocks base method.
+func (m *MockAlertRuleRepository) FindLatestSourceEventTime(ctx context.Context, projectID, sourceID string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestSourceEventTime", ctx, projectID, sourceID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestSourceEventTime indicates an expected call of FindLatestSourceEventTime.
+func (mr *MockAlertRuleRepositoryMockRecorder) FindLatestSourceEventTime(ctx, projectID, sourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSourceEventTime", reflect.TypeOf((*MockAlertRuleRepository)(nil).FindLatestSourceEventTime), ctx, projectID, sourceID)
+}
+
+// LoadAlertRules mocks base method.
+func (m *MockAlertRuleRepository) LoadAlertRules(ctx context.Context, projectID string) ([]datastore.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAlertRules", ctx, projectID)
+	ret0, _ := ret[0].([]datastore.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAlertRules indicates an expected call of LoadAlertRules.
+func (mr *MockAlertRuleRepositoryMockRecorder) LoadAlertRules(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAlertRules", reflect.TypeOf((*MockAlertRuleRepository)(nil).LoadAlertRules), ctx, projectID)
+}
+
+// LoadEnabledAlertRules mocks base method.
+func (m *MockAlertRuleRepository) LoadEnabledAlertRules(ctx context.Context, cursor string, limit int) ([]datastore.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEnabledAlertRules", ctx, cursor, limit)
+	ret0, _ := ret[0].([]datastore.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEnabledAlertRules indicates an expected call of LoadEnabledAlertRules.
+func (mr *MockAlertRuleRepositoryMockRecorder) LoadEnabledAlertRules(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEnabledAlertRules", reflect.TypeOf((*MockAlertRuleRepository)(nil).LoadEnabledAlertRules), ctx, cursor, limit)
+}
+
+// LoadLatestAttemptStatuses mocks base method.
+func (m *MockAlertRuleRepository) LoadLatestAttemptStatuses(ctx context.Context, projectID, endpointID string, limit int) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadLatestAttemptStatuses", ctx, projectID, endpointID, limit)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadLatestAttemptStatuses indicates an expected call of LoadLatestAttemptStatuses.
+func (mr *MockAlertRuleRepositoryMockRecorder) LoadLatestAttemptStatuses(ctx, projectID, endpointID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadLatestAttemptStatuses", reflect.TypeOf((*MockAlertRuleRepository)(nil).LoadLatestAttemptStatuses), ctx, projectID, endpointID, limit)
+}
+
+// UpdateAlertRule mocks base method.
+func (m *MockAlertRuleRepository) UpdateAlertRule(ctx context.Context, rule *datastore.AlertRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlertRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlertRule indicates an expected call of UpdateAlertRule.
+func (mr *MockAlertRuleRepositoryMockRecorder) UpdateAlertRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertRule", reflect.TypeOf((*MockAlertRuleRepository)(nil).UpdateAlertRule), ctx, rule)
+}
+
+// UpdateAlertRuleState mocks base method.
+func (m *MockAlertRuleRepository) UpdateAlertRuleState(ctx context.Context, rule *datastore.AlertRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlertRuleState", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlertRuleState indicates an expected call of UpdateAlertRuleState.
+func (mr *MockAlertRuleRepositoryMockRecorder) UpdateAlertRuleState(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertRuleState", reflect.TypeOf((*MockAlertRuleRepository)(nil).UpdateAlertRuleState), ctx, rule)
+}
+
+// MockEventTypesRepository is a mock of EventTypesRepository interface.
+type MockEventTypesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventTypesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventTypesRepositoryMockRecorder is the mock recorder for MockEventTypesRepository.
+type MockEventTypesRepositoryMockRecorder struct {
+	mock *MockEventTypesRepository
+}
+
+// NewMockEventTypesRepository creates a new mock instance.
+func NewMockEventTypesRepository(ctrl *gomock.Controller) *MockEventTypesRepository {
+	mock := &MockEventTypesRepository{ctrl: ctrl}
+	mock.recorder = &MockEventTypesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventTypesRepository) EXPECT() *MockEventTypesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckEventTypeExists mocks base method.
+func (m *MockEventTypesRepository) CheckEventTypeExists(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckEventTypeExists", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckEventTypeExists indicates an expected call of CheckEventTypeExists.
+func (mr *MockEventTypesRepositoryMockRecorder) CheckEventTypeExists(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEventTypeExists", reflect.TypeOf((*MockEventTypesRepository)(nil).CheckEventTypeExists), arg0, arg1, arg2)
+}
+
+// CreateDefaultEventType mocks base method.
+func (m *MockEventTypesRepository) CreateDefaultEventType(ctx context.Context, projectId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDefaultEventType", ctx, projectId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDefaultEventType indicates an expected call of CreateDefaultEventType.
+func (mr *MockEventTypesRepositoryMockRecorder) CreateDefaultEventType(ctx, projectId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDefaultEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).CreateDefaultEventType), ctx, projectId)
+}
+
+// CreateEventType mocks base method.
+func (m *MockEventTypesRepository) CreateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventType indicates an expected call of CreateEventType.
+func (mr *MockEventTypesRepositoryMockRecorder) CreateEventType(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).CreateEventType), arg0, arg1)
+}
+
+// DeprecateEventType mocks base method.
+func (m *MockEventTypesRepository) DeprecateEventType(arg0 context.Context, arg1, arg2 string) (*datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeprecateEventType", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeprecateEventType indicates an expected call of DeprecateEventType.
+func (mr *MockEventTypesRepositoryMockRecorder) DeprecateEventType(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeprecateEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).DeprecateEventType), arg0, arg1, arg2)
+}
+
+// FetchAllEventTypes mocks base method.
+func (m *MockEventTypesRepository) FetchAllEventTypes(arg0 context.Context, arg1 string) ([]datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAllEventTypes", arg0, arg1)
+	ret0, _ := ret[0].([]datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAllEventTypes indicates an expected call of FetchAllEventTypes.
+func (mr *MockEventTypesRepositoryMockRecorder) FetchAllEventTypes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllEventTypes", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchAllEventTypes), arg0, arg1)
+}
+
+// FetchEventTypeById mocks base method.
+func (m *MockEventTypesRepository) FetchEventTypeById(arg0 context.Context, arg1, arg2 string) (*datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventTypeById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventTypeById indicates an expected call of FetchEventTypeById.
+func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeById(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeById", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeById), arg0, arg1, arg2)
+}
+
+// FetchEventTypeByName mocks base method.
+func (m *MockEventTypesRepository) FetchEventTypeByName(arg0 context.Context, arg1, arg2 string) (*datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventTypeByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventTypeByName indicates an expected call of FetchEventTypeByName.
+func (mr *MockEventTypesRepositoryMockRecorder) FetchEventTypeByName(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventTypeByName", reflect.TypeOf((*MockEventTypesRepository)(nil).FetchEventTypeByName), arg0, arg1, arg2)
+}
+
+// UpdateEventType mocks base method.
+func (m *MockEventTypesRepository) UpdateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventType indicates an expected call of UpdateEventType.
+func (mr *MockEventTypesRepositoryMockRecorder) UpdateEventType(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventType", reflect.TypeOf((*MockEventTypesRepository)(nil).UpdateEventType), arg0, arg1)
+}
+
+// MockBatchRetryRepository is a mock of BatchRetryRepository interface.
+type MockBatchRetryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchRetryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchRetryRepositoryMockRecorder is the mock recorder for MockBatchRetryRepository.
+type MockBatchRetryRepositoryMockRecorder struct {
+	mock *MockBatchRetryRepository
+}
+
+// NewMockBatchRetryRepository creates a new mock instance.
+func NewMockBatchRetryRepository(ctrl *gomock.Controller) *MockBatchRetryRepository {
+	mock := &MockBatchRetryRepository{ctrl: ctrl}
+	mock.recorder = &MockBatchRetryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchRetryRepository) EXPECT() *MockBatchRetryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBatchRetry mocks base method.
+func (m *MockBatchRetryRepository) CreateBatchRetry(ctx context.Context, batchRetry *datastore.BatchRetry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatchRetry", ctx, batchRetry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatchRetry indicates an expected call of CreateBatchRetry.
+func (mr *MockBatchRetryRepositoryMockRecorder) CreateBatchRetry(ctx, batchRetry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchRetry", reflect.TypeOf((*MockBatchRetryRepository)(nil).CreateBatchRetry), ctx, batchRetry)
+}
+
+// FindActiveBatchRetry mocks base method.
+func (m *MockBatchRetryRepository) FindActiveBatchRetry(ctx context.Context, projectID string) (*datastore.BatchRetry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveBatchRetry", ctx, projectID)
+	ret0, _ := ret[0].(*datastore.BatchRetry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveBatchRetry indicates an expected call of FindActiveBatchRetry.
+func (mr *MockBatchRetryRepositoryMockRecorder) FindActiveBatchRetry(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveBatchRetry", reflect.TypeOf((*MockBatchRetryRepository)(nil).FindActiveBatchRetry), ctx, projectID)
+}
+
+// FindBatchRetryByID mocks base method.
+func (m *MockBatchRetryRepository) FindBatchRetryByID(ctx context.Context, id string) (*datastore.BatchRetry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBatchRetryByID", ctx, id)
+	ret0, _ := ret[0].(*datastore.BatchRetry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBatchRetryByID indicates an expected call of FindBatchRetryByID.
+func (mr *MockBatchRetryRepositoryMockRecorder) FindBatchRetryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBatchRetryByID", reflect.TypeOf((*MockBatchRetryRepository)(nil).FindBatchRetryByID), ctx, id)
+}
+
+// UpdateBatchRetry mocks base method.
+func (m *MockBatchRetryRepository) UpdateBatchRetry(ctx context.Context, batchRetry *datastore.BatchRetry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatchRetry", ctx, batchRetry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBatchRetry indicates an expected call of UpdateBatchRetry.
+func (mr *MockBatchRetryRepositoryMockRecorder) UpdateBatchRetry(ctx, batchRetry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatchRetry", reflect.TypeOf((*MockBatchRetryRepository)(nil).UpdateBatchRetry), ctx, batchRetry)
+}
+
+// MockReplayJobRepository is a mock of ReplayJobRepository interface.
+type MockReplayJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplayJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReplayJobRepositoryMockRecorder is the mock recorder for MockReplayJobRepository.
+type MockReplayJobRepositoryMockRecorder struct {
+	mock *MockReplayJobRepository
+}
+
+// NewMockReplayJobRepository creates a new mock instance.
+func NewMockReplayJobRepository(ctrl *gomock.Controller) *MockReplayJobRepository {
+	mock := &MockReplayJobRepository{ctrl: ctrl}
+	mock.recorder = &MockReplayJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplayJobRepository) EXPECT() *MockReplayJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelReplayJob mocks base method.
+func (m *MockReplayJobRepository) CancelReplayJob(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReplayJob", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReplayJob indicates an expected call of CancelReplayJob.
+func (mr *MockReplayJobRepositoryMockRecorder) CancelReplayJob(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReplayJob", reflect.TypeOf((*MockReplayJobRepository)(nil).CancelReplayJob), ctx, projectID, id)
+}
+
+// CreateReplayJob mocks base method.
+func (m *MockReplayJobRepository) CreateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReplayJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReplayJob indicates an expected call of CreateReplayJob.
+func (mr *MockReplayJobRepositoryMockRecorder) CreateReplayJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReplayJob", reflect.TypeOf((*MockReplayJobRepository)(nil).CreateReplayJob), ctx, job)
+}
+
+// FindReplayJobByID mocks base method.
+func (m *MockReplayJobRepository) FindReplayJobByID(ctx context.Context, projectID, id string) (*datastore.ReplayJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReplayJobByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.ReplayJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReplayJobByID indicates an expected call of FindReplayJobByID.
+func (mr *MockReplayJobRepositoryMockRecorder) FindReplayJobByID(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReplayJobByID", reflect.TypeOf((*MockReplayJobRepository)(nil).FindReplayJobByID), ctx, projectID, id)
+}
+
+// LoadReplayJobs mocks base method.
+func (m *MockReplayJobRepository) LoadReplayJobs(ctx context.Context, projectID string, limit int) ([]datastore.ReplayJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadReplayJobs", ctx, projectID, limit)
+	ret0, _ := ret[0].([]datastore.ReplayJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadReplayJobs indicates an expected call of LoadReplayJobs.
+func (mr *MockReplayJobRepositoryMockRecorder) LoadReplayJobs(ctx, projectID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadReplayJobs", reflect.TypeOf((*MockReplayJobRepository)(nil).LoadReplayJobs), ctx, projectID, limit)
+}
+
+// UpdateReplayJob mocks base method.
+func (m *MockReplayJobRepository) UpdateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReplayJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReplayJob indicates an expected call of UpdateReplayJob.
+func (mr *MockReplayJobRepositoryMockRecorder) UpdateReplayJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReplayJob", reflect.TypeOf((*MockReplayJobRepository)(nil).UpdateReplayJob), ctx, job)
+}
+
+// MockEventTypeVersionRepository is a mock of EventTypeVersionRepository interface.
+type MockEventTypeVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventTypeVersionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventTypeVersionRepositoryMockRecorder is the mock recorder for MockEventTypeVersionRepository.
+type MockEventTypeVersionRepositoryMockRecorder struct {
+	mock *MockEventTypeVersionRepository
+}
+
+// NewMockEventTypeVersionRepository creates a new mock instance.
+func NewMockEventTypeVersionRepository(ctrl *gomock.Controller) *MockEventTypeVersionRepository {
+	mock := &MockEventTypeVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockEventTypeVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventTypeVersionRepository) EXPECT() *MockEventTypeVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEventTypeVersion mocks base method.
+func (m *MockEventTypeVersionRepository) CreateEventTypeVersion(ctx context.Context, version *datastore.EventTypeVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventTypeVersion", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventTypeVersion indicates an expected call of CreateEventTypeVersion.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) CreateEventTypeVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventTypeVersion", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).CreateEventTypeVersion), ctx, version)
+}
+
+// FindEventTypeVersion mocks base method.
+func (m *MockEventTypeVersionRepository) FindEventTypeVersion(ctx context.Context, projectID, eventTypeID string, version int) (*datastore.EventTypeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventTypeVersion", ctx, projectID, eventTypeID, version)
+	ret0, _ := ret[0].(*datastore.EventTypeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventTypeVersion indicates an expected call of FindEventTypeVersion.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) FindEventTypeVersion(ctx, projectID, eventTypeID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventTypeVersion", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).FindEventTypeVersion), ctx, projectID, eventTypeID, version)
+}
+
+// HasEventTypeVersionPins mocks base method.
+func (m *MockEventTypeVersionRepository) HasEventTypeVersionPins(ctx context.Context, projectID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasEventTypeVersionPins", ctx, projectID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasEventTypeVersionPins indicates an expected call of HasEventTypeVersionPins.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) HasEventTypeVersionPins(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasEventTypeVersionPins", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).HasEventTypeVersionPins), ctx, projectID)
+}
+
+// LoadDueEventTypeVersionSunsets mocks base method.
+func (m *MockEventTypeVersionRepository) LoadDueEventTypeVersionSunsets(ctx context.Context, now time.Time, limit int) ([]datastore.EventTypeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDueEventTypeVersionSunsets", ctx, now, limit)
+	ret0, _ := ret[0].([]datastore.EventTypeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadDueEventTypeVersionSunsets indicates an expected call of LoadDueEventTypeVersionSunsets.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) LoadDueEventTypeVersionSunsets(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDueEventTypeVersionSunsets", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).LoadDueEventTypeVersionSunsets), ctx, now, limit)
+}
+
+// LoadEventTypeVersionPins mocks base method.
+func (m *MockEventTypeVersionRepository) LoadEventTypeVersionPins(ctx context.Context, projectID, eventType string, subscriptionIDs []string) ([]datastore.EventTypeVersionPin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventTypeVersionPins", ctx, projectID, eventType, subscriptionIDs)
+	ret0, _ := ret[0].([]datastore.EventTypeVersionPin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventTypeVersionPins indicates an expected call of LoadEventTypeVersionPins.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) LoadEventTypeVersionPins(ctx, projectID, eventType, subscriptionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventTypeVersionPins", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).LoadEventTypeVersionPins), ctx, projectID, eventType, subscriptionIDs)
+}
+
+// LoadEventTypeVersions mocks base method.
+func (m *MockEventTypeVersionRepository) LoadEventTypeVersions(ctx context.Context, projectID, eventType string) ([]datastore.EventTypeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventTypeVersions", ctx, projectID, eventType)
+	ret0, _ := ret[0].([]datastore.EventTypeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventTypeVersions indicates an expected call of LoadEventTypeVersions.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) LoadEventTypeVersions(ctx, projectID, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventTypeVersions", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).LoadEventTypeVersions), ctx, projectID, eventType)
+}
+
+// LoadSubscriptionVersionPins mocks base method.
+func (m *MockEventTypeVersionRepository) LoadSubscriptionVersionPins(ctx context.Context, projectID, subscriptionID string) ([]datastore.EventTypeVersionPin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSubscriptionVersionPins", ctx, projectID, subscriptionID)
+	ret0, _ := ret[0].([]datastore.EventTypeVersionPin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSubscriptionVersionPins indicates an expected call of LoadSubscriptionVersionPins.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) LoadSubscriptionVersionPins(ctx, projectID, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSubscriptionVersionPins", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).LoadSubscriptionVersionPins), ctx, projectID, subscriptionID)
+}
+
+// MarkEventTypeVersionSunsetNotified mocks base method.
+func (m *MockEventTypeVersionRepository) MarkEventTypeVersionSunsetNotified(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventTypeVersionSunsetNotified", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventTypeVersionSunsetNotified indicates an expected call of MarkEventTypeVersionSunsetNotified.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) MarkEventTypeVersionSunsetNotified(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventTypeVersionSunsetNotified", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).MarkEventTypeVersionSunsetNotified), ctx, projectID, id)
+}
+
+// PinSubscriptionVersion mocks base method.
+func (m *MockEventTypeVersionRepository) PinSubscriptionVersion(ctx context.Context, pin *datastore.EventTypeVersionPin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinSubscriptionVersion", ctx, pin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinSubscriptionVersion indicates an expected call of PinSubscriptionVersion.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) PinSubscriptionVersion(ctx, pin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinSubscriptionVersion", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).PinSubscriptionVersion), ctx, pin)
+}
+
+// UnpinSubscriptionVersion mocks base method.
+func (m *MockEventTypeVersionRepository) UnpinSubscriptionVersion(ctx context.Context, projectID, subscriptionID, eventType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinSubscriptionVersion", ctx, projectID, subscriptionID, eventType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinSubscriptionVersion indicates an expected call of UnpinSubscriptionVersion.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) UnpinSubscriptionVersion(ctx, projectID, subscriptionID, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinSubscriptionVersion", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).UnpinSubscriptionVersion), ctx, projectID, subscriptionID, eventType)
+}
+
+// UpdateEventTypeVersion mocks base method.
+func (m *MockEventTypeVersionRepository) UpdateEventTypeVersion(ctx context.Context, version *datastore.EventTypeVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventTypeVersion", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventTypeVersion indicates an expected call of UpdateEventTypeVersion.
+func (mr *MockEventTypeVersionRepositoryMockRecorder) UpdateEventTypeVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventTypeVersion", reflect.TypeOf((*MockEventTypeVersionRepository)(nil).UpdateEventTypeVersion), ctx, version)
+}
+
+// MockSavedSearchRepository is a mock of SavedSearchRepository interface.
+type MockSavedSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedSearchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSavedSearchRepositoryMockRecorder is the mock recorder for MockSavedSearchRepository.
+type MockSavedSearchRepositoryMockRecorder struct {
+	mock *MockSavedSearchRepository
+}
+
+// NewMockSavedSearchRepository creates a new mock instance.
+func NewMockSavedSearchRepository(ctrl *gomock.Controller) *MockSavedSearchRepository {
+	mock := &MockSavedSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSavedSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedSearchRepository) EXPECT() *MockSavedSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSavedSearch mocks base method.
+func (m *MockSavedSearchRepository) CreateSavedSearch(ctx context.Context, search *datastore.SavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedSearch", ctx, search)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockSavedSearchRepositoryMockRecorder) CreateSavedSearch(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockSavedSearchRepository)(nil).CreateSavedSearch), ctx, search)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockSavedSearchRepository) DeleteSavedSearch(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockSavedSearchRepositoryMockRecorder) DeleteSavedSearch(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockSavedSearchRepository)(nil).DeleteSavedSearch), ctx, projectID, id)
+}
+
+// FindSavedSearchByID mocks base method.
+func (m *MockSavedSearchRepository) FindSavedSearchByID(ctx context.Context, projectID, id string) (*datastore.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSavedSearchByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSavedSearchByID indicates an expected call of FindSavedSearchByID.
+func (mr *MockSavedSearchRepositoryMockRecorder) FindSavedSearchByID(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSavedSearchByID", reflect.TypeOf((*MockSavedSearchRepository)(nil).FindSavedSearchByID), ctx, projectID, id)
+}
+
+// LoadSavedSearches mocks base method.
+func (m *MockSavedSearchRepository) LoadSavedSearches(ctx context.Context, projectID string) ([]datastore.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSavedSearches", ctx, projectID)
+	ret0, _ := ret[0].([]datastore.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSavedSearches indicates an expected call of LoadSavedSearches.
+func (mr *MockSavedSearchRepositoryMockRecorder) LoadSavedSearches(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSavedSearches", reflect.TypeOf((*MockSavedSearchRepository)(nil).LoadSavedSearches), ctx, projectID)
+}
+
+// UpdateSavedSearch mocks base method.
+func (m *MockSavedSearchRepository) UpdateSavedSearch(ctx context.Context, search *datastore.SavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", ctx, search)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockSavedSearchRepositoryMockRecorder) UpdateSavedSearch(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockSavedSearchRepository)(nil).UpdateSavedSearch), ctx, search)
+}
+
+// MockEmailTemplateRepository is a mock of EmailTemplateRepository interface.
+type MockEmailTemplateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailTemplateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailTemplateRepositoryMockRecorder is the mock recorder for MockEmailTemplateRepository.
+type MockEmailTemplateRepositoryMockRecorder struct {
+	mock *MockEmailTemplateRepository
+}
+
+// NewMockEmailTemplateRepository creates a new mock instance.
+func NewMockEmailTemplateRepository(ctrl *gomock.Controller) *MockEmailTemplateRepository {
+	mock := &MockEmailTemplateRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailTemplateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailTemplateRepository) EXPECT() *MockEmailTemplateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteEmailTemplate mocks base method.
+func (m *MockEmailTemplateRepository) DeleteEmailTemplate(ctx context.Context, orgID, templateName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailTemplate", ctx, orgID, templateName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailTemplate indicates an expected call of DeleteEmailTemplate.
+func (mr *MockEmailTemplateRepositoryMockRecorder) DeleteEmailTemplate(ctx, orgID, templateName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailTemplate", reflect.TypeOf((*MockEmailTemplateRepository)(nil).DeleteEmailTemplate), ctx, orgID, templateName)
+}
+
+// FindEmailBranding mocks base method.
+func (m *MockEmailTemplateRepository) FindEmailBranding(ctx context.Context, orgID string) (*datastore.EmailBranding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmailBranding", ctx, orgID)
+	ret0, _ := ret[0].(*datastore.EmailBranding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmailBranding indicates an expected call of FindEmailBranding.
+func (mr *MockEmailTemplateRepositoryMockRecorder) FindEmailBranding(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmailBranding", reflect.TypeOf((*MockEmailTemplateRepository)(nil).FindEmailBranding), ctx, orgID)
+}
+
+// FindEmailTemplate mocks base method.
+func (m *MockEmailTemplateRepository) FindEmailTemplate(ctx context.Context, orgID, templateName string) (*datastore.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmailTemplate", ctx, orgID, templateName)
+	ret0, _ := ret[0].(*datastore.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmailTemplate indicates an expected call of FindEmailTemplate.
+func (mr *MockEmailTemplateRepositoryMockRecorder) FindEmailTemplate(ctx, orgID, templateName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmailTemplate", reflect.TypeOf((*MockEmailTemplateRepository)(nil).FindEmailTemplate), ctx, orgID, templateName)
+}
+
+// LoadEmailTemplates mocks base method.
+func (m *MockEmailTemplateRepository) LoadEmailTemplates(ctx context.Context, orgID string) ([]datastore.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEmailTemplates", ctx, orgID)
+	ret0, _ := ret[0].([]datastore.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEmailTemplates indicates an expected call of LoadEmailTemplates.
+func (mr *MockEmailTemplateRepositoryMockRecorder) LoadEmailTemplates(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEmailTemplates", reflect.TypeOf((*MockEmailTemplateRepository)(nil).LoadEmailTemplates), ctx, orgID)
+}
+
+// UpsertEmailBranding mocks base method.
+func (m *MockEmailTemplateRepository) UpsertEmailBranding(ctx context.Context, branding *datastore.EmailBranding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEmailBranding", ctx, branding)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertEmailBranding indicates an expected call of UpsertEmailBranding.
+func (mr *MockEmailTemplateRepositoryMockRecorder) UpsertEmailBranding(ctx, branding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmailBranding", reflect.TypeOf((*MockEmailTemplateRepository)(nil).UpsertEmailBranding), ctx, branding)
+}
+
+// UpsertEmailTemplate mocks base method.
+func (m *MockEmailTemplateRepository) UpsertEmailTemplate(ctx context.Context, template *datastore.EmailTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEmailTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertEmailTemplate indicates an expected call of UpsertEmailTemplate.
+func (mr *MockEmailTemplateRepositoryMockRecorder) UpsertEmailTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmailTemplate", reflect.TypeOf((*MockEmailTemplateRepository)(nil).UpsertEmailTemplate), ctx, template)
+}
+
+// MockExportJobRepository is a mock of ExportJobRepository interface.
+type MockExportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockExportJobRepositoryMockRecorder is the mock recorder for MockExportJobRepository.
+type MockExportJobRepositoryMockRecorder struct {
+	mock *MockExportJobRepository
+}
+
+// NewMockExportJobRepository creates a new mock instance.
+func NewMockExportJobRepository(ctrl *gomock.Controller) *MockExportJobRepository {
+	mock := &MockExportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockExportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportJobRepository) EXPECT() *MockExportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateExportJob mocks base method.
+func (m *MockExportJobRepository) CreateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExportJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExportJob indicates an expected call of CreateExportJob.
+func (mr *MockExportJobRepositoryMockRecorder) CreateExportJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExportJob", reflect.TypeOf((*MockExportJobRepository)(nil).CreateExportJob), ctx, job)
+}
+
+// FindExportJobByID mocks base method.
+func (m *MockExportJobRepository) FindExportJobByID(ctx context.Context, projectID, id string) (*datastore.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExportJobByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExportJobByID indicates an expected call of FindExportJobByID.
+func (mr *MockExportJobRepositoryMockRecorder) FindExportJobByID(ctx, projectID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExportJobByID", reflect.TypeOf((*MockExportJobRepository)(nil).FindExportJobByID), ctx, projectID, id)
+}
+
+// LoadExportJobs mocks base method.
+func (m *MockExportJobRepository) LoadExportJobs(ctx context.Context, projectID string, limit int) ([]datastore.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadExportJobs", ctx, projectID, limit)
+	ret0, _ := ret[0].([]datastore.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadExportJobs indicates an expected call of LoadExportJobs.
+func (mr *MockExportJobRepositoryMockRecorder) LoadExportJobs(ctx, projectID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadExportJobs", reflect.TypeOf((*MockExportJobRepository)(nil).LoadExportJobs), ctx, projectID, limit)
+}
+
+// UpdateExportJob mocks base method.
+func (m *MockExportJobRepository) UpdateExportJob(ctx context.Context, job *datastore.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExportJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExportJob indicates an expected call of UpdateExportJob.
+func (mr *MockExportJobRepositoryMockRecorder) UpdateExportJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExportJob", reflect.TypeOf((*MockExportJobRepository)(nil).UpdateExportJob), ctx, job)
+}
+
+// MockFilterRejectionRepository is a mock of FilterRejectionRepository interface.
+type MockFilterRejectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterRejectionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFilterRejectionRepositoryMockRecorder is the mock recorder for MockFilterRejectionRepository.
+type MockFilterRejectionRepositoryMockRecorder struct {
+	mock *MockFilterRejectionRepository
+}
+
+// NewMockFilterRejectionRepository creates a new mock instance.
+func NewMockFilterRejectionRepository(ctrl *gomock.Controller) *MockFilterRejectionRepository {
+	mock := &MockFilterRejectionRepository{ctrl: ctrl}
+	mock.recorder = &MockFilterRejectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterRejectionRepository) EXPECT() *MockFilterRejectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFilterRejections mocks base method.
+func (m *MockFilterRejectionRepository) CreateFilterRejections(ctx context.Context, rejections []datastore.FilterRejection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilterRejections", ctx, rejections)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFilterRejections indicates an expected call of CreateFilterRejections.
+func (mr *MockFilterRejectionRepositoryMockRecorder) CreateFilterRejections(ctx, rejections any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilterRejections", reflect.TypeOf((*MockFilterRejectionRepository)(nil).CreateFilterRejections), ctx, rejections)
+}
+
+// DeleteFilterRejectionsBefore mocks base method.
+func (m *MockFilterRejectionRepository) DeleteFilterRejectionsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilterRejectionsBefore", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFilterRejectionsBefore indicates an expected call of DeleteFilterRejectionsBefore.
+func (mr *MockFilterRejectionRepositoryMockRecorder) DeleteFilterRejectionsBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilterRejectionsBefore", reflect.TypeOf((*MockFilterRejectionRepository)(nil).DeleteFilterRejectionsBefore), ctx, before, limit)
+}
+
+// LoadFilterRejectionsByEventID mocks base method.
+func (m *MockFilterRejectionRepository) LoadFilterRejectionsByEventID(ctx context.Context, projectID, eventID string) ([]datastore.FilterRejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFilterRejectionsByEventID", ctx, projectID, eventID)
+	ret0, _ := ret[0].([]datastore.FilterRejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFilterRejectionsByEventID indicates an expected call of LoadFilterRejectionsByEventID.
+func (mr *MockFilterRejectionRepositoryMockRecorder) LoadFilterRejectionsByEventID(ctx, projectID, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFilterRejectionsByEventID", reflect.TypeOf((*MockFilterRejectionRepository)(nil).LoadFilterRejectionsByEventID), ctx, projectID, eventID)
 }
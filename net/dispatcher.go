@@ -46,6 +46,7 @@ var (
 	ErrLoggerIsRequired    = errors.New("logger is required")
 	ErrInvalidIPPrefix     = errors.New("invalid IP prefix")
 	ErrNon2xxResponse      = errors.New("endpoint returned a non-2xx response")
+	ErrUnexpectedStatus    = errors.New("endpoint returned an unexpected status code")
 )
 
 // ContentTypeConverter defines the interface for converting JSON data to different content types
@@ -918,6 +919,8 @@ type PingOptions struct {
 	ContentType       string
 	MtlsCert          *tls.Certificate
 	OAuth2TokenGetter OAuth2TokenGetter
+	// Headers are added to every ping request, e.g. an endpoint's API key.
+	Headers http.Header
 	// Method is used internally by tryPingMethod. It's set automatically by Ping.
 	Method string
 }
@@ -995,6 +998,9 @@ func (d *Dispatcher) tryPingMethod(ctx context.Context, opts PingOptions) error
 		return err
 	}
 
+	for k, v := range opts.Headers {
+		req.Header[k] = v
+	}
 	req.Header.Add("User-Agent", defaultUserAgent())
 	if reqContentType != "" {
 		req.Header.Set("Content-Type", reqContentType)
@@ -1021,3 +1027,78 @@ func (d *Dispatcher) tryPingMethod(ctx context.Context, opts PingOptions) error
 
 	return nil
 }
+
+// HealthCheckOptions contains options for a single health check request.
+type HealthCheckOptions struct {
+	URL     string
+	Method  string
+	Timeout time.Duration
+	// ExpectedStatus is the status code a healthy endpoint answers with;
+	// zero accepts any 2xx.
+	ExpectedStatus    int
+	Headers           http.Header
+	MtlsCert          *tls.Certificate
+	OAuth2TokenGetter OAuth2TokenGetter
+}
+
+// HealthCheckResult is the outcome of a health check request. StatusCode is
+// zero when no response was received.
+type HealthCheckResult struct {
+	StatusCode int
+	Latency    time.Duration
+}
+
+// CheckHealth sends one request to opts.URL with opts.Method and reports the
+// status code and latency. Unlike Ping it does not fall back to other methods.
+// It returns an error alongside the result when the endpoint is unreachable or
+// answers with a status other than the expected one.
+func (d *Dispatcher) CheckHealth(ctx context.Context, opts HealthCheckOptions) (*HealthCheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	ctx = d.ContextWithRules(ctx)
+	result := &HealthCheckResult{}
+
+	req, err := http.NewRequestWithContext(ctx, opts.Method, opts.URL, nil)
+	if err != nil {
+		return result, err
+	}
+
+	for k, v := range opts.Headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", defaultUserAgent())
+
+	if opts.OAuth2TokenGetter != nil {
+		authHeader, err := opts.OAuth2TokenGetter(ctx)
+		if err != nil {
+			return result, fmt.Errorf("failed to get OAuth2 token for health check: %w", err)
+		}
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	client := d.createClientWithMTLS(opts.MtlsCert)
+
+	start := time.Now()
+	response, err := client.Do(req)
+	result.Latency = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	result.StatusCode = response.StatusCode
+	if opts.ExpectedStatus == 0 {
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return result, fmt.Errorf("%w: got status code %d", ErrNon2xxResponse, response.StatusCode)
+		}
+		return result, nil
+	}
+
+	if response.StatusCode != opts.ExpectedStatus {
+		return result, fmt.Errorf("%w: expected %d, got %d", ErrUnexpectedStatus, opts.ExpectedStatus, response.StatusCode)
+	}
+
+	return result, nil
+}
@@ -1811,3 +1811,61 @@ func TestNotificationRoundTripper_BypassesGuardForProxyHopOnly(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, bypass)
 }
+
+func TestDispatcher_CheckHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLicenser := mocks.NewMockLicenser(ctrl)
+	mockLicenser.EXPECT().IpRules().Return(false).AnyTimes()
+
+	dispatcher, err := NewDispatcher(mockLicenser, fflag.NewFFlag([]string{}), LoggerOption(log.New("convoy", log.LevelInfo)))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" || r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		wantStatus     int
+		wantErr        error
+	}{
+		{name: "should_accept_any_2xx", method: http.MethodGet, path: "/health", wantStatus: http.StatusNoContent},
+		{name: "should_match_expected_status", method: http.MethodGet, path: "/health", expectedStatus: http.StatusNoContent, wantStatus: http.StatusNoContent},
+		{name: "should_reject_unexpected_status", method: http.MethodGet, path: "/health", expectedStatus: http.StatusOK, wantStatus: http.StatusNoContent, wantErr: ErrUnexpectedStatus},
+		{name: "should_not_fall_back_to_other_methods", method: http.MethodHead, path: "/health", wantStatus: http.StatusMethodNotAllowed, wantErr: ErrNon2xxResponse},
+		{name: "should_reject_non_2xx", method: http.MethodGet, path: "/missing", wantStatus: http.StatusNotFound, wantErr: ErrNon2xxResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := dispatcher.CheckHealth(context.Background(), HealthCheckOptions{
+				URL:            server.URL + tt.path,
+				Method:         tt.method,
+				Timeout:        5 * time.Second,
+				ExpectedStatus: tt.expectedStatus,
+				Headers:        http.Header{"X-Api-Key": []string{"secret"}},
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantStatus, result.StatusCode)
+			require.Positive(t, result.Latency)
+		})
+	}
+}
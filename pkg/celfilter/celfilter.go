@@ -0,0 +1,151 @@
+// Package celfilter evaluates subscription filters written as CEL
+// expressions. It is the expression counterpart of pkg/compare: where compare
+// matches a flattened payload against a tree of operators, an expression can
+// use string functions, list macros such as exists and all, timestamps and
+// arithmetic, e.g.
+//
+//	body.amount > 100 && headers["x-tenant"] in ["a", "b"]
+//
+// An expression sees four variables: body (the decoded payload), headers (a
+// map keyed by lower-cased header name), query (the query parameters) and path
+// (the request path).
+package celfilter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	// MaxExpressionLength bounds the size of an expression that can be saved.
+	MaxExpressionLength = 4096
+
+	// costLimit bounds the work a single evaluation may do, so a filter cannot
+	// stall event creation with a runaway comprehension.
+	costLimit = 1_000_000
+
+	cacheSize = 4096
+)
+
+var (
+	ErrEmptyExpression     = errors.New("filter expression cannot be empty")
+	ErrExpressionTooLong   = fmt.Errorf("filter expression must not exceed %d characters", MaxExpressionLength)
+	ErrNotBoolean          = errors.New("filter expression must evaluate to a bool")
+	ErrCompilerUnavailable = errors.New("filter expression compiler is unavailable")
+)
+
+var (
+	env      *cel.Env
+	envErr   error
+	programs *lru.Cache[string, cel.Program]
+)
+
+func init() {
+	env, envErr = cel.NewEnv(
+		cel.Variable("body", cel.DynType),
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("query", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("path", cel.StringType),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+	)
+
+	// New only fails for a non-positive size.
+	programs, _ = lru.New[string, cel.Program](cacheSize)
+}
+
+// Input is the request an expression is evaluated against.
+type Input struct {
+	Body    interface{}
+	Headers map[string]interface{}
+	Query   map[string]interface{}
+	Path    string
+}
+
+// Check parses and type-checks expr, returning an error that describes what
+// is wrong with it. A nil error means the expression can be saved.
+func Check(expr string) error {
+	_, err := compile(expr)
+	return err
+}
+
+// Match reports whether in satisfies expr. Only an expression that does not
+// compile returns an error; one that fails while evaluating, for example by
+// selecting a key the payload does not have, does not match, the same way a
+// missing field does not match an operator filter.
+func Match(expr string, in Input) (bool, error) {
+	prg, err := compile(expr)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := prg.Eval(map[string]interface{}{
+		"body":    in.Body,
+		"headers": lowerKeys(in.Headers),
+		"query":   nonNil(in.Query),
+		"path":    in.Path,
+	})
+	if err != nil {
+		return false, nil
+	}
+
+	matched, ok := out.Value().(bool)
+	return ok && matched, nil
+}
+
+func compile(expr string) (cel.Program, error) {
+	if envErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCompilerUnavailable, envErr)
+	}
+
+	if prg, ok := programs.Get(expr); ok {
+		return prg, nil
+	}
+
+	if strings.TrimSpace(expr) == "" {
+		return nil, ErrEmptyExpression
+	}
+
+	if len(expr) > MaxExpressionLength {
+		return nil, ErrExpressionTooLong
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid filter expression: %w", issues.Err())
+	}
+
+	// A dyn result, e.g. `body.enabled`, can only be checked once evaluated.
+	out := ast.OutputType()
+	if !out.IsExactType(cel.BoolType) && !out.IsExactType(cel.DynType) {
+		return nil, ErrNotBoolean
+	}
+
+	prg, err := env.Program(ast, cel.CostLimit(costLimit), cel.EvalOptions(cel.OptOptimize))
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	programs.Add(expr, prg)
+	return prg, nil
+}
+
+func lowerKeys(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[strings.ToLower(k)] = v
+	}
+	return out
+}
+
+func nonNil(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
@@ -0,0 +1,87 @@
+package celfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr error
+	}{
+		{name: "comparison", expr: `body.amount > 100`},
+		{name: "dyn result", expr: `body.enabled`},
+		{name: "empty", expr: "  ", wantErr: ErrEmptyExpression},
+		{name: "too long", expr: strings.Repeat("a", MaxExpressionLength+1), wantErr: ErrExpressionTooLong},
+		{name: "non bool", expr: `path + "/"`, wantErr: ErrNotBoolean},
+		{name: "unknown variable", expr: `payload.amount > 1`},
+		{name: "syntax error", expr: `body.amount >`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.expr)
+			switch {
+			case tt.wantErr != nil:
+				require.True(t, errors.Is(err, tt.wantErr), err)
+			case tt.name == "unknown variable", tt.name == "syntax error":
+				require.ErrorContains(t, err, "invalid filter expression")
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var body interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"amount": 250,
+		"currency": "usd",
+		"created_at": "2024-03-01T10:00:00Z",
+		"items": [{"sku": "a-1", "qty": 2}, {"sku": "b-2", "qty": 1}]
+	}`), &body))
+
+	in := Input{
+		Body:    body,
+		Headers: map[string]interface{}{"X-Tenant": "a"},
+		Query:   map[string]interface{}{"region": "eu"},
+		Path:    "/webhooks/orders",
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "numeric and header", expr: `body.amount > 100 && headers["x-tenant"] in ["a", "b"]`, want: true},
+		{name: "header mismatch", expr: `headers["x-tenant"] == "c"`, want: false},
+		{name: "string function", expr: `body.currency.upperAscii() == "USD"`, want: true},
+		{name: "exists macro", expr: `body.items.exists(i, i.sku.startsWith("b-"))`, want: true},
+		{name: "all macro", expr: `body.items.all(i, i.qty > 1)`, want: false},
+		{name: "timestamp", expr: `timestamp(body.created_at) > timestamp("2024-01-01T00:00:00Z")`, want: true},
+		{name: "arithmetic", expr: `body.amount * 2.0 >= 500.0`, want: true},
+		{name: "query and path", expr: `query.region == "eu" && path.endsWith("/orders")`, want: true},
+		{name: "missing key does not match", expr: `body.customer.id == "1"`, want: false},
+		{name: "has guard", expr: `!has(body.customer)`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Match(tt.expr, in)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatch_InvalidExpression(t *testing.T) {
+	_, err := Match(`body.amount >`, Input{})
+	require.ErrorContains(t, err, "invalid filter expression")
+}
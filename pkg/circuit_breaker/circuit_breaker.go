@@ -34,6 +34,9 @@ type CircuitBreaker struct {
 	// Set when disable transitions active→inactive this window; cleared on send or Reset.
 	// Retries failed enqueues without re-alerting on window rollover alone.
 	DisableAlertPending bool `json:"disable_alert_pending"`
+	// Set while an operator holds the breaker in its current state; the sampler
+	// keeps counting but does not transition it until the hold is released.
+	Forced bool `json:"forced"`
 
 	logger log.Logger
 }
@@ -86,6 +89,7 @@ func (b *CircuitBreaker) asKeyValue() map[string]interface{} {
 	kv["consecutive_failures"] = b.ConsecutiveFailures
 	kv["notifications_sent"] = b.NotificationsSent
 	kv["disable_alert_pending"] = b.DisableAlertPending
+	kv["forced"] = b.Forced
 	return kv
 }
 
@@ -99,6 +103,15 @@ func (b *CircuitBreaker) trip(resetTime time.Time) {
 	}
 }
 
+// extendOpen keeps an open breaker open until resetTime. Unlike trip it is not
+// counted as a failure: a failed half-open probe saw no real delivery fail.
+func (b *CircuitBreaker) extendOpen(resetTime time.Time) {
+	b.WillResetAt = resetTime
+	if b.logger != nil {
+		b.logger.Debugf("[circuit breaker] circuit breaker kept open: %+v", b.asKeyValue())
+	}
+}
+
 func (b *CircuitBreaker) toHalfOpen() {
 	b.State = StateHalfOpen
 	if b.logger != nil {
@@ -112,6 +125,7 @@ func (b *CircuitBreaker) Reset(resetTime time.Time) {
 	b.WillResetAt = resetTime
 	b.NotificationsSent = 0
 	b.DisableAlertPending = false
+	b.Forced = false
 	b.ConsecutiveFailures = 0
 	b.FailureRate = 0
 	b.SuccessRate = 0
@@ -124,6 +138,18 @@ func (b *CircuitBreaker) Reset(resetTime time.Time) {
 	}
 }
 
+// hold pins the breaker to state on an operator's behalf. A forced open
+// breaker has no reset time: it stays open until the hold is released.
+func (b *CircuitBreaker) hold(state State) {
+	b.State = state
+	b.Forced = true
+	b.WillResetAt = time.Time{}
+	if b.logger != nil {
+		b.logger.Infof("[circuit breaker] circuit breaker forced to %s", state)
+		b.logger.Debugf("[circuit breaker] circuit breaker state: %+v", b.asKeyValue())
+	}
+}
+
 // shouldTrip determines if the circuit breaker should trip based on the given configuration
 func (b *CircuitBreaker) shouldTrip(config *CircuitBreakerConfig) bool {
 	return b.Requests >= config.MinimumRequestCount && b.FailureRate >= float64(config.FailureThreshold)
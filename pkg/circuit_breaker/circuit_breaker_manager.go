@@ -15,7 +15,16 @@ import (
 const prefix = "breaker:"
 const mutexKey = "convoy:circuit_breaker:mutex"
 
+// maxConcurrentProbes bounds how many half-open probes run at once, so a large
+// outage does not open a connection to every endpoint at once.
+const maxConcurrentProbes = 10
+
+// probeTimeout bounds a single half-open probe.
+const probeTimeout = 30 * time.Second
+
 type PollFunc func(ctx context.Context, lookBackDuration uint64, resetTimes map[string]time.Time) (map[string]PollResult, error)
+type OverrideFunc func(ctx context.Context) (map[string]Override, error)
+type ProbeFunc func(ctx context.Context, breaker CircuitBreaker) error
 type CircuitBreakerOption func(cb *CircuitBreakerManager) error
 
 var (
@@ -42,6 +51,18 @@ var (
 
 	// ErrNotificationFunctionMustNotBeNil is returned when a nil function is passed to NewCircuitBreakerManager
 	ErrNotificationFunctionMustNotBeNil = errors.New("[circuit breaker] notification function must not be nil")
+
+	// ErrTransitionFunctionMustNotBeNil is returned when a nil function is passed to TransitionFunctionOption
+	ErrTransitionFunctionMustNotBeNil = errors.New("[circuit breaker] transition function must not be nil")
+
+	// ErrOverrideFunctionMustNotBeNil is returned when a nil function is passed to OverrideFunctionOption
+	ErrOverrideFunctionMustNotBeNil = errors.New("[circuit breaker] override function must not be nil")
+
+	// ErrProbeFunctionMustNotBeNil is returned when a nil function is passed to ProbeFunctionOption
+	ErrProbeFunctionMustNotBeNil = errors.New("[circuit breaker] probe function must not be nil")
+
+	// ErrInvalidForcedState is returned when a breaker is forced into a state other than open or closed
+	ErrInvalidForcedState = errors.New("[circuit breaker] a breaker can only be forced open or closed")
 )
 
 // State represents a state of a CircuitBreaker.
@@ -79,11 +100,28 @@ type PollResult struct {
 	Successes uint64 `json:"successes" db:"successes"`
 }
 
+// Override is an operator exception for a single breaker. The sampler reads
+// the full set on every tick, keyed by the breaker's key without the prefix.
+type Override struct {
+	TenantId string
+	// ForcedState pins the breaker to StateOpen or StateClosed. Nil leaves
+	// transitions to the sampler.
+	ForcedState *State
+	// Config replaces the tenant's thresholds for this breaker. Zero fields
+	// inherit the tenant value; SampleRate and ObservabilityWindow are
+	// instance wide and are ignored.
+	Config *CircuitBreakerConfig
+}
+
 type CircuitBreakerManager struct {
 	logger         log.Logger
 	clock          clock.Clock
 	store          CircuitBreakerStore
 	notificationFn func(NotificationType, CircuitBreakerConfig, *CircuitBreaker) (bool, error)
+	transitionFn   func(from State, breaker CircuitBreaker)
+	overrideFn     OverrideFunc
+	probeFn        ProbeFunc
+	probes         *probeTracker
 	configProvider func(projectID string) *CircuitBreakerConfig
 	masterConfig   CircuitBreakerConfig
 	skipSleep      bool
@@ -181,6 +219,54 @@ func NotificationFunctionOption(fn func(NotificationType, CircuitBreakerConfig,
 	}
 }
 
+// TransitionFunctionOption registers a handler run once per sampling tick for
+// every breaker whose state changed, after the change is applied. It is an
+// observer: it cannot veto the transition, and it runs before the new state is
+// written to the store, so it must not block the tick.
+func TransitionFunctionOption(fn func(from State, breaker CircuitBreaker)) CircuitBreakerOption {
+	return func(cb *CircuitBreakerManager) error {
+		if fn == nil {
+			return ErrTransitionFunctionMustNotBeNil
+		}
+
+		cb.transitionFn = fn
+		return nil
+	}
+}
+
+// OverrideFunctionOption registers the source of per-breaker overrides. It is
+// read once per sampling tick; an error is logged and the tick proceeds as if
+// there were no overrides, so a flaky source cannot stall the sampler.
+func OverrideFunctionOption(fn OverrideFunc) CircuitBreakerOption {
+	return func(cb *CircuitBreakerManager) error {
+		if fn == nil {
+			return ErrOverrideFunctionMustNotBeNil
+		}
+
+		cb.overrideFn = fn
+		return nil
+	}
+}
+
+// ProbeFunctionOption registers a check run against a breaker's resource once
+// its breaker timeout has elapsed. Probes run in the background, outside the
+// sampler's lock, and their outcome is applied on a later tick: the breaker
+// only moves to half-open and lets traffic through when the probe succeeds,
+// and a failed probe keeps it open for another timeout without counting as a
+// consecutive failure. Without a probe the breaker moves to half-open
+// unchecked.
+func ProbeFunctionOption(fn ProbeFunc) CircuitBreakerOption {
+	return func(cb *CircuitBreakerManager) error {
+		if fn == nil {
+			return ErrProbeFunctionMustNotBeNil
+		}
+
+		cb.probeFn = fn
+		cb.probes = newProbeTracker()
+		return nil
+	}
+}
+
 func MasterConfigOption(config CircuitBreakerConfig) CircuitBreakerOption {
 	return func(cb *CircuitBreakerManager) error {
 		cb.masterConfig = config
@@ -202,7 +288,7 @@ func EnabledFuncOption(fn func(ctx context.Context) bool) CircuitBreakerOption {
 	}
 }
 
-func (cb *CircuitBreakerManager) sampleStore(ctx context.Context, pollResults map[string]PollResult) error {
+func (cb *CircuitBreakerManager) sampleStore(ctx context.Context, pollResults map[string]PollResult, overrides map[string]Override) error {
 	redisCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -262,18 +348,47 @@ func (cb *CircuitBreakerManager) sampleStore(ctx context.Context, pollResults ma
 			breaker.SuccessRate = float64(breaker.TotalSuccesses) / float64(breaker.Requests) * 100
 		}
 
+		override, hasOverride := overrides[k[1]]
 		projectConfig := cb.GetProjectConfig(breaker.TenantId)
+		if hasOverride && override.Config != nil {
+			projectConfig = mergeConfig(projectConfig, *override.Config)
+		}
+		previousState := breaker.State
 
-		if breaker.State == StateHalfOpen && breaker.SuccessRate >= float64(projectConfig.SuccessThreshold) {
-			breaker.Reset(cb.clock.Now().Add(time.Duration(projectConfig.BreakerTimeout) * time.Second))
-		} else if (breaker.State == StateClosed || breaker.State == StateHalfOpen) && breaker.Requests >= projectConfig.MinimumRequestCount {
-			if breaker.FailureRate >= float64(projectConfig.FailureThreshold) {
-				breaker.trip(cb.clock.Now().Add(time.Duration(projectConfig.BreakerTimeout) * time.Second))
+		switch {
+		case hasOverride && override.ForcedState != nil:
+			if breaker.State != *override.ForcedState || !breaker.Forced {
+				breaker.hold(*override.ForcedState)
+			}
+		case breaker.Forced:
+			// The hold was lifted without going through Release, so hand the
+			// breaker back to the sampler from a clean closed state.
+			breaker.Reset(cb.clock.Now())
+		default:
+			if breaker.State == StateHalfOpen && breaker.SuccessRate >= float64(projectConfig.SuccessThreshold) {
+				breaker.Reset(cb.clock.Now().Add(time.Duration(projectConfig.BreakerTimeout) * time.Second))
+			} else if (breaker.State == StateClosed || breaker.State == StateHalfOpen) && breaker.Requests >= projectConfig.MinimumRequestCount {
+				if breaker.FailureRate >= float64(projectConfig.FailureThreshold) {
+					breaker.trip(cb.clock.Now().Add(time.Duration(projectConfig.BreakerTimeout) * time.Second))
+				}
+			}
+
+			if breaker.State == StateOpen && cb.clock.Now().After(breaker.WillResetAt) {
+				done, probeErr := cb.probeOutcome(key, breaker)
+				switch {
+				case !done:
+					// The probe is still running; stay open until it reports.
+				case probeErr != nil:
+					cb.logger.Infof("[circuit breaker] half-open probe for %s failed, keeping it open: %v", key, probeErr)
+					breaker.extendOpen(cb.clock.Now().Add(time.Duration(projectConfig.BreakerTimeout) * time.Second))
+				default:
+					breaker.toHalfOpen()
+				}
 			}
 		}
 
-		if breaker.State == StateOpen && cb.clock.Now().After(breaker.WillResetAt) {
-			breaker.toHalfOpen()
+		if cb.transitionFn != nil && breaker.State != previousState {
+			cb.transitionFn(previousState, breaker)
 		}
 
 		// Runs on every tick the breaker stays tripped, because disabling the
@@ -285,7 +400,10 @@ func (cb *CircuitBreakerManager) sampleStore(ctx context.Context, pollResults ma
 		//
 		// Only a handler that reports having dispatched increments the count, so a
 		// tick that declined to alert does not spend the window's one alert.
-		if cb.notificationFn != nil && breaker.State != StateOpen {
+		//
+		// A held breaker is the operator's call, so it never disables the
+		// resource on its own.
+		if cb.notificationFn != nil && breaker.State != StateOpen && !breaker.Forced {
 			if breaker.ConsecutiveFailures >= projectConfig.ConsecutiveFailureThreshold {
 				sent, innerErr := cb.notificationFn(TypeDisableResource, projectConfig, &breaker)
 				switch {
@@ -310,6 +428,54 @@ func (cb *CircuitBreakerManager) sampleStore(ctx context.Context, pollResults ma
 	return nil
 }
 
+// probeOutcome reports the result of the half-open probe for an open breaker
+// that is due to reset. When no probe has finished since the breaker became
+// due it starts one in the background and reports it as not done, so the
+// sampler never waits on an endpoint while holding the store lock.
+func (cb *CircuitBreakerManager) probeOutcome(key string, breaker CircuitBreaker) (done bool, err error) {
+	if cb.probeFn == nil {
+		return true, nil
+	}
+
+	t := cb.probes
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r, ok := t.results[key]; ok {
+		delete(t.results, key)
+		// A result from before the breaker's current timeout belongs to an
+		// earlier outage.
+		if !r.startedAt.Before(breaker.WillResetAt) {
+			return true, r.err
+		}
+	}
+
+	if _, running := t.running[key]; running {
+		return false, nil
+	}
+
+	t.running[key] = struct{}{}
+	t.wg.Add(1)
+	startedAt := cb.clock.Now()
+	go func() {
+		defer t.wg.Done()
+
+		t.sem <- struct{}{}
+		defer func() { <-t.sem }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		defer cancel()
+		probeErr := cb.probeFn(ctx, breaker)
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.running, key)
+		t.results[key] = probeResult{err: probeErr, startedAt: startedAt}
+	}()
+
+	return false, nil
+}
+
 func (cb *CircuitBreakerManager) updateCircuitBreakers(ctx context.Context, breakers map[string]CircuitBreaker) (err error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -508,17 +674,120 @@ func (cb *CircuitBreakerManager) sampleAndUpdate(ctx context.Context, pollFunc P
 		return fmt.Errorf("poll function failed: %w", err)
 	}
 
+	overrides := cb.loadOverrides(ctx)
+
+	// A held breaker must outlive the observability window even when its
+	// endpoint gets no traffic, so it is sampled with zero counts every tick.
+	for k, o := range overrides {
+		if _, ok := pollResults[k]; !ok && o.ForcedState != nil {
+			if pollResults == nil {
+				pollResults = make(map[string]PollResult, len(overrides))
+			}
+			pollResults[k] = PollResult{Key: k, TenantId: o.TenantId}
+		}
+	}
+
 	if len(pollResults) == 0 {
 		return nil // Nothing to update
 	}
 
-	if err = cb.sampleStore(ctx, pollResults); err != nil {
+	if err = cb.sampleStore(ctx, pollResults, overrides); err != nil {
 		return fmt.Errorf("[circuit breaker] failed to sample events and update state: %w", err)
 	}
 
 	return nil
 }
 
+func (cb *CircuitBreakerManager) loadOverrides(ctx context.Context) map[string]Override {
+	if cb.overrideFn == nil {
+		return nil
+	}
+
+	overrides, err := cb.overrideFn(ctx)
+	if err != nil {
+		cb.logger.Error("[circuit breaker] failed to load overrides, sampling without them", "error", err)
+		return nil
+	}
+
+	return overrides
+}
+
+// ForceState holds the breaker for key in state, which must be StateOpen or
+// StateClosed, and returns it. The hold takes effect on the next CanExecute;
+// it only survives sampling ticks while the OverrideFunc also reports it, so
+// callers persist the override before calling this.
+func (cb *CircuitBreakerManager) ForceState(ctx context.Context, key, tenantID string, state State) (*CircuitBreaker, error) {
+	if state != StateOpen && state != StateClosed {
+		return nil, ErrInvalidForcedState
+	}
+
+	breaker, err := cb.GetCircuitBreaker(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if breaker == nil {
+		breaker = NewCircuitBreaker(fmt.Sprintf("%s%s", prefix, key), tenantID, cb.logger)
+	}
+	breaker.logger = cb.logger
+	breaker.hold(state)
+
+	if err = cb.setCircuitBreaker(ctx, breaker); err != nil {
+		return nil, err
+	}
+
+	return breaker, nil
+}
+
+// Release resets the breaker for key to closed with fresh counters and lifts
+// any hold, so the sampler judges the endpoint from a clean window.
+func (cb *CircuitBreakerManager) Release(ctx context.Context, key, tenantID string) (*CircuitBreaker, error) {
+	breaker, err := cb.GetCircuitBreaker(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if breaker == nil {
+		breaker = NewCircuitBreaker(fmt.Sprintf("%s%s", prefix, key), tenantID, cb.logger)
+	}
+	breaker.logger = cb.logger
+	breaker.Reset(cb.clock.Now())
+
+	if err = cb.setCircuitBreaker(ctx, breaker); err != nil {
+		return nil, err
+	}
+
+	return breaker, nil
+}
+
+func (cb *CircuitBreakerManager) setCircuitBreaker(ctx context.Context, breaker *CircuitBreaker) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	masterConfig := cb.GetMasterConfig()
+	return cb.store.SetOne(ctx, breaker.Key, breaker.String(), time.Duration(masterConfig.ObservabilityWindow)*time.Minute)
+}
+
+// mergeConfig overlays the non-zero thresholds of override onto base.
+func mergeConfig(base, override CircuitBreakerConfig) CircuitBreakerConfig {
+	if override.BreakerTimeout > 0 {
+		base.BreakerTimeout = override.BreakerTimeout
+	}
+	if override.FailureThreshold > 0 {
+		base.FailureThreshold = override.FailureThreshold
+	}
+	if override.SuccessThreshold > 0 {
+		base.SuccessThreshold = override.SuccessThreshold
+	}
+	if override.MinimumRequestCount > 0 {
+		base.MinimumRequestCount = override.MinimumRequestCount
+	}
+	if override.ConsecutiveFailureThreshold > 0 {
+		base.ConsecutiveFailureThreshold = override.ConsecutiveFailureThreshold
+	}
+	return base
+}
+
 func (cb *CircuitBreakerManager) GetConfig() CircuitBreakerConfig {
 	return cb.GetMasterConfig()
 }
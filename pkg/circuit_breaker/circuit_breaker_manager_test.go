@@ -114,7 +114,7 @@ func TestCircuitBreakerManager(t *testing.T) {
 	}
 
 	for i := 0; i < len(pollResults); i++ {
-		innerErr := b.sampleStore(ctx, pollResults[i])
+		innerErr := b.sampleStore(ctx, pollResults[i], nil)
 		require.NoError(t, innerErr)
 
 		testClock.AdvanceTime(time.Minute)
@@ -168,7 +168,7 @@ func TestCircuitBreakerManager_AddNewBreakerMidway(t *testing.T) {
 	}
 
 	for i := 0; i < len(pollResults); i++ {
-		err = b.sampleStore(ctx, pollResults[i])
+		err = b.sampleStore(ctx, pollResults[i], nil)
 		require.NoError(t, err)
 
 		testClock.AdvanceTime(time.Minute)
@@ -228,7 +228,7 @@ func TestCircuitBreakerManager_Transitions(t *testing.T) {
 	}
 
 	for i, result := range pollResults {
-		err = b.sampleStore(ctx, result)
+		err = b.sampleStore(ctx, result, nil)
 		require.NoError(t, err)
 
 		breaker, innerErr := b.GetCircuitBreakerWithError(ctx, endpointId)
@@ -285,7 +285,7 @@ func TestCircuitBreakerManager_ConsecutiveFailures(t *testing.T) {
 	}
 
 	for _, result := range pollResults {
-		err = b.sampleStore(ctx, result)
+		err = b.sampleStore(ctx, result, nil)
 		require.NoError(t, err)
 
 		testClock.AdvanceTime(time.Duration(c.BreakerTimeout+1) * time.Second)
@@ -338,7 +338,7 @@ func TestCircuitBreakerManager_MultipleEndpoints(t *testing.T) {
 	}
 
 	for _, results := range pollResults {
-		err = b.sampleStore(ctx, results)
+		err = b.sampleStore(ctx, results, nil)
 		require.NoError(t, err)
 
 		testClock.AdvanceTime(time.Duration(c.BreakerTimeout+1) * time.Second)
@@ -495,7 +495,7 @@ func TestCircuitBreakerManager_SampleStore(t *testing.T) {
 		"test2": {Key: "test2", Failures: 6, Successes: 4},
 	}
 
-	err = manager.sampleStore(ctx, pollResults)
+	err = manager.sampleStore(ctx, pollResults, nil)
 	require.NoError(t, err)
 
 	// Check if circuit breakers were created and updated correctly
@@ -1128,3 +1128,300 @@ func TestCircuitBreakerManager_ProjectSpecificConfig(t *testing.T) {
 		require.Equal(t, customConfig.BreakerTimeout, config.BreakerTimeout)
 	})
 }
+
+// encodedTestStore returns breakers from GetMany encoded the way RedisStore and
+// PostgresStore do, so sampleStore can read back what it wrote without Redis.
+type encodedTestStore struct {
+	*TestStore
+}
+
+func (s encodedTestStore) GetMany(ctx context.Context, keys ...string) ([]any, error) {
+	vals, err := s.TestStore.GetMany(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range vals {
+		if b, ok := vals[i].(CircuitBreaker); ok {
+			vals[i] = b.String()
+		}
+	}
+	return vals, nil
+}
+
+func TestCircuitBreakerManager_TransitionFunction(t *testing.T) {
+	ctx := context.Background()
+	testClock := clock.NewSimulatedClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	c := &CircuitBreakerConfig{
+		SampleRate:                  2,
+		BreakerTimeout:              30,
+		FailureThreshold:            50,
+		SuccessThreshold:            10,
+		MinimumRequestCount:         10,
+		ObservabilityWindow:         5,
+		ConsecutiveFailureThreshold: 10,
+	}
+
+	type transition struct{ from, to State }
+	var got []transition
+
+	b, err := NewCircuitBreakerManager(
+		ClockOption(testClock),
+		StoreOption(encodedTestStore{NewTestStore()}),
+		ConfigProviderOption(createTestConfigProvider(c)),
+		LoggerOption(log.New("convoy", log.LevelInfo)),
+		TransitionFunctionOption(func(from State, breaker CircuitBreaker) {
+			got = append(got, transition{from: from, to: breaker.State})
+		}),
+	)
+	require.NoError(t, err)
+
+	endpointId := "endpoint-1"
+	pollResults := []map[string]PollResult{
+		pollResult(t, endpointId, 1, 2),  // Closed, no transition
+		pollResult(t, endpointId, 13, 1), // Closed -> Open
+		pollResult(t, endpointId, 10, 1), // Open -> Half-Open
+		pollResult(t, endpointId, 0, 2),  // Half-Open -> Closed
+	}
+
+	for i, result := range pollResults {
+		require.NoError(t, b.sampleStore(ctx, result, nil))
+
+		if i == 1 {
+			testClock.AdvanceTime(time.Duration(c.BreakerTimeout+1) * time.Second)
+		} else {
+			testClock.AdvanceTime(time.Second * 5)
+		}
+	}
+
+	require.Equal(t, []transition{
+		{from: StateClosed, to: StateOpen},
+		{from: StateOpen, to: StateHalfOpen},
+		{from: StateHalfOpen, to: StateClosed},
+	}, got)
+}
+
+func TestTransitionFunctionOption_Nil(t *testing.T) {
+	_, err := NewCircuitBreakerManager(TransitionFunctionOption(nil))
+	require.ErrorIs(t, err, ErrTransitionFunctionMustNotBeNil)
+}
+
+func newOverrideTestManager(t *testing.T, overrides map[string]Override) *CircuitBreakerManager {
+	t.Helper()
+
+	c := &CircuitBreakerConfig{
+		SampleRate:                  2,
+		BreakerTimeout:              30,
+		FailureThreshold:            50,
+		SuccessThreshold:            10,
+		MinimumRequestCount:         10,
+		ObservabilityWindow:         5,
+		ConsecutiveFailureThreshold: 10,
+	}
+
+	m, err := NewCircuitBreakerManager(
+		ClockOption(clock.NewSimulatedClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))),
+		StoreOption(encodedTestStore{NewTestStore()}),
+		ConfigProviderOption(createTestConfigProvider(c)),
+		LoggerOption(log.New("convoy", log.LevelInfo)),
+		SkipSleepOption(true),
+		OverrideFunctionOption(func(context.Context) (map[string]Override, error) {
+			return overrides, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestCircuitBreakerManager_ForceState(t *testing.T) {
+	ctx := context.Background()
+	open := StateOpen
+	overrides := map[string]Override{"endpoint-1": {TenantId: "project-1", ForcedState: &open}}
+	m := newOverrideTestManager(t, overrides)
+
+	b, err := m.ForceState(ctx, "endpoint-1", "project-1", StateOpen)
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, b.State)
+	require.True(t, b.Forced)
+	require.ErrorIs(t, m.CanExecute(ctx, "endpoint-1"), ErrOpenState)
+
+	// a healthy window does not close a held breaker, and a breaker with no
+	// traffic at all is still rewritten so the hold outlives the window
+	healthy := func(context.Context, uint64, map[string]time.Time) (map[string]PollResult, error) {
+		return map[string]PollResult{}, nil
+	}
+	require.NoError(t, m.sampleAndUpdate(ctx, healthy))
+
+	b, err = m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, b.State)
+	require.True(t, b.Forced)
+
+	delete(overrides, "endpoint-1")
+	b, err = m.Release(ctx, "endpoint-1", "project-1")
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, b.State)
+	require.False(t, b.Forced)
+	require.NoError(t, m.CanExecute(ctx, "endpoint-1"))
+}
+
+func TestCircuitBreakerManager_ForceStateRejectsHalfOpen(t *testing.T) {
+	m := newOverrideTestManager(t, nil)
+
+	_, err := m.ForceState(context.Background(), "endpoint-1", "project-1", StateHalfOpen)
+	require.ErrorIs(t, err, ErrInvalidForcedState)
+}
+
+func TestCircuitBreakerManager_ForcedClosedIgnoresFailures(t *testing.T) {
+	ctx := context.Background()
+	closed := StateClosed
+	m := newOverrideTestManager(t, map[string]Override{"endpoint-1": {TenantId: "project-1", ForcedState: &closed}})
+
+	require.NoError(t, m.sampleStore(ctx, pollResult(t, "endpoint-1", 10, 0), m.loadOverrides(ctx)))
+
+	b, err := m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, b.State)
+	require.True(t, b.Forced)
+	require.Equal(t, 100.0, b.FailureRate)
+}
+
+func TestCircuitBreakerManager_LiftedHoldResets(t *testing.T) {
+	ctx := context.Background()
+	m := newOverrideTestManager(t, nil)
+
+	_, err := m.ForceState(ctx, "endpoint-1", "project-1", StateOpen)
+	require.NoError(t, err)
+
+	// the override is gone but the stored breaker is still marked as held
+	require.NoError(t, m.sampleStore(ctx, pollResult(t, "endpoint-1", 0, 10), nil))
+
+	b, err := m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, b.State)
+	require.False(t, b.Forced)
+}
+
+func TestCircuitBreakerManager_EndpointConfigOverride(t *testing.T) {
+	ctx := context.Background()
+	m := newOverrideTestManager(t, map[string]Override{
+		"lenient": {TenantId: "project-1", Config: &CircuitBreakerConfig{FailureThreshold: 90}},
+	})
+
+	results := map[string]PollResult{
+		"lenient": {Key: "lenient", Failures: 6, Successes: 4},
+		"default": {Key: "default", Failures: 6, Successes: 4},
+	}
+	require.NoError(t, m.sampleStore(ctx, results, m.loadOverrides(ctx)))
+
+	b, err := m.GetCircuitBreakerWithError(ctx, "lenient")
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, b.State)
+
+	b, err = m.GetCircuitBreakerWithError(ctx, "default")
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, b.State)
+}
+
+func TestMergeConfig(t *testing.T) {
+	base := CircuitBreakerConfig{
+		SampleRate:                  30,
+		BreakerTimeout:              30,
+		FailureThreshold:            70,
+		SuccessThreshold:            5,
+		MinimumRequestCount:         10,
+		ObservabilityWindow:         5,
+		ConsecutiveFailureThreshold: 10,
+	}
+
+	got := mergeConfig(base, CircuitBreakerConfig{FailureThreshold: 90, MinimumRequestCount: 50, SampleRate: 1, ObservabilityWindow: 1})
+
+	want := base
+	want.FailureThreshold = 90
+	want.MinimumRequestCount = 50
+	require.Equal(t, want, got)
+}
+
+func TestCircuitBreakerManager_HalfOpenProbe(t *testing.T) {
+	ctx := context.Background()
+	m := newOverrideTestManager(t, nil)
+	clk := m.clock.(*clock.SimulatedClock)
+
+	var probeErr error
+	var probed []string
+	release := make(chan struct{})
+	require.NoError(t, ProbeFunctionOption(func(_ context.Context, b CircuitBreaker) error {
+		<-release
+		probed = append(probed, b.Key)
+		return probeErr
+	})(m))
+
+	failing := func(context.Context, uint64, map[string]time.Time) (map[string]PollResult, error) {
+		return map[string]PollResult{"endpoint-1": {Key: "endpoint-1", TenantId: "project-1", Failures: 20}}, nil
+	}
+	idle := func(context.Context, uint64, map[string]time.Time) (map[string]PollResult, error) {
+		return map[string]PollResult{"endpoint-1": {Key: "endpoint-1", TenantId: "project-1"}}, nil
+	}
+
+	require.NoError(t, m.sampleAndUpdate(ctx, failing))
+	b, err := m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, b.State)
+	require.Equal(t, uint64(1), b.ConsecutiveFailures)
+
+	// the timeout has passed but the endpoint is still down; the tick starts
+	// the probe and returns without waiting for it
+	clk.AdvanceTime(31 * time.Second)
+	probeErr = errors.New("connection refused")
+	require.NoError(t, m.sampleAndUpdate(ctx, idle))
+
+	b, err = m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, b.State)
+
+	close(release)
+	m.probes.wg.Wait()
+	require.Equal(t, []string{"breaker:endpoint-1"}, probed)
+
+	// the next tick applies the failed probe without counting it as a failure
+	require.NoError(t, m.sampleAndUpdate(ctx, idle))
+
+	b, err = m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, b.State)
+	require.Equal(t, uint64(1), b.ConsecutiveFailures)
+	require.True(t, b.WillResetAt.After(clk.Now()))
+
+	// the endpoint recovered
+	clk.AdvanceTime(31 * time.Second)
+	probeErr = nil
+	require.NoError(t, m.sampleAndUpdate(ctx, idle))
+	m.probes.wg.Wait()
+	require.NoError(t, m.sampleAndUpdate(ctx, idle))
+
+	b, err = m.GetCircuitBreakerWithError(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateHalfOpen, b.State)
+	require.Len(t, probed, 2)
+}
+
+func TestCircuitBreakerManager_ProbeSkipsHeldBreakers(t *testing.T) {
+	ctx := context.Background()
+	open := StateOpen
+	m := newOverrideTestManager(t, map[string]Override{"endpoint-1": {TenantId: "project-1", ForcedState: &open}})
+	clk := m.clock.(*clock.SimulatedClock)
+
+	require.NoError(t, ProbeFunctionOption(func(context.Context, CircuitBreaker) error {
+		t.Fatal("a held breaker must not be probed")
+		return nil
+	})(m))
+
+	_, err := m.ForceState(ctx, "endpoint-1", "project-1", StateOpen)
+	require.NoError(t, err)
+
+	clk.AdvanceTime(31 * time.Second)
+	require.NoError(t, m.sampleAndUpdate(ctx, func(context.Context, uint64, map[string]time.Time) (map[string]PollResult, error) {
+		return nil, nil
+	}))
+}
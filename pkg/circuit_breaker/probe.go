@@ -0,0 +1,29 @@
+package circuit_breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type probeResult struct {
+	err       error
+	startedAt time.Time
+}
+
+// probeTracker holds the half-open probes running in the background and the
+// results the sampler has not picked up yet, keyed by breaker key.
+type probeTracker struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	sem     chan struct{}
+	running map[string]struct{}
+	results map[string]probeResult
+}
+
+func newProbeTracker() *probeTracker {
+	return &probeTracker{
+		sem:     make(chan struct{}, maxConcurrentProbes),
+		running: map[string]struct{}{},
+		results: map[string]probeResult{},
+	}
+}
@@ -202,9 +202,29 @@ func (t *TestStore) GetMany(_ context.Context, keys ...string) (vals []interface
 }
 
 func (t *TestStore) SetOne(_ context.Context, key string, i interface{}, _ time.Duration) error {
+	var breaker CircuitBreaker
+	switch v := i.(type) {
+	case CircuitBreaker:
+		breaker = v
+	case string:
+		c, err := NewCircuitBreakerFromStore([]byte(v), nil)
+		if err != nil {
+			return err
+		}
+		breaker = *c
+	case []byte:
+		c, err := NewCircuitBreakerFromStore(v, nil)
+		if err != nil {
+			return err
+		}
+		breaker = *c
+	default:
+		return fmt.Errorf("unsupported circuit breaker value: %T", i)
+	}
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	t.store[key] = i.(CircuitBreaker)
+	t.store[key] = breaker
 	return nil
 }
 
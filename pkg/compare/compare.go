@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -18,21 +20,42 @@ var cmp map[string]CompareFunc
 
 func init() {
 	cmp = map[string]CompareFunc{
-		"$gte":   gte,
-		"$gt":    gt,
-		"$lte":   lte,
-		"$lt":    lt,
-		"$in":    in,
-		"$nin":   nin,
-		"$eq":    eq,
-		"$neq":   neq,
-		"$or":    or,
-		"$and":   and,
-		"$exist": exist,
-		"$regex": regex,
+		"$gte":        gte,
+		"$gt":         gt,
+		"$lte":        lte,
+		"$lt":         lt,
+		"$in":         in,
+		"$nin":        nin,
+		"$eq":         eq,
+		"$neq":        neq,
+		"$ieq":        ieq,
+		"$or":         or,
+		"$and":        and,
+		"$nor":        nor,
+		"$not":        not,
+		"$exist":      exist,
+		"$regex":      regex,
+		"$iregex":     iregex,
+		"$contains":   contains,
+		"$startsWith": startsWith,
+		"$endsWith":   endsWith,
+		"$size":       size,
+		"$all":        all,
+		"$elemMatch":  elemMatch,
 	}
 }
 
+// arrayOperators are the operators that need the whole array a key holds.
+// Arrays of objects are flattened into one key per element field
+// (items.0.sku, items.1.sku, ...), so for these the array is rebuilt
+// before the operator runs.
+var arrayOperators = map[string]struct{}{
+	"$contains":  {},
+	"$size":      {},
+	"$all":       {},
+	"$elemMatch": {},
+}
+
 func Compare(payload, filter map[string]interface{}) (bool, error) {
 	return compare(payload, filter)
 }
@@ -81,8 +104,12 @@ func compare(payload, filter map[string]interface{}) (bool, error) {
 		}
 
 		payloadVal, ok := payload[key]
+		if !ok && usesArrayOperator(filterVal) {
+			payloadVal, ok = collectElements(payload, key)
+		}
+
 		if !ok {
-			if key == "$or" || key == "$and" {
+			if key == "$or" || key == "$and" || key == "$nor" {
 				check, err := cmp[key](payload, filterVal)
 				if err != nil {
 					return false, err
@@ -107,7 +134,12 @@ func compare(payload, filter map[string]interface{}) (bool, error) {
 					continue
 				}
 
-				check, err := cmp[vk](payloadVal, vv)
+				fn, ok := cmp[vk]
+				if !ok {
+					return false, fmt.Errorf("%s is not a valid operator", vk)
+				}
+
+				check, err := fn(payloadVal, vv)
 				if err != nil {
 					return false, err
 				}
@@ -172,7 +204,158 @@ func regex(payload, filter interface{}) (bool, error) {
 	return len(match) > 0, nil
 }
 
+// iregex is regex with case-insensitive matching.
+func iregex(payload, filter interface{}) (bool, error) {
+	f, ok := filter.(string)
+	if !ok {
+		return false, fmt.Errorf("filter %v is not valid string", filter)
+	}
+
+	return regex(payload, "(?i)"+f)
+}
+
+// contains checks whether a string payload contains the filter as a substring,
+// or whether an array payload has an element equal to the filter.
+func contains(payload, filter interface{}) (bool, error) {
+	switch p := payload.(type) {
+	case string:
+		f, ok := filter.(string)
+		if !ok {
+			return false, fmt.Errorf("filter %v is not valid string", filter)
+		}
+		return strings.Contains(p, f), nil
+	case []interface{}:
+		return hasElement(p, filter)
+	default:
+		return false, nil
+	}
+}
+
+func startsWith(payload, filter interface{}) (bool, error) {
+	f, ok := filter.(string)
+	if !ok {
+		return false, fmt.Errorf("filter %v is not valid string", filter)
+	}
+
+	p, ok := payload.(string)
+	if !ok {
+		return false, nil
+	}
+
+	return strings.HasPrefix(p, f), nil
+}
+
+func endsWith(payload, filter interface{}) (bool, error) {
+	f, ok := filter.(string)
+	if !ok {
+		return false, fmt.Errorf("filter %v is not valid string", filter)
+	}
+
+	p, ok := payload.(string)
+	if !ok {
+		return false, nil
+	}
+
+	return strings.HasSuffix(p, f), nil
+}
+
+// size checks whether an array payload has exactly filter elements.
+func size(payload, filter interface{}) (bool, error) {
+	f, ok := toFloat64(filter)
+	if !ok {
+		return false, fmt.Errorf("filter %v is not a valid number", filter)
+	}
+
+	p, ok := payload.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	return float64(len(p)) == f, nil
+}
+
+// all checks whether an array payload has every element of the filter array.
+func all(payload, filter interface{}) (bool, error) {
+	f, ok := filter.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("filter %v is not a valid array", filter)
+	}
+
+	p, ok := payload.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	for _, v := range f {
+		chk, err := hasElement(p, v)
+		if err != nil {
+			return false, err
+		}
+
+		if !chk {
+			return false, nil
+		}
+	}
+
+	return len(f) > 0, nil
+}
+
+// elemMatch checks whether at least one element of an array payload satisfies
+// every condition in the filter. For an array of objects the filter is a query
+// on the element's fields, e.g. {"sku": {"$startsWith": "PRO-"}, "qty": {"$gt": 1}},
+// so conditions on several fields must hold for the same element. For an array
+// of scalars it is a set of operators applied to the element, e.g. {"$gt": 10}.
+func elemMatch(payload, filter interface{}) (bool, error) {
+	f, ok := filter.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("filter %v is not valid json", filter)
+	}
+
+	p, ok := payload.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	for _, el := range p {
+		var chk bool
+		var err error
+
+		if m, isObject := el.(map[string]interface{}); isObject {
+			chk, err = compare(m, f)
+		} else if isOperatorQuery(f) {
+			chk, err = matchValue(el, f)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if chk {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// not inverts the operators (or plain value) it holds for the same field,
+// e.g. {"status": {"$not": {"$regex": "^fail"}}}.
+func not(payload, filter interface{}) (bool, error) {
+	chk, err := matchValue(payload, filter)
+	return !chk, err
+}
+
+// nor is the inverse of or; it matches when none of the conditions match.
+func nor(payload, filter interface{}) (bool, error) {
+	chk, err := or(payload, filter)
+	return !chk, err
+}
+
 func gte(payload, filter interface{}) (bool, error) {
+	if pt, ft, ok := toTimes(payload, filter); ok {
+		return !pt.Before(ft), nil
+	}
+
 	p, ok := toFloat64(payload)
 	if !ok {
 		fmt.Printf("payload %v is not a valid number\n", payload)
@@ -189,6 +372,10 @@ func gte(payload, filter interface{}) (bool, error) {
 }
 
 func gt(payload, filter interface{}) (bool, error) {
+	if pt, ft, ok := toTimes(payload, filter); ok {
+		return pt.After(ft), nil
+	}
+
 	p, ok := toFloat64(payload)
 	if !ok {
 		fmt.Printf("payload %v is not a valid number", payload)
@@ -271,6 +458,17 @@ func neq(x, y interface{}) (bool, error) {
 	return !chk, err
 }
 
+// ieq is eq, except two strings are compared case-insensitively.
+func ieq(x, y interface{}) (bool, error) {
+	xs, xok := x.(string)
+	ys, yok := y.(string)
+	if xok && yok {
+		return strings.EqualFold(xs, ys), nil
+	}
+
+	return eq(x, y)
+}
+
 // or evaluate matches across an array of conditions. The array of conditions can contain any other valid json schema.
 func or(payload, filter interface{}) (bool, error) {
 	check := false
@@ -360,6 +558,136 @@ func exist(payload, filter interface{}) (bool, error) {
 	return b == want, nil
 }
 
+// matchValue evaluates filter against a single value the same way compare
+// evaluates it against a field holding that value.
+func matchValue(value, filter interface{}) (bool, error) {
+	return compare(map[string]interface{}{"value": value}, map[string]interface{}{"value": filter})
+}
+
+func isOperatorQuery(filter map[string]interface{}) bool {
+	for k := range filter {
+		if !strings.HasPrefix(k, "$") {
+			return false
+		}
+	}
+	return len(filter) > 0
+}
+
+// hasElement reports whether any element of p is eq to v.
+func hasElement(p []interface{}, v interface{}) (bool, error) {
+	for _, el := range p {
+		chk, err := eq(el, v)
+		if err != nil {
+			return false, err
+		}
+
+		if chk {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func usesArrayOperator(filterVal interface{}) bool {
+	f, ok := filterVal.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	for k := range f {
+		if _, ok := arrayOperators[k]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectElements rebuilds the array of objects stored under key from a
+// flattened payload, where {"items": [{"sku": "a"}]} is held as {"items.0.sku": "a"}.
+// Each element is itself a flattened object.
+func collectElements(payload map[string]interface{}, key string) ([]interface{}, bool) {
+	prefix := key + "."
+	elements := map[int]map[string]interface{}{}
+
+	for k, v := range payload {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		rest := k[len(prefix):]
+		idx, field, found := strings.Cut(rest, ".")
+		if !found {
+			continue
+		}
+
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 0 {
+			continue
+		}
+
+		if elements[i] == nil {
+			elements[i] = map[string]interface{}{}
+		}
+		elements[i][field] = v
+	}
+
+	if len(elements) == 0 {
+		return nil, false
+	}
+
+	indexes := make([]int, 0, len(elements))
+	for i := range elements {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	out := make([]interface{}, 0, len(indexes))
+	for _, i := range indexes {
+		out = append(out, elements[i])
+	}
+
+	return out, true
+}
+
+// isoLayouts are the ISO-8601 forms $gt, $gte, $lt and $lte compare as dates.
+var isoLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// toTimes parses x and y as ISO-8601 timestamps, reporting false unless both are.
+func toTimes(x, y interface{}) (time.Time, time.Time, bool) {
+	xt, ok := toTime(x)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	yt, ok := toTime(y)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return xt, yt, true
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range isoLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
 // toFloat64 converts interface{} value to float64 if the value is numeric, else return false
 func toFloat64(v interface{}) (float64, bool) {
 	var f float64
@@ -1657,3 +1657,180 @@ func TestInMixedTypeArrayNoPanic(t *testing.T) {
 		t.Fatalf("expected no match for %q in mixed array, got true", "b")
 	}
 }
+
+func TestCompareArrayStringAndDateOperators(t *testing.T) {
+	payload := map[string]interface{}{
+		"status":     "Failed_Permanently",
+		"email":      "ops@Example.com",
+		"tags":       []interface{}{"billing", "urgent"},
+		"scores":     []interface{}{3, 9, 12},
+		"created_at": "2024-03-01T10:00:00Z",
+		"order": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"sku": "BAS-1", "qty": 5},
+				map[string]interface{}{"sku": "PRO-7", "qty": 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter map[string]interface{}
+		want   bool
+	}{
+		{
+			name:   "$contains - substring",
+			filter: map[string]interface{}{"status": map[string]interface{}{"$contains": "Perm"}},
+			want:   true,
+		},
+		{
+			name:   "$contains - array element",
+			filter: map[string]interface{}{"tags": map[string]interface{}{"$contains": "urgent"}},
+			want:   true,
+		},
+		{
+			name:   "$contains - missing array element",
+			filter: map[string]interface{}{"tags": map[string]interface{}{"$contains": "low"}},
+			want:   false,
+		},
+		{
+			name:   "$startsWith",
+			filter: map[string]interface{}{"status": map[string]interface{}{"$startsWith": "Failed"}},
+			want:   true,
+		},
+		{
+			name:   "$endsWith",
+			filter: map[string]interface{}{"email": map[string]interface{}{"$endsWith": ".org"}},
+			want:   false,
+		},
+		{
+			name:   "$size - scalar array",
+			filter: map[string]interface{}{"scores": map[string]interface{}{"$size": 3}},
+			want:   true,
+		},
+		{
+			name:   "$size - object array",
+			filter: map[string]interface{}{"order": map[string]interface{}{"items": map[string]interface{}{"$size": 2}}},
+			want:   true,
+		},
+		{
+			name:   "$all",
+			filter: map[string]interface{}{"tags": map[string]interface{}{"$all": []interface{}{"urgent", "billing"}}},
+			want:   true,
+		},
+		{
+			name:   "$all - one missing",
+			filter: map[string]interface{}{"tags": map[string]interface{}{"$all": []interface{}{"urgent", "low"}}},
+			want:   false,
+		},
+		{
+			name: "$elemMatch - same element",
+			filter: map[string]interface{}{
+				"order": map[string]interface{}{
+					"items": map[string]interface{}{
+						"$elemMatch": map[string]interface{}{
+							"sku": map[string]interface{}{"$startsWith": "PRO-"},
+							"qty": map[string]interface{}{"$gte": 1},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "$elemMatch - conditions met by different elements",
+			filter: map[string]interface{}{
+				"order": map[string]interface{}{
+					"items": map[string]interface{}{
+						"$elemMatch": map[string]interface{}{
+							"sku": map[string]interface{}{"$startsWith": "PRO-"},
+							"qty": map[string]interface{}{"$gt": 2},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name:   "$elemMatch - scalar array",
+			filter: map[string]interface{}{"scores": map[string]interface{}{"$elemMatch": map[string]interface{}{"$gt": 10, "$lt": 20}}},
+			want:   true,
+		},
+		{
+			name:   "$not",
+			filter: map[string]interface{}{"status": map[string]interface{}{"$not": map[string]interface{}{"$regex": "^Succeeded"}}},
+			want:   true,
+		},
+		{
+			name:   "$not - plain value",
+			filter: map[string]interface{}{"status": map[string]interface{}{"$not": "Failed_Permanently"}},
+			want:   false,
+		},
+		{
+			name: "$nor",
+			filter: map[string]interface{}{
+				"$nor": []interface{}{
+					map[string]interface{}{"status": "Succeeded"},
+					map[string]interface{}{"tags": map[string]interface{}{"$contains": "low"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "$nor - one condition matches",
+			filter: map[string]interface{}{
+				"$nor": []interface{}{
+					map[string]interface{}{"status": "Succeeded"},
+					map[string]interface{}{"tags": map[string]interface{}{"$contains": "urgent"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name:   "$ieq",
+			filter: map[string]interface{}{"email": map[string]interface{}{"$ieq": "OPS@example.COM"}},
+			want:   true,
+		},
+		{
+			name:   "$iregex",
+			filter: map[string]interface{}{"status": map[string]interface{}{"$iregex": "^failed_"}},
+			want:   true,
+		},
+		{
+			name:   "$gt - date",
+			filter: map[string]interface{}{"created_at": map[string]interface{}{"$gt": "2024-01-01"}},
+			want:   true,
+		},
+		{
+			name:   "$lt - date",
+			filter: map[string]interface{}{"created_at": map[string]interface{}{"$lt": "2024-03-01T09:00:00Z"}},
+			want:   false,
+		},
+		{
+			name:   "$gte - date with offset",
+			filter: map[string]interface{}{"created_at": map[string]interface{}{"$gte": "2024-03-01T11:00:00+01:00"}},
+			want:   true,
+		},
+	}
+
+	p, err := flatten.Flatten(payload)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := flatten.Flatten(tt.filter)
+			require.NoError(t, err)
+
+			matched, err := Compare(p, f)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, matched)
+		})
+	}
+}
+
+func TestCompareStringOperatorsRejectNonStringFilter(t *testing.T) {
+	_, err := Compare(map[string]interface{}{"status": "ok"}, map[string]interface{}{
+		"status": map[string]interface{}{"$startsWith": 1},
+	})
+	require.Error(t, err)
+}
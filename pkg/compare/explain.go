@@ -0,0 +1,225 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Clause is one condition of a filter and the outcome of evaluating it.
+// Logical operators ($or, $and, $nor, $not) hold the conditions they combine
+// in Clauses.
+type Clause struct {
+	// Field is the flattened payload key the condition reads, empty for $or,
+	// $and and $nor.
+	Field    string      `json:"field,omitempty"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value,omitempty"`
+	Actual   interface{} `json:"actual,omitempty"`
+	Matched  bool        `json:"matched"`
+
+	// Skipped is set when the payload does not have Field. Compare leaves
+	// such a condition out, so it only fails a filter whose conditions are
+	// all skipped.
+	Skipped bool     `json:"skipped,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	Clauses []Clause `json:"clauses,omitempty"`
+}
+
+// Explanation is the outcome of a filter together with the outcome of each
+// of its conditions.
+type Explanation struct {
+	Matched bool     `json:"matched"`
+	Error   string   `json:"error,omitempty"`
+	Clauses []Clause `json:"clauses,omitempty"`
+}
+
+// Explain evaluates filter against payload like Compare does, and records the
+// outcome of every condition so a caller can tell which one rejected the
+// payload. Both payload and filter are expected to be flattened.
+func Explain(payload, filter map[string]interface{}) *Explanation {
+	matched, err := compare(payload, filter)
+
+	e := &Explanation{Matched: matched, Clauses: explain(payload, filter)}
+	if err != nil {
+		e.Matched = false
+		e.Error = err.Error()
+	}
+
+	return e
+}
+
+// FirstFailure returns the first condition that did not match, descending
+// into logical operators, or nil if there is none.
+func (e *Explanation) FirstFailure() *Clause {
+	return firstFailure(e.Clauses)
+}
+
+func firstFailure(clauses []Clause) *Clause {
+	for i := range clauses {
+		c := &clauses[i]
+		if c.Matched || c.Skipped {
+			continue
+		}
+
+		// $nor and $not fail because a condition they hold matched, so the
+		// operator itself is the most precise answer.
+		if c.Operator != "$nor" && c.Operator != "$not" {
+			if inner := firstFailure(c.Clauses); inner != nil {
+				return inner
+			}
+		}
+
+		return c
+	}
+
+	return nil
+}
+
+func explain(payload, filter map[string]interface{}) []Clause {
+	clauses := make([]Clause, 0, len(filter))
+	for _, key := range sortedKeys(filter) {
+		filterVal := filter[key]
+
+		if strings.HasSuffix(key, ".$") {
+			clauses = append(clauses, Clause{Field: key, Error: ErrTrailingDollarOpNotAllowed.Error()})
+			continue
+		}
+
+		if key == "$or" || key == "$and" || key == "$nor" {
+			clauses = append(clauses, explainLogical(payload, key, filterVal))
+			continue
+		}
+
+		if strings.Contains(key, "$.") {
+			clauses = append(clauses, explainWildcard(payload, key, filterVal)...)
+			continue
+		}
+
+		payloadVal, ok := payload[key]
+		if !ok && usesArrayOperator(filterVal) {
+			payloadVal, ok = collectElements(payload, key)
+		}
+
+		clauses = append(clauses, explainField(payload, key, payloadVal, ok, filterVal)...)
+	}
+
+	return clauses
+}
+
+func explainLogical(payload map[string]interface{}, op string, filterVal interface{}) Clause {
+	c := Clause{Operator: op}
+
+	matched, err := cmp[op](payload, filterVal)
+	if err != nil {
+		c.Error = err.Error()
+		return c
+	}
+	c.Matched = matched
+
+	conditions, _ := filterVal.([]interface{})
+	for _, cond := range conditions {
+		m, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		chk, err := compare(payload, m)
+		group := Clause{Operator: "$and", Matched: chk, Clauses: explain(payload, m)}
+		if err != nil {
+			group.Matched = false
+			group.Error = err.Error()
+		}
+		c.Clauses = append(c.Clauses, group)
+	}
+
+	return c
+}
+
+func explainWildcard(payload map[string]interface{}, key string, filterVal interface{}) []Clause {
+	ops, ok := filterVal.(map[string]interface{})
+	if !ok {
+		ops = map[string]interface{}{"$eq": filterVal}
+	}
+
+	clauses := make([]Clause, 0, len(ops))
+	for _, op := range sortedKeys(ops) {
+		c := Clause{Field: key, Operator: op, Value: ops[op]}
+
+		var f interface{} = map[string]interface{}{op: ops[op]}
+		if !ok {
+			f = filterVal
+		}
+
+		matched, err := compare(payload, map[string]interface{}{key: f})
+		if err != nil {
+			c.Error = err.Error()
+		} else {
+			c.Matched = matched
+		}
+		clauses = append(clauses, c)
+	}
+
+	return clauses
+}
+
+func explainField(payload map[string]interface{}, key string, payloadVal interface{}, present bool, filterVal interface{}) []Clause {
+	ops, isMap := filterVal.(map[string]interface{})
+	if !isMap {
+		op := "$eq"
+		if _, isArray := payloadVal.([]interface{}); isArray {
+			op = "$in"
+		}
+		ops = map[string]interface{}{op: filterVal}
+	}
+
+	clauses := make([]Clause, 0, len(ops))
+	for _, op := range sortedKeys(ops) {
+		vv := ops[op]
+		c := Clause{Field: key, Operator: op, Value: vv, Actual: payloadVal}
+
+		if !present {
+			c.Skipped = true
+			clauses = append(clauses, c)
+			continue
+		}
+
+		var matched bool
+		var err error
+
+		switch op {
+		case "$exist":
+			matched, err = exist(payload, map[string]interface{}{key: vv})
+		default:
+			fn, ok := cmp[op]
+			if !ok {
+				err = fmt.Errorf("%s is not a valid operator", op)
+				break
+			}
+			matched, err = fn(payloadVal, vv)
+		}
+
+		if err != nil {
+			c.Error = err.Error()
+		} else {
+			c.Matched = matched
+		}
+
+		if m, ok := vv.(map[string]interface{}); ok && op == "$not" {
+			c.Clauses = explainField(map[string]interface{}{key: payloadVal}, key, payloadVal, true, m)
+		}
+
+		clauses = append(clauses, c)
+	}
+
+	return clauses
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/pkg/flatten"
+)
+
+func TestExplain(t *testing.T) {
+	p, err := flatten.Flatten(map[string]interface{}{
+		"type":   "invoice.paid",
+		"amount": 50,
+		"customer": map[string]interface{}{
+			"tier": "gold",
+		},
+	})
+	require.NoError(t, err)
+
+	f, err := flatten.Flatten(map[string]interface{}{
+		"amount":   map[string]interface{}{"$gte": 100},
+		"customer": map[string]interface{}{"tier": "gold"},
+		"missing":  "value",
+		"$or": []interface{}{
+			map[string]interface{}{"type": "invoice.created"},
+			map[string]interface{}{"type": map[string]interface{}{"$startsWith": "invoice."}},
+		},
+	})
+	require.NoError(t, err)
+
+	e := Explain(p, f)
+	require.False(t, e.Matched)
+	require.Empty(t, e.Error)
+	require.Len(t, e.Clauses, 4)
+
+	or := e.Clauses[0]
+	require.Equal(t, "$or", or.Operator)
+	require.True(t, or.Matched)
+	require.Len(t, or.Clauses, 2)
+	require.False(t, or.Clauses[0].Matched)
+	require.True(t, or.Clauses[1].Matched)
+
+	amount := e.Clauses[1]
+	require.Equal(t, Clause{Field: "amount", Operator: "$gte", Value: 100, Actual: 50}, amount)
+
+	tier := e.Clauses[2]
+	require.Equal(t, "customer.tier", tier.Field)
+	require.Equal(t, "$eq", tier.Operator)
+	require.True(t, tier.Matched)
+
+	missing := e.Clauses[3]
+	require.True(t, missing.Skipped)
+	require.False(t, missing.Matched)
+
+	require.Equal(t, &e.Clauses[1], e.FirstFailure())
+}
+
+func TestExplain_Not(t *testing.T) {
+	e := Explain(
+		map[string]interface{}{"status": "failed"},
+		map[string]interface{}{"status": map[string]interface{}{"$not": map[string]interface{}{"$regex": "^fail"}}},
+	)
+
+	require.False(t, e.Matched)
+	require.Len(t, e.Clauses, 1)
+	require.Equal(t, "$not", e.Clauses[0].Operator)
+	require.Len(t, e.Clauses[0].Clauses, 1)
+	require.True(t, e.Clauses[0].Clauses[0].Matched)
+	require.Equal(t, "$not", e.FirstFailure().Operator)
+}
+
+func TestExplain_Error(t *testing.T) {
+	e := Explain(
+		map[string]interface{}{"status": "ok"},
+		map[string]interface{}{"status": map[string]interface{}{"$regex": "("}},
+	)
+
+	require.False(t, e.Matched)
+	require.NotEmpty(t, e.Error)
+	require.NotEmpty(t, e.Clauses[0].Error)
+}
+
+func TestExplain_EmptyFilter(t *testing.T) {
+	e := Explain(map[string]interface{}{"a": 1}, map[string]interface{}{})
+	require.True(t, e.Matched)
+	require.Nil(t, e.FirstFailure())
+}
@@ -11,18 +11,28 @@ import (
 // offer us a good technique for significantly reducing the number of allocations we do here
 
 var operators = map[string]struct{}{
-	"$gte":   {},
-	"$gt":    {},
-	"$lte":   {},
-	"$lt":    {},
-	"$in":    {},
-	"$nin":   {},
-	"$eq":    {},
-	"$neq":   {},
-	"$or":    {},
-	"$and":   {},
-	"$exist": {},
-	"$regex": {},
+	"$gte":        {},
+	"$gt":         {},
+	"$lte":        {},
+	"$lt":         {},
+	"$in":         {},
+	"$nin":        {},
+	"$eq":         {},
+	"$neq":        {},
+	"$ieq":        {},
+	"$or":         {},
+	"$and":        {},
+	"$nor":        {},
+	"$not":        {},
+	"$exist":      {},
+	"$regex":      {},
+	"$iregex":     {},
+	"$contains":   {},
+	"$startsWith": {},
+	"$endsWith":   {},
+	"$size":       {},
+	"$all":        {},
+	"$elemMatch":  {},
 }
 
 type stackFrame struct {
@@ -34,7 +44,7 @@ type counterStackFrame struct {
 	nested interface{}
 }
 
-var ErrOrAndMustBeArray = errors.New("the value of $or and $and must be an array")
+var ErrOrAndMustBeArray = errors.New("the value of $or, $and and $nor must be an array")
 
 // Flatten flattens extended JSON which is used to build and store queries.
 //
@@ -124,7 +134,7 @@ func flatten(prefix string, nested interface{}) (M, error) {
 						return nil, fmt.Errorf("%s starts with a $ and is not a valid operator", key)
 					}
 
-					if key == "$or" || key == "$and" {
+					if key == "$or" || key == "$and" || key == "$nor" {
 						switch a := value.(type) {
 						case []interface{}:
 
@@ -165,6 +175,18 @@ func flatten(prefix string, nested interface{}) (M, error) {
 						}
 					}
 
+					// $elemMatch and $not hold a query of their own, e.g. {"sku": {"$startsWith": "PRO-"}},
+					// which is flattened here so it can be matched against an array element or the field itself
+					if key == "$elemMatch" || key == "$not" {
+						if m, ok := value.(M); ok {
+							newM, err := flatten("", m)
+							if err != nil {
+								return nil, err
+							}
+							value = newM
+						}
+					}
+
 					// it's one of the unary ops [$in, $lt, ...] these do not require recursion or expansion
 					// and so forth so just set it directly
 					putValueInResult(currentFrame.prefix, key, value, result)
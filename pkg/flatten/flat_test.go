@@ -552,6 +552,31 @@ func TestFlattenWithOperator(t *testing.T) {
 				},
 			},
 		},
+
+		/////////////////// $elemMatch operator
+		{
+			name:  "elemMatch query is flattened",
+			given: `{"order":{"items":{"$elemMatch":{"product":{"sku":{"$startsWith":"PRO-"}},"qty":{"$gt":1}}}}}`,
+			want: M{
+				"order.items": M{
+					"$elemMatch": M{
+						"product.sku": M{"$startsWith": "PRO-"},
+						"qty":         M{"$gt": float64(1)},
+					},
+				},
+			},
+		},
+
+		/////////////////// $not operator
+		{
+			name:  "not query is flattened",
+			given: `{"status":{"$not":{"$iregex":"^fail"}}}`,
+			want: M{
+				"status": M{
+					"$not": M{"$iregex": "^fail"},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
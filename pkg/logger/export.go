@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// exportHandler receives a copy of every record any logger in the process
+// writes. Loggers are built with New long before telemetry is configured, so
+// the hook is global rather than threaded through each constructor.
+var exportHandler atomic.Pointer[slog.Handler]
+
+// SetExportHandler registers h to receive every log record alongside the local
+// JSON output, e.g. an OTLP log bridge. Passing nil removes it.
+func SetExportHandler(h slog.Handler) {
+	if h == nil {
+		exportHandler.Store(nil)
+		return
+	}
+	exportHandler.Store(&h)
+}
+
+// export forwards r to the registered export handler after replaying the
+// attributes and groups the logger was derived with.
+func export(ctx context.Context, ops []func(slog.Handler) slog.Handler, r slog.Record) {
+	p := exportHandler.Load()
+	if p == nil {
+		return
+	}
+
+	h := *p
+	for _, op := range ops {
+		h = op(h)
+	}
+
+	if !h.Enabled(ctx, r.Level) {
+		return
+	}
+	_ = h.Handle(ctx, r)
+}
@@ -10,9 +10,13 @@ import (
 )
 
 // traceHandler is a slog.Handler that injects OpenTelemetry trace context
-// and request ID into every log record when available in the context.
+// and request ID into every log record when available in the context. It also
+// mirrors records to the export handler, if one is registered.
 type traceHandler struct {
 	inner slog.Handler
+
+	// ops replays WithAttrs/WithGroup calls onto the export handler.
+	ops []func(slog.Handler) slog.Handler
 }
 
 func newTraceHandler(inner slog.Handler) *traceHandler {
@@ -37,13 +41,27 @@ func (h *traceHandler) Handle(ctx context.Context, r slog.Record) error {
 		r.AddAttrs(slog.String("request_id", reqID))
 	}
 
+	export(ctx, h.ops, r.Clone())
+
 	return h.inner.Handle(ctx, r)
 }
 
 func (h *traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &traceHandler{inner: h.inner.WithAttrs(attrs)}
+	return &traceHandler{
+		inner: h.inner.WithAttrs(attrs),
+		ops:   h.withOp(func(e slog.Handler) slog.Handler { return e.WithAttrs(attrs) }),
+	}
 }
 
 func (h *traceHandler) WithGroup(name string) slog.Handler {
-	return &traceHandler{inner: h.inner.WithGroup(name)}
+	return &traceHandler{
+		inner: h.inner.WithGroup(name),
+		ops:   h.withOp(func(e slog.Handler) slog.Handler { return e.WithGroup(name) }),
+	}
+}
+
+func (h *traceHandler) withOp(op func(slog.Handler) slog.Handler) []func(slog.Handler) slog.Handler {
+	ops := make([]func(slog.Handler) slog.Handler, 0, len(h.ops)+1)
+	ops = append(ops, h.ops...)
+	return append(ops, op)
 }
@@ -203,3 +203,47 @@ func TestSource_PointsToCallerNotLogger(t *testing.T) {
 		t.Errorf("source.file should point to the caller, not logger.go; got %s", file)
 	}
 }
+
+func TestSetExportHandler_MirrorsRecords(t *testing.T) {
+	local := &bytes.Buffer{}
+	exported := &bytes.Buffer{}
+
+	SetExportHandler(slog.NewJSONHandler(exported, nil))
+	t.Cleanup(func() { SetExportHandler(nil) })
+
+	jsonHandler := slog.NewJSONHandler(local, &slog.HandlerOptions{Level: LevelDebug})
+	l := &SlogLogger{
+		logger: slog.New(newTraceHandler(jsonHandler).WithAttrs([]slog.Attr{slog.String("service", "test")})),
+	}
+
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	l.InfoContext(ctx, "exported message", "key", "value")
+
+	entry := parseLogEntry(t, exported)
+	if entry["msg"] != "exported message" {
+		t.Errorf("msg = %v, want %v", entry["msg"], "exported message")
+	}
+	if entry["service"] != "test" {
+		t.Errorf("service = %v, want %v", entry["service"], "test")
+	}
+	if entry["trace_id"] != traceID.String() {
+		t.Errorf("trace_id = %v, want %v", entry["trace_id"], traceID.String())
+	}
+	if entry["key"] != "value" {
+		t.Errorf("key = %v, want %v", entry["key"], "value")
+	}
+
+	if local.Len() == 0 {
+		t.Error("expected the local handler to still receive the record")
+	}
+
+	SetExportHandler(nil)
+	exported.Reset()
+	l.Info("not exported")
+	if exported.Len() != 0 {
+		t.Errorf("expected no export after removing the handler, got %s", exported.String())
+	}
+}
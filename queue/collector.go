@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Descriptors shared by every provider's collector, so an alert written
+// against one provider keeps working after a switch to the other. They are
+// built from Stats rather than provider internals for the same reason: the
+// inspector already reconciles the two vocabularies.
+var (
+	QueueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("convoy", "queue", "depth"),
+		"Number of tasks in each queue, by status",
+		[]string{"queue", "status"}, nil,
+	)
+	QueueOldestTaskAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("convoy", "queue", "oldest_task_age_seconds"),
+		"Age (in seconds) of the oldest task in each queue that is due but not yet claimed",
+		[]string{"queue"}, nil,
+	)
+)
+
+// DescribeStats sends the descriptors CollectStats emits.
+func DescribeStats(ch chan<- *prometheus.Desc) {
+	ch <- QueueDepthDesc
+	ch <- QueueOldestTaskAgeDesc
+}
+
+// CollectStats emits the depth and oldest-task age of every queue in stats.
+// Only the statuses the provider serves are emitted; a status it does not have
+// is absent rather than reported as an empty queue.
+func CollectStats(ch chan<- prometheus.Metric, stats Stats) {
+	for _, q := range stats.Queues {
+		for _, status := range stats.Statuses {
+			ch <- prometheus.MustNewConstMetric(QueueDepthDesc, prometheus.GaugeValue, float64(q.Counts[status]), q.Queue, status)
+		}
+		ch <- prometheus.MustNewConstMetric(QueueOldestTaskAgeDesc, prometheus.GaugeValue, float64(q.LatencyMS)/1000, q.Queue)
+	}
+}
@@ -0,0 +1,58 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type statsCollector Stats
+
+func (s statsCollector) Describe(ch chan<- *prometheus.Desc) { DescribeStats(ch) }
+func (s statsCollector) Collect(ch chan<- prometheus.Metric) { CollectStats(ch, Stats(s)) }
+
+func TestCollectStats(t *testing.T) {
+	stats := Stats{
+		Provider: ProviderPostgres,
+		Statuses: []string{StatusPending, StatusProcessing},
+		Queues: []QueueStat{
+			{
+				Queue:     "EventQueue",
+				Counts:    map[string]int64{StatusPending: 12, StatusProcessing: 3},
+				LatencyMS: 1500,
+			},
+		},
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(statsCollector(stats)))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	depth := map[string]float64{}
+	var age float64
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			require.Equal(t, "EventQueue", labels["queue"])
+
+			switch f.GetName() {
+			case "convoy_queue_depth":
+				depth[labels["status"]] = m.GetGauge().GetValue()
+			case "convoy_queue_oldest_task_age_seconds":
+				age = m.GetGauge().GetValue()
+			default:
+				t.Fatalf("unexpected metric %s", f.GetName())
+			}
+		}
+	}
+
+	// A status the provider does not serve is absent, not reported as zero.
+	require.Equal(t, map[string]float64{StatusPending: 12, StatusProcessing: 3}, depth)
+	require.Equal(t, 1.5, age)
+}
@@ -0,0 +1,100 @@
+package queue
+
+import "context"
+
+// TenantHeader names the tenant a job is scheduled as. It rides in the job's
+// headers, beside the trace context, so both drivers and both consumers read
+// it from the same place. Jobs are stamped whether or not fair scheduling is
+// on, so turning it on does not wait for the queues to drain; a job enqueued
+// before tenants were stamped is simply untenanted.
+const TenantHeader = "Convoy-Tenant"
+
+// MaxTenantBacklog bounds the per-tenant backlog view to the busiest tenants.
+// An operator reading it is looking for the one that is drowning the rest,
+// not a census.
+const MaxTenantBacklog = 100
+
+// FairShare configures per-tenant fair scheduling. Queue weights decide which
+// queue a consumer reads next; these decide whose work it takes from that
+// queue, so one project with millions of deliveries waiting cannot hold every
+// worker while another project's single delivery waits behind it.
+//
+// Tenants are served weighted round robin: over any stretch where several
+// tenants have work ready, each gets slots in proportion to its weight.
+type FairShare struct {
+	Enabled bool
+	// DefaultWeight is the weight of a tenant Weights does not name. Zero is
+	// read as one.
+	DefaultWeight int
+	// Weights raises or lowers named tenants, keyed by project or organisation
+	// ID depending on what jobs are stamped with.
+	Weights map[string]int
+	// MaxInFlight caps how many jobs one tenant may hold at once. Zero leaves
+	// tenants limited only by their weighted share.
+	MaxInFlight int
+}
+
+// Weight is tenant's weight, never below one.
+func (f FairShare) Weight(tenant string) int {
+	if w := f.Weights[tenant]; w > 0 {
+		return w
+	}
+	if f.DefaultWeight > 0 {
+		return f.DefaultWeight
+	}
+	return 1
+}
+
+// TenantResolver maps the project a job belongs to onto the tenant it is
+// scheduled as. An empty result keeps the project as the tenant.
+type TenantResolver func(ctx context.Context, projectID string) string
+
+// StampTenant writes the job's tenant into its headers. A job with no project
+// is left untenanted; every such job shares one tenant.
+func StampTenant(ctx context.Context, resolve TenantResolver, job *Job) {
+	if job == nil || job.ProjectID == "" {
+		return
+	}
+
+	tenant := job.ProjectID
+	if resolve != nil {
+		if t := resolve(ctx, job.ProjectID); t != "" {
+			tenant = t
+		}
+	}
+
+	if job.Headers == nil {
+		job.Headers = map[string]string{}
+	}
+	job.Headers[TenantHeader] = tenant
+}
+
+// TenantOf returns the tenant a job's headers name, or "" for an untenanted
+// job.
+func TenantOf(headers map[string]string) string {
+	return headers[TenantHeader]
+}
+
+// TenantBacklog is one tenant's share of a queue.
+type TenantBacklog struct {
+	Tenant     string `json:"tenant"`
+	Pending    int64  `json:"pending"`
+	Processing int64  `json:"processing"`
+	// Weight is the tenant's fair-share weight on this instance.
+	Weight int `json:"weight"`
+}
+
+// TenantBacklogReport is a queue broken down by tenant, busiest first.
+type TenantBacklogReport struct {
+	Queue   string          `json:"queue"`
+	Tenants []TenantBacklog `json:"tenants"`
+	// Sampled means the counts cover only the first SampleSize pending tasks
+	// rather than the whole queue. Redis cannot count a list by a header
+	// without reading every task, so it reports the head of the queue, which
+	// is the part the workers take next.
+	Sampled    bool `json:"sampled"`
+	SampleSize int  `json:"sample_size,omitempty"`
+	// FairShare reports whether this instance schedules by tenant; the
+	// breakdown is available either way.
+	FairShare bool `json:"fair_share"`
+}
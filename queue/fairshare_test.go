@@ -0,0 +1,47 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairShareWeight(t *testing.T) {
+	share := FairShare{DefaultWeight: 2, Weights: map[string]int{"big": 6, "broken": 0}}
+
+	require.Equal(t, 6, share.Weight("big"))
+	require.Equal(t, 2, share.Weight("other"))
+	require.Equal(t, 2, share.Weight("broken"), "a zero weight must fall back, not starve the tenant")
+	require.Equal(t, 1, FairShare{}.Weight("other"))
+}
+
+func TestStampTenant(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("project is the tenant by default", func(t *testing.T) {
+		job := &Job{ProjectID: "project-1"}
+		StampTenant(ctx, nil, job)
+		require.Equal(t, "project-1", TenantOf(job.Headers))
+	})
+
+	t.Run("resolver maps the project", func(t *testing.T) {
+		job := &Job{ProjectID: "project-1", Headers: map[string]string{"traceparent": "x"}}
+		StampTenant(ctx, func(context.Context, string) string { return "org-1" }, job)
+		require.Equal(t, "org-1", TenantOf(job.Headers))
+		require.Equal(t, "x", job.Headers["traceparent"])
+	})
+
+	t.Run("failed resolution keeps the project", func(t *testing.T) {
+		job := &Job{ProjectID: "project-1"}
+		StampTenant(ctx, func(context.Context, string) string { return "" }, job)
+		require.Equal(t, "project-1", TenantOf(job.Headers))
+	})
+
+	t.Run("no project is untenanted", func(t *testing.T) {
+		job := &Job{}
+		StampTenant(ctx, func(context.Context, string) string { return "org-1" }, job)
+		require.Empty(t, TenantOf(job.Headers))
+		require.Nil(t, job.Headers)
+	})
+}
@@ -290,4 +290,9 @@ type Inspector interface {
 	// runs to completion; nothing new is picked up until it is resumed.
 	PauseQueue(ctx context.Context, queueName string) error
 	UnpauseQueue(ctx context.Context, queueName string) error
+
+	// TenantBacklog breaks one queue's waiting and running work down by
+	// tenant, busiest first, so an operator can see whose backlog everyone
+	// else is queued behind.
+	TenantBacklog(ctx context.Context, queueName string) (TenantBacklogReport, error)
 }
@@ -0,0 +1,318 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lib/pq"
+
+	"github.com/frain-dev/convoy/queue"
+)
+
+// fairShareTenantScan bounds how many tenants one fair claim considers. Each
+// costs an index probe and two bounded counts, so a deployment with thousands
+// of busy projects is served a window at a time: the next claim resumes after
+// the last tenant this one scanned.
+const fairShareTenantScan = 256
+
+// readyTenantsSQL enumerates the tenants holding pending rows with a skip scan
+// over idx_queue_jobs_tenant_claim, one probe per tenant however many rows
+// each holds, then counts what each has due on the requested queues and how
+// many it is already running. The probe ignores run_at and queue_name on
+// purpose: filtering there would walk every future-dated row of a tenant whose
+// endpoint is backing off before moving past it.
+//
+// The first step's comparison is filled in: ">=" to start from the first
+// tenant, ">" to resume after the cursor.
+const readyTenantsSQL = `
+	WITH RECURSIVE active AS (
+		SELECT COALESCE(array_agg(n), '{}') AS names
+		FROM unnest($1::text[]) AS n
+		WHERE NOT EXISTS (
+			SELECT 1 FROM convoy.queue_state s
+			WHERE s.queue_name = n
+			  AND s.paused_at IS NOT NULL
+		)
+	),
+	tenants AS (
+		(SELECT tenant
+		 FROM convoy.queue_jobs
+		 WHERE status = $2
+		   AND tenant %s $3
+		 ORDER BY tenant
+		 LIMIT 1)
+		UNION ALL
+		SELECT (
+			SELECT j.tenant
+			FROM convoy.queue_jobs j
+			WHERE j.status = $2
+			  AND j.tenant > t.tenant
+			ORDER BY j.tenant
+			LIMIT 1
+		)
+		FROM tenants t
+		WHERE t.tenant IS NOT NULL
+	)
+	SELECT t.tenant, ready.n AS ready, running.n AS in_flight
+	FROM (SELECT tenant FROM tenants WHERE tenant IS NOT NULL LIMIT $4) t
+	CROSS JOIN active a
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS n
+		FROM unnest(a.names) AS qn(name)
+		CROSS JOIN LATERAL (
+			SELECT 1
+			FROM convoy.queue_jobs j
+			WHERE j.status = $2
+			  AND j.tenant = t.tenant
+			  AND j.queue_name = qn.name
+			  AND j.run_at <= NOW()
+			LIMIT $5
+		) due
+	) ready
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS n
+		FROM convoy.queue_jobs j
+		WHERE j.status = $6
+		  AND j.tenant = t.tenant
+	) running`
+
+var (
+	readyTenantsFromStartSQL = fmt.Sprintf(readyTenantsSQL, ">=")
+	readyTenantsAfterSQL     = fmt.Sprintf(readyTenantsSQL, ">")
+)
+
+// claimFairSQL claims each tenant's quota. Rows are locked per tenant and
+// queue, oldest first, so no tenant's scan reads past its own quota; within a
+// tenant the runner's queue order decides which of those rows it takes, the
+// same preference the unfair claim applies across the whole queue.
+const claimFairSQL = `
+	WITH quota AS (
+		SELECT q.tenant, q.n
+		FROM unnest($1::text[], $2::integer[]) AS q(tenant, n)
+	),
+	candidates AS MATERIALIZED (
+		SELECT c.id, c.tenant, c.queue_name, c.run_at, q.n
+		FROM quota q
+		CROSS JOIN unnest($3::text[]) AS qn(name)
+		CROSS JOIN LATERAL (
+			SELECT j.id, j.tenant, j.queue_name, j.run_at
+			FROM convoy.queue_jobs j
+			WHERE j.status = $4
+			  AND j.tenant = q.tenant
+			  AND j.queue_name = qn.name
+			  AND j.run_at <= NOW()
+			  AND NOT EXISTS (
+				SELECT 1 FROM convoy.queue_state s
+				WHERE s.queue_name = j.queue_name
+				  AND s.paused_at IS NOT NULL
+			  )
+			ORDER BY j.run_at ASC
+			LIMIT q.n
+			FOR UPDATE SKIP LOCKED
+		) c
+	),
+	claim AS (
+		SELECT id
+		FROM (
+			SELECT id, n, ROW_NUMBER() OVER (
+				PARTITION BY tenant
+				ORDER BY array_position($3::text[], queue_name), run_at ASC
+			) AS rank
+			FROM candidates
+		) ranked
+		WHERE rank <= n
+	)
+	UPDATE convoy.queue_jobs AS j
+	SET status = $5,
+	    claimed_at = NOW(),
+	    claim_id = gen_random_uuid(),
+	    updated_at = NOW()
+	FROM claim
+	WHERE j.id = claim.id
+	RETURNING j.id, j.task_name, j.queue_name, j.payload, j.headers, j.max_retry, j.retry_count, j.claim_id::text`
+
+// tenantLoad is what one tenant has due and running when a claim is planned.
+type tenantLoad struct {
+	Tenant   string `db:"tenant"`
+	Ready    int    `db:"ready"`
+	InFlight int    `db:"in_flight"`
+}
+
+// fairScheduler splits each claim's slots across tenants by smooth weighted
+// round robin. Credit carries from one claim to the next, because a claim
+// takes only a pool's worth of jobs: a tenant with twice the weight of another
+// wins twice the slots over a run of claims, not necessarily within one.
+type fairScheduler struct {
+	share queue.FairShare
+
+	mu     sync.Mutex
+	credit map[string]int
+	// cursor is the last tenant of a full scan window; empty starts over.
+	cursor string
+}
+
+func newFairScheduler(share queue.FairShare) *fairScheduler {
+	return &fairScheduler{share: share, credit: make(map[string]int)}
+}
+
+// allocate hands out up to limit slots. A tenant is offered no more than it
+// has due, nor more than its in-flight cap leaves room for. A tenant absent
+// from loads has nothing due, so its credit is dropped rather than saved up
+// into a burst when it comes back.
+func (s *fairScheduler) allocate(loads []tenantLoad, limit int) map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room := make(map[string]int, len(loads))
+	tenants := make([]string, 0, len(loads))
+	for _, l := range loads {
+		r := l.Ready
+		if s.share.MaxInFlight > 0 && s.share.MaxInFlight-l.InFlight < r {
+			r = s.share.MaxInFlight - l.InFlight
+		}
+		if r <= 0 {
+			continue
+		}
+		room[l.Tenant] = r
+		tenants = append(tenants, l.Tenant)
+	}
+	sort.Strings(tenants)
+
+	for tenant := range s.credit {
+		if _, ok := room[tenant]; !ok {
+			delete(s.credit, tenant)
+		}
+	}
+
+	// best is an index rather than a tenant because "" is a tenant: the one
+	// untenanted jobs share.
+	quotas := make(map[string]int)
+	for range limit {
+		best, total := -1, 0
+		for i, tenant := range tenants {
+			if room[tenant] == 0 {
+				continue
+			}
+			w := s.share.Weight(tenant)
+			s.credit[tenant] += w
+			total += w
+			if best < 0 || s.credit[tenant] > s.credit[tenants[best]] {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		tenant := tenants[best]
+		s.credit[tenant] -= total
+		room[tenant]--
+		quotas[tenant]++
+	}
+	return quotas
+}
+
+// scanFrom returns where the next tenant scan starts, and whether it resumes
+// after that tenant rather than at the beginning.
+func (s *fairScheduler) scanFrom() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor, s.cursor != ""
+}
+
+// scanned records a finished scan. A full window resumes after its last tenant
+// next time; a short one reached the end, so the next scan starts over.
+func (s *fairScheduler) scanned(loads []tenantLoad) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(loads) < fairShareTenantScan {
+		s.cursor = ""
+		return
+	}
+	s.cursor = loads[len(loads)-1].Tenant
+}
+
+// claimFair is Claim under fair scheduling: plan the split across tenants from
+// what each has due, then claim each tenant's share in one statement. The
+// in-flight cap is read from the rows, so it holds across every replica; two
+// replicas planning at the same moment can each fill the same headroom, so it
+// is a bound that is briefly exceeded under contention rather than a lock.
+func (q *PostgresQueue) claimFair(ctx context.Context, queueNames []string, limit int) ([]queue.ClaimedJob, error) {
+	query := readyTenantsFromStartSQL
+	cursor, resume := q.fair.scanFrom()
+	if resume {
+		query = readyTenantsAfterSQL
+	}
+
+	loads := []tenantLoad{}
+	err := q.db.SelectContext(ctx, &loads, query,
+		pq.Array(queueNames), statusPending, cursor, fairShareTenantScan, limit, statusProcessing)
+	if err != nil {
+		return nil, err
+	}
+	q.fair.scanned(loads)
+
+	quotas := q.fair.allocate(loads, limit)
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	tenants := make([]string, 0, len(quotas))
+	counts := make([]int64, 0, len(quotas))
+	for tenant, n := range quotas {
+		tenants = append(tenants, tenant)
+		counts = append(counts, int64(n))
+	}
+
+	rows, err := q.db.QueryxContext(ctx, claimFairSQL,
+		pq.StringArray(tenants), pq.Int64Array(counts), pq.Array(queueNames), statusPending, statusProcessing)
+	if err != nil {
+		return nil, err
+	}
+	return q.collectClaims(rows)
+}
+
+// TenantBacklog counts one queue's pending and processing rows per tenant. It
+// reads every such row of the queue, which is fine for an operator's page and
+// is why nothing polls it.
+func (q *PostgresQueue) TenantBacklog(ctx context.Context, queueName string) (queue.TenantBacklogReport, error) {
+	if queueName == "" {
+		return queue.TenantBacklogReport{}, queue.ErrQueueRequired
+	}
+
+	rows := []struct {
+		Tenant     string `db:"tenant"`
+		Pending    int64  `db:"pending"`
+		Processing int64  `db:"processing"`
+	}{}
+	err := q.db.SelectContext(ctx, &rows, `
+		SELECT tenant,
+		       COUNT(*) FILTER (WHERE status = $2) AS pending,
+		       COUNT(*) FILTER (WHERE status = $3) AS processing
+		FROM convoy.queue_jobs
+		WHERE queue_name = $1
+		  AND status IN ($2, $3)
+		GROUP BY tenant
+		ORDER BY pending DESC, processing DESC, tenant
+		LIMIT $4`,
+		queueName, statusPending, statusProcessing, queue.MaxTenantBacklog)
+	if err != nil {
+		return queue.TenantBacklogReport{}, err
+	}
+
+	report := queue.TenantBacklogReport{
+		Queue:     queueName,
+		Tenants:   make([]queue.TenantBacklog, 0, len(rows)),
+		FairShare: q.opts.FairShare.Enabled,
+	}
+	for _, r := range rows {
+		report.Tenants = append(report.Tenants, queue.TenantBacklog{
+			Tenant:     r.Tenant,
+			Pending:    r.Pending,
+			Processing: r.Processing,
+			Weight:     q.opts.FairShare.Weight(r.Tenant),
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/queue"
+)
+
+func TestAllocateSplitsByWeight(t *testing.T) {
+	s := newFairScheduler(queue.FairShare{Weights: map[string]int{"a": 3}})
+	loads := []tenantLoad{{Tenant: "a", Ready: 100}, {Tenant: "b", Ready: 100}}
+
+	got := map[string]int{}
+	for range 10 {
+		for tenant, n := range s.allocate(loads, 4) {
+			got[tenant] += n
+		}
+	}
+	require.Equal(t, map[string]int{"a": 30, "b": 10}, got)
+}
+
+func TestAllocateServesTheSmallTenantFirstClaim(t *testing.T) {
+	s := newFairScheduler(queue.FairShare{})
+	quotas := s.allocate([]tenantLoad{{Tenant: "flood", Ready: 10000}, {Tenant: "trickle", Ready: 1}}, 8)
+
+	require.Equal(t, 1, quotas["trickle"])
+	require.Equal(t, 7, quotas["flood"], "slots a tenant cannot use go to the others")
+}
+
+func TestAllocateHonoursInFlightCap(t *testing.T) {
+	s := newFairScheduler(queue.FairShare{MaxInFlight: 5})
+	quotas := s.allocate([]tenantLoad{
+		{Tenant: "a", Ready: 100, InFlight: 3},
+		{Tenant: "b", Ready: 100, InFlight: 5},
+	}, 10)
+
+	require.Equal(t, map[string]int{"a": 2}, quotas)
+}
+
+func TestAllocateTreatsUntenantedJobsAsATenant(t *testing.T) {
+	s := newFairScheduler(queue.FairShare{})
+	quotas := s.allocate([]tenantLoad{{Tenant: "", Ready: 10}, {Tenant: "a", Ready: 10}}, 4)
+
+	require.Equal(t, map[string]int{"": 2, "a": 2}, quotas)
+}
+
+func TestAllocateDropsCreditOfIdleTenants(t *testing.T) {
+	s := newFairScheduler(queue.FairShare{})
+	s.allocate([]tenantLoad{{Tenant: "a", Ready: 1}, {Tenant: "b", Ready: 100}}, 1)
+	s.allocate([]tenantLoad{{Tenant: "b", Ready: 100}}, 1)
+
+	_, ok := s.credit["a"]
+	require.False(t, ok, "a tenant with nothing due must not bank credit for a later burst")
+}
+
+func TestScanCursorResumesAfterAFullWindow(t *testing.T) {
+	s := newFairScheduler(queue.FairShare{})
+
+	loads := make([]tenantLoad, fairShareTenantScan)
+	for i := range loads {
+		loads[i].Tenant = fmt.Sprintf("t%04d", i)
+	}
+	s.scanned(loads)
+	cursor, resume := s.scanFrom()
+	require.True(t, resume)
+	require.Equal(t, loads[len(loads)-1].Tenant, cursor)
+
+	s.scanned(loads[:1])
+	_, resume = s.scanFrom()
+	require.False(t, resume)
+}
+
+func setupFairQueue(t *testing.T, share queue.FairShare) *PostgresQueue {
+	t.Helper()
+	base := setupQueue(t)
+	opts := base.opts
+	opts.FairShare = share
+	q, err := NewQueue(opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = q.Close() })
+	return q
+}
+
+func TestFairClaimInterleavesTenants(t *testing.T) {
+	q := setupFairQueue(t, queue.FairShare{Enabled: true})
+	ctx := context.Background()
+
+	for range 20 {
+		require.NoError(t, q.Write(ctx, convoy.EventProcessor, convoy.EventQueue, &queue.Job{
+			ID: ulid.Make().String(), Payload: []byte("flood"), ProjectID: "flood",
+		}))
+	}
+	require.NoError(t, q.Write(ctx, convoy.EventProcessor, convoy.EventQueue, &queue.Job{
+		ID: ulid.Make().String(), Payload: []byte("trickle"), ProjectID: "trickle",
+	}))
+
+	jobs, err := q.Claim(ctx, []string{string(convoy.EventQueue)}, 4)
+	require.NoError(t, err)
+	require.Len(t, jobs, 4)
+
+	byTenant := map[string]int{}
+	for _, j := range jobs {
+		byTenant[queue.TenantOf(j.Headers)]++
+	}
+	require.Equal(t, map[string]int{"flood": 3, "trickle": 1}, byTenant)
+}
+
+func TestFairClaimHonoursInFlightCap(t *testing.T) {
+	q := setupFairQueue(t, queue.FairShare{Enabled: true, MaxInFlight: 2})
+	ctx := context.Background()
+
+	for range 5 {
+		require.NoError(t, q.Write(ctx, convoy.EventProcessor, convoy.EventQueue, &queue.Job{
+			ID: ulid.Make().String(), Payload: []byte("x"), ProjectID: "project-1",
+		}))
+	}
+
+	jobs, err := q.Claim(ctx, []string{string(convoy.EventQueue)}, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	jobs, err = q.Claim(ctx, []string{string(convoy.EventQueue)}, 10)
+	require.NoError(t, err)
+	require.Empty(t, jobs, "the tenant already holds its cap")
+}
+
+func TestTenantBacklog(t *testing.T) {
+	q := setupFairQueue(t, queue.FairShare{Enabled: true, Weights: map[string]int{"big": 4}})
+	ctx := context.Background()
+
+	for _, project := range []string{"big", "big", "small", ""} {
+		require.NoError(t, q.Write(ctx, convoy.EventProcessor, convoy.EventQueue, &queue.Job{
+			ID: ulid.Make().String(), Payload: []byte("x"), ProjectID: project,
+		}))
+	}
+
+	report, err := q.TenantBacklog(ctx, string(convoy.EventQueue))
+	require.NoError(t, err)
+	require.True(t, report.FairShare)
+	require.False(t, report.Sampled)
+	require.Len(t, report.Tenants, 3)
+	require.Equal(t, queue.TenantBacklog{Tenant: "big", Pending: 2, Weight: 4}, report.Tenants[0])
+}
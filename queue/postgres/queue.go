@@ -16,6 +16,7 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/internal/pkg/queue/tracectx"
+	log "github.com/frain-dev/convoy/pkg/logger"
 	"github.com/frain-dev/convoy/queue"
 )
 
@@ -80,11 +81,12 @@ var (
 const writeJobSQL = `
 	INSERT INTO convoy.queue_jobs (
 		id, task_name, queue_name, payload, headers,
-		max_retry, retry_count, status, run_at, claimed_at, last_error, created_at, updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6, 0, $7, NOW() + make_interval(secs => $8), NULL, NULL, NOW(), NOW())
+		max_retry, retry_count, status, run_at, claimed_at, last_error, created_at, updated_at, tenant
+	) VALUES ($1, $2, $3, $4, $5, $6, 0, $7, NOW() + make_interval(secs => $8), NULL, NULL, NOW(), NOW(), $15)
 	ON CONFLICT (id) DO UPDATE SET
 		task_name = EXCLUDED.task_name,
 		queue_name = EXCLUDED.queue_name,
+		tenant = EXCLUDED.tenant,
 		payload = EXCLUDED.payload,
 		headers = EXCLUDED.headers,
 		max_retry = EXCLUDED.max_retry,
@@ -113,17 +115,18 @@ const writeJobSQL = `
 const writeJobsSQL = `
 	INSERT INTO convoy.queue_jobs (
 		id, task_name, queue_name, payload, headers,
-		max_retry, retry_count, status, run_at, claimed_at, last_error, created_at, updated_at
+		max_retry, retry_count, status, run_at, claimed_at, last_error, created_at, updated_at, tenant
 	)
 	SELECT j.id, j.task_name, j.queue_name, j.payload, j.headers,
-	       j.max_retry, 0, $8, NOW() + make_interval(secs => j.delay), NULL, NULL, NOW(), NOW()
+	       j.max_retry, 0, $8, NOW() + make_interval(secs => j.delay), NULL, NULL, NOW(), NOW(), j.tenant
 	FROM UNNEST(
 		$1::text[], $2::text[], $3::text[], $4::bytea[],
-		$5::jsonb[], $6::int[], $7::double precision[]
-	) AS j(id, task_name, queue_name, payload, headers, max_retry, delay)
+		$5::jsonb[], $6::int[], $7::double precision[], $15::text[]
+	) AS j(id, task_name, queue_name, payload, headers, max_retry, delay, tenant)
 	ON CONFLICT (id) DO UPDATE SET
 		task_name = EXCLUDED.task_name,
 		queue_name = EXCLUDED.queue_name,
+		tenant = EXCLUDED.tenant,
 		payload = EXCLUDED.payload,
 		headers = EXCLUDED.headers,
 		max_retry = EXCLUDED.max_retry,
@@ -149,6 +152,7 @@ const writeJobsSQL = `
 // PostgresQueue implements queue.Queuer with convoy.queue_jobs as the broker.
 type PostgresQueue struct {
 	db             *sqlx.DB
+	logger         log.Logger
 	opts           queue.QueueOptions
 	stuckTimeout   time.Duration
 	batchSize      int
@@ -179,12 +183,17 @@ type PostgresQueue struct {
 	// CAS key.
 	leaseMu sync.Mutex
 	leases  map[string]string
+
+	// fair is nil unless fair scheduling is on, in which case Claim splits
+	// each claim across tenants.
+	fair *fairScheduler
 }
 
 type writeRequest struct {
 	id        string
 	taskName  string
 	queueName string
+	tenant    string
 	payload   []byte
 	headers   []byte
 	maxRetry  int
@@ -227,6 +236,7 @@ func NewQueue(opts queue.QueueOptions) (*PostgresQueue, error) {
 	t := withDefaults(opts.PostgresTuning)
 	q := &PostgresQueue{
 		db:             opts.DB,
+		logger:         opts.LoggerOrDefault(),
 		opts:           opts,
 		stuckTimeout:   t.LeaseTimeout,
 		batchSize:      t.BatchSize,
@@ -239,6 +249,9 @@ func NewQueue(opts queue.QueueOptions) (*PostgresQueue, error) {
 		done:           make(chan struct{}),
 		leases:         make(map[string]string),
 	}
+	if opts.FairShare.Enabled {
+		q.fair = newFairScheduler(opts.FairShare)
+	}
 	q.batcherWg.Add(t.WriteConcurrency + 1)
 	for i := 0; i < t.WriteConcurrency; i++ {
 		go q.runWriteBatcher()
@@ -331,6 +344,7 @@ func (q *PostgresQueue) write(ctx context.Context, taskName convoy.TaskName, que
 		job.ID = ulid.Make().String()
 	}
 	tracectx.InjectIntoJob(ctx, job)
+	queue.StampTenant(ctx, q.opts.TenantResolver, job)
 
 	headers := job.Headers
 	if headers == nil {
@@ -360,6 +374,7 @@ func (q *PostgresQueue) write(ctx context.Context, taskName convoy.TaskName, que
 		id:        job.ID,
 		taskName:  string(taskName),
 		queueName: string(queueName),
+		tenant:    queue.TenantOf(headers),
 		payload:   payload,
 		headers:   headerBytes,
 		maxRetry:  maxRetry,
@@ -505,6 +520,7 @@ func (q *PostgresQueue) writeBatch(batch []writeRequest) []error {
 	headers := make([]string, 0, n)
 	maxRetries := make([]int64, 0, n)
 	delays := make([]float64, 0, n)
+	tenants := make([]string, 0, n)
 	for _, i := range order {
 		ids = append(ids, batch[i].id)
 		taskNames = append(taskNames, batch[i].taskName)
@@ -513,6 +529,7 @@ func (q *PostgresQueue) writeBatch(batch []writeRequest) []error {
 		headers = append(headers, string(batch[i].headers))
 		maxRetries = append(maxRetries, int64(batch[i].maxRetry))
 		delays = append(delays, batch[i].delay)
+		tenants = append(tenants, batch[i].tenant)
 	}
 
 	rows, err := q.db.QueryContext(ctx, writeJobsSQL,
@@ -521,6 +538,7 @@ func (q *PostgresQueue) writeBatch(batch []writeRequest) []error {
 		pq.Float64Array(delays), statusPending, statusProcessing,
 		cronJobPrefix+"%", statusArchived, statusCompleted,
 		string(convoy.EventQueue), string(convoy.RetryEventQueue),
+		pq.StringArray(tenants),
 	)
 	if err != nil {
 		return fillErrors(results, err)
@@ -580,6 +598,7 @@ func (q *PostgresQueue) execWrite(ctx context.Context, db sqlx.ExecerContext, re
 		req.maxRetry, statusPending, req.delay, statusProcessing,
 		cronJobPrefix+"%", statusArchived, statusCompleted,
 		string(convoy.EventQueue), string(convoy.RetryEventQueue),
+		req.tenant,
 	)
 	if err != nil {
 		return err
@@ -600,6 +619,9 @@ func (q *PostgresQueue) Claim(ctx context.Context, queueNames []string, limit in
 	if limit <= 0 || len(queueNames) == 0 {
 		return nil, nil
 	}
+	if q.fair != nil {
+		return q.claimFair(ctx, queueNames, limit)
+	}
 
 	rows, err := q.db.QueryxContext(ctx, `
 		WITH candidates AS MATERIALIZED (
@@ -647,6 +669,12 @@ func (q *PostgresQueue) Claim(ctx context.Context, queueNames []string, limit in
 	if err != nil {
 		return nil, err
 	}
+	return q.collectClaims(rows)
+}
+
+// collectClaims reads the rows a claim statement returned and records their
+// leases for Heartbeat.
+func (q *PostgresQueue) collectClaims(rows *sqlx.Rows) ([]queue.ClaimedJob, error) {
 	defer rows.Close()
 
 	var jobs []queue.ClaimedJob
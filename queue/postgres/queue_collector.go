@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/queue"
 )
 
 const namespace = "convoy"
@@ -31,6 +33,7 @@ func (q *PostgresQueue) Describe(ch chan<- *prometheus.Desc) {
 	}
 	ch <- eventQueueTotalDesc
 	ch <- eventQueueMatchSubscriptionsTotalDesc
+	queue.DescribeStats(ch)
 }
 
 func (q *PostgresQueue) Collect(ch chan<- prometheus.Metric) {
@@ -48,6 +51,7 @@ func (q *PostgresQueue) Collect(ch chan<- prometheus.Metric) {
 
 	counts, err := q.Counts(ctx)
 	if err != nil {
+		q.logger.Error(fmt.Sprintf("an error occurred while fetching queue counts: %+v", err))
 		ch <- prometheus.MustNewConstMetric(eventQueueTotalDesc, prometheus.GaugeValue, 0, "scheduled")
 		ch <- prometheus.MustNewConstMetric(eventQueueMatchSubscriptionsTotalDesc, prometheus.GaugeValue, 0, "scheduled")
 		return
@@ -70,6 +74,13 @@ func (q *PostgresQueue) Collect(ch chan<- prometheus.Metric) {
 		float64(scheduled(byName[string(convoy.EventWorkflowQueue)])),
 		"scheduled",
 	)
+
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		q.logger.Error(fmt.Sprintf("an error occurred while fetching queue stats: %+v", err))
+		return
+	}
+	queue.CollectStats(ch, stats)
 }
 
 func scheduled(c QueueCount) int64 {
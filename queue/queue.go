@@ -13,6 +13,7 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/internal/pkg/rdb"
+	log "github.com/frain-dev/convoy/pkg/logger"
 )
 
 const (
@@ -132,6 +133,12 @@ type Job struct {
 	// rarely set it directly. Empty for untraced enqueues; the consumer
 	// middleware starts a root span in that case.
 	Headers map[string]string `json:"-"`
+
+	// ProjectID, when set, is the project the job's work belongs to. The
+	// driver stamps the tenant derived from it into Headers, which is what
+	// fair scheduling and the per-tenant backlog read. Jobs with no project,
+	// like scheduler ticks, share one tenant.
+	ProjectID string `json:"-"`
 }
 
 // ClaimedJob is a broker-neutral job claimed by a queue consumer.
@@ -160,6 +167,22 @@ type QueueOptions struct {
 	// PostgresConnString enables LISTEN/NOTIFY consumer wakeups when set.
 	// Redis queues ignore it.
 	PostgresConnString string
+	// FairShare schedules work across tenants rather than strictly by age.
+	FairShare FairShare
+	// TenantResolver maps a job's project onto its tenant. Nil schedules each
+	// project as its own tenant.
+	TenantResolver TenantResolver
+	// Logger is the process logger, so queue errors follow the configured
+	// level and sink. Nil falls back to a default info logger.
+	Logger log.Logger
+}
+
+// LoggerOrDefault returns Logger, or an info logger when none was given.
+func (o QueueOptions) LoggerOrDefault() log.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return log.New("convoy", log.LevelInfo)
 }
 
 // PostgresTuning carries the postgres queue's write-path settings in
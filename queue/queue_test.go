@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/frain-dev/convoy"
+	log "github.com/frain-dev/convoy/pkg/logger"
 )
 
 func TestCronJobID(t *testing.T) {
@@ -95,3 +96,10 @@ func countQueue(names []string, target string) int {
 	}
 	return count
 }
+
+func TestQueueOptionsLoggerOrDefault(t *testing.T) {
+	require.NotNil(t, QueueOptions{}.LoggerOrDefault())
+
+	logger := log.New("queue-test", log.LevelError)
+	require.Same(t, logger, QueueOptions{Logger: logger}.LoggerOrDefault())
+}
@@ -57,7 +57,7 @@ func NewQueue(opts queue.QueueOptions) *RedisQueue {
 		client:    client,
 		opts:      opts,
 		inspector: inspector,
-		logger:    log.New("convoy", log.LevelInfo),
+		logger:    opts.LoggerOrDefault(),
 	}
 }
 
@@ -70,6 +70,7 @@ func (q *RedisQueue) Write(ctx context.Context, taskName convoy.TaskName, queueN
 	// span on the consumer side becomes a child of the producer's. No-op
 	// when ctx has no active span, so untraced callers stay zero-cost.
 	tracectx.InjectIntoJob(ctx, job)
+	queue.StampTenant(ctx, q.opts.TenantResolver, job)
 	opts := []asynq.Option{asynq.Queue(s), asynq.TaskID(job.ID), asynq.ProcessIn(job.Delay)}
 	if job.MaxRetry != nil {
 		opts = append(opts, asynq.MaxRetry(*job.MaxRetry))
@@ -106,6 +107,7 @@ func (q *RedisQueue) WriteWithoutTimeout(ctx context.Context, taskName convoy.Ta
 	}
 
 	tracectx.InjectIntoJob(ctx, job)
+	queue.StampTenant(ctx, q.opts.TenantResolver, job)
 	opts := []asynq.Option{asynq.Queue(s), asynq.TaskID(job.ID), asynq.Timeout(0), asynq.ProcessIn(job.Delay)}
 	if job.MaxRetry != nil {
 		opts = append(opts, asynq.MaxRetry(*job.MaxRetry))
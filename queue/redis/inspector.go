@@ -262,6 +262,79 @@ func (q *RedisQueue) UnpauseQueue(_ context.Context, queueName string) error {
 	return pauseError(q.inspector.UnpauseQueue(queueName))
 }
 
+// redisTenantSample is how many pending tasks TenantBacklog reads. Redis keeps
+// a queue as a list with no index on headers, so counting a tenant's share of
+// the whole queue means reading every task; the head is what the workers take
+// next, which is the part an operator chasing a stuck tenant is asking about.
+const redisTenantSample = 1000
+
+// TenantBacklog tallies the head of a queue and its running tasks by tenant.
+func (q *RedisQueue) TenantBacklog(_ context.Context, queueName string) (queue.TenantBacklogReport, error) {
+	if queueName == "" {
+		return queue.TenantBacklogReport{}, queue.ErrQueueRequired
+	}
+
+	pending, err := q.inspector.ListPendingTasks(queueName, asynq.PageSize(redisTenantSample))
+	if err != nil {
+		if errors.Is(err, asynq.ErrQueueNotFound) {
+			return queue.TenantBacklogReport{}, queue.ErrQueueNotFound
+		}
+		return queue.TenantBacklogReport{}, err
+	}
+	active, err := q.inspector.ListActiveTasks(queueName, asynq.PageSize(redisTenantSample))
+	if err != nil {
+		return queue.TenantBacklogReport{}, err
+	}
+
+	byTenant := map[string]*queue.TenantBacklog{}
+	tally := func(info *asynq.TaskInfo) *queue.TenantBacklog {
+		tenant := queue.TenantOf(info.Headers)
+		b, ok := byTenant[tenant]
+		if !ok {
+			b = &queue.TenantBacklog{Tenant: tenant, Weight: q.opts.FairShare.Weight(tenant)}
+			byTenant[tenant] = b
+		}
+		return b
+	}
+	for _, info := range pending {
+		tally(info).Pending++
+	}
+	for _, info := range active {
+		tally(info).Processing++
+	}
+
+	report := queue.TenantBacklogReport{
+		Queue:      queueName,
+		Tenants:    make([]queue.TenantBacklog, 0, len(byTenant)),
+		Sampled:    true,
+		SampleSize: redisTenantSample,
+		FairShare:  q.opts.FairShare.Enabled,
+	}
+	for _, b := range byTenant {
+		report.Tenants = append(report.Tenants, *b)
+	}
+	sortTenantBacklog(report.Tenants)
+	if len(report.Tenants) > queue.MaxTenantBacklog {
+		report.Tenants = report.Tenants[:queue.MaxTenantBacklog]
+	}
+	return report, nil
+}
+
+// sortTenantBacklog orders busiest first, the same order the postgres
+// provider's query returns.
+func sortTenantBacklog(tenants []queue.TenantBacklog) {
+	sort.Slice(tenants, func(i, j int) bool {
+		a, b := tenants[i], tenants[j]
+		if a.Pending != b.Pending {
+			return a.Pending > b.Pending
+		}
+		if a.Processing != b.Processing {
+			return a.Processing > b.Processing
+		}
+		return a.Tenant < b.Tenant
+	})
+}
+
 // pauseError treats "already in that state" as success. The operator asked for
 // an end state, not a transition, and reporting a queue that is already paused
 // as an error would make a second click look like a failure. asynq expresses
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/queue"
 )
 
 // Namespace used in fully qualified metrics names.
@@ -33,6 +35,7 @@ func (q *RedisQueue) Describe(ch chan<- *prometheus.Desc) {
 	}
 	ch <- eventQueueTotalDesc
 	ch <- eventQueueMatchSubscriptionsTotalDesc
+	queue.DescribeStats(ch)
 }
 
 func (q *RedisQueue) Collect(ch chan<- prometheus.Metric) {
@@ -93,4 +96,11 @@ func (q *RedisQueue) Collect(ch chan<- prometheus.Metric) {
 			"scheduled",
 		)
 	}
+
+	stats, err := q.Stats(context.Background())
+	if err != nil {
+		q.logger.Error(fmt.Sprintf("an error occurred while fetching queue stats: %+v", err))
+		return
+	}
+	queue.CollectStats(ch, stats)
 }
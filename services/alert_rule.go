@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/notifications"
+	"github.com/frain-dev/convoy/pkg/clock"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+)
+
+const (
+	// The evaluator runs once a minute, so shorter windows cannot be honoured.
+	minAlertRuleWindow     = 60
+	maxAlertRuleWindow     = 24 * 60 * 60
+	defaultAlertRuleWindow = 5 * 60
+
+	defaultAlertRuleCooldown = 30 * 60
+	maxAlertRuleCooldown     = 7 * 24 * 60 * 60
+
+	maxConsecutiveFailuresThreshold = 1000
+
+	alertRuleBatchSize = 500
+)
+
+type CreateAlertRuleService struct {
+	Repo         datastore.AlertRuleRepository
+	EndpointRepo datastore.EndpointRepository
+	SourceRepo   datastore.SourceRepository
+	ProjectID    string
+	Rule         *datastore.AlertRule
+	Logger       log.Logger
+}
+
+func (s *CreateAlertRuleService) Run(ctx context.Context) (*datastore.AlertRule, error) {
+	rule := s.Rule
+	rule.ProjectID = s.ProjectID
+	if err := validateAlertRule(rule); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if err := checkAlertRuleScope(ctx, s.EndpointRepo, s.SourceRepo, rule); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.UID = ulid.Make().String()
+	rule.State = datastore.AlertRuleOK
+	rule.CreatedAt, rule.UpdatedAt = now, now
+
+	err := s.Repo.CreateAlertRule(ctx, rule)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateAlertRuleName) {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+		s.Logger.ErrorContext(ctx, "failed to create alert rule", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to create alert rule", Err: err}
+	}
+
+	return rule, nil
+}
+
+// UpdateAlertRuleService replaces a rule's configuration. The evaluation state
+// is kept, so a firing rule still sends its resolved notification.
+type UpdateAlertRuleService struct {
+	Repo         datastore.AlertRuleRepository
+	EndpointRepo datastore.EndpointRepository
+	SourceRepo   datastore.SourceRepository
+	ProjectID    string
+	AlertRuleID  string
+	Update       *datastore.AlertRule
+	Logger       log.Logger
+}
+
+func (s *UpdateAlertRuleService) Run(ctx context.Context) (*datastore.AlertRule, error) {
+	rule, err := s.Repo.FindAlertRuleByID(ctx, s.ProjectID, s.AlertRuleID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find alert rule", Err: err}
+	}
+
+	rule.Name = s.Update.Name
+	rule.Type = s.Update.Type
+	rule.EndpointID = s.Update.EndpointID
+	rule.SourceID = s.Update.SourceID
+	rule.Threshold = s.Update.Threshold
+	rule.WindowSeconds = s.Update.WindowSeconds
+	rule.CooldownSeconds = s.Update.CooldownSeconds
+	channels := s.Update.Channels
+	channels.RestoreRedacted(&rule.Channels.NotificationChannels)
+	rule.Channels = channels
+	rule.Enabled = s.Update.Enabled
+	if err = validateAlertRule(rule); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if err = checkAlertRuleScope(ctx, s.EndpointRepo, s.SourceRepo, rule); err != nil {
+		return nil, err
+	}
+
+	err = s.Repo.UpdateAlertRule(ctx, rule)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateAlertRuleName) {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+		s.Logger.ErrorContext(ctx, "failed to update alert rule", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to update alert rule", Err: err}
+	}
+
+	rule.UpdatedAt = time.Now()
+	return rule, nil
+}
+
+// validateAlertRule fills in defaults for unset fields and rejects values the
+// evaluator cannot honour.
+func validateAlertRule(rule *datastore.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return errors.New("please provide a name")
+	}
+
+	if !rule.Type.IsValid() {
+		return fmt.Errorf("unsupported alert rule type - %s", rule.Type)
+	}
+
+	switch rule.Type {
+	case datastore.SourceSilenceAlertRule:
+		if rule.SourceID == "" {
+			return errors.New("source_id is required for source_silence rules")
+		}
+		if rule.EndpointID != "" {
+			return errors.New("endpoint_id cannot be used with source_silence rules")
+		}
+	default:
+		if rule.SourceID != "" {
+			return errors.New("source_id can only be used with source_silence rules")
+		}
+	}
+
+	switch rule.Type {
+	case datastore.FailureRateAlertRule:
+		if rule.Threshold <= 0 || rule.Threshold > 100 {
+			return errors.New("threshold must be a percentage between 0 and 100")
+		}
+	case datastore.ConsecutiveFailuresAlertRule:
+		if rule.Threshold < 1 || rule.Threshold > maxConsecutiveFailuresThreshold || rule.Threshold != math.Trunc(rule.Threshold) {
+			return fmt.Errorf("threshold must be a whole number between 1 and %d", maxConsecutiveFailuresThreshold)
+		}
+	case datastore.LatencyP95AlertRule, datastore.RetryBacklogAlertRule:
+		if rule.Threshold <= 0 {
+			return errors.New("threshold must be greater than 0")
+		}
+	}
+
+	if rule.WindowSeconds == 0 {
+		rule.WindowSeconds = defaultAlertRuleWindow
+	}
+	if rule.WindowSeconds < minAlertRuleWindow || rule.WindowSeconds > maxAlertRuleWindow {
+		return fmt.Errorf("window must be between %d and %d seconds", minAlertRuleWindow, maxAlertRuleWindow)
+	}
+
+	if rule.CooldownSeconds == 0 {
+		rule.CooldownSeconds = defaultAlertRuleCooldown
+	}
+	if rule.CooldownSeconds < minAlertRuleWindow || rule.CooldownSeconds > maxAlertRuleCooldown {
+		return fmt.Errorf("cooldown must be between %d and %d seconds", minAlertRuleWindow, maxAlertRuleCooldown)
+	}
+
+	c := &rule.Channels
+	c.Email = strings.TrimSpace(c.Email)
+	if c.Email == "" && c.SlackWebhookURL == "" && c.TeamsWebhookURL == "" && c.NotificationChannels.IsEmpty() {
+		return errors.New("please provide at least one notification channel")
+	}
+
+	// The webhook urls are bearer secrets, so the parse error, which quotes
+	// the url, is dropped.
+	if !util.IsStringEmpty(c.SlackWebhookURL) {
+		if _, err := util.ValidateOutboundURL(c.SlackWebhookURL, false); err != nil {
+			return errors.New("invalid slack webhook url")
+		}
+	}
+	if !util.IsStringEmpty(c.TeamsWebhookURL) {
+		if _, err := util.ValidateOutboundURL(c.TeamsWebhookURL, false); err != nil {
+			return errors.New("invalid teams webhook url")
+		}
+	}
+
+	return validateNotificationChannels(&c.NotificationChannels)
+}
+
+// checkAlertRuleScope makes sure the endpoint or source a rule watches belongs
+// to the rule's project.
+func checkAlertRuleScope(ctx context.Context, endpointRepo datastore.EndpointRepository, sourceRepo datastore.SourceRepository, rule *datastore.AlertRule) error {
+	if rule.EndpointID != "" {
+		if _, err := endpointRepo.FindEndpointByID(ctx, rule.EndpointID, rule.ProjectID); err != nil {
+			return &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+		}
+	}
+
+	if rule.SourceID != "" {
+		if _, err := sourceRepo.FindSourceByID(ctx, rule.ProjectID, rule.SourceID); err != nil {
+			return &ServiceError{ErrMsg: "failed to find source", Err: err}
+		}
+	}
+
+	return nil
+}
+
+// AlertRuleEvaluator checks every enabled alert rule against the project's
+// recent traffic. A rule that starts firing notifies its channels, and keeps
+// reminding them once per cooldown while it stays firing; a rule that recovers
+// sends a resolved notification. A firing notification always comes at least
+// a cooldown after the rule's previous notification, so a flapping condition
+// does not flood the channels, and a resolve is only announced when the firing
+// was.
+type AlertRuleEvaluator struct {
+	Repo        datastore.AlertRuleRepository
+	ProjectRepo datastore.ProjectRepository
+	Queue       queue.Queuer
+	Clock       clock.Clock
+	Logger      log.Logger
+}
+
+func (e *AlertRuleEvaluator) Run(ctx context.Context) error {
+	projects := make(map[string]*datastore.Project)
+
+	cursor := ""
+	for {
+		rules, err := e.Repo.LoadEnabledAlertRules(ctx, cursor, alertRuleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range rules {
+			e.evaluate(ctx, &rules[i], projects)
+		}
+
+		if len(rules) < alertRuleBatchSize {
+			return nil
+		}
+		cursor = rules[len(rules)-1].UID
+	}
+}
+
+func (e *AlertRuleEvaluator) evaluate(ctx context.Context, rule *datastore.AlertRule, projects map[string]*datastore.Project) {
+	now := e.Clock.Now()
+
+	value, breached, err := e.measure(ctx, rule, now)
+	if err != nil {
+		e.Logger.ErrorContext(ctx, "failed to evaluate alert rule", "alert_rule_id", rule.UID, "error", err)
+		return
+	}
+
+	rule.LastValue = value
+	rule.LastEvaluatedAt = &now
+
+	cooledDown := rule.LastNotifiedAt == nil ||
+		now.Sub(*rule.LastNotifiedAt) >= time.Duration(rule.CooldownSeconds)*time.Second
+
+	switch {
+	case breached:
+		if rule.State != datastore.AlertRuleFiring {
+			rule.State = datastore.AlertRuleFiring
+			rule.LastTriggeredAt = &now
+		}
+		if cooledDown && e.notify(ctx, rule, true, projects) {
+			rule.LastNotifiedAt = &now
+		}
+	case rule.State == datastore.AlertRuleFiring:
+		notified := rule.LastNotifiedAt != nil && rule.LastTriggeredAt != nil &&
+			!rule.LastNotifiedAt.Before(*rule.LastTriggeredAt)
+
+		rule.State = datastore.AlertRuleOK
+		rule.LastResolvedAt = &now
+		if notified && e.notify(ctx, rule, false, projects) {
+			rule.LastNotifiedAt = &now
+		}
+	}
+
+	if err = e.Repo.UpdateAlertRuleState(ctx, rule); err != nil {
+		e.Logger.ErrorContext(ctx, "failed to update alert rule state", "alert_rule_id", rule.UID, "error", err)
+	}
+}
+
+// measure reads the metric a rule watches and reports whether it is past the
+// rule's threshold.
+func (e *AlertRuleEvaluator) measure(ctx context.Context, rule *datastore.AlertRule, now time.Time) (float64, bool, error) {
+	window := time.Duration(rule.WindowSeconds) * time.Second
+
+	switch rule.Type {
+	case datastore.FailureRateAlertRule:
+		counts, err := e.Repo.CountDeliveryAttempts(ctx, rule.ProjectID, rule.EndpointID, now.Add(-window))
+		if err != nil {
+			return 0, false, err
+		}
+		if counts.Attempts == 0 {
+			return 0, false, nil
+		}
+		rate := float64(counts.Failures) / float64(counts.Attempts) * 100
+		return rate, rate > rule.Threshold, nil
+
+	case datastore.ConsecutiveFailuresAlertRule:
+		limit := int(rule.Threshold)
+		statuses, err := e.Repo.LoadLatestAttemptStatuses(ctx, rule.ProjectID, rule.EndpointID, limit)
+		if err != nil {
+			return 0, false, err
+		}
+		streak := 0
+		for _, ok := range statuses {
+			if ok {
+				break
+			}
+			streak++
+		}
+		return float64(streak), streak >= limit, nil
+
+	case datastore.LatencyP95AlertRule:
+		p95, err := e.Repo.DeliveryLatencyP95(ctx, rule.ProjectID, rule.EndpointID, now.Add(-window))
+		if err != nil {
+			return 0, false, err
+		}
+		ms := p95 * 1000
+		return ms, ms > rule.Threshold, nil
+
+	case datastore.RetryBacklogAlertRule:
+		retrying, err := e.Repo.CountRetryingDeliveries(ctx, rule.ProjectID, rule.EndpointID)
+		if err != nil {
+			return 0, false, err
+		}
+		return float64(retrying), float64(retrying) > rule.Threshold, nil
+
+	case datastore.SourceSilenceAlertRule:
+		latest, err := e.Repo.FindLatestSourceEventTime(ctx, rule.ProjectID, rule.SourceID)
+		if err != nil {
+			return 0, false, err
+		}
+		// Silence is counted from the rule's creation at the earliest, so a
+		// new rule on a quiet source waits out a full window first.
+		since := rule.CreatedAt
+		if latest != nil && latest.After(since) {
+			since = *latest
+		}
+		silent := now.Sub(since)
+		return silent.Seconds(), silent >= window, nil
+
+	default:
+		return 0, false, fmt.Errorf("unsupported alert rule type - %s", rule.Type)
+	}
+}
+
+func (e *AlertRuleEvaluator) notify(ctx context.Context, rule *datastore.AlertRule, firing bool, projects map[string]*datastore.Project) bool {
+	project, ok := projects[rule.ProjectID]
+	if !ok {
+		var err error
+		project, err = e.ProjectRepo.FetchProjectByID(ctx, rule.ProjectID)
+		if err != nil {
+			e.Logger.ErrorContext(ctx, "failed to fetch project for alert rule", "alert_rule_id", rule.UID, "error", err)
+			return false
+		}
+		projects[rule.ProjectID] = project
+	}
+
+	return notifications.SendAlertRuleNotification(ctx, rule, project, firing,
+		describeAlertRule(rule), describeAlertRuleValue(rule), e.Queue, e.Logger)
+}
+
+// describeAlertRule words a rule's condition for its notifications.
+func describeAlertRule(rule *datastore.AlertRule) string {
+	window := formatAlertRuleWindow(rule.WindowSeconds)
+
+	scope := "deliveries"
+	if rule.EndpointID != "" {
+		scope = fmt.Sprintf("deliveries to endpoint %s", rule.EndpointID)
+	}
+
+	switch rule.Type {
+	case datastore.FailureRateAlertRule:
+		return fmt.Sprintf("failure rate of %s above %.2f%% over %s", scope, rule.Threshold, window)
+	case datastore.ConsecutiveFailuresAlertRule:
+		return fmt.Sprintf("%d consecutive failed %s", int(rule.Threshold), scope)
+	case datastore.LatencyP95AlertRule:
+		return fmt.Sprintf("p95 latency of %s above %.0fms over %s", scope, rule.Threshold, window)
+	case datastore.RetryBacklogAlertRule:
+		return fmt.Sprintf("more than %.0f %s waiting to be retried", rule.Threshold, scope)
+	case datastore.SourceSilenceAlertRule:
+		return fmt.Sprintf("no events received from source %s for %s", rule.SourceID, window)
+	default:
+		return string(rule.Type)
+	}
+}
+
+func describeAlertRuleValue(rule *datastore.AlertRule) string {
+	switch rule.Type {
+	case datastore.FailureRateAlertRule:
+		return fmt.Sprintf("%.2f%%", rule.LastValue)
+	case datastore.ConsecutiveFailuresAlertRule:
+		return fmt.Sprintf("%.0f failed attempts in a row", rule.LastValue)
+	case datastore.LatencyP95AlertRule:
+		return fmt.Sprintf("%.0fms", rule.LastValue)
+	case datastore.RetryBacklogAlertRule:
+		return fmt.Sprintf("%.0f deliveries", rule.LastValue)
+	case datastore.SourceSilenceAlertRule:
+		return fmt.Sprintf("last event %s ago", formatAlertRuleWindow(uint64(rule.LastValue)))
+	default:
+		return fmt.Sprintf("%.2f", rule.LastValue)
+	}
+}
+
+func formatAlertRuleWindow(seconds uint64) string {
+	switch {
+	case seconds >= 3600 && seconds%3600 == 0:
+		return fmt.Sprintf("%dh", seconds/3600)
+	case seconds >= 60:
+		return fmt.Sprintf("%dm", seconds/60)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
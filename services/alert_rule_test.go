@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/clock"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestValidateAlertRule(t *testing.T) {
+	channels := datastore.AlertRuleChannels{Email: "oncall@example.com"}
+
+	tests := []struct {
+		name    string
+		rule    datastore.AlertRule
+		wantErr string
+	}{
+		{
+			name: "fills in defaults",
+			rule: datastore.AlertRule{Name: " failures ", Type: datastore.FailureRateAlertRule, Threshold: 5, Channels: channels},
+		},
+		{
+			name:    "requires a name",
+			rule:    datastore.AlertRule{Type: datastore.FailureRateAlertRule, Threshold: 5, Channels: channels},
+			wantErr: "please provide a name",
+		},
+		{
+			name:    "rejects an unknown type",
+			rule:    datastore.AlertRule{Name: "r", Type: "cpu", Channels: channels},
+			wantErr: "unsupported alert rule type - cpu",
+		},
+		{
+			name:    "rejects a failure rate above 100",
+			rule:    datastore.AlertRule{Name: "r", Type: datastore.FailureRateAlertRule, Threshold: 101, Channels: channels},
+			wantErr: "threshold must be a percentage between 0 and 100",
+		},
+		{
+			name:    "rejects a fractional failure count",
+			rule:    datastore.AlertRule{Name: "r", Type: datastore.ConsecutiveFailuresAlertRule, Threshold: 2.5, Channels: channels},
+			wantErr: "threshold must be a whole number between 1 and 1000",
+		},
+		{
+			name:    "source silence needs a source",
+			rule:    datastore.AlertRule{Name: "r", Type: datastore.SourceSilenceAlertRule, Channels: channels},
+			wantErr: "source_id is required for source_silence rules",
+		},
+		{
+			name:    "only source silence takes a source",
+			rule:    datastore.AlertRule{Name: "r", Type: datastore.RetryBacklogAlertRule, Threshold: 10, SourceID: "src", Channels: channels},
+			wantErr: "source_id can only be used with source_silence rules",
+		},
+		{
+			name:    "rejects a window the evaluator cannot honour",
+			rule:    datastore.AlertRule{Name: "r", Type: datastore.LatencyP95AlertRule, Threshold: 500, WindowSeconds: 30, Channels: channels},
+			wantErr: "window must be between 60 and 86400 seconds",
+		},
+		{
+			name:    "requires a channel",
+			rule:    datastore.AlertRule{Name: "r", Type: datastore.RetryBacklogAlertRule, Threshold: 10},
+			wantErr: "please provide at least one notification channel",
+		},
+		{
+			name: "rejects a private slack webhook",
+			rule: datastore.AlertRule{
+				Name: "r", Type: datastore.RetryBacklogAlertRule, Threshold: 10,
+				Channels: datastore.AlertRuleChannels{SlackWebhookURL: "http://10.0.0.1/hook?token=secret"},
+			},
+			wantErr: "invalid slack webhook url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			err := validateAlertRule(&rule)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "failures", rule.Name)
+			require.Equal(t, uint64(defaultAlertRuleWindow), rule.WindowSeconds)
+			require.Equal(t, uint64(defaultAlertRuleCooldown), rule.CooldownSeconds)
+		})
+	}
+}
+
+func TestAlertRuleEvaluator_Run(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name         string
+		rule         datastore.AlertRule
+		attempts     *datastore.DeliveryAttemptCounts
+		wantState    string
+		wantNotified bool
+		wantValue    float64
+	}{
+		{
+			name:         "starts firing and notifies",
+			rule:         datastore.AlertRule{State: datastore.AlertRuleOK},
+			attempts:     &datastore.DeliveryAttemptCounts{Attempts: 10, Failures: 4},
+			wantState:    datastore.AlertRuleFiring,
+			wantNotified: true,
+			wantValue:    40,
+		},
+		{
+			name: "stays quiet while firing inside the cooldown",
+			rule: datastore.AlertRule{
+				State: datastore.AlertRuleFiring, LastTriggeredAt: ago(10 * time.Minute), LastNotifiedAt: ago(10 * time.Minute),
+			},
+			attempts:  &datastore.DeliveryAttemptCounts{Attempts: 10, Failures: 4},
+			wantState: datastore.AlertRuleFiring,
+			wantValue: 40,
+		},
+		{
+			name: "reminds once the cooldown passed",
+			rule: datastore.AlertRule{
+				State: datastore.AlertRuleFiring, LastTriggeredAt: ago(time.Hour), LastNotifiedAt: ago(time.Hour),
+			},
+			attempts:     &datastore.DeliveryAttemptCounts{Attempts: 10, Failures: 4},
+			wantState:    datastore.AlertRuleFiring,
+			wantNotified: true,
+			wantValue:    40,
+		},
+		{
+			name: "refiring inside the cooldown is held back",
+			rule: datastore.AlertRule{
+				State: datastore.AlertRuleOK, LastNotifiedAt: ago(5 * time.Minute), LastResolvedAt: ago(5 * time.Minute),
+			},
+			attempts:  &datastore.DeliveryAttemptCounts{Attempts: 10, Failures: 4},
+			wantState: datastore.AlertRuleFiring,
+			wantValue: 40,
+		},
+		{
+			name: "resolves and notifies",
+			rule: datastore.AlertRule{
+				State: datastore.AlertRuleFiring, LastTriggeredAt: ago(10 * time.Minute), LastNotifiedAt: ago(10 * time.Minute),
+			},
+			attempts:     &datastore.DeliveryAttemptCounts{Attempts: 10, Failures: 0},
+			wantState:    datastore.AlertRuleOK,
+			wantNotified: true,
+		},
+		{
+			name: "resolves quietly when the firing was never announced",
+			rule: datastore.AlertRule{
+				State: datastore.AlertRuleFiring, LastTriggeredAt: ago(2 * time.Minute), LastNotifiedAt: ago(5 * time.Minute),
+			},
+			attempts:  &datastore.DeliveryAttemptCounts{Attempts: 10, Failures: 0},
+			wantState: datastore.AlertRuleOK,
+		},
+		{
+			name:      "no traffic is not a failure",
+			rule:      datastore.AlertRule{State: datastore.AlertRuleOK},
+			attempts:  &datastore.DeliveryAttemptCounts{},
+			wantState: datastore.AlertRuleOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAlertRuleRepository(ctrl)
+			projectRepo := mocks.NewMockProjectRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+
+			rule := tt.rule
+			rule.UID, rule.ProjectID, rule.Name = "rule-1", "abc", "checkout failures"
+			rule.Type = datastore.FailureRateAlertRule
+			rule.EndpointID = "123"
+			rule.Threshold = 25
+			rule.WindowSeconds = 300
+			rule.CooldownSeconds = 1800
+			rule.Channels = datastore.AlertRuleChannels{Email: "oncall@example.com"}
+
+			repo.EXPECT().LoadEnabledAlertRules(gomock.Any(), "", alertRuleBatchSize).Return([]datastore.AlertRule{rule}, nil)
+			repo.EXPECT().CountDeliveryAttempts(gomock.Any(), "abc", "123", now.Add(-5*time.Minute)).Return(tt.attempts, nil)
+			if tt.wantNotified {
+				projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "abc").Return(&datastore.Project{UID: "abc", Name: "P1"}, nil)
+				q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+			repo.EXPECT().UpdateAlertRuleState(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, r *datastore.AlertRule) error {
+					require.Equal(t, tt.wantState, r.State)
+					require.Equal(t, tt.wantValue, r.LastValue)
+					require.Equal(t, now, *r.LastEvaluatedAt)
+					if tt.wantNotified {
+						require.Equal(t, now, *r.LastNotifiedAt)
+					} else if r.LastNotifiedAt != nil {
+						require.NotEqual(t, now, *r.LastNotifiedAt)
+					}
+					return nil
+				})
+
+			evaluator := &AlertRuleEvaluator{
+				Repo:        repo,
+				ProjectRepo: projectRepo,
+				Queue:       q,
+				Clock:       clock.NewSimulatedClock(now),
+				Logger:      log.New("convoy", log.LevelError),
+			}
+
+			require.NoError(t, evaluator.Run(context.Background()))
+		})
+	}
+}
+
+func TestAlertRuleEvaluator_Measure(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	lastEvent := now.Add(-20 * time.Minute)
+
+	tests := []struct {
+		name         string
+		rule         datastore.AlertRule
+		setup        func(repo *mocks.MockAlertRuleRepository)
+		wantValue    float64
+		wantBreached bool
+	}{
+		{
+			name: "consecutive failures counts the leading failed attempts",
+			rule: datastore.AlertRule{Type: datastore.ConsecutiveFailuresAlertRule, Threshold: 3},
+			setup: func(repo *mocks.MockAlertRuleRepository) {
+				repo.EXPECT().LoadLatestAttemptStatuses(gomock.Any(), "abc", "", 3).Return([]bool{false, false, true}, nil)
+			},
+			wantValue: 2,
+		},
+		{
+			name: "consecutive failures fires on a full streak",
+			rule: datastore.AlertRule{Type: datastore.ConsecutiveFailuresAlertRule, Threshold: 3},
+			setup: func(repo *mocks.MockAlertRuleRepository) {
+				repo.EXPECT().LoadLatestAttemptStatuses(gomock.Any(), "abc", "", 3).Return([]bool{false, false, false}, nil)
+			},
+			wantValue:    3,
+			wantBreached: true,
+		},
+		{
+			name: "latency is compared in milliseconds",
+			rule: datastore.AlertRule{Type: datastore.LatencyP95AlertRule, Threshold: 500, WindowSeconds: 600},
+			setup: func(repo *mocks.MockAlertRuleRepository) {
+				repo.EXPECT().DeliveryLatencyP95(gomock.Any(), "abc", "", now.Add(-10*time.Minute)).Return(0.75, nil)
+			},
+			wantValue:    750,
+			wantBreached: true,
+		},
+		{
+			name: "retry backlog",
+			rule: datastore.AlertRule{Type: datastore.RetryBacklogAlertRule, Threshold: 100},
+			setup: func(repo *mocks.MockAlertRuleRepository) {
+				repo.EXPECT().CountRetryingDeliveries(gomock.Any(), "abc", "").Return(int64(40), nil)
+			},
+			wantValue: 40,
+		},
+		{
+			name: "source silent for longer than the window",
+			rule: datastore.AlertRule{Type: datastore.SourceSilenceAlertRule, SourceID: "src", WindowSeconds: 900, CreatedAt: now.Add(-time.Hour)},
+			setup: func(repo *mocks.MockAlertRuleRepository) {
+				repo.EXPECT().FindLatestSourceEventTime(gomock.Any(), "abc", "src").Return(&lastEvent, nil)
+			},
+			wantValue:    1200,
+			wantBreached: true,
+		},
+		{
+			name: "a new rule waits out its window on a quiet source",
+			rule: datastore.AlertRule{Type: datastore.SourceSilenceAlertRule, SourceID: "src", WindowSeconds: 900, CreatedAt: now.Add(-5 * time.Minute)},
+			setup: func(repo *mocks.MockAlertRuleRepository) {
+				repo.EXPECT().FindLatestSourceEventTime(gomock.Any(), "abc", "src").Return(nil, nil)
+			},
+			wantValue: 300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockAlertRuleRepository(ctrl)
+			tt.setup(repo)
+
+			rule := tt.rule
+			rule.ProjectID = "abc"
+			evaluator := &AlertRuleEvaluator{Repo: repo, Clock: clock.NewSimulatedClock(now)}
+
+			value, breached, err := evaluator.measure(context.Background(), &rule, now)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantValue, value)
+			require.Equal(t, tt.wantBreached, breached)
+		})
+	}
+}
+
+func TestAlertRuleEvaluator_SkipsRulesThatFailToEvaluate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := mocks.NewMockAlertRuleRepository(ctrl)
+
+	rule := datastore.AlertRule{UID: "rule-1", ProjectID: "abc", Type: datastore.RetryBacklogAlertRule, Threshold: 10}
+	repo.EXPECT().LoadEnabledAlertRules(gomock.Any(), "", alertRuleBatchSize).Return([]datastore.AlertRule{rule}, nil)
+	repo.EXPECT().CountRetryingDeliveries(gomock.Any(), "abc", "").Return(int64(0), errors.New("boom"))
+
+	evaluator := &AlertRuleEvaluator{
+		Repo:   repo,
+		Clock:  clock.NewSimulatedClock(now),
+		Logger: log.New("convoy", log.LevelError),
+	}
+
+	require.NoError(t, evaluator.Run(context.Background()))
+}
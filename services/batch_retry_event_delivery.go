@@ -68,9 +68,10 @@ func (e *BatchRetryEventDeliveryService) Run(ctx context.Context) error {
 	}
 
 	job := &queue.Job{
-		ID:      batchRetry.ID,
-		Payload: data,
-		Delay:   0,
+		ID:        batchRetry.ID,
+		Payload:   data,
+		Delay:     0,
+		ProjectID: batchRetry.ProjectID,
 	}
 
 	err = e.Queue.WriteWithoutTimeout(ctx, convoy.BatchRetryProcessor, convoy.BatchRetryQueue, job)
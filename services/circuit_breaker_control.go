@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	cb "github.com/frain-dev/convoy/pkg/circuit_breaker"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+type CircuitBreakerAction string
+
+const (
+	CircuitBreakerForceOpen  CircuitBreakerAction = "force_open"
+	CircuitBreakerForceClose CircuitBreakerAction = "force_close"
+	CircuitBreakerReset      CircuitBreakerAction = "reset"
+)
+
+// UpdateCircuitBreakerStateService holds an endpoint's breaker open or closed,
+// or releases it back to the sampler. The override is written before the
+// breaker so the sampler never lifts a hold it has not seen yet.
+type UpdateCircuitBreakerStateService struct {
+	Repo         datastore.CircuitBreakerRepository
+	EndpointRepo datastore.EndpointRepository
+	Manager      *cb.CircuitBreakerManager
+	ProjectID    string
+	EndpointID   string
+	Action       CircuitBreakerAction
+	Reason       string
+	// Notifier, when set, announces the change; nil keeps it silent.
+	Notifier *CircuitBreakerTransitionNotifier
+	Logger   log.Logger
+}
+
+func (s *UpdateCircuitBreakerStateService) Run(ctx context.Context) (*cb.CircuitBreaker, error) {
+	var forced string
+	switch s.Action {
+	case CircuitBreakerForceOpen:
+		forced = cb.StateOpen.String()
+	case CircuitBreakerForceClose:
+		forced = cb.StateClosed.String()
+	case CircuitBreakerReset:
+	default:
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("unsupported circuit breaker action - %s", s.Action)}
+	}
+
+	_, err := s.EndpointRepo.FindEndpointByID(ctx, s.EndpointID, s.ProjectID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to find endpoint", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+	}
+
+	from := cb.StateClosed
+	current, err := s.Manager.GetCircuitBreaker(ctx, s.EndpointID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to load circuit breaker", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to load circuit breaker", Err: err}
+	}
+	if current != nil {
+		from = current.State
+	}
+
+	override, err := findOrNewOverride(ctx, s.Repo, s.ProjectID, s.EndpointID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to find circuit breaker override", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find circuit breaker override", Err: err}
+	}
+
+	override.ForcedState = forced
+	override.Reason = s.Reason
+	if err = saveOverride(ctx, s.Repo, override); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to save circuit breaker override", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to save circuit breaker override", Err: err}
+	}
+
+	var breaker *cb.CircuitBreaker
+	switch s.Action {
+	case CircuitBreakerForceOpen:
+		breaker, err = s.Manager.ForceState(ctx, s.EndpointID, s.ProjectID, cb.StateOpen)
+	case CircuitBreakerForceClose:
+		breaker, err = s.Manager.ForceState(ctx, s.EndpointID, s.ProjectID, cb.StateClosed)
+	default:
+		breaker, err = s.Manager.Release(ctx, s.EndpointID, s.ProjectID)
+	}
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to update circuit breaker", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to update circuit breaker", Err: err}
+	}
+
+	if from != breaker.State {
+		transition := &datastore.CircuitBreakerTransition{
+			ProjectID:   s.ProjectID,
+			EndpointID:  s.EndpointID,
+			FromState:   from.String(),
+			ToState:     breaker.State.String(),
+			Source:      datastore.CircuitBreakerTransitionSourceManual,
+			Reason:      s.Reason,
+			FailureRate: breaker.FailureRate,
+		}
+
+		// The breaker has already moved; a missing history row is not worth
+		// failing the request over.
+		if err = s.Repo.CreateCircuitBreakerTransition(ctx, transition); err != nil {
+			s.Logger.ErrorContext(ctx, "failed to record circuit breaker transition", "error", err)
+		}
+
+		if s.Notifier != nil {
+			s.Notifier.Run(ctx, transition)
+		}
+	}
+
+	return breaker, nil
+}
+
+// UpdateCircuitBreakerConfigService sets or, with a nil Config, clears an
+// endpoint's threshold overrides. The sampler picks them up on its next tick.
+type UpdateCircuitBreakerConfigService struct {
+	Repo         datastore.CircuitBreakerRepository
+	EndpointRepo datastore.EndpointRepository
+	ProjectID    string
+	EndpointID   string
+	Config       *datastore.EndpointCircuitBreakerConfiguration
+	Logger       log.Logger
+}
+
+func (s *UpdateCircuitBreakerConfigService) Run(ctx context.Context) (*datastore.CircuitBreakerOverride, error) {
+	if s.Config != nil {
+		if err := validateEndpointCircuitBreakerConfig(s.Config); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
+	_, err := s.EndpointRepo.FindEndpointByID(ctx, s.EndpointID, s.ProjectID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to find endpoint", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+	}
+
+	override, err := findOrNewOverride(ctx, s.Repo, s.ProjectID, s.EndpointID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to find circuit breaker override", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find circuit breaker override", Err: err}
+	}
+
+	override.Config = s.Config
+	if err = saveOverride(ctx, s.Repo, override); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to save circuit breaker override", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to save circuit breaker override", Err: err}
+	}
+
+	return override, nil
+}
+
+func validateEndpointCircuitBreakerConfig(c *datastore.EndpointCircuitBreakerConfiguration) error {
+	if c.FailureThreshold > 100 {
+		return errors.New("failure_threshold must be between 0 and 100")
+	}
+	if c.SuccessThreshold > 100 {
+		return errors.New("success_threshold must be between 0 and 100")
+	}
+	if c.MinimumRequestCount != 0 && c.MinimumRequestCount < 10 {
+		return errors.New("minimum_request_count must be at least 10")
+	}
+	return nil
+}
+
+func findOrNewOverride(ctx context.Context, repo datastore.CircuitBreakerRepository, projectID, endpointID string) (*datastore.CircuitBreakerOverride, error) {
+	override, err := repo.FindCircuitBreakerOverride(ctx, projectID, endpointID)
+	if errors.Is(err, datastore.ErrCircuitBreakerOverrideNotFound) {
+		return &datastore.CircuitBreakerOverride{ProjectID: projectID, EndpointID: endpointID}, nil
+	}
+	return override, err
+}
+
+// saveOverride drops overrides that no longer change anything, so the
+// sampler does not load a row per endpoint that was ever touched.
+func saveOverride(ctx context.Context, repo datastore.CircuitBreakerRepository, override *datastore.CircuitBreakerOverride) error {
+	if override.IsEmpty() {
+		err := repo.DeleteCircuitBreakerOverride(ctx, override.ProjectID, override.EndpointID)
+		if errors.Is(err, datastore.ErrCircuitBreakerOverrideNotFound) {
+			return nil
+		}
+		return err
+	}
+	return repo.UpsertCircuitBreakerOverride(ctx, override)
+}
+
+// CircuitBreakerOverrideFunc adapts the stored overrides to the form the
+// circuit breaker sampler reads on every tick.
+func CircuitBreakerOverrideFunc(repo datastore.CircuitBreakerRepository) cb.OverrideFunc {
+	return func(ctx context.Context) (map[string]cb.Override, error) {
+		overrides, err := repo.LoadCircuitBreakerOverrides(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]cb.Override, len(overrides))
+		for i := range overrides {
+			o := cb.Override{TenantId: overrides[i].ProjectID}
+
+			switch overrides[i].ForcedState {
+			case cb.StateOpen.String():
+				state := cb.StateOpen
+				o.ForcedState = &state
+			case cb.StateClosed.String():
+				state := cb.StateClosed
+				o.ForcedState = &state
+			}
+
+			if c := overrides[i].Config; c != nil {
+				o.Config = &cb.CircuitBreakerConfig{
+					BreakerTimeout:              c.ErrorTimeout,
+					FailureThreshold:            c.FailureThreshold,
+					SuccessThreshold:            c.SuccessThreshold,
+					MinimumRequestCount:         c.MinimumRequestCount,
+					ConsecutiveFailureThreshold: c.ConsecutiveFailureThreshold,
+				}
+			}
+
+			m[overrides[i].EndpointID] = o
+		}
+
+		return m, nil
+	}
+}
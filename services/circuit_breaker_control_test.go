@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	cb "github.com/frain-dev/convoy/pkg/circuit_breaker"
+	"github.com/frain-dev/convoy/pkg/clock"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func provideCircuitBreakerManager(t *testing.T) *cb.CircuitBreakerManager {
+	t.Helper()
+
+	m, err := cb.NewCircuitBreakerManager(
+		cb.StoreOption(cb.NewTestStore()),
+		cb.ClockOption(clock.NewSimulatedClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))),
+		cb.ConfigProviderOption(func(string) *cb.CircuitBreakerConfig { return nil }),
+		cb.LoggerOption(log.New("convoy", log.LevelError)),
+	)
+	require.NoError(t, err)
+	return m
+}
+
+func TestUpdateCircuitBreakerStateService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		action     CircuitBreakerAction
+		dbFn       func(repo *mocks.MockCircuitBreakerRepository)
+		wantState  cb.State
+		wantForced bool
+		wantErrMsg string
+	}{
+		{
+			name:   "should_force_open_and_record_transition",
+			action: CircuitBreakerForceOpen,
+			dbFn: func(repo *mocks.MockCircuitBreakerRepository) {
+				repo.EXPECT().FindCircuitBreakerOverride(gomock.Any(), "abc", "123").
+					Return(nil, datastore.ErrCircuitBreakerOverrideNotFound)
+				repo.EXPECT().UpsertCircuitBreakerOverride(gomock.Any(), &datastore.CircuitBreakerOverride{
+					ProjectID: "abc", EndpointID: "123", ForcedState: "open", Reason: "maintenance",
+				}).Return(nil)
+				repo.EXPECT().CreateCircuitBreakerTransition(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tr *datastore.CircuitBreakerTransition) error {
+						require.Equal(t, "closed", tr.FromState)
+						require.Equal(t, "open", tr.ToState)
+						require.Equal(t, datastore.CircuitBreakerTransitionSourceManual, tr.Source)
+						return nil
+					})
+			},
+			wantState:  cb.StateOpen,
+			wantForced: true,
+		},
+		{
+			name:   "should_keep_config_when_releasing_a_hold",
+			action: CircuitBreakerReset,
+			dbFn: func(repo *mocks.MockCircuitBreakerRepository) {
+				cfg := &datastore.EndpointCircuitBreakerConfiguration{FailureThreshold: 90}
+				repo.EXPECT().FindCircuitBreakerOverride(gomock.Any(), "abc", "123").
+					Return(&datastore.CircuitBreakerOverride{ProjectID: "abc", EndpointID: "123", ForcedState: "open", Config: cfg}, nil)
+				repo.EXPECT().UpsertCircuitBreakerOverride(gomock.Any(), &datastore.CircuitBreakerOverride{
+					ProjectID: "abc", EndpointID: "123", Config: cfg, Reason: "maintenance",
+				}).Return(nil)
+			},
+			wantState: cb.StateClosed,
+		},
+		{
+			name:   "should_delete_an_override_left_empty",
+			action: CircuitBreakerReset,
+			dbFn: func(repo *mocks.MockCircuitBreakerRepository) {
+				repo.EXPECT().FindCircuitBreakerOverride(gomock.Any(), "abc", "123").
+					Return(&datastore.CircuitBreakerOverride{ProjectID: "abc", EndpointID: "123", ForcedState: "closed"}, nil)
+				repo.EXPECT().DeleteCircuitBreakerOverride(gomock.Any(), "abc", "123").Return(nil)
+			},
+			wantState: cb.StateClosed,
+		},
+		{
+			name:       "should_reject_unknown_action",
+			action:     "half_open",
+			wantErrMsg: "unsupported circuit breaker action - half_open",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockCircuitBreakerRepository(ctrl)
+			endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+			if tt.dbFn != nil {
+				endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").
+					Return(&datastore.Endpoint{UID: "123"}, nil)
+				tt.dbFn(repo)
+			}
+
+			s := &UpdateCircuitBreakerStateService{
+				Repo:         repo,
+				EndpointRepo: endpointRepo,
+				Manager:      provideCircuitBreakerManager(t),
+				ProjectID:    "abc",
+				EndpointID:   "123",
+				Action:       tt.action,
+				Reason:       "maintenance",
+				Logger:       log.New("convoy", log.LevelError),
+			}
+
+			breaker, err := s.Run(ctx)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantState, breaker.State)
+			require.Equal(t, tt.wantForced, breaker.Forced)
+		})
+	}
+}
+
+func TestUpdateCircuitBreakerConfigService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_reject_threshold_over_100", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := &UpdateCircuitBreakerConfigService{
+			Repo:         mocks.NewMockCircuitBreakerRepository(ctrl),
+			EndpointRepo: mocks.NewMockEndpointRepository(ctrl),
+			ProjectID:    "abc",
+			EndpointID:   "123",
+			Config:       &datastore.EndpointCircuitBreakerConfiguration{FailureThreshold: 101},
+			Logger:       log.New("convoy", log.LevelError),
+		}
+
+		_, err := s.Run(ctx)
+		require.Error(t, err)
+		require.Equal(t, "failure_threshold must be between 0 and 100", err.(*ServiceError).Error())
+	})
+
+	t.Run("should_store_config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockCircuitBreakerRepository(ctrl)
+		endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+		cfg := &datastore.EndpointCircuitBreakerConfiguration{FailureThreshold: 90, MinimumRequestCount: 20}
+
+		endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Return(&datastore.Endpoint{UID: "123"}, nil)
+		repo.EXPECT().FindCircuitBreakerOverride(gomock.Any(), "abc", "123").
+			Return(nil, datastore.ErrCircuitBreakerOverrideNotFound)
+		repo.EXPECT().UpsertCircuitBreakerOverride(gomock.Any(), &datastore.CircuitBreakerOverride{
+			ProjectID: "abc", EndpointID: "123", Config: cfg,
+		}).Return(nil)
+
+		s := &UpdateCircuitBreakerConfigService{
+			Repo:         repo,
+			EndpointRepo: endpointRepo,
+			ProjectID:    "abc",
+			EndpointID:   "123",
+			Config:       cfg,
+			Logger:       log.New("convoy", log.LevelError),
+		}
+
+		override, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, cfg, override.Config)
+	})
+}
+
+func TestCircuitBreakerOverrideFunc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCircuitBreakerRepository(ctrl)
+	repo.EXPECT().LoadCircuitBreakerOverrides(gomock.Any()).Return([]datastore.CircuitBreakerOverride{
+		{ProjectID: "abc", EndpointID: "1", ForcedState: "open"},
+		{ProjectID: "abc", EndpointID: "2", Config: &datastore.EndpointCircuitBreakerConfiguration{ErrorTimeout: 60}},
+	}, nil)
+
+	overrides, err := CircuitBreakerOverrideFunc(repo)(context.Background())
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+
+	require.Equal(t, cb.StateOpen, *overrides["1"].ForcedState)
+	require.Nil(t, overrides["1"].Config)
+
+	require.Nil(t, overrides["2"].ForcedState)
+	require.Equal(t, uint64(60), overrides["2"].Config.BreakerTimeout)
+	require.Equal(t, "abc", overrides["2"].TenantId)
+}
+
+func TestCircuitBreakerTransitionNotifier_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	metaEventRepo := mocks.NewMockMetaEventRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+	licenser := mocks.NewMockLicenser(ctrl)
+	lo := log.New("convoy", log.LevelError)
+
+	project := &datastore.Project{UID: "abc", Config: &datastore.ProjectConfig{
+		MetaEvent: &datastore.MetaEventConfiguration{IsEnabled: true, EventType: []string{string(datastore.CircuitBreakerOpened)}},
+		Strategy:  &datastore.StrategyConfiguration{},
+	}}
+
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "abc").Times(2).Return(project, nil)
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Return(
+		&datastore.Endpoint{UID: "123", SlackWebhookURL: "https://hooks.example.com/services/T/B/X"}, nil)
+	licenser.EXPECT().AdvancedEndpointMgmt().Return(true)
+	metaEventRepo.EXPECT().CreateMetaEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *datastore.MetaEvent) error {
+			require.Equal(t, string(datastore.CircuitBreakerOpened), m.EventType)
+			return nil
+		})
+	// one meta event job and one slack notification
+	q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	n := &CircuitBreakerTransitionNotifier{
+		ProjectRepo:  projectRepo,
+		EndpointRepo: endpointRepo,
+		MetaEvent:    NewMetaEvent(q, projectRepo, metaEventRepo, lo),
+		Queue:        q,
+		Licenser:     licenser,
+		Logger:       lo,
+	}
+
+	n.Run(context.Background(), &datastore.CircuitBreakerTransition{
+		ProjectID:  "abc",
+		EndpointID: "123",
+		FromState:  "closed",
+		ToState:    "open",
+		Source:     datastore.CircuitBreakerTransitionSourceSampler,
+	})
+}
+
+func TestCircuitBreakerTransitionNotifier_AlertCooldown(t *testing.T) {
+	clk := clock.NewSimulatedClock(time.Now())
+	n := &CircuitBreakerTransitionNotifier{AlertCooldown: 10 * time.Minute, Clock: clk}
+
+	transition := func(to string) *datastore.CircuitBreakerTransition {
+		return &datastore.CircuitBreakerTransition{ProjectID: "abc", EndpointID: "123", ToState: to}
+	}
+	send := func(to string) bool {
+		if !n.shouldAlert(transition(to)) {
+			return false
+		}
+		n.recordAlert(transition(to))
+		return true
+	}
+
+	require.True(t, send("open"))
+	require.False(t, send("half-open"), "a firing alert covers the breaker flapping")
+	require.False(t, send("open"))
+	require.True(t, send("closed"))
+	require.False(t, send("closed"), "an alert is resolved once")
+
+	clk.AdvanceTime(time.Minute)
+	require.False(t, send("open"), "a new alert waits out the cooldown")
+
+	clk.AdvanceTime(10 * time.Minute)
+	require.True(t, send("open"))
+	require.True(t, send("closed"))
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/notifications"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/pkg/clock"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/queue"
+)
+
+// CircuitBreakerTransitionNotifier announces a breaker state change as a meta
+// event and on the endpoint's own alert channels. Both legs are best effort: a
+// failure is logged and never undoes the transition.
+//
+// Meta events go out for every transition, but endpoint alerts are throttled
+// so a breaker flapping between open and half-open does not page on every
+// tick: once an alert is firing, further open and half-open transitions are
+// folded into it until the breaker closes, and a new firing alert is held back
+// for AlertCooldown after the previous one. The throttle lives in memory, so a
+// notifier should be kept for the life of the process rather than built per
+// transition.
+type CircuitBreakerTransitionNotifier struct {
+	ProjectRepo  datastore.ProjectRepository
+	EndpointRepo datastore.EndpointRepository
+	MetaEvent    *MetaEvent
+	Queue        queue.Queuer
+	Licenser     license.Licenser
+	Logger       log.Logger
+	// AlertCooldown is the least time between two firing alerts for the same
+	// endpoint. Zero uses DefaultCircuitBreakerAlertCooldown.
+	AlertCooldown time.Duration
+	// Clock defaults to the real clock.
+	Clock clock.Clock
+
+	mu     sync.Mutex
+	alerts map[string]circuitBreakerAlert
+}
+
+// DefaultCircuitBreakerAlertCooldown is the default AlertCooldown.
+const DefaultCircuitBreakerAlertCooldown = 15 * time.Minute
+
+// circuitBreakerAlert is the last alert sent for an endpoint.
+type circuitBreakerAlert struct {
+	resolved bool
+	firedAt  time.Time
+}
+
+func (n *CircuitBreakerTransitionNotifier) Run(ctx context.Context, transition *datastore.CircuitBreakerTransition) {
+	eventType, ok := circuitBreakerHookEvent(transition.ToState)
+	if !ok {
+		return
+	}
+
+	if n.MetaEvent != nil {
+		if err := n.MetaEvent.Run(ctx, string(eventType), transition.ProjectID, transition); err != nil {
+			n.Logger.ErrorContext(ctx, "circuit breaker meta event failed", "error", err)
+		}
+	}
+
+	// Endpoint alerts ride on the same entitlement as the circuit breaker's
+	// disable alerts.
+	if n.Licenser == nil || !n.Licenser.AdvancedEndpointMgmt() {
+		return
+	}
+
+	if !n.shouldAlert(transition) {
+		n.Logger.DebugContext(ctx, "circuit breaker alert suppressed", "endpoint_id", transition.EndpointID, "state", transition.ToState)
+		return
+	}
+
+	project, err := n.ProjectRepo.FetchProjectByID(ctx, transition.ProjectID)
+	if err != nil {
+		n.Logger.ErrorContext(ctx, "failed to fetch project for circuit breaker alert", "error", err)
+		return
+	}
+
+	endpoint, err := n.EndpointRepo.FindEndpointByID(ctx, transition.EndpointID, transition.ProjectID)
+	if err != nil {
+		n.Logger.ErrorContext(ctx, "failed to fetch endpoint for circuit breaker alert", "error", err)
+		return
+	}
+
+	if notifications.SendCircuitBreakerNotification(ctx, endpoint, project, transition, n.Queue, n.Logger) {
+		n.recordAlert(transition)
+	}
+}
+
+// shouldAlert reports whether the transition's alert should be sent given the
+// last alert sent for the endpoint.
+func (n *CircuitBreakerTransitionNotifier) shouldAlert(transition *datastore.CircuitBreakerTransition) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	last, ok := n.alerts[transition.EndpointID]
+	if !ok {
+		return true
+	}
+
+	if transition.ToState == "closed" {
+		// Nothing to resolve if the last alert already did.
+		return !last.resolved
+	}
+
+	if !last.resolved {
+		// Already firing; the alert covers this transition too.
+		return false
+	}
+
+	return n.now().Sub(last.firedAt) >= n.cooldown()
+}
+
+func (n *CircuitBreakerTransitionNotifier) recordAlert(transition *datastore.CircuitBreakerTransition) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.alerts == nil {
+		n.alerts = map[string]circuitBreakerAlert{}
+	}
+
+	now := n.now()
+	for id, a := range n.alerts {
+		// Resolved alerts past their cooldown no longer hold anything back.
+		if a.resolved && now.Sub(a.firedAt) >= n.cooldown() {
+			delete(n.alerts, id)
+		}
+	}
+
+	if transition.ToState == "closed" {
+		last := n.alerts[transition.EndpointID]
+		n.alerts[transition.EndpointID] = circuitBreakerAlert{resolved: true, firedAt: last.firedAt}
+		return
+	}
+
+	n.alerts[transition.EndpointID] = circuitBreakerAlert{firedAt: now}
+}
+
+func (n *CircuitBreakerTransitionNotifier) cooldown() time.Duration {
+	if n.AlertCooldown > 0 {
+		return n.AlertCooldown
+	}
+	return DefaultCircuitBreakerAlertCooldown
+}
+
+func (n *CircuitBreakerTransitionNotifier) now() time.Time {
+	if n.Clock != nil {
+		return n.Clock.Now()
+	}
+	return time.Now()
+}
+
+func circuitBreakerHookEvent(state string) (datastore.HookEventType, bool) {
+	switch state {
+	case "open":
+		return datastore.CircuitBreakerOpened, true
+	case "half-open":
+		return datastore.CircuitBreakerHalfOpened, true
+	case "closed":
+		return datastore.CircuitBreakerClosed, true
+	default:
+		return "", false
+	}
+}
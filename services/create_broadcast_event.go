@@ -49,8 +49,9 @@ func (e *CreateBroadcastEventService) Run(ctx context.Context) error {
 	}
 
 	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
+		ID:        jobId,
+		Payload:   eventByte,
+		ProjectID: e.Project.UID,
 	}
 
 	err = e.Queue.Write(ctx, taskName, convoy.CreateEventQueue, job)
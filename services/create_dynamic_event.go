@@ -70,8 +70,9 @@ func (e *CreateDynamicEventService) Run(ctx context.Context) (err error) {
 	}
 
 	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
+		ID:        jobId,
+		Payload:   eventByte,
+		ProjectID: e.Project.UID,
 	}
 
 	err = e.Queue.Write(ctx, taskName, convoy.CreateEventQueue, job)
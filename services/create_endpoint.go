@@ -137,24 +137,25 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 	}
 
 	endpoint := &datastore.Endpoint{
-		UID:                ulid.Make().String(),
-		ProjectID:          a.ProjectID,
-		OwnerID:            a.E.OwnerID,
-		Name:               a.E.Name,
-		SupportEmail:       a.E.SupportEmail,
-		SlackWebhookURL:    a.E.SlackWebhookURL,
-		TeamsWebhookURL:    a.E.TeamsWebhookURL,
-		Url:                a.E.URL,
-		Description:        a.E.Description,
-		RateLimit:          a.E.RateLimit,
-		HttpTimeout:        a.E.HttpTimeout,
-		AdvancedSignatures: *a.E.AdvancedSignatures,
-		AppID:              a.E.AppID,
-		RateLimitDuration:  a.E.RateLimitDuration,
-		ContentType:        a.E.ContentType,
-		Status:             datastore.ActiveEndpointStatus,
-		CreatedAt:          time.Now(),
-		UpdatedAt:          time.Now(),
+		UID:                  ulid.Make().String(),
+		ProjectID:            a.ProjectID,
+		OwnerID:              a.E.OwnerID,
+		Name:                 a.E.Name,
+		SupportEmail:         a.E.SupportEmail,
+		SlackWebhookURL:      a.E.SlackWebhookURL,
+		TeamsWebhookURL:      a.E.TeamsWebhookURL,
+		NotificationChannels: a.E.NotificationChannels,
+		Url:                  a.E.URL,
+		Description:          a.E.Description,
+		RateLimit:            a.E.RateLimit,
+		HttpTimeout:          a.E.HttpTimeout,
+		AdvancedSignatures:   *a.E.AdvancedSignatures,
+		AppID:                a.E.AppID,
+		RateLimitDuration:    a.E.RateLimitDuration,
+		ContentType:          a.E.ContentType,
+		Status:               datastore.ActiveEndpointStatus,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
 	}
 
 	if !a.Licenser.AdvancedEndpointMgmt() {
@@ -164,6 +165,7 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		endpoint.SupportEmail = ""
 		endpoint.SlackWebhookURL = ""
 		endpoint.TeamsWebhookURL = ""
+		endpoint.NotificationChannels = nil
 	}
 
 	// Reject a slack_webhook_url that targets loopback/private/reserved
@@ -188,6 +190,13 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		}
 	}
 
+	if endpoint.NotificationChannels.IsEmpty() {
+		endpoint.NotificationChannels = nil
+	}
+	if err := validateNotificationChannels(endpoint.NotificationChannels); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
 	if util.IsStringEmpty(endpoint.AppID) {
 		endpoint.AppID = endpoint.UID
 	}
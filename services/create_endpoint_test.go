@@ -206,6 +206,9 @@ func TestCreateEndpointService_Run(t *testing.T) {
 					URL:             "https://google.com",
 					HttpTimeout:     3,
 					Description:     "test_endpoint",
+					NotificationChannels: &datastore.NotificationChannels{
+						PagerDuty: &datastore.PagerDutyChannel{RoutingKey: "R0UT1NGKEY"},
+					},
 				},
 				g: project,
 			},
@@ -51,6 +51,9 @@ type newEvent struct {
 	IdempotencyKey string
 	IsDuplicate    bool
 	AcknowledgedAt time.Time
+
+	// EventTypeVersion is the event type version the publisher named, or 0.
+	EventTypeVersion int
 }
 
 func (e *CreateFanoutEventService) Run(ctx context.Context) (event *datastore.Event, err error) {
@@ -123,6 +126,8 @@ func (e *CreateFanoutEventService) Run(ctx context.Context) (event *datastore.Ev
 		CustomHeaders:  e.NewMessage.CustomHeaders,
 		IsDuplicate:    isDuplicate,
 		AcknowledgedAt: time.Now(),
+
+		EventTypeVersion: e.NewMessage.EventTypeVersion,
 	}
 
 	event, err = createEvent(ctx, endpointIDs, ev, e.Project, e.Queue, e.Logger)
@@ -170,6 +175,7 @@ func createEvent(ctx context.Context, endpointIDs []string, newMessage *newEvent
 		JobID:              jobId,
 		Event:              event,
 		CreateSubscription: !util.IsStringEmpty(newMessage.EndpointID),
+		EventTypeVersion:   newMessage.EventTypeVersion,
 	}
 
 	eventByte, err := msgpack.EncodeMsgPack(e)
@@ -178,8 +184,9 @@ func createEvent(ctx context.Context, endpointIDs []string, newMessage *newEvent
 	}
 
 	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
+		ID:        jobId,
+		Payload:   eventByte,
+		ProjectID: project.UID,
 	}
 	startQueue := time.Now()
 	err = queuer.Write(ctx, convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/oklog/ulid/v2"
@@ -41,19 +43,52 @@ func (m *MetaEvent) Run(ctx context.Context, eventType, projectID string, data i
 		return err
 	}
 
-	cfg := project.Config
-	if cfg.MetaEvent == nil {
+	if !m.wants(project, eventType) {
 		return nil
 	}
 
-	if !cfg.MetaEvent.IsEnabled {
-		return nil
+	return m.create(ctx, eventType, project, data)
+}
+
+// Broadcast sends an instance wide meta event, like a completed backup, to
+// every project subscribed to it. Every project gets the same data, so it
+// must not carry anything about other tenants or the instance itself.
+func (m *MetaEvent) Broadcast(ctx context.Context, eventType string, data interface{}) error {
+	projects, err := m.projectRepo.LoadProjects(ctx, &datastore.ProjectFilter{})
+	if err != nil {
+		return err
 	}
 
-	if !m.isSubscribed(eventType, cfg.MetaEvent.EventType) {
-		return nil
+	var errs []error
+	for _, project := range projects {
+		if !m.wants(project, eventType) {
+			continue
+		}
+
+		if err = m.create(ctx, eventType, project, data); err != nil {
+			errs = append(errs, fmt.Errorf("project %s: %w", project.UID, err))
+		}
 	}
 
+	return errors.Join(errs...)
+}
+
+func (m *MetaEvent) wants(project *datastore.Project, eventType string) bool {
+	cfg := project.Config
+	if cfg == nil || cfg.MetaEvent == nil {
+		return false
+	}
+
+	if !cfg.MetaEvent.IsEnabled {
+		return false
+	}
+
+	return m.isSubscribed(eventType, cfg.MetaEvent.EventType)
+}
+
+func (m *MetaEvent) create(ctx context.Context, eventType string, project *datastore.Project, data interface{}) error {
+	projectID := project.UID
+
 	dByte, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -107,8 +142,9 @@ func (m *MetaEvent) Run(ctx context.Context, eventType, projectID string, data i
 
 	jobId := queue.JobId{ProjectID: metaEvent.ProjectID, ResourceID: metaEvent.UID}.MetaJobId()
 	err = m.queue.Write(ctx, convoy.MetaEventProcessor, convoy.MetaEventQueue, &queue.Job{
-		ID:      jobId,
-		Payload: bytes,
+		ID:        jobId,
+		Payload:   bytes,
+		ProjectID: metaEvent.ProjectID,
 	})
 
 	if err != nil {
@@ -130,3 +130,44 @@ func Test_MetaEvent_Run(t *testing.T) {
 		})
 	}
 }
+
+func Test_MetaEvent_Broadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mE := provideMetaEvent(ctrl)
+
+	project := func(uid string, enabled bool, eventTypes ...string) *datastore.Project {
+		return &datastore.Project{
+			UID: uid,
+			Config: &datastore.ProjectConfig{
+				Strategy: &datastore.DefaultStrategyConfig,
+				MetaEvent: &datastore.MetaEventConfiguration{
+					IsEnabled: enabled,
+					EventType: eventTypes,
+				},
+			},
+		}
+	}
+
+	projectRepo, _ := mE.projectRepo.(*mocks.MockProjectRepository)
+	projectRepo.EXPECT().LoadProjects(gomock.Any(), gomock.Any()).Return([]*datastore.Project{
+		project("subscribed", true, string(datastore.BackupJobCompleted)),
+		project("not-subscribed", true, string(datastore.EndpointCreated)),
+		project("disabled", false, string(datastore.BackupJobCompleted)),
+		{UID: "no-config"},
+	}, nil)
+
+	metaEventRepo, _ := mE.metaEventRepo.(*mocks.MockMetaEventRepository)
+	metaEventRepo.EXPECT().CreateMetaEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *datastore.MetaEvent) error {
+		require.Equal(t, "subscribed", m.ProjectID)
+		require.Equal(t, string(datastore.BackupJobCompleted), m.EventType)
+		return nil
+	})
+
+	queue, _ := mE.queue.(*mocks.MockQueuer)
+	queue.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	err := mE.Broadcast(context.Background(), string(datastore.BackupJobCompleted), &datastore.BackupJob{ID: "job-1"})
+	require.NoError(t, err)
+}
@@ -29,6 +29,10 @@ type CreateSubscriptionService struct {
 	NewSubscription *models.CreateSubscription
 	Licenser        license.Licenser
 	Logger          log.Logger
+
+	// EventTypes, when set, are the subscription's event types whatever the
+	// licence, portal links restricted to a set of event types rely on it.
+	EventTypes []string
 }
 
 func NewCreateSubscriptionService(
@@ -120,13 +124,21 @@ func (s *CreateSubscriptionService) Run(ctx context.Context) (*datastore.Subscri
 		subscription.FilterConfig = &datastore.FilterConfiguration{}
 	}
 
+	if len(s.EventTypes) > 0 {
+		subscription.FilterConfig.EventTypes = s.EventTypes
+	}
+
 	if len(subscription.FilterConfig.EventTypes) == 0 {
 		subscription.FilterConfig.EventTypes = []string{"*"}
 	}
 
-	if !filterSchemaHasConditions(subscription.FilterConfig.Filter) {
+	if !subscription.FilterConfig.Filter.HasConditions() {
 		subscription.FilterConfig.Filter = emptyFilterSchema()
 	} else {
+		if err = subscription.FilterConfig.Filter.ValidateExpression(); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+
 		// validate that the filter is a json string
 		_, err = json.Marshal(subscription.FilterConfig.Filter)
 		if err != nil {
@@ -172,10 +184,6 @@ func (s *CreateSubscriptionService) findEndpoint(ctx context.Context, appID, end
 	return endpoint, nil
 }
 
-func filterSchemaHasConditions(filter datastore.FilterSchema) bool {
-	return len(filter.Body) > 0 || len(filter.Headers) > 0 || len(filter.Query) > 0 || len(filter.Path) > 0
-}
-
 func emptyFilterSchema() datastore.FilterSchema {
 	return datastore.FilterSchema{
 		Headers:    datastore.M{},
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/email"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+)
+
+const (
+	maxEmailSubjectTemplateLength = 512
+	maxEmailBodyTemplateLength    = 64 * 1024
+	maxEmailLogoURLLength         = 2048
+)
+
+// validateEmailOverride checks an override's size and renders it against the
+// template's sample variables.
+func validateEmailOverride(name email.TemplateName, subject, body string) error {
+	if !email.IsCustomisable(name) {
+		return fmt.Errorf("email template %q cannot be customised", name)
+	}
+
+	if len(subject) > maxEmailSubjectTemplateLength {
+		return fmt.Errorf("subject cannot be longer than %d characters", maxEmailSubjectTemplateLength)
+	}
+
+	if len(body) > maxEmailBodyTemplateLength {
+		return fmt.Errorf("body cannot be longer than %d bytes", maxEmailBodyTemplateLength)
+	}
+
+	return email.ValidateCustomisation(name, email.Customisation{Subject: subject, Body: body})
+}
+
+// validateEmailBranding checks the colour is a hex colour and the logo an
+// absolute http(s) URL; both may be empty to keep the defaults.
+func validateEmailBranding(branding *datastore.EmailBranding) error {
+	if !email.ValidBrandColor(branding.BrandColor) {
+		return email.ErrInvalidBrandColor
+	}
+
+	if branding.LogoURL == "" {
+		return nil
+	}
+
+	if len(branding.LogoURL) > maxEmailLogoURLLength {
+		return fmt.Errorf("logo url cannot be longer than %d characters", maxEmailLogoURLLength)
+	}
+
+	u, err := url.Parse(branding.LogoURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("logo url must be an absolute http or https url")
+	}
+
+	return nil
+}
+
+type UpsertEmailTemplateService struct {
+	Repo   datastore.EmailTemplateRepository
+	OrgID  string
+	Update *datastore.EmailTemplate
+	Logger log.Logger
+}
+
+func (s *UpsertEmailTemplateService) Run(ctx context.Context) (*datastore.EmailTemplate, error) {
+	template := s.Update
+	template.Subject = strings.TrimSpace(template.Subject)
+	template.Body = strings.TrimSpace(template.Body)
+
+	if template.Subject == "" && template.Body == "" {
+		return nil, &ServiceError{ErrMsg: "please provide a subject or a body"}
+	}
+
+	if err := validateEmailOverride(email.TemplateName(template.TemplateName), template.Subject, template.Body); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	template.UID = ulid.Make().String()
+	template.OrganisationID = s.OrgID
+
+	if err := s.Repo.UpsertEmailTemplate(ctx, template); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to save email template", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to save email template", Err: err}
+	}
+
+	saved, err := s.Repo.FindEmailTemplate(ctx, s.OrgID, template.TemplateName)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find email template", Err: err}
+	}
+
+	return saved, nil
+}
+
+type UpdateEmailBrandingService struct {
+	Repo     datastore.EmailTemplateRepository
+	OrgID    string
+	Branding *datastore.EmailBranding
+	Logger   log.Logger
+}
+
+func (s *UpdateEmailBrandingService) Run(ctx context.Context) (*datastore.EmailBranding, error) {
+	branding := s.Branding
+	branding.OrganisationID = s.OrgID
+	branding.BrandColor = strings.TrimSpace(branding.BrandColor)
+	branding.LogoURL = strings.TrimSpace(branding.LogoURL)
+
+	if err := validateEmailBranding(branding); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if err := s.Repo.UpsertEmailBranding(ctx, branding); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to save email branding", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to save email branding", Err: err}
+	}
+
+	saved, err := s.Repo.FindEmailBranding(ctx, s.OrgID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find email branding", Err: err}
+	}
+
+	return saved, nil
+}
+
+// EmailPreview is an email rendered with sample variables.
+type EmailPreview struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+}
+
+// PreviewEmailTemplateService renders a subject and body, saved or not, with
+// the template's sample variables and the organisation's branding. Empty
+// fields preview the built-in subject and body.
+type PreviewEmailTemplateService struct {
+	Repo         datastore.EmailTemplateRepository
+	OrgID        string
+	TemplateName email.TemplateName
+	Subject      string
+	Body         string
+}
+
+func (s *PreviewEmailTemplateService) Run(ctx context.Context) (*EmailPreview, error) {
+	subject, body := strings.TrimSpace(s.Subject), strings.TrimSpace(s.Body)
+	if err := validateEmailOverride(s.TemplateName, subject, body); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	c := &email.Customisation{Subject: subject, Body: body}
+
+	branding, err := s.Repo.FindEmailBranding(ctx, s.OrgID)
+	switch {
+	case err == nil:
+		c.BrandColor, c.LogoURL = branding.BrandColor, branding.LogoURL
+	case !errors.Is(err, datastore.ErrEmailBrandingNotFound):
+		return nil, &ServiceError{ErrMsg: "failed to find email branding", Err: err}
+	}
+
+	e := email.NewEmail(nil)
+	rendered, err := e.BuildCustomised(s.TemplateName, testEmailSubject(s.TemplateName), email.SampleParams(s.TemplateName), c)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	return &EmailPreview{Subject: rendered, HTML: e.HTML()}, nil
+}
+
+// SendTestEmailService queues the template, with the organisation's saved
+// override and branding, to Recipient filled with sample variables. It goes
+// through the email worker like a real notification does.
+type SendTestEmailService struct {
+	Queue        queue.Queuer
+	OrgID        string
+	TemplateName email.TemplateName
+	Recipient    string
+}
+
+func (s *SendTestEmailService) Run(ctx context.Context) error {
+	if !email.IsCustomisable(s.TemplateName) {
+		return &ServiceError{ErrMsg: fmt.Sprintf("email template %q cannot be customised", s.TemplateName)}
+	}
+
+	message := email.Message{
+		Email:          s.Recipient,
+		Subject:        testEmailSubject(s.TemplateName),
+		TemplateName:   s.TemplateName,
+		Params:         email.SampleParams(s.TemplateName),
+		OrganisationID: s.OrgID,
+	}
+
+	payload, err := msgpack.EncodeMsgPack(message)
+	if err != nil {
+		return &ServiceError{ErrMsg: "failed to encode test email", Err: err}
+	}
+
+	job := &queue.Job{
+		ID:      ulid.Make().String(),
+		Payload: payload,
+	}
+
+	if err = s.Queue.Write(ctx, convoy.EmailProcessor, convoy.DefaultQueue, job); err != nil {
+		return &ServiceError{ErrMsg: "failed to queue test email", Err: err}
+	}
+
+	return nil
+}
+
+// testEmailSubject is the subject of previews and test sends of a template
+// with no subject override.
+func testEmailSubject(name email.TemplateName) string {
+	return fmt.Sprintf("[Test] %s", name)
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/email"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+)
+
+func TestUpsertEmailTemplateService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		update     *datastore.EmailTemplate
+		dbFn       func(repo *mocks.MockEmailTemplateRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "should_save_override",
+			update: &datastore.EmailTemplate{
+				TemplateName: "endpoint.update",
+				Subject:      "  Acme: {{ .name }} is {{ .endpoint_status }} ",
+				Body:         "<p>{{ .failure_msg }}</p>",
+			},
+			dbFn: func(repo *mocks.MockEmailTemplateRepository) {
+				repo.EXPECT().UpsertEmailTemplate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tmpl *datastore.EmailTemplate) error {
+					require.Equal(t, "org-1", tmpl.OrganisationID)
+					require.Equal(t, "Acme: {{ .name }} is {{ .endpoint_status }}", tmpl.Subject)
+					require.NotEmpty(t, tmpl.UID)
+					return nil
+				})
+				repo.EXPECT().FindEmailTemplate(gomock.Any(), "org-1", "endpoint.update").Return(&datastore.EmailTemplate{}, nil)
+			},
+		},
+		{
+			name:       "should_require_subject_or_body",
+			update:     &datastore.EmailTemplate{TemplateName: "endpoint.update", Subject: "  "},
+			wantErrMsg: "please provide a subject or a body",
+		},
+		{
+			name:       "should_reject_account_emails",
+			update:     &datastore.EmailTemplate{TemplateName: "reset.password", Subject: "Reset"},
+			wantErrMsg: `email template "reset.password" cannot be customised`,
+		},
+		{
+			name:       "should_reject_unknown_variable",
+			update:     &datastore.EmailTemplate{TemplateName: "alert.rule", Body: "{{ .target_url }}"},
+			wantErrMsg: `failed to render body template: template: body:1:3: executing "body" at <.target_url>: map has no entry for key "target_url"`,
+		},
+		{
+			name: "should_fail_on_repo_error",
+			update: &datastore.EmailTemplate{
+				TemplateName: "organisation.invite",
+				Subject:      "Join {{ .organisation_name }}",
+			},
+			dbFn: func(repo *mocks.MockEmailTemplateRepository) {
+				repo.EXPECT().UpsertEmailTemplate(gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+			},
+			wantErrMsg: "failed to save email template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEmailTemplateRepository(ctrl)
+			if tc.dbFn != nil {
+				tc.dbFn(repo)
+			}
+
+			s := &UpsertEmailTemplateService{
+				Repo:   repo,
+				OrgID:  "org-1",
+				Update: tc.update,
+				Logger: log.New("convoy", log.LevelInfo),
+			}
+
+			_, err := s.Run(ctx)
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateEmailBrandingService_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		branding   *datastore.EmailBranding
+		wantErrMsg string
+	}{
+		{
+			name:     "should_save_branding",
+			branding: &datastore.EmailBranding{BrandColor: "#112233", LogoURL: "https://example.com/logo.png"},
+		},
+		{
+			name:     "should_allow_defaults",
+			branding: &datastore.EmailBranding{},
+		},
+		{
+			name:       "should_reject_named_colour",
+			branding:   &datastore.EmailBranding{BrandColor: "red"},
+			wantErrMsg: email.ErrInvalidBrandColor.Error(),
+		},
+		{
+			name:       "should_reject_relative_logo",
+			branding:   &datastore.EmailBranding{LogoURL: "/logo.png"},
+			wantErrMsg: "logo url must be an absolute http or https url",
+		},
+		{
+			name:       "should_reject_script_logo",
+			branding:   &datastore.EmailBranding{LogoURL: "javascript:alert(1)"},
+			wantErrMsg: "logo url must be an absolute http or https url",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEmailTemplateRepository(ctrl)
+			if tc.wantErrMsg == "" {
+				repo.EXPECT().UpsertEmailBranding(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().FindEmailBranding(gomock.Any(), "org-1").Return(tc.branding, nil)
+			}
+
+			s := &UpdateEmailBrandingService{
+				Repo:     repo,
+				OrgID:    "org-1",
+				Branding: tc.branding,
+				Logger:   log.New("convoy", log.LevelInfo),
+			}
+
+			_, err := s.Run(context.Background())
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPreviewEmailTemplateService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEmailTemplateRepository(ctrl)
+	repo.EXPECT().FindEmailBranding(gomock.Any(), "org-1").Return(&datastore.EmailBranding{
+		BrandColor: "#112233",
+		LogoURL:    "https://example.com/acme.png",
+	}, nil)
+
+	s := &PreviewEmailTemplateService{
+		Repo:         repo,
+		OrgID:        "org-1",
+		TemplateName: email.TemplateAlertRule,
+		Subject:      "{{ .rule_name }} is {{ .alert_state }}",
+		Body:         "<p>{{ .condition }}</p>",
+	}
+
+	preview, err := s.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "checkout failures is firing", preview.Subject)
+	require.Contains(t, preview.HTML, "<p>failure rate above 5%</p>")
+	require.Contains(t, preview.HTML, "#112233")
+	require.Contains(t, preview.HTML, "https://example.com/acme.png")
+}
+
+func TestSendTestEmailService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q := mocks.NewMockQueuer(ctrl)
+	q.EXPECT().Write(gomock.Any(), convoy.EmailProcessor, convoy.DefaultQueue, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+			var message email.Message
+			require.NoError(t, msgpack.DecodeMsgPack(job.Payload, &message))
+			require.Equal(t, "admin@example.com", message.Email)
+			require.Equal(t, "org-1", message.OrganisationID)
+			require.Equal(t, email.TemplateEndpointUpdate, message.TemplateName)
+			return nil
+		})
+
+	s := &SendTestEmailService{
+		Queue:        q,
+		OrgID:        "org-1",
+		TemplateName: email.TemplateEndpointUpdate,
+		Recipient:    "admin@example.com",
+	}
+	require.NoError(t, s.Run(context.Background()))
+
+	s.TemplateName = email.TemplateEmailVerification
+	require.Error(t, s.Run(context.Background()))
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/clock"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+const (
+	// The checker runs once a minute, so shorter intervals cannot be honoured.
+	minHealthCheckInterval     = 60
+	maxHealthCheckInterval     = 24 * 60 * 60
+	defaultHealthCheckTimeout  = 10
+	maxHealthCheckTimeout      = 30
+	defaultHealthCheckFailures = 3
+
+	healthCheckBatchSize       = 500
+	maxConcurrentHealthChecks  = 10
+	healthCheckResultRetention = 30 * 24 * time.Hour
+)
+
+var healthCheckMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodOptions: true,
+}
+
+// UpsertEndpointHealthCheckService creates or replaces an endpoint's health
+// check configuration. The running state is kept; the next check is due
+// immediately so the new configuration is tried straight away.
+type UpsertEndpointHealthCheckService struct {
+	Repo         datastore.EndpointHealthRepository
+	EndpointRepo datastore.EndpointRepository
+	ProjectID    string
+	EndpointID   string
+	Check        *datastore.EndpointHealthCheck
+	Logger       log.Logger
+}
+
+func (s *UpsertEndpointHealthCheckService) Run(ctx context.Context) (*datastore.EndpointHealthCheck, error) {
+	if err := validateEndpointHealthCheck(s.Check); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	_, err := s.EndpointRepo.FindEndpointByID(ctx, s.EndpointID, s.ProjectID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to find endpoint", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+	}
+
+	s.Check.ProjectID = s.ProjectID
+	s.Check.EndpointID = s.EndpointID
+	if err = s.Repo.UpsertEndpointHealthCheck(ctx, s.Check); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to save endpoint health check", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to save endpoint health check", Err: err}
+	}
+
+	check, err := s.Repo.FindEndpointHealthCheck(ctx, s.ProjectID, s.EndpointID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to find endpoint health check", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find endpoint health check", Err: err}
+	}
+
+	return check, nil
+}
+
+// validateEndpointHealthCheck fills in defaults for unset fields and rejects
+// values the checker cannot honour.
+func validateEndpointHealthCheck(c *datastore.EndpointHealthCheck) error {
+	c.Method = strings.ToUpper(c.Method)
+	if c.Method == "" {
+		c.Method = http.MethodGet
+	}
+	if !healthCheckMethods[c.Method] {
+		return fmt.Errorf("unsupported health check method - %s", c.Method)
+	}
+
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		return errors.New("path must start with /")
+	}
+
+	if c.ExpectedStatus != 0 && (c.ExpectedStatus < 100 || c.ExpectedStatus > 599) {
+		return errors.New("expected_status must be a valid http status code")
+	}
+
+	if c.IntervalSeconds == 0 {
+		c.IntervalSeconds = minHealthCheckInterval
+	}
+	if c.IntervalSeconds < minHealthCheckInterval || c.IntervalSeconds > maxHealthCheckInterval {
+		return fmt.Errorf("interval must be between %d and %d seconds", minHealthCheckInterval, maxHealthCheckInterval)
+	}
+
+	if c.TimeoutSeconds == 0 {
+		c.TimeoutSeconds = defaultHealthCheckTimeout
+	}
+	if c.TimeoutSeconds > maxHealthCheckTimeout {
+		return fmt.Errorf("timeout must not exceed %d seconds", maxHealthCheckTimeout)
+	}
+
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = defaultHealthCheckFailures
+	}
+	if c.FailureThreshold < 1 || c.FailureThreshold > 100 {
+		return errors.New("failure_threshold must be between 1 and 100")
+	}
+
+	return nil
+}
+
+// EndpointHealthDispatcher is the part of net.Dispatcher the checker needs.
+type EndpointHealthDispatcher interface {
+	CheckHealth(ctx context.Context, opts net.HealthCheckOptions) (*net.HealthCheckResult, error)
+}
+
+// EndpointHealthChecker runs the health checks that are due, records their
+// results and, where configured, pauses an endpoint that keeps failing and
+// activates it again once it recovers. It only reactivates endpoints it paused
+// itself or that were disabled for failing deliveries; an endpoint a user
+// paused stays paused.
+type EndpointHealthChecker struct {
+	Repo         datastore.EndpointHealthRepository
+	EndpointRepo datastore.EndpointRepository
+	Dispatcher   EndpointHealthDispatcher
+	// OAuth2TokenService authenticates checks against OAuth2 endpoints; nil
+	// sends those checks without an Authorization header.
+	OAuth2TokenService *OAuth2TokenService
+	Licenser           license.Licenser
+	Clock              clock.Clock
+	Logger             log.Logger
+}
+
+func (c *EndpointHealthChecker) Run(ctx context.Context) error {
+	now := c.Clock.Now()
+
+	if err := c.Repo.DeleteEndpointHealthCheckResultsBefore(ctx, now.Add(-healthCheckResultRetention)); err != nil {
+		c.Logger.ErrorContext(ctx, "failed to prune endpoint health check results", "error", err)
+	}
+
+	checks, err := c.Repo.FetchDueEndpointHealthChecks(ctx, now, healthCheckBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentHealthChecks)
+	for i := range checks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(check *datastore.EndpointHealthCheck) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			c.check(ctx, check)
+		}(&checks[i])
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (c *EndpointHealthChecker) check(ctx context.Context, check *datastore.EndpointHealthCheck) {
+	endpoint, err := c.EndpointRepo.FindEndpointByID(ctx, check.EndpointID, check.ProjectID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointNotFound) {
+			if err = c.Repo.DeleteEndpointHealthCheck(ctx, check.ProjectID, check.EndpointID); err != nil {
+				c.Logger.ErrorContext(ctx, "failed to delete orphaned endpoint health check", "endpoint_id", check.EndpointID, "error", err)
+			}
+			return
+		}
+		c.Logger.ErrorContext(ctx, "failed to find endpoint for health check", "endpoint_id", check.EndpointID, "error", err)
+		return
+	}
+
+	now := c.Clock.Now()
+	result := &datastore.EndpointHealthCheckResult{
+		ProjectID:  check.ProjectID,
+		EndpointID: check.EndpointID,
+		CheckedAt:  now,
+	}
+
+	opts, err := c.options(endpoint, check)
+	if err == nil {
+		var res *net.HealthCheckResult
+		res, err = c.Dispatcher.CheckHealth(ctx, opts)
+		if res != nil {
+			result.StatusCode = res.StatusCode
+			result.LatencyMs = res.Latency.Milliseconds()
+		}
+	}
+	result.Healthy = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	if err = c.Repo.CreateEndpointHealthCheckResult(ctx, result); err != nil {
+		c.Logger.ErrorContext(ctx, "failed to record endpoint health check result", "endpoint_id", check.EndpointID, "error", err)
+	}
+
+	c.apply(ctx, endpoint, check, result.Healthy)
+
+	check.LastCheckedAt = &now
+	check.NextCheckAt = now.Add(time.Duration(check.IntervalSeconds) * time.Second)
+	if err = c.Repo.UpdateEndpointHealthCheckState(ctx, check); err != nil {
+		c.Logger.ErrorContext(ctx, "failed to update endpoint health check", "endpoint_id", check.EndpointID, "error", err)
+	}
+}
+
+// apply moves the check's running state on by one result and pauses or
+// activates the endpoint when the check asks for it.
+func (c *EndpointHealthChecker) apply(ctx context.Context, endpoint *datastore.Endpoint, check *datastore.EndpointHealthCheck, healthy bool) {
+	// A paused endpoint the checker did not pause, or one a user has since
+	// resumed, is no longer the checker's to manage.
+	if check.PausedByCheck && endpoint.Status != datastore.PausedEndpointStatus {
+		check.PausedByCheck = false
+	}
+
+	if healthy {
+		check.ConsecutiveFailures = 0
+		check.LastStatus = datastore.EndpointHealthy
+
+		if !check.AutoActivate {
+			return
+		}
+
+		pausedByCheck := endpoint.Status == datastore.PausedEndpointStatus && check.PausedByCheck
+		if pausedByCheck || endpoint.Status == datastore.InactiveEndpointStatus {
+			if c.updateStatus(ctx, endpoint, datastore.ActiveEndpointStatus) {
+				check.PausedByCheck = false
+			}
+		}
+		return
+	}
+
+	check.ConsecutiveFailures++
+	if check.ConsecutiveFailures < check.FailureThreshold {
+		return
+	}
+	check.LastStatus = datastore.EndpointUnhealthy
+
+	if check.AutoPause && endpoint.Status == datastore.ActiveEndpointStatus {
+		if c.updateStatus(ctx, endpoint, datastore.PausedEndpointStatus) {
+			check.PausedByCheck = true
+		}
+	}
+}
+
+func (c *EndpointHealthChecker) updateStatus(ctx context.Context, endpoint *datastore.Endpoint, status datastore.EndpointStatus) bool {
+	changed, err := c.EndpointRepo.UpdateEndpointStatus(ctx, endpoint.ProjectID, endpoint.UID, status)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "failed to update endpoint status from health check", "endpoint_id", endpoint.UID, "status", status, "error", err)
+		return false
+	}
+
+	if changed {
+		c.Logger.InfoContext(ctx, "endpoint status updated from health check", "endpoint_id", endpoint.UID, "status", status)
+	}
+	return changed
+}
+
+func (c *EndpointHealthChecker) options(endpoint *datastore.Endpoint, check *datastore.EndpointHealthCheck) (net.HealthCheckOptions, error) {
+	url := endpoint.Url
+	if check.Path != "" {
+		url = strings.TrimSuffix(endpoint.Url, "/") + check.Path
+	}
+
+	opts := net.HealthCheckOptions{
+		URL:            url,
+		Method:         check.Method,
+		Timeout:        time.Duration(check.TimeoutSeconds) * time.Second,
+		ExpectedStatus: check.ExpectedStatus,
+		Headers:        http.Header{},
+	}
+
+	if auth := endpoint.Authentication; auth != nil {
+		switch auth.Type {
+		case datastore.APIKeyAuthentication:
+			if auth.ApiKey != nil {
+				opts.Headers.Set(auth.ApiKey.HeaderName, auth.ApiKey.HeaderValue)
+			}
+		case datastore.BasicAuthentication:
+			if auth.BasicAuth != nil {
+				req := &http.Request{Header: opts.Headers}
+				req.SetBasicAuth(auth.BasicAuth.UserName, auth.BasicAuth.Password)
+			}
+		case datastore.OAuth2Authentication:
+			if c.OAuth2TokenService != nil {
+				opts.OAuth2TokenGetter = func(ctx context.Context) (string, error) {
+					return c.OAuth2TokenService.GetAuthorizationHeader(ctx, endpoint)
+				}
+			}
+		}
+	}
+
+	if endpoint.MtlsClientCert != nil && c.Licenser.MutualTLS() {
+		cert, err := config.LoadClientCertificateWithCache(endpoint.UID, endpoint.MtlsClientCert.ClientCert, endpoint.MtlsClientCert.ClientKey)
+		if err != nil {
+			return opts, fmt.Errorf("failed to load mTLS client certificate: %w", err)
+		}
+		opts.MtlsCert = cert
+	}
+
+	return opts, nil
+}
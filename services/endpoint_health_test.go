@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/clock"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+type fakeHealthDispatcher struct {
+	err  error
+	opts []net.HealthCheckOptions
+}
+
+func (f *fakeHealthDispatcher) CheckHealth(_ context.Context, opts net.HealthCheckOptions) (*net.HealthCheckResult, error) {
+	f.opts = append(f.opts, opts)
+	status := http.StatusOK
+	if f.err != nil {
+		status = http.StatusServiceUnavailable
+	}
+	return &net.HealthCheckResult{StatusCode: status, Latency: 25 * time.Millisecond}, f.err
+}
+
+func TestUpsertEndpointHealthCheckService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		check      *datastore.EndpointHealthCheck
+		want       *datastore.EndpointHealthCheck
+		wantErrMsg string
+	}{
+		{
+			name:  "should_apply_defaults",
+			check: &datastore.EndpointHealthCheck{Path: "/health"},
+			want: &datastore.EndpointHealthCheck{
+				ProjectID: "abc", EndpointID: "123", Method: "GET", Path: "/health",
+				IntervalSeconds: 60, TimeoutSeconds: 10, FailureThreshold: 3,
+			},
+		},
+		{
+			name:       "should_reject_interval_below_a_minute",
+			check:      &datastore.EndpointHealthCheck{IntervalSeconds: 30},
+			wantErrMsg: "interval must be between 60 and 86400 seconds",
+		},
+		{
+			name:       "should_reject_relative_path",
+			check:      &datastore.EndpointHealthCheck{Path: "health"},
+			wantErrMsg: "path must start with /",
+		},
+		{
+			name:       "should_reject_unknown_method",
+			check:      &datastore.EndpointHealthCheck{Method: "delete"},
+			wantErrMsg: "unsupported health check method - DELETE",
+		},
+		{
+			name:       "should_reject_invalid_expected_status",
+			check:      &datastore.EndpointHealthCheck{ExpectedStatus: 1000},
+			wantErrMsg: "expected_status must be a valid http status code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEndpointHealthRepository(ctrl)
+			endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+			if tt.want != nil {
+				endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Return(&datastore.Endpoint{UID: "123"}, nil)
+				repo.EXPECT().UpsertEndpointHealthCheck(gomock.Any(), tt.want).Return(nil)
+				repo.EXPECT().FindEndpointHealthCheck(gomock.Any(), "abc", "123").Return(tt.want, nil)
+			}
+
+			s := &UpsertEndpointHealthCheckService{
+				Repo:         repo,
+				EndpointRepo: endpointRepo,
+				ProjectID:    "abc",
+				EndpointID:   "123",
+				Check:        tt.check,
+				Logger:       log.New("convoy", log.LevelError),
+			}
+
+			check, err := s.Run(ctx)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, check)
+		})
+	}
+}
+
+func TestEndpointHealthChecker_Run(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		check             datastore.EndpointHealthCheck
+		status            datastore.EndpointStatus
+		dispatchErr       error
+		wantStatus        datastore.EndpointStatus
+		wantState         string
+		wantFailures      int
+		wantPausedByCheck bool
+	}{
+		{
+			name:         "should_count_failures_below_threshold",
+			check:        datastore.EndpointHealthCheck{FailureThreshold: 3, ConsecutiveFailures: 1, AutoPause: true},
+			status:       datastore.ActiveEndpointStatus,
+			dispatchErr:  net.ErrNon2xxResponse,
+			wantFailures: 2,
+		},
+		{
+			name:              "should_pause_endpoint_at_threshold",
+			check:             datastore.EndpointHealthCheck{FailureThreshold: 3, ConsecutiveFailures: 2, AutoPause: true},
+			status:            datastore.ActiveEndpointStatus,
+			dispatchErr:       net.ErrNon2xxResponse,
+			wantStatus:        datastore.PausedEndpointStatus,
+			wantState:         datastore.EndpointUnhealthy,
+			wantFailures:      3,
+			wantPausedByCheck: true,
+		},
+		{
+			name:       "should_activate_endpoint_it_paused",
+			check:      datastore.EndpointHealthCheck{FailureThreshold: 3, ConsecutiveFailures: 5, AutoActivate: true, PausedByCheck: true},
+			status:     datastore.PausedEndpointStatus,
+			wantStatus: datastore.ActiveEndpointStatus,
+			wantState:  datastore.EndpointHealthy,
+		},
+		{
+			name:       "should_activate_endpoint_disabled_for_failures",
+			check:      datastore.EndpointHealthCheck{FailureThreshold: 3, AutoActivate: true},
+			status:     datastore.InactiveEndpointStatus,
+			wantStatus: datastore.ActiveEndpointStatus,
+			wantState:  datastore.EndpointHealthy,
+		},
+		{
+			name:      "should_leave_user_paused_endpoint_paused",
+			check:     datastore.EndpointHealthCheck{FailureThreshold: 3, AutoActivate: true},
+			status:    datastore.PausedEndpointStatus,
+			wantState: datastore.EndpointHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEndpointHealthRepository(ctrl)
+			endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+			licenser := mocks.NewMockLicenser(ctrl)
+			dispatcher := &fakeHealthDispatcher{err: tt.dispatchErr}
+
+			check := tt.check
+			check.ProjectID, check.EndpointID = "abc", "123"
+			check.Method, check.Path, check.IntervalSeconds, check.TimeoutSeconds = "GET", "/health", 120, 5
+
+			repo.EXPECT().DeleteEndpointHealthCheckResultsBefore(gomock.Any(), now.Add(-healthCheckResultRetention)).Return(nil)
+			repo.EXPECT().FetchDueEndpointHealthChecks(gomock.Any(), now, healthCheckBatchSize).
+				Return([]datastore.EndpointHealthCheck{check}, nil)
+			endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Return(&datastore.Endpoint{
+				UID: "123", ProjectID: "abc", Url: "https://example.com/webhook/", Status: tt.status,
+				Authentication: &datastore.EndpointAuthentication{
+					Type:   datastore.APIKeyAuthentication,
+					ApiKey: &datastore.ApiKey{HeaderName: "X-Api-Key", HeaderValue: "secret"},
+				},
+			}, nil)
+			repo.EXPECT().CreateEndpointHealthCheckResult(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, r *datastore.EndpointHealthCheckResult) error {
+					require.Equal(t, tt.dispatchErr == nil, r.Healthy)
+					require.Equal(t, int64(25), r.LatencyMs)
+					return nil
+				})
+			if tt.wantStatus != "" {
+				endpointRepo.EXPECT().UpdateEndpointStatus(gomock.Any(), "abc", "123", tt.wantStatus).Return(true, nil)
+			}
+			repo.EXPECT().UpdateEndpointHealthCheckState(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, c *datastore.EndpointHealthCheck) error {
+					require.Equal(t, tt.wantState, c.LastStatus)
+					require.Equal(t, tt.wantFailures, c.ConsecutiveFailures)
+					require.Equal(t, tt.wantPausedByCheck, c.PausedByCheck)
+					require.Equal(t, now, *c.LastCheckedAt)
+					require.Equal(t, now.Add(2*time.Minute), c.NextCheckAt)
+					return nil
+				})
+
+			checker := &EndpointHealthChecker{
+				Repo:         repo,
+				EndpointRepo: endpointRepo,
+				Dispatcher:   dispatcher,
+				Licenser:     licenser,
+				Clock:        clock.NewSimulatedClock(now),
+				Logger:       log.New("convoy", log.LevelError),
+			}
+
+			require.NoError(t, checker.Run(context.Background()))
+			require.Len(t, dispatcher.opts, 1)
+			require.Equal(t, "https://example.com/webhook/health", dispatcher.opts[0].URL)
+			require.Equal(t, "secret", dispatcher.opts[0].Headers.Get("X-Api-Key"))
+		})
+	}
+}
+
+func TestEndpointHealthChecker_DeletesOrphanedChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := mocks.NewMockEndpointHealthRepository(ctrl)
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+
+	repo.EXPECT().DeleteEndpointHealthCheckResultsBefore(gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+	repo.EXPECT().FetchDueEndpointHealthChecks(gomock.Any(), now, healthCheckBatchSize).
+		Return([]datastore.EndpointHealthCheck{{ProjectID: "abc", EndpointID: "123"}}, nil)
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").Return(nil, datastore.ErrEndpointNotFound)
+	repo.EXPECT().DeleteEndpointHealthCheck(gomock.Any(), "abc", "123").Return(nil)
+
+	checker := &EndpointHealthChecker{
+		Repo:         repo,
+		EndpointRepo: endpointRepo,
+		Dispatcher:   &fakeHealthDispatcher{},
+		Clock:        clock.NewSimulatedClock(now),
+		Logger:       log.New("convoy", log.LevelError),
+	}
+
+	require.NoError(t, checker.Run(context.Background()))
+}
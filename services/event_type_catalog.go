@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/openapi"
+)
+
+// EventTypeCatalogService builds the webhook catalog of a project from its
+// event types and signature configuration.
+type EventTypeCatalogService struct {
+	EventTypesRepo datastore.EventTypesRepository
+	Project        *datastore.Project
+
+	// PortalLink, when set, limits the catalog to the event types the
+	// portal link may subscribe to.
+	PortalLink *datastore.PortalLink
+}
+
+func (s *EventTypeCatalogService) Run(ctx context.Context) (*openapi.Catalog, error) {
+	eventTypes, err := s.EventTypesRepo.FetchAllEventTypes(ctx, s.Project.UID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to load event types", Err: err}
+	}
+
+	catalog := &openapi.Catalog{
+		Title:       fmt.Sprintf("%s webhooks", s.Project.Name),
+		Description: fmt.Sprintf("The webhook events sent by %s.", s.Project.Name),
+		EventTypes:  make([]openapi.CatalogEventType, 0, len(eventTypes)),
+	}
+
+	// The catalog only changes when an event type does, so its version is the
+	// date of the latest change.
+	var latest time.Time
+	for _, et := range eventTypes {
+		if s.PortalLink != nil && !s.PortalLink.AllowsEventType(et.Name) {
+			continue
+		}
+
+		if et.UpdatedAt.After(latest) {
+			latest = et.UpdatedAt
+		}
+
+		catalog.EventTypes = append(catalog.EventTypes, openapi.CatalogEventType{
+			Name:        et.Name,
+			Description: et.Description,
+			Category:    et.Category,
+			Schema:      et.JSONSchema,
+			Deprecated:  et.DeprecatedAt.Valid,
+		})
+	}
+
+	catalog.Version = "1.0.0"
+	if !latest.IsZero() {
+		catalog.Version = latest.UTC().Format("2006-01-02")
+	}
+
+	if s.Project.Config != nil && s.Project.Config.Signature != nil {
+		sig := s.Project.Config.Signature
+		catalog.SignatureHeader = string(sig.Header)
+		for _, v := range sig.Versions {
+			catalog.SignatureSchemes = append(catalog.SignatureSchemes, openapi.CatalogSignatureScheme{
+				Hash:     v.Hash,
+				Encoding: string(v.Encoding),
+			})
+		}
+	}
+
+	return catalog, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+)
+
+func TestEventTypeCatalogService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_build_catalog", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypesRepository(ctrl)
+
+		updated := time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC)
+		repo.EXPECT().FetchAllEventTypes(gomock.Any(), "project-1").Return([]datastore.ProjectEventType{
+			{Name: "invoice.paid", Category: "billing", JSONSchema: []byte(`{"type":"object"}`), UpdatedAt: updated.Add(-time.Hour)},
+			{Name: "invoice.voided", DeprecatedAt: null.TimeFrom(updated), UpdatedAt: updated},
+		}, nil)
+
+		s := &EventTypeCatalogService{
+			EventTypesRepo: repo,
+			Project: &datastore.Project{
+				UID:    "project-1",
+				Name:   "Acme",
+				Config: &datastore.ProjectConfig{Signature: datastore.GetDefaultSignatureConfig()},
+			},
+		}
+
+		catalog, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "Acme webhooks", catalog.Title)
+		require.Equal(t, "2026-09-30", catalog.Version)
+		require.Equal(t, "X-Convoy-Signature", catalog.SignatureHeader)
+		require.NotEmpty(t, catalog.SignatureSchemes)
+		require.Len(t, catalog.EventTypes, 2)
+		require.False(t, catalog.EventTypes[0].Deprecated)
+		require.True(t, catalog.EventTypes[1].Deprecated)
+	})
+
+	t.Run("should_build_empty_catalog_without_signature_config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypesRepository(ctrl)
+		repo.EXPECT().FetchAllEventTypes(gomock.Any(), "project-1").Return(nil, nil)
+
+		s := &EventTypeCatalogService{EventTypesRepo: repo, Project: &datastore.Project{UID: "project-1", Name: "Acme"}}
+
+		catalog, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "1.0.0", catalog.Version)
+		require.Empty(t, catalog.SignatureHeader)
+		require.Empty(t, catalog.EventTypes)
+	})
+
+	t.Run("should_fail_when_event_types_cannot_be_loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypesRepository(ctrl)
+		repo.EXPECT().FetchAllEventTypes(gomock.Any(), "project-1").Return(nil, errors.New("boom"))
+
+		s := &EventTypeCatalogService{EventTypesRepo: repo, Project: &datastore.Project{UID: "project-1"}}
+
+		_, err := s.Run(ctx)
+		require.ErrorContains(t, err, "failed to load event types")
+	})
+}
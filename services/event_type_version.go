@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+// sunsetBatchSize bounds how many sunsets one run announces.
+const sunsetBatchSize = 100
+
+type CreateEventTypeVersionService struct {
+	EventTypeRepo datastore.EventTypesRepository
+	VersionRepo   datastore.EventTypeVersionRepository
+	ProjectID     string
+	EventTypeID   string
+	Version       *datastore.EventTypeVersion
+	Logger        log.Logger
+}
+
+func (s *CreateEventTypeVersionService) Run(ctx context.Context) (*datastore.EventTypeVersion, error) {
+	// The event type repository already reports a missing event type as a 404.
+	eventType, err := s.EventTypeRepo.FetchEventTypeById(ctx, s.EventTypeID, s.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := s.Version
+	version.UID = ulid.Make().String()
+	version.ProjectID = s.ProjectID
+	version.EventTypeID = eventType.UID
+	version.EventType = eventType.Name
+
+	err = s.VersionRepo.CreateEventTypeVersion(ctx, version)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to create event type version", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to create event type version", Err: err}
+	}
+
+	return version, nil
+}
+
+type UpdateEventTypeVersionService struct {
+	VersionRepo datastore.EventTypeVersionRepository
+	ProjectID   string
+	EventTypeID string
+	Version     int
+	Update      *models.UpdateEventTypeVersion
+	Logger      log.Logger
+}
+
+func (s *UpdateEventTypeVersionService) Run(ctx context.Context) (*datastore.EventTypeVersion, error) {
+	version, err := s.VersionRepo.FindEventTypeVersion(ctx, s.ProjectID, s.EventTypeID, s.Version)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find event type version", Err: err}
+	}
+
+	if err = s.Update.Apply(version); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	err = s.VersionRepo.UpdateEventTypeVersion(ctx, version)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to update event type version", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to update event type version", Err: err}
+	}
+
+	return version, nil
+}
+
+// PinEventTypeVersionService pins a subscription to a version of an event
+// type, replacing any pin it already has for that event type.
+type PinEventTypeVersionService struct {
+	VersionRepo    datastore.EventTypeVersionRepository
+	ProjectID      string
+	SubscriptionID string
+	EventType      string
+	Version        int
+	Logger         log.Logger
+}
+
+func (s *PinEventTypeVersionService) Run(ctx context.Context) (*datastore.EventTypeVersionPin, error) {
+	if err := CheckEventTypeVersion(ctx, s.VersionRepo, s.ProjectID, s.EventType, s.Version); err != nil {
+		return nil, err
+	}
+
+	pin := &datastore.EventTypeVersionPin{
+		UID:            ulid.Make().String(),
+		ProjectID:      s.ProjectID,
+		SubscriptionID: s.SubscriptionID,
+		EventType:      s.EventType,
+		Version:        s.Version,
+	}
+
+	err := s.VersionRepo.PinSubscriptionVersion(ctx, pin)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to pin event type version", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to pin event type version", Err: err}
+	}
+
+	return pin, nil
+}
+
+// CheckEventTypeVersion reports whether an event type version can still be
+// published or pinned. Version 0 names no version and is always accepted.
+func CheckEventTypeVersion(ctx context.Context, repo datastore.EventTypeVersionRepository, projectID, eventType string, version int) error {
+	if version < 0 {
+		return &ServiceError{ErrMsg: "event type version must be positive"}
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	versions, err := repo.LoadEventTypeVersions(ctx, projectID, eventType)
+	if err != nil {
+		return &ServiceError{ErrMsg: "failed to load event type versions", Err: err}
+	}
+
+	for _, v := range versions {
+		if v.Version != version {
+			continue
+		}
+
+		if v.IsSunset(time.Now()) {
+			return &ServiceError{ErrMsg: fmt.Sprintf("version %d of %s was sunset on %s", version, eventType, v.SunsetAt.Time.Format(time.RFC3339))}
+		}
+		return nil
+	}
+
+	return &ServiceError{
+		ErrMsg: fmt.Sprintf("%s has no version %d", eventType, version),
+		Err:    datastore.ErrEventTypeVersionNotFound,
+	}
+}
+
+// EventTypeVersionSunsetNotifier announces the event type versions whose
+// sunset has passed as meta events, once each.
+type EventTypeVersionSunsetNotifier struct {
+	VersionRepo datastore.EventTypeVersionRepository
+	MetaEvent   *MetaEvent
+	Logger      log.Logger
+}
+
+func (n *EventTypeVersionSunsetNotifier) Run(ctx context.Context) error {
+	for {
+		versions, err := n.VersionRepo.LoadDueEventTypeVersionSunsets(ctx, time.Now(), sunsetBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range versions {
+			v := &versions[i]
+
+			// The meta event is best effort: a project without meta events,
+			// or one that fails to send, must not hold the sunset back.
+			if err = n.MetaEvent.Run(ctx, string(datastore.EventTypeVersionSunset), v.ProjectID, v); err != nil {
+				n.Logger.ErrorContext(ctx, "event type version sunset meta event failed", "project_id", v.ProjectID, "error", err)
+			}
+
+			err = n.VersionRepo.MarkEventTypeVersionSunsetNotified(ctx, v.ProjectID, v.UID)
+			if err != nil && !errors.Is(err, datastore.ErrEventTypeVersionNotFound) {
+				return err
+			}
+		}
+
+		if len(versions) < sunsetBatchSize {
+			return nil
+		}
+	}
+}
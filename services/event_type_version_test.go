@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestCheckEventTypeVersion(t *testing.T) {
+	ctx := context.Background()
+	versions := []datastore.EventTypeVersion{
+		{Version: 1, SunsetAt: null.TimeFrom(time.Now().Add(-time.Hour))},
+		{Version: 2, SunsetAt: null.TimeFrom(time.Now().Add(time.Hour))},
+		{Version: 3},
+	}
+
+	tests := []struct {
+		name       string
+		version    int
+		wantErrMsg string
+		wantErr    error
+	}{
+		{name: "should_accept_no_version", version: 0},
+		{name: "should_accept_current_version", version: 3},
+		{name: "should_accept_version_before_its_sunset", version: 2},
+		{name: "should_reject_negative_version", version: -1, wantErrMsg: "event type version must be positive"},
+		{name: "should_reject_sunset_version", version: 1, wantErrMsg: "version 1 of invoice.paid was sunset on"},
+		{name: "should_reject_unknown_version", version: 4, wantErrMsg: "invoice.paid has no version 4", wantErr: datastore.ErrEventTypeVersionNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+			if tc.version > 0 {
+				repo.EXPECT().LoadEventTypeVersions(gomock.Any(), "project-1", "invoice.paid").Return(versions, nil)
+			}
+
+			err := CheckEventTypeVersion(ctx, repo, "project-1", "invoice.paid", tc.version)
+			if tc.wantErrMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.wantErrMsg)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateEventTypeVersionService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	eventTypeRepo := mocks.NewMockEventTypesRepository(ctrl)
+	versionRepo := mocks.NewMockEventTypeVersionRepository(ctrl)
+
+	eventTypeRepo.EXPECT().FetchEventTypeById(gomock.Any(), "event-type-1", "project-1").
+		Return(&datastore.ProjectEventType{UID: "event-type-1", Name: "invoice.paid"}, nil)
+	versionRepo.EXPECT().CreateEventTypeVersion(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, v *datastore.EventTypeVersion) error {
+			v.Version = 2
+			return nil
+		})
+
+	s := &CreateEventTypeVersionService{
+		EventTypeRepo: eventTypeRepo,
+		VersionRepo:   versionRepo,
+		ProjectID:     "project-1",
+		EventTypeID:   "event-type-1",
+		Version:       &datastore.EventTypeVersion{JSONSchema: []byte("{}")},
+		Logger:        log.New("convoy", log.LevelInfo),
+	}
+
+	version, err := s.Run(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, version.UID)
+	require.Equal(t, "project-1", version.ProjectID)
+	require.Equal(t, "invoice.paid", version.EventType)
+	require.Equal(t, 2, version.Version)
+}
+
+func TestPinEventTypeVersionService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_pin_known_version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+		repo.EXPECT().LoadEventTypeVersions(gomock.Any(), "project-1", "invoice.paid").
+			Return([]datastore.EventTypeVersion{{Version: 1}, {Version: 2}}, nil)
+		repo.EXPECT().PinSubscriptionVersion(gomock.Any(), gomock.Any()).Return(nil)
+
+		s := &PinEventTypeVersionService{
+			VersionRepo:    repo,
+			ProjectID:      "project-1",
+			SubscriptionID: "sub-1",
+			EventType:      "invoice.paid",
+			Version:        1,
+			Logger:         log.New("convoy", log.LevelInfo),
+		}
+
+		pin, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "sub-1", pin.SubscriptionID)
+		require.Equal(t, 1, pin.Version)
+	})
+
+	t.Run("should_not_pin_unknown_version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+		repo.EXPECT().LoadEventTypeVersions(gomock.Any(), "project-1", "invoice.paid").
+			Return([]datastore.EventTypeVersion{{Version: 1}}, nil)
+
+		s := &PinEventTypeVersionService{
+			VersionRepo:    repo,
+			ProjectID:      "project-1",
+			SubscriptionID: "sub-1",
+			EventType:      "invoice.paid",
+			Version:        3,
+			Logger:         log.New("convoy", log.LevelInfo),
+		}
+
+		_, err := s.Run(ctx)
+		require.ErrorIs(t, err, datastore.ErrEventTypeVersionNotFound)
+	})
+}
+
+func TestEventTypeVersionSunsetNotifier_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	versionRepo := mocks.NewMockEventTypeVersionRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+
+	due := []datastore.EventTypeVersion{
+		{UID: "v1", ProjectID: "project-1", EventType: "invoice.paid", Version: 1},
+		{UID: "v2", ProjectID: "project-2", EventType: "invoice.paid", Version: 1},
+	}
+	versionRepo.EXPECT().LoadDueEventTypeVersionSunsets(gomock.Any(), gomock.Any(), sunsetBatchSize).Return(due, nil)
+
+	// A failing meta event is logged and the sunset is still marked.
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "project-1").Return(&datastore.Project{UID: "project-1", Config: &datastore.ProjectConfig{}}, nil)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "project-2").Return(nil, errors.New("project lookup failed"))
+
+	versionRepo.EXPECT().MarkEventTypeVersionSunsetNotified(gomock.Any(), "project-1", "v1").Return(nil)
+	versionRepo.EXPECT().MarkEventTypeVersionSunsetNotified(gomock.Any(), "project-2", "v2").Return(nil)
+
+	logger := log.New("convoy", log.LevelInfo)
+	n := &EventTypeVersionSunsetNotifier{
+		VersionRepo: versionRepo,
+		MetaEvent:   NewMetaEvent(mocks.NewMockQueuer(ctrl), projectRepo, mocks.NewMockMetaEventRepository(ctrl), logger),
+		Logger:      logger,
+	}
+
+	require.NoError(t, n.Run(context.Background()))
+}
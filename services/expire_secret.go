@@ -61,9 +61,10 @@ func (a *ExpireSecretService) Run(ctx context.Context) (*datastore.Endpoint, err
 	}
 
 	job := &queue.Job{
-		ID:      secret.UID,
-		Payload: bytes,
-		Delay:   time.Hour * time.Duration(a.S.Expiration),
+		ID:        secret.UID,
+		Payload:   bytes,
+		Delay:     time.Hour * time.Duration(a.S.Expiration),
+		ProjectID: a.Project.UID,
 	}
 
 	taskName := convoy.ExpireSecretsProcessor
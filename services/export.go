@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/worker/task"
+)
+
+// ResolveExportService works out what an export covers. An export naming a
+// saved search takes the search's resource and filter; dates given with the
+// export replace the search's, so one search can be exported for any window.
+type ResolveExportService struct {
+	SavedSearchRepo datastore.SavedSearchRepository
+	ProjectID       string
+	Job             *datastore.ExportJob
+}
+
+func (s *ResolveExportService) Run(ctx context.Context) (*datastore.ExportJob, error) {
+	job := s.Job
+
+	if job.SavedSearchID != "" {
+		search, err := s.SavedSearchRepo.FindSavedSearchByID(ctx, s.ProjectID, job.SavedSearchID)
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: "failed to find saved search", Err: err}
+		}
+
+		filter := search.Filter
+		if !job.Filter.StartDate.IsZero() {
+			filter.StartDate = job.Filter.StartDate
+		}
+		if !job.Filter.EndDate.IsZero() {
+			filter.EndDate = job.Filter.EndDate
+		}
+
+		job.Resource = search.Resource
+		job.Filter = filter
+	}
+
+	if job.Format == "" {
+		job.Format = datastore.ExportFormatCSV
+	}
+	if !job.Format.IsValid() {
+		return nil, &ServiceError{ErrMsg: "format must be csv or jsonl"}
+	}
+
+	f := &job.Filter
+	if f.StartDate.IsZero() {
+		return nil, &ServiceError{ErrMsg: "please provide a start date"}
+	}
+	if f.EndDate.IsZero() {
+		f.EndDate = time.Now()
+	}
+
+	if err := validateSearchFilter(job.Resource, f); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	job.ProjectID = s.ProjectID
+	return job, nil
+}
+
+// CreateExportJobService records an export to the blob store and queues it
+// for a worker. The job must already be resolved.
+type CreateExportJobService struct {
+	ExportJobRepo datastore.ExportJobRepository
+	Queue         queue.Queuer
+	Job           *datastore.ExportJob
+	Logger        log.Logger
+}
+
+func (s *CreateExportJobService) Run(ctx context.Context) (*datastore.ExportJob, error) {
+	job := s.Job
+
+	now := time.Now()
+	job.UID = ulid.Make().String()
+	job.Status = datastore.ExportJobStatusPending
+	job.CreatedAt, job.UpdatedAt = now, now
+
+	err := s.ExportJobRepo.CreateExportJob(ctx, job)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to create export job", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to create export job", Err: err}
+	}
+
+	data, err := msgpack.EncodeMsgPack(task.ExportJobPayload{ProjectID: job.ProjectID, ExportJobID: job.UID})
+	if err != nil {
+		return nil, s.abandon(ctx, job, "failed to encode export job payload", err)
+	}
+
+	err = s.Queue.WriteWithoutTimeout(ctx, convoy.ExportJobProcessor, convoy.DefaultQueue, &queue.Job{
+		ID:        job.UID,
+		Payload:   data,
+		ProjectID: job.ProjectID,
+	})
+	if err != nil {
+		return nil, s.abandon(ctx, job, "failed to queue export job", err)
+	}
+
+	return job, nil
+}
+
+// abandon marks a job that never reached the queue as failed, so it does not
+// linger as pending.
+func (s *CreateExportJobService) abandon(ctx context.Context, job *datastore.ExportJob, msg string, cause error) error {
+	s.Logger.ErrorContext(ctx, msg, "error", cause)
+
+	job.Status = datastore.ExportJobStatusFailed
+	job.Error = msg
+	job.CompletedAt = null.TimeFrom(time.Now())
+	if err := s.ExportJobRepo.UpdateExportJob(ctx, job); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to mark export job as failed", "error", err)
+	}
+
+	return &ServiceError{ErrMsg: msg, Err: cause}
+}
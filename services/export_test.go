@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestResolveExportService_Run(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	saved := &datastore.SavedSearch{
+		UID:      "search-1",
+		Resource: datastore.SearchResourceEventDeliveries,
+		Filter: datastore.SearchFilter{
+			EndpointIDs: []string{"customer-x"},
+			Status:      []datastore.EventDeliveryStatus{datastore.FailureEventStatus},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		job        *datastore.ExportJob
+		dbFn       func(repo *mocks.MockSavedSearchRepository)
+		wantErrMsg string
+		assert     func(t *testing.T, job *datastore.ExportJob)
+	}{
+		{
+			name: "should_apply_export_window_to_saved_search",
+			job: &datastore.ExportJob{
+				SavedSearchID: "search-1",
+				Format:        datastore.ExportFormatJSONL,
+				Filter:        datastore.SearchFilter{StartDate: start, EndDate: end},
+			},
+			dbFn: func(repo *mocks.MockSavedSearchRepository) {
+				repo.EXPECT().FindSavedSearchByID(gomock.Any(), "project-1", "search-1").Return(saved, nil)
+			},
+			assert: func(t *testing.T, job *datastore.ExportJob) {
+				require.Equal(t, datastore.SearchResourceEventDeliveries, job.Resource)
+				require.Equal(t, []string{"customer-x"}, job.Filter.EndpointIDs)
+				require.Equal(t, start, job.Filter.StartDate)
+				require.Equal(t, end, job.Filter.EndDate)
+				require.Equal(t, "project-1", job.ProjectID)
+			},
+		},
+		{
+			name: "should_default_to_csv_ending_now",
+			job: &datastore.ExportJob{
+				Resource: datastore.SearchResourceEvents,
+				Filter:   datastore.SearchFilter{StartDate: start},
+			},
+			assert: func(t *testing.T, job *datastore.ExportJob) {
+				require.Equal(t, datastore.ExportFormatCSV, job.Format)
+				require.False(t, job.Filter.EndDate.IsZero())
+			},
+		},
+		{
+			name: "should_require_start_date",
+			job: &datastore.ExportJob{
+				Resource: datastore.SearchResourceEvents,
+			},
+			wantErrMsg: "please provide a start date",
+		},
+		{
+			name: "should_reject_unknown_format",
+			job: &datastore.ExportJob{
+				Resource: datastore.SearchResourceEvents,
+				Format:   "xlsx",
+				Filter:   datastore.SearchFilter{StartDate: start},
+			},
+			wantErrMsg: "format must be csv or jsonl",
+		},
+		{
+			name: "should_reject_status_on_events",
+			job: &datastore.ExportJob{
+				Resource: datastore.SearchResourceEvents,
+				Filter: datastore.SearchFilter{
+					StartDate: start,
+					Status:    []datastore.EventDeliveryStatus{datastore.FailureEventStatus},
+				},
+			},
+			wantErrMsg: "status can only filter event deliveries",
+		},
+		{
+			name: "should_fail_for_unknown_saved_search",
+			job:  &datastore.ExportJob{SavedSearchID: "search-2"},
+			dbFn: func(repo *mocks.MockSavedSearchRepository) {
+				repo.EXPECT().FindSavedSearchByID(gomock.Any(), "project-1", "search-2").Return(nil, datastore.ErrSavedSearchNotFound)
+			},
+			wantErrMsg: "failed to find saved search",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockSavedSearchRepository(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(repo)
+			}
+
+			s := &ResolveExportService{SavedSearchRepo: repo, ProjectID: "project-1", Job: tt.job}
+			job, err := s.Run(ctx)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+			tt.assert(t, job)
+		})
+	}
+}
+
+func TestCreateExportJobService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_queue_export", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockExportJobRepository(ctrl)
+		q := mocks.NewMockQueuer(ctrl)
+
+		repo.EXPECT().CreateExportJob(gomock.Any(), gomock.Any()).Return(nil)
+		q.EXPECT().WriteWithoutTimeout(gomock.Any(), convoy.ExportJobProcessor, convoy.DefaultQueue, gomock.Any()).Return(nil)
+
+		s := &CreateExportJobService{
+			ExportJobRepo: repo,
+			Queue:         q,
+			Job:           &datastore.ExportJob{ProjectID: "project-1", Resource: datastore.SearchResourceEvents},
+			Logger:        log.New("convoy", log.LevelInfo),
+		}
+
+		job, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, job.UID)
+		require.Equal(t, datastore.ExportJobStatusPending, job.Status)
+	})
+
+	t.Run("should_fail_job_that_cannot_be_queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockExportJobRepository(ctrl)
+		q := mocks.NewMockQueuer(ctrl)
+
+		repo.EXPECT().CreateExportJob(gomock.Any(), gomock.Any()).Return(nil)
+		q.EXPECT().WriteWithoutTimeout(gomock.Any(), convoy.ExportJobProcessor, convoy.DefaultQueue, gomock.Any()).Return(errors.New("redis down"))
+		repo.EXPECT().UpdateExportJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *datastore.ExportJob) error {
+			require.Equal(t, datastore.ExportJobStatusFailed, job.Status)
+			return nil
+		})
+
+		s := &CreateExportJobService{
+			ExportJobRepo: repo,
+			Queue:         q,
+			Job:           &datastore.ExportJob{ProjectID: "project-1", Resource: datastore.SearchResourceEvents},
+			Logger:        log.New("convoy", log.LevelInfo),
+		}
+
+		_, err := s.Run(ctx)
+		require.Error(t, err)
+		require.Equal(t, "failed to queue export job", err.Error())
+	})
+}
@@ -143,9 +143,10 @@ func sendInviteEmail(ctx context.Context, iv *datastore.OrganisationInvite, user
 	}
 
 	em := email.Message{
-		Email:        iv.InviteeEmail,
-		Subject:      "Convoy Organization Invite",
-		TemplateName: email.TemplateOrganisationInvite,
+		Email:          iv.InviteeEmail,
+		Subject:        "Convoy Organization Invite",
+		TemplateName:   email.TemplateOrganisationInvite,
+		OrganisationID: org.UID,
 		Params: map[string]string{
 			"invite_url":        inviteURL,
 			"organisation_name": org.Name,
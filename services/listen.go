@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/util"
+)
+
+// ListenSessionTTL is how long a CLI subscription outlives the last
+// heartbeat of its listen session before it is reaped.
+const ListenSessionTTL = 2 * time.Minute
+
+// ListenSessionService opens a convoy listen session. The session owns a CLI
+// subscription on the key's endpoint, event creation matches it like any
+// other subscription and leaves its deliveries Scheduled for the session to
+// claim instead of queueing them for HTTP dispatch.
+type ListenSessionService struct {
+	SubRepo    datastore.SubscriptionRepository
+	SourceRepo datastore.SourceRepository
+	Project    *datastore.Project
+	Endpoint   *datastore.Endpoint
+	SourceID   string
+	EventTypes []string
+	Logger     log.Logger
+}
+
+func (s *ListenSessionService) Run(ctx context.Context) (*datastore.Subscription, error) {
+	if s.Endpoint.ProjectID != s.Project.UID {
+		return nil, &ServiceError{ErrMsg: "endpoint does not belong to project"}
+	}
+
+	switch s.Project.Type {
+	case datastore.IncomingProject:
+		if util.IsStringEmpty(s.SourceID) {
+			return nil, &ServiceError{ErrMsg: "source_id is required for incoming projects"}
+		}
+
+		_, err := s.SourceRepo.FindSourceByID(ctx, s.Project.UID, s.SourceID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrSourceNotFound) {
+				return nil, util.NewServiceError(http.StatusNotFound, err)
+			}
+			s.Logger.ErrorContext(ctx, "failed to find source by id", "error", err)
+			return nil, &ServiceError{ErrMsg: "failed to find source by id", Err: err}
+		}
+	default:
+		if !util.IsStringEmpty(s.SourceID) {
+			return nil, &ServiceError{ErrMsg: "source_id is only supported for incoming projects"}
+		}
+	}
+
+	eventTypes := s.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = []string{"*"}
+	}
+
+	uid := ulid.Make().String()
+	subscription := &datastore.Subscription{
+		UID:          uid,
+		ProjectID:    s.Project.UID,
+		Name:         fmt.Sprintf("%s-cli-%s", s.Endpoint.Name, uid),
+		Type:         datastore.SubscriptionTypeCLI,
+		SourceID:     s.SourceID,
+		EndpointID:   s.Endpoint.UID,
+		DeliveryMode: datastore.AtLeastOnceDeliveryMode,
+		FilterConfig: &datastore.FilterConfiguration{
+			EventTypes: eventTypes,
+			Filter:     emptyFilterSchema(),
+		},
+		CLIExpiresAt: null.TimeFrom(time.Now().Add(ListenSessionTTL)),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	err := s.SubRepo.CreateSubscription(ctx, s.Project.UID, subscription)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to create cli subscription", "error", err)
+		return nil, &ServiceError{ErrMsg: ErrCreateSubscriptionError.Error(), Err: err}
+	}
+
+	return subscription, nil
+}
+
+// CloseListenSessionService tears down the CLI subscription of a listen
+// session that ended or stopped heartbeating. The deliveries it still holds
+// are discarded first, nothing is left to claim or acknowledge them.
+type CloseListenSessionService struct {
+	SubRepo           datastore.SubscriptionRepository
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	Subscription      *datastore.Subscription
+	Reason            string
+	Logger            log.Logger
+}
+
+func (s *CloseListenSessionService) Run(ctx context.Context) error {
+	err := s.EventDeliveryRepo.DiscardCLIDeliveries(ctx, s.Subscription.ProjectID, s.Subscription.UID, s.Reason)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to discard cli deliveries", "subscription_id", s.Subscription.UID, "error", err)
+		return &ServiceError{ErrMsg: "failed to discard cli deliveries", Err: err}
+	}
+
+	err = s.SubRepo.DeleteSubscription(ctx, s.Subscription.ProjectID, s.Subscription)
+	if err != nil && !errors.Is(err, datastore.ErrSubscriptionNotFound) {
+		s.Logger.ErrorContext(ctx, "failed to delete cli subscription", "subscription_id", s.Subscription.UID, "error", err)
+		return &ServiceError{ErrMsg: "failed to delete cli subscription", Err: err}
+	}
+
+	return nil
+}
+
+// cliSubscriptionReapBatchSize caps the expired CLI subscriptions loaded at
+// once by CLISubscriptionReaper.
+const cliSubscriptionReapBatchSize = 100
+
+// CLISubscriptionReaper closes the listen sessions whose CLI subscription
+// expired, a server that died mid-session never ran its own cleanup.
+type CLISubscriptionReaper struct {
+	SubRepo           datastore.SubscriptionRepository
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	Logger            log.Logger
+}
+
+// A subscription that fails to close is left for the next run, the rest of
+// the batch is still reaped.
+func (r *CLISubscriptionReaper) Run(ctx context.Context) error {
+	var reaped int
+	var closeErr error
+	for {
+		subs, err := r.SubRepo.FindExpiredCLISubscriptions(ctx, cliSubscriptionReapBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range subs {
+			cs := CloseListenSessionService{
+				SubRepo:           r.SubRepo,
+				EventDeliveryRepo: r.EventDeliveryRepo,
+				Subscription:      &subs[i],
+				Reason:            "convoy listen session expired",
+				Logger:            r.Logger,
+			}
+			if err := cs.Run(ctx); err != nil {
+				closeErr = err
+				continue
+			}
+			reaped++
+		}
+
+		// Failed subscriptions are still expired, fetching again would
+		// return them forever.
+		if closeErr != nil || len(subs) < cliSubscriptionReapBatchSize {
+			break
+		}
+	}
+
+	if reaped > 0 {
+		r.Logger.InfoContext(ctx, "reaped expired cli subscriptions", "subscriptions", reaped)
+	}
+	return closeErr
+}
+
+// AckCLIDeliveryService records what the local server did with a delivery a
+// listen session forwarded, as a delivery attempt, and settles the delivery.
+// The local server gets one try, a failure is not retried.
+type AckCLIDeliveryService struct {
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	AttemptsRepo      datastore.DeliveryAttemptsRepository
+	Project           *datastore.Project
+	EndpointID        string
+	EventDeliveryID   string
+	Ack               *models.CLIDeliveryAck
+	Logger            log.Logger
+}
+
+func (s *AckCLIDeliveryService) Run(ctx context.Context) (*datastore.EventDelivery, error) {
+	if err := s.Ack.Validate(); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	delivery, err := s.EventDeliveryRepo.FindEventDeliveryByIDSlim(ctx, s.Project.UID, s.EventDeliveryID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventDeliveryNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+		s.Logger.ErrorContext(ctx, "failed to find event delivery", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to find event delivery", Err: err}
+	}
+
+	// A CLI key only settles deliveries streamed for its own endpoint.
+	if delivery.CLIMetadata == nil || delivery.EndpointID != s.EndpointID {
+		return nil, util.NewServiceError(http.StatusNotFound, datastore.ErrEventDeliveryNotFound)
+	}
+
+	if delivery.Status != datastore.ProcessingEventStatus {
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("event delivery is %s, only streamed deliveries can be acknowledged", delivery.Status)}
+	}
+
+	succeeded := s.Ack.Succeeded()
+	attempt := &datastore.DeliveryAttempt{
+		UID:             ulid.Make().String(),
+		URL:             s.Ack.ForwardURL,
+		Method:          http.MethodPost,
+		EndpointID:      delivery.EndpointID,
+		APIVersion:      convoy.GetVersion(),
+		ProjectId:       s.Project.UID,
+		EventDeliveryId: delivery.UID,
+		ResponseHeader:  cliResponseHeader(s.Ack),
+		ResponseData:    []byte(s.Ack.Body),
+		Error:           s.Ack.Error,
+		Status:          succeeded,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if s.Ack.StatusCode != 0 {
+		attempt.HttpResponseCode = fmt.Sprintf("%d %s", s.Ack.StatusCode, http.StatusText(s.Ack.StatusCode))
+	}
+	if !s.Ack.RequestedAt.IsZero() {
+		attempt.RequestedAt = null.TimeFrom(s.Ack.RequestedAt)
+	}
+	if !s.Ack.RespondedAt.IsZero() {
+		attempt.RespondedAt = null.TimeFrom(s.Ack.RespondedAt)
+	}
+
+	switch {
+	case succeeded:
+		delivery.Status = datastore.SuccessEventStatus
+		delivery.Description = ""
+		delivery.LatencySeconds = time.Since(delivery.GetLatencyStartTime()).Seconds()
+	case s.Ack.Error != "":
+		delivery.Status = datastore.FailureEventStatus
+		delivery.Description = s.Ack.Error
+	default:
+		delivery.Status = datastore.FailureEventStatus
+		delivery.Description = fmt.Sprintf("Endpoint returned status code %d", s.Ack.StatusCode)
+	}
+	if delivery.Metadata != nil {
+		delivery.Metadata.NumTrials++
+	}
+
+	err = s.AttemptsRepo.CreateDeliveryAttempt(ctx, attempt)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to create delivery attempt", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to create delivery attempt", Err: err}
+	}
+
+	err = s.EventDeliveryRepo.UpdateEventDeliveryMetadata(ctx, s.Project.UID, delivery)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to update event delivery", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to update event delivery", Err: err}
+	}
+
+	return delivery, nil
+}
+
+func cliResponseHeader(ack *models.CLIDeliveryAck) datastore.HttpHeader {
+	header := http.Header(ack.Headers)
+	return *datastore.ConvertDefaultHeaderToCustomHeader(&header)
+}
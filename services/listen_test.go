@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/util"
+)
+
+func TestListenSessionService_Run(t *testing.T) {
+	ctx := context.Background()
+	endpoint := &datastore.Endpoint{UID: "endpoint-1", ProjectID: "project-1", Name: "billing"}
+
+	t.Run("should_create_cli_subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		subRepo.EXPECT().CreateSubscription(gomock.Any(), "project-1", gomock.Any()).Return(nil)
+
+		s := &ListenSessionService{
+			SubRepo:    subRepo,
+			Project:    &datastore.Project{UID: "project-1", Type: datastore.OutgoingProject},
+			Endpoint:   endpoint,
+			EventTypes: []string{"invoice.paid"},
+			Logger:     log.New("convoy", log.LevelError),
+		}
+
+		sub, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, datastore.SubscriptionTypeCLI, sub.Type)
+		require.Equal(t, "endpoint-1", sub.EndpointID)
+		require.ElementsMatch(t, []string{"invoice.paid"}, sub.FilterConfig.EventTypes)
+		require.True(t, sub.CLIExpiresAt.Valid)
+		require.WithinDuration(t, time.Now().Add(ListenSessionTTL), sub.CLIExpiresAt.Time, time.Minute)
+	})
+
+	t.Run("should_default_to_all_event_types", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		subRepo.EXPECT().CreateSubscription(gomock.Any(), "project-1", gomock.Any()).Return(nil)
+		sourceRepo := mocks.NewMockSourceRepository(ctrl)
+		sourceRepo.EXPECT().FindSourceByID(gomock.Any(), "project-1", "source-1").Return(&datastore.Source{UID: "source-1"}, nil)
+
+		s := &ListenSessionService{
+			SubRepo:    subRepo,
+			SourceRepo: sourceRepo,
+			Project:    &datastore.Project{UID: "project-1", Type: datastore.IncomingProject},
+			Endpoint:   endpoint,
+			SourceID:   "source-1",
+			Logger:     log.New("convoy", log.LevelError),
+		}
+
+		sub, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "source-1", sub.SourceID)
+		require.ElementsMatch(t, []string{"*"}, sub.FilterConfig.EventTypes)
+	})
+
+	t.Run("should_require_source_for_incoming_projects", func(t *testing.T) {
+		s := &ListenSessionService{
+			Project:  &datastore.Project{UID: "project-1", Type: datastore.IncomingProject},
+			Endpoint: endpoint,
+			Logger:   log.New("convoy", log.LevelError),
+		}
+
+		_, err := s.Run(ctx)
+		require.EqualError(t, err, "source_id is required for incoming projects")
+	})
+
+	t.Run("should_reject_source_for_outgoing_projects", func(t *testing.T) {
+		s := &ListenSessionService{
+			Project:  &datastore.Project{UID: "project-1", Type: datastore.OutgoingProject},
+			Endpoint: endpoint,
+			SourceID: "source-1",
+			Logger:   log.New("convoy", log.LevelError),
+		}
+
+		_, err := s.Run(ctx)
+		require.EqualError(t, err, "source_id is only supported for incoming projects")
+	})
+}
+
+func TestCloseListenSessionService_Run(t *testing.T) {
+	ctx := context.Background()
+	sub := &datastore.Subscription{UID: "sub-1", ProjectID: "project-1", Type: datastore.SubscriptionTypeCLI}
+
+	t.Run("should_discard_pending_deliveries_then_delete_subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		gomock.InOrder(
+			edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-1", "sub-1", "convoy listen session ended").Return(nil),
+			subRepo.EXPECT().DeleteSubscription(gomock.Any(), "project-1", sub).Return(nil),
+		)
+
+		s := &CloseListenSessionService{
+			SubRepo:           subRepo,
+			EventDeliveryRepo: edRepo,
+			Subscription:      sub,
+			Reason:            "convoy listen session ended",
+			Logger:            log.New("convoy", log.LevelError),
+		}
+		require.NoError(t, s.Run(ctx))
+	})
+
+	t.Run("should_keep_subscription_when_discard_fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-1", "sub-1", gomock.Any()).Return(errors.New("db down"))
+
+		s := &CloseListenSessionService{
+			SubRepo:           subRepo,
+			EventDeliveryRepo: edRepo,
+			Subscription:      sub,
+			Logger:            log.New("convoy", log.LevelError),
+		}
+		require.EqualError(t, s.Run(ctx), "failed to discard cli deliveries")
+	})
+
+	t.Run("should_ignore_already_deleted_subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-1", "sub-1", gomock.Any()).Return(nil)
+		subRepo.EXPECT().DeleteSubscription(gomock.Any(), "project-1", sub).Return(datastore.ErrSubscriptionNotFound)
+
+		s := &CloseListenSessionService{
+			SubRepo:           subRepo,
+			EventDeliveryRepo: edRepo,
+			Subscription:      sub,
+			Logger:            log.New("convoy", log.LevelError),
+		}
+		require.NoError(t, s.Run(ctx))
+	})
+}
+
+func TestCLISubscriptionReaper_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_close_every_expired_subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+		expired := []datastore.Subscription{
+			{UID: "sub-1", ProjectID: "project-1", Type: datastore.SubscriptionTypeCLI},
+			{UID: "sub-2", ProjectID: "project-2", Type: datastore.SubscriptionTypeCLI},
+		}
+		subRepo.EXPECT().FindExpiredCLISubscriptions(gomock.Any(), cliSubscriptionReapBatchSize).Return(expired, nil)
+		edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-1", "sub-1", "convoy listen session expired").Return(nil)
+		edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-2", "sub-2", "convoy listen session expired").Return(nil)
+		subRepo.EXPECT().DeleteSubscription(gomock.Any(), "project-1", gomock.Any()).Return(nil)
+		subRepo.EXPECT().DeleteSubscription(gomock.Any(), "project-2", gomock.Any()).Return(nil)
+
+		r := &CLISubscriptionReaper{SubRepo: subRepo, EventDeliveryRepo: edRepo, Logger: log.New("convoy", log.LevelError)}
+		require.NoError(t, r.Run(ctx))
+	})
+
+	t.Run("should_reap_the_rest_of_the_batch_when_one_fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+
+		expired := make([]datastore.Subscription, cliSubscriptionReapBatchSize)
+		for i := range expired {
+			expired[i] = datastore.Subscription{UID: fmt.Sprintf("sub-%d", i), ProjectID: "project-1", Type: datastore.SubscriptionTypeCLI}
+		}
+		// A full batch would normally be followed by another fetch, the
+		// failure stops the run instead of refetching the same rows.
+		subRepo.EXPECT().FindExpiredCLISubscriptions(gomock.Any(), cliSubscriptionReapBatchSize).Return(expired, nil).Times(1)
+		edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-1", "sub-0", gomock.Any()).Return(errors.New("db down"))
+		edRepo.EXPECT().DiscardCLIDeliveries(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).Return(nil).Times(cliSubscriptionReapBatchSize - 1)
+		subRepo.EXPECT().DeleteSubscription(gomock.Any(), "project-1", gomock.Any()).Return(nil).Times(cliSubscriptionReapBatchSize - 1)
+
+		r := &CLISubscriptionReaper{SubRepo: subRepo, EventDeliveryRepo: edRepo, Logger: log.New("convoy", log.LevelError)}
+		require.Error(t, r.Run(ctx))
+	})
+}
+
+func TestAckCLIDeliveryService_Run(t *testing.T) {
+	ctx := context.Background()
+	project := &datastore.Project{UID: "project-1"}
+
+	streamed := func() *datastore.EventDelivery {
+		return &datastore.EventDelivery{
+			UID:         "delivery-1",
+			ProjectID:   "project-1",
+			EndpointID:  "endpoint-1",
+			Status:      datastore.ProcessingEventStatus,
+			Metadata:    &datastore.Metadata{RetryLimit: 3},
+			CLIMetadata: &datastore.CLIMetadata{EventType: "invoice.paid"},
+			CreatedAt:   time.Now(),
+		}
+	}
+
+	t.Run("should_record_successful_delivery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		attemptsRepo := mocks.NewMockDeliveryAttemptsRepository(ctrl)
+
+		edRepo.EXPECT().FindEventDeliveryByIDSlim(gomock.Any(), "project-1", "delivery-1").Return(streamed(), nil)
+		attemptsRepo.EXPECT().CreateDeliveryAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *datastore.DeliveryAttempt) error {
+			require.True(t, a.Status)
+			require.Equal(t, "200 OK", a.HttpResponseCode)
+			require.Equal(t, "http://localhost:3000/webhooks", a.URL)
+			require.Equal(t, "ok", string(a.ResponseData))
+			return nil
+		})
+		edRepo.EXPECT().UpdateEventDeliveryMetadata(gomock.Any(), "project-1", gomock.Any()).Return(nil)
+
+		s := &AckCLIDeliveryService{
+			EventDeliveryRepo: edRepo,
+			AttemptsRepo:      attemptsRepo,
+			Project:           project,
+			EndpointID:        "endpoint-1",
+			EventDeliveryID:   "delivery-1",
+			Ack:               &models.CLIDeliveryAck{ForwardURL: "http://localhost:3000/webhooks", StatusCode: http.StatusOK, Body: "ok"},
+			Logger:            log.New("convoy", log.LevelError),
+		}
+
+		delivery, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, datastore.SuccessEventStatus, delivery.Status)
+		require.Equal(t, uint64(1), delivery.Metadata.NumTrials)
+	})
+
+	t.Run("should_fail_delivery_when_local_server_is_unreachable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		attemptsRepo := mocks.NewMockDeliveryAttemptsRepository(ctrl)
+
+		edRepo.EXPECT().FindEventDeliveryByIDSlim(gomock.Any(), "project-1", "delivery-1").Return(streamed(), nil)
+		attemptsRepo.EXPECT().CreateDeliveryAttempt(gomock.Any(), gomock.Any()).Return(nil)
+		edRepo.EXPECT().UpdateEventDeliveryMetadata(gomock.Any(), "project-1", gomock.Any()).Return(nil)
+
+		s := &AckCLIDeliveryService{
+			EventDeliveryRepo: edRepo,
+			AttemptsRepo:      attemptsRepo,
+			Project:           project,
+			EndpointID:        "endpoint-1",
+			EventDeliveryID:   "delivery-1",
+			Ack:               &models.CLIDeliveryAck{Error: "connection refused"},
+			Logger:            log.New("convoy", log.LevelError),
+		}
+
+		delivery, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, datastore.FailureEventStatus, delivery.Status)
+		require.Equal(t, "connection refused", delivery.Description)
+	})
+
+	t.Run("should_not_acknowledge_another_endpoints_delivery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		edRepo.EXPECT().FindEventDeliveryByIDSlim(gomock.Any(), "project-1", "delivery-1").Return(streamed(), nil)
+
+		s := &AckCLIDeliveryService{
+			EventDeliveryRepo: edRepo,
+			Project:           project,
+			EndpointID:        "endpoint-2",
+			EventDeliveryID:   "delivery-1",
+			Ack:               &models.CLIDeliveryAck{StatusCode: http.StatusOK},
+			Logger:            log.New("convoy", log.LevelError),
+		}
+
+		_, err := s.Run(ctx)
+		var serviceErr *util.ServiceError
+		require.ErrorAs(t, err, &serviceErr)
+		require.Equal(t, http.StatusNotFound, serviceErr.ErrCode())
+	})
+
+	t.Run("should_not_acknowledge_twice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		edRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+		delivery := streamed()
+		delivery.Status = datastore.SuccessEventStatus
+		edRepo.EXPECT().FindEventDeliveryByIDSlim(gomock.Any(), "project-1", "delivery-1").Return(delivery, nil)
+
+		s := &AckCLIDeliveryService{
+			EventDeliveryRepo: edRepo,
+			Project:           project,
+			EndpointID:        "endpoint-1",
+			EventDeliveryID:   "delivery-1",
+			Ack:               &models.CLIDeliveryAck{StatusCode: http.StatusOK},
+			Logger:            log.New("convoy", log.LevelError),
+		}
+
+		_, err := s.Run(ctx)
+		require.EqualError(t, err, "event delivery is Success, only streamed deliveries can be acknowledged")
+	})
+}
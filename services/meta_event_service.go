@@ -39,8 +39,9 @@ func (m *MetaEventService) Run(ctx context.Context, metaEvent *datastore.MetaEve
 
 	jobId := queue.JobId{ProjectID: metaEvent.ProjectID, ResourceID: metaEvent.UID}.MetaJobId()
 	err = m.Queue.Write(ctx, convoy.MetaEventProcessor, convoy.MetaEventQueue, &queue.Job{
-		ID:      jobId,
-		Payload: bytes,
+		ID:        jobId,
+		Payload:   bytes,
+		ProjectID: metaEvent.ProjectID,
 	})
 	if err != nil {
 		return fmt.Errorf("error occurred re-enqueing meta event - %s: %v", metaEvent.UID, err)
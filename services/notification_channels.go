@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+// validateNotificationChannels checks the incident channels of an endpoint or
+// alert rule, generating a webhook secret when none was given. Errors never
+// quote the rejected value: the webhook url, secret, routing key and api key
+// are all credentials. A credential still carrying the response mask had no
+// stored value to restore and is rejected rather than saved as the mask.
+func validateNotificationChannels(c *datastore.NotificationChannels) error {
+	if c == nil {
+		return nil
+	}
+
+	if (c.Webhook != nil && c.Webhook.Secret == datastore.RedactedSecret) ||
+		(c.PagerDuty != nil && c.PagerDuty.RoutingKey == datastore.RedactedSecret) ||
+		(c.Opsgenie != nil && c.Opsgenie.APIKey == datastore.RedactedSecret) {
+		return errors.New("notification channel credentials cannot be the redacted placeholder")
+	}
+
+	if c.Webhook != nil {
+		if util.IsStringEmpty(c.Webhook.URL) {
+			return errors.New("webhook channel url is required")
+		}
+		if _, err := util.ValidateOutboundURL(c.Webhook.URL, false); err != nil {
+			return errors.New("invalid webhook channel url")
+		}
+
+		if util.IsStringEmpty(c.Webhook.Secret) {
+			secret, err := util.GenerateSecret()
+			if err != nil {
+				return errors.New("could not generate webhook channel secret")
+			}
+			c.Webhook.Secret = secret
+		}
+	}
+
+	if c.PagerDuty != nil && util.IsStringEmpty(c.PagerDuty.RoutingKey) {
+		return errors.New("pagerduty routing key is required")
+	}
+
+	if c.Opsgenie != nil {
+		if util.IsStringEmpty(c.Opsgenie.APIKey) {
+			return errors.New("opsgenie api key is required")
+		}
+
+		c.Opsgenie.Region = strings.ToLower(strings.TrimSpace(c.Opsgenie.Region))
+		switch c.Opsgenie.Region {
+		case "":
+			c.Opsgenie.Region = datastore.OpsgenieRegionUS
+		case datastore.OpsgenieRegionUS, datastore.OpsgenieRegionEU:
+		default:
+			return errors.New("opsgenie region must be us or eu")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestValidateNotificationChannels(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels *datastore.NotificationChannels
+		wantErr  string
+	}{
+		{
+			name: "every channel",
+			channels: &datastore.NotificationChannels{
+				Webhook:   &datastore.WebhookChannel{URL: "https://alerts.example.com/convoy", Secret: "whsec"},
+				PagerDuty: &datastore.PagerDutyChannel{RoutingKey: "R0UT1NGKEY"},
+				Opsgenie:  &datastore.OpsgenieChannel{APIKey: "genie-key", Region: " EU "},
+			},
+		},
+		{
+			name:     "webhook without url",
+			channels: &datastore.NotificationChannels{Webhook: &datastore.WebhookChannel{}},
+			wantErr:  "webhook channel url is required",
+		},
+		{
+			name: "webhook targeting a private address",
+			channels: &datastore.NotificationChannels{
+				Webhook: &datastore.WebhookChannel{URL: "http://127.0.0.1/hook?token=notasecretbutpretend"},
+			},
+			wantErr: "invalid webhook channel url",
+		},
+		{
+			name:     "pagerduty without routing key",
+			channels: &datastore.NotificationChannels{PagerDuty: &datastore.PagerDutyChannel{}},
+			wantErr:  "pagerduty routing key is required",
+		},
+		{
+			name:     "opsgenie without api key",
+			channels: &datastore.NotificationChannels{Opsgenie: &datastore.OpsgenieChannel{}},
+			wantErr:  "opsgenie api key is required",
+		},
+		{
+			name:     "opsgenie with unknown region",
+			channels: &datastore.NotificationChannels{Opsgenie: &datastore.OpsgenieChannel{APIKey: "genie-key", Region: "apac"}},
+			wantErr:  "opsgenie region must be us or eu",
+		},
+		{
+			name:     "masked routing key with nothing stored",
+			channels: &datastore.NotificationChannels{PagerDuty: &datastore.PagerDutyChannel{RoutingKey: datastore.RedactedSecret}},
+			wantErr:  "notification channel credentials cannot be the redacted placeholder",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateNotificationChannels(tc.channels)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				require.NotContains(t, err.Error(), "notasecretbutpretend")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, datastore.OpsgenieRegionEU, tc.channels.Opsgenie.Region)
+		})
+	}
+}
+
+func TestValidateNotificationChannels_Defaults(t *testing.T) {
+	channels := &datastore.NotificationChannels{
+		Webhook:  &datastore.WebhookChannel{URL: "https://alerts.example.com/convoy"},
+		Opsgenie: &datastore.OpsgenieChannel{APIKey: "genie-key"},
+	}
+
+	require.NoError(t, validateNotificationChannels(channels))
+	require.NotEmpty(t, channels.Webhook.Secret)
+	require.Equal(t, datastore.OpsgenieRegionUS, channels.Opsgenie.Region)
+}
+
+func TestNotificationChannels_RedactedRoundTrip(t *testing.T) {
+	stored := &datastore.NotificationChannels{
+		Webhook:   &datastore.WebhookChannel{URL: "https://alerts.example.com/convoy", Secret: "whsec"},
+		PagerDuty: &datastore.PagerDutyChannel{RoutingKey: "R0UT1NGKEY"},
+		Opsgenie:  &datastore.OpsgenieChannel{APIKey: "genie-key", Region: datastore.OpsgenieRegionUS},
+	}
+
+	redacted := stored.Redacted()
+	require.Equal(t, datastore.RedactedSecret, redacted.Webhook.Secret)
+	require.Equal(t, datastore.RedactedSecret, redacted.PagerDuty.RoutingKey)
+	require.Equal(t, datastore.RedactedSecret, redacted.Opsgenie.APIKey)
+	require.Equal(t, "whsec", stored.Webhook.Secret, "the stored channels must not be masked")
+
+	// A client sending the fetched channels back, with a new PagerDuty key.
+	redacted.PagerDuty.RoutingKey = "N3WKEY"
+	redacted.RestoreRedacted(stored)
+	require.NoError(t, validateNotificationChannels(redacted))
+	require.Equal(t, "whsec", redacted.Webhook.Secret)
+	require.Equal(t, "N3WKEY", redacted.PagerDuty.RoutingKey)
+	require.Equal(t, "genie-key", redacted.Opsgenie.APIKey)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+// PortalEventCatalogService lists the event types a portal link can subscribe
+// its endpoints to. Deprecated event types are left out since they can no
+// longer be chosen.
+type PortalEventCatalogService struct {
+	EventTypesRepo datastore.EventTypesRepository
+	ProjectID      string
+	PortalLink     *datastore.PortalLink
+}
+
+func (s *PortalEventCatalogService) Run(ctx context.Context) ([]models.PortalEventType, error) {
+	eventTypes, err := s.EventTypesRepo.FetchAllEventTypes(ctx, s.ProjectID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to load event types", Err: err}
+	}
+
+	catalog := make([]models.PortalEventType, 0, len(eventTypes))
+	for i := range eventTypes {
+		et := &eventTypes[i]
+		if et.DeprecatedAt.Valid || !s.PortalLink.AllowsEventType(et.Name) {
+			continue
+		}
+
+		sample, err := samplePayload(et)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog = append(catalog, models.PortalEventType{
+			Name:        et.Name,
+			Category:    et.Category,
+			Description: et.Description,
+			JSONSchema:  et.JSONSchema,
+			Sample:      sample,
+		})
+	}
+
+	return catalog, nil
+}
+
+// PortalSubscriptionEventTypesService checks the event types a portal link
+// subscribes an endpoint to and returns the ones to store. A link restricted
+// to a set of event types that asks for none gets every type in the set that
+// is not deprecated; an unrestricted one gets none, which the subscription
+// services read as all event types.
+type PortalSubscriptionEventTypesService struct {
+	EventTypesRepo datastore.EventTypesRepository
+	ProjectID      string
+	PortalLink     *datastore.PortalLink
+
+	// EventTypes are the requested event types.
+	EventTypes []string
+
+	// Current are the subscription's event types before an update. They are
+	// kept even when deprecated or no longer allowed since then, so editing
+	// anything else on the subscription does not fail.
+	Current []string
+}
+
+func (s *PortalSubscriptionEventTypesService) Run(ctx context.Context) ([]string, error) {
+	restricted := len(s.PortalLink.EventTypes) > 0
+	if len(s.EventTypes) == 0 && !restricted {
+		return nil, nil
+	}
+
+	eventTypes, err := s.EventTypesRepo.FetchAllEventTypes(ctx, s.ProjectID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to load event types", Err: err}
+	}
+
+	deprecated := make(map[string]bool, len(eventTypes))
+	for _, et := range eventTypes {
+		deprecated[et.Name] = et.DeprecatedAt.Valid
+	}
+
+	if len(s.EventTypes) == 0 {
+		allowed := make([]string, 0, len(s.PortalLink.EventTypes))
+		for _, name := range s.PortalLink.EventTypes {
+			if !deprecated[name] {
+				allowed = append(allowed, name)
+			}
+		}
+
+		if len(allowed) == 0 {
+			return nil, &ServiceError{ErrMsg: "no event types are available to this portal link"}
+		}
+
+		return allowed, nil
+	}
+
+	for _, name := range s.EventTypes {
+		if util.StringSliceContains(s.Current, name) {
+			continue
+		}
+
+		// The wildcard is only allowed by unrestricted links, it is never
+		// in a link's event types.
+		if !s.PortalLink.AllowsEventType(name) {
+			return nil, &ServiceError{ErrMsg: fmt.Sprintf("event type %s is not available to this portal link", name)}
+		}
+
+		if deprecated[name] {
+			return nil, &ServiceError{ErrMsg: fmt.Sprintf("event type %s is deprecated", name)}
+		}
+	}
+
+	return s.EventTypes, nil
+}
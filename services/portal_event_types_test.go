@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+)
+
+func portalTestEventTypes() []datastore.ProjectEventType {
+	return []datastore.ProjectEventType{
+		{Name: "invoice.paid", Category: "billing", JSONSchema: []byte(`{"type":"object","properties":{"amount":{"type":"integer"}}}`)},
+		{Name: "invoice.voided", DeprecatedAt: null.TimeFrom(time.Now())},
+		{Name: "customer.created"},
+	}
+}
+
+func TestPortalEventCatalogService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_list_allowed_event_types", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypesRepository(ctrl)
+		repo.EXPECT().FetchAllEventTypes(gomock.Any(), "project-1").Return(portalTestEventTypes(), nil)
+
+		s := &PortalEventCatalogService{
+			EventTypesRepo: repo,
+			ProjectID:      "project-1",
+			PortalLink:     &datastore.PortalLink{EventTypes: []string{"invoice.paid", "invoice.voided"}},
+		}
+
+		catalog, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Len(t, catalog, 1)
+		require.Equal(t, "invoice.paid", catalog[0].Name)
+		require.JSONEq(t, `{"amount":1}`, string(catalog[0].Sample))
+	})
+
+	t.Run("should_list_all_event_types_for_unrestricted_link", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockEventTypesRepository(ctrl)
+		repo.EXPECT().FetchAllEventTypes(gomock.Any(), "project-1").Return(portalTestEventTypes(), nil)
+
+		s := &PortalEventCatalogService{EventTypesRepo: repo, ProjectID: "project-1", PortalLink: &datastore.PortalLink{}}
+
+		catalog, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Len(t, catalog, 2)
+		require.Equal(t, "customer.created", catalog[1].Name)
+	})
+}
+
+func TestPortalSubscriptionEventTypesService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		allowed    []string
+		eventTypes []string
+		current    []string
+		want       []string
+		wantErr    string
+	}{
+		{
+			name: "unrestricted link without event types",
+		},
+		{
+			name:       "unrestricted link with wildcard",
+			eventTypes: []string{"*"},
+			want:       []string{"*"},
+		},
+		{
+			name:       "unrestricted link with deprecated event type",
+			eventTypes: []string{"invoice.voided"},
+			wantErr:    "event type invoice.voided is deprecated",
+		},
+		{
+			name:    "restricted link defaults to its event types",
+			allowed: []string{"invoice.paid", "invoice.voided"},
+			want:    []string{"invoice.paid"},
+		},
+		{
+			name:       "restricted link with allowed event type",
+			allowed:    []string{"invoice.paid", "customer.created"},
+			eventTypes: []string{"customer.created"},
+			want:       []string{"customer.created"},
+		},
+		{
+			name:       "restricted link with wildcard",
+			allowed:    []string{"invoice.paid"},
+			eventTypes: []string{"*"},
+			wantErr:    "event type * is not available to this portal link",
+		},
+		{
+			name:       "restricted link with other event type",
+			allowed:    []string{"invoice.paid"},
+			eventTypes: []string{"invoice.paid", "customer.created"},
+			wantErr:    "event type customer.created is not available to this portal link",
+		},
+		{
+			name:       "current event types are kept",
+			allowed:    []string{"invoice.paid"},
+			eventTypes: []string{"invoice.paid", "invoice.voided"},
+			current:    []string{"invoice.voided"},
+			want:       []string{"invoice.paid", "invoice.voided"},
+		},
+		{
+			name:    "restricted link without available event types",
+			allowed: []string{"invoice.voided"},
+			wantErr: "no event types are available to this portal link",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEventTypesRepository(ctrl)
+			repo.EXPECT().FetchAllEventTypes(gomock.Any(), "project-1").Return(portalTestEventTypes(), nil).MaxTimes(1)
+
+			s := &PortalSubscriptionEventTypesService{
+				EventTypesRepo: repo,
+				ProjectID:      "project-1",
+				PortalLink:     &datastore.PortalLink{EventTypes: tt.allowed},
+				EventTypes:     tt.eventTypes,
+				Current:        tt.current,
+			}
+
+			got, err := s.Run(ctx)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
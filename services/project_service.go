@@ -295,6 +295,12 @@ func applyProjectConfigPatch(existing *datastore.ProjectConfig, patch *models.Pr
 		if _, ok := present["allow_unmatched_dynamic_urls"]; ok {
 			merged.AllowUnmatchedDynamicURLs = incoming.AllowUnmatchedDynamicURLs
 		}
+		if _, ok := present["endpoint_metric_labels"]; ok {
+			merged.EndpointMetricLabels = incoming.EndpointMetricLabels
+		}
+		if _, ok := present["record_filter_rejections"]; ok {
+			merged.RecordFilterRejections = incoming.RecordFilterRejections
+		}
 	} else {
 		if !util.IsStringEmpty(patch.SearchPolicy) {
 			merged.SearchPolicy = incoming.SearchPolicy
@@ -374,6 +380,12 @@ func validateMetaEvent(c *datastore.ProjectConfig, licenser license.Licenser) er
 		return nil
 	}
 
+	for _, eventType := range metaEvent.EventType {
+		if !datastore.IsValidMetaEventType(eventType) {
+			return fmt.Errorf("unsupported meta event type: %s", eventType)
+		}
+	}
+
 	// An empty type defaults to HTTP. The worker dispatches every enabled meta
 	// event as an HTTP POST to metaEvent.URL regardless of type, so the URL must
 	// be validated here (SSRF/private-IP rejection). Gating validation on
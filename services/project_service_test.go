@@ -872,3 +872,31 @@ func TestProjectService_UpdateProject(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMetaEvent_EventTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	licenser := mocks.NewMockLicenser(ctrl)
+
+	// pub_sub skips the URL check, which dials the URL.
+	cfg := func(eventTypes ...string) *datastore.ProjectConfig {
+		return &datastore.ProjectConfig{
+			MetaEvent: &datastore.MetaEventConfiguration{
+				IsEnabled: true,
+				Type:      datastore.PubSubMetaEvent,
+				EventType: eventTypes,
+			},
+		}
+	}
+
+	err := validateMetaEvent(cfg(string(datastore.SubscriptionCreated), string(datastore.BackupJobCompleted)), licenser)
+	require.NoError(t, err)
+
+	err = validateMetaEvent(cfg("endpoint.created", "subscription.exploded"), licenser)
+	require.EqualError(t, err, "unsupported meta event type: subscription.exploded")
+
+	// eventdelivery.updated only drives the success and failed events.
+	err = validateMetaEvent(cfg(string(datastore.EventDeliveryUpdated)), licenser)
+	require.Error(t, err)
+}
@@ -0,0 +1,1267 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/internal/pkg/project_spec"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/util"
+)
+
+// ProjectSpecService exports a project's configuration as a project_spec
+// document, and plans and applies documents to the project.
+type ProjectSpecService struct {
+	ProjectRepo    datastore.ProjectRepository
+	EndpointRepo   datastore.EndpointRepository
+	SourceRepo     datastore.SourceRepository
+	SubRepo        datastore.SubscriptionRepository
+	FilterRepo     datastore.FilterRepository
+	EventTypesRepo datastore.EventTypesRepository
+	PortalLinkRepo datastore.PortalLinkRepository
+	Licenser       license.Licenser
+	Logger         log.Logger
+	Project        *datastore.Project
+}
+
+// projectState is the project as it is stored, by name, and the document
+// describing it.
+type projectState struct {
+	doc           *project_spec.Document
+	eventTypes    map[string]*datastore.ProjectEventType
+	sources       map[string]*datastore.Source
+	endpoints     map[string]*datastore.Endpoint
+	subscriptions map[string]*datastore.Subscription
+	portalLinks   map[string]*datastore.PortalLink
+
+	// duplicates are names used by more than one object of a kind, a
+	// document cannot tell them apart.
+	duplicates map[project_spec.Kind][]string
+}
+
+// Export describes the project as a document. Secrets get conventional
+// references, their values are returned by reference.
+func (s *ProjectSpecService) Export(ctx context.Context) (*project_spec.Document, map[string]string, error) {
+	state, err := s.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = state.checkDuplicates(kindsOf(state.doc)); err != nil {
+		return nil, nil, err
+	}
+
+	// Normalising leaves out the filters that match their subscription's.
+	state.doc.Normalize()
+	values := state.doc.NameSecrets()
+	return state.doc, values, nil
+}
+
+// Plan works out the changes applying desired would make, without making them.
+func (s *ProjectSpecService) Plan(ctx context.Context, desired *project_spec.Document, resolver project_spec.SecretResolver, prune bool) (*project_spec.Plan, error) {
+	_, plan, err := s.plan(ctx, desired, resolver, prune)
+	return plan, err
+}
+
+// Apply makes the changes planned for desired, in order, and stops at the
+// first one that fails. The returned plan lists the changes that were made.
+func (s *ProjectSpecService) Apply(ctx context.Context, desired *project_spec.Document, resolver project_spec.SecretResolver, prune bool) (*project_spec.Plan, error) {
+	state, plan, err := s.plan(ctx, desired, resolver, prune)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := &project_spec.Plan{Changes: []project_spec.Change{}, Warnings: plan.Warnings}
+	for _, change := range plan.Changes {
+		if err = s.apply(ctx, change, desired, state); err != nil {
+			s.Logger.ErrorContext(ctx, "failed to apply project spec change", "kind", change.Kind, "name", change.Name, "action", change.Action, "error", err)
+			return applied, &ServiceError{ErrMsg: fmt.Sprintf("failed to %s %s %s after %d change(s): %v", change.Action, change.Kind, change.Name, len(applied.Changes), err), Err: err}
+		}
+		applied.Changes = append(applied.Changes, change)
+	}
+
+	return applied, nil
+}
+
+func (s *ProjectSpecService) plan(ctx context.Context, desired *project_spec.Document, resolver project_spec.SecretResolver, prune bool) (*projectState, *project_spec.Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := desired.Resolve(resolver); err != nil {
+		return nil, nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	state, err := s.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = state.checkDuplicates(kindsOf(desired)); err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	warn := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	if err = s.prepare(desired, state, prune, warn); err != nil {
+		return nil, nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	desired.Inherit(state.doc)
+	plan := project_spec.Diff(state.doc, desired, prune)
+	plan.Warnings = append(warnings, plan.Warnings...)
+
+	if err = s.checkPlan(plan, desired, state); err != nil {
+		return nil, nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	return state, plan, nil
+}
+
+// prepare applies the licence and project rules the API applies when the
+// objects are written, and checks each object the way the API would.
+func (s *ProjectSpecService) prepare(desired *project_spec.Document, state *projectState, prune bool, warn func(string, ...interface{})) error {
+	var errs []error
+	addErr := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	projectConfig := s.Project.Config
+	if projectConfig == nil {
+		projectConfig = &datastore.ProjectConfig{}
+	}
+
+	if c := desired.ProjectConfig; c != nil {
+		if c.RequestIDHeader == "" {
+			c.RequestIDHeader = string(config.DefaultRequestIDHeader)
+		}
+
+		if c.SearchPolicy != "" && !s.Licenser.EventSearch() {
+			warn("project_config.search_policy needs the event search feature and is ignored")
+			c.SearchPolicy = ""
+		}
+
+		if c.SearchPolicy != "" {
+			if _, err := time.ParseDuration(c.SearchPolicy); err != nil {
+				addErr("project_config.search_policy: %v", err)
+			}
+		}
+
+		cfg := projectConfigFromSpec(projectConfig, c)
+		if err := validateRequestIDHeaderForProject(s.Project.Type, cfg); err != nil {
+			addErr("project_config.request_id_header: %v", err)
+		}
+		if cfg.Strategy != nil {
+			if err := util.Validate(cfg.Strategy); err != nil {
+				addErr("project_config.strategy: %v", err)
+			}
+		}
+		if cfg.Signature != nil {
+			for i := range cfg.Signature.Versions {
+				if err := util.Validate(cfg.Signature.Versions[i]); err != nil {
+					addErr("project_config.signature.versions[%d]: %v", i, err)
+				}
+			}
+		}
+		if cfg.MetaEvent != nil {
+			for _, eventType := range cfg.MetaEvent.EventType {
+				if !datastore.IsValidMetaEventType(eventType) {
+					addErr("project_config.meta_event: unsupported meta event type %s", eventType)
+				}
+			}
+		}
+
+		projectConfig = cfg
+	}
+
+	for _, et := range desired.EventTypes {
+		e := models.CreateEventType{Name: et.Name, Category: et.Category, Description: et.Description, JSONSchema: et.JSONSchema}
+		if err := e.Validate(); err != nil {
+			addErr("event_types[%s]: %v", et.Name, err)
+		}
+	}
+
+	for i := range desired.Sources {
+		src := &desired.Sources[i]
+		if err := newSourceRequest(src).Validate(); err != nil {
+			addErr("sources[%s]: %v", src.Name, err)
+		}
+	}
+
+	enforceSecure := projectConfig.SSL != nil && projectConfig.SSL.EnforceSecureEndpoints
+	for i := range desired.Endpoints {
+		e := &desired.Endpoints[i]
+
+		u, err := ValidateEndpointURL(e.URL, enforceSecure)
+		if err != nil {
+			addErr("endpoints[%s]: %v", e.Name, err)
+		} else {
+			e.URL = u
+		}
+
+		advanced := true
+		if s.Project.Type == datastore.IncomingProject && e.AdvancedSignatures != nil && !*e.AdvancedSignatures {
+			warn("endpoints[%s]: incoming projects always use advanced signatures", e.Name)
+			e.AdvancedSignatures = &advanced
+		}
+
+		if !s.Licenser.AdvancedEndpointMgmt() {
+			if e.HttpTimeout != 0 && e.HttpTimeout != convoy.HTTP_TIMEOUT || e.SupportEmail != "" {
+				warn("endpoints[%s]: http_timeout and support_email need advanced endpoint management and are ignored", e.Name)
+			}
+			e.HttpTimeout = convoy.HTTP_TIMEOUT
+			e.SupportEmail = ""
+		}
+
+		if e.Authentication != nil && e.Authentication.Type == datastore.APIKeyAuthentication {
+			if _, err = ValidateEndpointAuthentication(endpointAuthFromSpec(e.Authentication)); err != nil {
+				addErr("endpoints[%s]: %v", e.Name, err)
+			}
+		}
+	}
+
+	endpoints := availableNames(desired.Endpoints != nil, prune, len(desired.Endpoints), func(i int) string { return desired.Endpoints[i].Name }, state.endpoints)
+	sources := availableNames(desired.Sources != nil, prune, len(desired.Sources), func(i int) string { return desired.Sources[i].Name }, state.sources)
+	perEndpoint := map[string]int{}
+
+	for i := range desired.Subscriptions {
+		sub := &desired.Subscriptions[i]
+
+		if !endpoints[sub.Endpoint] {
+			addErr("subscriptions[%s]: endpoint %q does not exist", sub.Name, sub.Endpoint)
+		}
+		perEndpoint[sub.Endpoint]++
+
+		if sub.Source != "" && !sources[sub.Source] {
+			addErr("subscriptions[%s]: source %q does not exist", sub.Name, sub.Source)
+		}
+		if s.Project.Type == datastore.IncomingProject && sub.Source == "" {
+			addErr("subscriptions[%s]: source is required for incoming projects", sub.Name)
+		}
+
+		if sub.DeliveryMode != "" && sub.DeliveryMode != datastore.AtLeastOnceDeliveryMode && sub.DeliveryMode != datastore.AtMostOnceDeliveryMode {
+			addErr("subscriptions[%s]: invalid delivery mode value, must be either 'at_least_once' or 'at_most_once'", sub.Name)
+		}
+
+		if !s.Licenser.AdvancedSubscriptions() && (len(sub.EventTypes) > 0 || sub.Filter != nil || len(sub.EventTypeFilters) > 0) {
+			warn("subscriptions[%s]: event_types, filter and event_type_filters need advanced subscriptions and are ignored", sub.Name)
+			sub.EventTypes, sub.Filter, sub.EventTypeFilters = nil, nil, nil
+		}
+		if !s.Licenser.Transformations() && sub.Function != "" {
+			warn("subscriptions[%s]: function needs transformations and is ignored", sub.Name)
+			sub.Function = ""
+		}
+
+		filter := filterSchemaFromSpec(sub.Filter)
+		if err := filter.ValidateExpression(); err != nil {
+			addErr("subscriptions[%s].filter: %v", sub.Name, err)
+		}
+		for _, f := range sub.EventTypeFilters {
+			etf := datastore.EventTypeFilter{Headers: f.Headers, Body: f.Body, Query: f.Query, Path: f.Path, Expression: f.Expression}
+			if err := etf.ValidateExpression(); err != nil {
+				addErr("subscriptions[%s].event_type_filters[%s]: %v", sub.Name, f.EventType, err)
+			}
+		}
+	}
+
+	if desired.Subscriptions != nil && s.Project.Type == datastore.OutgoingProject && !projectConfig.MultipleEndpointSubscriptions {
+		for name, count := range perEndpoint {
+			if count > 1 {
+				addErr("endpoints[%s]: has %d subscriptions but the project does not allow multiple endpoint subscriptions", name, count)
+			}
+		}
+	}
+
+	for _, p := range desired.PortalLinks {
+		if err := newPortalLinkRequest(&p).Validate(); err != nil {
+			addErr("portal_links[%s]: %v", p.Name, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkPlan rejects the changes the repositories cannot make in place.
+func (s *ProjectSpecService) checkPlan(plan *project_spec.Plan, desired *project_spec.Document, state *projectState) error {
+	var errs []error
+	for _, change := range plan.Changes {
+		switch {
+		case change.Kind == project_spec.KindSource && change.Action == project_spec.ActionUpdate:
+			want := findByName(desired.Sources, change.Name, func(s *project_spec.Source) string { return s.Name })
+			cur := state.sources[change.Name]
+			if (want.Verifier == nil) != (cur.Verifier == nil || cur.Verifier.Type == datastore.NoopVerifier) {
+				errs = append(errs, fmt.Errorf("sources[%s]: a verifier cannot be added to or removed from an existing source", change.Name))
+			}
+			if want.Type != cur.Type {
+				errs = append(errs, fmt.Errorf("sources[%s]: the type of an existing source cannot be changed", change.Name))
+			}
+
+		case change.Kind == project_spec.KindEndpoint && (change.Action == project_spec.ActionCreate || change.Action == project_spec.ActionUpdate):
+			want := findByName(desired.Endpoints, change.Name, func(e *project_spec.Endpoint) string { return e.Name })
+			if want.Authentication == nil || want.Authentication.Type == datastore.APIKeyAuthentication {
+				continue
+			}
+
+			cur := state.endpoints[change.Name]
+			if cur == nil || cur.Authentication == nil || cur.Authentication.Type != want.Authentication.Type {
+				errs = append(errs, fmt.Errorf("endpoints[%s]: %s authentication can only be configured through the API", change.Name, want.Authentication.Type))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *ProjectSpecService) apply(ctx context.Context, change project_spec.Change, desired *project_spec.Document, state *projectState) error {
+	switch change.Kind {
+	case project_spec.KindProjectConfig:
+		return s.applyProjectConfig(ctx, desired.ProjectConfig)
+	case project_spec.KindEventType:
+		return s.applyEventType(ctx, change, desired, state)
+	case project_spec.KindSource:
+		return s.applySource(ctx, change, desired, state)
+	case project_spec.KindEndpoint:
+		return s.applyEndpoint(ctx, change, desired, state)
+	case project_spec.KindSubscription:
+		return s.applySubscription(ctx, change, desired, state)
+	case project_spec.KindPortalLink:
+		return s.applyPortalLink(ctx, change, desired, state)
+	default:
+		return fmt.Errorf("unknown kind %s", change.Kind)
+	}
+}
+
+func (s *ProjectSpecService) applyProjectConfig(ctx context.Context, c *project_spec.ProjectConfig) error {
+	current := s.Project.Config
+	if current == nil {
+		current = &datastore.ProjectConfig{}
+	}
+
+	cfg := projectConfigFromSpec(current, c)
+	normalizeRequestIDHeader(cfg)
+	if cfg.Signature != nil {
+		checkSignatureVersions(cfg.Signature.Versions)
+	}
+	if err := validateMetaEvent(cfg, s.Licenser); err != nil {
+		return err
+	}
+
+	project := *s.Project
+	project.Config = cfg
+	if err := s.ProjectRepo.UpdateProject(ctx, &project); err != nil {
+		return err
+	}
+
+	s.Project.Config = cfg
+	return nil
+}
+
+func (s *ProjectSpecService) applyEventType(ctx context.Context, change project_spec.Change, desired *project_spec.Document, state *projectState) error {
+	if change.Action == project_spec.ActionDeprecate {
+		et := state.eventTypes[change.Name]
+		_, err := s.EventTypesRepo.DeprecateEventType(ctx, et.UID, s.Project.UID)
+		return err
+	}
+
+	want := findByName(desired.EventTypes, change.Name, func(e *project_spec.EventType) string { return e.Name })
+	schema, err := json.Marshal(want.JSONSchema)
+	if err != nil {
+		return err
+	}
+	if want.JSONSchema == nil {
+		schema = []byte("{}")
+	}
+
+	et := state.eventTypes[change.Name]
+	if change.Action == project_spec.ActionCreate {
+		et = &datastore.ProjectEventType{
+			UID:       ulid.Make().String(),
+			Name:      want.Name,
+			ProjectId: s.Project.UID,
+		}
+	}
+
+	et.Description = want.Description
+	et.Category = want.Category
+	et.JSONSchema = schema
+
+	if change.Action == project_spec.ActionCreate {
+		err = s.EventTypesRepo.CreateEventType(ctx, et)
+	} else {
+		err = s.EventTypesRepo.UpdateEventType(ctx, et)
+	}
+	if err != nil {
+		return err
+	}
+	state.eventTypes[want.Name] = et
+
+	if want.Deprecated && !et.DeprecatedAt.Valid {
+		_, err = s.EventTypesRepo.DeprecateEventType(ctx, et.UID, s.Project.UID)
+	}
+	return err
+}
+
+func (s *ProjectSpecService) applySource(ctx context.Context, change project_spec.Change, desired *project_spec.Document, state *projectState) error {
+	if change.Action == project_spec.ActionDelete {
+		src := state.sources[change.Name]
+		return s.SourceRepo.DeleteSourceByID(ctx, s.Project.UID, src.UID, src.VerifierID)
+	}
+
+	want := findByName(desired.Sources, change.Name, func(src *project_spec.Source) string { return src.Name })
+	req := newSourceRequest(want)
+
+	if change.Action == project_spec.ActionCreate {
+		src, err := (&CreateSourceService{SourceRepo: s.SourceRepo, NewSource: req, Project: s.Project, Logger: s.Logger}).Run(ctx)
+		if err != nil {
+			return err
+		}
+
+		src.IsDisabled = want.IsDisabled
+		src.ForwardHeaders = want.ForwardHeaders
+		if src.IsDisabled || len(src.ForwardHeaders) > 0 {
+			if err = s.SourceRepo.UpdateSource(ctx, s.Project.UID, src); err != nil {
+				return err
+			}
+		}
+
+		state.sources[want.Name] = src
+		return nil
+	}
+
+	src := state.sources[change.Name]
+	src.Provider = want.Provider
+	src.IsDisabled = want.IsDisabled
+	src.Verifier = req.Verifier.Transform()
+	src.ForwardHeaders = want.ForwardHeaders
+	src.IdempotencyKeys = want.IdempotencyKeys
+	src.EventTypeLocation = want.EventTypeLocation
+	src.CustomResponse = datastore.CustomResponse{Body: req.CustomResponse.Body, ContentType: req.CustomResponse.ContentType}
+	src.BodyFunction = req.BodyFunction
+	src.HeaderFunction = req.HeaderFunction
+
+	if datastore.SourceUsesPayloadSignature(src) && datastore.EventTypeLocationUsesRequestMetadata(src.EventTypeLocation) {
+		return errors.New("event type location cannot use request headers or query parameters with payload signature verification")
+	}
+
+	return s.SourceRepo.UpdateSource(ctx, s.Project.UID, src)
+}
+
+func (s *ProjectSpecService) applyEndpoint(ctx context.Context, change project_spec.Change, desired *project_spec.Document, state *projectState) error {
+	if change.Action == project_spec.ActionDelete {
+		return s.EndpointRepo.DeleteEndpoint(ctx, state.endpoints[change.Name], s.Project.UID)
+	}
+
+	want := findByName(desired.Endpoints, change.Name, func(e *project_spec.Endpoint) string { return e.Name })
+
+	endpoint := state.endpoints[change.Name]
+	if change.Action == project_spec.ActionCreate {
+		uid := ulid.Make().String()
+		endpoint = &datastore.Endpoint{
+			UID:       uid,
+			ProjectID: s.Project.UID,
+			AppID:     uid,
+			Status:    datastore.ActiveEndpointStatus,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	endpoint.Name = want.Name
+	endpoint.Url = want.URL
+	endpoint.Description = want.Description
+	endpoint.OwnerID = want.OwnerID
+	endpoint.ContentType = want.ContentType
+	endpoint.HttpTimeout = want.HttpTimeout
+	endpoint.RateLimit = want.RateLimit
+	endpoint.RateLimitDuration = want.RateLimitDuration
+	endpoint.AdvancedSignatures = want.AdvancedSignatures == nil || *want.AdvancedSignatures
+	endpoint.SupportEmail = want.SupportEmail
+	endpoint.UpdatedAt = time.Now()
+
+	// OAuth2 and basic auth are left as they are, checkPlan made sure the
+	// endpoint already uses them.
+	if want.Authentication == nil || want.Authentication.Type == datastore.APIKeyAuthentication {
+		endpoint.Authentication = endpointAuthFromSpec(want.Authentication)
+	}
+
+	if err := rotateEndpointSecret(endpoint, want.Secret.Value()); err != nil {
+		return err
+	}
+
+	var err error
+	if change.Action == project_spec.ActionCreate {
+		err = s.EndpointRepo.CreateEndpoint(ctx, endpoint, s.Project.UID)
+	} else {
+		err = s.EndpointRepo.UpdateEndpoint(ctx, endpoint, s.Project.UID)
+	}
+	if err != nil {
+		return err
+	}
+
+	state.endpoints[want.Name] = endpoint
+	return nil
+}
+
+// rotateEndpointSecret makes value the endpoint's active secret, expiring the
+// current one now and dropping the ones that have expired. An empty value
+// keeps the current secret, or generates one for a new endpoint.
+func rotateEndpointSecret(endpoint *datastore.Endpoint, value string) error {
+	idx, err := endpoint.GetActiveSecretIndex()
+	hasActive := err == nil
+	if hasActive && (value == "" || endpoint.Secrets[idx].Value == value) {
+		return nil
+	}
+
+	if value == "" {
+		if value, err = util.GenerateSecret(); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	secrets := datastore.Secrets{}
+	for i, secret := range endpoint.Secrets {
+		switch {
+		case hasActive && i == idx:
+			secret.ExpiresAt = null.TimeFrom(now)
+			secret.UpdatedAt = now
+		case secret.ExpiresAt.Valid && secret.ExpiresAt.Time.After(now):
+		default:
+			continue
+		}
+		secrets = append(secrets, secret)
+	}
+
+	endpoint.Secrets = append(secrets, datastore.Secret{
+		UID:       ulid.Make().String(),
+		Value:     value,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	return nil
+}
+
+func (s *ProjectSpecService) applySubscription(ctx context.Context, change project_spec.Change, desired *project_spec.Document, state *projectState) error {
+	if change.Action == project_spec.ActionDelete {
+		return s.SubRepo.DeleteSubscription(ctx, s.Project.UID, state.subscriptions[change.Name])
+	}
+
+	want := findByName(desired.Subscriptions, change.Name, func(sub *project_spec.Subscription) string { return sub.Name })
+
+	sub := state.subscriptions[change.Name]
+	if change.Action == project_spec.ActionCreate {
+		sub = &datastore.Subscription{
+			UID:       ulid.Make().String(),
+			ProjectID: s.Project.UID,
+			Type:      datastore.SubscriptionTypeAPI,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	sub.Name = want.Name
+	sub.EndpointID = state.endpoints[want.Endpoint].UID
+	sub.SourceID = ""
+	if want.Source != "" {
+		sub.SourceID = state.sources[want.Source].UID
+	}
+	sub.DeliveryMode = want.DeliveryMode
+	sub.Function = null.NewString(want.Function, want.Function != "")
+	sub.AlertConfig = want.AlertConfig
+	sub.RateLimitConfig = want.RateLimit
+	sub.FilterConfig = &datastore.FilterConfiguration{
+		EventTypes: want.EventTypes,
+		Filter:     filterSchemaFromSpec(want.Filter),
+	}
+	sub.UpdatedAt = time.Now()
+
+	var err error
+	if change.Action == project_spec.ActionCreate {
+		err = s.SubRepo.CreateSubscription(ctx, s.Project.UID, sub)
+	} else {
+		err = s.SubRepo.UpdateSubscription(ctx, s.Project.UID, sub)
+	}
+	if err != nil {
+		return err
+	}
+	state.subscriptions[want.Name] = sub
+
+	return s.reconcileFilters(ctx, sub, want)
+}
+
+// reconcileFilters gives each of the subscription's event type filters its
+// override, or the subscription's filter. The repository keeps the filters
+// of event types that stay on a subscription as they were.
+func (s *ProjectSpecService) reconcileFilters(ctx context.Context, sub *datastore.Subscription, want *project_spec.Subscription) error {
+	filters, err := s.FilterRepo.FindFiltersBySubscriptionID(ctx, sub.UID)
+	if err != nil {
+		return err
+	}
+
+	overrides := make(map[string]project_spec.EventTypeFilter, len(want.EventTypeFilters))
+	for _, f := range want.EventTypeFilters {
+		overrides[f.EventType] = f
+	}
+
+	var base project_spec.Filter
+	if want.Filter != nil {
+		base = *want.Filter
+	}
+
+	write := func(filter *datastore.EventTypeFilter, spec project_spec.Filter, disabled bool, create bool) error {
+		schema := filterSchemaFromSpec(&spec)
+		if !create && filterMatches(filter, schema, disabled) {
+			return nil
+		}
+
+		filter.Headers, filter.RawHeaders = schema.Headers, models.CloneFilterMap(schema.Headers)
+		filter.Body, filter.RawBody = schema.Body, models.CloneFilterMap(schema.Body)
+		filter.Query, filter.RawQuery = schema.Query, models.CloneFilterMap(schema.Query)
+		filter.Path, filter.RawPath = schema.Path, models.CloneFilterMap(schema.Path)
+		filter.Expression = schema.Expression
+
+		filter.EnabledAtSet = true
+		if disabled {
+			filter.EnabledAt = nil
+		} else if filter.EnabledAt == nil {
+			now := time.Now()
+			filter.EnabledAt = &now
+		}
+
+		if create {
+			return s.FilterRepo.CreateFilter(ctx, filter)
+		}
+		return s.FilterRepo.UpdateFilter(ctx, filter)
+	}
+
+	for i := range filters {
+		f := &filters[i]
+		spec, disabled := base, false
+		if o, ok := overrides[f.EventType]; ok {
+			spec, disabled = o.Filter, o.Disabled
+			delete(overrides, f.EventType)
+		}
+
+		if err = write(f, spec, disabled, false); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range overrides {
+		f := &datastore.EventTypeFilter{
+			UID:            ulid.Make().String(),
+			SubscriptionID: sub.UID,
+			EventType:      o.EventType,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+		if err = write(f, o.Filter, o.Disabled, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func filterMatches(f *datastore.EventTypeFilter, schema datastore.FilterSchema, disabled bool) bool {
+	have, _ := json.Marshal([]interface{}{f.RawHeaders, f.RawBody, f.RawQuery, f.RawPath, f.Expression, !f.IsEnabled()})
+	want, _ := json.Marshal([]interface{}{schema.RawHeaders, schema.RawBody, schema.RawQuery, schema.RawPath, schema.Expression, disabled})
+	return string(have) == string(want)
+}
+
+func (s *ProjectSpecService) applyPortalLink(ctx context.Context, change project_spec.Change, desired *project_spec.Document, state *projectState) error {
+	if change.Action == project_spec.ActionDelete {
+		return s.PortalLinkRepo.RevokePortalLink(ctx, s.Project.UID, state.portalLinks[change.Name].UID)
+	}
+
+	// A link only rewritten to take in its owner's endpoints may not be in a
+	// managed section.
+	want := findByName(desired.PortalLinks, change.Name, func(p *project_spec.PortalLink) string { return p.Name })
+	if want == nil {
+		want = findByName(state.doc.PortalLinks, change.Name, func(p *project_spec.PortalLink) string { return p.Name })
+	}
+	req := newPortalLinkRequest(want)
+
+	if change.Action == project_spec.ActionCreate {
+		link, err := s.PortalLinkRepo.CreatePortalLink(ctx, s.Project.UID, req)
+		if err != nil {
+			return err
+		}
+		state.portalLinks[want.Name] = link
+		return nil
+	}
+
+	link, err := s.PortalLinkRepo.UpdatePortalLink(ctx, s.Project.UID, state.portalLinks[change.Name], &datastore.UpdatePortalLinkRequest{
+		Name:              req.Name,
+		AuthType:          req.AuthType,
+		OwnerID:           req.OwnerID,
+		CanManageEndpoint: req.CanManageEndpoint,
+		EventTypes:        req.EventTypes,
+		Permissions:       req.Permissions,
+	})
+	if err != nil {
+		return err
+	}
+
+	state.portalLinks[want.Name] = link
+	return nil
+}
+
+const projectSpecPageSize = 100
+
+func loadAllPages[T any](load func(datastore.Pageable) ([]T, datastore.PaginationData, error)) ([]T, error) {
+	cursor := firstPageCursor
+	var all []T
+
+	for {
+		items, page, err := load(datastore.Pageable{
+			PerPage:    projectSpecPageSize,
+			Direction:  datastore.Next,
+			NextCursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if !page.HasNextPage {
+			return all, nil
+		}
+		cursor = page.NextPageCursor
+	}
+}
+
+// load reads the project's configuration and describes it as a document.
+func (s *ProjectSpecService) load(ctx context.Context) (*projectState, error) {
+	pid := s.Project.UID
+	state := &projectState{
+		doc: &project_spec.Document{
+			Version:       project_spec.Version,
+			ProjectConfig: projectConfigToSpec(s.Project.Config),
+			EventTypes:    []project_spec.EventType{},
+			Sources:       []project_spec.Source{},
+			Endpoints:     []project_spec.Endpoint{},
+			Subscriptions: []project_spec.Subscription{},
+			PortalLinks:   []project_spec.PortalLink{},
+		},
+		eventTypes:    map[string]*datastore.ProjectEventType{},
+		sources:       map[string]*datastore.Source{},
+		endpoints:     map[string]*datastore.Endpoint{},
+		subscriptions: map[string]*datastore.Subscription{},
+		portalLinks:   map[string]*datastore.PortalLink{},
+		duplicates:    map[project_spec.Kind][]string{},
+	}
+
+	fail := func(what string, err error) error {
+		s.Logger.ErrorContext(ctx, "failed to load "+what, "error", err)
+		return &ServiceError{ErrMsg: "failed to load " + what, Err: err}
+	}
+
+	eventTypes, err := s.EventTypesRepo.FetchAllEventTypes(ctx, pid)
+	if err != nil {
+		return nil, fail("event types", err)
+	}
+	for i := range eventTypes {
+		et := &eventTypes[i]
+		if et.Name == "*" || !state.add(project_spec.KindEventType, et.Name, state.eventTypes, et) {
+			continue
+		}
+		state.doc.EventTypes = append(state.doc.EventTypes, eventTypeToSpec(et))
+	}
+
+	sources, err := loadAllPages(func(p datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
+		return s.SourceRepo.LoadSourcesPaged(ctx, pid, &datastore.SourceFilter{}, p)
+	})
+	if err != nil {
+		return nil, fail("sources", err)
+	}
+	sourceNames := map[string]string{}
+	for i := range sources {
+		src := &sources[i]
+		if src.Type == datastore.PubSubSource {
+			continue
+		}
+		sourceNames[src.UID] = src.Name
+		if state.add(project_spec.KindSource, src.Name, state.sources, src) {
+			state.doc.Sources = append(state.doc.Sources, sourceToSpec(src))
+		}
+	}
+
+	endpoints, err := loadAllPages(func(p datastore.Pageable) ([]datastore.Endpoint, datastore.PaginationData, error) {
+		return s.EndpointRepo.LoadEndpointsPaged(ctx, pid, &datastore.Filter{}, p)
+	})
+	if err != nil {
+		return nil, fail("endpoints", err)
+	}
+	endpointNames := map[string]string{}
+	for i := range endpoints {
+		e := &endpoints[i]
+		endpointNames[e.UID] = e.Name
+		if state.add(project_spec.KindEndpoint, e.Name, state.endpoints, e) {
+			state.doc.Endpoints = append(state.doc.Endpoints, endpointToSpec(e))
+		}
+	}
+
+	subscriptions, err := loadAllPages(func(p datastore.Pageable) ([]datastore.Subscription, datastore.PaginationData, error) {
+		return s.SubRepo.LoadSubscriptionsPaged(ctx, pid, &datastore.FilterBy{ProjectID: pid}, p)
+	})
+	if err != nil {
+		return nil, fail("subscriptions", err)
+	}
+	for i := range subscriptions {
+		sub := &subscriptions[i]
+		if sub.Type == datastore.SubscriptionTypeCLI || endpointNames[sub.EndpointID] == "" {
+			continue
+		}
+		if !state.add(project_spec.KindSubscription, sub.Name, state.subscriptions, sub) {
+			continue
+		}
+
+		filters, err := s.FilterRepo.FindFiltersBySubscriptionID(ctx, sub.UID)
+		if err != nil {
+			return nil, fail("subscription filters", err)
+		}
+		state.doc.Subscriptions = append(state.doc.Subscriptions, subscriptionToSpec(sub, endpointNames[sub.EndpointID], sourceNames[sub.SourceID], filters))
+	}
+
+	links, err := loadAllPages(func(p datastore.Pageable) ([]datastore.PortalLink, datastore.PaginationData, error) {
+		return s.PortalLinkRepo.LoadPortalLinksPaged(ctx, pid, &datastore.FilterBy{ProjectID: pid}, p)
+	})
+	if err != nil {
+		return nil, fail("portal links", err)
+	}
+	for i := range links {
+		link := &links[i]
+		if state.add(project_spec.KindPortalLink, link.Name, state.portalLinks, link) {
+			state.doc.PortalLinks = append(state.doc.PortalLinks, portalLinkToSpec(link))
+		}
+	}
+
+	return state, nil
+}
+
+// add records an object by name, reporting false when the name is taken.
+func (st *projectState) add(kind project_spec.Kind, name string, byName interface{}, obj interface{}) bool {
+	var taken bool
+	switch m := byName.(type) {
+	case map[string]*datastore.ProjectEventType:
+		if _, taken = m[name]; !taken {
+			m[name] = obj.(*datastore.ProjectEventType)
+		}
+	case map[string]*datastore.Source:
+		if _, taken = m[name]; !taken {
+			m[name] = obj.(*datastore.Source)
+		}
+	case map[string]*datastore.Endpoint:
+		if _, taken = m[name]; !taken {
+			m[name] = obj.(*datastore.Endpoint)
+		}
+	case map[string]*datastore.Subscription:
+		if _, taken = m[name]; !taken {
+			m[name] = obj.(*datastore.Subscription)
+		}
+	case map[string]*datastore.PortalLink:
+		if _, taken = m[name]; !taken {
+			m[name] = obj.(*datastore.PortalLink)
+		}
+	}
+
+	if taken {
+		st.duplicates[kind] = append(st.duplicates[kind], name)
+	}
+	return !taken
+}
+
+func (st *projectState) checkDuplicates(kinds []project_spec.Kind) error {
+	var errs []error
+	for _, kind := range kinds {
+		names := st.duplicates[kind]
+		sort.Strings(names)
+		for _, name := range names {
+			errs = append(errs, fmt.Errorf("more than one %s is named %q, rename them to manage the project with a document", kind, name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return util.NewServiceError(http.StatusConflict, errors.Join(errs...))
+	}
+	return nil
+}
+
+// kindsOf lists the sections a document manages.
+func kindsOf(doc *project_spec.Document) []project_spec.Kind {
+	var kinds []project_spec.Kind
+	if doc.EventTypes != nil {
+		kinds = append(kinds, project_spec.KindEventType)
+	}
+	if doc.Sources != nil {
+		kinds = append(kinds, project_spec.KindSource)
+	}
+	if doc.Endpoints != nil {
+		kinds = append(kinds, project_spec.KindEndpoint)
+	}
+	if doc.Subscriptions != nil {
+		kinds = append(kinds, project_spec.KindSubscription)
+	}
+	if doc.PortalLinks != nil {
+		kinds = append(kinds, project_spec.KindPortalLink)
+	}
+	return kinds
+}
+
+// availableNames are the names subscriptions can refer to once the document
+// is applied.
+func availableNames[T any](managed, prune bool, n int, name func(int) string, current map[string]*T) map[string]bool {
+	names := map[string]bool{}
+	if !managed || !prune {
+		for k := range current {
+			names[k] = true
+		}
+	}
+	for i := 0; i < n; i++ {
+		names[name(i)] = true
+	}
+	return names
+}
+
+func findByName[T any](items []T, name string, nameOf func(*T) string) *T {
+	for i := range items {
+		if nameOf(&items[i]) == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func projectConfigToSpec(cfg *datastore.ProjectConfig) *project_spec.ProjectConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	c := &project_spec.ProjectConfig{
+		MaxPayloadReadSize:             cfg.MaxIngestSize,
+		ReplayAttacksPreventionEnabled: cfg.ReplayAttacks,
+		AddEventIDTraceHeaders:         cfg.AddEventIDTraceHeaders,
+		DisableEndpoint:                cfg.DisableEndpoint,
+		MultipleEndpointSubscriptions:  cfg.MultipleEndpointSubscriptions,
+		VerifyDynamicEvents:            cfg.VerifyDynamicEvents,
+		AllowUnmatchedDynamicURLs:      cfg.AllowUnmatchedDynamicURLs,
+		EndpointMetricLabels:           cfg.EndpointMetricLabels,
+		RecordFilterRejections:         cfg.RecordFilterRejections,
+		SearchPolicy:                   cfg.SearchPolicy,
+		RequestIDHeader:                string(cfg.GetRequestIDHeader()),
+		SSL:                            cfg.SSL,
+		RateLimit:                      cfg.RateLimit,
+		Strategy:                       cfg.Strategy,
+		CircuitBreaker:                 cfg.CircuitBreaker,
+	}
+
+	if cfg.Signature != nil {
+		c.Signature = &project_spec.Signature{Header: string(cfg.Signature.Header), Versions: []project_spec.SignatureVersion{}}
+		for _, v := range cfg.Signature.Versions {
+			c.Signature.Versions = append(c.Signature.Versions, project_spec.SignatureVersion{Hash: v.Hash, Encoding: v.Encoding})
+		}
+	}
+
+	// Pub/sub meta events are not part of the document.
+	if me := cfg.MetaEvent; me != nil && me.Type != datastore.PubSubMetaEvent {
+		c.MetaEvent = &project_spec.MetaEvent{IsEnabled: me.IsEnabled, URL: me.URL, EventType: me.EventType}
+		if me.Secret != "" {
+			c.MetaEvent.Secret = project_spec.NewSecret("", me.Secret)
+		}
+	}
+
+	return c
+}
+
+// projectConfigFromSpec applies a document's project config to a copy of
+// the current one.
+func projectConfigFromSpec(current *datastore.ProjectConfig, c *project_spec.ProjectConfig) *datastore.ProjectConfig {
+	cfg := *current
+	if c.MaxPayloadReadSize != 0 {
+		cfg.MaxIngestSize = c.MaxPayloadReadSize
+	}
+	cfg.ReplayAttacks = c.ReplayAttacksPreventionEnabled
+	cfg.AddEventIDTraceHeaders = c.AddEventIDTraceHeaders
+	cfg.DisableEndpoint = c.DisableEndpoint
+	cfg.MultipleEndpointSubscriptions = c.MultipleEndpointSubscriptions
+	cfg.VerifyDynamicEvents = c.VerifyDynamicEvents
+	cfg.AllowUnmatchedDynamicURLs = c.AllowUnmatchedDynamicURLs
+	cfg.EndpointMetricLabels = c.EndpointMetricLabels
+	cfg.RecordFilterRejections = c.RecordFilterRejections
+	cfg.SearchPolicy = c.SearchPolicy
+	cfg.RequestIDHeader = config.RequestIDHeaderProvider(c.RequestIDHeader)
+
+	if c.SSL != nil {
+		cfg.SSL = c.SSL
+	}
+	if c.RateLimit != nil {
+		cfg.RateLimit = c.RateLimit
+	}
+	if c.Strategy != nil {
+		cfg.Strategy = c.Strategy
+	}
+	if c.CircuitBreaker != nil {
+		cfg.CircuitBreaker = c.CircuitBreaker
+	}
+
+	if c.Signature != nil {
+		var existing datastore.SignatureVersions
+		if current.Signature != nil {
+			existing = current.Signature.Versions
+		}
+
+		// Versions that did not change keep their IDs and creation times.
+		versions := datastore.SignatureVersions{}
+		for i, v := range c.Signature.Versions {
+			version := datastore.SignatureVersion{Hash: v.Hash, Encoding: v.Encoding}
+			if i < len(existing) && existing[i].Hash == v.Hash && existing[i].Encoding == v.Encoding {
+				version = existing[i]
+			}
+			versions = append(versions, version)
+		}
+		cfg.Signature = &datastore.SignatureConfiguration{Header: config.SignatureHeaderProvider(c.Signature.Header), Versions: versions}
+	}
+
+	if c.MetaEvent != nil {
+		cfg.MetaEvent = &datastore.MetaEventConfiguration{
+			IsEnabled: c.MetaEvent.IsEnabled,
+			Type:      datastore.HTTPMetaEvent,
+			EventType: c.MetaEvent.EventType,
+			URL:       c.MetaEvent.URL,
+			Secret:    c.MetaEvent.Secret.Value(),
+		}
+	}
+
+	return &cfg
+}
+
+func eventTypeToSpec(et *datastore.ProjectEventType) project_spec.EventType {
+	spec := project_spec.EventType{
+		Name:        et.Name,
+		Description: et.Description,
+		Category:    et.Category,
+		Deprecated:  et.DeprecatedAt.Valid,
+	}
+
+	if len(et.JSONSchema) > 0 {
+		_ = json.Unmarshal(et.JSONSchema, &spec.JSONSchema)
+	}
+	return spec
+}
+
+func sourceToSpec(src *datastore.Source) project_spec.Source {
+	spec := project_spec.Source{
+		Name:              src.Name,
+		Type:              src.Type,
+		Provider:          src.Provider,
+		IsDisabled:        src.IsDisabled,
+		ForwardHeaders:    src.ForwardHeaders,
+		IdempotencyKeys:   src.IdempotencyKeys,
+		EventTypeLocation: src.EventTypeLocation,
+	}
+
+	if src.CustomResponse != (datastore.CustomResponse{}) {
+		cr := src.CustomResponse
+		spec.CustomResponse = &cr
+	}
+	if src.BodyFunction != nil {
+		spec.BodyFunction = *src.BodyFunction
+	}
+	if src.HeaderFunction != nil {
+		spec.HeaderFunction = *src.HeaderFunction
+	}
+
+	if v := src.Verifier; v != nil && v.Type != datastore.NoopVerifier && v.Type != "" {
+		spec.Verifier = &project_spec.Verifier{Type: v.Type}
+		if v.HMac != nil {
+			spec.Verifier.HMac = &project_spec.HMac{Header: v.HMac.Header, Hash: v.HMac.Hash, Encoding: v.HMac.Encoding, Secret: *project_spec.NewSecret("", v.HMac.Secret)}
+		}
+		if v.BasicAuth != nil {
+			spec.Verifier.BasicAuth = &project_spec.BasicAuth{UserName: v.BasicAuth.UserName, Password: *project_spec.NewSecret("", v.BasicAuth.Password)}
+		}
+		if v.ApiKey != nil {
+			spec.Verifier.ApiKey = &project_spec.ApiKey{HeaderName: v.ApiKey.HeaderName, HeaderValue: *project_spec.NewSecret("", v.ApiKey.HeaderValue)}
+		}
+	}
+
+	return spec
+}
+
+func newSourceRequest(src *project_spec.Source) *models.CreateSource {
+	req := &models.CreateSource{
+		Name:              src.Name,
+		Type:              src.Type,
+		Provider:          src.Provider,
+		Verifier:          models.VerifierConfig{Type: datastore.NoopVerifier},
+		IdempotencyKeys:   src.IdempotencyKeys,
+		EventTypeLocation: src.EventTypeLocation,
+	}
+
+	if src.CustomResponse != nil {
+		req.CustomResponse = models.CustomResponse{Body: src.CustomResponse.Body, ContentType: src.CustomResponse.ContentType}
+	}
+	if src.BodyFunction != "" {
+		req.BodyFunction = &src.BodyFunction
+	}
+	if src.HeaderFunction != "" {
+		req.HeaderFunction = &src.HeaderFunction
+	}
+
+	if v := src.Verifier; v != nil {
+		req.Verifier.Type = v.Type
+		if v.HMac != nil {
+			req.Verifier.HMac = &models.HMac{Header: v.HMac.Header, Hash: v.HMac.Hash, Encoding: v.HMac.Encoding, Secret: v.HMac.Secret.Value()}
+		}
+		if v.BasicAuth != nil {
+			req.Verifier.BasicAuth = &models.BasicAuth{UserName: v.BasicAuth.UserName, Password: v.BasicAuth.Password.Value()}
+		}
+		if v.ApiKey != nil {
+			req.Verifier.ApiKey = &models.ApiKey{HeaderName: v.ApiKey.HeaderName, HeaderValue: v.ApiKey.HeaderValue.Value()}
+		}
+	}
+
+	return req
+}
+
+func endpointToSpec(e *datastore.Endpoint) project_spec.Endpoint {
+	advanced := e.AdvancedSignatures
+	spec := project_spec.Endpoint{
+		Name:               e.Name,
+		URL:                e.Url,
+		Description:        e.Description,
+		OwnerID:            e.OwnerID,
+		ContentType:        e.ContentType,
+		HttpTimeout:        e.HttpTimeout,
+		RateLimit:          e.RateLimit,
+		RateLimitDuration:  e.RateLimitDuration,
+		AdvancedSignatures: &advanced,
+		SupportEmail:       e.SupportEmail,
+	}
+
+	if idx, err := e.GetActiveSecretIndex(); err == nil {
+		spec.Secret = project_spec.NewSecret("", e.Secrets[idx].Value)
+	}
+
+	if auth := e.Authentication; auth != nil && auth.Type != "" {
+		spec.Authentication = &project_spec.EndpointAuthentication{Type: auth.Type}
+		if auth.Type == datastore.APIKeyAuthentication && auth.ApiKey != nil {
+			spec.Authentication.ApiKey = &project_spec.ApiKey{HeaderName: auth.ApiKey.HeaderName, HeaderValue: *project_spec.NewSecret("", auth.ApiKey.HeaderValue)}
+		}
+	}
+
+	return spec
+}
+
+func endpointAuthFromSpec(auth *project_spec.EndpointAuthentication) *datastore.EndpointAuthentication {
+	if auth == nil {
+		return nil
+	}
+
+	a := &datastore.EndpointAuthentication{Type: auth.Type}
+	if auth.ApiKey != nil {
+		a.ApiKey = &datastore.ApiKey{HeaderName: auth.ApiKey.HeaderName, HeaderValue: auth.ApiKey.HeaderValue.Value()}
+	}
+	return a
+}
+
+func subscriptionToSpec(sub *datastore.Subscription, endpoint, source string, filters []datastore.EventTypeFilter) project_spec.Subscription {
+	spec := project_spec.Subscription{
+		Name:         sub.Name,
+		Endpoint:     endpoint,
+		Source:       source,
+		DeliveryMode: sub.DeliveryMode,
+		Function:     sub.Function.String,
+		AlertConfig:  sub.AlertConfig,
+		RateLimit:    sub.RateLimitConfig,
+	}
+
+	if fc := sub.FilterConfig; fc != nil {
+		spec.EventTypes = fc.EventTypes
+		spec.Filter = &project_spec.Filter{
+			Headers:    fc.Filter.RawHeaders,
+			Body:       fc.Filter.RawBody,
+			Query:      fc.Filter.RawQuery,
+			Path:       fc.Filter.RawPath,
+			Expression: fc.Filter.Expression,
+		}
+	}
+
+	// Every filter is listed, the document leaves out the ones matching the
+	// subscription's filter when it is normalised.
+	for _, f := range filters {
+		spec.EventTypeFilters = append(spec.EventTypeFilters, project_spec.EventTypeFilter{
+			EventType: f.EventType,
+			Filter: project_spec.Filter{
+				Headers:    f.RawHeaders,
+				Body:       f.RawBody,
+				Query:      f.RawQuery,
+				Path:       f.RawPath,
+				Expression: f.Expression,
+			},
+			Disabled: !f.IsEnabled(),
+		})
+	}
+
+	return spec
+}
+
+func filterSchemaFromSpec(f *project_spec.Filter) datastore.FilterSchema {
+	schema := emptyFilterSchema()
+	if f == nil {
+		return schema
+	}
+
+	if len(f.Headers) > 0 {
+		schema.Headers, schema.RawHeaders = models.CloneFilterMap(f.Headers), models.CloneFilterMap(f.Headers)
+	}
+	if len(f.Body) > 0 {
+		schema.Body, schema.RawBody = models.CloneFilterMap(f.Body), models.CloneFilterMap(f.Body)
+	}
+	if len(f.Query) > 0 {
+		schema.Query, schema.RawQuery = models.CloneFilterMap(f.Query), models.CloneFilterMap(f.Query)
+	}
+	if len(f.Path) > 0 {
+		schema.Path, schema.RawPath = models.CloneFilterMap(f.Path), models.CloneFilterMap(f.Path)
+	}
+	schema.Expression = f.Expression
+
+	return schema
+}
+
+func portalLinkToSpec(link *datastore.PortalLink) project_spec.PortalLink {
+	return project_spec.PortalLink{
+		Name:              link.Name,
+		OwnerID:           link.OwnerID,
+		AuthType:          link.AuthType,
+		CanManageEndpoint: link.CanManageEndpoint,
+		EventTypes:        link.EventTypes,
+		Permissions:       link.Permissions,
+	}
+}
+
+func newPortalLinkRequest(p *project_spec.PortalLink) *datastore.CreatePortalLinkRequest {
+	req := &datastore.CreatePortalLinkRequest{
+		Name:              p.Name,
+		AuthType:          string(p.AuthType),
+		OwnerID:           p.OwnerID,
+		CanManageEndpoint: p.CanManageEndpoint,
+		EventTypes:        p.EventTypes,
+		Permissions:       p.Permissions,
+	}
+	req.SetDefaultAuthType()
+	return req
+}
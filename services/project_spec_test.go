@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/project_spec"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/util"
+)
+
+func provideProjectSpecService(ctrl *gomock.Controller) *ProjectSpecService {
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().AdvancedEndpointMgmt().Return(true).AnyTimes()
+	licenser.EXPECT().AdvancedSubscriptions().Return(true).AnyTimes()
+	licenser.EXPECT().Transformations().Return(true).AnyTimes()
+	licenser.EXPECT().EventSearch().Return(true).AnyTimes()
+
+	return &ProjectSpecService{
+		ProjectRepo:    mocks.NewMockProjectRepository(ctrl),
+		EndpointRepo:   mocks.NewMockEndpointRepository(ctrl),
+		SourceRepo:     mocks.NewMockSourceRepository(ctrl),
+		SubRepo:        mocks.NewMockSubscriptionRepository(ctrl),
+		FilterRepo:     mocks.NewMockFilterRepository(ctrl),
+		EventTypesRepo: mocks.NewMockEventTypesRepository(ctrl),
+		PortalLinkRepo: mocks.NewMockPortalLinkRepository(ctrl),
+		Licenser:       licenser,
+		Logger:         mocks.NewMockLogger(ctrl),
+		Project:        &datastore.Project{UID: "project-1", Type: datastore.OutgoingProject, Config: &datastore.ProjectConfig{}},
+	}
+}
+
+// expectProject makes the repositories return a project with an endpoint, a
+// source, a subscription and a portal link.
+func expectProject(s *ProjectSpecService) {
+	s.EventTypesRepo.(*mocks.MockEventTypesRepository).EXPECT().
+		FetchAllEventTypes(gomock.Any(), "project-1").
+		Return([]datastore.ProjectEventType{{UID: "et-0", Name: "*"}, {UID: "et-1", Name: "order.created", JSONSchema: []byte(`{"type":"object"}`)}}, nil)
+
+	s.SourceRepo.(*mocks.MockSourceRepository).EXPECT().
+		LoadSourcesPaged(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).
+		Return([]datastore.Source{{
+			UID:  "src-1",
+			Name: "github",
+			Type: datastore.HTTPSource,
+			Verifier: &datastore.VerifierConfig{
+				Type: datastore.HMacVerifier,
+				HMac: &datastore.HMac{Header: "X-Hub-Signature", Hash: "SHA256", Encoding: datastore.HexEncoding, Secret: "github-secret"},
+			},
+		}}, datastore.PaginationData{}, nil)
+
+	s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().
+		LoadEndpointsPaged(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).
+		Return([]datastore.Endpoint{{
+			UID:                "ep-1",
+			Name:               "orders",
+			Url:                "https://example.com/orders",
+			OwnerID:            "tenant-1",
+			ContentType:        "application/json",
+			AdvancedSignatures: true,
+			HttpTimeout:        30,
+			Secrets:            []datastore.Secret{{UID: "secret-1", Value: "orders-secret"}},
+		}}, datastore.PaginationData{}, nil)
+
+	s.SubRepo.(*mocks.MockSubscriptionRepository).EXPECT().
+		LoadSubscriptionsPaged(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).
+		Return([]datastore.Subscription{
+			{
+				UID:          "sub-1",
+				Name:         "orders",
+				Type:         datastore.SubscriptionTypeAPI,
+				EndpointID:   "ep-1",
+				SourceID:     "src-1",
+				DeliveryMode: datastore.AtLeastOnceDeliveryMode,
+				FilterConfig: &datastore.FilterConfiguration{
+					EventTypes: []string{"order.created"},
+					Filter:     datastore.FilterSchema{RawBody: datastore.M{"amount": map[string]interface{}{"$gte": float64(100)}}},
+				},
+			},
+			{UID: "sub-2", Name: "listen", Type: datastore.SubscriptionTypeCLI, EndpointID: "ep-1"},
+		}, datastore.PaginationData{}, nil)
+
+	s.FilterRepo.(*mocks.MockFilterRepository).EXPECT().
+		FindFiltersBySubscriptionID(gomock.Any(), "sub-1").
+		Return([]datastore.EventTypeFilter{{
+			UID:          "filter-1",
+			EventType:    "order.created",
+			RawBody:      datastore.M{"amount": map[string]interface{}{"$gte": float64(100)}},
+			EnabledAtSet: true,
+		}}, nil)
+
+	s.PortalLinkRepo.(*mocks.MockPortalLinkRepository).EXPECT().
+		LoadPortalLinksPaged(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).
+		Return([]datastore.PortalLink{{UID: "pl-1", Name: "tenant-1", OwnerID: "tenant-1", AuthType: datastore.PortalAuthTypeStaticToken}}, datastore.PaginationData{}, nil)
+}
+
+func TestProjectSpecService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideProjectSpecService(ctrl)
+	expectProject(s)
+
+	doc, values, err := s.Export(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []project_spec.EventType{{Name: "order.created", JSONSchema: map[string]interface{}{"type": "object"}}}, doc.EventTypes)
+	require.Len(t, doc.Sources, 1)
+	require.Equal(t, "SOURCE_GITHUB_HMAC_SECRET", doc.Sources[0].Verifier.HMac.Secret.Ref)
+	require.Len(t, doc.Endpoints, 1)
+	require.Equal(t, "ENDPOINT_ORDERS_SECRET", doc.Endpoints[0].Secret.Ref)
+
+	require.Len(t, doc.Subscriptions, 1, "CLI subscriptions are not exported")
+	require.Equal(t, "orders", doc.Subscriptions[0].Endpoint)
+	require.Equal(t, "github", doc.Subscriptions[0].Source)
+	require.Equal(t, []project_spec.EventTypeFilter{{EventType: "order.created", Filter: project_spec.Filter{Body: datastore.M{"amount": map[string]interface{}{"$gte": float64(100)}}}, Disabled: true}}, doc.Subscriptions[0].EventTypeFilters)
+
+	require.Equal(t, map[string]string{
+		"SOURCE_GITHUB_HMAC_SECRET": "github-secret",
+		"ENDPOINT_ORDERS_SECRET":    "orders-secret",
+	}, values)
+
+	b, err := project_spec.Encode(doc, project_spec.FormatYAML)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "orders-secret")
+}
+
+func TestProjectSpecService_PlanRejectsUnknownReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideProjectSpecService(ctrl)
+	expectProject(s)
+
+	desired := &project_spec.Document{
+		Version:       project_spec.Version,
+		Endpoints:     []project_spec.Endpoint{{Name: "billing", URL: "https://example.com/billing"}},
+		Subscriptions: []project_spec.Subscription{{Name: "orders", Endpoint: "orders"}},
+	}
+
+	_, err := s.Plan(context.Background(), desired, project_spec.SecretMap{}, true)
+	require.ErrorContains(t, err, `subscriptions[orders]: endpoint "orders" does not exist`)
+
+	var serviceErr *util.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	require.Equal(t, http.StatusBadRequest, serviceErr.ErrCode())
+}
+
+func TestProjectSpecService_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideProjectSpecService(ctrl)
+	expectProject(s)
+
+	desired := &project_spec.Document{
+		Version: project_spec.Version,
+		Endpoints: []project_spec.Endpoint{
+			{Name: "billing", URL: "https://example.com/billing", OwnerID: "tenant-1", Secret: &project_spec.Secret{Ref: "BILLING_SECRET"}},
+		},
+		Subscriptions: []project_spec.Subscription{
+			{Name: "billing", Endpoint: "billing", EventTypes: []string{"order.created"}, EventTypeFilters: []project_spec.EventTypeFilter{{EventType: "order.created", Disabled: true}}},
+		},
+	}
+
+	var endpoint *datastore.Endpoint
+	s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().
+		CreateEndpoint(gomock.Any(), gomock.Any(), "project-1").
+		DoAndReturn(func(_ context.Context, e *datastore.Endpoint, _ string) error {
+			endpoint = e
+			return nil
+		})
+
+	var sub *datastore.Subscription
+	s.SubRepo.(*mocks.MockSubscriptionRepository).EXPECT().
+		CreateSubscription(gomock.Any(), "project-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, subscription *datastore.Subscription) error {
+			sub = subscription
+			return nil
+		})
+
+	s.FilterRepo.(*mocks.MockFilterRepository).EXPECT().
+		FindFiltersBySubscriptionID(gomock.Any(), gomock.Not("sub-1")).
+		Return([]datastore.EventTypeFilter{{UID: "filter-2", EventType: "order.created", EnabledAtSet: true}}, nil)
+
+	var filter *datastore.EventTypeFilter
+	s.FilterRepo.(*mocks.MockFilterRepository).EXPECT().
+		UpdateFilter(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, f *datastore.EventTypeFilter) error {
+			filter = f
+			return nil
+		})
+
+	s.PortalLinkRepo.(*mocks.MockPortalLinkRepository).EXPECT().
+		UpdatePortalLink(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).
+		Return(&datastore.PortalLink{UID: "pl-1", Name: "tenant-1", OwnerID: "tenant-1"}, nil)
+
+	plan, err := s.Apply(context.Background(), desired, project_spec.SecretMap{"BILLING_SECRET": "billing-secret"}, false)
+	require.NoError(t, err)
+
+	require.Equal(t, []project_spec.Change{
+		{Kind: project_spec.KindEndpoint, Name: "billing", Action: project_spec.ActionCreate},
+		{Kind: project_spec.KindSubscription, Name: "billing", Action: project_spec.ActionCreate},
+		{Kind: project_spec.KindPortalLink, Name: "tenant-1", Action: project_spec.ActionUpdate, Fields: []string{"endpoints"}},
+	}, plan.Changes)
+
+	require.Equal(t, "https://example.com/billing", endpoint.Url)
+	require.Equal(t, "billing-secret", endpoint.Secrets[0].Value)
+	require.Equal(t, endpoint.UID, sub.EndpointID)
+	require.Equal(t, []string{"order.created"}, []string(sub.FilterConfig.EventTypes))
+	require.False(t, filter.IsEnabled())
+}
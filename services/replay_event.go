@@ -41,8 +41,9 @@ func (e *ReplayEventService) Run(ctx context.Context) error {
 	}
 
 	job := &queue.Job{
-		ID:      jobId,
-		Payload: eventByte,
+		ID:        jobId,
+		Payload:   eventByte,
+		ProjectID: e.Event.ProjectID,
 	}
 
 	err = e.Queue.Write(ctx, convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/worker/task"
+)
+
+const (
+	defaultReplayJobRateLimit = 50
+	maxReplayJobRateLimit     = 1000
+)
+
+// CreateReplayJobService records a replay job and queues it for a worker.
+type CreateReplayJobService struct {
+	ReplayJobRepo datastore.ReplayJobRepository
+	EventRepo     datastore.EventRepository
+	EndpointRepo  datastore.EndpointRepository
+	Queue         queue.Queuer
+	Project       *datastore.Project
+	Job           *datastore.ReplayJob
+	// CreateEndpoint creates the endpoint a new_endpoint job replays to. It
+	// runs once the job is known to be valid, so a rejected job leaves no
+	// endpoint behind.
+	CreateEndpoint func(ctx context.Context) (*datastore.Endpoint, error)
+	Logger         log.Logger
+}
+
+func (s *CreateReplayJobService) Run(ctx context.Context) (*datastore.ReplayJob, error) {
+	job := s.Job
+	if err := validateReplayJob(job); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if job.TargetType == datastore.ReplayTargetNewEndpoint && s.CreateEndpoint == nil {
+		return nil, &ServiceError{ErrMsg: "please provide the endpoint to create"}
+	}
+
+	if job.TargetType == datastore.ReplayTargetEndpoint {
+		_, err := s.EndpointRepo.FindEndpointByID(ctx, job.EndpointID, s.Project.UID)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "failed to find endpoint", "error", err)
+			return nil, &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+		}
+	}
+
+	count, err := s.EventRepo.CountEvents(ctx, s.Project.UID, job.Filter.EventFilter(s.Project, "", 0))
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to count events", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to count events", Err: err}
+	}
+
+	if job.TargetType == datastore.ReplayTargetNewEndpoint {
+		endpoint, err := s.CreateEndpoint(ctx)
+		if err != nil {
+			return nil, err
+		}
+		job.EndpointID = endpoint.UID
+	}
+
+	now := time.Now()
+	job.UID = ulid.Make().String()
+	job.ProjectID = s.Project.UID
+	job.Status = datastore.ReplayJobStatusPending
+	job.TotalEvents = int(count)
+	job.CreatedAt, job.UpdatedAt = now, now
+
+	err = s.ReplayJobRepo.CreateReplayJob(ctx, job)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "failed to create replay job", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to create replay job", Err: err}
+	}
+
+	data, err := msgpack.EncodeMsgPack(task.ReplayJobPayload{ProjectID: job.ProjectID, ReplayJobID: job.UID})
+	if err != nil {
+		return nil, s.abandon(ctx, job, "failed to encode replay job payload", err)
+	}
+
+	err = s.Queue.WriteWithoutTimeout(ctx, convoy.ReplayJobProcessor, convoy.BatchRetryQueue, &queue.Job{
+		ID:        job.UID,
+		Payload:   data,
+		ProjectID: job.ProjectID,
+	})
+	if err != nil {
+		return nil, s.abandon(ctx, job, "failed to queue replay job", err)
+	}
+
+	return job, nil
+}
+
+// abandon marks a job that never reached the queue as failed, so it does not
+// linger as pending.
+func (s *CreateReplayJobService) abandon(ctx context.Context, job *datastore.ReplayJob, msg string, cause error) error {
+	s.Logger.ErrorContext(ctx, msg, "error", cause)
+
+	job.Status = datastore.ReplayJobStatusFailed
+	job.Error = msg
+	job.CompletedAt = null.TimeFrom(time.Now())
+	if err := s.ReplayJobRepo.UpdateReplayJob(ctx, job); err != nil {
+		s.Logger.ErrorContext(ctx, "failed to mark replay job as failed", "error", err)
+	}
+
+	return &ServiceError{ErrMsg: msg, Err: cause}
+}
+
+// validateReplayJob fills in defaults for unset fields and rejects jobs the
+// worker cannot run.
+func validateReplayJob(job *datastore.ReplayJob) error {
+	if job.TargetType == "" {
+		job.TargetType = datastore.ReplayTargetSubscriptions
+	}
+	if !job.TargetType.IsValid() {
+		return fmt.Errorf("unsupported replay target - %s", job.TargetType)
+	}
+
+	switch job.TargetType {
+	case datastore.ReplayTargetSubscriptions, datastore.ReplayTargetNewEndpoint:
+		job.EndpointID = ""
+	default:
+		if job.EndpointID == "" {
+			return errors.New("please provide the endpoint to replay to")
+		}
+	}
+
+	f := &job.Filter
+	if f.StartDate.IsZero() {
+		return errors.New("please provide a start date")
+	}
+	if f.EndDate.IsZero() {
+		f.EndDate = time.Now()
+	}
+	if !f.EndDate.After(f.StartDate) {
+		return errors.New("end date must be after start date")
+	}
+
+	if len(f.Body) > 0 {
+		var body map[string]any
+		if err := json.Unmarshal(f.Body, &body); err != nil {
+			return errors.New("body must be a JSON object")
+		}
+	}
+
+	if job.RateLimit == 0 {
+		job.RateLimit = defaultReplayJobRateLimit
+	}
+	if job.RateLimit < 1 || job.RateLimit > maxReplayJobRateLimit {
+		return fmt.Errorf("rate limit must be between 1 and %d events per second", maxReplayJobRateLimit)
+	}
+
+	return nil
+}
+
+// CancelReplayJobService stops a pending or processing replay job. A job
+// being processed stops after the event it is on.
+type CancelReplayJobService struct {
+	ReplayJobRepo datastore.ReplayJobRepository
+	ProjectID     string
+	ReplayJobID   string
+	Logger        log.Logger
+}
+
+func (s *CancelReplayJobService) Run(ctx context.Context) (*datastore.ReplayJob, error) {
+	job, err := s.ReplayJobRepo.FindReplayJobByID(ctx, s.ProjectID, s.ReplayJobID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find replay job", Err: err}
+	}
+
+	if job.Status.IsTerminal() {
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("replay job is already %s", job.Status)}
+	}
+
+	err = s.ReplayJobRepo.CancelReplayJob(ctx, s.ProjectID, s.ReplayJobID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrReplayJobNotFound) {
+			return nil, &ServiceError{ErrMsg: "replay job has already finished"}
+		}
+		s.Logger.ErrorContext(ctx, "failed to cancel replay job", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to cancel replay job", Err: err}
+	}
+
+	job, err = s.ReplayJobRepo.FindReplayJobByID(ctx, s.ProjectID, s.ReplayJobID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find replay job", Err: err}
+	}
+
+	return job, nil
+}
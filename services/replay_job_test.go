@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func provideCreateReplayJobService(ctrl *gomock.Controller, job *datastore.ReplayJob) *CreateReplayJobService {
+	return &CreateReplayJobService{
+		ReplayJobRepo: mocks.NewMockReplayJobRepository(ctrl),
+		EventRepo:     mocks.NewMockEventRepository(ctrl),
+		EndpointRepo:  mocks.NewMockEndpointRepository(ctrl),
+		Queue:         mocks.NewMockQueuer(ctrl),
+		Project:       &datastore.Project{UID: "project-1"},
+		Job:           job,
+		Logger:        log.New("convoy", log.LevelInfo),
+	}
+}
+
+func TestCreateReplayJobService_Run(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		job        *datastore.ReplayJob
+		dbFn       func(s *CreateReplayJobService)
+		wantErrMsg string
+	}{
+		{
+			name: "should_queue_backfill_to_endpoint",
+			job: &datastore.ReplayJob{
+				TargetType: datastore.ReplayTargetEndpoint,
+				EndpointID: "endpoint-1",
+				Filter:     datastore.ReplayJobFilter{StartDate: start},
+			},
+			dbFn: func(s *CreateReplayJobService) {
+				e, _ := s.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Return(&datastore.Endpoint{UID: "endpoint-1"}, nil)
+
+				ev, _ := s.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().CountEvents(gomock.Any(), "project-1", gomock.Any()).Return(int64(12), nil)
+
+				r, _ := s.ReplayJobRepo.(*mocks.MockReplayJobRepository)
+				r.EXPECT().CreateReplayJob(gomock.Any(), gomock.Any()).Return(nil)
+
+				q, _ := s.Queue.(*mocks.MockQueuer)
+				q.EXPECT().WriteWithoutTimeout(gomock.Any(), convoy.ReplayJobProcessor, convoy.BatchRetryQueue, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "should_require_endpoint_for_endpoint_target",
+			job: &datastore.ReplayJob{
+				TargetType: datastore.ReplayTargetEndpoint,
+				Filter:     datastore.ReplayJobFilter{StartDate: start},
+			},
+			wantErrMsg: "please provide the endpoint to replay to",
+		},
+		{
+			name: "should_create_endpoint_for_new_endpoint_target",
+			job: &datastore.ReplayJob{
+				TargetType: datastore.ReplayTargetNewEndpoint,
+				Filter:     datastore.ReplayJobFilter{StartDate: start},
+			},
+			dbFn: func(s *CreateReplayJobService) {
+				ev, _ := s.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().CountEvents(gomock.Any(), "project-1", gomock.Any()).Return(int64(12), nil)
+
+				s.CreateEndpoint = func(context.Context) (*datastore.Endpoint, error) {
+					return &datastore.Endpoint{UID: "endpoint-2"}, nil
+				}
+
+				r, _ := s.ReplayJobRepo.(*mocks.MockReplayJobRepository)
+				r.EXPECT().CreateReplayJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *datastore.ReplayJob) error {
+					require.Equal(t, "endpoint-2", job.EndpointID)
+					return nil
+				})
+
+				q, _ := s.Queue.(*mocks.MockQueuer)
+				q.EXPECT().WriteWithoutTimeout(gomock.Any(), convoy.ReplayJobProcessor, convoy.BatchRetryQueue, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "should_not_create_endpoint_for_invalid_job",
+			job: &datastore.ReplayJob{
+				TargetType: datastore.ReplayTargetNewEndpoint,
+				RateLimit:  5000,
+				Filter:     datastore.ReplayJobFilter{StartDate: start},
+			},
+			dbFn: func(s *CreateReplayJobService) {
+				s.CreateEndpoint = func(context.Context) (*datastore.Endpoint, error) {
+					t.Fatal("endpoint created for a rejected job")
+					return nil, nil
+				}
+			},
+			wantErrMsg: "rate limit must be between 1 and 1000 events per second",
+		},
+		{
+			name:       "should_require_start_date",
+			job:        &datastore.ReplayJob{},
+			wantErrMsg: "please provide a start date",
+		},
+		{
+			name: "should_reject_rate_limit_above_max",
+			job: &datastore.ReplayJob{
+				RateLimit: 5000,
+				Filter:    datastore.ReplayJobFilter{StartDate: start},
+			},
+			wantErrMsg: "rate limit must be between 1 and 1000 events per second",
+		},
+		{
+			name: "should_fail_job_that_cannot_be_queued",
+			job: &datastore.ReplayJob{
+				Filter: datastore.ReplayJobFilter{StartDate: start},
+			},
+			dbFn: func(s *CreateReplayJobService) {
+				ev, _ := s.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().CountEvents(gomock.Any(), "project-1", gomock.Any()).Return(int64(0), nil)
+
+				r, _ := s.ReplayJobRepo.(*mocks.MockReplayJobRepository)
+				r.EXPECT().CreateReplayJob(gomock.Any(), gomock.Any()).Return(nil)
+				r.EXPECT().UpdateReplayJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *datastore.ReplayJob) error {
+					require.Equal(t, datastore.ReplayJobStatusFailed, job.Status)
+					return nil
+				})
+
+				q, _ := s.Queue.(*mocks.MockQueuer)
+				q.EXPECT().WriteWithoutTimeout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("queue down"))
+			},
+			wantErrMsg: "failed to queue replay job",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideCreateReplayJobService(ctrl, tt.job)
+			if tt.dbFn != nil {
+				tt.dbFn(s)
+			}
+
+			job, err := s.Run(ctx)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, job.UID)
+			require.Equal(t, "project-1", job.ProjectID)
+			require.Equal(t, datastore.ReplayJobStatusPending, job.Status)
+			require.Equal(t, defaultReplayJobRateLimit, job.RateLimit)
+			require.Equal(t, 12, job.TotalEvents)
+			require.False(t, job.Filter.EndDate.IsZero())
+		})
+	}
+}
+
+func TestCancelReplayJobService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should_cancel_processing_job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockReplayJobRepository(ctrl)
+		gomock.InOrder(
+			repo.EXPECT().FindReplayJobByID(gomock.Any(), "project-1", "job-1").Return(&datastore.ReplayJob{Status: datastore.ReplayJobStatusProcessing}, nil),
+			repo.EXPECT().CancelReplayJob(gomock.Any(), "project-1", "job-1").Return(nil),
+			repo.EXPECT().FindReplayJobByID(gomock.Any(), "project-1", "job-1").Return(&datastore.ReplayJob{Status: datastore.ReplayJobStatusCancelled}, nil),
+		)
+
+		s := &CancelReplayJobService{ReplayJobRepo: repo, ProjectID: "project-1", ReplayJobID: "job-1", Logger: log.New("convoy", log.LevelInfo)}
+		job, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, datastore.ReplayJobStatusCancelled, job.Status)
+	})
+
+	t.Run("should_reject_finished_job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockReplayJobRepository(ctrl)
+		repo.EXPECT().FindReplayJobByID(gomock.Any(), "project-1", "job-1").Return(&datastore.ReplayJob{Status: datastore.ReplayJobStatusCompleted}, nil)
+
+		s := &CancelReplayJobService{ReplayJobRepo: repo, ProjectID: "project-1", ReplayJobID: "job-1", Logger: log.New("convoy", log.LevelInfo)}
+		_, err := s.Run(ctx)
+		require.Error(t, err)
+		require.Equal(t, "replay job is already completed", err.Error())
+	})
+}
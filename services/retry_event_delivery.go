@@ -87,9 +87,10 @@ func requeueEventDelivery(ctx context.Context, eventDelivery *datastore.EventDel
 	}
 
 	job := &queue.Job{
-		ID:      eventDelivery.UID,
-		Payload: bytes,
-		Delay:   1 * time.Second,
+		ID:        eventDelivery.UID,
+		Payload:   bytes,
+		Delay:     1 * time.Second,
+		ProjectID: g.UID,
 	}
 
 	err = q.Write(ctx, taskName, convoy.EventQueue, job)
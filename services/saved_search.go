@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+type CreateSavedSearchService struct {
+	SavedSearchRepo datastore.SavedSearchRepository
+	ProjectID       string
+	Search          *datastore.SavedSearch
+	Logger          log.Logger
+}
+
+func (s *CreateSavedSearchService) Run(ctx context.Context) (*datastore.SavedSearch, error) {
+	search := s.Search
+	search.Name = strings.TrimSpace(search.Name)
+	if err := validateSavedSearch(search); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	now := time.Now()
+	search.UID = ulid.Make().String()
+	search.ProjectID = s.ProjectID
+	search.CreatedAt, search.UpdatedAt = now, now
+
+	err := s.SavedSearchRepo.CreateSavedSearch(ctx, search)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateSavedSearchName) {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+		s.Logger.ErrorContext(ctx, "failed to create saved search", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to create saved search", Err: err}
+	}
+
+	return search, nil
+}
+
+type UpdateSavedSearchService struct {
+	SavedSearchRepo datastore.SavedSearchRepository
+	ProjectID       string
+	SavedSearchID   string
+	Update          *datastore.SavedSearch
+	Logger          log.Logger
+}
+
+func (s *UpdateSavedSearchService) Run(ctx context.Context) (*datastore.SavedSearch, error) {
+	search, err := s.SavedSearchRepo.FindSavedSearchByID(ctx, s.ProjectID, s.SavedSearchID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to find saved search", Err: err}
+	}
+
+	search.Name = strings.TrimSpace(s.Update.Name)
+	search.Resource = s.Update.Resource
+	search.Filter = s.Update.Filter
+	if err = validateSavedSearch(search); err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	err = s.SavedSearchRepo.UpdateSavedSearch(ctx, search)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateSavedSearchName) {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+		s.Logger.ErrorContext(ctx, "failed to update saved search", "error", err)
+		return nil, &ServiceError{ErrMsg: "failed to update saved search", Err: err}
+	}
+
+	search.UpdatedAt = time.Now()
+	return search, nil
+}
+
+func validateSavedSearch(search *datastore.SavedSearch) error {
+	if search.Name == "" {
+		return errors.New("please provide a name")
+	}
+
+	return validateSearchFilter(search.Resource, &search.Filter)
+}
+
+// validateSearchFilter rejects filters the resource cannot apply. Dates are
+// optional here; exports check them separately.
+func validateSearchFilter(resource datastore.SearchResource, f *datastore.SearchFilter) error {
+	if !resource.IsValid() {
+		return fmt.Errorf("unsupported resource - %s", resource)
+	}
+
+	if resource != datastore.SearchResourceEventDeliveries && len(f.Status) > 0 {
+		return errors.New("status can only filter event deliveries")
+	}
+
+	if !f.StartDate.IsZero() && !f.EndDate.IsZero() && !f.EndDate.After(f.StartDate) {
+		return errors.New("end date must be after start date")
+	}
+
+	if len(f.Body) > 0 {
+		var body map[string]any
+		if err := json.Unmarshal(f.Body, &body); err != nil {
+			return errors.New("body must be a JSON object")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestCreateSavedSearchService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		search     *datastore.SavedSearch
+		dbFn       func(repo *mocks.MockSavedSearchRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "should_save_search",
+			search: &datastore.SavedSearch{
+				Name:     "  Failed deliveries to customer X ",
+				Resource: datastore.SearchResourceEventDeliveries,
+				Filter: datastore.SearchFilter{
+					EndpointIDs: []string{"endpoint-1"},
+					Status:      []datastore.EventDeliveryStatus{datastore.FailureEventStatus},
+				},
+			},
+			dbFn: func(repo *mocks.MockSavedSearchRepository) {
+				repo.EXPECT().CreateSavedSearch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *datastore.SavedSearch) error {
+					require.Equal(t, "Failed deliveries to customer X", s.Name)
+					require.Equal(t, "project-1", s.ProjectID)
+					require.NotEmpty(t, s.UID)
+					return nil
+				})
+			},
+		},
+		{
+			name:       "should_require_name",
+			search:     &datastore.SavedSearch{Resource: datastore.SearchResourceEvents},
+			wantErrMsg: "please provide a name",
+		},
+		{
+			name:       "should_reject_unknown_resource",
+			search:     &datastore.SavedSearch{Name: "endpoints", Resource: "endpoints"},
+			wantErrMsg: "unsupported resource - endpoints",
+		},
+		{
+			name: "should_reject_non_object_body",
+			search: &datastore.SavedSearch{
+				Name:     "payload",
+				Resource: datastore.SearchResourceEvents,
+				Filter:   datastore.SearchFilter{Body: json.RawMessage(`[1,2]`)},
+			},
+			wantErrMsg: "body must be a JSON object",
+		},
+		{
+			name:   "should_reject_duplicate_name",
+			search: &datastore.SavedSearch{Name: "dupe", Resource: datastore.SearchResourceEvents},
+			dbFn: func(repo *mocks.MockSavedSearchRepository) {
+				repo.EXPECT().CreateSavedSearch(gomock.Any(), gomock.Any()).Return(datastore.ErrDuplicateSavedSearchName)
+			},
+			wantErrMsg: datastore.ErrDuplicateSavedSearchName.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockSavedSearchRepository(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(repo)
+			}
+
+			s := &CreateSavedSearchService{
+				SavedSearchRepo: repo,
+				ProjectID:       "project-1",
+				Search:          tt.search,
+				Logger:          log.New("convoy", log.LevelInfo),
+			}
+
+			_, err := s.Run(ctx)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateSavedSearchService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSavedSearchRepository(ctrl)
+	repo.EXPECT().FindSavedSearchByID(gomock.Any(), "project-1", "search-1").Return(&datastore.SavedSearch{
+		UID:       "search-1",
+		ProjectID: "project-1",
+		Name:      "old",
+		Resource:  datastore.SearchResourceEvents,
+	}, nil)
+	repo.EXPECT().UpdateSavedSearch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *datastore.SavedSearch) error {
+		require.Equal(t, "search-1", s.UID)
+		require.Equal(t, "new", s.Name)
+		require.Equal(t, datastore.SearchResourceEventDeliveries, s.Resource)
+		return nil
+	})
+
+	s := &UpdateSavedSearchService{
+		SavedSearchRepo: repo,
+		ProjectID:       "project-1",
+		SavedSearchID:   "search-1",
+		Update:          &datastore.SavedSearch{Name: "new", Resource: datastore.SearchResourceEventDeliveries},
+		Logger:          log.New("convoy", log.LevelInfo),
+	}
+
+	search, err := s.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "new", search.Name)
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/openapi"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
+)
+
+// EventTypeSampleService generates a sample payload for an event type from
+// its JSON schema.
+type EventTypeSampleService struct {
+	EventTypesRepo datastore.EventTypesRepository
+	ProjectID      string
+	EventTypeID    string
+
+	// PortalLink, when set, hides event types the portal link may not
+	// subscribe to.
+	PortalLink *datastore.PortalLink
+}
+
+func (s *EventTypeSampleService) Run(ctx context.Context) (json.RawMessage, error) {
+	// The event type repository already reports a missing event type as a 404.
+	eventType, err := s.EventTypesRepo.FetchEventTypeById(ctx, s.EventTypeID, s.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.PortalLink != nil && !s.PortalLink.AllowsEventType(eventType.Name) {
+		return nil, util.NewServiceError(http.StatusNotFound, errors.New("event type not found"))
+	}
+
+	return samplePayload(eventType)
+}
+
+// SendTestEventService sends a test event of an event type to one endpoint.
+// The event skips subscription filters and goes out through one of the
+// endpoint's subscriptions, signed and authenticated like any other, but its
+// delivery is marked as a test: it is attempted once, it is left out of
+// metrics and usage, and it never disables the endpoint.
+type SendTestEventService struct {
+	EndpointRepo   datastore.EndpointRepository
+	EventTypesRepo datastore.EventTypesRepository
+	SubRepo        datastore.SubscriptionRepository
+	Queue          queue.Queuer
+	Logger         log.Logger
+
+	Project    *datastore.Project
+	EndpointID string
+	TestEvent  *models.SendTestEvent
+}
+
+func (s *SendTestEventService) Run(ctx context.Context) (*models.TestEventResponse, error) {
+	endpoint, err := s.EndpointRepo.FindEndpointByID(ctx, s.EndpointID, s.Project.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+		return nil, &ServiceError{ErrMsg: "failed to find endpoint", Err: err}
+	}
+
+	// Deliveries to an endpoint that is not active are discarded, so the
+	// test would never reach it.
+	if endpoint.Status != datastore.ActiveEndpointStatus {
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("endpoint is %s, activate it to send a test event", endpoint.Status)}
+	}
+
+	// Every delivery belongs to a subscription, and a test must not leave
+	// one behind, so the endpoint needs its own.
+	count, err := s.SubRepo.CountEndpointSubscriptions(ctx, s.Project.UID, endpoint.UID, "")
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to count endpoint subscriptions", Err: err}
+	}
+	if count == 0 {
+		return nil, &ServiceError{ErrMsg: "endpoint has no subscriptions, create one to send a test event"}
+	}
+
+	eventType, err := s.EventTypesRepo.FetchEventTypeByName(ctx, s.TestEvent.EventType, s.Project.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := s.TestEvent.Data
+	if len(data) == 0 || string(data) == "null" {
+		data, err = samplePayload(eventType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	id := ulid.Make().String()
+	jobId := queue.JobId{ProjectID: s.Project.UID, ResourceID: id}.SingleJobId()
+	e := task.CreateEvent{
+		JobID: jobId,
+		Params: task.CreateEventTaskParams{
+			UID:            id,
+			ProjectID:      s.Project.UID,
+			EndpointID:     endpoint.UID,
+			EventType:      eventType.Name,
+			Data:           data,
+			CustomHeaders:  s.TestEvent.CustomHeaders,
+			AcknowledgedAt: time.Now(),
+			IsTest:         true,
+		},
+	}
+
+	eventByte, err := msgpack.EncodeMsgPack(e)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	job := &queue.Job{
+		ID:      jobId,
+		Payload: eventByte,
+	}
+
+	err = s.Queue.Write(ctx, convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("Error occurred sending test event to the queue %s", err))
+		return nil, &ServiceError{ErrMsg: "failed to send test event", Err: err}
+	}
+
+	return &models.TestEventResponse{EventID: id, Data: data}, nil
+}
+
+func samplePayload(eventType *datastore.ProjectEventType) (json.RawMessage, error) {
+	sample, err := openapi.SamplePayload(eventType.JSONSchema)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("failed to generate a sample for %s", eventType.Name), Err: err}
+	}
+
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("failed to generate a sample for %s", eventType.Name), Err: err}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/frain-dev/convoy/worker/task"
+)
+
+func provideSendTestEventService(ctrl *gomock.Controller, testEvent *models.SendTestEvent) *SendTestEventService {
+	return &SendTestEventService{
+		EndpointRepo:   mocks.NewMockEndpointRepository(ctrl),
+		EventTypesRepo: mocks.NewMockEventTypesRepository(ctrl),
+		SubRepo:        mocks.NewMockSubscriptionRepository(ctrl),
+		Queue:          mocks.NewMockQueuer(ctrl),
+		Logger:         logger.New("convoy", logger.LevelError),
+		Project:        &datastore.Project{UID: "project-1"},
+		EndpointID:     "endpoint-1",
+		TestEvent:      testEvent,
+	}
+}
+
+func TestSendTestEventService_Run(t *testing.T) {
+	ctx := context.Background()
+	schema := []byte(`{"type":"object","properties":{"id":{"type":"string","format":"uuid"},"status":{"enum":["paid","void"]}}}`)
+
+	t.Run("should_send_sample_payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := provideSendTestEventService(ctrl, &models.SendTestEvent{EventType: "invoice.paid"})
+
+		s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+			Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil)
+		s.SubRepo.(*mocks.MockSubscriptionRepository).EXPECT().CountEndpointSubscriptions(gomock.Any(), "project-1", "endpoint-1", "").Return(int64(1), nil)
+		s.EventTypesRepo.(*mocks.MockEventTypesRepository).EXPECT().FetchEventTypeByName(gomock.Any(), "invoice.paid", "project-1").
+			Return(&datastore.ProjectEventType{Name: "invoice.paid", JSONSchema: schema}, nil)
+
+		var job *queue.Job
+		s.Queue.(*mocks.MockQueuer).EXPECT().Write(gomock.Any(), convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ convoy.TaskName, _ convoy.QueueName, j *queue.Job) error {
+				job = j
+				return nil
+			})
+
+		resp, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"3fa85f64-5717-4562-b3fc-2c963f66afa6","status":"paid"}`, string(resp.Data))
+
+		var createEvent task.CreateEvent
+		require.NoError(t, msgpack.DecodeMsgPack(job.Payload, &createEvent))
+		require.True(t, createEvent.Params.IsTest)
+		require.False(t, createEvent.CreateSubscription)
+		require.Equal(t, resp.EventID, createEvent.Params.UID)
+		require.Equal(t, "endpoint-1", createEvent.Params.EndpointID)
+		require.JSONEq(t, string(resp.Data), string(createEvent.Params.Data))
+	})
+
+	t.Run("should_send_given_payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := provideSendTestEventService(ctrl, &models.SendTestEvent{EventType: "invoice.paid", Data: []byte(`{"id":"inv_1"}`)})
+
+		s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+			Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil)
+		s.SubRepo.(*mocks.MockSubscriptionRepository).EXPECT().CountEndpointSubscriptions(gomock.Any(), "project-1", "endpoint-1", "").Return(int64(1), nil)
+		s.EventTypesRepo.(*mocks.MockEventTypesRepository).EXPECT().FetchEventTypeByName(gomock.Any(), "invoice.paid", "project-1").
+			Return(&datastore.ProjectEventType{Name: "invoice.paid", JSONSchema: schema}, nil)
+		s.Queue.(*mocks.MockQueuer).EXPECT().Write(gomock.Any(), convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).Return(nil)
+
+		resp, err := s.Run(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"inv_1"}`, string(resp.Data))
+	})
+
+	t.Run("should_fail_for_missing_endpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := provideSendTestEventService(ctrl, &models.SendTestEvent{EventType: "invoice.paid"})
+
+		s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+			Return(nil, datastore.ErrEndpointNotFound)
+
+		_, err := s.Run(ctx)
+		var serviceErr *util.ServiceError
+		require.True(t, errors.As(err, &serviceErr))
+		require.Equal(t, http.StatusNotFound, serviceErr.ErrCode())
+	})
+
+	t.Run("should_fail_for_inactive_endpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := provideSendTestEventService(ctrl, &models.SendTestEvent{EventType: "invoice.paid"})
+
+		s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+			Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.PausedEndpointStatus}, nil)
+
+		_, err := s.Run(ctx)
+		require.ErrorContains(t, err, "endpoint is paused")
+	})
+
+	t.Run("should_fail_for_endpoint_without_subscriptions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := provideSendTestEventService(ctrl, &models.SendTestEvent{EventType: "invoice.paid"})
+
+		s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+			Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil)
+		s.SubRepo.(*mocks.MockSubscriptionRepository).EXPECT().CountEndpointSubscriptions(gomock.Any(), "project-1", "endpoint-1", "").Return(int64(0), nil)
+
+		_, err := s.Run(ctx)
+		require.ErrorContains(t, err, "endpoint has no subscriptions")
+	})
+
+	t.Run("should_fail_for_unknown_event_type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := provideSendTestEventService(ctrl, &models.SendTestEvent{EventType: "invoice.lost"})
+
+		s.EndpointRepo.(*mocks.MockEndpointRepository).EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").
+			Return(&datastore.Endpoint{UID: "endpoint-1", Status: datastore.ActiveEndpointStatus}, nil)
+		s.SubRepo.(*mocks.MockSubscriptionRepository).EXPECT().CountEndpointSubscriptions(gomock.Any(), "project-1", "endpoint-1", "").Return(int64(1), nil)
+		s.EventTypesRepo.(*mocks.MockEventTypesRepository).EXPECT().FetchEventTypeByName(gomock.Any(), "invoice.lost", "project-1").
+			Return(nil, util.NewServiceError(http.StatusNotFound, errors.New("event type not found")))
+
+		_, err := s.Run(ctx)
+		require.ErrorContains(t, err, "event type not found")
+	})
+}
+
+func TestEventTypeSampleService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockEventTypesRepository(ctrl)
+	repo.EXPECT().FetchEventTypeById(gomock.Any(), "et-1", "project-1").
+		Return(&datastore.ProjectEventType{Name: "invoice.paid", JSONSchema: []byte(`{"type":"object","properties":{"amount":{"type":"integer","minimum":100}}}`)}, nil)
+
+	s := &EventTypeSampleService{EventTypesRepo: repo, ProjectID: "project-1", EventTypeID: "et-1"}
+
+	sample, err := s.Run(context.Background())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":100}`, string(sample))
+}
@@ -246,6 +246,19 @@ func (a *UpdateEndpointService) updateEndpoint(ctx context.Context, endpoint *da
 		endpoint.TeamsWebhookURL = *e.TeamsWebhookURL
 	}
 
+	// An empty object clears the incident channels; omitting the field keeps them.
+	if e.NotificationChannels != nil && a.Licenser.AdvancedEndpointMgmt() {
+		channels := e.NotificationChannels
+		if channels.IsEmpty() {
+			channels = nil
+		}
+		channels.RestoreRedacted(endpoint.NotificationChannels)
+		if err := validateNotificationChannels(channels); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+		endpoint.NotificationChannels = channels
+	}
+
 	if e.RateLimit >= 0 {
 		endpoint.RateLimit = e.RateLimit
 	}
@@ -182,7 +182,11 @@ func (s *UpdateSubscriptionService) Run(ctx context.Context) (*datastore.Subscri
 		}
 
 		filterSchema := s.Update.FilterConfig.Filter.Transform()
-		if filterSchemaHasConditions(filterSchema) {
+		if filterSchema.HasConditions() {
+			if err = filterSchema.ValidateExpression(); err != nil {
+				return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+			}
+
 			// validate that the filter is a json string
 			_, err = json.Marshal(s.Update.FilterConfig.Filter)
 			if err != nil {
@@ -0,0 +1,46 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Operator exceptions to the sampled circuit breakers. forced_state holds a
+-- breaker open or closed until it is released; config overlays the project's
+-- thresholds for one endpoint. A row with neither is deleted, not kept.
+CREATE TABLE IF NOT EXISTS convoy.circuit_breaker_overrides (
+    endpoint_id  TEXT PRIMARY KEY,
+    project_id   TEXT NOT NULL,
+    forced_state TEXT,
+    config       JSONB,
+    reason       TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Every state change of a breaker, whether the sampler or an operator made it.
+CREATE TABLE IF NOT EXISTS convoy.circuit_breaker_transitions (
+    id           VARCHAR PRIMARY KEY DEFAULT convoy.generate_ulid(),
+    project_id   TEXT NOT NULL,
+    endpoint_id  TEXT NOT NULL,
+    from_state   TEXT NOT NULL,
+    to_state     TEXT NOT NULL,
+    source       TEXT NOT NULL,
+    reason       TEXT,
+    failure_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_circuit_breaker_transitions_endpoint
+    ON convoy.circuit_breaker_transitions (project_id, endpoint_id, created_at DESC);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.circuit_breaker_transitions;
+DROP TABLE IF EXISTS convoy.circuit_breaker_overrides;
+
+RESET lock_timeout;
+RESET statement_timeout;
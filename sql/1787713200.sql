@@ -0,0 +1,61 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Periodic health check configuration for an endpoint, together with the
+-- running state the checker needs between rounds.
+CREATE TABLE IF NOT EXISTS convoy.endpoint_health_checks (
+    endpoint_id          TEXT PRIMARY KEY,
+    project_id           TEXT NOT NULL,
+    method               TEXT NOT NULL,
+    path                 TEXT NOT NULL DEFAULT '',
+    expected_status      INTEGER NOT NULL DEFAULT 0,
+    interval_seconds     INTEGER NOT NULL,
+    timeout_seconds      INTEGER NOT NULL,
+    failure_threshold    INTEGER NOT NULL,
+    auto_pause           BOOLEAN NOT NULL DEFAULT FALSE,
+    auto_activate        BOOLEAN NOT NULL DEFAULT FALSE,
+    last_status          TEXT NOT NULL DEFAULT '',
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    paused_by_check      BOOLEAN NOT NULL DEFAULT FALSE,
+    last_checked_at      TIMESTAMPTZ,
+    next_check_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One row per health check run; uptime and latency percentiles are computed
+-- from here.
+CREATE TABLE IF NOT EXISTS convoy.endpoint_health_check_results (
+    id          VARCHAR PRIMARY KEY DEFAULT convoy.generate_ulid(),
+    project_id  TEXT NOT NULL,
+    endpoint_id TEXT NOT NULL,
+    healthy     BOOLEAN NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    latency_ms  BIGINT NOT NULL DEFAULT 0,
+    error       TEXT,
+    checked_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_endpoint_health_checks_next_check_at
+    ON convoy.endpoint_health_checks (next_check_at);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_endpoint_health_check_results_endpoint
+    ON convoy.endpoint_health_check_results (project_id, endpoint_id, checked_at DESC);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_endpoint_health_check_results_checked_at
+    ON convoy.endpoint_health_check_results (checked_at);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.endpoint_health_check_results;
+DROP TABLE IF EXISTS convoy.endpoint_health_checks;
+
+RESET lock_timeout;
+RESET statement_timeout;
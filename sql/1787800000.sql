@@ -0,0 +1,14 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+ALTER TABLE convoy.filters ADD COLUMN IF NOT EXISTS expression TEXT NOT NULL DEFAULT '';
+ALTER TABLE convoy.subscriptions ADD COLUMN IF NOT EXISTS filter_config_filter_expression TEXT NOT NULL DEFAULT '';
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.filters DROP COLUMN IF EXISTS expression;
+ALTER TABLE convoy.subscriptions DROP COLUMN IF EXISTS filter_config_filter_expression;
@@ -0,0 +1,41 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Background jobs that replay the events a filter selects, to their current
+-- subscriptions or to one endpoint.
+CREATE TABLE IF NOT EXISTS convoy.replay_jobs (
+    id               VARCHAR PRIMARY KEY,
+    project_id       VARCHAR NOT NULL,
+    status           VARCHAR(50) NOT NULL,
+    filter           JSONB NOT NULL,
+    target_type      VARCHAR(50) NOT NULL,
+    endpoint_id      VARCHAR NOT NULL DEFAULT '',
+    rate_limit       INTEGER NOT NULL,
+    total_events     INTEGER NOT NULL DEFAULT 0,
+    processed_events INTEGER NOT NULL DEFAULT 0,
+    failed_events    INTEGER NOT NULL DEFAULT 0,
+    skipped_events   INTEGER NOT NULL DEFAULT 0,
+    cursor           VARCHAR NOT NULL DEFAULT '',
+    error            TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at     TIMESTAMPTZ,
+    CONSTRAINT fk_replay_jobs_project FOREIGN KEY (project_id) REFERENCES convoy.projects(id) ON DELETE CASCADE
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_replay_jobs_project_id_created_at
+    ON convoy.replay_jobs (project_id, created_at DESC);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.replay_jobs;
+
+RESET lock_timeout;
+RESET statement_timeout;
@@ -0,0 +1,51 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Named events and deliveries searches kept on a project.
+CREATE TABLE IF NOT EXISTS convoy.saved_searches (
+    id         VARCHAR PRIMARY KEY,
+    project_id VARCHAR NOT NULL,
+    name       VARCHAR NOT NULL,
+    resource   VARCHAR(50) NOT NULL,
+    filter     JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_saved_searches_project FOREIGN KEY (project_id) REFERENCES convoy.projects(id) ON DELETE CASCADE,
+    CONSTRAINT uq_saved_searches_project_id_name UNIQUE (project_id, name)
+);
+
+-- Exports of a search's events or deliveries to the blob store.
+CREATE TABLE IF NOT EXISTS convoy.export_jobs (
+    id              VARCHAR PRIMARY KEY,
+    project_id      VARCHAR NOT NULL,
+    saved_search_id VARCHAR NOT NULL DEFAULT '',
+    resource        VARCHAR(50) NOT NULL,
+    format          VARCHAR(50) NOT NULL,
+    filter          JSONB NOT NULL,
+    status          VARCHAR(50) NOT NULL,
+    row_count       BIGINT NOT NULL DEFAULT 0,
+    object_key      VARCHAR NOT NULL DEFAULT '',
+    error           TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMPTZ,
+    CONSTRAINT fk_export_jobs_project FOREIGN KEY (project_id) REFERENCES convoy.projects(id) ON DELETE CASCADE
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_export_jobs_project_id_created_at
+    ON convoy.export_jobs (project_id, created_at DESC);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.export_jobs;
+DROP TABLE IF EXISTS convoy.saved_searches;
+
+RESET lock_timeout;
+RESET statement_timeout;
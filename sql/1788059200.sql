@@ -0,0 +1,54 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Numbered revisions of an event type's payload, each with its own schema.
+CREATE TABLE IF NOT EXISTS convoy.event_type_versions (
+    id                 VARCHAR PRIMARY KEY,
+    project_id         VARCHAR NOT NULL,
+    event_type_id      VARCHAR NOT NULL,
+    version            INTEGER NOT NULL,
+    json_schema        JSONB NOT NULL DEFAULT '{}',
+    downgrade          TEXT,
+    sunset_at          TIMESTAMPTZ,
+    sunset_notified_at TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_type_versions_project FOREIGN KEY (project_id) REFERENCES convoy.projects(id) ON DELETE CASCADE,
+    CONSTRAINT fk_event_type_versions_event_type FOREIGN KEY (event_type_id) REFERENCES convoy.event_types(id) ON DELETE CASCADE,
+    CONSTRAINT uq_event_type_versions_event_type_id_version UNIQUE (event_type_id, version)
+);
+
+-- The event type version a subscription receives its events as.
+CREATE TABLE IF NOT EXISTS convoy.subscription_event_type_versions (
+    id              VARCHAR PRIMARY KEY,
+    project_id      VARCHAR NOT NULL,
+    subscription_id VARCHAR NOT NULL,
+    event_type      VARCHAR NOT NULL,
+    version         INTEGER NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_subscription_event_type_versions_project FOREIGN KEY (project_id) REFERENCES convoy.projects(id) ON DELETE CASCADE,
+    CONSTRAINT fk_subscription_event_type_versions_subscription FOREIGN KEY (subscription_id) REFERENCES convoy.subscriptions(id) ON DELETE CASCADE,
+    CONSTRAINT uq_subscription_event_type_versions_subscription_id_event_type UNIQUE (subscription_id, event_type)
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_event_type_versions_pending_sunset
+    ON convoy.event_type_versions (sunset_at) WHERE sunset_notified_at IS NULL AND sunset_at IS NOT NULL;
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_subscription_event_type_versions_project_id_event_type
+    ON convoy.subscription_event_type_versions (project_id, event_type);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.subscription_event_type_versions;
+DROP TABLE IF EXISTS convoy.event_type_versions;
+
+RESET lock_timeout;
+RESET statement_timeout;
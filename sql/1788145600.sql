@@ -0,0 +1,21 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Marks events sent with the send test event API so usage can skip them. A
+-- constant default is a catalog change only, so convoy.events is not rewritten.
+ALTER TABLE convoy.events
+ADD COLUMN IF NOT EXISTS is_test BOOLEAN NOT NULL DEFAULT FALSE;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.events DROP COLUMN IF EXISTS is_test;
+
+RESET lock_timeout;
+RESET statement_timeout;
@@ -0,0 +1,21 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Event types a portal link may subscribe its endpoints to, stored like
+-- portal_links.endpoints. NULL allows every event type.
+ALTER TABLE convoy.portal_links
+ADD COLUMN IF NOT EXISTS event_types TEXT;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.portal_links DROP COLUMN IF EXISTS event_types;
+
+RESET lock_timeout;
+RESET statement_timeout;
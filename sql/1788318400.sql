@@ -0,0 +1,21 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Features a portal link may use, stored like portal_links.endpoints. NULL
+-- gives the default permissions, which existing links keep.
+ALTER TABLE convoy.portal_links
+ADD COLUMN IF NOT EXISTS permissions TEXT;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.portal_links DROP COLUMN IF EXISTS permissions;
+
+RESET lock_timeout;
+RESET statement_timeout;
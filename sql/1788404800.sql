@@ -0,0 +1,52 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- User-defined alert conditions on a project's traffic, together with the
+-- state the evaluator keeps between runs.
+CREATE TABLE IF NOT EXISTS convoy.alert_rules (
+    id                VARCHAR PRIMARY KEY,
+    project_id        TEXT NOT NULL,
+    name              TEXT NOT NULL,
+    type              TEXT NOT NULL,
+    endpoint_id       TEXT NOT NULL DEFAULT '',
+    source_id         TEXT NOT NULL DEFAULT '',
+    threshold         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    window_seconds    INTEGER NOT NULL DEFAULT 0,
+    cooldown_seconds  INTEGER NOT NULL DEFAULT 0,
+    channels          JSONB NOT NULL DEFAULT '{}',
+    enabled           BOOLEAN NOT NULL DEFAULT TRUE,
+    state             TEXT NOT NULL DEFAULT 'ok',
+    last_value        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    last_evaluated_at TIMESTAMPTZ,
+    last_notified_at  TIMESTAMPTZ,
+    last_triggered_at TIMESTAMPTZ,
+    last_resolved_at  TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT alert_rules_project_name_key UNIQUE (project_id, name)
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_alert_rules_enabled
+    ON convoy.alert_rules (id) WHERE enabled;
+
+-- Failure rate and consecutive failure rules read the latest attempts of a
+-- project.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_delivery_attempts_project_created_at
+    ON convoy.delivery_attempts (project_id, created_at DESC);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.alert_rules;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down notransaction
+DROP INDEX CONCURRENTLY IF EXISTS convoy.idx_delivery_attempts_project_created_at;
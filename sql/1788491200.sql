@@ -0,0 +1,21 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Appended for the same reason as teams_webhook_url: the generated endpoint
+-- update statement addresses columns positionally.
+ALTER TABLE convoy.endpoints
+ADD COLUMN IF NOT EXISTS notification_channels JSONB;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS notification_channels;
+
+RESET lock_timeout;
+RESET statement_timeout;
@@ -0,0 +1,41 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- An organisation's override of one built-in email: a Go-template subject and
+-- body. Either may be empty to keep the built-in one.
+CREATE TABLE IF NOT EXISTS convoy.email_templates (
+    id              VARCHAR PRIMARY KEY,
+    organisation_id VARCHAR NOT NULL,
+    template_name   VARCHAR(100) NOT NULL,
+    subject         TEXT NOT NULL DEFAULT '',
+    body            TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_email_templates_organisation FOREIGN KEY (organisation_id) REFERENCES convoy.organisations(id) ON DELETE CASCADE,
+    CONSTRAINT uq_email_templates_organisation_id_template_name UNIQUE (organisation_id, template_name)
+);
+
+-- The header colour and fallback logo of every email an organisation sends. A
+-- project's logo_url still wins for emails about that project.
+CREATE TABLE IF NOT EXISTS convoy.email_branding (
+    organisation_id VARCHAR PRIMARY KEY,
+    brand_color     VARCHAR(7) NOT NULL DEFAULT '',
+    logo_url        TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_email_branding_organisation FOREIGN KEY (organisation_id) REFERENCES convoy.organisations(id) ON DELETE CASCADE
+);
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP TABLE IF EXISTS convoy.email_branding;
+DROP TABLE IF EXISTS convoy.email_templates;
+
+RESET lock_timeout;
+RESET statement_timeout;
@@ -0,0 +1,52 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- The tenant a queue job is scheduled as: its project, or its organisation,
+-- depending on queue.fair_share.tenant_scope when it was written. Fair
+-- scheduling claims round robin across tenants rather than strictly by
+-- run_at. Jobs with no project (scheduler ticks, meta work) share ''.
+ALTER TABLE convoy.queue_jobs
+    ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT '';
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Fair claim: one probe per tenant to find who has work ready, then each
+-- tenant's oldest rows.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_queue_jobs_tenant_claim
+    ON convoy.queue_jobs (tenant, run_at)
+    WHERE status = 'pending';
+
+-- Per-tenant in-flight cap and the tenant backlog view.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_queue_jobs_tenant_processing
+    ON convoy.queue_jobs (tenant)
+    WHERE status = 'processing';
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down notransaction
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+DROP INDEX CONCURRENTLY IF EXISTS convoy.idx_queue_jobs_tenant_processing;
+DROP INDEX CONCURRENTLY IF EXISTS convoy.idx_queue_jobs_tenant_claim;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.queue_jobs
+    DROP COLUMN IF EXISTS tenant;
+
+RESET lock_timeout;
+RESET statement_timeout;
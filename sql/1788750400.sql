@@ -0,0 +1,70 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Where a standby region's configuration replication has got to. One row per
+-- slot on the source. A database with a row whose promoted_at is unset is an
+-- unpromoted standby, and the server and agent refuse to start against it.
+CREATE TABLE IF NOT EXISTS convoy.config_replication_state (
+    slot_name        TEXT PRIMARY KEY,
+    applied_lsn      PG_LSN NOT NULL,
+    source_wal_end   PG_LSN,
+    source_commit_at TIMESTAMPTZ,
+    heartbeat_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    promoted_at      TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- portal_links_endpoints has no primary key, and a published table without a
+-- replica identity rejects deletes on the source.
+ALTER TABLE convoy.portal_links_endpoints REPLICA IDENTITY FULL;
+
+-- The configuration a standby region replicates. Event data stays regional.
+-- Creating a publication needs CREATE on the database, which managed Postgres
+-- often withholds from the application role. Without it the migration goes
+-- on and only replication is unavailable: the replicator reports what the
+-- publication lacks until an operator with the privilege creates it.
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = 'convoy_config') THEN
+        EXECUTE 'CREATE PUBLICATION convoy_config FOR TABLE
+            convoy.users, convoy.organisations, convoy.project_configurations, convoy.projects,
+            convoy.source_verifiers, convoy.sources, convoy.endpoints, convoy.devices,
+            convoy.event_types, convoy.event_type_versions,
+            convoy.subscriptions, convoy.subscription_event_type_versions, convoy.filters,
+            convoy.portal_links, convoy.portal_links_endpoints, convoy.portal_tokens,
+            convoy.organisation_members, convoy.api_keys,
+            convoy.alert_rules, convoy.email_templates, convoy.email_branding, convoy.endpoint_health_checks
+            WITH (publish = ''insert, update, delete'')';
+    END IF;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'convoy_config publication not created: %; configuration replication needs it', SQLERRM;
+END;
+$$;
+-- +migrate StatementEnd
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    DROP PUBLICATION IF EXISTS convoy_config;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'convoy_config publication not dropped: %', SQLERRM;
+END;
+$$;
+-- +migrate StatementEnd
+
+ALTER TABLE convoy.portal_links_endpoints REPLICA IDENTITY DEFAULT;
+
+DROP TABLE IF EXISTS convoy.config_replication_state;
+
+RESET lock_timeout;
+RESET statement_timeout;
@@ -0,0 +1,21 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Default FALSE keeps delivery metrics at one series per project. Projects
+-- that want per-endpoint detail opt in from their settings.
+ALTER TABLE convoy.project_configurations
+ADD COLUMN IF NOT EXISTS endpoint_metric_labels BOOLEAN NOT NULL DEFAULT FALSE;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.project_configurations DROP COLUMN IF EXISTS endpoint_metric_labels;
+
+RESET lock_timeout;
+RESET statement_timeout;
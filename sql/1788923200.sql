@@ -0,0 +1,68 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Rows left behind by endpoints or projects that were removed before the
+-- foreign keys existed would fail validation.
+DELETE FROM convoy.circuit_breaker_overrides o
+WHERE NOT EXISTS (SELECT 1 FROM convoy.endpoints e WHERE e.id = o.endpoint_id)
+   OR NOT EXISTS (SELECT 1 FROM convoy.projects p WHERE p.id = o.project_id);
+
+DELETE FROM convoy.circuit_breaker_transitions t
+WHERE NOT EXISTS (SELECT 1 FROM convoy.endpoints e WHERE e.id = t.endpoint_id)
+   OR NOT EXISTS (SELECT 1 FROM convoy.projects p WHERE p.id = t.project_id);
+
+ALTER TABLE convoy.circuit_breaker_overrides
+    ADD CONSTRAINT circuit_breaker_overrides_endpoint_id_fkey
+        FOREIGN KEY (endpoint_id)
+            REFERENCES convoy.endpoints(id)
+            ON DELETE CASCADE
+            NOT VALID;
+ALTER TABLE convoy.circuit_breaker_overrides VALIDATE CONSTRAINT circuit_breaker_overrides_endpoint_id_fkey;
+
+ALTER TABLE convoy.circuit_breaker_overrides
+    ADD CONSTRAINT circuit_breaker_overrides_project_id_fkey
+        FOREIGN KEY (project_id)
+            REFERENCES convoy.projects(id)
+            ON DELETE CASCADE
+            NOT VALID;
+ALTER TABLE convoy.circuit_breaker_overrides VALIDATE CONSTRAINT circuit_breaker_overrides_project_id_fkey;
+
+ALTER TABLE convoy.circuit_breaker_transitions
+    ADD CONSTRAINT circuit_breaker_transitions_endpoint_id_fkey
+        FOREIGN KEY (endpoint_id)
+            REFERENCES convoy.endpoints(id)
+            ON DELETE CASCADE
+            NOT VALID;
+ALTER TABLE convoy.circuit_breaker_transitions VALIDATE CONSTRAINT circuit_breaker_transitions_endpoint_id_fkey;
+
+ALTER TABLE convoy.circuit_breaker_transitions
+    ADD CONSTRAINT circuit_breaker_transitions_project_id_fkey
+        FOREIGN KEY (project_id)
+            REFERENCES convoy.projects(id)
+            ON DELETE CASCADE
+            NOT VALID;
+ALTER TABLE convoy.circuit_breaker_transitions VALIDATE CONSTRAINT circuit_breaker_transitions_project_id_fkey;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+-- The retention job deletes by age across every endpoint.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_circuit_breaker_transitions_created_at
+    ON convoy.circuit_breaker_transitions (created_at);
+
+-- +migrate Down notransaction
+DROP INDEX CONCURRENTLY IF EXISTS convoy.idx_circuit_breaker_transitions_created_at;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+ALTER TABLE convoy.circuit_breaker_transitions DROP CONSTRAINT IF EXISTS circuit_breaker_transitions_project_id_fkey;
+ALTER TABLE convoy.circuit_breaker_transitions DROP CONSTRAINT IF EXISTS circuit_breaker_transitions_endpoint_id_fkey;
+ALTER TABLE convoy.circuit_breaker_overrides DROP CONSTRAINT IF EXISTS circuit_breaker_overrides_project_id_fkey;
+ALTER TABLE convoy.circuit_breaker_overrides DROP CONSTRAINT IF EXISTS circuit_breaker_overrides_endpoint_id_fkey;
+
+RESET lock_timeout;
+RESET statement_timeout;
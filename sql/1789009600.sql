@@ -0,0 +1,43 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- The subscriptions whose filters turned an event away, kept for a few days so
+-- integrators can see why a subscription did not receive an event even when
+-- another one did. Only written for projects that opt in.
+CREATE TABLE IF NOT EXISTS convoy.event_filter_rejections (
+    id              VARCHAR PRIMARY KEY,
+    project_id      VARCHAR NOT NULL,
+    event_id        VARCHAR NOT NULL,
+    subscription_id VARCHAR NOT NULL,
+    event_type      VARCHAR NOT NULL DEFAULT '',
+    reason          TEXT NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_event_filter_rejections_project FOREIGN KEY (project_id) REFERENCES convoy.projects(id) ON DELETE CASCADE,
+    CONSTRAINT uq_event_filter_rejections_event_subscription UNIQUE (event_id, subscription_id)
+);
+
+ALTER TABLE convoy.project_configurations
+ADD COLUMN IF NOT EXISTS record_filter_rejections BOOLEAN NOT NULL DEFAULT FALSE;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_event_filter_rejections_project_id_event_id
+    ON convoy.event_filter_rejections (project_id, event_id);
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_event_filter_rejections_created_at
+    ON convoy.event_filter_rejections (created_at);
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.project_configurations DROP COLUMN IF EXISTS record_filter_rejections;
+DROP TABLE IF EXISTS convoy.event_filter_rejections;
+
+RESET lock_timeout;
+RESET statement_timeout;
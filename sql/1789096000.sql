@@ -0,0 +1,35 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- When a convoy listen session is presumed gone. The session pushes it forward
+-- while it is open, so a CLI subscription left behind by a crashed server is
+-- reaped instead of collecting deliveries nothing will claim.
+ALTER TABLE convoy.subscriptions
+ADD COLUMN IF NOT EXISTS cli_expires_at TIMESTAMPTZ;
+
+-- Sessions open during the upgrade do not heartbeat yet, give them an hour.
+UPDATE convoy.subscriptions
+SET cli_expires_at = NOW() + INTERVAL '1 hour'
+WHERE type = 'cli' AND cli_expires_at IS NULL AND deleted_at IS NULL;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_subscriptions_cli_expires_at
+    ON convoy.subscriptions (cli_expires_at)
+    WHERE type = 'cli' AND deleted_at IS NULL;
+
+-- +migrate Down notransaction
+DROP INDEX CONCURRENTLY IF EXISTS convoy.idx_subscriptions_cli_expires_at;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- squawk-ignore ban-drop-column
+ALTER TABLE convoy.subscriptions DROP COLUMN IF EXISTS cli_expires_at;
+
+RESET lock_timeout;
+RESET statement_timeout;
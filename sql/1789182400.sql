@@ -0,0 +1,37 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Rules left behind by projects that were removed before the foreign key
+-- existed would fail validation.
+DELETE FROM convoy.alert_rules r
+WHERE NOT EXISTS (SELECT 1 FROM convoy.projects p WHERE p.id = r.project_id);
+
+ALTER TABLE convoy.alert_rules
+    ADD CONSTRAINT alert_rules_project_id_fkey
+        FOREIGN KEY (project_id)
+            REFERENCES convoy.projects(id)
+            ON DELETE CASCADE
+            NOT VALID;
+ALTER TABLE convoy.alert_rules VALIDATE CONSTRAINT alert_rules_project_id_fkey;
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Up notransaction
+-- Endpoint scoped failure rate and consecutive failure rules read the latest
+-- attempts of one endpoint.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_delivery_attempts_project_endpoint_created_at
+    ON convoy.delivery_attempts (project_id, endpoint_id, created_at DESC);
+
+-- +migrate Down notransaction
+DROP INDEX CONCURRENTLY IF EXISTS convoy.idx_delivery_attempts_project_endpoint_created_at;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+ALTER TABLE convoy.alert_rules DROP CONSTRAINT IF EXISTS alert_rules_project_id_fkey;
+
+RESET lock_timeout;
+RESET statement_timeout;
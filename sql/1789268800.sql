@@ -0,0 +1,58 @@
+-- +migrate Up
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- Per-endpoint circuit breaker overrides and saved searches are configuration
+-- too, so a standby keeps them through a failover. A database without the
+-- publication, where the role could not create it, is left alone.
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = 'convoy_config') THEN
+        RETURN;
+    END IF;
+
+    FOREACH t IN ARRAY ARRAY['circuit_breaker_overrides', 'saved_searches'] LOOP
+        IF NOT EXISTS (
+            SELECT 1 FROM pg_publication_tables
+            WHERE pubname = 'convoy_config' AND schemaname = 'convoy' AND tablename = t
+        ) THEN
+            EXECUTE format('ALTER PUBLICATION convoy_config ADD TABLE convoy.%I', t);
+        END IF;
+    END LOOP;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'convoy_config publication not extended: %; configuration replication needs it', SQLERRM;
+END;
+$$;
+-- +migrate StatementEnd
+
+RESET lock_timeout;
+RESET statement_timeout;
+
+-- +migrate Down
+SET lock_timeout = '2s';
+SET statement_timeout = '30s';
+
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['circuit_breaker_overrides', 'saved_searches'] LOOP
+        IF EXISTS (
+            SELECT 1 FROM pg_publication_tables
+            WHERE pubname = 'convoy_config' AND schemaname = 'convoy' AND tablename = t
+        ) THEN
+            EXECUTE format('ALTER PUBLICATION convoy_config DROP TABLE convoy.%I', t);
+        END IF;
+    END LOOP;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'convoy_config publication not reduced: %', SQLERRM;
+END;
+$$;
+-- +migrate StatementEnd
+
+RESET lock_timeout;
+RESET statement_timeout;
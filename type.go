@@ -120,6 +120,14 @@ const (
 	BatchRetryProcessor              TaskName = "BatchRetryProcessor"
 	BulkOnboardProcessor             TaskName = "BulkOnboardProcessor"
 	UpdateOrganisationStatus         TaskName = "UpdateOrganisationStatus"
+	RunEndpointHealthChecks          TaskName = "RunEndpointHealthChecks"
+	ReplayJobProcessor               TaskName = "ReplayJobProcessor"
+	ExportJobProcessor               TaskName = "ExportJobProcessor"
+	NotifyEventTypeVersionSunsets    TaskName = "NotifyEventTypeVersionSunsets"
+	RunAlertRules                    TaskName = "RunAlertRules"
+	PruneCircuitBreakerTransitions   TaskName = "PruneCircuitBreakerTransitions"
+	PruneFilterRejections            TaskName = "PruneFilterRejections"
+	ReapCLISubscriptions             TaskName = "ReapCLISubscriptions"
 
 	TokenCacheKey   CacheKey = "tokens"
 	ProjectCacheKey CacheKey = "projects"
@@ -132,7 +132,38 @@ export class CreateProjectComponent implements OnInit {
 		{ label: 'circuit breaker', svg: 'stroke', icon: 'shield' }
 	];
 	activeTab = this.tabs[0];
-	events = ['endpoint.created', 'endpoint.deleted', 'endpoint.updated', 'eventdelivery.success', 'eventdelivery.failed', 'project.updated'];
+	events = [
+		'endpoint.created',
+		'endpoint.deleted',
+		'endpoint.updated',
+		'endpoint.paused',
+		'endpoint.activated',
+		'endpoint.disabled',
+		'endpoint.secret_rotated',
+		'endpoint.secret_expired',
+		'eventdelivery.success',
+		'eventdelivery.failed',
+		'project.updated',
+		'circuitbreaker.opened',
+		'circuitbreaker.half_opened',
+		'circuitbreaker.closed',
+		'subscription.created',
+		'subscription.updated',
+		'subscription.deleted',
+		'source.created',
+		'source.updated',
+		'source.deleted',
+		'portallink.created',
+		'portallink.updated',
+		'portallink.revoked',
+		'eventtype.created',
+		'eventtype.updated',
+		'eventtype.deprecated',
+		'eventtype.version_sunset',
+		'batchretry.completed',
+		'batchretry.failed',
+		'backupjob.completed'
+	];
 	eventTypes: EVENT_TYPE[] = [];
 	selectedEventType: EVENT_TYPE | null = null;
     rateLimitDeleted = false;
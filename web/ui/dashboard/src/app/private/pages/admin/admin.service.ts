@@ -384,6 +384,20 @@ export class AdminService {
 		});
 	}
 
+	getQueueTenantBacklog(queueName: string): Promise<HTTP_RESPONSE> {
+		return new Promise(async (resolve, reject) => {
+			try {
+				const response = await this.http.request({
+					url: `/admin/queue/${encodeURIComponent(queueName)}/tenants`,
+					method: 'get'
+				});
+				return resolve(response);
+			} catch (error) {
+				return reject(error);
+			}
+		});
+	}
+
 	getQueueSchedulerEntries(): Promise<HTTP_RESPONSE> {
 		return new Promise(async (resolve, reject) => {
 			try {
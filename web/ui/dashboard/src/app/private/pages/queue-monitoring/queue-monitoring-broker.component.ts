@@ -44,6 +44,21 @@ interface HistoryPoint {
 	failed: number;
 }
 
+interface TenantBacklog {
+	tenant: string;
+	pending: number;
+	processing: number;
+	weight: number;
+}
+
+interface TenantBacklogReport {
+	queue: string;
+	tenants: TenantBacklog[];
+	sampled: boolean;
+	sample_size?: number;
+	fair_share: boolean;
+}
+
 interface SchedulerEntry {
 	id: string;
 	spec: string;
@@ -113,6 +128,8 @@ export class QueueMonitoringBrokerComponent implements OnInit, OnDestroy {
 	historyDays = 7;
 	isLoadingHistory = false;
 
+	tenantBacklog: TenantBacklogReport | null = null;
+
 	schedulerEntries: SchedulerEntry[] = [];
 	showScheduler = false;
 	isLoadingScheduler = false;
@@ -161,6 +178,7 @@ export class QueueMonitoringBrokerComponent implements OnInit, OnDestroy {
 	// response lands last on screen.
 	private taskRequest = 0;
 	private historyRequest = 0;
+	private tenantRequest = 0;
 
 	constructor(
 		private readonly adminService: AdminService,
@@ -331,7 +349,7 @@ export class QueueMonitoringBrokerComponent implements OnInit, OnDestroy {
 		this.selectedStatus = status;
 		this.page = 1;
 		this.clearSearch();
-		await Promise.all([this.loadTasks(), this.loadHistory()]);
+		await Promise.all([this.loadTasks(), this.loadHistory(), this.loadTenantBacklog()]);
 	}
 
 	closeTasks(): void {
@@ -342,6 +360,7 @@ export class QueueMonitoringBrokerComponent implements OnInit, OnDestroy {
 		this.hasNext = false;
 		this.expandedTask = '';
 		this.history = [];
+		this.tenantBacklog = null;
 		this.selected.clear();
 		this.clearSearch();
 		// Counts moved while the drill-down was open, and the operator is
@@ -445,6 +464,21 @@ export class QueueMonitoringBrokerComponent implements OnInit, OnDestroy {
 		}
 	}
 
+	async loadTenantBacklog(): Promise<void> {
+		const request = ++this.tenantRequest;
+
+		try {
+			const response = await this.adminService.getQueueTenantBacklog(this.selectedQueue);
+			if (request !== this.tenantRequest) return;
+			this.tenantBacklog = response.data ?? null;
+		} catch {
+			if (request !== this.tenantRequest) return;
+			// Like the chart, the breakdown is context for the drill-down, so a
+			// failed read hides it rather than the task list.
+			this.tenantBacklog = null;
+		}
+	}
+
 	async changeHistoryDays(days: number): Promise<void> {
 		if (days === this.historyDays) return;
 		this.historyDays = days;
@@ -48,12 +48,12 @@ type redisConsumerBackend struct {
 }
 
 type asynqRunner struct {
-	srv *asynq.Server
-	mux *asynq.ServeMux
+	srv     *asynq.Server
+	handler asynq.Handler
 }
 
 func (r *asynqRunner) Start() error {
-	if err := r.srv.Start(r.mux); err != nil {
+	if err := r.srv.Start(r.handler); err != nil {
 		return fmt.Errorf("error starting worker: %w", err)
 	}
 	return nil
@@ -108,10 +108,17 @@ func (b *redisConsumerBackend) newRunner(ctx context.Context, consumerPoolSize i
 		return nil, errors.New("redis consumer connection is required")
 	}
 
+	// Under fair scheduling asynq fetches ahead of the pool and the scheduler
+	// decides which fetched task runs next.
+	concurrency := consumerPoolSize
+	if queueOpts.FairShare.Enabled {
+		concurrency = consumerPoolSize * fairShareLookahead
+	}
+
 	srv := asynq.NewServer(
 		opts,
 		asynq.Config{
-			Concurrency: consumerPoolSize,
+			Concurrency: concurrency,
 			BaseContext: func() context.Context {
 				return ctx
 			},
@@ -122,7 +129,12 @@ func (b *redisConsumerBackend) newRunner(ctx context.Context, consumerPoolSize i
 			LogLevel:       getLogLevel(level),
 		},
 	)
-	return &asynqRunner{srv: srv, mux: mux}, nil
+
+	var handler asynq.Handler = mux
+	if queueOpts.FairShare.Enabled {
+		handler = newTenantScheduler(queueOpts.FairShare, consumerPoolSize).middleware(mux)
+	}
+	return &asynqRunner{srv: srv, handler: handler}, nil
 }
 
 func (c *Consumer) Start() error {
@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/frain-dev/convoy/queue"
+)
+
+// fairShareLookahead is how many tasks per worker the redis consumer fetches
+// under fair scheduling. Asynq hands tasks out in queue order, so the consumer
+// reads ahead and picks among the tenants in that window when a worker frees;
+// a window the size of the pool would leave nothing to choose from.
+const fairShareLookahead = 4
+
+// tenantScheduler is fair scheduling for the redis consumer. Asynq dequeues
+// with no notion of whose task it is, so the choice is made when a fetched
+// task is handed a worker: each tenant waits in its own lane, and a freed
+// worker goes to the next tenant by smooth weighted round robin, the same split
+// the postgres claim makes across tenants.
+//
+// Only tasks this consumer has fetched are seen, so a tenant's backlog further
+// down the redis queue is reordered a window at a time. Counts are per consumer
+// process: the postgres provider reads in-flight work from the rows and so caps
+// a tenant across replicas; here MaxInFlight is per replica.
+type tenantScheduler struct {
+	share queue.FairShare
+
+	mu      sync.Mutex
+	free    int
+	running map[string]int
+	waiting map[string][]chan struct{}
+	credit  map[string]int
+}
+
+func newTenantScheduler(share queue.FairShare, slots int) *tenantScheduler {
+	if slots < 1 {
+		slots = 1
+	}
+	return &tenantScheduler{
+		share:   share,
+		free:    slots,
+		running: make(map[string]int),
+		waiting: make(map[string][]chan struct{}),
+		credit:  make(map[string]int),
+	}
+}
+
+// acquire waits until tenant is handed a worker, or ctx is done.
+func (s *tenantScheduler) acquire(ctx context.Context, tenant string) error {
+	ready := make(chan struct{})
+
+	s.mu.Lock()
+	s.waiting[tenant] = append(s.waiting[tenant], ready)
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-ready:
+		// Handed a worker as ctx ended; pass it on.
+		s.releaseLocked(tenant)
+	default:
+		s.dropWaiter(tenant, ready)
+	}
+	return ctx.Err()
+}
+
+func (s *tenantScheduler) release(tenant string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLocked(tenant)
+}
+
+func (s *tenantScheduler) releaseLocked(tenant string) {
+	s.running[tenant]--
+	if s.running[tenant] <= 0 {
+		delete(s.running, tenant)
+	}
+	s.free++
+	s.dispatch()
+}
+
+func (s *tenantScheduler) dropWaiter(tenant string, ready chan struct{}) {
+	lane := s.waiting[tenant]
+	for i, c := range lane {
+		if c == ready {
+			lane = append(lane[:i], lane[i+1:]...)
+			break
+		}
+	}
+	if len(lane) == 0 {
+		delete(s.waiting, tenant)
+		return
+	}
+	s.waiting[tenant] = lane
+}
+
+// dispatch hands free workers to waiting tenants, oldest task first within a
+// tenant. A tenant at its in-flight cap sits out, and a tenant with nothing
+// waiting has its credit dropped rather than saved up into a burst when it
+// comes back. Callers hold mu.
+func (s *tenantScheduler) dispatch() {
+	for s.free > 0 {
+		tenants := make([]string, 0, len(s.waiting))
+		for tenant := range s.waiting {
+			if s.share.MaxInFlight > 0 && s.running[tenant] >= s.share.MaxInFlight {
+				continue
+			}
+			tenants = append(tenants, tenant)
+		}
+		sort.Strings(tenants)
+
+		for tenant := range s.credit {
+			if _, ok := s.waiting[tenant]; !ok {
+				delete(s.credit, tenant)
+			}
+		}
+
+		// best is an index rather than a tenant because "" is a tenant: the
+		// one untenanted tasks share. A tie goes to the tenant holding fewer
+		// workers, so a tenant that just arrived is not queued behind one
+		// that already has the pool.
+		best, total := -1, 0
+		for i, tenant := range tenants {
+			w := s.share.Weight(tenant)
+			s.credit[tenant] += w
+			total += w
+			if best < 0 || s.credit[tenant] > s.credit[tenants[best]] ||
+				(s.credit[tenant] == s.credit[tenants[best]] && s.running[tenant] < s.running[tenants[best]]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			return
+		}
+
+		tenant := tenants[best]
+		s.credit[tenant] -= total
+
+		lane := s.waiting[tenant]
+		close(lane[0])
+		if len(lane) == 1 {
+			delete(s.waiting, tenant)
+		} else {
+			s.waiting[tenant] = lane[1:]
+		}
+		s.running[tenant]++
+		s.free--
+	}
+}
+
+// middleware holds a task until its tenant is handed a worker. A task whose
+// context ends while waiting fails with the context's error, as it would had
+// it been running.
+func (s *tenantScheduler) middleware(h asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		tenant := queue.TenantOf(t.Headers())
+
+		if err := s.acquire(ctx, tenant); err != nil {
+			return err
+		}
+		defer s.release(tenant)
+
+		return h.ProcessTask(ctx, t)
+	})
+}
@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/worker/task"
+)
+
+// granted reports whether a waiter has been handed a worker.
+func granted(ready chan struct{}) bool {
+	select {
+	case <-ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// enqueue parks n waiters for tenant without blocking, as fetched tasks do.
+func enqueue(s *tenantScheduler, tenant string, n int) []chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lane := make([]chan struct{}, n)
+	for i := range lane {
+		lane[i] = make(chan struct{})
+		s.waiting[tenant] = append(s.waiting[tenant], lane[i])
+	}
+	s.dispatch()
+	return lane
+}
+
+func countGranted(lane []chan struct{}) int {
+	n := 0
+	for _, c := range lane {
+		if granted(c) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTenantSchedulerLoneTenantTakesThePool(t *testing.T) {
+	s := newTenantScheduler(queue.FairShare{Enabled: true}, 4)
+
+	lane := enqueue(s, "a", 6)
+	require.Equal(t, 4, countGranted(lane))
+	require.True(t, granted(lane[0]), "a tenant's oldest task runs first")
+	require.False(t, granted(lane[5]))
+}
+
+func TestTenantSchedulerSplitsByWeight(t *testing.T) {
+	s := newTenantScheduler(queue.FairShare{Enabled: true, Weights: map[string]int{"a": 3}}, 8)
+	s.free = 0
+
+	a := enqueue(s, "a", 20)
+	b := enqueue(s, "b", 20)
+
+	// Free the whole pool at once: a's backlog arrived first, but the workers
+	// split 3:1 between the tenants waiting for them.
+	s.mu.Lock()
+	s.free = 8
+	s.dispatch()
+	s.mu.Unlock()
+
+	require.Equal(t, 6, countGranted(a))
+	require.Equal(t, 2, countGranted(b))
+}
+
+func TestTenantSchedulerNextWorkerGoesToTheWaitingTenant(t *testing.T) {
+	s := newTenantScheduler(queue.FairShare{Enabled: true}, 2)
+
+	a := enqueue(s, "a", 10)
+	require.Equal(t, 2, countGranted(a))
+
+	b := enqueue(s, "b", 1)
+	require.False(t, granted(b[0]), "the pool is busy")
+
+	s.release("a")
+	require.True(t, granted(b[0]), "b does not wait behind a's backlog")
+	require.Equal(t, 2, countGranted(a))
+}
+
+func TestTenantSchedulerHonoursInFlightCap(t *testing.T) {
+	s := newTenantScheduler(queue.FairShare{Enabled: true, MaxInFlight: 2}, 10)
+
+	a := enqueue(s, "a", 3)
+	require.Equal(t, 2, countGranted(a))
+
+	b := enqueue(s, "b", 1)
+	require.True(t, granted(b[0]))
+
+	s.release("a")
+	require.True(t, granted(a[2]), "a gets its next task in once under the cap")
+}
+
+func TestTenantSchedulerWaiterGivesUpWithItsContext(t *testing.T) {
+	s := newTenantScheduler(queue.FairShare{Enabled: true}, 1)
+	require.NoError(t, s.acquire(context.Background(), "a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.acquire(ctx, "b"), context.DeadlineExceeded)
+
+	s.mu.Lock()
+	require.Empty(t, s.waiting, "an abandoned task leaves its lane")
+	s.mu.Unlock()
+
+	s.release("a")
+	require.NoError(t, s.acquire(context.Background(), "c"), "the worker is not lost to the abandoned task")
+}
+
+func TestTenantSchedulerMiddlewareReleasesItsWorker(t *testing.T) {
+	s := newTenantScheduler(queue.FairShare{Enabled: true}, 1)
+
+	ran := false
+	h := s.middleware(asynq.HandlerFunc(func(context.Context, *asynq.Task) error {
+		ran = true
+		return nil
+	}))
+	tk := asynq.NewTaskWithHeaders("t", nil, map[string]string{queue.TenantHeader: "a"})
+	require.NoError(t, h.ProcessTask(context.Background(), tk))
+	require.True(t, ran)
+	require.Equal(t, 1, s.free)
+	require.Empty(t, s.running)
+}
+
+func TestBackpressureIsNotACountedFailure(t *testing.T) {
+	require.False(t, isCountedFailure(&task.RateLimitError{}))
+	require.False(t, isCountedFailure(&task.CircuitBreakerError{}))
+	require.True(t, isCountedFailure(&task.EndpointError{}))
+}
@@ -0,0 +1,19 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// AlertRuleRunner evaluates every enabled alert rule.
+type AlertRuleRunner interface {
+	Run(ctx context.Context) error
+}
+
+func RunAlertRules(runner AlertRuleRunner, locker JobLocker) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		return skipIfLockBusy(locker.WithLock(ctx, "convoy:alert_rules:mutex", 5*time.Minute, runner.Run))
+	}
+}
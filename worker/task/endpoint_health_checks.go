@@ -0,0 +1,19 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// EndpointHealthRunner runs the endpoint health checks that are due.
+type EndpointHealthRunner interface {
+	Run(ctx context.Context) error
+}
+
+func RunEndpointHealthChecks(runner EndpointHealthRunner, locker JobLocker) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		return skipIfLockBusy(locker.WithLock(ctx, "convoy:endpoint_health_checks:mutex", 5*time.Minute, runner.Run))
+	}
+}
@@ -0,0 +1,121 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/transform"
+)
+
+// EventTypeVersionSunsetRunner announces the event type version sunsets that
+// are due.
+type EventTypeVersionSunsetRunner interface {
+	Run(ctx context.Context) error
+}
+
+func NotifyEventTypeVersionSunsets(runner EventTypeVersionSunsetRunner, locker JobLocker) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		return skipIfLockBusy(locker.WithLock(ctx, "convoy:event_type_version_sunsets:mutex", 5*time.Minute, runner.Run))
+	}
+}
+
+// versionedPayloads downgrades an event for the subscriptions pinned to an
+// older version of its event type than it was published as, keyed by
+// subscription. An event that names no version is taken to be the latest.
+// Downgrades run the project's own functions, so they need the
+// transformations entitlement. Subscriptions whose version cannot be reached,
+// because a downgrade function is missing or fails, are returned separately
+// with the reason, so only their deliveries are discarded.
+func versionedPayloads(ctx context.Context, repo datastore.EventTypeVersionRepository, licenser license.Licenser, event *datastore.Event, subscriptions []datastore.Subscription, logger log.Logger) (map[string]json.RawMessage, map[string]string, error) {
+	if repo == nil || len(subscriptions) == 0 || licenser == nil || !licenser.Transformations() {
+		return nil, nil, nil
+	}
+
+	pinned, err := repo.HasEventTypeVersionPins(ctx, event.ProjectID)
+	if err != nil || !pinned {
+		return nil, nil, err
+	}
+
+	subscriptionIDs := make([]string, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		subscriptionIDs = append(subscriptionIDs, s.UID)
+	}
+
+	pins, err := repo.LoadEventTypeVersionPins(ctx, event.ProjectID, string(event.EventType), subscriptionIDs)
+	if err != nil || len(pins) == 0 {
+		return nil, nil, err
+	}
+
+	versions, err := repo.LoadEventTypeVersions(ctx, event.ProjectID, string(event.EventType))
+	if err != nil || len(versions) == 0 {
+		return nil, nil, err
+	}
+
+	published := event.GetEventTypeVersion()
+	if published == 0 {
+		published = versions[len(versions)-1].Version
+	}
+
+	payloads := map[string]json.RawMessage{}
+	discarded := map[string]string{}
+	byVersion := map[int]json.RawMessage{}
+	failed := map[int]bool{}
+	for _, pin := range pins {
+		if pin.Version >= published {
+			continue
+		}
+
+		if payload, ok := byVersion[pin.Version]; ok {
+			payloads[pin.SubscriptionID] = payload
+			continue
+		}
+		if failed[pin.Version] {
+			discarded[pin.SubscriptionID] = metrics.DiscardReasonDowngradeFailed
+			continue
+		}
+
+		chain, ok := datastore.DowngradeChain(versions, published, pin.Version)
+		if !ok {
+			discarded[pin.SubscriptionID] = metrics.DiscardReasonVersionUnreachable
+			continue
+		}
+
+		payload, err := downgrade(event.Data, chain)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to downgrade event", "event_id", event.UID, "event_type", event.EventType, "from_version", published, "to_version", pin.Version, "error", err)
+			failed[pin.Version] = true
+			discarded[pin.SubscriptionID] = metrics.DiscardReasonDowngradeFailed
+			continue
+		}
+
+		byVersion[pin.Version] = payload
+		payloads[pin.SubscriptionID] = payload
+	}
+
+	return payloads, discarded, nil
+}
+
+func downgrade(data json.RawMessage, chain []string) (json.RawMessage, error) {
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	// Each step gets its own runtime, and so its own execution deadline.
+	for _, fn := range chain {
+		mutated, _, err := transform.NewTransformer().Transform(fn, payload)
+		if err != nil {
+			return nil, err
+		}
+		payload = mutated
+	}
+
+	return json.Marshal(payload)
+}
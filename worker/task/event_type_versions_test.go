@@ -0,0 +1,164 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func transformationsLicenser(ctrl *gomock.Controller, enabled bool) *mocks.MockLicenser {
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().Transformations().Return(enabled).AnyTimes()
+	return licenser
+}
+
+func TestVersionedPayloadsDowngradesPinnedSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+
+	event := &datastore.Event{
+		UID:       "event-id-1",
+		ProjectID: "project-id-1",
+		EventType: "invoice.paid",
+		Data:      json.RawMessage(`{"amount_cents":1000}`),
+	}
+	subscriptions := []datastore.Subscription{{UID: "sub-v1"}, {UID: "sub-v1-again"}, {UID: "sub-v2"}, {UID: "sub-v3"}, {UID: "sub-unpinned"}}
+
+	repo.EXPECT().HasEventTypeVersionPins(gomock.Any(), "project-id-1").Return(true, nil)
+	repo.EXPECT().LoadEventTypeVersionPins(gomock.Any(), "project-id-1", "invoice.paid", []string{"sub-v1", "sub-v1-again", "sub-v2", "sub-v3", "sub-unpinned"}).
+		Return([]datastore.EventTypeVersionPin{
+			{SubscriptionID: "sub-v1", Version: 1},
+			{SubscriptionID: "sub-v1-again", Version: 1},
+			{SubscriptionID: "sub-v2", Version: 2},
+			{SubscriptionID: "sub-v3", Version: 3},
+		}, nil)
+	repo.EXPECT().LoadEventTypeVersions(gomock.Any(), "project-id-1", "invoice.paid").
+		Return([]datastore.EventTypeVersion{
+			{Version: 1, Downgrade: null.StringFrom(`function transform(p) { return {amount: p.amount_dollars} }`)},
+			{Version: 2, Downgrade: null.StringFrom(`function transform(p) { return {amount_dollars: p.amount_cents / 100} }`)},
+			{Version: 3},
+		}, nil)
+
+	payloads, discarded, err := versionedPayloads(context.Background(), repo, transformationsLicenser(ctrl, true), event, subscriptions, log.New("convoy", log.LevelError))
+	require.NoError(t, err)
+	require.Empty(t, discarded)
+
+	// The latest version is assumed when the event names none, so only the
+	// subscriptions pinned below it are rewritten.
+	require.Len(t, payloads, 3)
+	require.JSONEq(t, `{"amount":10}`, string(payloads["sub-v1"]))
+	require.JSONEq(t, `{"amount":10}`, string(payloads["sub-v1-again"]))
+	require.JSONEq(t, `{"amount_dollars":10}`, string(payloads["sub-v2"]))
+}
+
+func TestVersionedPayloadsMarksUnreachableVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+
+	event := &datastore.Event{
+		ProjectID: "project-id-1",
+		EventType: "invoice.paid",
+		Data:      json.RawMessage(`{"amount_cents":1000}`),
+		Metadata:  `{"eventTypeVersion":"2"}`,
+	}
+
+	repo.EXPECT().HasEventTypeVersionPins(gomock.Any(), "project-id-1").Return(true, nil)
+	repo.EXPECT().LoadEventTypeVersionPins(gomock.Any(), "project-id-1", "invoice.paid", []string{"sub-v1"}).
+		Return([]datastore.EventTypeVersionPin{{SubscriptionID: "sub-v1", Version: 1}}, nil)
+	repo.EXPECT().LoadEventTypeVersions(gomock.Any(), "project-id-1", "invoice.paid").
+		Return([]datastore.EventTypeVersion{{Version: 1}, {Version: 2}, {Version: 3}}, nil)
+
+	payloads, discarded, err := versionedPayloads(context.Background(), repo, transformationsLicenser(ctrl, true), event, []datastore.Subscription{{UID: "sub-v1"}}, log.New("convoy", log.LevelError))
+	require.NoError(t, err)
+	require.Empty(t, payloads)
+	require.Equal(t, map[string]string{"sub-v1": metrics.DiscardReasonVersionUnreachable}, discarded)
+}
+
+func TestVersionedPayloadsDiscardsOnlyFailedDowngrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+
+	event := &datastore.Event{
+		ProjectID: "project-id-1",
+		EventType: "invoice.paid",
+		Data:      json.RawMessage(`{"amount_cents":1000}`),
+	}
+
+	repo.EXPECT().HasEventTypeVersionPins(gomock.Any(), "project-id-1").Return(true, nil)
+	repo.EXPECT().LoadEventTypeVersionPins(gomock.Any(), "project-id-1", "invoice.paid", []string{"sub-v1", "sub-v1-again", "sub-v2"}).
+		Return([]datastore.EventTypeVersionPin{
+			{SubscriptionID: "sub-v1", Version: 1},
+			{SubscriptionID: "sub-v1-again", Version: 1},
+			{SubscriptionID: "sub-v2", Version: 2},
+		}, nil)
+	repo.EXPECT().LoadEventTypeVersions(gomock.Any(), "project-id-1", "invoice.paid").
+		Return([]datastore.EventTypeVersion{
+			{Version: 1, Downgrade: null.StringFrom(`function transform(p) { throw new Error("boom") }`)},
+			{Version: 2, Downgrade: null.StringFrom(`function transform(p) { return {amount_dollars: p.amount_cents / 100} }`)},
+			{Version: 3},
+		}, nil)
+
+	subscriptions := []datastore.Subscription{{UID: "sub-v1"}, {UID: "sub-v1-again"}, {UID: "sub-v2"}}
+	payloads, discarded, err := versionedPayloads(context.Background(), repo, transformationsLicenser(ctrl, true), event, subscriptions, log.New("convoy", log.LevelError))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"sub-v1":       metrics.DiscardReasonDowngradeFailed,
+		"sub-v1-again": metrics.DiscardReasonDowngradeFailed,
+	}, discarded)
+	require.Len(t, payloads, 1)
+	require.JSONEq(t, `{"amount_dollars":10}`, string(payloads["sub-v2"]))
+}
+
+func TestVersionedPayloadsSkipsLookups(t *testing.T) {
+	event := &datastore.Event{ProjectID: "project-id-1", EventType: "invoice.paid"}
+	subscriptions := []datastore.Subscription{{UID: "sub-id-1"}}
+
+	t.Run("without transformations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// no repository calls are expected
+		repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+
+		payloads, discarded, err := versionedPayloads(context.Background(), repo, transformationsLicenser(ctrl, false), event, subscriptions, log.New("convoy", log.LevelError))
+		require.NoError(t, err)
+		require.Nil(t, payloads)
+		require.Nil(t, discarded)
+	})
+
+	t.Run("without pins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockEventTypeVersionRepository(ctrl)
+		repo.EXPECT().HasEventTypeVersionPins(gomock.Any(), "project-id-1").Return(false, nil)
+
+		payloads, discarded, err := versionedPayloads(context.Background(), repo, transformationsLicenser(ctrl, true), event, subscriptions, log.New("convoy", log.LevelError))
+		require.NoError(t, err)
+		require.Nil(t, payloads)
+		require.Nil(t, discarded)
+	})
+}
+
+func TestVersionedPayloadsWithoutRepository(t *testing.T) {
+	payloads, discarded, err := versionedPayloads(context.Background(), nil, nil, &datastore.Event{}, []datastore.Subscription{{UID: "sub-id-1"}}, log.New("convoy", log.LevelError))
+	require.NoError(t, err)
+	require.Nil(t, payloads)
+	require.Nil(t, discarded)
+}
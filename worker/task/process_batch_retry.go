@@ -154,8 +154,9 @@ func ProcessBatchRetry(
 				}
 
 				job := &queue.Job{
-					ID:      delivery.UID,
-					Payload: data,
+					ID:        delivery.UID,
+					Payload:   data,
+					ProjectID: activeRetry.ProjectID,
 				}
 
 				err2 = queuer.Write(ctx, convoy.EventProcessor, convoy.EventQueue, job)
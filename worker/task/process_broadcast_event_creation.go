@@ -90,7 +90,7 @@ func (b *BroadcastEventChannel) CreateEvent(ctx context.Context, t *asynq.Task,
 		AcknowledgedAt:   null.TimeFrom(time.Now()),
 	}
 
-	err = updateEventMetadata(channel, event, false, args.logger)
+	err = updateEventMetadata(channel, event, false, broadcastEvent.EventTypeVersion, args.logger)
 	if err != nil {
 		tracer.AddEvent(ctx, tracer.EventBroadcastEventCreationError, attributes)
 		return nil, err
@@ -153,7 +153,7 @@ func (b *BroadcastEventChannel) MatchSubscriptions(ctx context.Context, metadata
 
 	args.logger.DebugContext(ctx, "matching subscriptions using filter", "event.id", broadcastEvent.UID)
 
-	subscriptions, err = matchSubscriptionsUsingFilter(ctx, broadcastEvent, args.subRepo, args.filterRepo, args.licenser, subscriptions, true, args.logger)
+	subscriptions, rejections, err := matchSubscriptionsUsingFilter(ctx, broadcastEvent, args.subRepo, args.filterRepo, args.licenser, subscriptions, true, args.logger)
 	if err != nil {
 		tracer.AddEvent(ctx, tracer.EventBroadcastSubscriptionMatchingErr, attributes)
 		return nil, &EndpointError{Err: fmt.Errorf("failed to match subscriptions using filter, err: %s", err.Error()), delay: defaultBroadcastDelay}
@@ -171,6 +171,7 @@ func (b *BroadcastEventChannel) MatchSubscriptions(ctx context.Context, metadata
 	response.Project = project
 	response.Subscriptions = ss
 	response.IsDuplicateEvent = broadcastEvent.IsDuplicateEvent
+	response.filterRejections = rejections
 
 	tracer.AddEvent(ctx, tracer.EventBroadcastSubscriptionMatchingOK, attributes)
 	return &response, nil
@@ -7,14 +7,16 @@ import (
 
 	"github.com/hibiken/asynq"
 
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/email"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
+	log "github.com/frain-dev/convoy/pkg/logger"
 	"github.com/frain-dev/convoy/pkg/msgpack"
 )
 
 var ErrInvalidEmailPayload = errors.New("invalid email payload")
 
-func ProcessEmails(sc smtp.SmtpClient) func(context.Context, *asynq.Task) error {
+func ProcessEmails(sc smtp.SmtpClient, templateRepo datastore.EmailTemplateRepository, lo log.Logger) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 		var message email.Message
 
@@ -26,16 +28,75 @@ func ProcessEmails(sc smtp.SmtpClient) func(context.Context, *asynq.Task) error
 			}
 		}
 
-		newEmail := email.NewEmail(sc)
+		return sendEmail(ctx, sc, templateRepo, lo, &message)
+	}
+}
 
-		if err := newEmail.Build(string(message.TemplateName), message.Params); err != nil {
-			return err
-		}
+// sendEmail builds and sends message with its organisation's override and
+// branding of the template, when it has any. An override that no longer
+// renders falls back to the built-in copy under the same branding: a broken
+// template must not cost the recipient the notification. templateRepo may be
+// nil.
+func sendEmail(ctx context.Context, sc smtp.SmtpClient, templateRepo datastore.EmailTemplateRepository, lo log.Logger, message *email.Message) error {
+	customisation, err := loadEmailCustomisation(ctx, templateRepo, message)
+	if err != nil {
+		lo.ErrorContext(ctx, "failed to load email customisation, sending built-in email",
+			"organisation_id", message.OrganisationID, "template", message.TemplateName, "error", err)
+	}
 
-		if err := newEmail.Send(message.Email, message.Subject); err != nil {
-			return err
+	if customisation != nil && (customisation.Subject != "" || customisation.Body != "") {
+		newEmail := email.NewEmail(sc)
+		subject, err := newEmail.BuildCustomised(message.TemplateName, message.Subject, message.Params, customisation)
+		if err == nil {
+			return newEmail.Send(message.Email, subject)
 		}
 
-		return nil
+		lo.ErrorContext(ctx, "failed to render email template override, sending built-in copy",
+			"organisation_id", message.OrganisationID, "template", message.TemplateName, "error", err)
+
+		customisation = &email.Customisation{LogoURL: customisation.LogoURL, BrandColor: customisation.BrandColor}
+	}
+
+	newEmail := email.NewEmail(sc)
+	subject, err := newEmail.BuildCustomised(message.TemplateName, message.Subject, message.Params, customisation)
+	if err != nil {
+		return err
 	}
+
+	return newEmail.Send(message.Email, subject)
+}
+
+// loadEmailCustomisation returns the organisation's override and branding of
+// message's template, or nil when there is neither.
+func loadEmailCustomisation(ctx context.Context, templateRepo datastore.EmailTemplateRepository, message *email.Message) (*email.Customisation, error) {
+	if templateRepo == nil || message.OrganisationID == "" || !email.IsCustomisable(message.TemplateName) {
+		return nil, nil
+	}
+
+	var (
+		c     email.Customisation
+		found bool
+	)
+
+	override, err := templateRepo.FindEmailTemplate(ctx, message.OrganisationID, string(message.TemplateName))
+	switch {
+	case err == nil:
+		c.Subject, c.Body, found = override.Subject, override.Body, true
+	case !errors.Is(err, datastore.ErrEmailTemplateNotFound):
+		return nil, err
+	}
+
+	branding, err := templateRepo.FindEmailBranding(ctx, message.OrganisationID)
+	switch {
+	case err == nil:
+		c.BrandColor, c.LogoURL, found = branding.BrandColor, branding.LogoURL, true
+	case !errors.Is(err, datastore.ErrEmailBrandingNotFound):
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return &c, nil
 }
@@ -1,16 +1,22 @@
 package task
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"testing"
 
 	"github.com/hibiken/asynq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/email"
 	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
 	"github.com/frain-dev/convoy/queue"
 )
 
@@ -54,7 +60,7 @@ func Test_ProcessEmails(t *testing.T) {
 				asynq.Queue(string(convoy.DefaultQueue)),
 				asynq.ProcessIn(job.Delay))
 
-			processFn := ProcessEmails(sc)
+			processFn := ProcessEmails(sc, nil, log.New("convoy", log.LevelInfo))
 
 			// Act.
 			err := processFn(context.Background(), task)
@@ -64,3 +70,71 @@ func Test_ProcessEmails(t *testing.T) {
 		})
 	}
 }
+
+func Test_ProcessEmails_AppliesOrganisationOverride(t *testing.T) {
+	tests := []struct {
+		name            string
+		override        *datastore.EmailTemplate
+		expectedSubject string
+		expectedBody    string
+	}{
+		{
+			name: "should_render_override",
+			override: &datastore.EmailTemplate{
+				Subject: "Acme: {{ .name }} disabled",
+				Body:    "<p>We could not reach {{ .target_url }}</p>",
+			},
+			expectedSubject: "Acme: payments disabled",
+			expectedBody:    "<p>We could not reach https://example.com/hook</p>",
+		},
+		{
+			name: "should_fall_back_to_built_in_email",
+			override: &datastore.EmailTemplate{
+				Body: "{{ .no_such_variable }}",
+			},
+			expectedSubject: "Endpoint Status Update",
+			expectedBody:    "https://example.com/hook",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEmailTemplateRepository(ctrl)
+			repo.EXPECT().FindEmailTemplate(gomock.Any(), "org-1", "endpoint.update").Return(tc.override, nil)
+			repo.EXPECT().FindEmailBranding(gomock.Any(), "org-1").Return(&datastore.EmailBranding{BrandColor: "#112233"}, nil)
+
+			sc := mocks.NewMockSmtpClient(ctrl)
+			sc.EXPECT().
+				SendEmail("owner@example.com", tc.expectedSubject, gomock.Any()).
+				DoAndReturn(func(_, _ string, body bytes.Buffer) error {
+					require.Contains(t, body.String(), tc.expectedBody)
+					require.Contains(t, body.String(), "#112233")
+					return nil
+				})
+
+			payload, err := msgpack.EncodeMsgPack(email.Message{
+				Email:          "owner@example.com",
+				Subject:        "Endpoint Status Update",
+				TemplateName:   email.TemplateEndpointUpdate,
+				OrganisationID: "org-1",
+				Params: map[string]string{
+					"name":            "payments",
+					"logo_url":        "",
+					"target_url":      "https://example.com/hook",
+					"failure_msg":     "connection refused",
+					"response_body":   "",
+					"failure_rate":    "10.00",
+					"status_code":     "502",
+					"endpoint_status": "inactive",
+				},
+			})
+			require.NoError(t, err)
+
+			processFn := ProcessEmails(sc, repo, log.New("convoy", log.LevelInfo))
+			require.NoError(t, processFn(context.Background(), asynq.NewTask(string(convoy.EmailProcessor), payload)))
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/filters"
 	"github.com/frain-dev/convoy/internal/pkg/dynamiceventack"
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/license"
@@ -26,9 +27,20 @@ import (
 
 // reasonNoMatchingSubscriptions is shown against a failed event in the
 // dashboard. It is deliberately static operator facing text, so no event
-// payload, URL, or credential can reach a user visible field.
+// payload, URL, or credential can reach a user visible field. When filters
+// turned the event away, noMatchReason adds the subscriptions and the filter
+// conditions that did, which come from the filters and not the event.
 const reasonNoMatchingSubscriptions = "no subscription matched this event"
 
+// maxExplainedRejections bounds how many filter rejections noMatchReason
+// evaluates and lists.
+const maxExplainedRejections = 3
+
+// maxRecordedRejections bounds how many filter rejections one event records,
+// so a project with many subscriptions does not write a row per subscription
+// for every event.
+const maxRecordedRejections = 100
+
 // reasonMissingEndpointID is shown against a failed event in the dashboard.
 // Failure policy: a NOT NULL insert with a null endpoint_id is a deterministic
 // validation failure. Persist Failure and return nil so the worker completes
@@ -104,6 +116,86 @@ type EventChannelSubResponse struct {
 	Subscriptions    []datastore.Subscription
 	IsDuplicateEvent bool
 	TargetURL        string
+
+	// filterRejections are the subscriptions whose filters turned the event away.
+	filterRejections []filterRejection
+}
+
+// filterRejection is a subscription whose filter did not match an event.
+type filterRejection struct {
+	subscriptionID string
+	filter         *datastore.EventTypeFilter
+}
+
+// noMatchReason is the failure reason for an event no subscription matched,
+// naming the filter condition that rejected it for the first few subscriptions.
+func noMatchReason(event *datastore.Event, rejections []filterRejection) string {
+	if len(rejections) == 0 {
+		return reasonNoMatchingSubscriptions
+	}
+
+	req := filterRejectionRequest(event)
+
+	notes := make([]string, 0, maxExplainedRejections+1)
+	for i, r := range rejections {
+		if i == maxExplainedRejections {
+			notes = append(notes, fmt.Sprintf("and %d more", len(rejections)-i))
+			break
+		}
+
+		notes = append(notes, fmt.Sprintf("subscription %s rejected on %s", r.subscriptionID, rejectionReason(req, r)))
+	}
+
+	return fmt.Sprintf("%s: %s", reasonNoMatchingSubscriptions, strings.Join(notes, "; "))
+}
+
+// recordFilterRejections stores the filter rejections of a matched event for
+// projects that opted in, whether or not another subscription received the
+// event. Failing to record is logged and never fails the event.
+func recordFilterRejections(ctx context.Context, repo datastore.FilterRejectionRepository, project *datastore.Project, event *datastore.Event, rejections []filterRejection, logger log.Logger) {
+	if repo == nil || len(rejections) == 0 || project == nil || project.Config == nil || !project.Config.RecordFilterRejections {
+		return
+	}
+
+	if len(rejections) > maxRecordedRejections {
+		rejections = rejections[:maxRecordedRejections]
+	}
+
+	req := filterRejectionRequest(event)
+	rows := make([]datastore.FilterRejection, 0, len(rejections))
+	for _, r := range rejections {
+		rows = append(rows, datastore.FilterRejection{
+			ProjectID:      project.UID,
+			EventID:        event.UID,
+			SubscriptionID: r.subscriptionID,
+			EventType:      string(event.EventType),
+			Reason:         rejectionReason(req, r),
+		})
+	}
+
+	if err := repo.CreateFilterRejections(ctx, rows); err != nil {
+		logger.ErrorContext(ctx, "failed to record filter rejections", "event_id", event.UID, "error", err)
+	}
+}
+
+func filterRejectionRequest(event *datastore.Event) datastore.FilterTestRequest {
+	var body interface{}
+	_ = json.Unmarshal(event.Data, &body)
+
+	return datastore.FilterTestRequest{
+		Body:    body,
+		Headers: event.GetRawHeaders(),
+		Query:   queryParamsToMap(event.URLQueryParams),
+		Path:    datastore.M{"path": event.URLPath},
+	}
+}
+
+func rejectionReason(req datastore.FilterTestRequest, r filterRejection) string {
+	reason := filters.Explain(req, r.filter).Reason
+	if reason == "" {
+		reason = "filter did not match"
+	}
+	return reason
 }
 
 type EventChannel interface {
@@ -194,9 +286,10 @@ func ProcessEventCreationByChannel(channel EventChannel, endpointRepo datastore.
 
 		jobId := queue.JobId{ProjectID: event.ProjectID, ResourceID: event.UID}.MatchSubsJobId()
 		job := &queue.Job{
-			ID:      jobId,
-			Payload: payload,
-			Delay:   0,
+			ID:        jobId,
+			Payload:   payload,
+			Delay:     0,
+			ProjectID: event.ProjectID,
 		}
 
 		err = eventQueue.Write(ctx, convoy.MatchEventSubscriptionsProcessor, convoy.EventWorkflowQueue, job)
@@ -217,6 +310,8 @@ type MatchSubscriptionsDeps struct {
 	EventQueue                 queue.Queuer
 	SubRepo                    datastore.SubscriptionRepository
 	FilterRepo                 datastore.FilterRepository
+	EventTypeVersionRepo       datastore.EventTypeVersionRepository
+	FilterRejectionRepo        datastore.FilterRejectionRepository
 	Licenser                   license.Licenser
 	OAuth2TokenService         OAuth2TokenService
 	FeatureFlag                *fflag.FFlag
@@ -286,11 +381,13 @@ func MatchSubscriptionsAndCreateEventDeliveries(deps MatchSubscriptionsDeps) fun
 			return nil
 		}
 
+		recordFilterRejections(ctx, deps.FilterRejectionRepo, subResponse.Project, event, subResponse.filterRejections, deps.Logger)
+
 		if len(subscriptions) < 1 {
 			err = &EndpointError{Err: fmt.Errorf("CODE: 1011, empty subscriptions via channel %s", cfg.Channel), delay: cfg.DefaultDelay}
 			deps.Logger.Error(fmt.Sprintf("failed to send %s: %v", event.UID, err))
 			tracer.AddEvent(ctx, tracer.EventEventSubscriptionMatchingError, attributes)
-			return deps.EventRepo.UpdateEventStatus(ctx, event, datastore.FailureStatus, reasonNoMatchingSubscriptions)
+			return deps.EventRepo.UpdateEventStatus(ctx, event, datastore.FailureStatus, noMatchReason(event, subResponse.filterRejections))
 		}
 
 		endpointIDs, err := collectAPIEndpointIDs(subscriptions)
@@ -325,6 +422,7 @@ func MatchSubscriptionsAndCreateEventDeliveries(deps MatchSubscriptionsDeps) fun
 			FeatureFlag:                deps.FeatureFlag,
 			FeatureFlagFetcher:         deps.FeatureFlagFetcher,
 			EarlyAdopterFeatureFetcher: deps.EarlyAdopterFeatureFetcher,
+			EventTypeVersionRepo:       deps.EventTypeVersionRepo,
 			Logger:                     deps.Logger,
 		})
 		if err != nil {
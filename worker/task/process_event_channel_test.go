@@ -141,6 +141,77 @@ func TestMatchSubscriptionsMissingEndpointIDFailsClosed(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMatchSubscriptionsRecordsFilterRejections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &EventChannelConfig{Channel: "dynamic", DefaultDelay: time.Second}
+	event := &datastore.Event{UID: "event-id-1", ProjectID: "project-id-1", Data: []byte(`{"amount": 50}`)}
+	project := &datastore.Project{UID: "project-id-1"}
+
+	payload, err := msgpack.EncodeMsgPack(EventChannelMetadata{Event: event, Config: cfg})
+	require.NoError(t, err)
+
+	want := "no subscription matched this event: subscription sub-id-1 rejected on body: amount $gte; " +
+		"subscription sub-id-2 rejected on expression: did not match"
+
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	eventRepo.EXPECT().UpdateEventStatus(gomock.Any(), event, datastore.FailureStatus, want).Return(nil)
+
+	fn := MatchSubscriptionsAndCreateEventDeliveries(MatchSubscriptionsDeps{
+		Channels: map[string]EventChannel{
+			cfg.Channel: &duplicateWithoutSubscriptionsChannel{
+				cfg: cfg,
+				response: &EventChannelSubResponse{
+					Event:   event,
+					Project: project,
+					filterRejections: []filterRejection{
+						{subscriptionID: "sub-id-1", filter: &datastore.EventTypeFilter{Body: datastore.M{"amount": map[string]interface{}{"$gte": 100}}}},
+						{subscriptionID: "sub-id-2", filter: &datastore.EventTypeFilter{Expression: "body.amount > 100"}},
+					},
+				},
+			},
+		},
+		EventRepo: eventRepo,
+		Logger:    log.New("convoy", log.LevelError),
+	})
+
+	err = fn(context.Background(), asynq.NewTask("match-subscriptions", payload))
+	require.NoError(t, err)
+}
+
+func TestRecordFilterRejections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := &datastore.Event{UID: "event-id-1", ProjectID: "project-id-1", EventType: "payment.created", Data: []byte(`{"amount": 50}`)}
+	rejections := []filterRejection{
+		{subscriptionID: "sub-id-1", filter: &datastore.EventTypeFilter{Body: datastore.M{"amount": map[string]interface{}{"$gte": 100}}}},
+	}
+	lo := log.New("convoy", log.LevelError)
+	repo := mocks.NewMockFilterRejectionRepository(ctrl)
+
+	// projects that did not opt in record nothing
+	projectCfg := datastore.DefaultProjectConfig
+	project := &datastore.Project{UID: "project-id-1", Config: &projectCfg}
+	recordFilterRejections(context.Background(), repo, project, event, rejections, lo)
+
+	// a matched event still records the subscriptions that rejected it
+	optedIn := projectCfg
+	optedIn.RecordFilterRejections = true
+	project.Config = &optedIn
+	repo.EXPECT().CreateFilterRejections(gomock.Any(), []datastore.FilterRejection{{
+		ProjectID:      "project-id-1",
+		EventID:        "event-id-1",
+		SubscriptionID: "sub-id-1",
+		EventType:      "payment.created",
+		Reason:         "body: amount $gte",
+	}}).Return(errors.New("database is down"))
+
+	// a failed write is logged, never returned
+	recordFilterRejections(context.Background(), repo, project, event, rejections, lo)
+}
+
 type taskErrorQueueStub struct {
 	lastError string
 	err       error
@@ -20,7 +20,9 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/dynamiceventack"
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/internal/pkg/license"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/tracer"
+	"github.com/frain-dev/convoy/pkg/celfilter"
 	"github.com/frain-dev/convoy/pkg/flatten"
 	log "github.com/frain-dev/convoy/pkg/logger"
 	"github.com/frain-dev/convoy/pkg/msgpack"
@@ -50,6 +52,7 @@ type CreateEventTaskParams struct {
 	CustomHeaders  map[string]string `json:"custom_headers"`
 	IdempotencyKey string            `json:"idempotency_key"`
 	AcknowledgedAt time.Time         `json:"acknowledged_at,omitempty"`
+	IsTest         bool              `json:"is_test,omitempty"`
 }
 
 type CreateEvent struct {
@@ -57,6 +60,9 @@ type CreateEvent struct {
 	Params             CreateEventTaskParams
 	Event              *datastore.Event
 	CreateSubscription bool
+
+	// EventTypeVersion is the event type version the publisher named, or 0.
+	EventTypeVersion int
 }
 
 type DefaultEventChannel struct {
@@ -143,7 +149,7 @@ func (d *DefaultEventChannel) CreateEvent(ctx context.Context, t *asynq.Task, ch
 
 	attributes["event.id"] = event.UID
 
-	err = updateEventMetadata(channel, event, createEvent.CreateSubscription, args.logger)
+	err = updateEventMetadata(channel, event, createEvent.CreateSubscription, createEvent.EventTypeVersion, args.logger)
 	if err != nil {
 		tracer.AddEvent(ctx, tracer.EventEventCreationError, attributes)
 		return nil, err
@@ -178,13 +184,19 @@ func (d *DefaultEventChannel) CreateEvent(ctx context.Context, t *asynq.Task, ch
 	return event, nil
 }
 
-func updateEventMetadata(channel EventChannel, event *datastore.Event, createSubscription bool, logger log.Logger) error {
+func updateEventMetadata(channel EventChannel, event *datastore.Event, createSubscription bool, eventTypeVersion int, logger log.Logger) error {
 	metadata := make(map[string]string)
 	metadata["channel"] = channel.GetConfig().Channel
 	metadata["delay"] = strconv.FormatInt(int64(channel.GetConfig().DefaultDelay), 10)
 	if createSubscription {
 		metadata["createSubscription"] = "true"
 	}
+	if eventTypeVersion > 0 {
+		metadata[datastore.EventTypeVersionMetadataKey] = strconv.Itoa(eventTypeVersion)
+	}
+	if event.IsTest {
+		metadata[datastore.TestEventMetadataKey] = "true"
+	}
 	m, err := json.Marshal(metadata)
 	if err != nil {
 		logger.Error("failed to marshal metadata for event", "error", err)
@@ -223,9 +235,10 @@ func (d *DefaultEventChannel) MatchSubscriptions(ctx context.Context, metadata E
 		}
 		cs := m["createSubscription"]
 		createSubscription = !util.IsStringEmpty(cs) && cs == "true"
+		event.IsTest = m[datastore.TestEventMetadataKey] == "true"
 	}
 
-	subscriptions, err := findSubscriptions(ctx, args.endpointRepo, args.subRepo, args.filterRepo, args.licenser, project, event, createSubscription, args.logger)
+	subscriptions, rejections, err := findSubscriptions(ctx, args.endpointRepo, args.subRepo, args.filterRepo, args.licenser, project, event, createSubscription, args.logger)
 	if err != nil {
 		return nil, &EndpointError{Err: err, delay: defaultDelay}
 	}
@@ -233,6 +246,7 @@ func (d *DefaultEventChannel) MatchSubscriptions(ctx context.Context, metadata E
 	response.Event = event
 	response.Project = project
 	response.Subscriptions = subscriptions
+	response.filterRejections = rejections
 	response.IsDuplicateEvent = event.IsDuplicateEvent
 
 	return &response, nil
@@ -273,13 +287,32 @@ type WriteEventDeliveriesToQueueOptions struct {
 	FeatureFlag                *fflag.FFlag
 	FeatureFlagFetcher         fflag.FeatureFlagFetcher
 	EarlyAdopterFeatureFetcher fflag.EarlyAdopterFeatureFetcher
+	EventTypeVersionRepo       datastore.EventTypeVersionRepository
 	Logger                     log.Logger
+
+	// ReplayJobID marks the deliveries as created by a replay job.
+	ReplayJobID string
 }
 
 func writeEventDeliveriesToQueue(ctx context.Context, opts WriteEventDeliveriesToQueueOptions) error {
 	ec := &EventDeliveryConfig{project: opts.Project}
+	mm := metrics.GetDPInstance(opts.Licenser)
 
 	eventDeliveries := make([]*datastore.EventDelivery, 0)
+	// discardReasons is only counted once the deliveries are written, so a
+	// creation that fails and is retried does not count its discards twice.
+	discardReasons := map[string]string{}
+
+	// Test events go out for real but are left out of the metrics.
+	if opts.Event.IsTest {
+		mm = metrics.Noop()
+	}
+
+	versioned, versionDiscards, err := versionedPayloads(ctx, opts.EventTypeVersionRepo, opts.Licenser, opts.Event, opts.Subscriptions, opts.Logger)
+	if err != nil {
+		return &EndpointError{Err: err, delay: 10 * time.Second}
+	}
+
 	for _, s := range opts.Subscriptions {
 		ec.subscription = &s
 		headers := opts.Event.Headers
@@ -330,15 +363,24 @@ func writeEventDeliveriesToQueue(ctx context.Context, opts WriteEventDeliveriesT
 		raw := string(opts.Event.Data)
 		data := opts.Event.Data
 
+		// A subscription pinned to an older event type version gets the
+		// payload downgraded first; its own function then runs on that.
+		if payload, ok := versioned[s.UID]; ok {
+			raw = string(payload)
+			data = payload
+		}
+
 		if s.Function.Ptr() != nil && !util.IsStringEmpty(s.Function.String) && opts.Licenser.Transformations() {
 			var payload map[string]interface{}
-			err = json.Unmarshal(opts.Event.Data, &payload)
+			err = json.Unmarshal(data, &payload)
 			if err != nil {
 				return &EndpointError{Err: err, delay: 10 * time.Second}
 			}
 
 			transformer := transform.NewTransformer()
+			transformStart := time.Now()
 			mutated, _, err := transformer.Transform(s.Function.String, payload)
+			mm.RecordEvaluationLatency(opts.Project.UID, metrics.EvaluationKindTransform, time.Since(transformStart))
 			if err != nil {
 				return &EndpointError{Err: err, delay: 10 * time.Second}
 			}
@@ -359,11 +401,24 @@ func writeEventDeliveriesToQueue(ctx context.Context, opts WriteEventDeliveriesT
 			NextSendTime:    time.Now(),
 			IntervalSeconds: rc.Duration,
 			RetryLimit:      rc.RetryCount,
+			ReplayJobID:     opts.ReplayJobID,
+			Test:            opts.Event.IsTest,
+		}
+
+		// A test event is sent once; a failure is reported back instead of retried.
+		if metadata.Test {
+			metadata.RetryLimit = 1
 		}
 
 		deliveryStatus := getEventDeliveryStatus(ctx, &s, s.Endpoint, opts.Logger)
+		discardReason := metrics.DiscardReasonEndpointInactive
 		if authUnavailable {
 			deliveryStatus = datastore.DiscardedEventStatus
+			discardReason = metrics.DiscardReasonAuthUnavailable
+		}
+		if reason, ok := versionDiscards[s.UID]; ok {
+			deliveryStatus = datastore.DiscardedEventStatus
+			discardReason = reason
 		}
 
 		eventDelivery := &datastore.EventDelivery{
@@ -392,14 +447,22 @@ func writeEventDeliveriesToQueue(ctx context.Context, opts WriteEventDeliveriesT
 			}
 		}
 
+		if deliveryStatus == datastore.DiscardedEventStatus {
+			discardReasons[eventDelivery.UID] = discardReason
+		}
+
 		eventDeliveries = append(eventDeliveries, eventDelivery)
 	}
 
-	err := opts.EventDeliveryRepo.CreateEventDeliveries(ctx, eventDeliveries)
+	err = opts.EventDeliveryRepo.CreateEventDeliveries(ctx, eventDeliveries)
 	if err != nil {
 		return &EndpointError{Err: fmt.Errorf("CODE: 1008, err: %s", err.Error()), delay: defaultDelay}
 	}
 
+	for _, reason := range discardReasons {
+		mm.IncrementDiscarded(opts.Project.UID, reason)
+	}
+
 	subscriptionByID := make(map[string]datastore.Subscription, len(opts.Subscriptions))
 	for _, subscription := range opts.Subscriptions {
 		subscriptionByID[subscription.UID] = subscription
@@ -423,8 +486,9 @@ func writeEventDeliveriesToQueue(ctx context.Context, opts WriteEventDeliveriesT
 			}
 
 			job := &queue.Job{
-				ID:      eventDelivery.UID,
-				Payload: data,
+				ID:        eventDelivery.UID,
+				Payload:   data,
+				ProjectID: eventDelivery.ProjectID,
 			}
 
 			if s.Type == datastore.SubscriptionTypeAPI {
@@ -441,10 +505,16 @@ func writeEventDeliveriesToQueue(ctx context.Context, opts WriteEventDeliveriesT
 
 func findSubscriptions(ctx context.Context, endpointRepo datastore.EndpointRepository,
 	subRepo datastore.SubscriptionRepository, filterRepo datastore.FilterRepository, licenser license.Licenser, project *datastore.Project, event *datastore.Event, shouldCreateSubscription bool, logger log.Logger,
-) ([]datastore.Subscription, error) {
+) ([]datastore.Subscription, []filterRejection, error) {
 	var subscriptions []datastore.Subscription
+	var rejections []filterRejection
 	var err error
 
+	if event.IsTest {
+		subscriptions, err = testEventSubscriptions(ctx, subRepo, filterRepo, project, event)
+		return subscriptions, nil, err
+	}
+
 	switch project.Type {
 	case datastore.OutgoingProject:
 		for _, endpointID := range event.Endpoints {
@@ -452,19 +522,19 @@ func findSubscriptions(ctx context.Context, endpointRepo datastore.EndpointRepos
 
 			endpoint, err = endpointRepo.FindEndpointByID(ctx, endpointID, project.UID)
 			if err != nil {
-				return subscriptions, &EndpointError{Err: err, delay: defaultDelay}
+				return subscriptions, nil, &EndpointError{Err: err, delay: defaultDelay}
 			}
 
 			subs, innerErr := subRepo.FindSubscriptionsByEndpointID(ctx, project.UID, endpoint.UID)
 			if innerErr != nil {
-				return subscriptions, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for event type"), delay: defaultDelay}
+				return subscriptions, nil, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for event type"), delay: defaultDelay}
 			}
 
 			if len(subs) == 0 && shouldCreateSubscription {
 				genSubs := generateSubscription(project, endpoint)
 				createSubErr := subRepo.CreateSubscription(ctx, project.UID, genSubs)
 				if createSubErr != nil {
-					return subscriptions, &EndpointError{Err: fmt.Errorf("error creating subscription for endpoint: %v", createSubErr), delay: defaultDelay}
+					return subscriptions, nil, &EndpointError{Err: fmt.Errorf("error creating subscription for endpoint: %v", createSubErr), delay: defaultDelay}
 				}
 
 				subscriptions = append(subscriptions, *genSubs)
@@ -476,46 +546,79 @@ func findSubscriptions(ctx context.Context, endpointRepo datastore.EndpointRepos
 
 			matchedSubs, innerErr := matchSubscriptions(ctx, string(event.EventType), subs, filterRepo)
 			if innerErr != nil {
-				return subscriptions, &EndpointError{Err: fmt.Errorf("error matching subscriptions for event type: %v", innerErr), delay: defaultDelay}
+				return subscriptions, nil, &EndpointError{Err: fmt.Errorf("error matching subscriptions for event type: %v", innerErr), delay: defaultDelay}
 			}
 
-			matchedSubs, innerErr = matchSubscriptionsUsingFilter(ctx, event, subRepo, filterRepo, licenser, matchedSubs, false, logger)
+			matchedSubs, rejected, innerErr := matchSubscriptionsUsingFilter(ctx, event, subRepo, filterRepo, licenser, matchedSubs, false, logger)
 			if innerErr != nil {
-				return subscriptions, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for event type: %v", innerErr), delay: defaultDelay}
+				return subscriptions, nil, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for event type: %v", innerErr), delay: defaultDelay}
 			}
 
 			subscriptions = append(subscriptions, matchedSubs...)
+			rejections = append(rejections, rejected...)
 		}
 	case datastore.IncomingProject:
 		subscriptions, err = subRepo.FindSubscriptionsBySourceID(ctx, project.UID, event.SourceID)
 		if err != nil {
-			return nil, &EndpointError{Err: err, delay: defaultDelay}
+			return nil, nil, &EndpointError{Err: err, delay: defaultDelay}
 		}
 
 		if len(subscriptions) > 0 {
 			matchedSubs, innerErr := matchSubscriptions(ctx, string(event.EventType), subscriptions, filterRepo)
 			if innerErr != nil {
-				return subscriptions, &EndpointError{Err: fmt.Errorf("error matching subscriptions for event type: %v", innerErr), delay: defaultDelay}
+				return subscriptions, nil, &EndpointError{Err: fmt.Errorf("error matching subscriptions for event type: %v", innerErr), delay: defaultDelay}
 			}
 
-			matchedSubs, innerErr = matchSubscriptionsUsingFilter(ctx, event, subRepo, filterRepo, licenser, matchedSubs, false, logger)
+			matchedSubs, rejections, innerErr = matchSubscriptionsUsingFilter(ctx, event, subRepo, filterRepo, licenser, matchedSubs, false, logger)
 			if innerErr != nil {
-				return subscriptions, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for event type: %v", innerErr), delay: defaultDelay}
+				return subscriptions, nil, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for event type: %v", innerErr), delay: defaultDelay}
 			}
 
 			subscriptions = matchedSubs
 		}
 	}
 
+	return subscriptions, rejections, nil
+}
+
+// testEventSubscriptions picks the subscription a test event is delivered
+// through: one per endpoint, preferring a subscription to the event's type,
+// with filters ignored, since the test is meant to reach the endpoint.
+// Deliveries must belong to a subscription, so an endpoint without one gets
+// nothing.
+func testEventSubscriptions(ctx context.Context, subRepo datastore.SubscriptionRepository, filterRepo datastore.FilterRepository, project *datastore.Project, event *datastore.Event) ([]datastore.Subscription, error) {
+	var subscriptions []datastore.Subscription
+	for _, endpointID := range event.Endpoints {
+		subs, err := subRepo.FindSubscriptionsByEndpointID(ctx, project.UID, endpointID)
+		if err != nil {
+			return nil, &EndpointError{Err: fmt.Errorf("error fetching subscriptions for endpoint: %v", err), delay: defaultDelay}
+		}
+		if len(subs) == 0 {
+			continue
+		}
+
+		matched, err := matchSubscriptions(ctx, string(event.EventType), subs, filterRepo)
+		if err != nil {
+			return nil, &EndpointError{Err: fmt.Errorf("error matching subscriptions for event type: %v", err), delay: defaultDelay}
+		}
+
+		if len(matched) > 0 {
+			subscriptions = append(subscriptions, matched[0])
+		} else {
+			subscriptions = append(subscriptions, subs[0])
+		}
+	}
+
 	return subscriptions, nil
 }
 
-func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subRepo datastore.SubscriptionRepository, filterRepo datastore.FilterRepository, licenser license.Licenser, subscriptions []datastore.Subscription, soft bool, logger log.Logger) ([]datastore.Subscription, error) {
+func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subRepo datastore.SubscriptionRepository, filterRepo datastore.FilterRepository, licenser license.Licenser, subscriptions []datastore.Subscription, soft bool, logger log.Logger) ([]datastore.Subscription, []filterRejection, error) {
 	if !licenser.AdvancedSubscriptions() {
-		return subscriptions, nil
+		return subscriptions, nil, nil
 	}
 
 	var matched []datastore.Subscription
+	var rejections []filterRejection
 
 	// payload is interface{} and not map[string]interface{} because
 	// map[string]interface{} won't work for array based json e.g:
@@ -530,16 +633,17 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 	var payload interface{}
 	err := json.Unmarshal(e.Data, &payload) // TODO(all): find a way to stop doing this repeatedly, json.Unmarshal is slow and costly
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	flatPayload, err := flatten.Flatten(payload)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	headers := e.GetRawHeaders()
 	queryParams := queryParamsToMap(e.URLQueryParams)
+	mm := metrics.GetDPInstance(licenser)
 	path := datastore.M{"path": e.URLPath}
 
 	for i := range subscriptions {
@@ -554,7 +658,7 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 			continue
 		} else if innerErr != nil && innerErr.Error() != datastore.ErrFilterNotFound.Error() {
 			logger.ErrorContext(ctx, "fiter not found", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID)
-			return nil, innerErr
+			return nil, nil, innerErr
 		}
 
 		// If no specific filter found, try to find a catch-all filter
@@ -573,7 +677,7 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 				continue
 			} else if innerErr != nil && !errors.Is(innerErr, datastore.ErrFilterNotFound) {
 				logger.ErrorContext(ctx, "catch-all filter not found", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID)
-				return nil, innerErr
+				return nil, nil, innerErr
 			} else if errors.Is(innerErr, datastore.ErrFilterNotFound) {
 				if !hadExactFilter {
 					matched = append(matched, *sub)
@@ -587,19 +691,40 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 		}
 
 		// If filter has no conditions, match the subscription
-		if len(filter.Body) == 0 && len(filter.Headers) == 0 && len(filter.Query) == 0 && len(filter.Path) == 0 {
+		if !filter.HasConditions() {
 			matched = append(matched, *sub)
 			logger.DebugContext(ctx, "subscription event type matched passed", "event.id", e.UID, "subscription.id", sub.UID)
 			continue
 		}
 
+		evaluationStart := time.Now()
+		if filter.Expression != "" {
+			isMatched, innerErr := celfilter.Match(filter.Expression, celfilter.Input{Body: payload, Headers: headers, Query: queryParams, Path: e.URLPath})
+			if innerErr != nil && soft {
+				logger.ErrorContext(ctx, "subscription failed to match expression", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
+				continue
+			} else if innerErr != nil {
+				logger.ErrorContext(ctx, "subscription failed to match expression", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
+				return nil, nil, innerErr
+			}
+			mm.RecordEvaluationLatency(e.ProjectID, metrics.EvaluationKindFilter, time.Since(evaluationStart))
+
+			if isMatched {
+				matched = append(matched, *sub)
+				logger.DebugContext(ctx, "subscription filter matched passed", "event.id", e.UID, "subscription.id", sub.UID)
+			} else {
+				rejections = append(rejections, filterRejection{subscriptionID: sub.UID, filter: filter})
+			}
+			continue
+		}
+
 		isBodyMatched, innerErr := subRepo.CompareFlattenedPayload(ctx, flatPayload, filter.Body, true)
 		if innerErr != nil && soft {
 			logger.ErrorContext(ctx, "subscription failed to match body", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
 			continue
 		} else if innerErr != nil {
 			logger.ErrorContext(ctx, "subscription failed to match body", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
-			return nil, innerErr
+			return nil, nil, innerErr
 		}
 
 		isHeaderMatched, innerErr := compareFilterScope(ctx, subRepo, headers, filter.Headers)
@@ -608,7 +733,7 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 			continue
 		} else if innerErr != nil {
 			logger.ErrorContext(ctx, "subscription failed to match header", "error", innerErr, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
-			return nil, innerErr
+			return nil, nil, innerErr
 		}
 
 		isQueryMatched, innerErr := compareFilterScope(ctx, subRepo, queryParams, filter.Query)
@@ -617,7 +742,7 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 			continue
 		} else if innerErr != nil {
 			logger.ErrorContext(ctx, "subscription failed to match query", "error", errSubscriptionFilterComparisonFailed, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
-			return nil, errSubscriptionFilterComparisonFailed
+			return nil, nil, errSubscriptionFilterComparisonFailed
 		}
 
 		isPathMatched, innerErr := compareFilterScope(ctx, subRepo, path, filter.Path)
@@ -626,19 +751,22 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e *datastore.Event, subR
 			continue
 		} else if innerErr != nil {
 			logger.ErrorContext(ctx, "subscription failed to match path", "error", errSubscriptionFilterComparisonFailed, "event.id", e.UID, "subscription.id", sub.UID, "soft", soft)
-			return nil, errSubscriptionFilterComparisonFailed
+			return nil, nil, errSubscriptionFilterComparisonFailed
 		}
 
 		isMatched := isHeaderMatched && isBodyMatched && isQueryMatched && isPathMatched
+		mm.RecordEvaluationLatency(e.ProjectID, metrics.EvaluationKindFilter, time.Since(evaluationStart))
 
 		if isMatched {
 			matched = append(matched, *sub)
 
 			logger.DebugContext(ctx, "subscription filter matched passed", "event.id", e.UID, "subscription.id", sub.UID)
+		} else {
+			rejections = append(rejections, filterRejection{subscriptionID: sub.UID, filter: filter})
 		}
 	}
 
-	return matched, nil
+	return matched, rejections, nil
 }
 
 func compareFilterScope(ctx context.Context, subRepo datastore.SubscriptionRepository, payload, filter datastore.M) (bool, error) {
@@ -795,6 +923,7 @@ func buildEvent(ctx context.Context, eventRepo datastore.EventRepository, endpoi
 		Endpoints:        endpointIDs,
 		SourceID:         eventParams.SourceID,
 		ProjectID:        project.UID,
+		IsTest:           eventParams.IsTest,
 	}
 
 	if (project.Config == nil || project.Config.Strategy == nil) ||
@@ -1027,6 +1027,42 @@ func TestMatchSubscriptionsUsingFilter(t *testing.T) {
 			inputSubs: []datastore.Subscription{{UID: "123"}},
 			wantSubs:  []datastore.Subscription{},
 		},
+		{
+			name:      "Expression filter matches",
+			eventType: "invoice.created",
+			payload: map[string]interface{}{
+				"amount": 250,
+				"items":  []interface{}{map[string]interface{}{"sku": "b-2"}},
+			},
+			path: "/webhooks/invoices",
+			dbFn: func(args *testArgs) {
+				fe, _ := args.filterRepo.(*mocks.MockFilterRepository)
+				fe.EXPECT().FindFilterBySubscriptionAndEventType(gomock.Any(), "123", "invoice.created").
+					Return(&datastore.EventTypeFilter{Expression: `body.amount > 100 && body.items.exists(i, i.sku.startsWith("b-")) && path.endsWith("/invoices")`}, nil)
+
+				licenser, _ := args.licenser.(*mocks.MockLicenser)
+				licenser.EXPECT().AdvancedSubscriptions().Times(1).Return(true)
+			},
+			inputSubs: []datastore.Subscription{{UID: "123"}},
+			wantSubs:  []datastore.Subscription{{UID: "123"}},
+		},
+		{
+			name:      "Expression filter does not match",
+			eventType: "invoice.created",
+			payload: map[string]interface{}{
+				"amount": 50,
+			},
+			dbFn: func(args *testArgs) {
+				fe, _ := args.filterRepo.(*mocks.MockFilterRepository)
+				fe.EXPECT().FindFilterBySubscriptionAndEventType(gomock.Any(), "123", "invoice.created").
+					Return(&datastore.EventTypeFilter{Expression: `body.amount > 100`}, nil)
+
+				licenser, _ := args.licenser.(*mocks.MockLicenser)
+				licenser.EXPECT().AdvancedSubscriptions().Times(1).Return(true)
+			},
+			inputSubs: []datastore.Subscription{{UID: "123"}},
+			wantSubs:  []datastore.Subscription{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1057,7 +1093,7 @@ func TestMatchSubscriptionsUsingFilter(t *testing.T) {
 				URLPath:        tt.path,
 			}
 
-			subs, err := matchSubscriptionsUsingFilter(context.Background(), event, args.subRepo, args.filterRepo, args.licenser, tt.inputSubs, false, logger.New("test", logger.LevelInfo))
+			subs, _, err := matchSubscriptionsUsingFilter(context.Background(), event, args.subRepo, args.filterRepo, args.licenser, tt.inputSubs, false, logger.New("test", logger.LevelInfo))
 			if tt.wantErr {
 				require.NotNil(t, err)
 				return
@@ -1102,7 +1138,7 @@ func TestFindSubscriptionsProvisionsCatchAllForEveryEndpoint(t *testing.T) {
 			return nil
 		})
 
-	subs, err := findSubscriptions(context.Background(), args.endpointRepo, args.subRepo, args.filterRepo, args.licenser, project, event, true, logger.New("test", logger.LevelInfo))
+	subs, _, err := findSubscriptions(context.Background(), args.endpointRepo, args.subRepo, args.filterRepo, args.licenser, project, event, true, logger.New("test", logger.LevelInfo))
 
 	require.NoError(t, err)
 	require.Len(t, subs, 2)
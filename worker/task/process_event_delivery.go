@@ -108,10 +108,11 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 			}
 
 			job := &queue.Job{
-				Payload:  t.Payload(),
-				Delay:    delayDuration,
-				ID:       data.EventDeliveryID,
-				MaxRetry: retryLimit,
+				Payload:   t.Payload(),
+				Delay:     delayDuration,
+				ID:        data.EventDeliveryID,
+				MaxRetry:  retryLimit,
+				ProjectID: data.ProjectID,
 			}
 
 			// write it to the retry queue.
@@ -133,6 +134,8 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 		attributes["event_delivery.id"] = data.EventDeliveryID
 		attributes["project.id"] = data.ProjectID
 
+		mm := metrics.GetDPInstance(deps.Licenser)
+
 		cfg, err := config.Get()
 		if err != nil {
 			tracer.AddEvent(ctx, tracer.EventEventDeliveryError, attributes)
@@ -146,6 +149,11 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 		}
 		eventDelivery.Metadata.MaxRetrySeconds = cfg.MaxRetrySeconds
 
+		// Test deliveries are sent for real but are left out of the metrics.
+		if eventDelivery.Metadata.Test {
+			mm = metrics.Noop()
+		}
+
 		// Sync the retry-queue task's asynq retry budget with the configured retry
 		// limit so large limits are not silently capped at asynq's default of 25.
 		// We only ever raise the budget, never lower it: limits at or below the
@@ -171,6 +179,7 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 				if updErr := deps.EventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, eventDelivery.ProjectID, *eventDelivery, datastore.DiscardedEventStatus); updErr != nil {
 					deps.Logger.ErrorContext(ctx, "failed to update event delivery status to discarded", "error", updErr)
 				}
+				mm.IncrementDiscarded(eventDelivery.ProjectID, metrics.DiscardReasonProjectNotFound)
 				tracer.AddEvent(ctx, tracer.EventEventDeliveryError, attributes)
 				return nil
 			}
@@ -190,6 +199,7 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 				if err != nil {
 					deps.Logger.ErrorContext(ctx, "failed to update event delivery status to discarded", "error", err)
 				}
+				mm.IncrementDiscarded(project.UID, metrics.DiscardReasonEndpointNotFound)
 
 				tracer.AddEvent(ctx, tracer.EventEventDeliveryError, attributes)
 				return nil
@@ -258,6 +268,7 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 			}
 
 			deps.Logger.DebugContext(ctx, "endpoint is inactive, failing to send", "endpoint_url", endpoint.Url)
+			mm.IncrementDiscarded(project.UID, metrics.DiscardReasonEndpointInactive)
 			tracer.AddEvent(ctx, tracer.EventEventDeliveryDiscarded, attributes)
 			return nil
 		}
@@ -278,6 +289,7 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 					tracer.AddEvent(ctx, tracer.EventEventDeliveryError, attributes)
 					return &DeliveryError{Err: updateErr}
 				}
+				mm.IncrementDiscarded(project.UID, metrics.DiscardReasonUnresolvedTargetURL)
 				tracer.AddEvent(ctx, tracer.EventEventDeliveryDiscarded, attributes)
 				return nil
 			}
@@ -422,6 +434,7 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 		}
 
 		duration := time.Since(httpDispatchStart)
+		mm.RecordDeliveryAttempt(project, endpoint.UID, statusCode, responseReceivedAt.Sub(requestSentAt))
 		// log request details
 		logAttrs := []any{"status", status, "uri", targetURL, "method", convoy.HttpPost, "duration", duration, "eventDeliveryID", eventDelivery.UID}
 
@@ -434,7 +447,6 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 			eventDelivery.LatencySeconds = time.Since(eventDelivery.GetLatencyStartTime()).Seconds()
 
 			// register latency
-			mm := metrics.GetDPInstance(deps.Licenser)
 			mm.RecordEndToEndLatency(eventDelivery)
 		} else {
 			deps.Logger.ErrorContext(ctx, "event delivery http error", append(logAttrs, "event_delivery_uid", eventDelivery.UID)...)
@@ -510,7 +522,8 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 				eventDelivery.Status = datastore.FailureEventStatus
 			}
 
-			if retryLimitOwnsEndpointDisable(ctx, deps.Licenser, deps.CBEnablement, project) {
+			// A failed test event must not take a live endpoint down.
+			if !eventDelivery.Metadata.Test && retryLimitOwnsEndpointDisable(ctx, deps.Licenser, deps.CBEnablement, project) {
 				endpointStatus := datastore.InactiveEndpointStatus
 
 				statusChanged, err := deps.EndpointRepo.UpdateEndpointStatus(ctx, project.UID, endpoint.UID, endpointStatus)
@@ -544,6 +557,8 @@ func ProcessEventDelivery(deps EventDeliveryProcessorDeps) func(context.Context,
 		}
 
 		if !done && eventDelivery.Metadata.NumTrials < eventDelivery.Metadata.RetryLimit {
+			mm.IncrementRetriesScheduled(project, endpoint.UID)
+
 			errS := "nil"
 			if err != nil {
 				errS = err.Error()
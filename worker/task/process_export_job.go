@@ -0,0 +1,132 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	blobstore "github.com/frain-dev/convoy/internal/pkg/blob-store"
+	"github.com/frain-dev/convoy/internal/pkg/search_export"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+)
+
+// ExportJobPayload is the queue payload that starts an export job.
+type ExportJobPayload struct {
+	ProjectID   string
+	ExportJobID string
+}
+
+// ProcessExportJob streams the rows an export job's search matches to the
+// blob store configured for the instance, under
+// exports/<project id>/<job id>.<format>.
+func ProcessExportJob(
+	configRepo datastore.ConfigurationRepository,
+	projectRepo datastore.ProjectRepository,
+	exportJobRepo datastore.ExportJobRepository,
+	eventRepo datastore.EventRepository,
+	eventDeliveryRepo datastore.EventDeliveryRepository,
+	logger log.Logger,
+) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload ExportJobPayload
+		err := msgpack.DecodeMsgPack(t.Payload(), &payload)
+		if err != nil {
+			logger.Error("failed to unmarshal export job payload", "error", err)
+			return err
+		}
+
+		job, err := exportJobRepo.FindExportJobByID(ctx, payload.ProjectID, payload.ExportJobID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrExportJobNotFound) {
+				logger.Warn("export job not found", "export_job_id", payload.ExportJobID)
+				return nil
+			}
+			return err
+		}
+
+		if job.Status == datastore.ExportJobStatusCompleted || job.Status == datastore.ExportJobStatusFailed {
+			return nil
+		}
+
+		project, err := projectRepo.FetchProjectByID(ctx, job.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		job.Status = datastore.ExportJobStatusProcessing
+		if err = exportJobRepo.UpdateExportJob(ctx, job); err != nil {
+			return err
+		}
+
+		fail := func(cause error) error {
+			logger.ErrorContext(ctx, "export job failed", "export_job_id", job.UID, "error", cause)
+
+			job.Status = datastore.ExportJobStatusFailed
+			job.Error = cause.Error()
+			job.CompletedAt = null.TimeFrom(time.Now())
+			if err := exportJobRepo.UpdateExportJob(ctx, job); err != nil {
+				return errors.Join(cause, err)
+			}
+			return cause
+		}
+
+		dbConfig, err := configRepo.LoadConfiguration(ctx)
+		if err != nil {
+			return fail(fmt.Errorf("load configuration: %w", err))
+		}
+
+		store, err := blobstore.NewBlobStoreClient(dbConfig.StoragePolicy, logger)
+		if err != nil {
+			return fail(fmt.Errorf("create blob store: %w", err))
+		}
+
+		key := fmt.Sprintf("exports/%s/%s.%s", project.UID, job.UID, job.Format)
+		rows, err := streamSearchExport(ctx, store, key, search_export.New(eventRepo, eventDeliveryRepo), project, job)
+		if err != nil {
+			return fail(err)
+		}
+
+		job.Status = datastore.ExportJobStatusCompleted
+		job.RowCount = rows
+		job.ObjectKey = key
+		job.CompletedAt = null.TimeFrom(time.Now())
+		return exportJobRepo.UpdateExportJob(ctx, job)
+	}
+}
+
+// streamSearchExport pipes the export straight into the upload, so the file
+// is never written to local disk.
+func streamSearchExport(ctx context.Context, store blobstore.BlobStore, key string, exporter *search_export.Exporter, project *datastore.Project, job *datastore.ExportJob) (int64, error) {
+	pr, pw := io.Pipe()
+
+	var rows int64
+	errCh := make(chan error, 1)
+
+	go func() {
+		n, exportErr := exporter.Export(ctx, project, job.Resource, job.Format, job.Filter, pw)
+		rows = n
+		pw.CloseWithError(exportErr)
+		errCh <- exportErr
+	}()
+
+	uploadErr := store.Upload(ctx, key, pr)
+	// Unblock the exporter if the upload stopped reading early.
+	pr.CloseWithError(uploadErr)
+	exportErr := <-errCh
+
+	if uploadErr != nil {
+		return 0, fmt.Errorf("upload %q: %w", key, uploadErr)
+	}
+	if exportErr != nil {
+		return 0, fmt.Errorf("export %q: %w", key, exportErr)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+)
+
+func exportJobTask(t *testing.T, job *datastore.ExportJob) *asynq.Task {
+	t.Helper()
+
+	payload, err := msgpack.EncodeMsgPack(ExportJobPayload{ProjectID: job.ProjectID, ExportJobID: job.UID})
+	require.NoError(t, err)
+
+	return asynq.NewTask("export-job", payload)
+}
+
+func newExportJob() *datastore.ExportJob {
+	return &datastore.ExportJob{
+		UID:       "job-id-1",
+		ProjectID: "project-id-1",
+		Resource:  datastore.SearchResourceEvents,
+		Format:    datastore.ExportFormatJSONL,
+		Status:    datastore.ExportJobStatusPending,
+		Filter: datastore.SearchFilter{
+			StartDate: time.Now().Add(-time.Hour),
+			EndDate:   time.Now(),
+		},
+	}
+}
+
+func TestProcessExportJobWritesToBlobStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	job := newExportJob()
+
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	exportJobRepo := mocks.NewMockExportJobRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+
+	exportJobRepo.EXPECT().FindExportJobByID(gomock.Any(), job.ProjectID, job.UID).Return(job, nil)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), job.ProjectID).Return(&datastore.Project{UID: job.ProjectID}, nil)
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Return(&datastore.Configuration{
+		StoragePolicy: &datastore.StoragePolicyConfiguration{
+			Type:   datastore.OnPrem,
+			OnPrem: &datastore.OnPremStorage{Path: null.StringFrom(dir)},
+		},
+	}, nil)
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), job.ProjectID, gomock.Any()).Return([]datastore.Event{
+		{UID: "event-id-1", EventType: "invoice.paid"},
+		{UID: "event-id-2", EventType: "invoice.paid"},
+	}, datastore.PaginationData{}, nil)
+
+	var statuses []datastore.ExportJobStatus
+	exportJobRepo.EXPECT().UpdateExportJob(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, j *datastore.ExportJob) error {
+			statuses = append(statuses, j.Status)
+			return nil
+		})
+
+	fn := ProcessExportJob(configRepo, projectRepo, exportJobRepo, eventRepo, nil, log.New("convoy", log.LevelInfo))
+	require.NoError(t, fn(context.Background(), exportJobTask(t, job)))
+
+	require.Equal(t, []datastore.ExportJobStatus{datastore.ExportJobStatusProcessing, datastore.ExportJobStatusCompleted}, statuses)
+	require.Equal(t, int64(2), job.RowCount)
+	require.Equal(t, "exports/project-id-1/job-id-1.jsonl", job.ObjectKey)
+
+	data, err := os.ReadFile(filepath.Join(dir, job.ObjectKey))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"uid":"event-id-2"`)
+}
+
+func TestProcessExportJobFailsJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newExportJob()
+
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	exportJobRepo := mocks.NewMockExportJobRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+
+	exportJobRepo.EXPECT().FindExportJobByID(gomock.Any(), job.ProjectID, job.UID).Return(job, nil)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), job.ProjectID).Return(&datastore.Project{UID: job.ProjectID}, nil)
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Return(&datastore.Configuration{
+		StoragePolicy: &datastore.StoragePolicyConfiguration{
+			Type:   datastore.OnPrem,
+			OnPrem: &datastore.OnPremStorage{Path: null.StringFrom(t.TempDir())},
+		},
+	}, nil)
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), job.ProjectID, gomock.Any()).
+		Return(nil, datastore.PaginationData{}, errors.New("statement timeout"))
+	exportJobRepo.EXPECT().UpdateExportJob(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	fn := ProcessExportJob(configRepo, projectRepo, exportJobRepo, eventRepo, nil, log.New("convoy", log.LevelInfo))
+	require.Error(t, fn(context.Background(), exportJobTask(t, job)))
+
+	require.Equal(t, datastore.ExportJobStatusFailed, job.Status)
+	require.Contains(t, job.Error, "statement timeout")
+	require.True(t, job.CompletedAt.Valid)
+}
+
+func TestProcessExportJobIgnoresFinishedJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := newExportJob()
+	job.Status = datastore.ExportJobStatusCompleted
+
+	exportJobRepo := mocks.NewMockExportJobRepository(ctrl)
+	exportJobRepo.EXPECT().FindExportJobByID(gomock.Any(), job.ProjectID, job.UID).Return(job, nil)
+
+	fn := ProcessExportJob(nil, nil, exportJobRepo, nil, nil, log.New("convoy", log.LevelInfo))
+	require.NoError(t, fn(context.Background(), exportJobTask(t, job)))
+}
@@ -0,0 +1,167 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/frain-dev/convoy/config/algo"
+	"github.com/frain-dev/convoy/datastore"
+	notification "github.com/frain-dev/convoy/internal/notifications"
+	"github.com/frain-dev/convoy/util"
+)
+
+var ErrInvalidWebhookNotificationPayload = errors.New("invalid webhook notification payload")
+var ErrWebhookNotificationFailed = errors.New("failed to post webhook notification")
+var ErrInvalidPagerDutyPayload = errors.New("invalid pagerduty payload")
+var ErrPagerDutyRequestFailed = errors.New("failed to send pagerduty event")
+var ErrInvalidOpsgeniePayload = errors.New("invalid opsgenie payload")
+var ErrOpsgenieRequestFailed = errors.New("failed to send opsgenie alert")
+
+const (
+	pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+	opsgenieAPIURL   = "https://api.opsgenie.com"
+	opsgenieEUAPIURL = "https://api.eu.opsgenie.com"
+
+	// webhookNotificationSignatureHeader carries the generic webhook signature,
+	// in the t=<unix>,v1=<hex> form of Convoy's advanced signatures, so a
+	// receiver that already verifies Convoy deliveries can reuse its verifier.
+	webhookNotificationSignatureHeader = "X-Convoy-Signature"
+)
+
+// opsgenieBaseURL maps an Opsgenie channel region onto its API host.
+func opsgenieBaseURL(region string) string {
+	if region == datastore.OpsgenieRegionEU {
+		return opsgenieEUAPIURL
+	}
+	return opsgenieAPIURL
+}
+
+// postWebhookNotification POSTs an alert to a generic webhook channel, signed
+// with the channel secret over "<timestamp>,<body>".
+//
+// Like postTeamsCard, no error returned here may carry webhookURL or the
+// secret: the URL is user-supplied and may embed a token.
+func postWebhookNotification(ctx context.Context, client *http.Client, webhookURL, secret string, alert notification.WebhookAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook alert: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac, err := util.ComputeJSONHmac(algo.SHA256, timestamp+","+string(body), secret, false)
+	if err != nil {
+		return fmt.Errorf("%w: could not sign payload", ErrWebhookNotificationFailed)
+	}
+
+	header := http.Header{}
+	header.Set(webhookNotificationSignatureHeader, fmt.Sprintf("t=%s,v1=%s", timestamp, mac))
+
+	return postIncidentJSON(ctx, client, webhookURL, header, body, ErrWebhookNotificationFailed)
+}
+
+// postPagerDutyEvent sends a trigger or resolve to the PagerDuty Events API v2.
+// The routing key sits in the body, so failures report only a status code or a
+// transport category.
+func postPagerDutyEvent(ctx context.Context, client *http.Client, eventsURL string, n notification.PagerDutyNotification) error {
+	body, err := json.Marshal(notification.BuildPagerDutyEvent(n))
+	if err != nil {
+		return fmt.Errorf("failed to encode pagerduty event: %w", err)
+	}
+
+	return postIncidentJSON(ctx, client, eventsURL, http.Header{}, body, ErrPagerDutyRequestFailed)
+}
+
+// postOpsgenieAlert creates an Opsgenie alert aliased to the dedup key, or
+// closes the alert holding that alias. Closing an alert that is already closed
+// or was never created is accepted by Opsgenie and processed asynchronously.
+func postOpsgenieAlert(ctx context.Context, client *http.Client, baseURL string, n notification.OpsgenieNotification) error {
+	var (
+		target string
+		body   []byte
+		err    error
+	)
+
+	if n.Resolved {
+		target = fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", baseURL, url.PathEscape(notification.OpsgenieAlias(n.Alias)))
+		body, err = json.Marshal(notification.BuildOpsgenieCloseAlert())
+	} else {
+		target = baseURL + "/v2/alerts"
+		body, err = json.Marshal(notification.BuildOpsgenieAlert(n))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode opsgenie alert: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "GenieKey "+n.APIKey)
+
+	return postIncidentJSON(ctx, client, target, header, body, ErrOpsgenieRequestFailed)
+}
+
+// postIncidentJSON POSTs a JSON body and maps every failure onto failed plus a
+// status code or a fixed transport category, never the request URL or the
+// response body.
+func postIncidentJSON(ctx context.Context, client *http.Client, target string, header http.Header, body []byte, failed error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: invalid url", failed)
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", failed, classifyTeamsTransportError(err))
+	}
+	defer res.Body.Close()
+
+	// Same bounded drain as the Teams leg.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, teamsResponseReadLimit))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("%w: status %d", failed, res.StatusCode)
+	}
+
+	return nil
+}
+
+// processIncidentNotification decodes and sends one incident channel job.
+func processIncidentNotification(ctx context.Context, client *http.Client, notificationType notification.NotificationType, payload []byte) error {
+	switch notificationType {
+	case notification.WebhookNotificationType:
+		np := &notification.WebhookNotification{}
+		if err := json.Unmarshal(payload, np); err != nil {
+			return ErrInvalidWebhookNotificationPayload
+		}
+
+		return postWebhookNotification(ctx, client, np.URL, np.Secret, np.Alert)
+	case notification.PagerDutyNotificationType:
+		np := &notification.PagerDutyNotification{}
+		if err := json.Unmarshal(payload, np); err != nil {
+			return ErrInvalidPagerDutyPayload
+		}
+
+		return postPagerDutyEvent(ctx, client, pagerDutyEventsURL, *np)
+	case notification.OpsgenieNotificationType:
+		np := &notification.OpsgenieNotification{}
+		if err := json.Unmarshal(payload, np); err != nil {
+			return ErrInvalidOpsgeniePayload
+		}
+
+		return postOpsgenieAlert(ctx, client, opsgenieBaseURL(np.Region), *np)
+	default:
+		return ErrInvalidNotificationType
+	}
+}
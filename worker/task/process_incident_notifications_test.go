@@ -0,0 +1,188 @@
+package task
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	notification "github.com/frain-dev/convoy/internal/notifications"
+)
+
+type capturedRequest struct {
+	method string
+	path   string
+	query  string
+	header http.Header
+	body   string
+}
+
+// captureServer records every request and answers with status.
+func captureServer(t *testing.T, status int) (*httptest.Server, *[]capturedRequest) {
+	t.Helper()
+
+	var reqs []capturedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		reqs = append(reqs, capturedRequest{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			query:  r.URL.RawQuery,
+			header: r.Header.Clone(),
+			body:   string(body),
+		})
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("rejected request to " + r.URL.String()))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &reqs
+}
+
+func TestPostWebhookNotification_SignsBody(t *testing.T) {
+	srv, reqs := captureServer(t, http.StatusOK)
+
+	alert := notification.WebhookAlert{
+		Event:    notification.WebhookAlertTriggered,
+		DedupKey: "convoy:endpoint:ep-1:status",
+		Title:    "Endpoint Status Update",
+		Text:     "endpoint disabled",
+		SentAt:   "2026-10-19T10:00:00Z",
+	}
+	require.NoError(t, postWebhookNotification(context.Background(), srv.Client(), srv.URL, "whsec", alert))
+	require.Len(t, *reqs, 1)
+
+	req := (*reqs)[0]
+	require.Equal(t, http.MethodPost, req.method)
+	require.Equal(t, "application/json", req.header.Get("Content-Type"))
+	require.JSONEq(t, `{
+		"event": "alert.triggered",
+		"dedup_key": "convoy:endpoint:ep-1:status",
+		"title": "Endpoint Status Update",
+		"text": "endpoint disabled",
+		"sent_at": "2026-10-19T10:00:00Z"
+	}`, req.body)
+
+	// A receiver verifies exactly as it would an advanced-signature delivery.
+	parts := strings.Split(req.header.Get("X-Convoy-Signature"), ",")
+	require.Len(t, parts, 2)
+	timestamp, ok := strings.CutPrefix(parts[0], "t=")
+	require.True(t, ok)
+	sig, ok := strings.CutPrefix(parts[1], "v1=")
+	require.True(t, ok)
+
+	mac := hmac.New(sha256.New, []byte("whsec"))
+	mac.Write([]byte(timestamp + "," + req.body))
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), sig)
+}
+
+func TestPostPagerDutyEvent_Request(t *testing.T) {
+	srv, reqs := captureServer(t, http.StatusAccepted)
+
+	err := postPagerDutyEvent(context.Background(), srv.Client(), srv.URL+"/v2/enqueue", notification.PagerDutyNotification{
+		RoutingKey: "R0UT1NGKEY",
+		DedupKey:   "convoy:alert-rule:rule-1",
+		Resolved:   true,
+	})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+
+	req := (*reqs)[0]
+	require.Equal(t, "/v2/enqueue", req.path)
+	require.JSONEq(t, `{
+		"routing_key": "R0UT1NGKEY",
+		"event_action": "resolve",
+		"dedup_key": "convoy:alert-rule:rule-1"
+	}`, req.body)
+}
+
+func TestPostOpsgenieAlert_CreateAndClose(t *testing.T) {
+	srv, reqs := captureServer(t, http.StatusAccepted)
+
+	n := notification.OpsgenieNotification{
+		APIKey:      "genie-key",
+		Alias:       "convoy:endpoint:ep-1:status",
+		Message:     "Endpoint Status Update",
+		Description: "endpoint disabled",
+	}
+	require.NoError(t, postOpsgenieAlert(context.Background(), srv.Client(), srv.URL, n))
+
+	n.Resolved = true
+	require.NoError(t, postOpsgenieAlert(context.Background(), srv.Client(), srv.URL, n))
+
+	require.Len(t, *reqs, 2)
+
+	create := (*reqs)[0]
+	require.Equal(t, "/v2/alerts", create.path)
+	require.Equal(t, "GenieKey genie-key", create.header.Get("Authorization"))
+	require.JSONEq(t, `{
+		"message": "Endpoint Status Update",
+		"alias": "convoy:endpoint:ep-1:status",
+		"description": "endpoint disabled",
+		"source": "convoy"
+	}`, create.body)
+
+	closeReq := (*reqs)[1]
+	require.Equal(t, "/v2/alerts/convoy:endpoint:ep-1:status/close", closeReq.path)
+	require.Equal(t, "identifierType=alias", closeReq.query)
+	require.Equal(t, "GenieKey genie-key", closeReq.header.Get("Authorization"))
+	require.JSONEq(t, `{"source": "convoy"}`, closeReq.body)
+}
+
+func TestOpsgenieBaseURL(t *testing.T) {
+	require.Equal(t, "https://api.opsgenie.com", opsgenieBaseURL(""))
+	require.Equal(t, "https://api.opsgenie.com", opsgenieBaseURL("us"))
+	require.Equal(t, "https://api.eu.opsgenie.com", opsgenieBaseURL("eu"))
+}
+
+// TestIncidentNotificationErrorsDoNotLeakCredentials covers both failure paths
+// for every channel: a provider error body that quotes the request, and a
+// transport error, whose *url.Error quotes the URL.
+func TestIncidentNotificationErrorsDoNotLeakCredentials(t *testing.T) {
+	const token = "notasecretbutpretend"
+
+	send := map[string]func(client *http.Client, target string) error{
+		"webhook": func(client *http.Client, target string) error {
+			return postWebhookNotification(context.Background(), client, target+"/hook?token="+token, token, notification.WebhookAlert{})
+		},
+		"pagerduty": func(client *http.Client, target string) error {
+			return postPagerDutyEvent(context.Background(), client, target, notification.PagerDutyNotification{RoutingKey: token})
+		},
+		"opsgenie": func(client *http.Client, target string) error {
+			return postOpsgenieAlert(context.Background(), client, target, notification.OpsgenieNotification{APIKey: token, Alias: token, Resolved: true})
+		},
+	}
+
+	for name, fn := range send {
+		t.Run(name+"/status", func(t *testing.T) {
+			srv, _ := captureServer(t, http.StatusForbidden)
+
+			err := fn(srv.Client(), srv.URL)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "status 403")
+			require.NotContains(t, err.Error(), token)
+		})
+
+		t.Run(name+"/transport", func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			client := srv.Client()
+			srv.Close()
+
+			err := fn(client, srv.URL)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "connection refused")
+			require.NotContains(t, err.Error(), token)
+			require.NotContains(t, err.Error(), srv.URL)
+		})
+	}
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/slack-go/slack"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/email"
 	notification "github.com/frain-dev/convoy/internal/notifications"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
 	"github.com/frain-dev/convoy/net"
+	log "github.com/frain-dev/convoy/pkg/logger"
 	"github.com/frain-dev/convoy/pkg/msgpack"
 )
 
@@ -33,7 +35,7 @@ var ErrInvalidNotificationType = errors.New("invalid notification type")
 // connection can be reused, then discarded.
 const teamsResponseReadLimit = 1 << 10
 
-func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher) func(context.Context, *asynq.Task) error {
+func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher, templateRepo datastore.EmailTemplateRepository, lo log.Logger) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 		n := &notification.Notification{}
 		err := msgpack.DecodeMsgPack(t.Payload(), &n)
@@ -51,12 +53,7 @@ func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher) func(c
 				}
 				// Successfully parsed as email, process it
 				if np.Email != "" {
-					newEmail := email.NewEmail(sc)
-					err = newEmail.Build(string(np.TemplateName), np.Params)
-					if err != nil {
-						return err
-					}
-					return newEmail.Send(np.Email, np.Subject)
+					return sendEmail(ctx, sc, templateRepo, lo, np)
 				}
 				return ErrInvalidNotificationPayload
 			}
@@ -81,12 +78,7 @@ func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher) func(c
 			}
 			// Successfully parsed as email, process it
 			if np.Email != "" {
-				newEmail := email.NewEmail(sc)
-				err = newEmail.Build(string(np.TemplateName), np.Params)
-				if err != nil {
-					return err
-				}
-				return newEmail.Send(np.Email, np.Subject)
+				return sendEmail(ctx, sc, templateRepo, lo, np)
 			}
 			return ErrInvalidNotificationPayload
 		}
@@ -104,13 +96,7 @@ func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher) func(c
 				return ErrInvalidEmailPayload
 			}
 
-			newEmail := email.NewEmail(sc)
-			err = newEmail.Build(string(np.TemplateName), np.Params)
-			if err != nil {
-				return err
-			}
-
-			return newEmail.Send(np.Email, np.Subject)
+			return sendEmail(ctx, sc, templateRepo, lo, np)
 		case notification.SlackNotificationType:
 			np := &notification.SlackNotification{}
 			err := json.Unmarshal(bufP, np)
@@ -148,6 +134,10 @@ func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher) func(c
 			}
 
 			return postTeamsCard(ctx, dispatcher.NotificationHTTPClient(), np.WebhookURL, np.Text)
+		case notification.WebhookNotificationType, notification.PagerDutyNotificationType, notification.OpsgenieNotificationType:
+			// Sent through the same SSRF-guarded notification client; the
+			// webhook URL is user-controlled and the provider hosts are public.
+			return processIncidentNotification(ctx, dispatcher.NotificationHTTPClient(), n.NotificationType, bufP)
 
 		default:
 			// Default to email if notification type is empty/invalid but payload can be parsed as email
@@ -155,12 +145,7 @@ func ProcessNotifications(sc smtp.SmtpClient, dispatcher *net.Dispatcher) func(c
 			err := json.Unmarshal(bufP, np)
 			if err == nil && np.Email != "" {
 				// Successfully parsed as email, process it
-				newEmail := email.NewEmail(sc)
-				err = newEmail.Build(string(np.TemplateName), np.Params)
-				if err != nil {
-					return err
-				}
-				return newEmail.Send(np.Email, np.Subject)
+				return sendEmail(ctx, sc, templateRepo, lo, np)
 			}
 
 			return ErrInvalidNotificationType
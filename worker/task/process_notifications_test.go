@@ -17,6 +17,7 @@ import (
 	"github.com/frain-dev/convoy/internal/pkg/fflag"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/net"
+	log "github.com/frain-dev/convoy/pkg/logger"
 	"github.com/frain-dev/convoy/queue"
 )
 
@@ -241,7 +242,7 @@ func TestProcessNotifications(t *testing.T) {
 				asynq.Queue(string(convoy.DefaultQueue)),
 				asynq.ProcessIn(job.Delay))
 
-			processFn := ProcessNotifications(sc, dispatcher)
+			processFn := ProcessNotifications(sc, dispatcher, nil, log.New("convoy", log.LevelInfo))
 
 			// Act.
 			err = processFn(context.Background(), task)
@@ -0,0 +1,283 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/fflag"
+	"github.com/frain-dev/convoy/internal/pkg/license"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+	"github.com/frain-dev/convoy/queue"
+)
+
+// replayJobPageSize is how many events a replay job reads at a time.
+const replayJobPageSize = 100
+
+// ReplayJobPayload is the queue payload that starts a replay job.
+type ReplayJobPayload struct {
+	ProjectID   string
+	ReplayJobID string
+}
+
+type ReplayJobDeps struct {
+	ReplayJobRepo              datastore.ReplayJobRepository
+	EndpointRepo               datastore.EndpointRepository
+	EventRepo                  datastore.EventRepository
+	ProjectRepo                datastore.ProjectRepository
+	EventDeliveryRepo          datastore.EventDeliveryRepository
+	SubRepo                    datastore.SubscriptionRepository
+	FilterRepo                 datastore.FilterRepository
+	EventTypeVersionRepo       datastore.EventTypeVersionRepository
+	EventQueue                 queue.Queuer
+	Licenser                   license.Licenser
+	OAuth2TokenService         OAuth2TokenService
+	FeatureFlag                *fflag.FFlag
+	FeatureFlagFetcher         fflag.FeatureFlagFetcher
+	EarlyAdopterFeatureFetcher fflag.EarlyAdopterFeatureFetcher
+	Logger                     log.Logger
+}
+
+// ProcessReplayJob replays the events a replay job selects, oldest first and
+// no faster than its rate limit. A job that is run again after a worker
+// restart resumes from the last event it saved.
+func ProcessReplayJob(deps ReplayJobDeps) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload ReplayJobPayload
+		err := msgpack.DecodeMsgPack(t.Payload(), &payload)
+		if err != nil {
+			deps.Logger.Error("failed to unmarshal replay job payload", "error", err)
+			return err
+		}
+
+		job, err := deps.ReplayJobRepo.FindReplayJobByID(ctx, payload.ProjectID, payload.ReplayJobID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrReplayJobNotFound) {
+				deps.Logger.Warn("replay job not found", "replay_job_id", payload.ReplayJobID)
+				return nil
+			}
+			return err
+		}
+
+		if job.Status.IsTerminal() {
+			return nil
+		}
+
+		project, err := deps.ProjectRepo.FetchProjectByID(ctx, job.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		r := &replayer{deps: deps, job: job, project: project}
+		return r.run(ctx)
+	}
+}
+
+type replayer struct {
+	deps    ReplayJobDeps
+	job     *datastore.ReplayJob
+	project *datastore.Project
+
+	// subscriptions are the target endpoint's subscriptions; nil when the
+	// job replays to each event's own subscriptions.
+	subscriptions []datastore.Subscription
+}
+
+func (r *replayer) run(ctx context.Context) error {
+	if r.job.TargetType != datastore.ReplayTargetSubscriptions {
+		subscriptions, err := r.endpointSubscriptions(ctx)
+		if err != nil {
+			return r.fail(ctx, err)
+		}
+		r.subscriptions = subscriptions
+	}
+
+	r.job.Status = datastore.ReplayJobStatusProcessing
+	if err := r.save(ctx); err != nil {
+		return r.stopped(err)
+	}
+
+	rateLimit := max(r.job.RateLimit, 1)
+	ticker := time.NewTicker(time.Second / time.Duration(rateLimit))
+	defer ticker.Stop()
+
+	for {
+		filter := r.job.Filter.EventFilter(r.project, r.job.Cursor, replayJobPageSize)
+		events, pagination, err := r.deps.EventRepo.LoadEventsPaged(ctx, r.project.UID, filter)
+		if err != nil {
+			return r.fail(ctx, fmt.Errorf("failed to load events: %w", err))
+		}
+
+		for i := range events {
+			event := &events[i]
+
+			// The cursor is inclusive, so it names the next event to
+			// replay and a job resumed after a restart skips nothing. After
+			// the last event it stays on that event, which the job is
+			// completed right after.
+			switch {
+			case i+1 < len(events):
+				r.job.Cursor = events[i+1].UID
+			case pagination.HasNextPage:
+				r.job.Cursor = pagination.NextPageCursor
+			default:
+				r.job.Cursor = event.UID
+			}
+
+			if !r.job.Filter.MatchesEventType(string(event.EventType)) {
+				r.job.SkippedEvents++
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+
+			replayed, err := r.replay(ctx, event)
+			switch {
+			case err != nil:
+				r.job.FailedEvents++
+				r.deps.Logger.ErrorContext(ctx, "failed to replay event", "replay_job_id", r.job.UID, "event_id", event.UID, "error", err)
+			case !replayed:
+				r.job.SkippedEvents++
+			default:
+				r.job.ProcessedEvents++
+			}
+
+			// Saving after each event is also how the job notices it was
+			// cancelled, without waiting for the page to finish.
+			if err = r.save(ctx); err != nil {
+				return r.stopped(err)
+			}
+		}
+
+		if !pagination.HasNextPage {
+			break
+		}
+	}
+
+	r.job.Status = datastore.ReplayJobStatusCompleted
+	r.job.CompletedAt = null.TimeFrom(time.Now())
+	if err := r.save(ctx); err != nil {
+		return r.stopped(err)
+	}
+
+	return nil
+}
+
+// endpointSubscriptions returns the subscriptions events are replayed
+// through when the job targets an endpoint.
+func (r *replayer) endpointSubscriptions(ctx context.Context) ([]datastore.Subscription, error) {
+	endpoint, err := r.deps.EndpointRepo.FindEndpointByID(ctx, r.job.EndpointID, r.project.UID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find endpoint %s: %w", r.job.EndpointID, err)
+	}
+
+	subscriptions, err := r.deps.SubRepo.FindSubscriptionsByEndpointID(ctx, r.project.UID, endpoint.UID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subscriptions for endpoint %s: %w", endpoint.UID, err)
+	}
+
+	if len(subscriptions) > 0 {
+		return subscriptions, nil
+	}
+
+	if r.job.TargetType != datastore.ReplayTargetNewEndpoint {
+		return nil, fmt.Errorf("endpoint %s has no subscriptions", endpoint.UID)
+	}
+
+	subscription := generateSubscription(r.project, endpoint)
+	err = r.deps.SubRepo.CreateSubscription(ctx, r.project.UID, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription for endpoint %s: %w", endpoint.UID, err)
+	}
+
+	return []datastore.Subscription{*subscription}, nil
+}
+
+// replay creates the event's deliveries for the job's target. It reports
+// false when no subscription accepts the event.
+func (r *replayer) replay(ctx context.Context, event *datastore.Event) (bool, error) {
+	var subscriptions []datastore.Subscription
+	var err error
+
+	if r.subscriptions == nil {
+		subscriptions, _, err = findSubscriptions(ctx, r.deps.EndpointRepo, r.deps.SubRepo, r.deps.FilterRepo, r.deps.Licenser, r.project, event, false, r.deps.Logger)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		subscriptions, err = matchSubscriptions(ctx, string(event.EventType), r.subscriptions, r.deps.FilterRepo)
+		if err != nil {
+			return false, err
+		}
+
+		subscriptions, _, err = matchSubscriptionsUsingFilter(ctx, event, r.deps.SubRepo, r.deps.FilterRepo, r.deps.Licenser, subscriptions, false, r.deps.Logger)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(subscriptions) == 0 {
+		return false, nil
+	}
+
+	err = writeEventDeliveriesToQueue(ctx, WriteEventDeliveriesToQueueOptions{
+		Subscriptions:              subscriptions,
+		Event:                      event,
+		Project:                    r.project,
+		EventDeliveryRepo:          r.deps.EventDeliveryRepo,
+		EventQueue:                 r.deps.EventQueue,
+		EndpointRepo:               r.deps.EndpointRepo,
+		Licenser:                   r.deps.Licenser,
+		OAuth2TokenService:         r.deps.OAuth2TokenService,
+		FeatureFlag:                r.deps.FeatureFlag,
+		FeatureFlagFetcher:         r.deps.FeatureFlagFetcher,
+		EarlyAdopterFeatureFetcher: r.deps.EarlyAdopterFeatureFetcher,
+		EventTypeVersionRepo:       r.deps.EventTypeVersionRepo,
+		Logger:                     r.deps.Logger,
+		ReplayJobID:                r.job.UID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *replayer) save(ctx context.Context) error {
+	return r.deps.ReplayJobRepo.UpdateReplayJob(ctx, r.job)
+}
+
+// fail marks the job as failed and returns cause.
+func (r *replayer) fail(ctx context.Context, cause error) error {
+	r.deps.Logger.ErrorContext(ctx, "replay job failed", "replay_job_id", r.job.UID, "error", cause)
+
+	r.job.Status = datastore.ReplayJobStatusFailed
+	r.job.Error = cause.Error()
+	r.job.CompletedAt = null.TimeFrom(time.Now())
+	if err := r.save(ctx); err != nil {
+		return r.stopped(errors.Join(cause, err))
+	}
+
+	return cause
+}
+
+// stopped handles an error saving the job. The repository refuses to write
+// a cancelled job, which is how a running job learns it was cancelled.
+func (r *replayer) stopped(err error) error {
+	if errors.Is(err, datastore.ErrReplayJobNotFound) {
+		r.deps.Logger.Info("replay job cancelled", "replay_job_id", r.job.UID)
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,238 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+	"github.com/frain-dev/convoy/pkg/msgpack"
+)
+
+func replayJobTask(t *testing.T, job *datastore.ReplayJob) *asynq.Task {
+	t.Helper()
+
+	payload, err := msgpack.EncodeMsgPack(ReplayJobPayload{ProjectID: job.ProjectID, ReplayJobID: job.UID})
+	require.NoError(t, err)
+
+	return asynq.NewTask("replay-job", payload)
+}
+
+func TestProcessReplayJobReplaysToEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectCfg := datastore.DefaultProjectConfig
+	project := &datastore.Project{UID: "project-id-1", Type: datastore.OutgoingProject, Config: &projectCfg}
+	endpoint := &datastore.Endpoint{UID: "endpoint-id-1", Status: datastore.ActiveEndpointStatus}
+	sub := datastore.Subscription{UID: "sub-id-1", Type: datastore.SubscriptionTypeAPI, EndpointID: endpoint.UID}
+	job := &datastore.ReplayJob{
+		UID:        "job-id-1",
+		ProjectID:  project.UID,
+		Status:     datastore.ReplayJobStatusPending,
+		TargetType: datastore.ReplayTargetEndpoint,
+		EndpointID: endpoint.UID,
+		RateLimit:  1000,
+		Filter: datastore.ReplayJobFilter{
+			StartDate:  time.Now().Add(-time.Hour),
+			EndDate:    time.Now(),
+			EventTypes: []string{"invoice.paid"},
+		},
+	}
+
+	replayJobRepo := mocks.NewMockReplayJobRepository(ctrl)
+	replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), project.UID, job.UID).Return(job, nil)
+	var statuses []datastore.ReplayJobStatus
+	replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), job).DoAndReturn(func(_ context.Context, j *datastore.ReplayJob) error {
+		statuses = append(statuses, j.Status)
+		return nil
+	}).Times(3)
+
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), project.UID).Return(project, nil)
+
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), endpoint.UID, project.UID).Return(endpoint, nil).Times(2)
+
+	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+	subRepo.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), project.UID, endpoint.UID).Return([]datastore.Subscription{sub}, nil)
+
+	filterRepo := mocks.NewMockFilterRepository(ctrl)
+	filterRepo.EXPECT().FindFilterBySubscriptionAndEventType(gomock.Any(), sub.UID, "invoice.paid").Return(&datastore.EventTypeFilter{}, nil)
+
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), project.UID, gomock.Any()).Return([]datastore.Event{
+		{UID: "event-id-1", ProjectID: project.UID, EventType: "invoice.paid", Data: []byte(`{"ok":true}`)},
+		{UID: "event-id-2", ProjectID: project.UID, EventType: "invoice.created", Data: []byte(`{"ok":true}`)},
+	}, datastore.PaginationData{}, nil)
+
+	deliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	deliveryRepo.EXPECT().CreateEventDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, deliveries []*datastore.EventDelivery) error {
+		require.Len(t, deliveries, 1)
+		require.Equal(t, "event-id-1", deliveries[0].EventID)
+		require.Equal(t, endpoint.UID, deliveries[0].EndpointID)
+		require.Equal(t, job.UID, deliveries[0].Metadata.ReplayJobID)
+		return nil
+	})
+
+	eventQueue := mocks.NewMockQueuer(ctrl)
+	eventQueue.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().AdvancedSubscriptions().Return(false).AnyTimes()
+	licenser.EXPECT().Transformations().Return(false).AnyTimes()
+
+	fn := ProcessReplayJob(ReplayJobDeps{
+		ReplayJobRepo:     replayJobRepo,
+		EndpointRepo:      endpointRepo,
+		EventRepo:         eventRepo,
+		ProjectRepo:       projectRepo,
+		EventDeliveryRepo: deliveryRepo,
+		SubRepo:           subRepo,
+		FilterRepo:        filterRepo,
+		EventQueue:        eventQueue,
+		Licenser:          licenser,
+		Logger:            log.New("convoy", log.LevelError),
+	})
+
+	err := fn(context.Background(), replayJobTask(t, job))
+	require.NoError(t, err)
+
+	require.Equal(t, []datastore.ReplayJobStatus{datastore.ReplayJobStatusProcessing, datastore.ReplayJobStatusProcessing, datastore.ReplayJobStatusCompleted}, statuses)
+	require.Equal(t, 1, job.ProcessedEvents)
+	require.Equal(t, 1, job.SkippedEvents)
+	require.Zero(t, job.FailedEvents)
+}
+
+func TestProcessReplayJobStopsWhenCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	project := &datastore.Project{UID: "project-id-1", Type: datastore.OutgoingProject}
+	job := &datastore.ReplayJob{
+		UID:        "job-id-1",
+		ProjectID:  project.UID,
+		Status:     datastore.ReplayJobStatusProcessing,
+		TargetType: datastore.ReplayTargetSubscriptions,
+		RateLimit:  1000,
+		Filter:     datastore.ReplayJobFilter{StartDate: time.Now().Add(-time.Hour), EndDate: time.Now()},
+	}
+
+	replayJobRepo := mocks.NewMockReplayJobRepository(ctrl)
+	replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), project.UID, job.UID).Return(job, nil)
+	// the job was cancelled before the worker picked it up again
+	replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), job).Return(datastore.ErrReplayJobNotFound)
+
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), project.UID).Return(project, nil)
+
+	fn := ProcessReplayJob(ReplayJobDeps{
+		ReplayJobRepo: replayJobRepo,
+		ProjectRepo:   projectRepo,
+		EventRepo:     mocks.NewMockEventRepository(ctrl),
+		Logger:        log.New("convoy", log.LevelError),
+	})
+
+	err := fn(context.Background(), replayJobTask(t, job))
+	require.NoError(t, err)
+}
+
+func TestProcessReplayJobStopsWhenCancelledMidPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectCfg := datastore.DefaultProjectConfig
+	project := &datastore.Project{UID: "project-id-1", Type: datastore.OutgoingProject, Config: &projectCfg}
+	endpoint := &datastore.Endpoint{UID: "endpoint-id-1", Status: datastore.ActiveEndpointStatus}
+	sub := datastore.Subscription{UID: "sub-id-1", Type: datastore.SubscriptionTypeAPI, EndpointID: endpoint.UID}
+	job := &datastore.ReplayJob{
+		UID:        "job-id-1",
+		ProjectID:  project.UID,
+		Status:     datastore.ReplayJobStatusPending,
+		TargetType: datastore.ReplayTargetEndpoint,
+		EndpointID: endpoint.UID,
+		RateLimit:  1000,
+		Filter:     datastore.ReplayJobFilter{StartDate: time.Now().Add(-time.Hour), EndDate: time.Now()},
+	}
+
+	replayJobRepo := mocks.NewMockReplayJobRepository(ctrl)
+	replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), project.UID, job.UID).Return(job, nil)
+	gomock.InOrder(
+		replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), job).Return(nil),
+		// the job is cancelled while its first event is replayed
+		replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), job).DoAndReturn(func(_ context.Context, j *datastore.ReplayJob) error {
+			require.Equal(t, "event-id-2", j.Cursor)
+			require.Equal(t, 1, j.ProcessedEvents)
+			return datastore.ErrReplayJobNotFound
+		}),
+	)
+
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), project.UID).Return(project, nil)
+
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), endpoint.UID, project.UID).Return(endpoint, nil).Times(2)
+
+	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+	subRepo.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), project.UID, endpoint.UID).Return([]datastore.Subscription{sub}, nil)
+
+	filterRepo := mocks.NewMockFilterRepository(ctrl)
+	filterRepo.EXPECT().FindFilterBySubscriptionAndEventType(gomock.Any(), sub.UID, "invoice.paid").Return(&datastore.EventTypeFilter{}, nil)
+
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	eventRepo.EXPECT().LoadEventsPaged(gomock.Any(), project.UID, gomock.Any()).Return([]datastore.Event{
+		{UID: "event-id-1", ProjectID: project.UID, EventType: "invoice.paid", Data: []byte(`{"ok":true}`)},
+		{UID: "event-id-2", ProjectID: project.UID, EventType: "invoice.paid", Data: []byte(`{"ok":true}`)},
+	}, datastore.PaginationData{}, nil)
+
+	// only the first event is replayed
+	deliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	deliveryRepo.EXPECT().CreateEventDeliveries(gomock.Any(), gomock.Any()).Return(nil)
+
+	eventQueue := mocks.NewMockQueuer(ctrl)
+	eventQueue.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().AdvancedSubscriptions().Return(false).AnyTimes()
+	licenser.EXPECT().Transformations().Return(false).AnyTimes()
+
+	fn := ProcessReplayJob(ReplayJobDeps{
+		ReplayJobRepo:     replayJobRepo,
+		EndpointRepo:      endpointRepo,
+		EventRepo:         eventRepo,
+		ProjectRepo:       projectRepo,
+		EventDeliveryRepo: deliveryRepo,
+		SubRepo:           subRepo,
+		FilterRepo:        filterRepo,
+		EventQueue:        eventQueue,
+		Licenser:          licenser,
+		Logger:            log.New("convoy", log.LevelError),
+	})
+
+	err := fn(context.Background(), replayJobTask(t, job))
+	require.NoError(t, err)
+}
+
+func TestProcessReplayJobIgnoresFinishedJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := &datastore.ReplayJob{UID: "job-id-1", ProjectID: "project-id-1", Status: datastore.ReplayJobStatusCancelled}
+
+	replayJobRepo := mocks.NewMockReplayJobRepository(ctrl)
+	replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), job.ProjectID, job.UID).Return(job, nil)
+
+	fn := ProcessReplayJob(ReplayJobDeps{
+		ReplayJobRepo: replayJobRepo,
+		Logger:        log.New("convoy", log.LevelError),
+	})
+
+	err := fn(context.Background(), replayJobTask(t, job))
+	require.NoError(t, err)
+}
@@ -0,0 +1,55 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+const (
+	// circuitBreakerTransitionRetention is how long a breaker's history is
+	// kept. The endpoint page shows the latest transitions, not an audit log.
+	circuitBreakerTransitionRetention = 30 * 24 * time.Hour
+
+	// circuitBreakerPruneBatchSize bounds each delete so a large backlog does
+	// not hold locks on the table for the whole run.
+	circuitBreakerPruneBatchSize = 1000
+)
+
+// PruneCircuitBreakerTransitions deletes breaker transitions past the
+// retention window and the overrides of deleted endpoints.
+func PruneCircuitBreakerTransitions(repo datastore.CircuitBreakerRepository, locker JobLocker, lo log.Logger) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		return skipIfLockBusy(locker.WithLock(ctx, "convoy:circuit_breaker_prune:mutex", 30*time.Minute, func(ctx context.Context) error {
+			return pruneCircuitBreakerState(ctx, repo, time.Now().Add(-circuitBreakerTransitionRetention), lo)
+		}))
+	}
+}
+
+func pruneCircuitBreakerState(ctx context.Context, repo datastore.CircuitBreakerRepository, before time.Time, lo log.Logger) error {
+	var transitions int64
+	for {
+		n, err := repo.DeleteCircuitBreakerTransitionsBefore(ctx, before, circuitBreakerPruneBatchSize)
+		if err != nil {
+			return err
+		}
+		transitions += n
+		if n < circuitBreakerPruneBatchSize {
+			break
+		}
+	}
+
+	overrides, err := repo.DeleteCircuitBreakerOverridesForDeletedEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	if transitions > 0 || overrides > 0 {
+		lo.Info("pruned circuit breaker state", "transitions", transitions, "overrides", overrides)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestPruneCircuitBreakerState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCircuitBreakerRepository(ctrl)
+	before := time.Now().Add(-circuitBreakerTransitionRetention)
+
+	gomock.InOrder(
+		repo.EXPECT().DeleteCircuitBreakerTransitionsBefore(gomock.Any(), before, circuitBreakerPruneBatchSize).Return(int64(circuitBreakerPruneBatchSize), nil),
+		repo.EXPECT().DeleteCircuitBreakerTransitionsBefore(gomock.Any(), before, circuitBreakerPruneBatchSize).Return(int64(12), nil),
+		repo.EXPECT().DeleteCircuitBreakerOverridesForDeletedEndpoints(gomock.Any()).Return(int64(1), nil),
+	)
+
+	err := pruneCircuitBreakerState(context.Background(), repo, before, log.New("convoy", log.LevelError))
+	require.NoError(t, err)
+}
@@ -0,0 +1,49 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+const (
+	// filterRejectionRetention is how long recorded filter rejections are
+	// kept. They answer "why didn't this subscription get the event" for
+	// recent events, not as a history.
+	filterRejectionRetention = 7 * 24 * time.Hour
+
+	filterRejectionPruneBatchSize = 1000
+)
+
+// PruneFilterRejections deletes recorded filter rejections past the retention
+// window.
+func PruneFilterRejections(repo datastore.FilterRejectionRepository, locker JobLocker, lo log.Logger) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		return skipIfLockBusy(locker.WithLock(ctx, "convoy:filter_rejection_prune:mutex", 30*time.Minute, func(ctx context.Context) error {
+			return pruneFilterRejections(ctx, repo, time.Now().Add(-filterRejectionRetention), lo)
+		}))
+	}
+}
+
+func pruneFilterRejections(ctx context.Context, repo datastore.FilterRejectionRepository, before time.Time, lo log.Logger) error {
+	var pruned int64
+	for {
+		n, err := repo.DeleteFilterRejectionsBefore(ctx, before, filterRejectionPruneBatchSize)
+		if err != nil {
+			return err
+		}
+		pruned += n
+		if n < filterRejectionPruneBatchSize {
+			break
+		}
+	}
+
+	if pruned > 0 {
+		lo.Info("pruned filter rejections", "rejections", pruned)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy/mocks"
+	log "github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestPruneFilterRejections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockFilterRejectionRepository(ctrl)
+	before := time.Now().Add(-filterRejectionRetention)
+
+	gomock.InOrder(
+		repo.EXPECT().DeleteFilterRejectionsBefore(gomock.Any(), before, filterRejectionPruneBatchSize).Return(int64(filterRejectionPruneBatchSize), nil),
+		repo.EXPECT().DeleteFilterRejectionsBefore(gomock.Any(), before, filterRejectionPruneBatchSize).Return(int64(3), nil),
+	)
+
+	err := pruneFilterRejections(context.Background(), repo, before, log.New("convoy", log.LevelError))
+	require.NoError(t, err)
+}
@@ -48,9 +48,10 @@ func QueueStuckEventDeliveries(ctx context.Context, edRepo datastore.EventDelive
 			}
 
 			job := &queue.Job{
-				ID:      eventDelivery.UID,
-				Payload: data,
-				Delay:   1 * time.Second,
+				ID:        eventDelivery.UID,
+				Payload:   data,
+				Delay:     1 * time.Second,
+				ProjectID: eventDelivery.ProjectID,
 			}
 
 			err = q.Write(ctx, convoy.EventProcessor, convoy.EventQueue, job)
@@ -0,0 +1,20 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// CLISubscriptionReaper tears down the CLI subscriptions of listen sessions
+// that stopped heartbeating.
+type CLISubscriptionReaper interface {
+	Run(ctx context.Context) error
+}
+
+func ReapCLISubscriptions(reaper CLISubscriptionReaper, locker JobLocker) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		return skipIfLockBusy(locker.WithLock(ctx, "convoy:cli_subscription_reap:mutex", 5*time.Minute, reaper.Run))
+	}
+}
@@ -230,9 +230,10 @@ func processEventDeliveryBatch(ctx context.Context, projectID string, s datastor
 
 			taskName := convoy.EventProcessor
 			job := &queue.Job{
-				ID:      delivery.UID,
-				Payload: data,
-				Delay:   1 * time.Second,
+				ID:        delivery.UID,
+				Payload:   data,
+				Delay:     1 * time.Second,
+				ProjectID: delivery.ProjectID,
 			}
 			err = q.Write(ctx, taskName, convoy.EventQueue, job)
 			if err != nil {
@@ -0,0 +1,123 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/logger"
+)
+
+func TestUpdateEventMetadataMarksTestEvents(t *testing.T) {
+	event := &datastore.Event{IsTest: true}
+	require.NoError(t, updateEventMetadata(NewDefaultEventChannel(), event, true, 0, logger.New("convoy", logger.LevelError)))
+
+	var m map[string]string
+	require.NoError(t, json.Unmarshal([]byte(event.Metadata), &m))
+	require.Equal(t, "true", m[datastore.TestEventMetadataKey])
+
+	// Other events carry no marker.
+	event = &datastore.Event{}
+	require.NoError(t, updateEventMetadata(NewDefaultEventChannel(), event, true, 0, logger.New("convoy", logger.LevelError)))
+	require.NotContains(t, event.Metadata, datastore.TestEventMetadataKey)
+}
+
+func TestWriteEventDeliveriesToQueueMarksTestDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+	licenser := mocks.NewMockLicenser(ctrl)
+	licenser.EXPECT().CanExportPrometheusMetrics().Return(false).AnyTimes()
+
+	project := &datastore.Project{
+		UID: "project-id-1",
+		Config: &datastore.ProjectConfig{
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       datastore.LinearStrategyProvider,
+				Duration:   10,
+				RetryCount: 5,
+			},
+		},
+	}
+	event := &datastore.Event{
+		UID:       "event-id-1",
+		ProjectID: project.UID,
+		EventType: "invoice.paid",
+		Data:      json.RawMessage(`{"amount":100}`),
+		IsTest:    true,
+	}
+
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", project.UID).
+		Return(&datastore.Endpoint{UID: "endpoint-id-1", Status: datastore.ActiveEndpointStatus}, nil)
+
+	var created []*datastore.EventDelivery
+	eventDeliveryRepo.EXPECT().CreateEventDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, deliveries []*datastore.EventDelivery) error {
+			created = deliveries
+			return nil
+		})
+	q.EXPECT().Write(gomock.Any(), convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Return(nil)
+
+	err := writeEventDeliveriesToQueue(context.Background(), WriteEventDeliveriesToQueueOptions{
+		Subscriptions: []datastore.Subscription{
+			{UID: "sub-id-1", Type: datastore.SubscriptionTypeAPI, EndpointID: "endpoint-id-1"},
+		},
+		Event:             event,
+		Project:           project,
+		EventDeliveryRepo: eventDeliveryRepo,
+		EventQueue:        q,
+		EndpointRepo:      endpointRepo,
+		Licenser:          licenser,
+		Logger:            logger.New("convoy", logger.LevelError),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, created, 1)
+	require.True(t, created[0].Metadata.Test)
+	require.Equal(t, uint64(1), created[0].Metadata.RetryLimit)
+	require.Equal(t, datastore.ScheduledEventStatus, created[0].Status)
+}
+
+func TestFindSubscriptionsSendsTestEventsThroughAnEndpointSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+	filterRepo := mocks.NewMockFilterRepository(ctrl)
+
+	// An incoming project would match by source; test events go to the
+	// endpoint they name either way.
+	project := &datastore.Project{UID: "project-id-1", Type: datastore.IncomingProject}
+	event := &datastore.Event{
+		ProjectID: project.UID,
+		EventType: "invoice.paid",
+		Endpoints: []string{"endpoint-1", "endpoint-2"},
+		IsTest:    true,
+	}
+
+	// endpoint-1's only subscription is to another event type; it is used
+	// anyway. endpoint-2 has none, and no catch-all is created for it.
+	subRepo.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), project.UID, "endpoint-1").
+		Return([]datastore.Subscription{{UID: "sub-id-1", EndpointID: "endpoint-1"}}, nil)
+	subRepo.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), project.UID, "endpoint-2").
+		Return([]datastore.Subscription{}, nil)
+	filterRepo.EXPECT().FindFilterBySubscriptionAndEventType(gomock.Any(), "sub-id-1", "invoice.paid").
+		Return(nil, datastore.ErrFilterNotFound)
+	filterRepo.EXPECT().FindFilterBySubscriptionAndEventType(gomock.Any(), "sub-id-1", "*").
+		Return(nil, datastore.ErrFilterNotFound).AnyTimes()
+
+	subs, rejections, err := findSubscriptions(context.Background(), nil, subRepo, filterRepo, nil, project, event, false, logger.New("convoy", logger.LevelError))
+	require.NoError(t, err)
+	require.Empty(t, rejections)
+	require.Len(t, subs, 1)
+	require.Equal(t, "sub-id-1", subs[0].UID)
+}